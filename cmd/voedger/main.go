@@ -29,6 +29,7 @@ func execRootCmd(args []string, ver string) error {
 		args,
 		ver,
 		newServerCmd(),
+		newStorageCmd(),
 	)
 
 	return cobrau.ExecCommandAndCatchInterrupt(rootCmd)
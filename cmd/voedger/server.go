@@ -41,6 +41,8 @@ func newServerCmd() *cobra.Command {
 	}
 	serverCmd.PersistentFlags().IntVar(&httpCLIParams.Port, "ihttp.Port", Default_ihttp_Port, "")
 	serverCmd.Flags().StringVar(&appsCLIParams.Storage, "storage", "", "")
+	serverCmd.Flags().StringArrayVar(&appsCLIParams.AppImages, "app-image", []string{}, "pkg folder of the application image to serve its STATIC folders")
+	serverCmd.Flags().BoolVar(&appsCLIParams.StorageEncryption, "storage-encryption", false, "encrypt storage values by keys read from secrets")
 	serverCmd.Flags().StringArrayVar((*[]string)(&httpCLIParams.AcmeDomains), "acme-domain", []string{}, "")
 	return serverCmd
 }
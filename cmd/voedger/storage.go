@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	voedger "github.com/voedger/voedger/cmd/voedger/voedgerimpl"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/isecretsimpl"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istorageenc"
+)
+
+func newStorageCmd() *cobra.Command {
+	storageCmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Storage maintenance",
+	}
+	storageCmd.AddCommand(newStorageMigrateCmd())
+	storageCmd.AddCommand(newStorageReEncryptCmd())
+	return storageCmd
+}
+
+func newStorageMigrateCmd() *cobra.Command {
+	var from, to voedger.CLIParams
+	var params provider.MigrateParams
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy all keyspaces from one storage to another. Server must be stopped",
+		Long: `Copy all keyspaces from one storage to another. Server must be stopped.
+Storage is cas1, cas3, bbolt:<database dir>, lsm:<database dir> or amazondb:<endpoint URL>.
+Migration is resumed from the journal file if it exists.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			src, err := voedger.NewAppStorageFactory(from)
+			if err != nil {
+				return fmt.Errorf("source storage: %w", err)
+			}
+			defer src.StopGoroutines()
+			dst, err := voedger.NewAppStorageFactory(to)
+			if err != nil {
+				return fmt.Errorf("destination storage: %w", err)
+			}
+			defer dst.StopGoroutines()
+			res, err := provider.Migrate(cmd.Context(), src, dst, params)
+			for _, m := range res {
+				fmt.Printf("%s: %d partitions, %d records, %d TTL records, checksum %x\n", m.Keyspace, m.Partitions, m.Records, m.TTLRecords, m.Checksum)
+			}
+			return err
+		},
+	}
+	migrateCmd.Flags().StringVar(&from.Storage, "from", "", "source storage")
+	migrateCmd.Flags().StringVar(&to.Storage, "to", "", "destination storage")
+	migrateCmd.Flags().StringVar(&params.JournalPath, "journal", "", "migration journal file")
+	migrateCmd.Flags().IntVar(&params.BatchSize, "batch-size", provider.DefaultMigrateBatchSize, "records amount written by one batch")
+	_ = migrateCmd.MarkFlagRequired("from")
+	_ = migrateCmd.MarkFlagRequired("to")
+	return migrateCmd
+}
+
+func newStorageReEncryptCmd() *cobra.Command {
+	var params voedger.CLIParams
+	var apps []string
+	reEncryptCmd := &cobra.Command{
+		Use:   "reencrypt",
+		Short: "Encrypt values of applications by the current keys",
+		Long: `Encrypt plaintext values and values written under old keys by the current keys of applications.
+Keys are read from secrets, see pkg/istorageenc. Server could be running if the storage allows several connections, e.g. cas3.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			appQNames := make([]appdef.AppQName, len(apps))
+			for i, app := range apps {
+				appQName, err := appdef.ParseAppQName(app)
+				if err != nil {
+					return err
+				}
+				appQNames[i] = appQName
+			}
+			asf, err := voedger.NewAppStorageFactory(params)
+			if err != nil {
+				return err
+			}
+			defer asf.StopGoroutines()
+			asp := provideEncryptingAppStorageProvider(provider.Provide(asf))
+			for _, appQName := range appQNames {
+				reEncrypted, err := asp.ReEncryptApp(cmd.Context(), appQName)
+				fmt.Printf("%s: %d values re-encrypted\n", appQName, reEncrypted)
+				if err != nil {
+					return fmt.Errorf("%s: %w", appQName, err)
+				}
+			}
+			return nil
+		},
+	}
+	reEncryptCmd.Flags().StringVar(&params.Storage, "storage", "", "storage")
+	reEncryptCmd.Flags().StringArrayVar(&apps, "app", []string{}, "application to re-encrypt, e.g. untill/airs-bp")
+	_ = reEncryptCmd.MarkFlagRequired("app")
+	return reEncryptCmd
+}
+
+// plaintext values written before the encryption is enabled are read as is
+func provideEncryptingAppStorageProvider(asp istorage.IAppStorageProvider) istorageenc.IAppStorageProvider {
+	return istorageenc.Provide(asp, isecretsimpl.ProvideSecretReader(), timeu.NewITime())
+}
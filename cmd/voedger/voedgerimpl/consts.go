@@ -13,6 +13,9 @@ const (
 	storageTypeCas1         string = "cas1"
 	storageTypeCas3         string = "cas3"
 	storageTypeMem          string = "mem"
+	storageTypeBBolt        string = "bbolt:"    // bbolt:<database dir>
+	storageTypeAmazonDB     string = "amazondb:" // amazondb:<endpoint URL>, region and credentials are taken from AWS_* env vars
+	storageTypeLSM          string = "lsm:"      // lsm:<database dir>
 	cas1ReplicationStrategy string = "{'class': 'SimpleStrategy', 'replication_factor': '1'}"
 	cas3ReplicationStrategy string = "{ 'class': 'NetworkTopologyStrategy', 'dc1': 2, 'dc2': 1}"
 )
@@ -11,22 +11,46 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 
 	sysmonitor "github.com/voedger/voedger/cmd/voedger/sys.monitor"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/ihttp"
 	"github.com/voedger/voedger/pkg/ihttpctl"
 	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/amazondb"
+	"github.com/voedger/voedger/pkg/istorage/bbolt"
 	"github.com/voedger/voedger/pkg/istorage/cas"
+	"github.com/voedger/voedger/pkg/istorage/lsm"
 	"github.com/voedger/voedger/pkg/istorage/mem"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
-func NewStaticEmbeddedResources() []ihttpctl.StaticResourcesType {
-	return []ihttpctl.StaticResourcesType{
+func NewStaticResources(params CLIParams) ([]ihttpctl.StaticResourcesType, error) {
+	res := []ihttpctl.StaticResourcesType{
 		sysmonitor.New(),
 	}
+	for _, pkgPath := range params.AppImages {
+		folders, err := cluster.AppImageStaticFolders(pkgPath)
+		if err != nil {
+			return nil, fmt.Errorf("app image %s: %w", pkgPath, err)
+		}
+		appImageResources := ihttpctl.StaticResourcesType{}
+		for _, folder := range folders {
+			// e.g. /static/github.com/company/app/Web/
+			appImageResources[folder.PkgPath+"/"+folder.Name] = ihttpctl.StaticFolder{
+				FS:      folder.FS,
+				Options: ihttp.StaticFolderOptions{SPA: folder.SPA},
+			}
+		}
+		res = append(res, appImageResources)
+	}
+	return res, nil
 }
 
 func NewRedirectionRoutes() ihttpctl.RedirectRoutes {
@@ -55,6 +79,21 @@ func NewAppStorageFactory(params CLIParams) (istorage.IAppStorageFactory, error)
 	case storageTypeMem:
 		return mem.Provide(testingu.MockTime), nil
 	default:
+		if dir, ok := strings.CutPrefix(params.Storage, storageTypeBBolt); ok {
+			return bbolt.Provide(bbolt.ParamsType{DBDir: dir}, timeu.NewITime()), nil
+		}
+		if dir, ok := strings.CutPrefix(params.Storage, storageTypeLSM); ok {
+			return lsm.Provide(lsm.ParamsType{DBDir: dir}, timeu.NewITime()), nil
+		}
+		if endpoint, ok := strings.CutPrefix(params.Storage, storageTypeAmazonDB); ok {
+			return amazondb.Provide(amazondb.DynamoDBParams{
+				EndpointURL:     endpoint,
+				Region:          os.Getenv("AWS_REGION"),
+				AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+				SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+				SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			}, timeu.NewITime()), nil
+		}
 		return nil, errors.New("unable to define replication strategy")
 	}
 	return cas.Provide(casParams)
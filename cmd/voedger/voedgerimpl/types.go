@@ -6,5 +6,9 @@
 package voedger
 
 type CLIParams struct {
-	Storage string
+	Storage   string
+	AppImages []string // `pkg` folders of the application images to serve static folders from
+
+	// values are encrypted by keys read from secrets, see [istorageenc]
+	StorageEncryption bool
 }
@@ -25,7 +25,7 @@ func wireServer(httpCliParams ihttp.CLIParams, appsCliParams voedger.CLIParams)
 			ihttpimpl.NewProcessor,
 			ihttpctl.NewHTTPProcessorController,
 			ihttp.NewIRouterStorage,
-			voedger.NewStaticEmbeddedResources,
+			voedger.NewStaticResources,
 			voedger.NewRedirectionRoutes,
 			voedger.NewDefaultRedirectionRoute,
 			voedger.NewAppStorageFactory,
@@ -38,6 +38,10 @@ func wireServer(httpCliParams ihttp.CLIParams, appsCliParams voedger.CLIParams)
 }
 
 // provideAppStorageProvider is intended to be used by wire instead of istorage/provider.Provide, because wire can not handle variadic arguments
-func provideAppStorageProvider(appStorageFactory istorage.IAppStorageFactory) istorage.IAppStorageProvider {
-	return provider.Provide(appStorageFactory)
+func provideAppStorageProvider(appStorageFactory istorage.IAppStorageFactory, appsCliParams voedger.CLIParams) istorage.IAppStorageProvider {
+	asp := provider.Provide(appStorageFactory)
+	if appsCliParams.StorageEncryption {
+		return provideEncryptingAppStorageProvider(asp)
+	}
+	return asp
 }
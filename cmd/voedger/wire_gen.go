@@ -26,13 +26,17 @@ func wireServer(httpCliParams ihttp.CLIParams, appsCliParams voedger.CLIParams)
 	if err != nil {
 		return WiredServer{}, nil, err
 	}
-	iAppStorageProvider := provideAppStorageProvider(iAppStorageFactory)
+	iAppStorageProvider := provideAppStorageProvider(iAppStorageFactory, appsCliParams)
 	iRouterStorage, err := ihttp.NewIRouterStorage(iAppStorageProvider)
 	if err != nil {
 		return WiredServer{}, nil, err
 	}
 	ihttpProcessor, cleanup := ihttpimpl.NewProcessor(httpCliParams, iRouterStorage)
-	v := voedger.NewStaticEmbeddedResources()
+	v, err := voedger.NewStaticResources(appsCliParams)
+	if err != nil {
+		cleanup()
+		return WiredServer{}, nil, err
+	}
 	redirectRoutes := voedger.NewRedirectionRoutes()
 	defaultRedirectRoute := voedger.NewDefaultRedirectionRoute()
 	acmeDomains := httpCliParams.AcmeDomains
@@ -50,6 +54,10 @@ func wireServer(httpCliParams ihttp.CLIParams, appsCliParams voedger.CLIParams)
 // wire.go:
 
 // provideAppStorageProvider is intended to be used by wire instead of istorage/provider.Provide, because wire can not handle variadic arguments
-func provideAppStorageProvider(appStorageFactory istorage.IAppStorageFactory) istorage.IAppStorageProvider {
-	return provider.Provide(appStorageFactory)
+func provideAppStorageProvider(appStorageFactory istorage.IAppStorageFactory, appsCliParams voedger.CLIParams) istorage.IAppStorageProvider {
+	asp := provider.Provide(appStorageFactory)
+	if appsCliParams.StorageEncryption {
+		return provideEncryptingAppStorageProvider(asp)
+	}
+	return asp
 }
@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/voedger/voedger/pkg/vsqllsp"
+)
+
+func newLspCmd(ver string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "run VSQL language server over stdin and stdout",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return vsqllsp.Serve(cmd.Context(), ver, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	return cmd
+}
@@ -42,6 +42,7 @@ func execRootCmd(args []string, ver string) error {
 		newInitCmd(params),
 		newTidyCmd(params),
 		newBuildCmd(params),
+		newLspCmd(ver),
 	)
 	correctCommandTexts(rootCmd)
 	initChangeDirFlags(rootCmd.Commands(), params)
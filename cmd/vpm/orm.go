@@ -476,7 +476,10 @@ func processITypeObj(
 			uniqueProjectorCommandEvents,
 		); resultObj != nil {
 			if tableData, ok := resultObj.(ormTableItem); ok {
-				resultFields = tableData.Fields
+				// sys.Container is not the field to be set by the extension
+				resultFields = slices.DeleteFunc(slices.Clone(tableData.Fields), func(f ormField) bool {
+					return f.Name == normalizeName(appdef.SystemField_Container)
+				})
 			}
 		}
 
@@ -626,6 +629,8 @@ func getObjType(obj interface{}) string {
 		return "CRecord"
 	case appdef.IQuery:
 		return "Query"
+	case appdef.IJob:
+		return "Job"
 	case appdef.IWorkspace:
 		return "WS"
 	case appdef.IProjector:
@@ -85,6 +85,33 @@ func FillPbillDates() {
 	intent.Set_LastOffset(offs)
 }
 
+// Query
+//
+//export PbillDate
+func PbillDate() {
+	params := orm.Package_air.Query_PbillDate.ArgumentObject()
+
+	val, ok := orm.Package_air.View_PbillDates.Get(params.Get_Year(), params.Get_DayOfYear())
+	if !ok {
+		return
+	}
+
+	orm.Package_air.Query_PbillDate.Result(val.Get_FirstOffset(), val.Get_LastOffset())
+}
+
+// Job
+//
+//export Heartbeat
+func Heartbeat() {
+	runTime := time.Unix(orm.Package_air.Job_Heartbeat.UnixTime(), 0).UTC()
+	day := int32(runTime.Unix() / secondsInDay) //nolint G115
+
+	intent := orm.Package_air.View_Heartbeats.Insert(day, int32(runTime.Hour())) //nolint G115
+	intent.Set_UnixTime(runTime.Unix())
+}
+
+const secondsInDay = 24 * 60 * 60
+
 func main() {
 	Pbill()
 }
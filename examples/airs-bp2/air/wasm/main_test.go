@@ -99,3 +99,62 @@ func TestPbill(t *testing.T) {
 			Run()
 	})
 }
+
+func TestPbillDate(t *testing.T) {
+	t.Run("PbillDates view record exists", func(t *testing.T) {
+		test.NewQueryTest(
+			t,
+			orm.Package_air.Query_PbillDate,
+			PbillDate,
+		).
+			StateView(
+				orm.Package_air.View_PbillDates,
+				0,
+				`Year`, 2025,
+				`DayOfYear`, 100,
+				`FirstOffset`, 10,
+				`LastOffset`, 20,
+			).
+			ArgumentObject(
+				1,
+				`Year`, 2025,
+				`DayOfYear`, 100,
+			).
+			ResultRow(
+				`FirstOffset`, 10,
+				`LastOffset`, 20,
+			).
+			Run()
+	})
+
+	t.Run("No PbillDates view record", func(t *testing.T) {
+		test.NewQueryTest(
+			t,
+			orm.Package_air.Query_PbillDate,
+			PbillDate,
+		).
+			ArgumentObject(
+				1,
+				`Year`, 2025,
+				`DayOfYear`, 101,
+			).
+			Run()
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	runTime := time.Date(2025, time.March, 1, 13, 0, 0, 0, time.UTC)
+
+	test.NewJobTest(
+		t,
+		Heartbeat,
+	).
+		JobTime(runTime).
+		IntentViewInsert(
+			orm.Package_air.View_Heartbeats,
+			`Day`, int(runTime.Unix()/secondsInDay),
+			`Hour`, 13,
+			`UnixTime`, runTime.Unix(),
+		).
+		Run()
+}
@@ -22,11 +22,13 @@ require (
 	github.com/gorilla/mux v1.8.1
 	github.com/hashicorp/golang-lru/v2 v2.0.7
 	github.com/juju/errors v1.0.0
+	github.com/klauspost/compress v1.18.0
 	github.com/pires/go-proxyproto v0.12.0
 	github.com/robfig/cron/v3 v3.0.1
 	github.com/spf13/cobra v1.10.2
 	github.com/spf13/pflag v1.0.10
 	github.com/stretchr/testify v1.11.1
+	github.com/syndtr/goleveldb v1.0.1-0.20220721030215-126854af5e6d
 	github.com/tetratelabs/wazero v1.11.0
 	github.com/untillpro/dynobuffers v0.0.0-20251212090544-93da105bf1da
 	github.com/valyala/bytebufferpool v1.0.0
@@ -106,6 +106,33 @@ func MaxExcl(v float64, c ...string) appdef.IConstraint {
 	return datas.NewDataConstraint(appdef.ConstraintKind_MaxExcl, v, c...)
 }
 
+// Return new precision constraint for currency data types.
+//
+// Precision is the total number of decimal digits, the value must be in [1, MaxCurrencyPrecision].
+//
+// # Panics:
+//   - if value is zero
+//   - if value is greater than MaxCurrencyPrecision
+func Precision(v uint16, c ...string) appdef.IConstraint {
+	if v == 0 || v > appdef.MaxCurrencyPrecision {
+		panic(appdef.ErrOutOfBounds("precision %d, expected [1, %d]", v, appdef.MaxCurrencyPrecision))
+	}
+	return datas.NewDataConstraint(appdef.ConstraintKind_Precision, v, c...)
+}
+
+// Return new scale constraint for currency data types.
+//
+// Scale is the number of fractional decimal digits, the value must not be greater than MaxCurrencyPrecision.
+//
+// # Panics:
+//   - if value is greater than MaxCurrencyPrecision
+func Scale(v uint16, c ...string) appdef.IConstraint {
+	if v > appdef.MaxCurrencyPrecision {
+		panic(appdef.ErrOutOfBounds("scale %d, expected [0, %d]", v, appdef.MaxCurrencyPrecision))
+	}
+	return datas.NewDataConstraint(appdef.ConstraintKind_Scale, v, c...)
+}
+
 // #3434 [~server.vsql.smallints/cmp.AppDef~impl]
 type enumerable interface {
 	string | int8 | int16 | int32 | int64 | float32 | float64
@@ -189,6 +216,10 @@ func NewConstraint(kind appdef.ConstraintKind, value any, c ...string) appdef.IC
 			}
 		}
 		return enum
+	case appdef.ConstraintKind_Precision:
+		return Precision(value.(uint16), c...)
+	case appdef.ConstraintKind_Scale:
+		return Scale(value.(uint16), c...)
 	}
 	panic(appdef.ErrUnsupported("constraint kind: %v", kind))
 }
@@ -43,6 +43,9 @@ var QNameRoleSystem = NewQName(SysPackage, "System")
 
 var QNameRoleDeveloper = NewQName(SysPackage, "Developer")
 
+// QNameRoleInboundWebhook is role for verified requests to inbound webhooks.
+var QNameRoleInboundWebhook = NewQName(SysPackage, "InboundWebhook")
+
 const (
 	// System application owner name
 	SysOwner = "sys"
@@ -76,6 +79,28 @@ var (
 	SysData_QName    QName = SysDataName(DataKind_QName)
 	SysData_bool     QName = SysDataName(DataKind_bool)
 	SysData_RecordID QName = SysDataName(DataKind_RecordID)
+
+	// Timestamp, stored as int64 Unix time in milliseconds
+	SysData_Timestamp QName = NewQName(SysPackage, "Timestamp")
+
+	// Currency (decimal), stored as int64 scaled by 10^scale, see ConstraintKind_Scale and pkg/coreutils/decimal
+	SysData_Currency QName = NewQName(SysPackage, "Currency")
+)
+
+// Range of timestamp data, Unix milliseconds of 0001-01-01T00:00:00.000Z and 9999-12-31T23:59:59.999Z
+const (
+	MinTimestamp int64 = -62135596800000
+	MaxTimestamp int64 = 253402300799999
+)
+
+// Precision (total number of decimal digits) and scale (number of fractional digits) of currency data.
+//
+// 10^MaxCurrencyPrecision-1 fits int64.
+// 10^DefaultCurrencyPrecision-1 is less than 2^53, so every value of default currency is exact as float64
+const (
+	MaxCurrencyPrecision     uint16 = 18
+	DefaultCurrencyPrecision uint16 = 15
+	DefaultCurrencyScale     uint16 = 4
 )
 
 // Maximum containers per one structured type
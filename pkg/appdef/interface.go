@@ -12,6 +12,10 @@ type IAppDef interface {
 	IWithPackages
 	IWithWorkspaces
 	IWithACL
+	IWithTranslations
+	IWithWebhooks
+	IWithInboundWebhooks
+	IWithEgressPolicy
 
 	// Returns type by name.
 	//
@@ -27,6 +31,10 @@ type IAppDefBuilder interface {
 
 	IPackagesBuilder
 	IWorkspacesBuilder
+	ITranslationsBuilder
+	IWebhooksBuilder
+	IInboundWebhooksBuilder
+	IEgressPolicyBuilder
 
 	// Returns application definition while building.
 	//
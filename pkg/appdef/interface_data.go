@@ -106,6 +106,9 @@ const (
 
 	ConstraintKind_Enum
 
+	ConstraintKind_Precision
+	ConstraintKind_Scale
+
 	ConstraintKind_count
 )
 
@@ -124,6 +127,7 @@ type IConstraint interface {
 	//	- uint16 value for min/max length constraints,
 	// 	- *regexp.Regexp value for pattern constraint,
 	// 	- float64 value for min/max inclusive/exclusive constraints.
-	//	- sorted slice with values for enumeration constraint.
+	//	- sorted slice with values for enumeration constraint,
+	//	- uint16 value for precision and scale constraints.
 	Value() any
 }
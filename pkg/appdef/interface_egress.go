@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package appdef
+
+import "time"
+
+// Egress rule allows outgoing HTTP requests of the application extensions
+// to the host by the scheme.
+type IEgressRule interface {
+	// Returns URL scheme in lower case, e.g. "https".
+	Scheme() string
+
+	// Returns host name in lower case, e.g. "api.example.com".
+	//
+	// Host can start with "*." to match all subdomains, e.g. "*.example.com".
+	Host() string
+
+	// Returns names of the application secrets that can be injected
+	// into the headers of requests to the host.
+	Secrets() []string
+}
+
+// Egress policy restricts outgoing HTTP requests of the application extensions.
+type IEgressPolicy interface {
+	// Returns all rules in order of scheme and host.
+	Rules() []IEgressRule
+
+	// Returns rule that allows requests to the host by the scheme.
+	//
+	// Returns nil if requests are not allowed.
+	Rule(scheme, host string) IEgressRule
+
+	// Returns maximum size of request and response bodies in bytes.
+	//
+	// Zero means unlimited.
+	MaxBodySize() uint64
+
+	// Returns ceiling of the request timeout.
+	//
+	// Zero means the default timeout ceiling.
+	MaxTimeout() time.Duration
+}
+
+type IWithEgressPolicy interface {
+	// Returns egress policy of the application.
+	//
+	// Returns nil if the application does not declare egress policy,
+	// outgoing requests are not restricted then.
+	EgressPolicy() IEgressPolicy
+}
+
+type IEgressPolicyBuilder interface {
+	// Allows outgoing requests to the host by the scheme.
+	// Secrets are names of the application secrets that can be injected into the headers of requests to the host.
+	//
+	// # Panics:
+	//   - if scheme is empty,
+	//   - if host is empty or is invalid wildcard,
+	//   - if requests to the host by the scheme are already allowed.
+	AllowEgress(scheme, host string, secrets ...string) IEgressPolicyBuilder
+
+	// Sets maximum size of request and response bodies in bytes.
+	SetEgressMaxBodySize(uint64) IEgressPolicyBuilder
+
+	// Sets ceiling of the request timeout.
+	//
+	// # Panics:
+	//   - if timeout is negative.
+	SetEgressMaxTimeout(time.Duration) IEgressPolicyBuilder
+}
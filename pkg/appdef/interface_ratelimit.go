@@ -26,6 +26,31 @@ const (
 
 var DefaultRateScopes = []RateScope{RateScope_AppPartition}
 
+// Rate buckets enumeration.
+//
+// Defines how the buckets of the rate are kept.
+type RateBuckets uint8
+
+//go:generate stringer -type=RateBuckets -output=stringer_ratebuckets.go
+const (
+	RateBuckets_null RateBuckets = iota
+
+	// Token buckets in the memory of VVM.
+	// Each VVM enforces its own copy of the rate, restarts reset the buckets.
+	// Default.
+	RateBuckets_Local
+
+	// Token buckets in the application TTL storage.
+	// Buckets are shared by all VVMs and survive restarts.
+	RateBuckets_Distributed
+
+	// Sliding window counters in the application TTL storage.
+	// Counters are shared by all VVMs and survive restarts, no bursts on the window boundaries.
+	RateBuckets_SlidingWindow
+
+	RateBuckets_count
+)
+
 type (
 	RateCount  = uint32
 	RatePeriod = time.Duration
@@ -41,6 +66,11 @@ type IRate interface {
 
 	// Returns rate scopes.
 	Scopes() []RateScope
+
+	// Returns how the buckets of the rate are kept.
+	//
+	// RateBuckets_Local is returned by default.
+	Buckets() RateBuckets
 }
 
 type IRatesBuilder interface {
@@ -54,6 +84,13 @@ type IRatesBuilder interface {
 	//   - if count is zero,
 	//   - if period is zero.
 	AddRate(name QName, count RateCount, period RatePeriod, scopes []RateScope, comment ...string)
+
+	// Sets how the buckets of the rate with specified name are kept.
+	//
+	// # Panics:
+	//   - if rate with specified name is not found,
+	//   - if buckets are unknown.
+	SetRateBuckets(name QName, buckets RateBuckets)
 }
 
 // Limit filter options enumeration
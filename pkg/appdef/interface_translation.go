@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package appdef
+
+type IWithTranslations interface {
+	// Returns all languages that have translations in alphabetical order.
+	//
+	// Languages are BCP 47 tags, e.g. "en", "nl", "pt-BR"
+	Languages() []string
+
+	// Returns translation of the text to the specified language.
+	//
+	// Texts with fmt verbs, e.g. "field %s is required", are translated texts formatted by them,
+	// e.g. "field Name is required", args are put into verbs of the translation in order or by explicit index, e.g. %[2]s.
+	//
+	// Returns false if there is no translation
+	Translation(lang, text string) (string, bool)
+}
+
+type ITranslationsBuilder interface {
+	// Adds translation of the text to the specified language.
+	//
+	// # Panics:
+	//   - if language is empty,
+	//   - if text is empty,
+	//   - if other translation of the text to the language already exists.
+	AddTranslation(lang, text, translation string) ITranslationsBuilder
+}
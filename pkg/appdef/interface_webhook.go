@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package appdef
+
+// Webhook is an outbound HTTP notification about application events.
+//
+// The JSON envelope of the triggering event is POSTed to the webhook URL
+// and signed by HMAC-SHA256 with the webhook secret.
+type IWebhook interface {
+	IWithComments
+
+	// Returns qualified name of the webhook.
+	QName() QName
+
+	// Returns workspace the webhook is declared in.
+	//
+	// Webhook triggers by events of this workspace and all its descendants.
+	Workspace() QName
+
+	// Returns URL the events are posted to.
+	//
+	// URL can be a `{{secret:name}}` placeholder, then the URL is read from
+	// the application secret on every delivery, see WebhookURLSecret.
+	URL() string
+
+	// Returns name of the application secret that is used to sign the events.
+	Secret() string
+
+	// Returns events that trigger this webhook.
+	Events() []IProjectorEvent
+
+	// Returns whether this webhook triggers with the specified operation and type.
+	Triggers(OperationKind, IType) bool
+}
+
+type IWithWebhooks interface {
+	// Returns all webhooks in alphabetical order.
+	Webhooks() []IWebhook
+
+	// Returns webhook by name.
+	//
+	// Returns nil if not found.
+	Webhook(QName) IWebhook
+}
+
+type IWebhookBuilder interface {
+	ICommenter
+
+	// Adds new event.
+	//
+	// # Panics:
+	//	 - if specified operations are not projector operations,
+	//	 - if filter is nil.
+	AddEvent(ops []OperationKind, flt IFilter) IWebhookBuilder
+}
+
+type IWebhooksBuilder interface {
+	// Adds new webhook.
+	//
+	// # Panics:
+	//   - if name is empty or invalid,
+	//   - if webhook with the same name already exists,
+	//   - if workspace is empty,
+	//   - if URL is empty,
+	//   - if secret is empty.
+	AddWebhook(name, ws QName, url, secret string) IWebhookBuilder
+}
+
+// Inbound webhook verification kind.
+//
+// Ref. to stringer_inboundwebhookverify.go for string representation
+type InboundWebhookVerify uint8
+
+//go:generate stringer -type=InboundWebhookVerify -output=stringer_inboundwebhookverify.go
+
+const (
+	InboundWebhookVerify_null InboundWebhookVerify = iota
+
+	// Header contains "t=<unix seconds>,v1=<hex>", where v1 is HMAC-SHA256
+	// of "<t>.<wsid>.<inbound webhook qname>.<request body>" signed by the secret.
+	// Requests with the timestamp older or newer than 5 minutes are rejected.
+	InboundWebhookVerify_HMAC
+
+	// Header contains the token of the workspace: hex encoded HMAC-SHA256
+	// of "<wsid>.<inbound webhook qname>" signed by the secret.
+	InboundWebhookVerify_Token
+
+	InboundWebhookVerify_count
+)
+
+// Inbound webhook is an HTTP endpoint for third party callbacks.
+//
+// The request body is passed to the command as its argument.
+// The request is executed under the sys.InboundWebhook role if the sender is verified.
+type IInboundWebhook interface {
+	IWithComments
+
+	// Returns qualified name of the inbound webhook.
+	QName() QName
+
+	// Returns workspace the inbound webhook is declared in.
+	//
+	// Inbound webhook is available in this workspace and all its descendants.
+	Workspace() QName
+
+	// Returns command the request body is passed to.
+	Command() QName
+
+	// Returns how the sender is verified.
+	Verify() InboundWebhookVerify
+
+	// Returns HTTP header that contains the signature or the token.
+	Header() string
+
+	// Returns name of the application secret that is used to verify the sender.
+	Secret() string
+
+	// Returns HTTP status code of the successful reply.
+	//
+	// http.StatusOK by default.
+	ReplyStatus() int
+}
+
+type IWithInboundWebhooks interface {
+	// Returns all inbound webhooks in alphabetical order.
+	InboundWebhooks() []IInboundWebhook
+
+	// Returns inbound webhook by name.
+	//
+	// Returns nil if not found.
+	InboundWebhook(QName) IInboundWebhook
+}
+
+type IInboundWebhookBuilder interface {
+	ICommenter
+
+	// Sets HTTP status code of the successful reply.
+	//
+	// # Panics:
+	//	 - if status is not 2xx or is http.StatusNoContent, the reply always has a body.
+	SetReplyStatus(int) IInboundWebhookBuilder
+}
+
+type IInboundWebhooksBuilder interface {
+	// Adds new inbound webhook.
+	//
+	// # Panics:
+	//   - if name is empty or invalid,
+	//   - if inbound webhook with the same name already exists,
+	//   - if workspace or command is empty,
+	//   - if verify kind is unknown,
+	//   - if header or secret is empty.
+	AddInboundWebhook(name, ws, cmd QName, verify InboundWebhookVerify, header, secret string) IInboundWebhookBuilder
+}
@@ -9,8 +9,11 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appdef/internal/acl"
 	"github.com/voedger/voedger/pkg/appdef/internal/comments"
+	"github.com/voedger/voedger/pkg/appdef/internal/egress"
 	"github.com/voedger/voedger/pkg/appdef/internal/packages"
+	"github.com/voedger/voedger/pkg/appdef/internal/translations"
 	"github.com/voedger/voedger/pkg/appdef/internal/types"
+	"github.com/voedger/voedger/pkg/appdef/internal/webhooks"
 	"github.com/voedger/voedger/pkg/appdef/internal/workspaces"
 )
 
@@ -22,15 +25,23 @@ type AppDef struct {
 	workspaces.WithWorkspaces
 	acl.WithACL
 	types.WithTypes
+	translations.WithTranslations
+	webhooks.WithWebhooks
+	webhooks.WithInboundWebhooks
+	egress.WithEgressPolicy
 }
 
 func NewAppDef() *AppDef {
 	app := AppDef{
-		WithComments:   comments.MakeWithComments(),
-		WithPackages:   packages.MakeWithPackages(),
-		WithWorkspaces: workspaces.MakeWithWorkspaces(),
-		WithACL:        acl.MakeWithACL(),
-		WithTypes:      types.MakeWithTypes(),
+		WithComments:        comments.MakeWithComments(),
+		WithPackages:        packages.MakeWithPackages(),
+		WithWorkspaces:      workspaces.MakeWithWorkspaces(),
+		WithACL:             acl.MakeWithACL(),
+		WithTypes:           types.MakeWithTypes(),
+		WithTranslations:    translations.MakeWithTranslations(),
+		WithWebhooks:        webhooks.MakeWithWebhooks(),
+		WithInboundWebhooks: webhooks.MakeWithInboundWebhooks(),
+		WithEgressPolicy:    egress.MakeWithEgressPolicy(),
 	}
 	return &app
 }
@@ -56,15 +67,23 @@ type AppDefBuilder struct {
 	comments.CommentBuilder
 	packages.PackagesBuilder
 	workspaces.WorkspacesBuilder
+	translations.TranslationsBuilder
+	webhooks.WebhooksBuilder
+	webhooks.InboundWebhooksBuilder
+	egress.EgressPolicyBuilder
 	app *AppDef
 }
 
 func NewAppDefBuilder(app *AppDef) *AppDefBuilder {
 	return &AppDefBuilder{
-		CommentBuilder:    comments.MakeCommentBuilder(&app.WithComments),
-		PackagesBuilder:   packages.MakePackagesBuilder(&app.WithPackages),
-		WorkspacesBuilder: workspaces.MakeWorkspacesBuilder(app, &app.WithWorkspaces),
-		app:               app,
+		CommentBuilder:         comments.MakeCommentBuilder(&app.WithComments),
+		PackagesBuilder:        packages.MakePackagesBuilder(&app.WithPackages),
+		WorkspacesBuilder:      workspaces.MakeWorkspacesBuilder(app, &app.WithWorkspaces),
+		TranslationsBuilder:    translations.MakeTranslationsBuilder(&app.WithTranslations),
+		WebhooksBuilder:        webhooks.MakeWebhooksBuilder(&app.WithWebhooks),
+		InboundWebhooksBuilder: webhooks.MakeInboundWebhooksBuilder(&app.WithInboundWebhooks),
+		EgressPolicyBuilder:    egress.MakeEgressPolicyBuilder(&app.WithEgressPolicy),
+		app:                    app,
 	}
 }
 
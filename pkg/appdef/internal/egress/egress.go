@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package egress
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+const (
+	// "*.example.com" matches all subdomains of example.com
+	wildcardPrefix = "*."
+
+	// "*" matches any host
+	anyHost = "*"
+)
+
+// # Supports:
+//   - appdef.IEgressRule
+type Rule struct {
+	scheme  string
+	host    string
+	secrets []string
+}
+
+func (r Rule) Host() string { return r.host }
+
+func (r Rule) Scheme() string { return r.scheme }
+
+func (r Rule) Secrets() []string { return r.secrets }
+
+func (r Rule) String() string {
+	return fmt.Sprintf("egress rule «%s://%s»", r.scheme, r.host)
+}
+
+// # Supports:
+//   - appdef.IEgressPolicy
+type Policy struct {
+	byTarget    map[string]*Rule
+	rules       []appdef.IEgressRule
+	maxBodySize uint64
+	maxTimeout  time.Duration
+}
+
+func (p Policy) MaxBodySize() uint64 { return p.maxBodySize }
+
+func (p Policy) MaxTimeout() time.Duration { return p.maxTimeout }
+
+func (p Policy) Rule(scheme, host string) appdef.IEgressRule {
+	scheme, host = strings.ToLower(scheme), strings.ToLower(host)
+	if r, ok := p.byTarget[target(scheme, host)]; ok {
+		return r
+	}
+	// wildcard rules from the nearest parent domain to the farthest
+	for d := host; ; {
+		i := strings.IndexByte(d, '.')
+		if i < 0 {
+			break
+		}
+		d = d[i+1:]
+		if r, ok := p.byTarget[target(scheme, wildcardPrefix+d)]; ok {
+			return r
+		}
+	}
+	if r, ok := p.byTarget[target(scheme, anyHost)]; ok {
+		return r
+	}
+	return nil
+}
+
+func (p Policy) Rules() []appdef.IEgressRule { return p.rules }
+
+func (p *Policy) allow(scheme, host string, secrets ...string) {
+	if scheme == "" {
+		panic(appdef.ErrMissed("egress scheme"))
+	}
+	if host == "" {
+		panic(appdef.ErrMissed("egress host for scheme «%s»", scheme))
+	}
+	scheme, host = strings.ToLower(scheme), strings.ToLower(host)
+	if host != anyHost && strings.Contains(strings.TrimPrefix(host, wildcardPrefix), "*") {
+		panic(appdef.ErrInvalid("egress host «%s», only leading «%s» wildcard is supported", host, wildcardPrefix))
+	}
+	t := target(scheme, host)
+	if _, ok := p.byTarget[t]; ok {
+		panic(appdef.ErrAlreadyExists("egress rule «%s»", t))
+	}
+	for _, s := range secrets {
+		if s == "" {
+			panic(appdef.ErrMissed("egress rule «%s» secret name", t))
+		}
+	}
+	r := &Rule{scheme: scheme, host: host, secrets: slices.Clone(secrets)}
+	p.byTarget[t] = r
+	p.rules = append(p.rules, r)
+	slices.SortFunc(p.rules, func(a, b appdef.IEgressRule) int {
+		return strings.Compare(target(a.Scheme(), a.Host()), target(b.Scheme(), b.Host()))
+	})
+}
+
+func (p *Policy) setMaxTimeout(timeout time.Duration) {
+	if timeout < 0 {
+		panic(appdef.ErrOutOfBounds("egress max timeout %v", timeout))
+	}
+	p.maxTimeout = timeout
+}
+
+// # Supports:
+//   - appdef.IWithEgressPolicy
+type WithEgressPolicy struct {
+	policy *Policy
+}
+
+func MakeWithEgressPolicy() WithEgressPolicy {
+	return WithEgressPolicy{}
+}
+
+func (wp WithEgressPolicy) EgressPolicy() appdef.IEgressPolicy {
+	if wp.policy == nil {
+		return nil
+	}
+	return wp.policy
+}
+
+// egress policy is created by the first call of the builder
+func (wp *WithEgressPolicy) get() *Policy {
+	if wp.policy == nil {
+		wp.policy = &Policy{
+			byTarget: make(map[string]*Rule),
+			rules:    make([]appdef.IEgressRule, 0),
+		}
+	}
+	return wp.policy
+}
+
+// # Supports:
+//   - appdef.IEgressPolicyBuilder
+type EgressPolicyBuilder struct {
+	wp *WithEgressPolicy
+}
+
+func MakeEgressPolicyBuilder(wp *WithEgressPolicy) EgressPolicyBuilder {
+	return EgressPolicyBuilder{wp}
+}
+
+func (pb *EgressPolicyBuilder) AllowEgress(scheme, host string, secrets ...string) appdef.IEgressPolicyBuilder {
+	pb.wp.get().allow(scheme, host, secrets...)
+	return pb
+}
+
+func (pb *EgressPolicyBuilder) SetEgressMaxBodySize(size uint64) appdef.IEgressPolicyBuilder {
+	pb.wp.get().maxBodySize = size
+	return pb
+}
+
+func (pb *EgressPolicyBuilder) SetEgressMaxTimeout(timeout time.Duration) appdef.IEgressPolicyBuilder {
+	pb.wp.get().setMaxTimeout(timeout)
+	return pb
+}
+
+func target(scheme, host string) string { return scheme + "://" + host }
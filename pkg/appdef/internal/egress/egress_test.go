@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package egress_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/internal/egress"
+	"github.com/voedger/voedger/pkg/goutils/testingu/require"
+)
+
+func Test_EgressPolicy(t *testing.T) {
+	require := require.New(t)
+
+	wp := egress.MakeWithEgressPolicy()
+
+	// should be appdef.IWithEgressPolicy compatible
+	var _ appdef.IWithEgressPolicy = wp
+
+	pb := egress.MakeEgressPolicyBuilder(&wp)
+
+	// should be appdef.IEgressPolicyBuilder compatible
+	var _ appdef.IEgressPolicyBuilder = &pb
+
+	t.Run("should be nil policy if not declared", func(t *testing.T) {
+		require.Nil(wp.EgressPolicy())
+	})
+
+	t.Run("should be ok to build policy", func(t *testing.T) {
+		pb.AllowEgress("https", "api.example.com", "api-key").
+			AllowEgress("HTTPS", "*.Example.com").
+			AllowEgress("http", "*").
+			SetEgressMaxBodySize(1024).
+			SetEgressMaxTimeout(10 * time.Second)
+	})
+
+	t.Run("should be ok to inspect policy", func(t *testing.T) {
+		p := wp.EgressPolicy()
+		require.NotNil(p)
+		require.EqualValues(1024, p.MaxBodySize())
+		require.Equal(10*time.Second, p.MaxTimeout())
+
+		require.Len(p.Rules(), 3)
+		require.Equal("http", p.Rules()[0].Scheme(), "should be sorted")
+		require.Equal("*.example.com", p.Rules()[1].Host(), "should be in lower case")
+
+		r := p.Rule("https", "API.example.com")
+		require.NotNil(r)
+		require.Equal("api.example.com", r.Host())
+		require.Equal([]string{"api-key"}, r.Secrets())
+
+		require.Equal("*.example.com", p.Rule("https", "a.b.example.com").Host())
+		require.Nil(p.Rule("https", "example.com"), "wildcard should not match the domain itself")
+		require.Nil(p.Rule("https", "example.org"))
+		require.Equal("*", p.Rule("http", "example.org").Host())
+		require.Nil(p.Rule("ftp", "api.example.com"))
+	})
+
+	t.Run("should be panics", func(t *testing.T) {
+		require.Panics(func() { pb.AllowEgress("", "example.org") }, require.Is(appdef.ErrMissedError))
+		require.Panics(func() { pb.AllowEgress("https", "") }, require.Is(appdef.ErrMissedError))
+		require.Panics(func() { pb.AllowEgress("https", "api.*.org") }, require.Is(appdef.ErrInvalidError))
+		require.Panics(func() { pb.AllowEgress("https", "API.example.com") },
+			require.Is(appdef.ErrAlreadyExistsError), require.Has("https://api.example.com"))
+		require.Panics(func() { pb.AllowEgress("https", "example.org", "") }, require.Is(appdef.ErrMissedError))
+		require.Panics(func() { pb.SetEgressMaxTimeout(-time.Second) }, require.Is(appdef.ErrOutOfBoundsError))
+	})
+}
@@ -150,18 +150,25 @@ func (e ProjectorEvent) Op(o appdef.OperationKind) bool { return e.opSet.Contain
 func (e ProjectorEvent) Ops() []appdef.OperationKind { return e.ops }
 
 // Validates projector event.
+//
+// Projector is triggered by events of the workspaces that inherit its workspace,
+// so the filter may match the types of these workspaces
 func (e ProjectorEvent) Validate(prj appdef.IProjector) (err error) {
-	cnt := 0
-	for _, t := range prj.Workspace().Types() {
-		if appdef.TypeKind_ProjectorTriggers.Contains(t.Kind()) {
-			if e.flt.Match(t) {
-				cnt++
-				break // is enough
+	matches := func(ws appdef.IWorkspace) bool {
+		for _, t := range ws.Types() {
+			if appdef.TypeKind_ProjectorTriggers.Contains(t.Kind()) && e.flt.Match(t) {
+				return true
 			}
 		}
+		return false
 	}
-	if cnt == 0 {
-		err = errors.Join(err, appdef.ErrFilterHasNoMatches(prj, e.flt, prj.Workspace()))
+	if matches(prj.Workspace()) {
+		return nil
 	}
-	return err
+	for _, ws := range prj.App().Workspaces() {
+		if ws != prj.Workspace() && ws.Inherits(prj.Workspace().QName()) && matches(ws) {
+			return nil
+		}
+	}
+	return appdef.ErrFilterHasNoMatches(prj, e.flt, prj.Workspace())
 }
@@ -223,6 +223,25 @@ func Test_Projectors(t *testing.T) {
 		})
 	})
 
+	t.Run("should be ok if filter matches types of the descendant workspace", func(t *testing.T) {
+		adb := builder.New()
+		adb.AddPackage("test", "test.com/test")
+
+		wsb := adb.AddWorkspace(wsName)
+		wsb.SetAbstract()
+		prj := wsb.AddProjector(prjRecName)
+		prj.Events().Add(
+			[]appdef.OperationKind{appdef.OperationKind_Insert},
+			filter.QNames(recName))
+
+		descendant := adb.AddWorkspace(appdef.NewQName("test", "descendant"))
+		descendant.SetAncestors(wsName)
+		descendant.AddCRecord(recName)
+
+		_, err := adb.Build()
+		require.NoError(err)
+	})
+
 	t.Run("should be panics", func(t *testing.T) {
 
 		t.Run("if invalid events", func(t *testing.T) {
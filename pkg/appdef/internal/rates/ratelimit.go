@@ -24,6 +24,7 @@ type Rate struct {
 	period   appdef.RatePeriod
 	scopes   []appdef.RateScope
 	scopeSet set.Set[appdef.RateScope]
+	buckets  appdef.RateBuckets
 }
 
 func NewRate(ws appdef.IWorkspace, name appdef.QName, count appdef.RateCount, period appdef.RatePeriod, scopes []appdef.RateScope, comment ...string) *Rate {
@@ -32,6 +33,7 @@ func NewRate(ws appdef.IWorkspace, name appdef.QName, count appdef.RateCount, pe
 		count:    count,
 		period:   period,
 		scopeSet: set.From(scopes...),
+		buckets:  appdef.RateBuckets_Local,
 	}
 	if r.scopeSet.Len() == 0 {
 		r.scopeSet.Set(appdef.DefaultRateScopes...)
@@ -44,12 +46,21 @@ func NewRate(ws appdef.IWorkspace, name appdef.QName, count appdef.RateCount, pe
 
 func (r Rate) Count() appdef.RateCount { return r.count }
 
+func (r Rate) Buckets() appdef.RateBuckets { return r.buckets }
+
 func (r Rate) Period() appdef.RatePeriod { return r.period }
 
 func (r Rate) Scope(s appdef.RateScope) bool { return r.scopeSet.Contains(s) }
 
 func (r Rate) Scopes() []appdef.RateScope { return r.scopes }
 
+func (r *Rate) SetBuckets(buckets appdef.RateBuckets) {
+	if buckets == appdef.RateBuckets_null || buckets >= appdef.RateBuckets_count {
+		panic(appdef.ErrOutOfBounds("%v buckets %v", r, buckets))
+	}
+	r.buckets = buckets
+}
+
 // # Supports:
 //   - appdef.ILimitFilter
 type LimitFilter struct {
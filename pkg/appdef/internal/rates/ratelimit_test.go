@@ -152,6 +152,23 @@ func TestRateLimits(t *testing.T) {
 		require.Equal(time.Hour, r.Period())
 		require.Equal(appdef.DefaultRateScopes, r.Scopes())
 		require.Equal("10 times per hour", r.Comment())
+		require.Equal(appdef.RateBuckets_Local, r.Buckets(), "should be local by default")
+	})
+
+	t.Run("should be ok to set rate buckets", func(t *testing.T) {
+		app := func() appdef.IAppDef {
+			adb := builder.New()
+			adb.AddPackage("test", "test.com/test")
+
+			wsb := adb.AddWorkspace(wsName)
+
+			wsb.AddRate(rateName, 10, time.Hour, []appdef.RateScope{appdef.RateScope_IP}, "10 times per hour per IP")
+			wsb.SetRateBuckets(rateName, appdef.RateBuckets_SlidingWindow)
+
+			return adb.MustBuild()
+		}()
+
+		require.Equal(appdef.RateBuckets_SlidingWindow, appdef.Rate(app.Type, rateName).Buckets())
 	})
 }
 
@@ -218,6 +235,16 @@ func Test_RateLimitErrors(t *testing.T) {
 				require.Is(appdef.ErrUnsupportedError), require.Has(appdef.SysData_bool))
 		})
 
+		t.Run("if set buckets of unknown rate", func(t *testing.T) {
+			require.Panics(func() { wsb.SetRateBuckets(unknown, appdef.RateBuckets_Distributed) },
+				require.Is(appdef.ErrNotFoundError), require.Has(unknown))
+		})
+
+		t.Run("if set unknown rate buckets", func(t *testing.T) {
+			require.Panics(func() { wsb.SetRateBuckets(rateName, appdef.RateBuckets_count) },
+				require.Is(appdef.ErrOutOfBoundsError), require.Has(rateName))
+		})
+
 		t.Run("if missed or unknown rate", func(t *testing.T) {
 			require.Panics(func() {
 				wsb.AddLimit(limitName, []appdef.OperationKind{appdef.OperationKind_Execute}, appdef.LimitFilterOption_ALL, filter.AllWSFunctions(wsName),
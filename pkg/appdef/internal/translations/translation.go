@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package translations
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// # Supports:
+//   - appdef.IWithTranslations
+type WithTranslations struct {
+	langs []string
+	// lang -> text -> translation
+	byLang map[string]map[string]string
+	// lang -> texts with format verbs in order of addition
+	formats map[string][]*format
+}
+
+// text with format verbs, e.g. "field %s is required", matches formatted messages, e.g. "field Name is required"
+type format struct {
+	re          *regexp.Regexp
+	translation string
+}
+
+// fmt verb with optional argument index, flags, width and precision, e.g. %s, %[2]d, %-5.2f, or escaped percent %%
+var formatVerb = regexp.MustCompile(`%%|%(?:\[(\d+)\])?[-+# 0]*\d*(?:\.\d+)?[a-zA-Z]`)
+
+func MakeWithTranslations() WithTranslations {
+	return WithTranslations{
+		langs:   make([]string, 0),
+		byLang:  make(map[string]map[string]string),
+		formats: make(map[string][]*format),
+	}
+}
+
+func (t WithTranslations) Languages() []string { return t.langs }
+
+func (t WithTranslations) Translation(lang, text string) (string, bool) {
+	if tr, ok := t.byLang[lang][text]; ok {
+		return tr, true
+	}
+	for _, f := range t.formats[lang] {
+		if args := f.re.FindStringSubmatch(text); args != nil {
+			return formatTranslation(f.translation, args[1:]), true
+		}
+	}
+	return "", false
+}
+
+func (t *WithTranslations) add(lang, text, translation string) {
+	if lang == "" {
+		panic(appdef.ErrMissed("translation language"))
+	}
+	if text == "" {
+		panic(appdef.ErrMissed("translation text for language «%s»", lang))
+	}
+
+	texts, ok := t.byLang[lang]
+	if !ok {
+		texts = make(map[string]string)
+		t.byLang[lang] = texts
+		t.langs = append(t.langs, lang)
+		slices.Sort(t.langs)
+	}
+	if tr, ok := texts[text]; ok && tr != translation {
+		panic(appdef.ErrAlreadyExists("translation of «%s» to «%s» as «%s»", text, lang, tr))
+	}
+	texts[text] = translation
+	if f := newFormat(text, translation); f != nil {
+		t.formats[lang] = append(t.formats[lang], f)
+	}
+}
+
+// returns nil if the text has no format verbs
+func newFormat(text, translation string) *format {
+	verbs := formatVerb.FindAllStringIndex(text, -1)
+	pattern := strings.Builder{}
+	pattern.WriteString(`(?s)^`)
+	hasArgs := false
+	pos := 0
+	for _, verb := range verbs {
+		pattern.WriteString(regexp.QuoteMeta(text[pos:verb[0]]))
+		if text[verb[0]:verb[1]] == "%%" {
+			pattern.WriteString("%")
+		} else {
+			pattern.WriteString(`(.*?)`)
+			hasArgs = true
+		}
+		pos = verb[1]
+	}
+	if !hasArgs {
+		return nil
+	}
+	pattern.WriteString(regexp.QuoteMeta(text[pos:]))
+	pattern.WriteString(`$`)
+	return &format{re: regexp.MustCompile(pattern.String()), translation: translation}
+}
+
+// replaces format verbs of the translation with the args matched by the text verbs.
+//
+// Verbs are replaced in order, explicit argument index, e.g. %[2]s, changes the order. Verbs without args are kept as is
+func formatTranslation(translation string, args []string) string {
+	argNum := 0
+	return formatVerb.ReplaceAllStringFunc(translation, func(verb string) string {
+		if verb == "%%" {
+			return "%"
+		}
+		if m := formatVerb.FindStringSubmatch(verb); len(m[1]) > 0 {
+			idx, err := strconv.Atoi(m[1])
+			if err != nil {
+				// notest: digits only
+				return verb
+			}
+			argNum = idx - 1
+		}
+		if argNum < 0 || argNum >= len(args) {
+			return verb
+		}
+		argNum++
+		return args[argNum-1]
+	})
+}
+
+type TranslationsBuilder struct {
+	t *WithTranslations
+}
+
+func MakeTranslationsBuilder(t *WithTranslations) TranslationsBuilder {
+	return TranslationsBuilder{t}
+}
+
+func (tb *TranslationsBuilder) AddTranslation(lang, text, translation string) appdef.ITranslationsBuilder {
+	tb.t.add(lang, text, translation)
+	return tb
+}
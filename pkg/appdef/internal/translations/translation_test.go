@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package translations_test
+
+import (
+	"testing"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/internal/translations"
+	"github.com/voedger/voedger/pkg/goutils/testingu/require"
+)
+
+func Test_Translations(t *testing.T) {
+	require := require.New(t)
+
+	tr := translations.MakeWithTranslations()
+
+	// should be appdef.IWithTranslations compatible
+	var _ appdef.IWithTranslations = tr
+
+	tb := translations.MakeTranslationsBuilder(&tr)
+
+	// should be appdef.ITranslationsBuilder compatible
+	var _ appdef.ITranslationsBuilder = &tb
+
+	t.Run("should be ok to add translations", func(t *testing.T) {
+		tb.AddTranslation("nl", "Hello", "Hallo").
+			AddTranslation("de", "Hello", "Hallo").
+			AddTranslation("nl", "Goodbye", "Tot ziens").
+			AddTranslation("nl", "Hello", "Hallo") // same translation again is ok
+	})
+
+	t.Run("should be ok to inspect translations", func(t *testing.T) {
+		require.Equal([]string{"de", "nl"}, tr.Languages())
+
+		s, ok := tr.Translation("nl", "Goodbye")
+		require.True(ok)
+		require.Equal("Tot ziens", s)
+
+		_, ok = tr.Translation("de", "Goodbye")
+		require.False(ok)
+
+		_, ok = tr.Translation("fr", "Hello")
+		require.False(ok)
+	})
+
+	t.Run("should be ok to translate formatted texts", func(t *testing.T) {
+		tb.AddTranslation("nl", "field %s is required", "veld %s is verplicht").
+			AddTranslation("nl", "%d of %d done, 100%% sure", "%[2]d waarvan %[1]d klaar, 100%% zeker").
+			AddTranslation("nl", "file %q: %v", "bestand %q")
+
+		tests := map[string]string{
+			"field Name is required":  "veld Name is verplicht",
+			"field  is required":      "veld  is verplicht",
+			"3 of 5 done, 100% sure":  "5 waarvan 3 klaar, 100% zeker",
+			`file "a.txt": not found`: `bestand "a.txt"`,
+			"field %s is required":    "veld %s is verplicht",
+		}
+		for text, expected := range tests {
+			s, ok := tr.Translation("nl", text)
+			require.True(ok, text)
+			require.Equal(expected, s, text)
+		}
+
+		for _, text := range []string{"field Name is optional", "the field Name is required", "3 of 5 done"} {
+			_, ok := tr.Translation("nl", text)
+			require.False(ok, text)
+		}
+
+		_, ok := tr.Translation("de", "field Name is required")
+		require.False(ok)
+	})
+
+	t.Run("should be panics", func(t *testing.T) {
+		require.Panics(func() { tb.AddTranslation("", "Hello", "Hallo") },
+			require.Is(appdef.ErrMissedError))
+		require.Panics(func() { tb.AddTranslation("nl", "", "Hallo") },
+			require.Is(appdef.ErrMissedError), require.Has("nl"))
+		require.Panics(func() { tb.AddTranslation("nl", "Hello", "Goedendag") },
+			require.Is(appdef.ErrAlreadyExistsError), require.Has("Hallo"))
+	})
+}
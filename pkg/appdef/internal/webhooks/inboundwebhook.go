@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/internal/comments"
+)
+
+// # Supports:
+//   - appdef.IInboundWebhook
+type InboundWebhook struct {
+	comments.WithComments
+	name        appdef.QName
+	ws          appdef.QName
+	cmd         appdef.QName
+	verify      appdef.InboundWebhookVerify
+	header      string
+	secret      string
+	replyStatus int
+}
+
+func (w InboundWebhook) Command() appdef.QName { return w.cmd }
+
+func (w InboundWebhook) Header() string { return w.header }
+
+func (w InboundWebhook) QName() appdef.QName { return w.name }
+
+func (w InboundWebhook) ReplyStatus() int { return w.replyStatus }
+
+func (w InboundWebhook) Secret() string { return w.secret }
+
+func (w InboundWebhook) Verify() appdef.InboundWebhookVerify { return w.verify }
+
+func (w InboundWebhook) Workspace() appdef.QName { return w.ws }
+
+func (w InboundWebhook) String() string {
+	return fmt.Sprintf("inbound webhook «%v»", w.name)
+}
+
+func (w *InboundWebhook) setReplyStatus(status int) {
+	if status < http.StatusOK || status >= http.StatusMultipleChoices || status == http.StatusNoContent {
+		panic(appdef.ErrUnsupported("%v reply status %d", w, status))
+	}
+	w.replyStatus = status
+}
+
+// # Supports:
+//   - appdef.IWithInboundWebhooks
+type WithInboundWebhooks struct {
+	byName map[appdef.QName]*InboundWebhook
+	list   []appdef.IInboundWebhook
+}
+
+func MakeWithInboundWebhooks() WithInboundWebhooks {
+	return WithInboundWebhooks{
+		byName: make(map[appdef.QName]*InboundWebhook),
+		list:   make([]appdef.IInboundWebhook, 0),
+	}
+}
+
+func (ww WithInboundWebhooks) InboundWebhook(name appdef.QName) appdef.IInboundWebhook {
+	if w, ok := ww.byName[name]; ok {
+		return w
+	}
+	return nil
+}
+
+func (ww WithInboundWebhooks) InboundWebhooks() []appdef.IInboundWebhook { return ww.list }
+
+func (ww *WithInboundWebhooks) add(name, ws, cmd appdef.QName, verify appdef.InboundWebhookVerify, header, secret string) *InboundWebhook {
+	if name == appdef.NullQName {
+		panic(appdef.ErrMissed("inbound webhook name"))
+	}
+	if ok, err := appdef.ValidQName(name); !ok {
+		panic(fmt.Errorf("invalid inbound webhook name «%v»: %w", name, err))
+	}
+	if _, ok := ww.byName[name]; ok {
+		panic(appdef.ErrAlreadyExists("inbound webhook «%v»", name))
+	}
+	if ws == appdef.NullQName {
+		panic(appdef.ErrMissed("inbound webhook «%v» workspace", name))
+	}
+	if cmd == appdef.NullQName {
+		panic(appdef.ErrMissed("inbound webhook «%v» command", name))
+	}
+	if verify == appdef.InboundWebhookVerify_null || verify >= appdef.InboundWebhookVerify_count {
+		panic(appdef.ErrOutOfBounds("inbound webhook «%v» verify kind %v", name, verify))
+	}
+	if header == "" {
+		panic(appdef.ErrMissed("inbound webhook «%v» header", name))
+	}
+	if secret == "" {
+		panic(appdef.ErrMissed("inbound webhook «%v» secret", name))
+	}
+	w := &InboundWebhook{
+		name:        name,
+		ws:          ws,
+		cmd:         cmd,
+		verify:      verify,
+		header:      header,
+		secret:      secret,
+		replyStatus: http.StatusOK,
+	}
+	ww.byName[name] = w
+	ww.list = append(ww.list, w)
+	slices.SortFunc(ww.list, func(a, b appdef.IInboundWebhook) int {
+		return appdef.CompareQName(a.QName(), b.QName())
+	})
+	return w
+}
+
+// # Supports:
+//   - appdef.IInboundWebhookBuilder
+type InboundWebhookBuilder struct {
+	comments.CommentBuilder
+	w *InboundWebhook
+}
+
+func (wb *InboundWebhookBuilder) SetReplyStatus(status int) appdef.IInboundWebhookBuilder {
+	wb.w.setReplyStatus(status)
+	return wb
+}
+
+// # Supports:
+//   - appdef.IInboundWebhooksBuilder
+type InboundWebhooksBuilder struct {
+	ww *WithInboundWebhooks
+}
+
+func MakeInboundWebhooksBuilder(ww *WithInboundWebhooks) InboundWebhooksBuilder {
+	return InboundWebhooksBuilder{ww}
+}
+
+func (wb *InboundWebhooksBuilder) AddInboundWebhook(name, ws, cmd appdef.QName, verify appdef.InboundWebhookVerify, header, secret string) appdef.IInboundWebhookBuilder {
+	w := wb.ww.add(name, ws, cmd, verify, header, secret)
+	return &InboundWebhookBuilder{
+		CommentBuilder: comments.MakeCommentBuilder(&w.WithComments),
+		w:              w,
+	}
+}
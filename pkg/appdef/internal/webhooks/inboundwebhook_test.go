@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/internal/webhooks"
+	"github.com/voedger/voedger/pkg/goutils/testingu/require"
+)
+
+func Test_InboundWebhooks(t *testing.T) {
+	require := require.New(t)
+
+	wsName := appdef.NewQName("test", "workspace")
+	cmdName := appdef.NewQName("test", "command")
+	whName := appdef.NewQName("test", "hook")
+	whName2 := appdef.NewQName("test", "aHook")
+
+	ww := webhooks.MakeWithInboundWebhooks()
+
+	// should be appdef.IWithInboundWebhooks compatible
+	var _ appdef.IWithInboundWebhooks = ww
+
+	wb := webhooks.MakeInboundWebhooksBuilder(&ww)
+
+	// should be appdef.IInboundWebhooksBuilder compatible
+	var _ appdef.IInboundWebhooksBuilder = &wb
+
+	t.Run("should be ok to add inbound webhooks", func(t *testing.T) {
+		b := wb.AddInboundWebhook(whName, wsName, cmdName, appdef.InboundWebhookVerify_HMAC, "X-Signature", "hook-secret")
+		b.SetComment("test inbound webhook")
+		b.SetReplyStatus(http.StatusAccepted)
+		wb.AddInboundWebhook(whName2, wsName, cmdName, appdef.InboundWebhookVerify_Token, "X-Token", "hook-secret")
+	})
+
+	t.Run("should be ok to inspect inbound webhooks", func(t *testing.T) {
+		require.Len(ww.InboundWebhooks(), 2)
+		require.Equal(whName2, ww.InboundWebhooks()[0].QName(), "should be sorted by name")
+
+		w := ww.InboundWebhook(whName)
+		require.NotNil(w)
+		require.Equal(wsName, w.Workspace())
+		require.Equal(cmdName, w.Command())
+		require.Equal(appdef.InboundWebhookVerify_HMAC, w.Verify())
+		require.Equal("X-Signature", w.Header())
+		require.Equal("hook-secret", w.Secret())
+		require.Equal(http.StatusAccepted, w.ReplyStatus())
+		require.Equal("test inbound webhook", w.Comment())
+
+		require.Equal(http.StatusOK, ww.InboundWebhook(whName2).ReplyStatus(), "should be 200 by default")
+		require.Nil(ww.InboundWebhook(appdef.NewQName("test", "unknown")))
+	})
+
+	t.Run("should be panics", func(t *testing.T) {
+		hmac := appdef.InboundWebhookVerify_HMAC
+		require.Panics(func() { wb.AddInboundWebhook(appdef.NullQName, wsName, cmdName, hmac, "X-Signature", "secret") },
+			require.Is(appdef.ErrMissedError))
+		require.Panics(func() { wb.AddInboundWebhook(whName, wsName, cmdName, hmac, "X-Signature", "secret") },
+			require.Is(appdef.ErrAlreadyExistsError), require.Has(whName))
+		newName := appdef.NewQName("test", "new")
+		require.Panics(func() { wb.AddInboundWebhook(newName, appdef.NullQName, cmdName, hmac, "X-Signature", "secret") },
+			require.Is(appdef.ErrMissedError), require.Has("workspace"))
+		require.Panics(func() { wb.AddInboundWebhook(newName, wsName, appdef.NullQName, hmac, "X-Signature", "secret") },
+			require.Is(appdef.ErrMissedError), require.Has("command"))
+		require.Panics(func() {
+			wb.AddInboundWebhook(newName, wsName, cmdName, appdef.InboundWebhookVerify_count, "X-Signature", "secret")
+		}, require.Is(appdef.ErrOutOfBoundsError))
+		require.Panics(func() { wb.AddInboundWebhook(newName, wsName, cmdName, hmac, "", "secret") },
+			require.Is(appdef.ErrMissedError), require.Has("header"))
+		require.Panics(func() { wb.AddInboundWebhook(newName, wsName, cmdName, hmac, "X-Signature", "") },
+			require.Is(appdef.ErrMissedError), require.Has("secret"))
+		w := wb.AddInboundWebhook(newName, wsName, cmdName, hmac, "X-Signature", "secret")
+		require.Panics(func() { w.SetReplyStatus(http.StatusNoContent) }, require.Is(appdef.ErrUnsupportedError))
+		require.Panics(func() { w.SetReplyStatus(http.StatusBadRequest) }, require.Is(appdef.ErrUnsupportedError))
+	})
+}
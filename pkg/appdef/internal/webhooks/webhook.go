@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/internal/comments"
+	"github.com/voedger/voedger/pkg/goutils/set"
+)
+
+// # Supports:
+//   - appdef.IWebhook
+type Webhook struct {
+	comments.WithComments
+	name   appdef.QName
+	ws     appdef.QName
+	url    string
+	secret string
+	events []appdef.IProjectorEvent
+}
+
+func (w Webhook) Events() []appdef.IProjectorEvent { return w.events }
+
+func (w Webhook) QName() appdef.QName { return w.name }
+
+func (w Webhook) Secret() string { return w.secret }
+
+func (w Webhook) Triggers(op appdef.OperationKind, t appdef.IType) bool {
+	for _, e := range w.events {
+		if e.Op(op) && e.Filter().Match(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Webhook) URL() string { return w.url }
+
+func (w Webhook) Workspace() appdef.QName { return w.ws }
+
+func (w Webhook) String() string {
+	return fmt.Sprintf("webhook «%v»", w.name)
+}
+
+func (w *Webhook) addEvent(ops []appdef.OperationKind, flt appdef.IFilter) {
+	if !appdef.ProjectorOperations.ContainsAll(ops...) {
+		panic(appdef.ErrUnsupported("%v operations %v", w, ops))
+	}
+	if flt == nil {
+		panic(appdef.ErrMissed("%v filter", w))
+	}
+	e := &event{
+		opSet: set.From(ops...),
+		flt:   flt,
+	}
+	e.ops = e.opSet.AsArray()
+	w.events = append(w.events, e)
+}
+
+// # Supports:
+//   - appdef.IProjectorEvent
+type event struct {
+	comments.WithComments
+	ops   []appdef.OperationKind
+	opSet set.Set[appdef.OperationKind]
+	flt   appdef.IFilter
+}
+
+func (e event) Filter() appdef.IFilter { return e.flt }
+
+func (e event) Op(o appdef.OperationKind) bool { return e.opSet.Contains(o) }
+
+func (e event) Ops() []appdef.OperationKind { return e.ops }
+
+// # Supports:
+//   - appdef.IWithWebhooks
+type WithWebhooks struct {
+	byName map[appdef.QName]*Webhook
+	list   []appdef.IWebhook
+}
+
+func MakeWithWebhooks() WithWebhooks {
+	return WithWebhooks{
+		byName: make(map[appdef.QName]*Webhook),
+		list:   make([]appdef.IWebhook, 0),
+	}
+}
+
+func (ww WithWebhooks) Webhook(name appdef.QName) appdef.IWebhook {
+	if w, ok := ww.byName[name]; ok {
+		return w
+	}
+	return nil
+}
+
+func (ww WithWebhooks) Webhooks() []appdef.IWebhook { return ww.list }
+
+func (ww *WithWebhooks) add(name, ws appdef.QName, url, secret string) *Webhook {
+	if name == appdef.NullQName {
+		panic(appdef.ErrMissed("webhook name"))
+	}
+	if ok, err := appdef.ValidQName(name); !ok {
+		panic(fmt.Errorf("invalid webhook name «%v»: %w", name, err))
+	}
+	if _, ok := ww.byName[name]; ok {
+		panic(appdef.ErrAlreadyExists("webhook «%v»", name))
+	}
+	if ws == appdef.NullQName {
+		panic(appdef.ErrMissed("webhook «%v» workspace", name))
+	}
+	if url == "" {
+		panic(appdef.ErrMissed("webhook «%v» URL", name))
+	}
+	if secret == "" {
+		panic(appdef.ErrMissed("webhook «%v» secret", name))
+	}
+	w := &Webhook{
+		name:   name,
+		ws:     ws,
+		url:    url,
+		secret: secret,
+		events: make([]appdef.IProjectorEvent, 0),
+	}
+	ww.byName[name] = w
+	ww.list = append(ww.list, w)
+	slices.SortFunc(ww.list, func(a, b appdef.IWebhook) int {
+		return appdef.CompareQName(a.QName(), b.QName())
+	})
+	return w
+}
+
+// # Supports:
+//   - appdef.IWebhookBuilder
+type WebhookBuilder struct {
+	comments.CommentBuilder
+	w *Webhook
+}
+
+func (wb *WebhookBuilder) AddEvent(ops []appdef.OperationKind, flt appdef.IFilter) appdef.IWebhookBuilder {
+	wb.w.addEvent(ops, flt)
+	return wb
+}
+
+// # Supports:
+//   - appdef.IWebhooksBuilder
+type WebhooksBuilder struct {
+	ww *WithWebhooks
+}
+
+func MakeWebhooksBuilder(ww *WithWebhooks) WebhooksBuilder {
+	return WebhooksBuilder{ww}
+}
+
+func (wb *WebhooksBuilder) AddWebhook(name, ws appdef.QName, url, secret string) appdef.IWebhookBuilder {
+	w := wb.ww.add(name, ws, url, secret)
+	return &WebhookBuilder{
+		CommentBuilder: comments.MakeCommentBuilder(&w.WithComments),
+		w:              w,
+	}
+}
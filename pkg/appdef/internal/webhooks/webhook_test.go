@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/filter"
+	"github.com/voedger/voedger/pkg/appdef/internal/webhooks"
+	"github.com/voedger/voedger/pkg/goutils/testingu/require"
+)
+
+func Test_Webhooks(t *testing.T) {
+	require := require.New(t)
+
+	wsName := appdef.NewQName("test", "workspace")
+	cmdName := appdef.NewQName("test", "command")
+	docName := appdef.NewQName("test", "doc")
+	whName := appdef.NewQName("test", "webhook")
+	whName2 := appdef.NewQName("test", "aWebhook")
+
+	ww := webhooks.MakeWithWebhooks()
+
+	// should be appdef.IWithWebhooks compatible
+	var _ appdef.IWithWebhooks = ww
+
+	wb := webhooks.MakeWebhooksBuilder(&ww)
+
+	// should be appdef.IWebhooksBuilder compatible
+	var _ appdef.IWebhooksBuilder = &wb
+
+	t.Run("should be ok to add webhooks", func(t *testing.T) {
+		b := wb.AddWebhook(whName, wsName, "https://example.com/hook", "hook-secret")
+		b.SetComment("test webhook")
+		b.AddEvent([]appdef.OperationKind{appdef.OperationKind_Execute}, filter.QNames(cmdName)).
+			AddEvent([]appdef.OperationKind{appdef.OperationKind_Insert, appdef.OperationKind_Update}, filter.QNames(docName))
+		wb.AddWebhook(whName2, wsName, "https://example.com/hook2", "hook-secret")
+	})
+
+	t.Run("should be ok to inspect webhooks", func(t *testing.T) {
+		require.Len(ww.Webhooks(), 2)
+		require.Equal(whName2, ww.Webhooks()[0].QName(), "should be sorted by name")
+
+		w := ww.Webhook(whName)
+		require.NotNil(w)
+		require.Equal(wsName, w.Workspace())
+		require.Equal("https://example.com/hook", w.URL())
+		require.Equal("hook-secret", w.Secret())
+		require.Equal("test webhook", w.Comment())
+		require.Len(w.Events(), 2)
+		require.Equal([]appdef.OperationKind{appdef.OperationKind_Insert, appdef.OperationKind_Update}, w.Events()[1].Ops())
+
+		require.Nil(ww.Webhook(appdef.NewQName("test", "unknown")))
+	})
+
+	t.Run("should be panics", func(t *testing.T) {
+		require.Panics(func() { wb.AddWebhook(appdef.NullQName, wsName, "https://example.com", "secret") },
+			require.Is(appdef.ErrMissedError))
+		require.Panics(func() { wb.AddWebhook(whName, wsName, "https://example.com", "secret") },
+			require.Is(appdef.ErrAlreadyExistsError), require.Has(whName))
+		newName := appdef.NewQName("test", "new")
+		require.Panics(func() { wb.AddWebhook(newName, appdef.NullQName, "https://example.com", "secret") },
+			require.Is(appdef.ErrMissedError), require.Has("workspace"))
+		require.Panics(func() { wb.AddWebhook(newName, wsName, "", "secret") },
+			require.Is(appdef.ErrMissedError), require.Has("URL"))
+		require.Panics(func() { wb.AddWebhook(newName, wsName, "https://example.com", "") },
+			require.Is(appdef.ErrMissedError), require.Has("secret"))
+		w := wb.AddWebhook(newName, wsName, "https://example.com", "secret")
+		require.Panics(func() { w.AddEvent([]appdef.OperationKind{appdef.OperationKind_Inherits}, filter.QNames(docName)) },
+			require.Is(appdef.ErrUnsupportedError))
+		require.Panics(func() { w.AddEvent([]appdef.OperationKind{appdef.OperationKind_Insert}, nil) },
+			require.Is(appdef.ErrMissedError))
+	})
+}
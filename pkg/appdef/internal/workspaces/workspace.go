@@ -208,6 +208,14 @@ func (ws *Workspace) addRate(name appdef.QName, count appdef.RateCount, period a
 	rates.NewRate(ws, name, count, period, scopes, comment...)
 }
 
+func (ws *Workspace) setRateBuckets(name appdef.QName, buckets appdef.RateBuckets) {
+	r, ok := appdef.Rate(ws.LocalType, name).(*rates.Rate)
+	if !ok {
+		panic(appdef.ErrNotFound("rate «%v»", name))
+	}
+	r.SetBuckets(buckets)
+}
+
 func (ws *Workspace) addRole(name appdef.QName) appdef.IRoleBuilder {
 	r := roles.NewRole(ws, name)
 	return roles.NewRoleBuilder(r)
@@ -436,6 +444,10 @@ func (wb *WorkspaceBuilder) AddRate(name appdef.QName, count appdef.RateCount, p
 	wb.ws.addRate(name, count, period, scopes, comment...)
 }
 
+func (wb *WorkspaceBuilder) SetRateBuckets(name appdef.QName, buckets appdef.RateBuckets) {
+	wb.ws.setRateBuckets(name, buckets)
+}
+
 func (wb *WorkspaceBuilder) AddRole(name appdef.QName) appdef.IRoleBuilder {
 	return wb.ws.addRole(name)
 }
@@ -17,12 +17,14 @@ func _() {
 	_ = x[ConstraintKind_MaxIncl-6]
 	_ = x[ConstraintKind_MaxExcl-7]
 	_ = x[ConstraintKind_Enum-8]
-	_ = x[ConstraintKind_count-9]
+	_ = x[ConstraintKind_Precision-9]
+	_ = x[ConstraintKind_Scale-10]
+	_ = x[ConstraintKind_count-11]
 }
 
-const _ConstraintKind_name = "ConstraintKind_nullConstraintKind_MinLenConstraintKind_MaxLenConstraintKind_PatternConstraintKind_MinInclConstraintKind_MinExclConstraintKind_MaxInclConstraintKind_MaxExclConstraintKind_EnumConstraintKind_count"
+const _ConstraintKind_name = "ConstraintKind_nullConstraintKind_MinLenConstraintKind_MaxLenConstraintKind_PatternConstraintKind_MinInclConstraintKind_MinExclConstraintKind_MaxInclConstraintKind_MaxExclConstraintKind_EnumConstraintKind_PrecisionConstraintKind_ScaleConstraintKind_count"
 
-var _ConstraintKind_index = [...]uint8{0, 19, 40, 61, 83, 105, 127, 149, 171, 190, 210}
+var _ConstraintKind_index = [...]uint8{0, 19, 40, 61, 83, 105, 127, 149, 171, 190, 214, 234, 254}
 
 func (i ConstraintKind) String() string {
 	if i >= ConstraintKind(len(_ConstraintKind_index)-1) {
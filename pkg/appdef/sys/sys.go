@@ -7,6 +7,7 @@ package sys
 
 import (
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/constraints"
 	"github.com/voedger/voedger/pkg/appdef/internal/datas"
 )
 
@@ -25,6 +26,12 @@ func makeSysWorkspace(adb appdef.IAppDefBuilder) {
 		_ = datas.NewSysData(ws, k)
 	}
 
+	// semantic sys data types over int64
+	_ = wsb.AddData(appdef.SysData_Timestamp, appdef.DataKind_int64, appdef.NullQName,
+		constraints.MinIncl(float64(appdef.MinTimestamp)), constraints.MaxIncl(float64(appdef.MaxTimestamp)))
+	_ = wsb.AddData(appdef.SysData_Currency, appdef.DataKind_int64, appdef.NullQName,
+		constraints.Precision(appdef.DefaultCurrencyPrecision), constraints.Scale(appdef.DefaultCurrencyScale))
+
 	// workspace descriptor
 	_ = wsb.AddCDoc(SysWSKind)
 	wsb.SetDescriptor(SysWSKind)
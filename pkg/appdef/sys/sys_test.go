@@ -56,6 +56,21 @@ func Test_SysPackage(t *testing.T) {
 			require.Equal(appdef.SysData_RecordID, appdef.Data(app.Type, appdef.SysData_RecordID).QName())
 			require.Equal(appdef.SysData_String, appdef.Data(app.Type, appdef.SysData_String).QName())
 			require.Equal(appdef.SysData_bytes, appdef.Data(app.Type, appdef.SysData_bytes).QName())
+
+			for _, n := range []appdef.QName{appdef.SysData_Timestamp, appdef.SysData_Currency} {
+				d := appdef.Data(app.Type, n)
+				require.NotNil(d, n)
+				require.Equal(appdef.DataKind_int64, d.DataKind())
+				require.True(appdef.DataInherits(d, appdef.SysData_int64))
+				require.False(appdef.DataInherits(d, appdef.SysData_int32))
+			}
+
+			ts := appdef.Data(app.Type, appdef.SysData_Timestamp)
+			require.EqualValues(appdef.MinTimestamp, ts.Constraints(false)[appdef.ConstraintKind_MinIncl].Value())
+			require.EqualValues(appdef.MaxTimestamp, ts.Constraints(false)[appdef.ConstraintKind_MaxIncl].Value())
+			cur := appdef.Data(app.Type, appdef.SysData_Currency)
+			require.EqualValues(appdef.DefaultCurrencyPrecision, cur.Constraints(false)[appdef.ConstraintKind_Precision].Value())
+			require.EqualValues(appdef.DefaultCurrencyScale, cur.Constraints(false)[appdef.ConstraintKind_Scale].Value())
 		})
 
 		t.Run("should be ok to read sys views", func(t *testing.T) {
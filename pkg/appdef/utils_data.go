@@ -21,6 +21,18 @@ func SysDataName(k DataKind) QName {
 	return NullQName
 }
 
+// Returns is data type is specified type or inherits from it.
+//
+// Returns false if data is nil.
+func DataInherits(data IData, name QName) bool {
+	for d := data; d != nil; d = d.Ancestor() {
+		if d.QName() == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Returns is fixed width data kind
 func (k DataKind) IsFixed() bool {
 	switch k {
@@ -58,6 +70,10 @@ func (k DataKind) IsFixed() bool {
 //   - ConstraintKind_MaxIncl
 //   - ConstraintKind_MaxExcl
 //   - ConstraintKind_Enum
+//
+// # Int64 data additionally supports:
+//   - ConstraintKind_Precision
+//   - ConstraintKind_Scale
 func (k DataKind) IsCompatibleWithConstraint(c ConstraintKind) bool {
 	switch k {
 	case DataKind_bytes:
@@ -87,6 +103,10 @@ func (k DataKind) IsCompatibleWithConstraint(c ConstraintKind) bool {
 			ConstraintKind_MaxExcl,
 			ConstraintKind_Enum:
 			return true
+		case
+			ConstraintKind_Precision,
+			ConstraintKind_Scale:
+			return k == DataKind_int64
 		}
 	}
 	return false
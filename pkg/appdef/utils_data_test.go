@@ -134,6 +134,8 @@ func TestDataKind_IsSupportedConstraint(t *testing.T) {
 		{"int64: MaxIncl", appdef.DataKind_int64, args{appdef.ConstraintKind_MaxIncl}, true},
 		{"int64: MaxExcl", appdef.DataKind_int64, args{appdef.ConstraintKind_MaxExcl}, true},
 		{"int64: Enum", appdef.DataKind_int64, args{appdef.ConstraintKind_Enum}, true},
+		{"int64: Precision", appdef.DataKind_int64, args{appdef.ConstraintKind_Precision}, true},
+		{"int64: Scale", appdef.DataKind_int64, args{appdef.ConstraintKind_Scale}, true},
 		//-
 		{"float32: appdef.MinLen", appdef.DataKind_float32, args{appdef.ConstraintKind_MinLen}, false},
 		{"float32: appdef.MaxLen", appdef.DataKind_float32, args{appdef.ConstraintKind_MaxLen}, false},
@@ -152,6 +154,7 @@ func TestDataKind_IsSupportedConstraint(t *testing.T) {
 		{"float64: MaxIncl", appdef.DataKind_float64, args{appdef.ConstraintKind_MaxIncl}, true},
 		{"float64: MaxExcl", appdef.DataKind_float64, args{appdef.ConstraintKind_MaxExcl}, true},
 		{"float64: Enum", appdef.DataKind_float64, args{appdef.ConstraintKind_Enum}, true},
+		{"float64: Precision", appdef.DataKind_float64, args{appdef.ConstraintKind_Precision}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package appdef
+
+import "strings"
+
+// Returns name of the application secret that holds the webhook URL.
+//
+// Returns false if the URL is not a `{{secret:name}}` placeholder.
+func WebhookURLSecret(url string) (secret string, ok bool) {
+	const pref, suff = "{{secret:", "}}"
+	if !strings.HasPrefix(url, pref) || !strings.HasSuffix(url, suff) {
+		return "", false
+	}
+	secret = url[len(pref) : len(url)-len(suff)]
+	if secret == "" || strings.ContainsAny(secret, "{}") {
+		return "", false
+	}
+	return secret, true
+}
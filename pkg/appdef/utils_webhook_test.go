@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package appdef_test
+
+import (
+	"testing"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/testingu/require"
+)
+
+func TestWebhookURLSecret(t *testing.T) {
+	tests := []struct {
+		url    string
+		secret string
+		ok     bool
+	}{
+		{"{{secret:hook-url}}", "hook-url", true},
+		{"https://example.com/hook", "", false},
+		{"{{secret:}}", "", false},
+		{"{{secret:a}}{{secret:b}}", "", false},
+		{"https://{{secret:host}}/hook", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			require := require.New(t)
+			secret, ok := appdef.WebhookURLSecret(tt.url)
+			require.Equal(tt.ok, ok)
+			require.Equal(tt.secret, secret)
+		})
+	}
+}
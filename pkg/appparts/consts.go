@@ -32,3 +32,6 @@ var NullActualizerRunner nullActualizerRunner = nullActualizerRunner{}
 
 // NullSchedulerRunner should be used in test only
 var NullSchedulerRunner nullSchedulerRunner = nullSchedulerRunner{}
+
+// NullCompatibilityChecker accepts any new application definition. Should be used in test only
+var NullCompatibilityChecker CompatibilityChecker = func(appdef.IAppDef, appdef.IAppDef) error { return nil }
@@ -19,6 +19,14 @@ var ErrNotFound = errors.New("not found")
 // validation or extension-engine initialization error.
 var ErrDeployment = errors.New("deployment error")
 
+// ErrIncompatible is returned when application can not be redeployed because
+// new definition is not backward compatible with the current one.
+var ErrIncompatible = errors.New("incompatible application definition")
+
+func errAppIncompatible(app appdef.AppQName, err error) error {
+	return fmt.Errorf("%w: app %v: %w", ErrIncompatible, app, err)
+}
+
 func errExtensionInVSQLNotInCode(app appdef.AppQName, ext appdef.IExtension, fqn appdef.FullQName) error {
 	return fmt.Errorf("%w: app %v: %s %v (%v): in vsql, not in code", ErrDeployment, app, ext.Kind().TrimString(), ext.QName(), fqn)
 }
@@ -31,6 +39,14 @@ func errExtensionUnknownPackage(app appdef.AppQName, ext appdef.IExtension) erro
 	return fmt.Errorf("%w: app %v: %s %v: package «%s» full path is unknown", ErrDeployment, app, ext.Kind().TrimString(), ext.QName(), ext.QName().Pkg())
 }
 
+func errExtensionModuleURLMissed(app appdef.AppQName, path string) error {
+	return fmt.Errorf("%w: app %v: module path %s is missing among extension modules URLs", ErrDeployment, app, path)
+}
+
+func errExtensionEngineFactoryMissed(app appdef.AppQName, kind appdef.ExtensionEngineKind) error {
+	return fmt.Errorf("%w: app %v: no extension engine factory for engine %s", ErrDeployment, app, kind)
+}
+
 func errExtensionEngineDeploy(app appdef.AppQName, kind appdef.ExtensionEngineKind, err error) error {
 	return fmt.Errorf("%w: app %v: extension engine %s: %w", ErrDeployment, app, kind, err)
 }
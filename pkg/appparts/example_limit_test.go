@@ -79,6 +79,7 @@ func ExampleIAppPartition_IsLimitExceeded() {
 		appparts.NullSchedulerRunner,
 		appparts.NullExtensionEngineFactories,
 		iratesce.TestBucketsFactory,
+		appparts.NullCompatibilityChecker,
 	)
 	if err != nil {
 		panic(err)
@@ -109,6 +110,9 @@ func ExampleIAppPartition_IsLimitExceeded() {
 
 	fmt.Println(partition.IsLimitExceeded(cmd2Name, appdef.OperationKind_Execute, 1, `addr1`)) // Exceeded WS
 
+	// Check time to wait for the next token
+	fmt.Println(partition.LimitRetryAfter(appdef.NewQName("test", "wsLimit"), cmd2Name, 1, `addr1`).Round(time.Second))
+
 	testingu.MockTime.Add(time.Minute) // Wait for the next minute
 
 	// check limits is respawned
@@ -129,6 +133,7 @@ func ExampleIAppPartition_IsLimitExceeded() {
 	// false .
 	// false .
 	// true test.wsLimit
+	// 12s
 	// false .
 	// false .
 	// false .
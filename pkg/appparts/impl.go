@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -32,8 +33,10 @@ type apps struct {
 	schedulerRunner        ISchedulerRunner
 	extEngineFactories     iextengine.ExtensionEngineFactories
 	bucketsFactory         irates.BucketsFactoryType
+	compat                 CompatibilityChecker
 	apps                   map[appdef.AppQName]*appRT
 	partBorrowRetryCfg     retrier.Config
+	partDeployments        atomic.Uint64
 }
 
 func newAppPartitions(
@@ -44,6 +47,7 @@ func newAppPartitions(
 	jobSchedulerRunner ISchedulerRunner,
 	eef iextengine.ExtensionEngineFactories,
 	bf irates.BucketsFactoryType,
+	compat CompatibilityChecker,
 ) (ap IAppPartitions, cleanup func(), err error) {
 	a := &apps{
 		mx:                     sync.RWMutex{},
@@ -54,6 +58,7 @@ func newAppPartitions(
 		syncActualizerFactory:  saf,
 		extEngineFactories:     eef,
 		bucketsFactory:         bf,
+		compat:                 compat,
 		apps:                   map[appdef.AppQName]*appRT{},
 		partBorrowRetryCfg:     retrier.NewConfig(AppPartitionBorrowRetryDelay, AppPartitionBorrowRetryDelay),
 	}
@@ -96,7 +101,7 @@ func (aps *apps) AppDef(name appdef.AppQName) (appdef.IAppDef, error) {
 	if !ok {
 		return nil, errAppNotFound(name)
 	}
-	return app.lastestVersion.appDef(), nil
+	return app.appDef(), nil
 }
 
 // Returns _total_ application partitions count.
@@ -132,6 +137,10 @@ func (aps *apps) Borrow(name appdef.AppQName, id istructs.PartitionID, proc Proc
 
 	app.mx.RLock()
 	part, ok := app.parts[id]
+	if ok {
+		// should be marked under lock, see UndeployAppPartitions
+		part.borrowed.Add(1)
+	}
 	app.mx.RUnlock()
 
 	if !ok {
@@ -157,23 +166,22 @@ func (aps *apps) DeployApp(name appdef.AppQName, extModuleURLs map[string]*url.U
 	}
 
 	a := newApplication(aps, name, partsCount)
-	aps.mx.Lock()
-	aps.apps[name] = a
-	aps.mx.Unlock()
 
-	var appStructs istructs.IAppStructs
-	var err error
-	if len(extModuleURLs) != 0 {
-		// TODO: is sidecarapp criteria?
+	appStructs, err := aps.structs.BuiltIn(name)
+	if errors.Is(err, istructs.ErrAppNotFound) {
+		// not builtin app, e.g. sidecar app or app deployed from image
 		appStructs, err = aps.structs.New(name, def, istructs.ClusterApps[name], numAppWorkspaces)
-	} else {
-		appStructs, err = aps.structs.BuiltIn(name)
 	}
 	if err != nil {
 		panic(err)
 	}
 
 	a.deploy(def, extModuleURLs, appStructs, engines)
+
+	// the app is available after its version is deployed only
+	aps.mx.Lock()
+	aps.apps[name] = a
+	aps.mx.Unlock()
 }
 
 func (aps *apps) DeployAppPartitions(name appdef.AppQName, partitionIDs []istructs.PartitionID) {
@@ -202,7 +210,7 @@ func (aps *apps) DeployAppPartitions(name appdef.AppQName, partitionIDs []istruc
 		go func(p *appPartitionRT) {
 			p.actualizers.Deploy(
 				aps.vvmCtx,
-				a.lastestVersion.appDef(),
+				a.appDef(),
 				aps.asyncActualizersRunner.NewAndRun,
 			)
 			wg.Done()
@@ -212,7 +220,7 @@ func (aps *apps) DeployAppPartitions(name appdef.AppQName, partitionIDs []istruc
 		go func(p *appPartitionRT) {
 			p.schedulers.Deploy(
 				aps.vvmCtx,
-				a.lastestVersion.appDef(),
+				a.appDef(),
 				aps.schedulerRunner.NewAndRun,
 			)
 			wg.Done()
@@ -221,16 +229,86 @@ func (aps *apps) DeployAppPartitions(name appdef.AppQName, partitionIDs []istruc
 	wg.Wait()
 }
 
-func (aps *apps) UpgradeAppDef(name appdef.AppQName, def appdef.IAppDef) {
+func (aps *apps) UndeployAppPartitions(name appdef.AppQName, partitionIDs []istructs.PartitionID) {
 	aps.mx.RLock()
-	app, ok := aps.apps[name]
+	a, ok := aps.apps[name]
 	aps.mx.RUnlock()
 
 	if !ok {
 		panic(errAppNotFound(name))
 	}
 
-	app.lastestVersion.upgrade(def, app.lastestVersion.appStructs(), app.lastestVersion.pools)
+	parts := make([]*appPartitionRT, 0, len(partitionIDs))
+	a.mx.Lock()
+	for _, partitionID := range partitionIDs {
+		if p, ok := a.parts[partitionID]; ok {
+			parts = append(parts, p)
+			delete(a.parts, partitionID)
+		}
+	}
+	a.mx.Unlock()
+
+	wg := sync.WaitGroup{}
+	for _, p := range parts {
+		wg.Add(1)
+		go func(p *appPartitionRT) {
+			defer wg.Done()
+			p.actualizers.Stop()
+			p.schedulers.Stop()
+			p.borrowed.Wait()
+		}(p)
+	}
+	wg.Wait()
+}
+
+func (aps *apps) RedeployApp(name appdef.AppQName, extModuleURLs map[string]*url.URL, def appdef.IAppDef, engines [ProcessorKind_Count]uint) error {
+	aps.mx.RLock()
+	a, ok := aps.apps[name]
+	aps.mx.RUnlock()
+
+	if !ok {
+		return errAppNotFound(name)
+	}
+
+	a.redeployMx.Lock()
+	defer a.redeployMx.Unlock()
+
+	prev := a.version()
+	if err := aps.compat(prev.def, def); err != nil {
+		return errAppIncompatible(name, err)
+	}
+
+	// application structures are created on version switch, see below
+	v, err := newAppVersion(a, def, extModuleURLs, nil, engines)
+	if err != nil {
+		return err
+	}
+
+	parts, err := a.redeploy(v, func() (istructs.IAppStructs, error) {
+		return aps.structs.New(name, def, istructs.ClusterApps[name], prev.structs.NumAppWorkspaces())
+	})
+	if err != nil {
+		v.close(aps.vvmCtx)
+		return err
+	}
+
+	wg := sync.WaitGroup{}
+	for _, p := range parts {
+		wg.Add(1)
+		go func(p *appPartitionRT) {
+			p.actualizers.Deploy(aps.vvmCtx, def, aps.asyncActualizersRunner.NewAndRun)
+			wg.Done()
+		}(p)
+
+		wg.Add(1)
+		go func(p *appPartitionRT) {
+			p.schedulers.Deploy(aps.vvmCtx, def, aps.schedulerRunner.NewAndRun)
+			wg.Done()
+		}(p)
+	}
+	wg.Wait()
+
+	return nil
 }
 
 func (aps *apps) WaitForBorrow(ctx context.Context, name appdef.AppQName, id istructs.PartitionID, proc ProcessorKind) (IAppPartition, error) {
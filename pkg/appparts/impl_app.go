@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appdef/acl"
@@ -19,78 +20,32 @@ import (
 	"github.com/voedger/voedger/pkg/appparts/internal/pool"
 	"github.com/voedger/voedger/pkg/appparts/internal/schedulers"
 	"github.com/voedger/voedger/pkg/iextengine"
+	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/pipeline"
 )
 
 type engines map[appdef.ExtensionEngineKind]iextengine.IExtensionEngine
 
+// Application version.
+//
+// Version is not changed after creation. Application redeployment creates a new version.
+// Borrowed partitions keep working with the version which was current at borrow time,
+// the version engines are closed when the last borrowed partition is released.
 type appVersion struct {
-	mx      sync.RWMutex
-	def     appdef.IAppDef
-	structs istructs.IAppStructs
-	pools   [ProcessorKind_Count]*pool.Pool[engines]
-}
-
-func (av *appVersion) appDef() appdef.IAppDef {
-	av.mx.RLock()
-	defer av.mx.RUnlock()
-	return av.def
-}
-
-func (av *appVersion) appStructs() istructs.IAppStructs {
-	av.mx.RLock()
-	defer av.mx.RUnlock()
-	return av.structs
-}
-
-// returns AppDef, AppStructs and engines pool for the specified processor kind
-func (av *appVersion) snapshot(proc ProcessorKind) (appdef.IAppDef, istructs.IAppStructs, *pool.Pool[engines]) {
-	av.mx.RLock()
-	defer av.mx.RUnlock()
-	return av.def, av.structs, av.pools[proc]
-}
-
-func (av *appVersion) upgrade(
-	def appdef.IAppDef,
-	structs istructs.IAppStructs,
-	pools [ProcessorKind_Count]*pool.Pool[engines],
-) {
-	av.mx.Lock()
-	defer av.mx.Unlock()
-
-	av.def = def
-	av.structs = structs
-	av.pools = pools
-}
-
-type appRT struct {
-	mx             sync.RWMutex
-	apps           *apps
-	name           appdef.AppQName
-	partsCount     istructs.NumAppPartitions
-	lastestVersion appVersion
-	parts          map[istructs.PartitionID]*appPartitionRT
-}
-
-func newApplication(apps *apps, name appdef.AppQName, partsCount istructs.NumAppPartitions) *appRT {
-	return &appRT{
-		mx:             sync.RWMutex{},
-		apps:           apps,
-		name:           name,
-		partsCount:     partsCount,
-		lastestVersion: appVersion{},
-		parts:          map[istructs.PartitionID]*appPartitionRT{},
-	}
+	def      appdef.IAppDef
+	structs  istructs.IAppStructs
+	pools    [ProcessorKind_Count]*pool.Pool[engines]
+	borrowed sync.WaitGroup
 }
 
 // extModuleURLs is important for non-builtin (non-native) apps
 // extModuleURLs: packagePath->packageURL
-func (a *appRT) deploy(def appdef.IAppDef, extModuleURLs map[string]*url.URL, structs istructs.IAppStructs, numEnginesPerEngineKind [ProcessorKind_Count]uint) {
+func newAppVersion(a *appRT, def appdef.IAppDef, extModuleURLs map[string]*url.URL, structs istructs.IAppStructs, numEnginesPerEngineKind [ProcessorKind_Count]uint) (*appVersion, error) {
 	eef := a.apps.extEngineFactories
 
 	if err := a.validateExtensions(def, eef); err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	enginesPathsModules := map[appdef.ExtensionEngineKind]map[string]*iextengine.ExtensionModule{}
@@ -110,7 +65,7 @@ func (a *appRT) deploy(def appdef.IAppDef, extModuleURLs map[string]*url.URL, st
 		if !ok {
 			moduleURL, ok := extModuleURLs[path]
 			if !ok {
-				panic(fmt.Sprintf("module path %s is missing among extension modules URLs", path))
+				return nil, errExtensionModuleURLMissed(a.name, path)
 			}
 			extModule = &iextengine.ExtensionModule{
 				Path:      path,
@@ -128,18 +83,20 @@ func (a *appRT) deploy(def appdef.IAppDef, extModuleURLs map[string]*url.URL, st
 		}
 	}
 
-	pools := [ProcessorKind_Count]*pool.Pool[engines]{}
+	v := &appVersion{def: def, structs: structs}
 	// processorKind here is one of ProcessorKind_Command, ProcessorKind_Query, ProcessorKind_Actualizer, ProcessorKind_Scheduler
 	for processorKind, processorsCountPerKind := range numEnginesPerEngineKind {
 		ee := make([]engines, processorsCountPerKind)
 		for extEngineKind, extensionModules := range extModules {
 			extensionEngineFactory, ok := eef[extEngineKind]
 			if !ok {
-				panic(fmt.Errorf("no extension engine factory for engine %s met among def of %s", extEngineKind.String(), a.name))
+				v.close(a.apps.vvmCtx)
+				return nil, errExtensionEngineFactoryMissed(a.name, extEngineKind)
 			}
 			extEngines, err := extensionEngineFactory.New(a.apps.vvmCtx, a.name, extensionModules, &iextengine.DefaultExtEngineConfig, processorsCountPerKind)
 			if err != nil {
-				panic(errExtensionEngineDeploy(a.name, extEngineKind, err))
+				v.close(a.apps.vvmCtx)
+				return nil, errExtensionEngineDeploy(a.name, extEngineKind, err)
 			}
 			for i := uint(0); i < processorsCountPerKind; i++ {
 				if ee[i] == nil {
@@ -148,10 +105,121 @@ func (a *appRT) deploy(def appdef.IAppDef, extModuleURLs map[string]*url.URL, st
 				ee[i][extEngineKind] = extEngines[i]
 			}
 		}
-		pools[processorKind] = pool.New(ee)
+		v.pools[processorKind] = pool.New(ee)
 	}
 
-	a.lastestVersion.upgrade(def, structs, pools)
+	return v, nil
+}
+
+// closes all engines of the version.
+//
+// Should be called when no partitions borrowed with this version
+func (v *appVersion) close(ctx context.Context) {
+	for _, p := range v.pools {
+		if p == nil {
+			continue
+		}
+		for {
+			ee, err := p.Borrow()
+			if err != nil {
+				break
+			}
+			for _, e := range ee {
+				e.Close(ctx)
+			}
+		}
+	}
+}
+
+// closes the version engines after all partitions borrowed with this version are released
+func (v *appVersion) retire(ctx context.Context) {
+	go func() {
+		v.borrowed.Wait()
+		v.close(ctx)
+	}()
+}
+
+type appRT struct {
+	mx             sync.RWMutex
+	redeployMx     sync.Mutex
+	apps           *apps
+	name           appdef.AppQName
+	partsCount     istructs.NumAppPartitions
+	versionMx      sync.RWMutex
+	lastestVersion *appVersion
+	parts          map[istructs.PartitionID]*appPartitionRT
+}
+
+func newApplication(apps *apps, name appdef.AppQName, partsCount istructs.NumAppPartitions) *appRT {
+	return &appRT{
+		mx:         sync.RWMutex{},
+		apps:       apps,
+		name:       name,
+		partsCount: partsCount,
+		versionMx:  sync.RWMutex{},
+		parts:      map[istructs.PartitionID]*appPartitionRT{},
+	}
+}
+
+// returns the current application version
+func (a *appRT) version() *appVersion {
+	a.versionMx.RLock()
+	defer a.versionMx.RUnlock()
+	return a.lastestVersion
+}
+
+// returns AppDef of the current application version
+func (a *appRT) appDef() appdef.IAppDef {
+	return a.version().def
+}
+
+// extModuleURLs is important for non-builtin (non-native) apps
+// extModuleURLs: packagePath->packageURL
+func (a *appRT) deploy(def appdef.IAppDef, extModuleURLs map[string]*url.URL, structs istructs.IAppStructs, numEnginesPerEngineKind [ProcessorKind_Count]uint) {
+	v, err := newAppVersion(a, def, extModuleURLs, structs, numEnginesPerEngineKind)
+	if err != nil {
+		panic(err)
+	}
+
+	a.versionMx.Lock()
+	a.lastestVersion = v
+	a.versionMx.Unlock()
+}
+
+// Makes the specified version current for the application and all its deployed partitions.
+//
+// Application structures of the version are created by specified func under partitions lock,
+// so new borrows get the new structures and the new definition at once.
+//
+// Partitions borrowed with the previous version keep working with it until released.
+// Returns deployed partitions.
+func (a *appRT) redeploy(v *appVersion, structs func() (istructs.IAppStructs, error)) ([]*appPartitionRT, error) {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	var err error
+	if v.structs, err = structs(); err != nil {
+		return nil, err
+	}
+
+	parts := make([]*appPartitionRT, 0, len(a.parts))
+	partVersions := make([]*partitionVersion, 0, len(a.parts))
+	for _, p := range a.parts {
+		parts = append(parts, p)
+		partVersions = append(partVersions, newPartitionVersion(p, v))
+	}
+
+	a.versionMx.Lock()
+	prev := a.lastestVersion
+	a.lastestVersion = v
+	for i, p := range parts {
+		p.lastestVersion = partVersions[i]
+	}
+	a.versionMx.Unlock()
+
+	prev.retire(a.apps.vvmCtx)
+
+	return parts, nil
 }
 
 // builtInFuncsRegistry is implemented by the BuiltIn extension engine factory and
@@ -221,29 +289,58 @@ func (a *appRT) validateExtensions(def appdef.IAppDef, eef iextengine.ExtensionE
 	return errors.Join(errs...)
 }
 
+// Application partition version: sync actualizer and rate limiter built for the application version
+type partitionVersion struct {
+	syncActualizer pipeline.ISyncOperator
+	limiter        *limiter.Limiter
+}
+
+func newPartitionVersion(p *appPartitionRT, v *appVersion) *partitionVersion {
+	return &partitionVersion{
+		syncActualizer: p.app.apps.syncActualizerFactory(v.structs, p.id),
+		limiter:        limiter.New(v.def, p.id, p.buckets),
+	}
+}
+
 type appPartitionRT struct {
 	app            *appRT
 	id             istructs.PartitionID
-	syncActualizer pipeline.ISyncOperator
+	deploymentID   uint64
+	borrowed       sync.WaitGroup // borrowed partitions, should be released before undeploy finished
 	actualizers    *actualizers.PartitionActualizers
 	schedulers     *schedulers.PartitionSchedulers
-	limiter        *limiter.Limiter
+	buckets        map[appdef.RateBuckets]irates.IBuckets
+	lastestVersion *partitionVersion // guarded by app.versionMx
 }
 
 func newAppPartitionRT(app *appRT, id istructs.PartitionID) *appPartitionRT {
-	as := app.lastestVersion.appStructs()
-	buckets := app.apps.bucketsFactory()
+	v := app.version()
 	part := &appPartitionRT{
-		app:            app,
-		id:             id,
-		syncActualizer: app.apps.syncActualizerFactory(as, id),
-		actualizers:    actualizers.New(app.name, id),
-		schedulers:     schedulers.New(app.name, app.partsCount, as.NumAppWorkspaces(), id),
-		limiter:        limiter.New(app.lastestVersion.appDef(), buckets),
+		app:          app,
+		id:           id,
+		deploymentID: app.apps.partDeployments.Add(1),
+		actualizers:  actualizers.New(app.name, id),
+		schedulers:   schedulers.New(app.name, app.partsCount, v.structs.NumAppWorkspaces(), id),
+		buckets:      make(map[appdef.RateBuckets]irates.IBuckets, appdef.RateBuckets_count-1),
+	}
+	for kind := appdef.RateBuckets_null + 1; kind < appdef.RateBuckets_count; kind++ {
+		part.buckets[kind] = app.apps.bucketsFactory(kind, v.structs.AppTTLStorage())
 	}
+	part.lastestVersion = newPartitionVersion(part, v)
 	return part
 }
 
+// returns the current application version and partition version.
+//
+// Returned application version is marked as borrowed, it should be released by calling v.borrowed.Done()
+func (p *appPartitionRT) snapshot() (*appVersion, *partitionVersion) {
+	p.app.versionMx.RLock()
+	defer p.app.versionMx.RUnlock()
+	v := p.app.lastestVersion
+	v.borrowed.Add(1)
+	return v, p.lastestVersion
+}
+
 func (p *appPartitionRT) borrow(proc ProcessorKind) (*borrowedPartition, error) {
 	b := newBorrowedPartition(p)
 
@@ -258,12 +355,14 @@ func (p *appPartitionRT) borrow(proc ProcessorKind) (*borrowedPartition, error)
 // # Supports:
 //   - IAppPartition
 type borrowedPartition struct {
-	part       *appPartitionRT
-	appDef     appdef.IAppDef
-	appStructs istructs.IAppStructs
-	pool       *pool.Pool[engines] // pool of borrowed engines
-	kind       ProcessorKind
-	engines    engines // borrowed engines
+	part        *appPartitionRT
+	version     *appVersion       // borrowed application version
+	partVersion *partitionVersion // borrowed partition version
+	appDef      appdef.IAppDef
+	appStructs  istructs.IAppStructs
+	pool        *pool.Pool[engines] // pool of borrowed engines
+	kind        ProcessorKind
+	engines     engines // borrowed engines
 }
 
 var borrowedPartitionsPool = sync.Pool{
@@ -286,12 +385,15 @@ func (bp *borrowedPartition) AppStructs() istructs.IAppStructs { return bp.appSt
 
 // # IAppPartition.DoSyncActualizer
 func (bp *borrowedPartition) DoSyncActualizer(ctx context.Context, work pipeline.IWorkpiece) error {
-	return bp.part.syncActualizer.DoSync(ctx, work)
+	return bp.partVersion.syncActualizer.DoSync(ctx, work)
 }
 
 // # IAppPartition.ID
 func (bp *borrowedPartition) ID() istructs.PartitionID { return bp.part.id }
 
+// # IAppPartition.DeploymentID
+func (bp *borrowedPartition) DeploymentID() uint64 { return bp.part.deploymentID }
+
 // # IAppPartition.Invoke
 func (bp *borrowedPartition) Invoke(ctx context.Context, name appdef.QName, state istructs.IState, intents istructs.IIntents) error {
 	e := appdef.Extension(bp.appDef.Type, name)
@@ -318,11 +420,15 @@ func (bp *borrowedPartition) Invoke(ctx context.Context, name appdef.QName, stat
 }
 
 func (bp *borrowedPartition) IsLimitExceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (bool, appdef.QName) {
-	return bp.part.limiter.Exceeded(resource, operation, workspace, remoteAddr)
+	return bp.partVersion.limiter.Exceeded(resource, operation, workspace, remoteAddr)
+}
+
+func (bp *borrowedPartition) LimitRetryAfter(limit appdef.QName, resource appdef.QName, workspace istructs.WSID, remoteAddr string) time.Duration {
+	return bp.partVersion.limiter.RetryAfter(limit, resource, workspace, remoteAddr)
 }
 
 func (bp *borrowedPartition) ResetRateLimit(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) {
-	bp.part.limiter.ResetLimits(resource, operation, workspace, remoteAddr)
+	bp.partVersion.limiter.ResetLimits(resource, operation, workspace, remoteAddr)
 }
 
 func (bp *borrowedPartition) IsOperationAllowed(ws appdef.IWorkspace, op appdef.OperationKind, res appdef.QName, fld []appdef.FieldName, roles []appdef.QName) (bool, error) {
@@ -335,7 +441,11 @@ func (bp *borrowedPartition) String() string {
 
 // # IAppPartition.Release
 func (bp *borrowedPartition) Release() {
+	if p := bp.part; p != nil {
+		defer p.borrowed.Done()
+	}
 	bp.part = nil
+	bp.partVersion = nil
 	bp.appDef = nil
 	bp.appStructs = nil
 	if pool := bp.pool; pool != nil {
@@ -345,12 +455,17 @@ func (bp *borrowedPartition) Release() {
 			pool.Release(engine)
 		}
 	}
+	if v := bp.version; v != nil {
+		bp.version = nil
+		v.borrowed.Done()
+	}
 	borrowedPartitionsPool.Put(bp)
 }
 
 func (bp *borrowedPartition) borrow(proc ProcessorKind) (err error) {
 	bp.kind = proc
-	bp.appDef, bp.appStructs, bp.pool = bp.part.app.lastestVersion.snapshot(proc)
+	bp.version, bp.partVersion = bp.part.snapshot()
+	bp.appDef, bp.appStructs, bp.pool = bp.version.def, bp.version.structs, bp.version.pools[proc]
 	bp.engines, err = bp.pool.Borrow()
 	if err != nil {
 		return errNotAvailableEngines[proc]
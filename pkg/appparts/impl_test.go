@@ -18,6 +18,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appdef/builder"
 	"github.com/voedger/voedger/pkg/appdef/filter"
+	"github.com/voedger/voedger/pkg/appdefcompat"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/appparts/internal/schedulers"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
@@ -25,7 +26,9 @@ import (
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/iextengine"
 	iextenginebuiltin "github.com/voedger/voedger/pkg/iextengine/builtin"
+	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/iratesstg"
 	"github.com/voedger/voedger/pkg/isequencer"
 	"github.com/voedger/voedger/pkg/istorage/mem"
 	"github.com/voedger/voedger/pkg/istorage/provider"
@@ -33,6 +36,7 @@ import (
 	"github.com/voedger/voedger/pkg/istructsmem"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 	"github.com/voedger/voedger/pkg/itokensjwt"
+	"github.com/voedger/voedger/pkg/vvm/storage"
 )
 
 type mockRunner struct {
@@ -154,7 +158,13 @@ func Test_DeployActualizersAndSchedulers(t *testing.T) {
 		mockActualizers,
 		mockSchedulers,
 		appparts.NullExtensionEngineFactories,
-		iratesce.TestBucketsFactory)
+		iratesce.TestBucketsFactory,
+		func(old, new appdef.IAppDef) error {
+			if cerrs := appdefcompat.CheckBackwardCompatibility(old, new); cerrs != nil && len(cerrs.Errors) > 0 {
+				return cerrs
+			}
+			return nil
+		})
 	require.NoError(err)
 
 	defer cleanupParts()
@@ -202,7 +212,7 @@ func Test_DeployActualizersAndSchedulers(t *testing.T) {
 		}
 	})
 
-	t.Run("redeploy odd partitions", func(t *testing.T) {
+	t.Run("redeploy app", func(t *testing.T) {
 		prj2name := appdef.NewQName("test", "projector2")
 		job2name := appdef.NewQName("test", "job2")
 		appDef2 := func() appdef.IAppDef {
@@ -217,60 +227,173 @@ func Test_DeployActualizersAndSchedulers(t *testing.T) {
 
 			_ = wsb.AddCommand(appdef.NewQName("test", "command"))
 
-			prj := wsb.AddProjector(prj2name)
-			prj.Events().Add(
-				[]appdef.OperationKind{appdef.OperationKind_Execute},
-				filter.WSTypes(wsName, appdef.TypeKind_Command))
-			prj.SetSync(false)
+			for _, n := range []appdef.QName{prj1name, prj2name} {
+				prj := wsb.AddProjector(n)
+				prj.Events().Add(
+					[]appdef.OperationKind{appdef.OperationKind_Execute},
+					filter.WSTypes(wsName, appdef.TypeKind_Command))
+				prj.SetSync(false)
+			}
 
-			job := wsb.AddJob(job2name)
-			job.SetCronSchedule("@every 1s")
+			for _, n := range []appdef.QName{job1name, job2name} {
+				job := wsb.AddJob(n)
+				job.SetCronSchedule("@every 1s")
+			}
 
 			return adb.MustBuild()
 		}()
 
-		t.Run("upgrade app to appDef2", func(t *testing.T) {
-			appParts.UpgradeAppDef(appName, appDef2)
+		t.Run("should be error if new definition is incompatible", func(t *testing.T) {
+			appDef3 := func() appdef.IAppDef {
+				adb := builder.New()
+				adb.AddPackage("test", "test.com/test")
+				wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+				wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+				wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+				// command, projector and job are removed
+				return adb.MustBuild()
+			}()
+			err := appParts.RedeployApp(appName, nil, appDef3, appparts.PoolSize(1, 1, 2, 2))
+			require.ErrorIs(err, appparts.ErrIncompatible)
 
 			def, err := appParts.AppDef(appName)
 			require.NoError(err)
-			require.Equal(appDef2, def)
+			require.Equal(appDef1, def)
 		})
 
-		parts := make([]istructs.PartitionID, 0, appPartsCount)
-		for partID := istructs.PartitionID(0); partID < istructs.PartitionID(appPartsCount); partID++ {
-			if partID%2 == 1 {
-				parts = append(parts, partID)
+		t.Run("should be error if extensions can not be deployed", func(t *testing.T) {
+			appDef3 := func() appdef.IAppDef {
+				adb := builder.New()
+				adb.AddPackage("test", "test.com/test")
 
-				mockActualizers.On("NewAndRun", mock.Anything, appName, partID, prj2name).Once()
+				wsName := appdef.NewQName("test", "workspace")
 
-				ws := schedulers.AppWorkspacesHandledByPartition(appPartsCount, appWSCount, partID)
-				for wsID, wsIdx := range ws {
-					mockSchedulers.On("NewAndRun", mock.Anything, appName, partID, wsIdx, wsID, job2name).Once()
-				}
+				wsb := adb.AddWorkspace(wsName)
+				wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+				wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+
+				_ = wsb.AddCommand(appdef.NewQName("test", "command"))
+
+				prj := wsb.AddProjector(prj1name)
+				prj.Events().Add(
+					[]appdef.OperationKind{appdef.OperationKind_Execute},
+					filter.WSTypes(wsName, appdef.TypeKind_Command))
+				prj.SetSync(false)
+
+				job := wsb.AddJob(job1name)
+				job.SetCronSchedule("@every 1s")
+
+				// no module URL for WASM extension
+				wsb.AddCommand(appdef.NewQName("test", "wasmCommand")).SetEngine(appdef.ExtensionEngineKind_WASM)
+				return adb.MustBuild()
+			}()
+			err := appParts.RedeployApp(appName, nil, appDef3, appparts.PoolSize(1, 1, 2, 2))
+			require.ErrorIs(err, appparts.ErrDeployment)
+
+			def, err := appParts.AppDef(appName)
+			require.NoError(err)
+			require.Equal(appDef1, def)
+		})
+
+		t.Run("should be error if app not found", func(t *testing.T) {
+			err := appParts.RedeployApp(istructs.AppQName_test1_app2, nil, appDef2, appparts.PoolSize(1, 1, 2, 2))
+			require.ErrorIs(err, appparts.ErrNotFound)
+		})
+
+		borrowed, err := appParts.Borrow(appName, 0, appparts.ProcessorKind_Command)
+		require.NoError(err)
+
+		for partID := istructs.PartitionID(0); partID < istructs.PartitionID(appPartsCount); partID++ {
+			mockActualizers.On("NewAndRun", mock.Anything, appName, partID, prj2name).Once()
+
+			ws := schedulers.AppWorkspacesHandledByPartition(appPartsCount, appWSCount, partID)
+			for wsID, wsIdx := range ws {
+				mockSchedulers.On("NewAndRun", mock.Anything, appName, partID, wsIdx, wsID, job2name).Once()
 			}
 		}
-		appParts.DeployAppPartitions(appName, parts)
+		require.NoError(appParts.RedeployApp(appName, nil, appDef2, appparts.PoolSize(1, 1, 2, 2)))
+
+		def, err := appParts.AppDef(appName)
+		require.NoError(err)
+		require.Equal(appDef2, def)
+
+		t.Run("should be available new engines while previous version is borrowed", func(t *testing.T) {
+			// command pool size is 1, the only engine of the previous version is still borrowed
+			p, err := appParts.Borrow(appName, 0, appparts.ProcessorKind_Command)
+			require.NoError(err)
+			defer p.Release()
+
+			t.Run("should be new application structures", func(t *testing.T) {
+				require.Equal(appDef2, p.AppStructs().AppDef())
+				require.Equal(appDef1, borrowed.AppStructs().AppDef())
+
+				as, err := appStructs.BuiltIn(appName)
+				require.NoError(err)
+				require.Same(p.AppStructs(), as)
+			})
+		})
+
+		borrowed.Release()
 
 		wr := whatsRun()
 		require.Len(wr, int(appPartsCount))
 		for partID := istructs.PartitionID(0); partID < istructs.PartitionID(appPartsCount); partID++ {
-			if partID%2 == 1 {
-				if len(schedulers.AppWorkspacesHandledByPartition(appPartsCount, appWSCount, partID)) == 0 {
-					require.Equal(appdef.QNames{prj2name}, wr[partID])
-				} else {
-					require.Equal(appdef.QNames{job2name, prj2name}, wr[partID])
-				}
+			if len(schedulers.AppWorkspacesHandledByPartition(appPartsCount, appWSCount, partID)) == 0 {
+				require.Equal(appdef.QNames{prj1name, prj2name}, wr[partID])
 			} else {
-				if len(schedulers.AppWorkspacesHandledByPartition(appPartsCount, appWSCount, partID)) == 0 {
-					require.Equal(appdef.QNames{prj1name}, wr[partID])
-				} else {
-					require.Equal(appdef.QNames{job1name, prj1name}, wr[partID])
-				}
+				require.Equal(appdef.QNames{job1name, job2name, prj1name, prj2name}, wr[partID])
 			}
 		}
 	})
 
+	t.Run("undeploy partitions", func(t *testing.T) {
+		borrowed, err := appParts.Borrow(appName, 0, appparts.ProcessorKind_Command)
+		require.NoError(err)
+		deploymentID := borrowed.DeploymentID()
+
+		undeployed := make(chan struct{})
+		go func() {
+			appParts.UndeployAppPartitions(appName, []istructs.PartitionID{0, 1})
+			close(undeployed)
+		}()
+
+		t.Run("should wait while borrowed partition is released", func(t *testing.T) {
+			require.Never(func() bool {
+				select {
+				case <-undeployed:
+					return true
+				default:
+					return false
+				}
+			}, 50*time.Millisecond, 10*time.Millisecond)
+			borrowed.Release()
+			<-undeployed
+		})
+
+		t.Run("should be error to borrow undeployed partition", func(t *testing.T) {
+			_, err := appParts.Borrow(appName, 0, appparts.ProcessorKind_Command)
+			require.ErrorIs(err, appparts.ErrNotFound)
+		})
+
+		t.Run("should stop actualizers and schedulers of undeployed partitions", func(t *testing.T) {
+			wr := whatsRun()
+			require.Len(wr, int(appPartsCount)-2)
+			require.NotContains(wr, istructs.PartitionID(0))
+			require.NotContains(wr, istructs.PartitionID(1))
+		})
+
+		t.Run("should be new deployment ID if partition deployed again", func(t *testing.T) {
+			mockActualizers.On("NewAndRun", mock.Anything, appName, istructs.PartitionID(0), mock.Anything)
+			mockSchedulers.On("NewAndRun", mock.Anything, appName, istructs.PartitionID(0), mock.Anything, mock.Anything, mock.Anything)
+			appParts.DeployAppPartitions(appName, []istructs.PartitionID{0})
+
+			p, err := appParts.Borrow(appName, 0, appparts.ProcessorKind_Command)
+			require.NoError(err)
+			require.NotEqual(deploymentID, p.DeploymentID())
+			p.Release()
+		})
+	})
+
 	t.Run("stop vvm from context, wait processors finished, check whatsRun", func(t *testing.T) {
 		stop()
 
@@ -330,7 +453,7 @@ func TestDeployApp_ValidateExtensions_MatchVSQLAndCode(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		appParts, cleanup, err := appparts.New2(ctx, appStructs,
 			appparts.NullSyncActualizerFactory, appparts.NullActualizerRunner, appparts.NullSchedulerRunner,
-			eef, iratesce.TestBucketsFactory)
+			eef, iratesce.TestBucketsFactory, appparts.NullCompatibilityChecker)
 		require.New(t).NoError(err)
 		defer func() {
 			cancel()
@@ -439,3 +562,119 @@ func TestDeployApp_ValidateExtensions_MatchVSQLAndCode(t *testing.T) {
 		require.ErrorContains(err, "wasm boom")
 	})
 }
+
+// Distributed and sliding window buckets are kept in the application TTL storage,
+// so they are shared by VVMs on the same storage and survive VVM restart
+func Test_DistributedLimits(t *testing.T) {
+	require := require.New(t)
+
+	appName := istructs.AppQName_test1_app1
+	partID := istructs.PartitionID(1)
+	wsName := appdef.NewQName("test", "workspace")
+	distributedCmd := appdef.NewQName("test", "distributedCmd")
+	slidingCmd := appdef.NewQName("test", "slidingCmd")
+
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(wsName)
+	wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+	wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+	_ = wsb.AddCommand(distributedCmd)
+	_ = wsb.AddCommand(slidingCmd)
+	for cmd, buckets := range map[appdef.QName]appdef.RateBuckets{
+		distributedCmd: appdef.RateBuckets_Distributed,
+		slidingCmd:     appdef.RateBuckets_SlidingWindow,
+	} {
+		rateName := appdef.NewQName("test", cmd.Entity()+"Rate")
+		wsb.AddRate(rateName, 2, time.Minute, []appdef.RateScope{appdef.RateScope_Workspace})
+		wsb.SetRateBuckets(rateName, buckets)
+		wsb.AddLimit(appdef.NewQName("test", cmd.Entity()+"Limit"),
+			[]appdef.OperationKind{appdef.OperationKind_Execute}, appdef.LimitFilterOption_ALL,
+			filter.QNames(cmd), rateName)
+	}
+	appDef := adb.MustBuild()
+
+	sharedStorage := provider.Provide(mem.Provide(testingu.MockTime), "")
+	sysVvmStorage, err := sharedStorage.AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(err)
+	appTTLStorageFactory := func(clusterAppID istructs.ClusterAppID) istructs.IAppTTLStorage {
+		return storage.NewAppTTLStorage(sysVvmStorage, clusterAppID)
+	}
+
+	bucketsFactory := func(kind appdef.RateBuckets, ttlStorage istructs.IAppTTLStorage) irates.IBuckets {
+		if kind == appdef.RateBuckets_Local {
+			return iratesce.Provide(testingu.MockTime)
+		}
+		return iratesstg.Provide(kind, ttlStorage, testingu.MockTime)
+	}
+
+	// launches VVM on the shared storage, returns partition and func to stop VVM
+	launch := func() (appparts.IAppPartition, func()) {
+		appConfigs := istructsmem.AppConfigsType{}
+		appConfigs.AddBuiltInAppConfig(appName, adb).SetNumAppWorkspaces(istructs.DefaultNumAppWorkspaces)
+		appStructs := istructsmem.Provide(
+			appConfigs,
+			payloads.ProvideIAppTokensFactory(itokensjwt.TestTokensJWT()),
+			sharedStorage, isequencer.SequencesTrustLevel_0, appTTLStorageFactory)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		appParts, cleanup, err := appparts.New2(ctx, appStructs,
+			appparts.NullSyncActualizerFactory,
+			appparts.NullActualizerRunner,
+			appparts.NullSchedulerRunner,
+			appparts.NullExtensionEngineFactories,
+			bucketsFactory,
+			appparts.NullCompatibilityChecker)
+		require.NoError(err)
+
+		appParts.DeployApp(appName, nil, appDef, 1, appparts.PoolSize(1, 1, 1, 1), istructs.DefaultNumAppWorkspaces)
+		appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
+
+		part, err := appParts.Borrow(appName, partID, appparts.ProcessorKind_Command)
+		require.NoError(err)
+
+		return part, func() {
+			part.Release()
+			cancel()
+			cleanup()
+		}
+	}
+
+	exceeded := func(part appparts.IAppPartition, cmd appdef.QName) bool {
+		exceeded, _ := part.IsLimitExceeded(cmd, appdef.OperationKind_Execute, istructs.FirstBaseUserWSID, "")
+		return exceeded
+	}
+
+	vvm1, stop1 := launch()
+	vvm2, stop2 := launch()
+	defer stop2()
+
+	for _, cmd := range []appdef.QName{distributedCmd, slidingCmd} {
+		t.Run("should share limit between VVMs: "+cmd.String(), func(t *testing.T) {
+			require.False(exceeded(vvm1, cmd))
+			require.False(exceeded(vvm2, cmd))
+			require.True(exceeded(vvm1, cmd))
+			require.True(exceeded(vvm2, cmd))
+		})
+	}
+
+	stop1()
+	vvm1, stop1 = launch()
+	defer stop1()
+
+	for _, cmd := range []appdef.QName{distributedCmd, slidingCmd} {
+		t.Run("should keep limit after VVM restart: "+cmd.String(), func(t *testing.T) {
+			require.True(exceeded(vvm1, cmd))
+		})
+	}
+
+	testingu.MockTime.Add(2 * time.Minute) // sliding window also counts tokens of the previous minute
+
+	for _, cmd := range []appdef.QName{distributedCmd, slidingCmd} {
+		t.Run("should respawn limit for all VVMs: "+cmd.String(), func(t *testing.T) {
+			require.False(exceeded(vvm1, cmd))
+			require.False(exceeded(vvm2, cmd))
+			require.True(exceeded(vvm1, cmd))
+		})
+	}
+}
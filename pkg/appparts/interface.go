@@ -9,6 +9,7 @@ import (
 	"context"
 	"iter"
 	"net/url"
+	"time"
 
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/pipeline"
@@ -39,6 +40,18 @@ type IAppPartitions interface {
 	// 	- if application not exists
 	DeployAppPartitions(appName appdef.AppQName, partIDs []istructs.PartitionID)
 
+	// Undeploys specified partitions of the application from the current VVM.
+	//
+	// Partitions become unavailable to borrow immediately, then async actualizers and schedulers are stopped
+	// and the method waits until all borrowed partitions are released.
+	// Partitions those are not deployed are skipped.
+	//
+	// Used to hand the partitions over to another VVM.
+	//
+	// # Panics:
+	// 	- if application not exists
+	UndeployAppPartitions(appName appdef.AppQName, partIDs []istructs.PartitionID)
+
 	// Returns application definition.
 	//
 	// Returns nil and error if app not exists.
@@ -78,11 +91,20 @@ type IAppPartitions interface {
 	// The snapshotted iterator are not updated when partitions are deployed or removed.
 	WorkedSchedulers(appdef.AppQName) iter.Seq2[istructs.PartitionID, map[appdef.QName][]istructs.WSID]
 
-	// Upgrade application definition.
+	// Redeploys application with new definition and extension modules without VVM restart.
 	//
-	// This experimental method should be used for test purposes only.
-	// Should be deprecated after application redeployment is implemented.
-	UpgradeAppDef(appdef.AppQName, appdef.IAppDef)
+	// New application version with new engines pools is created and becomes current.
+	// Partitions borrowed before redeployment keep working with the previous version until released,
+	// the previous version engines are closed after that.
+	// Sync actualizers and rate limiters of deployed partitions are rebuilt, async actualizers and schedulers are redeployed.
+	//
+	// extModuleURLs is important for non-builtin (non-native) apps.
+	// New application structures are created for the new definition, extensions of builtin apps are kept.
+	//
+	// Returns ErrIncompatible if new definition is not backward compatible with the current one, see CompatibilityChecker.
+	// Returns ErrDeployment if extensions can not be deployed. In this case the current version is not changed.
+	// Returns ErrNotFound if application is not deployed.
+	RedeployApp(name appdef.AppQName, extModuleURLs map[string]*url.URL, def appdef.IAppDef, engines [ProcessorKind_Count]uint) error
 }
 
 // Application partition.
@@ -90,6 +112,12 @@ type IAppPartition interface {
 	App() appdef.AppQName
 	ID() istructs.PartitionID
 
+	// Returns the identifier of the partition deployment.
+	//
+	// The identifier changes if the partition is undeployed and then deployed again,
+	// so it can be used to invalidate caches built for the partition.
+	DeploymentID() uint64
+
 	AppStructs() istructs.IAppStructs
 
 	// Releases borrowed partition
@@ -113,6 +141,11 @@ type IAppPartition interface {
 	// If resource usage is exceeded then returns name of first exceeded limit.
 	IsLimitExceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (exceed bool, limit appdef.QName)
 
+	// Returns the time to wait until specified exceeded limit allows to use specified resource again.
+	//
+	// Returns zero if the time is unknown.
+	LimitRetryAfter(limit appdef.QName, resource appdef.QName, workspace istructs.WSID, remoteAddr string) time.Duration
+
 	// Resets rate limit buckets for specified resource, operation and workspace to their default state.
 	ResetRateLimit(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string)
 }
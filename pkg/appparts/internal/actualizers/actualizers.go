@@ -57,6 +57,16 @@ func (pa *PartitionActualizers) Wait() {
 	pa.rtWG.Wait()
 }
 
+// Stop stops all deployed actualizers and waits for them to finish.
+//
+// Used when the partition is undeployed from the current VVM.
+func (pa *PartitionActualizers) Stop() {
+	for _, rt := range pa.rt.Range {
+		rt.(*runtime).cancel()
+	}
+	pa.rtWG.Wait()
+}
+
 // async start actualizer
 func (pa *PartitionActualizers) start(vvmCtx context.Context, name appdef.QName, run Run) {
 	ctx, cancel := context.WithCancel(vvmCtx)
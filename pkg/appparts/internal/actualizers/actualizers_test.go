@@ -79,6 +79,24 @@ func TestActualizersWaitTimeout(t *testing.T) {
 		})
 	})
 
+	t.Run("should ok to stop actualizers while vvm context is alive", func(t *testing.T) {
+		ctx, stop := context.WithCancel(context.Background())
+		defer stop()
+
+		actualizers := actualizers.New(appName, initialPartID)
+
+		actualizers.Deploy(ctx, appDef(),
+			func(ctx context.Context, _ appdef.AppQName, _ istructs.PartitionID, _ appdef.QName) {
+				<-ctx.Done()
+			})
+		require.Equal(prjNames, actualizers.Enum())
+
+		actualizers.Stop()
+
+		require.Empty(actualizers.Enum())
+		require.NoError(ctx.Err())
+	})
+
 	t.Run("should timeout to wait infinite run actualizers", func(t *testing.T) {
 
 		if testing.Short() {
@@ -14,6 +14,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef/filter"
 	"github.com/voedger/voedger/pkg/appparts/internal/limiter"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/iratesce"
 	"github.com/voedger/voedger/pkg/istructs"
 )
@@ -53,9 +54,11 @@ func Example() {
 		return adb.MustBuild()
 	}()
 
-	buckets := iratesce.TestBucketsFactory()
+	buckets := map[appdef.RateBuckets]irates.IBuckets{
+		appdef.RateBuckets_Local: iratesce.TestBucketsFactory(appdef.RateBuckets_Local, nil),
+	}
 
-	Limiter := limiter.New(app, buckets)
+	Limiter := limiter.New(app, 1, buckets)
 
 	// Check limits for cmd1
 	fmt.Println(Limiter.Exceeded(cmd1Name, appdef.OperationKind_Execute, 1, `addr1`))
@@ -120,8 +123,10 @@ func Example_resetLimits() {
 		return adb.MustBuild()
 	}()
 
-	buckets := iratesce.TestBucketsFactory()
-	Limiter := limiter.New(app, buckets)
+	buckets := map[appdef.RateBuckets]irates.IBuckets{
+		appdef.RateBuckets_Local: iratesce.TestBucketsFactory(appdef.RateBuckets_Local, nil),
+	}
+	Limiter := limiter.New(app, 1, buckets)
 
 	var ws istructs.WSID = 1
 
@@ -141,3 +146,49 @@ func Example_resetLimits() {
 	// true test.limit
 	// false .
 }
+
+func Example_retryAfter() {
+	cmdName := appdef.NewQName("test", "cmd")
+	limitName := appdef.NewQName("test", "limit")
+	app := func() appdef.IAppDef {
+		adb := builder.New()
+		adb.AddPackage("test", "test.com/test")
+
+		wsName := appdef.NewQName("test", "workspace")
+		wsb := adb.AddWorkspace(wsName)
+		_ = wsb.AddCommand(cmdName)
+
+		// Add distributed rate: 2 commands per minute for each workspace
+		rateName := appdef.NewQName("test", "rate")
+		wsb.AddRate(rateName, 2, time.Minute, []appdef.RateScope{appdef.RateScope_Workspace})
+		wsb.SetRateBuckets(rateName, appdef.RateBuckets_Distributed)
+		wsb.AddLimit(
+			limitName,
+			[]appdef.OperationKind{appdef.OperationKind_Execute},
+			appdef.LimitFilterOption_ALL,
+			filter.WSTypes(wsName, appdef.TypeKind_Command),
+			rateName)
+
+		return adb.MustBuild()
+	}()
+
+	// Buckets for distributed rates are not provided, so local buckets are used
+	buckets := map[appdef.RateBuckets]irates.IBuckets{
+		appdef.RateBuckets_Local: iratesce.TestBucketsFactory(appdef.RateBuckets_Local, nil),
+	}
+	Limiter := limiter.New(app, 1, buckets)
+
+	var ws istructs.WSID = 1
+
+	fmt.Println(Limiter.Exceeded(cmdName, appdef.OperationKind_Execute, ws, ``))
+	fmt.Println(Limiter.Exceeded(cmdName, appdef.OperationKind_Execute, ws, ``))
+	fmt.Println(Limiter.Exceeded(cmdName, appdef.OperationKind_Execute, ws, ``)) // exceeded
+
+	fmt.Println(Limiter.RetryAfter(limitName, cmdName, ws, ``).Round(time.Second))
+
+	// Output:
+	// false .
+	// false .
+	// true test.limit
+	// 30s
+}
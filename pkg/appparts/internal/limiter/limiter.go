@@ -7,6 +7,7 @@ package limiter
 
 import (
 	"errors"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/irates"
@@ -14,13 +15,18 @@ import (
 )
 
 type Limiter struct {
-	app     appdef.IAppDef
-	buckets irates.IBuckets
-	limits  map[appdef.QName][]appdef.ILimit
+	app       appdef.IAppDef
+	partition istructs.PartitionID
+	buckets   map[appdef.RateBuckets]irates.IBuckets
+	limits    map[appdef.QName][]appdef.ILimit
 }
 
-func New(app appdef.IAppDef, buckets irates.IBuckets) *Limiter {
-	l := &Limiter{app, buckets, make(map[appdef.QName][]appdef.ILimit)}
+// Creates new limiter for specified application partition.
+//
+// Buckets map should contains buckets for each rate buckets kind.
+// If there is no buckets for some kind, then local buckets are used.
+func New(app appdef.IAppDef, partition istructs.PartitionID, buckets map[appdef.RateBuckets]irates.IBuckets) *Limiter {
+	l := &Limiter{app, partition, buckets, make(map[appdef.QName][]appdef.ILimit)}
 	l.init()
 	return l
 }
@@ -30,33 +36,46 @@ func New(app appdef.IAppDef, buckets irates.IBuckets) *Limiter {
 // If resource usage is exceeded then returns name of first exceeded limit.
 func (l *Limiter) Exceeded(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) (bool, appdef.QName) {
 	if limits, ok := l.limits[resource]; ok {
-		keys := make([]irates.BucketKey, 0, len(limits))
+		keys := make(map[irates.IBuckets][]irates.BucketKey)
+		order := make([]irates.IBuckets, 0, 1)
 		for _, limit := range limits {
 			if limit.Op(operation) {
-				key := irates.BucketKey{
-					RateLimitName: limit.QName(),
-				}
-				if limit.Rate().Scope(appdef.RateScope_Workspace) {
-					key.Workspace = workspace
-				}
-				if limit.Rate().Scope(appdef.RateScope_IP) {
-					key.RemoteAddr = remoteAddr
-				}
-				if limit.Filter().Option() == appdef.LimitFilterOption_EACH {
-					key.QName = resource
+				buckets := l.bucketsFor(limit)
+				if _, ok := keys[buckets]; !ok {
+					order = append(order, buckets)
 				}
-				keys = append(keys, key)
+				keys[buckets] = append(keys[buckets], l.key(limit, resource, workspace, remoteAddr))
 			}
 		}
-		if len(keys) > 0 {
-			ok, excLimit := l.buckets.TakeTokens(keys, 1)
-			return !ok, excLimit
+		for i, buckets := range order {
+			if ok, excLimit := buckets.TakeTokens(keys[buckets], 1); !ok {
+				// return tokens taken from the buckets of other kinds
+				for _, taken := range order[:i] {
+					taken.TakeTokens(keys[taken], -1)
+				}
+				return true, excLimit
+			}
 		}
 	}
 
 	return false, appdef.NullQName
 }
 
+// Returns the time to wait until specified limit allows to use specified resource again.
+//
+// Returns zero if limit is not exceeded or is unknown.
+func (l *Limiter) RetryAfter(limit appdef.QName, resource appdef.QName, workspace istructs.WSID, remoteAddr string) time.Duration {
+	lim := appdef.Limit(l.app.Type, limit)
+	if lim == nil {
+		return 0
+	}
+	wait, err := l.bucketsFor(lim).RetryAfter(l.key(lim, resource, workspace, remoteAddr), 1)
+	if err != nil {
+		return 0
+	}
+	return wait
+}
+
 func (l *Limiter) ResetLimits(resource appdef.QName, operation appdef.OperationKind, workspace istructs.WSID, remoteAddr string) {
 	limits, ok := l.limits[resource]
 	if !ok {
@@ -66,23 +85,13 @@ func (l *Limiter) ResetLimits(resource appdef.QName, operation appdef.OperationK
 		if !limit.Op(operation) {
 			continue
 		}
-		key := irates.BucketKey{
-			RateLimitName: limit.QName(),
-		}
-		if limit.Rate().Scope(appdef.RateScope_Workspace) {
-			key.Workspace = workspace
-		}
-		if limit.Rate().Scope(appdef.RateScope_IP) {
-			key.RemoteAddr = remoteAddr
-		}
-		if limit.Filter().Option() == appdef.LimitFilterOption_EACH {
-			key.QName = resource
-		}
-		defaultState, err := l.buckets.GetDefaultBucketsState(limit.QName())
+		buckets := l.bucketsFor(limit)
+		key := l.key(limit, resource, workspace, remoteAddr)
+		defaultState, err := buckets.GetDefaultBucketsState(limit.QName())
 		if err != nil {
 			continue
 		}
-		err = l.buckets.SetBucketState(key, defaultState)
+		err = buckets.SetBucketState(key, defaultState)
 		if errors.Is(err, irates.ErrorRateLimitNotFound) {
 			// SetBucketState returns ErrorRateLimitNotFound when no bucket exists for the key.
 			// In the production IBuckets implementation (iratesce.bucketsType), bucketByKey
@@ -100,10 +109,38 @@ func (l *Limiter) ResetLimits(resource appdef.QName, operation appdef.OperationK
 	}
 }
 
+// returns buckets for specified limit rate
+func (l *Limiter) bucketsFor(limit appdef.ILimit) irates.IBuckets {
+	if b, ok := l.buckets[limit.Rate().Buckets()]; ok {
+		return b
+	}
+	return l.buckets[appdef.RateBuckets_Local]
+}
+
+// returns bucket key for specified limit and resource usage
+func (l *Limiter) key(limit appdef.ILimit, resource appdef.QName, workspace istructs.WSID, remoteAddr string) irates.BucketKey {
+	key := irates.BucketKey{
+		RateLimitName: limit.QName(),
+	}
+	if limit.Rate().Scope(appdef.RateScope_AppPartition) {
+		key.Partition = l.partition
+	}
+	if limit.Rate().Scope(appdef.RateScope_Workspace) {
+		key.Workspace = workspace
+	}
+	if limit.Rate().Scope(appdef.RateScope_IP) {
+		key.RemoteAddr = remoteAddr
+	}
+	if limit.Filter().Option() == appdef.LimitFilterOption_EACH {
+		key.QName = resource
+	}
+	return key
+}
+
 func (l *Limiter) init() {
 	// initialize default buckets states
 	for limit := range appdef.Limits(l.app.Types()) {
-		l.buckets.SetDefaultBucketState(
+		l.bucketsFor(limit).SetDefaultBucketState(
 			limit.QName(),
 			irates.BucketState{
 				Period:             limit.Rate().Period(),
@@ -73,6 +73,16 @@ func (ps *PartitionSchedulers) Wait() {
 	ps.rtWG.Wait()
 }
 
+// Stop stops all deployed schedulers and waits for them to finish.
+//
+// Used when the partition is undeployed from the current VVM.
+func (ps *PartitionSchedulers) Stop() {
+	for _, rt := range ps.rt.Range {
+		rt.(*runtime).cancel()
+	}
+	ps.rtWG.Wait()
+}
+
 // start actualizer
 func (ps *PartitionSchedulers) start(vvmCtx context.Context, jws jWS, run Run) {
 	ctx, cancel := context.WithCancel(vvmCtx)
@@ -8,6 +8,7 @@ package seqstorage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,7 @@ import (
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
 	"github.com/voedger/voedger/pkg/isequencer"
+	"github.com/voedger/voedger/pkg/istorage/faulty"
 	"github.com/voedger/voedger/pkg/istorage/mem"
 	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -261,6 +263,67 @@ func TestSequenceActualization(t *testing.T) {
 	}
 }
 
+func TestActualizeOnStorageFaults(t *testing.T) {
+	require := require.New(t)
+	appDef := setupTestAppDef(t)
+
+	pLogEvent := testPLogEvent{qName: testCmdQName, wsid: 1, pLogOffset: 1, wLogOffset: 2, cuds: []cud{{qName: testCDocQName, id: 5}}}
+	mockEvents := &coreutils.MockEvents{}
+	mockEvents.On("ReadPLog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			cb := args.Get(4).(istructs.PLogEventsReaderCallback)
+			if args.Get(2).(istructs.Offset) <= pLogEvent.pLogOffset {
+				require.NoError(cb(pLogEvent.pLogOffset, testPLogEventToIPlogEvent(pLogEvent, appDef)))
+			}
+		})
+
+	asf, faults := faulty.Provide(mem.Provide(testingu.MockTime), 0)
+	appStorage, err := provider.Provide(asf).AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(err)
+	seqStorage := New(istructs.ClusterApps[istructs.AppQName_test1_app1], istructs.PartitionID(42), mockEvents, appDef, storage.NewVVMSeqStorageAdapter(appStorage))
+
+	// reading the next PLog offset on actualization and writing the actualized numbers fail
+	faults.Inject(faulty.Fault{Operations: []faulty.Operation{faulty.Operation_Get}, Times: 2, Err: faulty.ErrInjected})
+	faults.Inject(faulty.Fault{Operations: []faulty.Operation{faulty.Operation_PutBatch}, Times: 1, Err: faulty.ErrInjected})
+
+	wsKind := isequencer.WSKind(1)
+	params := isequencer.NewDefaultParams(map[isequencer.WSKind]map[isequencer.SeqID]isequencer.Number{
+		wsKind: {
+			isequencer.SeqID(istructs.QNameIDRecordIDSequence):   1,
+			isequencer.SeqID(istructs.QNameIDWLogOffsetSequence): 1,
+		},
+	})
+	seq, cleanup := isequencer.New(params, seqStorage, testingu.MockTime)
+	defer cleanup()
+
+	// actualization is retried until the storage recovers
+	var plogOffset isequencer.PLogOffset
+	require.Eventually(func() (ok bool) {
+		plogOffset, ok = seq.Start(wsKind, isequencer.WSID(pLogEvent.wsid))
+		return ok
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(isequencer.PLogOffset(pLogEvent.pLogOffset+1), plogOffset)
+
+	num, err := seq.Next(isequencer.SeqID(istructs.QNameIDRecordIDSequence))
+	require.NoError(err)
+	require.Equal(isequencer.Number(6), num)
+	num, err = seq.Next(isequencer.SeqID(istructs.QNameIDWLogOffsetSequence))
+	require.NoError(err)
+	require.Equal(isequencer.Number(3), num)
+	seq.Flush()
+
+	// flushing is retried until the storage recovers
+	require.Eventually(func() bool {
+		numbers, err := seqStorage.ReadNumbers(isequencer.WSID(pLogEvent.wsid), []isequencer.SeqID{
+			isequencer.SeqID(istructs.QNameIDRecordIDSequence),
+			isequencer.SeqID(istructs.QNameIDWLogOffsetSequence),
+		})
+		return err == nil && numbers[0] == 6 && numbers[1] == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(3, faults.Injected())
+}
+
 func TestSeqIDMapping(t *testing.T) {
 	require := require.New(t)
 	mockEvents := &coreutils.MockEvents{}
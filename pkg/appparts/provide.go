@@ -8,6 +8,7 @@ package appparts
 import (
 	"context"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/iextengine"
 	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -16,6 +17,11 @@ import (
 
 type SyncActualizerFactory = func(istructs.IAppStructs, istructs.PartitionID) pipeline.ISyncOperator
 
+// CompatibilityChecker checks that new application definition is backward compatible with the old one, see appdefcompat.
+//
+// Should return error if new definition is not compatible.
+type CompatibilityChecker = func(old, new appdef.IAppDef) error
+
 // New2 creates new app partitions.
 //
 // # Parameters:
@@ -26,6 +32,8 @@ type SyncActualizerFactory = func(istructs.IAppStructs, istructs.PartitionID) pi
 //	asyncActualizersRunner - async actualizers runner
 //	jobSchedulerRunner - job scheduler runner
 //	eef - extension engine factories
+//	bf - rate limiter buckets factory
+//	compat - checks new application definition on redeploy
 func New2(
 	vvmCtx context.Context,
 	structs istructs.IAppStructsProvider,
@@ -34,6 +42,7 @@ func New2(
 	jobSchedulerRunner ISchedulerRunner,
 	eef iextengine.ExtensionEngineFactories,
 	bf irates.BucketsFactoryType,
+	compat CompatibilityChecker,
 ) (ap IAppPartitions, cleanup func(), err error) {
-	return newAppPartitions(vvmCtx, structs, syncAct, asyncActualizersRunner, jobSchedulerRunner, eef, bf)
+	return newAppPartitions(vvmCtx, structs, syncAct, asyncActualizersRunner, jobSchedulerRunner, eef, bf, compat)
 }
@@ -22,6 +22,7 @@ func NewTestAppParts(asp istructs.IAppStructsProvider) (IAppPartitions, func())
 		NullSchedulerRunner,
 		NullExtensionEngineFactories,
 		iratesce.TestBucketsFactory,
+		NullCompatibilityChecker,
 	)
 	if err != nil {
 		panic(err)
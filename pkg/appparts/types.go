@@ -56,3 +56,6 @@ type SidecarApp struct {
 	BuiltInApp
 	ExtModuleURLs map[string]*url.URL
 }
+
+// IAppPartitionsPtr is a placeholder for IAppPartitions that is filled after VVM wiring completes
+type IAppPartitionsPtr *IAppPartitions
@@ -0,0 +1,21 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apppartsctl
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/ielections"
+)
+
+const (
+	// Duration of the application partition ownership lease.
+	//
+	// If the owner VVM dies, then its partitions are reassigned to alive VVMs after the lease expired.
+	PartitionLeaseDurationSeconds ielections.LeadershipDurationSeconds = 20
+
+	// Interval to check alive VVMs and rebalance partitions among them.
+	RebalanceInterval = 5 * time.Second
+)
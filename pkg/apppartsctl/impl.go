@@ -8,15 +8,18 @@ package apppartsctl
 import (
 	"context"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/istructs"
 )
 
+// Controller for the single VVM cluster: all partitions are deployed on the current VVM.
 type appPartitionsController struct {
-	parts appparts.IAppPartitions
+	appparts.IAppPartitions
 }
 
 func newAppPartitionsController(parts appparts.IAppPartitions) (ctl IAppPartitionsController, cleanup func(), err error) {
-	apc := appPartitionsController{parts: parts}
+	apc := appPartitionsController{IAppPartitions: parts}
 
 	return &apc, func() {}, err
 }
@@ -28,3 +31,12 @@ func (ctl *appPartitionsController) Prepare() (err error) {
 func (ctl *appPartitionsController) Run(ctx context.Context) {
 	<-ctx.Done()
 }
+
+func (ctl *appPartitionsController) PartitionOwner(appdef.AppQName, istructs.PartitionID) (string, bool) {
+	return "", false
+}
+
+// All partitions are owned by the single VVM
+func (ctl *appPartitionsController) OwnsPartition(appdef.AppQName, istructs.PartitionID) bool {
+	return true
+}
@@ -0,0 +1,275 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apppartsctl
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/ielections"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Controller for the cluster of several VVMs: each partition is owned by exactly one VVM.
+type distributedController struct {
+	appparts.IAppPartitions
+	owners      ielections.ITTLStorage[PartitionKey, string]
+	elections   ielections.IElections[PartitionKey, string]
+	aliveVVMs   AliveVVMsCounter
+	self        string
+	time        timeu.ITime
+	rebalanceMx sync.Mutex // serializes rebalancing
+	mx          sync.RWMutex
+	apps        map[appdef.AppQName]*distributedApp
+	watchers    sync.WaitGroup // lease watchers of owned partitions
+}
+
+func newDistributedController(parts appparts.IAppPartitions, owners ielections.ITTLStorage[PartitionKey, string],
+	aliveVVMs AliveVVMsCounter, self VVMAddress, time timeu.ITime) (ctl IAppPartitionsController, cleanup func(), err error) {
+	elections, electionsCleanup := ielections.Provide(owners, time)
+	dc := &distributedController{
+		IAppPartitions: parts,
+		owners:         owners,
+		elections:      elections,
+		aliveVVMs:      aliveVVMs,
+		self:           string(self),
+		time:           time,
+		apps:           map[appdef.AppQName]*distributedApp{},
+	}
+	return dc, electionsCleanup, nil
+}
+
+func (dc *distributedController) Prepare() (err error) {
+	return err
+}
+
+// Periodically rebalances partitions among alive VVMs.
+//
+// Owned partitions are undeployed and their leases are released when ctx is done.
+func (dc *distributedController) Run(ctx context.Context) {
+	timer := dc.time.NewTimerChan(RebalanceInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			dc.undeployAll()
+			dc.watchers.Wait()
+			return
+		case <-timer:
+			dc.rebalance()
+			timer = dc.time.NewTimerChan(RebalanceInterval)
+		}
+	}
+}
+
+// Registers partitions to be distributed among VVMs and deploys the fair share of them on the current VVM.
+func (dc *distributedController) DeployAppPartitions(name appdef.AppQName, partIDs []istructs.PartitionID) {
+	dc.mx.Lock()
+	a, ok := dc.apps[name]
+	if !ok {
+		a = &distributedApp{owned: map[istructs.PartitionID]context.Context{}}
+		dc.apps[name] = a
+	}
+	for _, id := range partIDs {
+		if !slices.Contains(a.parts, id) {
+			a.parts = append(a.parts, id)
+		}
+	}
+	slices.Sort(a.parts)
+	dc.mx.Unlock()
+
+	dc.rebalance()
+}
+
+func (dc *distributedController) PartitionOwner(app appdef.AppQName, partID istructs.PartitionID) (string, bool) {
+	dc.mx.RLock()
+	a, ok := dc.apps[app]
+	if ok {
+		_, ok = a.owned[partID]
+	}
+	dc.mx.RUnlock()
+	if ok {
+		return "", false
+	}
+
+	ok, owner, err := dc.owners.Get(PartitionKey{App: app, Partition: partID})
+	if err != nil {
+		// notest
+		logger.Error(fmt.Sprintf("failed to get owner of partition %s/%d: %s", app, partID, err))
+		return "", false
+	}
+	if !ok || owner == dc.self {
+		return "", false
+	}
+	return owner, true
+}
+
+func (dc *distributedController) OwnsPartition(app appdef.AppQName, partID istructs.PartitionID) bool {
+	dc.mx.RLock()
+	defer dc.mx.RUnlock()
+	if a, ok := dc.apps[app]; ok {
+		_, ok = a.owned[partID]
+		return ok
+	}
+	return false
+}
+
+// Acquires free partitions up to the fair share and hands the excess over to other VVMs.
+//
+// Fair share is the total number of partitions of all applications divided by the number of alive VVMs, rounded up.
+func (dc *distributedController) rebalance() {
+	dc.rebalanceMx.Lock()
+	defer dc.rebalanceMx.Unlock()
+
+	numVVMs, err := dc.aliveVVMs()
+	if err != nil {
+		logger.Error("failed to count alive VVMs, rebalancing skipped:", err)
+		return
+	}
+	numVVMs = max(numVVMs, 1)
+
+	all, owned := dc.partitions()
+
+	// partitions whose leases are lost could be already owned by other VVM,
+	// lease watchers undeploy them as well, but could be not scheduled yet
+	lost := slices.DeleteFunc(slices.Clone(owned), func(k PartitionKey) bool { return dc.leaseCtx(k).Err() == nil })
+	if len(lost) > 0 {
+		dc.undeploy(lost, false)
+		owned = slices.DeleteFunc(owned, func(k PartitionKey) bool { return slices.Contains(lost, k) })
+	}
+
+	fairShare := (uint(len(all)) + numVVMs - 1) / numVVMs
+
+	if excess := len(owned) - int(fairShare); excess > 0 { // nolint G115
+		dc.undeploy(owned[len(owned)-excess:], true)
+		return
+	}
+
+	acquired := map[PartitionKey]context.Context{}
+	for _, k := range all {
+		if len(owned)+len(acquired) >= int(fairShare) { // nolint G115
+			break
+		}
+		if slices.Contains(owned, k) {
+			continue
+		}
+		if ok, _, err := dc.owners.Get(k); err != nil || ok {
+			continue // owned by other VVM
+		}
+		if ctx := dc.elections.AcquireLeadership(k, dc.self, PartitionLeaseDurationSeconds); ctx != nil {
+			acquired[k] = ctx
+		}
+	}
+	if len(acquired) > 0 {
+		dc.deploy(acquired)
+	}
+}
+
+// Returns all registered partitions and partitions owned by the current VVM, both sorted by application and partition ID.
+func (dc *distributedController) partitions() (all, owned []PartitionKey) {
+	dc.mx.RLock()
+	defer dc.mx.RUnlock()
+
+	for app, a := range dc.apps {
+		for _, id := range a.parts {
+			k := PartitionKey{App: app, Partition: id}
+			all = append(all, k)
+			if _, ok := a.owned[id]; ok {
+				owned = append(owned, k)
+			}
+		}
+	}
+	compare := func(a, b PartitionKey) int {
+		return cmp.Or(cmp.Compare(a.App.String(), b.App.String()), cmp.Compare(a.Partition, b.Partition))
+	}
+	slices.SortFunc(all, compare)
+	slices.SortFunc(owned, compare)
+	return all, owned
+}
+
+func (dc *distributedController) leaseCtx(k PartitionKey) context.Context {
+	dc.mx.RLock()
+	defer dc.mx.RUnlock()
+	return dc.apps[k.App].owned[k.Partition]
+}
+
+// Deploys partitions whose leases are acquired and marks them as owned
+func (dc *distributedController) deploy(acquired map[PartitionKey]context.Context) {
+	byApp := map[appdef.AppQName][]istructs.PartitionID{}
+	for k := range acquired {
+		byApp[k.App] = append(byApp[k.App], k.Partition)
+	}
+	for app, ids := range byApp {
+		slices.Sort(ids)
+		logger.Info(fmt.Sprintf("partitions %s%v are acquired by %s, deploying", app, ids, dc.self))
+		dc.IAppPartitions.DeployAppPartitions(app, ids)
+	}
+
+	dc.mx.Lock()
+	for k, ctx := range acquired {
+		dc.apps[k.App].owned[k.Partition] = ctx
+	}
+	dc.mx.Unlock()
+
+	for k, ctx := range acquired {
+		dc.watchers.Add(1)
+		go dc.watchLease(k, ctx)
+	}
+}
+
+// Undeploys the partition as soon as its lease is lost, e.g. lease renewal is failed or the lease is stolen,
+// so the partition is not handled by two VVMs until the next rebalancing.
+//
+// Does nothing if the partition is undeployed already, e.g. the lease is released on hand over.
+func (dc *distributedController) watchLease(k PartitionKey, ctx context.Context) {
+	defer dc.watchers.Done()
+	<-ctx.Done()
+
+	dc.rebalanceMx.Lock()
+	defer dc.rebalanceMx.Unlock()
+
+	if dc.leaseCtx(k) != ctx {
+		return
+	}
+	logger.Error(fmt.Sprintf("lease of partition %s/%d is lost by %s", k.App, k.Partition, dc.self))
+	dc.undeploy([]PartitionKey{k}, false)
+}
+
+// Undeploys partitions and then releases their leases if release is true.
+//
+// Partitions are undeployed before the leases are released, so the next owner could not deploy them while
+// the current VVM still handles them.
+func (dc *distributedController) undeploy(keys []PartitionKey, release bool) {
+	byApp := map[appdef.AppQName][]istructs.PartitionID{}
+	dc.mx.Lock()
+	for _, k := range keys {
+		delete(dc.apps[k.App].owned, k.Partition)
+		byApp[k.App] = append(byApp[k.App], k.Partition)
+	}
+	dc.mx.Unlock()
+
+	for app, ids := range byApp {
+		logger.Info(fmt.Sprintf("partitions %s%v are handed over by %s, undeploying", app, ids, dc.self))
+		dc.IAppPartitions.UndeployAppPartitions(app, ids)
+	}
+
+	for _, k := range keys {
+		dc.elections.ReleaseLeadership(k)
+	}
+}
+
+func (dc *distributedController) undeployAll() {
+	dc.rebalanceMx.Lock()
+	defer dc.rebalanceMx.Unlock()
+
+	_, owned := dc.partitions()
+	dc.undeploy(owned, true)
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apppartsctl
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type mockOwners struct {
+	mx   sync.Mutex
+	data map[PartitionKey]string
+}
+
+func (m *mockOwners) InsertIfNotExist(key PartitionKey, val string, _ int) (bool, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	if _, ok := m.data[key]; ok {
+		return false, nil
+	}
+	m.data[key] = val
+	return true, nil
+}
+
+func (m *mockOwners) CompareAndSwap(key PartitionKey, oldVal, newVal string, _ int) (bool, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	if m.data[key] != oldVal {
+		return false, nil
+	}
+	m.data[key] = newVal
+	return true, nil
+}
+
+func (m *mockOwners) CompareAndDelete(key PartitionKey, val string) (bool, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	if v, ok := m.data[key]; !ok || v != val {
+		return false, nil
+	}
+	delete(m.data, key)
+	return true, nil
+}
+
+func (m *mockOwners) Get(key PartitionKey) (bool, string, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	v, ok := m.data[key]
+	return ok, v, nil
+}
+
+type mockAppParts struct {
+	appparts.IAppPartitions
+	mx       sync.Mutex
+	deployed map[istructs.PartitionID]bool
+}
+
+func (m *mockAppParts) DeployAppPartitions(_ appdef.AppQName, ids []istructs.PartitionID) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	for _, id := range ids {
+		m.deployed[id] = true
+	}
+}
+
+func (m *mockAppParts) UndeployAppPartitions(_ appdef.AppQName, ids []istructs.PartitionID) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	for _, id := range ids {
+		delete(m.deployed, id)
+	}
+}
+
+func (m *mockAppParts) Deployed() []istructs.PartitionID {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	res := []istructs.PartitionID{}
+	for id := range m.deployed {
+		res = append(res, id)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func TestDistributedController(t *testing.T) {
+	require := require.New(t)
+
+	app := istructs.AppQName_test1_app1
+	parts := []istructs.PartitionID{0, 1, 2, 3}
+
+	owners := &mockOwners{data: map[PartitionKey]string{}}
+	aliveVVMs := uint(1)
+	counter := func() (uint, error) { return aliveVVMs, nil }
+
+	newCtl := func(addr VVMAddress) (*distributedController, *mockAppParts, func()) {
+		ap := &mockAppParts{deployed: map[istructs.PartitionID]bool{}}
+		ctl, cleanup, err := NewDistributed(ap, owners, counter, addr, testingu.MockTime)
+		require.NoError(err)
+		return ctl.(*distributedController), ap, cleanup
+	}
+
+	ctl1, parts1, cleanup1 := newCtl("http://vvm1")
+	defer cleanup1()
+
+	t.Run("should deploy all partitions on the single alive VVM", func(t *testing.T) {
+		ctl1.DeployAppPartitions(app, parts)
+		require.Equal(parts, parts1.Deployed())
+		for _, id := range parts {
+			_, ok := ctl1.PartitionOwner(app, id)
+			require.False(ok)
+			require.True(ctl1.OwnsPartition(app, id))
+		}
+	})
+
+	ctl2, parts2, cleanup2 := newCtl("http://vvm2")
+	defer cleanup2()
+	ctx2, stop2 := context.WithCancel(context.Background())
+	ctl2Done := make(chan struct{})
+
+	t.Run("should rebalance partitions if VVM joined", func(t *testing.T) {
+		aliveVVMs = 2
+		ctl2.DeployAppPartitions(app, parts)
+		require.Empty(parts2.Deployed(), "all partitions are owned by VVM1")
+
+		owner, ok := ctl2.PartitionOwner(app, 0)
+		require.True(ok)
+		require.Equal("http://vvm1", owner)
+
+		ctl1.rebalance()
+		require.Equal(parts[:2], parts1.Deployed(), "excess partitions should be handed over")
+
+		ctl2.rebalance()
+		require.Equal(parts[2:], parts2.Deployed())
+
+		owner, ok = ctl1.PartitionOwner(app, 3)
+		require.True(ok)
+		require.Equal("http://vvm2", owner)
+		_, ok = ctl1.PartitionOwner(app, 0)
+		require.False(ok)
+		require.True(ctl1.OwnsPartition(app, 0))
+		require.False(ctl1.OwnsPartition(app, 3))
+	})
+
+	t.Run("should reassign partitions if VVM left", func(t *testing.T) {
+		go func() {
+			ctl2.Run(ctx2)
+			close(ctl2Done)
+		}()
+		stop2()
+		<-ctl2Done
+		require.Empty(parts2.Deployed())
+
+		aliveVVMs = 1
+		ctl1.rebalance()
+		require.Equal(parts, parts1.Deployed())
+	})
+
+	t.Run("should undeploy partition as soon as its lease is lost", func(t *testing.T) {
+		// lease of partition 0 is stolen, the next renewal fails
+		owners.mx.Lock()
+		owners.data[PartitionKey{App: app, Partition: 0}] = "http://vvm3"
+		owners.mx.Unlock()
+		testingu.MockTime.Add(time.Duration(PartitionLeaseDurationSeconds) * time.Second)
+
+		require.Eventually(func() bool { return !ctl1.OwnsPartition(app, 0) }, time.Second, 10*time.Millisecond)
+		require.Equal(parts[1:], parts1.Deployed())
+
+		owner, ok := ctl1.PartitionOwner(app, 0)
+		require.True(ok)
+		require.Equal("http://vvm3", owner)
+	})
+}
@@ -6,10 +6,27 @@
 package apppartsctl
 
 import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/iservices"
+	"github.com/voedger/voedger/pkg/istructs"
 )
 
 // IAppPartitionsController is a service that creates, updates (replaces) and deletes applications partitions.
 type IAppPartitionsController interface {
 	iservices.IService
+
+	// Application partitions manager decorated by controller.
+	//
+	// DeployAppPartitions deploys only partitions owned by the current VVM,
+	// the rest of the partitions are deployed by other VVMs of the cluster.
+	appparts.IAppPartitions
+
+	// Returns the address of the VVM that owns the specified application partition.
+	//
+	// Returns false if the partition is owned by the current VVM or the owner is unknown.
+	PartitionOwner(appdef.AppQName, istructs.PartitionID) (owner string, ok bool)
+
+	// Returns true if the specified application partition is owned by the current VVM.
+	OwnsPartition(appdef.AppQName, istructs.PartitionID) bool
 }
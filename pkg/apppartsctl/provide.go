@@ -7,9 +7,23 @@ package apppartsctl
 
 import (
 	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/ielections"
 )
 
 // Returns a new instance of IAppPartitionsController.
+//
+// All application partitions are deployed on the current VVM.
 func New(parts appparts.IAppPartitions) (ctl IAppPartitionsController, cleanup func(), err error) {
 	return newAppPartitionsController(parts)
 }
+
+// Returns a new instance of IAppPartitionsController for the cluster of several VVMs.
+//
+// Each application partition is owned by exactly one VVM, ownership is leased using elections over the owners storage.
+// Controller periodically rebalances partitions among alive VVMs: acquires free partitions up to the fair share and
+// hands the excess over to other VVMs.
+func NewDistributed(parts appparts.IAppPartitions, owners ielections.ITTLStorage[PartitionKey, string],
+	aliveVVMs AliveVVMsCounter, self VVMAddress, time timeu.ITime) (ctl IAppPartitionsController, cleanup func(), err error) {
+	return newDistributedController(parts, owners, aliveVVMs, self, time)
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apppartsctl
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Key of the application partition ownership lease.
+type PartitionKey struct {
+	App       appdef.AppQName
+	Partition istructs.PartitionID
+}
+
+// Returns the number of VVMs alive in the cluster.
+type AliveVVMsCounter func() (uint, error)
+
+// Address of the VVM, e.g. `http://10.0.0.1:8822`.
+//
+// Used as the partition ownership lease value, other VVMs forward requests to this address.
+type VVMAddress string
+
+// Partitions requested to be deployed for the application and partitions owned by the current VVM.
+type distributedApp struct {
+	parts []istructs.PartitionID                   // sorted
+	owned map[istructs.PartitionID]context.Context // lease context per owned partition
+}
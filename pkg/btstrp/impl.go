@@ -29,7 +29,7 @@ import (
 func Bootstrap(federation federation.IFederation, asp istructs.IAppStructsProvider, time timeu.ITime, appparts appparts.IAppPartitions,
 	clusterApp ClusterBuiltInApp, otherApps []appparts.BuiltInApp, sidecarApps []appparts.SidecarApp, itokens itokens.ITokens, storageProvider istorage.IAppStorageProvider,
 	postWiredInterfacePtrs PostWireInterfacePtrs, blobHandler blobprocessor.IRequestHandler,
-	requestSender bus.IRequestSender) (err error) {
+	requestSender bus.IRequestSender, appImagesDeployer cluster.IAppImagesDeployer) (err error) {
 
 	logCtx := logger.WithContextAttrs(context.Background(), map[string]any{
 		logger.LogAttr_VApp:      sys.VApp_SysVoedger,
@@ -57,6 +57,8 @@ func Bootstrap(federation federation.IFederation, asp istructs.IAppStructsProvid
 
 	*postWiredInterfacePtrs.BlobHandler = blobHandler
 
+	*postWiredInterfacePtrs.AppPartitions = appparts
+
 	// appparts: deploy single clusterApp partition
 	appparts.DeployApp(istructs.AppQName_sys_cluster, nil, clusterApp.Def, clusterapp.ClusterAppNumPartitions,
 		clusterapp.ClusterAppNumEngines, clusterapp.ClusterAppNumAppWS)
@@ -85,6 +87,12 @@ func Bootstrap(federation federation.IFederation, asp istructs.IAppStructsProvid
 		deployAppPartitions(logCtx, "bootstrap.apppartdeploy.sidecar", appparts, app.BuiltInApp, app.ExtModuleURLs)
 	}
 
+	// deploy the app images accepted by c.cluster.DeployApp, including ones accepted before the VVM launch or by other VVMs
+	if err := appImagesDeployer.Bootstrap(clusterapp.ClusterAppWSID); err != nil {
+		return err
+	}
+	logger.InfoCtx(logCtx, "bootstrap", "app images deployed")
+
 	logger.InfoCtx(logCtx, "bootstrap", "completed")
 	return nil
 }
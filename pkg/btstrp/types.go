@@ -22,4 +22,5 @@ type PostWireInterfacePtrs struct {
 	RouterAppStorage  dbcertcache.RouterAppStoragePtr
 	BlobHandler       blobprocessor.IRequestHandlerPtr
 	RequestSender     bus.IRequestSenderPtr
+	AppPartitions     appparts.IAppPartitionsPtr
 }
@@ -7,8 +7,10 @@ package cluster
 
 import (
 	"embed"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/dml"
 )
 
@@ -22,21 +24,32 @@ const (
 	Field_AppQName         = "AppQName"
 	Field_NumPartitions    = "NumPartitions"
 	Field_NumAppWorkspaces = "NumAppWorkspaces"
+	Field_AppImage         = "AppImage"
 	field_Query            = "Query"
 	field_NewID            = "NewID"
 	field_LogWLogOffset    = "LogWLogOffset"
 	field_CUDWLogOffset    = "CUDWLogOffset"
+	appImagePkgDir         = "pkg"
+	maxAppImageSize        = 256 * 1024 * 1024
+
+	// Interval to check the images deployed by c.cluster.DeployApp on other VVMs of the cluster
+	AppImagesDeployInterval = 5 * time.Second
 )
 
 var (
-	qNameWDocApp           = appdef.NewQName(ClusterPackage, "App")
-	plog                   = appdef.NewQName(appdef.SysPackage, "PLog")
-	wlog                   = appdef.NewQName(appdef.SysPackage, "WLog")
-	qNameVSqlUpdateResult  = appdef.NewQName(ClusterPackage, "VSqlUpdateResult")
-	qNameVSqlUpdate2Result = appdef.NewQName(ClusterPackage, "VSqlUpdate2Result")
-	qNameCmdLogVSqlUpdate  = appdef.NewQName(ClusterPackage, "LogVSqlUpdate")
-	qNameQryVSqlUpdate2    = appdef.NewQName(ClusterPackage, "VSqlUpdate2")
-	updateDeniedFields     = map[string]bool{
+	// engine pools size for apps deployed from images: command, query, projector, scheduler
+	appImageEnginePoolSize = appparts.PoolSize(10, 10, 10, 1)
+
+	qNameWDocApp                      = appdef.NewQName(ClusterPackage, "App")
+	qNameCmdDeployApp                 = appdef.NewQName(ClusterPackage, "DeployApp")
+	qNameProjectorApplyDeployAppImage = appdef.NewQName(ClusterPackage, "ApplyDeployAppImage")
+	plog                              = appdef.NewQName(appdef.SysPackage, "PLog")
+	wlog                              = appdef.NewQName(appdef.SysPackage, "WLog")
+	qNameVSqlUpdateResult             = appdef.NewQName(ClusterPackage, "VSqlUpdateResult")
+	qNameVSqlUpdate2Result            = appdef.NewQName(ClusterPackage, "VSqlUpdate2Result")
+	qNameCmdLogVSqlUpdate             = appdef.NewQName(ClusterPackage, "LogVSqlUpdate")
+	qNameQryVSqlUpdate2               = appdef.NewQName(ClusterPackage, "VSqlUpdate2")
+	updateDeniedFields                = map[string]bool{
 		appdef.SystemField_ID:    true,
 		appdef.SystemField_QName: true,
 	}
@@ -10,6 +10,8 @@ import "errors"
 var (
 	ErrNumPartitionsChanged    = errors.New("num partitions changed")
 	ErrNumAppWorkspacesChanged = errors.New("num application workspaces changed")
+	ErrAppImageInvalid         = errors.New("application image is invalid")
+	ErrAppImageIncompatible    = errors.New("application image is incompatible with the deployed one")
 	errWrongWhereForView       = errors.New("'where viewField1 = val1 [and viewField2 = val2 ...]' condition is only supported")
 	errNullValueNoSupported    = errors.New("null value is not supported")
 )
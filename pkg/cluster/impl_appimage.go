@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cluster
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/goutils/filesu"
+	"github.com/voedger/voedger/pkg/parser"
+)
+
+// ParseAppImageDir parses the `pkg` folder of an application image, e.g. the unzipped .var file built by `vpm build`.
+//
+// Returns the application definition and URLs of the wasm extension modules found in the image
+func ParseAppImageDir(pkgPath string) (def appdef.IAppDef, extModuleURLs map[string]*url.URL, err error) {
+	extModuleURLs = map[string]*url.URL{}
+	appSchemaAST, err := parseAppImageSchema(pkgPath, extModuleURLs)
+	if err != nil {
+		return nil, nil, err
+	}
+	appDefBuilder := builder.New()
+	if err := parser.BuildAppDefs(appSchemaAST, appDefBuilder); err != nil {
+		return nil, nil, err
+	}
+	if def, err = appDefBuilder.Build(); err != nil {
+		return nil, nil, err
+	}
+	return def, extModuleURLs, nil
+}
+
+// AppImageStaticFolders returns folders with static web content declared by STATIC statements
+// in the `pkg` folder of an application image
+func AppImageStaticFolders(pkgPath string) ([]AppImageStaticFolder, error) {
+	appSchemaAST, err := parseAppImageSchema(pkgPath, map[string]*url.URL{})
+	if err != nil {
+		return nil, err
+	}
+	res := []AppImageStaticFolder{}
+	for _, folder := range parser.StaticFolders(appSchemaAST) {
+		dir := filepath.Join(pkgPath, filepath.FromSlash(folder.PkgPath), filepath.FromSlash(folder.Source))
+		if exists, err := filesu.Exists(dir); err != nil || !exists {
+			return nil, fmt.Errorf("%w: static folder %s.%s: no %s folder", ErrAppImageInvalid, folder.PkgPath, folder.Name, folder.Source)
+		}
+		res = append(res, AppImageStaticFolder{StaticFolder: folder, FS: os.DirFS(dir)})
+	}
+	return res, nil
+}
+
+// ParseAppImage unzips the application image (.var file built by `vpm build`) into destDir and parses it.
+//
+// Returns the application definition and URLs of the wasm extension modules unzipped into destDir
+func ParseAppImage(image []byte, destDir string) (def appdef.IAppDef, extModuleURLs map[string]*url.URL, err error) {
+	if err := unzipAppImage(image, destDir); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrAppImageInvalid, err)
+	}
+	pkgPath := filepath.Join(destDir, appImagePkgDir)
+	if exists, err := filesu.Exists(pkgPath); err != nil || !exists {
+		return nil, nil, fmt.Errorf("%w: no %s folder", ErrAppImageInvalid, appImagePkgDir)
+	}
+	return ParseAppImageDir(pkgPath)
+}
+
+// extModuleURLs is filled here
+func parseAppImageSchema(pkgPath string, out_extModuleURLs map[string]*url.URL) (*parser.AppSchemaAST, error) {
+	appASTs, err := parseAppImageSubDir(pkgPath, pkgPath, out_extModuleURLs)
+	if err != nil {
+		return nil, err
+	}
+	return parser.BuildAppSchema(appASTs)
+}
+
+// extModuleURLs is filled here
+func parseAppImageSubDir(fullPath string, basePath string, out_extModuleURLs map[string]*url.URL) (asts []*parser.PackageSchemaAST, err error) {
+	dirEntries, err := os.ReadDir(fullPath)
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	modulePath := strings.ReplaceAll(fullPath, basePath, "")
+	modulePath = strings.TrimPrefix(modulePath, string(os.PathSeparator))
+	modulePath = strings.ReplaceAll(modulePath, string(os.PathSeparator), "/")
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			subASTs, err := parseAppImageSubDir(filepath.Join(fullPath, dirEntry.Name()), basePath, out_extModuleURLs)
+			if err != nil {
+				return nil, err
+			}
+			asts = append(asts, subASTs...)
+			continue
+		}
+		if filepath.Ext(dirEntry.Name()) == ".wasm" {
+			moduleURL, err := url.Parse("file:///" + filepath.Join(fullPath, dirEntry.Name()))
+			if err != nil {
+				// notest
+				return nil, err
+			}
+
+			out_extModuleURLs[modulePath] = moduleURL
+			continue
+		}
+	}
+
+	dirAST, err := parser.ParsePackageDir(modulePath, os.DirFS(fullPath).(filesu.IReadFS), ".")
+	if err == nil {
+		asts = append(asts, dirAST)
+	} else if !errors.Is(err, parser.ErrDirContainsNoSchemaFiles) {
+		return nil, err
+	}
+	return asts, nil
+}
+
+func unzipAppImage(image []byte, destDir string) error {
+	reader, err := zip.NewReader(bytes.NewReader(image), int64(len(image)))
+	if err != nil {
+		return err
+	}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		destFilePath := filepath.Join(destDir, file.Name) // #nosec G305 checked below
+		if !strings.HasPrefix(destFilePath, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal file path in the image: %s", file.Name)
+		}
+		if err := unzipAppImageFile(file, destFilePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unzipAppImageFile(file *zip.File, destFilePath string) error {
+	if err := os.MkdirAll(filepath.Dir(destFilePath), filesu.FileMode_DefaultForDir); err != nil {
+		// notest
+		return err
+	}
+	zippedFile, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer zippedFile.Close()
+	destFile, err := os.Create(destFilePath)
+	if err != nil {
+		// notest
+		return err
+	}
+	defer destFile.Close()
+	n, err := io.Copy(destFile, io.LimitReader(zippedFile, maxAppImageSize+1))
+	if err != nil {
+		return err
+	}
+	if n > maxAppImageSize {
+		return fmt.Errorf("file %s in the image is larger than %d bytes", file.Name, maxAppImageSize)
+	}
+	return nil
+}
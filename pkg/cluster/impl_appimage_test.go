@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cluster
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+func TestParseAppImage(t *testing.T) {
+	require := require.New(t)
+
+	// the same layout as `vpm build` produces: pkg/<package path>/*.vsql, *.wasm
+	image := zipDir(t, filepath.Join("..", "sys", "it", "testdata", "apps", "test2.app1", "image"))
+
+	def, extModuleURLs, err := ParseAppImage(image, t.TempDir())
+	require.NoError(err)
+	require.NotNil(def.Workspace(appdef.NewQName("sidecartestapp", "test2app1WS")))
+
+	require.Len(extModuleURLs, 1)
+	moduleURL, ok := extModuleURLs["github.com/voedger/sidecartestapp"]
+	require.True(ok)
+	require.FileExists(moduleURL.Path)
+
+	t.Run("should be error on invalid image", func(t *testing.T) {
+		_, _, err := ParseAppImage([]byte("not a zip"), t.TempDir())
+		require.ErrorIs(err, ErrAppImageInvalid)
+
+		_, _, err = ParseAppImage(zipFiles(t, map[string]string{"other/a.vsql": ""}), t.TempDir())
+		require.ErrorIs(err, ErrAppImageInvalid)
+	})
+
+	t.Run("should be error on file path outside the destination dir", func(t *testing.T) {
+		_, _, err := ParseAppImage(zipFiles(t, map[string]string{"../evil.vsql": ""}), t.TempDir())
+		require.ErrorIs(err, ErrAppImageInvalid)
+	})
+}
+
+func zipDir(t *testing.T, dir string) []byte {
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		files[filepath.ToSlash(rel)] = string(content)
+		return err
+	})
+	require.NoError(t, err)
+	return zipFiles(t, files)
+}
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	buf := bytes.NewBuffer(nil)
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestAppImageStaticFolders(t *testing.T) {
+	require := require.New(t)
+
+	destDir := t.TempDir()
+	require.NoError(unzipAppImage(zipDir(t, filepath.Join("..", "sys", "it", "testdata", "apps", "test2.app1", "image")), destDir))
+	pkgPath := filepath.Join(destDir, appImagePkgDir)
+	appPkgPath := filepath.Join(pkgPath, "github.com", "voedger", "sidecartestapp")
+	require.NoError(os.WriteFile(filepath.Join(appPkgPath, "static.vsql"), []byte(`STATIC Web SOURCE 'web/dist' SPA;`), 0600))
+
+	t.Run("should be error if the folder does not exist", func(t *testing.T) {
+		_, err := AppImageStaticFolders(pkgPath)
+		require.ErrorIs(err, ErrAppImageInvalid)
+	})
+
+	require.NoError(os.MkdirAll(filepath.Join(appPkgPath, "web", "dist"), 0700))
+	require.NoError(os.WriteFile(filepath.Join(appPkgPath, "web", "dist", "index.html"), []byte("<html></html>"), 0600))
+
+	folders, err := AppImageStaticFolders(pkgPath)
+	require.NoError(err)
+	require.Len(folders, 1)
+	require.Equal("github.com/voedger/sidecartestapp", folders[0].PkgPath)
+	require.Equal("Web", folders[0].Name)
+	require.True(folders[0].SPA)
+	content, err := fs.ReadFile(folders[0].FS, "index.html")
+	require.NoError(err)
+	require.Equal("<html></html>", string(content))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/iblobstorage"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/sys"
@@ -24,7 +25,10 @@ import (
 )
 
 // wrong to use IAppPartitions to get total NumAppPartition because the app the cmd is called for is not deployed yet
-func provideCmdDeployApp(asp istructs.IAppStructsProvider, time timeu.ITime, sidecarApps []appparts.SidecarApp) istructsmem.ExecCommandClosure {
+//
+// if AppImage is provided then the image is validated and recorded to wdoc.cluster.App here. The image is deployed by [IAppImagesDeployer]
+func provideCmdDeployApp(asp istructs.IAppStructsProvider, time timeu.ITime, sidecarApps []appparts.SidecarApp,
+	blobStorage iblobstorage.IBLOBStorage, appPartsPtr appparts.IAppPartitionsPtr) istructsmem.ExecCommandClosure {
 	return func(args istructs.ExecCommandArgs) (err error) {
 		appQNameStr := args.ArgumentObject.AsString(Field_AppQName)
 		appQName, err := appdef.ParseAppQName(appQNameStr)
@@ -59,6 +63,8 @@ func provideCmdDeployApp(asp istructs.IAppStructsProvider, time timeu.ITime, sid
 		}
 		numAppWorkspacesToDeploy := istructs.NumAppWorkspaces(numAppWSInt)      // nolint G115 checked above
 		numAppPartitionsToDeploy := istructs.NumAppPartitions(numPartitionsInt) // nolint G115 checked above
+
+		appImageBLOBID := args.ArgumentObject.AsRecordID(Field_AppImage)
 		if wdocAppRecordID != istructs.NullRecordID {
 			kb, err := args.State.KeyBuilder(sys.Storage_Record, qNameWDocApp)
 			if err != nil {
@@ -85,9 +91,28 @@ func provideCmdDeployApp(asp istructs.IAppStructsProvider, time timeu.ITime, sid
 			}
 
 			// idempotency: was deployed already and nothing changed -> do not initiaize app workspaces
+			if appImageBLOBID == istructs.NullRecordID || appImageBLOBID == appRec.AsRecordID(Field_AppImage) {
+				return nil
+			}
+
+			if err := validateAppImage(args, blobStorage, *appPartsPtr, appQName, appImageBLOBID); err != nil {
+				return err
+			}
+			appRecUpdater, err := args.Intents.UpdateValue(kb, appRec)
+			if err != nil {
+				// notest
+				return err
+			}
+			appRecUpdater.PutRecordID(Field_AppImage, appImageBLOBID)
 			return nil
 		}
 
+		if appImageBLOBID != istructs.NullRecordID {
+			if err := validateAppImage(args, blobStorage, *appPartsPtr, appQName, appImageBLOBID); err != nil {
+				return err
+			}
+		}
+
 		kb, err := args.State.KeyBuilder(sys.Storage_Record, qNameWDocApp)
 		if err != nil {
 			// notest
@@ -104,6 +129,12 @@ func provideCmdDeployApp(asp istructs.IAppStructsProvider, time timeu.ITime, sid
 		vb.PutInt32(Field_NumAppWorkspaces, int32(numAppWorkspacesToDeploy))
 		vb.PutInt32(Field_NumPartitions, int32(numAppPartitionsToDeploy))
 
+		if appImageBLOBID != istructs.NullRecordID {
+			vb.PutRecordID(Field_AppImage, appImageBLOBID)
+			// app workspaces are initialized on the image deployment
+			return nil
+		}
+
 		// Create storage if not exists
 		// Initialize appstructs data
 		// note: for builtin apps that does nothing because IAppStructs is already initialized (including storage initialization) on VVM wiring
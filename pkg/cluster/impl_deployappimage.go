@@ -0,0 +1,333 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdefcompat"
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/ihttp"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// reads the application image from the persistent BLOB uploaded to the cluster app workspace
+//
+// BLOBs of all apps are kept by sys/blobber
+func readAppImageBLOB(blobStorage iblobstorage.IBLOBStorage, wsid istructs.WSID, blobID istructs.RecordID) ([]byte, error) {
+	key := iblobstorage.PersistentBLOBKeyType{
+		ClusterAppID: istructs.ClusterAppID_sys_blobber,
+		WSID:         wsid,
+		BlobID:       blobID,
+	}
+	buf := bytes.NewBuffer(nil)
+	readBytes := uint64(0)
+	limiter := func(wantReadBytes uint64) error {
+		if readBytes += wantReadBytes; readBytes > maxAppImageSize {
+			return iblobstorage.ErrBLOBSizeQuotaExceeded
+		}
+		return nil
+	}
+	if err := blobStorage.ReadBLOB(context.Background(), &key, nil, buf, limiter); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unzips the application image into a new temporary dir and parses it
+//
+// cleanup removes the dir. Should be called when the app is deployed, i.e. extension modules are loaded already
+func parseAppImage(appQName appdef.AppQName, image []byte) (img *appImage, cleanup func(), err error) {
+	dir, err := os.MkdirTemp("", "voedger-"+appQName.Owner()+"."+appQName.Name()+"-*")
+	if err != nil {
+		// notest
+		return nil, nil, err
+	}
+	cleanup = func() {
+		if err := os.RemoveAll(dir); err != nil {
+			// notest
+			logger.Error(fmt.Sprintf("failed to remove image dir of app %s: %s", appQName, err))
+		}
+	}
+	def, extModuleURLs, err := ParseAppImage(image, dir)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return &appImage{def: def, extModuleURLs: extModuleURLs, pkgPath: filepath.Join(dir, appImagePkgDir)}, cleanup, nil
+}
+
+// validates the application image provided to c.cluster.DeployApp
+//
+// the image is not deployed here, see [IAppImagesDeployer]
+func validateAppImage(args istructs.ExecCommandArgs, blobStorage iblobstorage.IBLOBStorage, appParts appparts.IAppPartitions,
+	appQName appdef.AppQName, blobID istructs.RecordID) error {
+	if _, ok := istructs.ClusterApps[appQName]; !ok {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Sprintf("ClusterAppID for app %s is unknown", appQName))
+	}
+	image, err := readAppImageBLOB(blobStorage, args.WSID, blobID)
+	if err != nil {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Sprintf("failed to read image of app %s: %s", appQName, err))
+	}
+	img, cleanup, err := parseAppImage(appQName, image)
+	if err != nil {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Sprintf("failed to parse image of app %s: %s", appQName, err))
+	}
+	defer cleanup()
+	if _, err := AppImageStaticFolders(img.pkgPath); err != nil {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Sprintf("invalid image of app %s: %s", appQName, err))
+	}
+	deployedDef, err := appParts.AppDef(appQName)
+	if err != nil {
+		// not deployed yet
+		return nil
+	}
+	return checkAppImageCompatibility(deployedDef, appQName, img.def)
+}
+
+func checkAppImageCompatibility(deployedDef appdef.IAppDef, appQName appdef.AppQName, def appdef.IAppDef) error {
+	if cerrs := appdefcompat.CheckBackwardCompatibility(deployedDef, def); cerrs != nil && len(cerrs.Errors) > 0 {
+		return coreutils.NewHTTPErrorf(http.StatusConflict, fmt.Sprintf("%s: app %s: %s", ErrAppImageIncompatible, appQName, cerrs.Error()))
+	}
+	return nil
+}
+
+func (d *appImagesDeployer) Prepare() error {
+	return nil
+}
+
+func (d *appImagesDeployer) Run(ctx context.Context) {
+	timer := d.time.NewTimerChan(AppImagesDeployInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer:
+			if err := d.deployNew(); err != nil {
+				logger.Error(fmt.Sprintf("failed to deploy app images: %s", err))
+			}
+			timer = d.time.NewTimerChan(AppImagesDeployInterval)
+		}
+	}
+}
+
+func (d *appImagesDeployer) Bootstrap(clusterAppWSID istructs.WSID) error {
+	d.Lock()
+	d.wsid = clusterAppWSID
+	d.Unlock()
+	return d.deployNew()
+}
+
+func (d *appImagesDeployer) Deploy(event istructs.IPLogEvent) error {
+	d.Lock()
+	defer d.Unlock()
+	if d.wsid == istructs.NullWSID {
+		// the event will be read from the WLog on the bootstrap
+		return nil
+	}
+	d.accept(event.WLogOffset(), event)
+	return d.deployAccepted()
+}
+
+// reads the events written since the previous call from the WLog of the cluster app workspace and deploys the accepted images
+//
+// the WLog is read directly from the storage so the events written by other VVMs are read as well
+func (d *appImagesDeployer) deployNew() error {
+	d.Lock()
+	defer d.Unlock()
+	if d.wsid == istructs.NullWSID {
+		// not bootstrapped yet
+		return nil
+	}
+	as, err := d.asp.BuiltIn(istructs.AppQName_sys_cluster)
+	if err != nil {
+		// notest
+		return err
+	}
+	err = as.Events().ReadWLog(context.Background(), d.wsid, d.wlogOffset, istructs.ReadToTheEnd, func(wlogOffset istructs.Offset, event istructs.IWLogEvent) error {
+		d.accept(wlogOffset, event)
+		d.wlogOffset = wlogOffset + 1
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return d.deployAccepted()
+}
+
+// remembers the image accepted by the c.cluster.DeployApp event unless a newer image of the app is accepted already
+func (d *appImagesDeployer) accept(wlogOffset istructs.Offset, event istructs.IAbstractEvent) {
+	if event.QName() != qNameCmdDeployApp || !isWDocAppChanged(event) {
+		return
+	}
+	blobID := event.ArgumentObject().AsRecordID(Field_AppImage)
+	if blobID == istructs.NullRecordID {
+		// deployed without the image, e.g. builtin or sidecar app
+		return
+	}
+	appQName, err := appdef.ParseAppQName(event.ArgumentObject().AsString(Field_AppQName))
+	if err != nil {
+		// notest: validated by c.cluster.DeployApp
+		logger.Error(fmt.Sprintf("image of app %s is not deployed: %s", event.ArgumentObject().AsString(Field_AppQName), err))
+		return
+	}
+	if prev, ok := d.accepted[appQName]; ok && prev.wlogOffset >= wlogOffset {
+		return
+	}
+	d.accepted[appQName] = acceptedAppImage{
+		wlogOffset:       wlogOffset,
+		blobID:           blobID,
+		numPartitions:    istructs.NumAppPartitions(event.ArgumentObject().AsInt32(Field_NumPartitions)),    // nolint G115 validated by c.cluster.DeployApp
+		numAppWorkspaces: istructs.NumAppWorkspaces(event.ArgumentObject().AsInt32(Field_NumAppWorkspaces)), // nolint G115 validated by c.cluster.DeployApp
+	}
+}
+
+// deploys the accepted images that are not deployed yet
+//
+// the failed deployment is retried on the next call
+func (d *appImagesDeployer) deployAccepted() error {
+	for appQName, img := range d.accepted {
+		if d.deployed[appQName] >= img.wlogOffset {
+			continue
+		}
+		if err := d.deployImage(appQName, img); err != nil {
+			return err
+		}
+		d.deployed[appQName] = img.wlogOffset
+	}
+	return nil
+}
+
+// images that could not be read or parsed are not deployed, the error is logged only
+func (d *appImagesDeployer) deployImage(appQName appdef.AppQName, img acceptedAppImage) error {
+	image, err := readAppImageBLOB(d.blobStorage, d.wsid, img.blobID)
+	if err != nil {
+		if errors.Is(err, iblobstorage.ErrBLOBNotFound) {
+			logger.Error(fmt.Sprintf("image of app %s is not deployed: %s", appQName, err))
+			return nil
+		}
+		return err
+	}
+	parsed, cleanup, err := parseAppImage(appQName, image)
+	if err != nil {
+		// notest: validated by c.cluster.DeployApp
+		logger.Error(fmt.Sprintf("image of app %s is not deployed: %s", appQName, err))
+		return nil
+	}
+	defer cleanup()
+
+	appParts := *d.appPartsPtr
+	if _, err := appParts.AppDef(appQName); err == nil {
+		if err := appParts.RedeployApp(appQName, parsed.extModuleURLs, parsed.def, appImageEnginePoolSize); err != nil {
+			logger.Error(fmt.Sprintf("failed to redeploy app %s: %s", appQName, err))
+			return nil
+		}
+		logger.Info(fmt.Sprintf("app %s image redeployed", appQName))
+		d.staticFolders.deploy(appQName, parsed)
+		return nil
+	}
+
+	// Create storage if not exists, initialize app workspaces
+	// note: app workspaces are initialized already if the app is deployed on another VVM or before the VVM restart
+	as, err := d.asp.New(appQName, parsed.def, istructs.ClusterApps[appQName], img.numAppWorkspaces)
+	if err != nil {
+		return fmt.Errorf("failed to get IAppStructs for %s: %w", appQName, err)
+	}
+	if _, err = InitAppWSes(as, img.numAppWorkspaces, img.numPartitions, istructs.UnixMilli(d.time.Now().UnixMilli())); err != nil {
+		return fmt.Errorf("failed to deploy %s: %w", appQName, err)
+	}
+	deployAppImage(appParts, appQName, parsed, img.numPartitions, img.numAppWorkspaces)
+	d.staticFolders.deploy(appQName, parsed)
+	return nil
+}
+
+// c.cluster.DeployApp creates or updates wdoc.cluster.App if the application image should be deployed
+func isWDocAppChanged(event istructs.IAbstractEvent) bool {
+	for rec := range event.CUDs {
+		if rec.QName() == qNameWDocApp {
+			return true
+		}
+	}
+	return false
+}
+
+// deploys the new application and all its partitions
+//
+// with several VVMs the partitions are distributed among them by the app partitions controller
+func deployAppImage(appParts appparts.IAppPartitions, appQName appdef.AppQName, img *appImage,
+	numAppPartitions istructs.NumAppPartitions, numAppWorkspaces istructs.NumAppWorkspaces) {
+	appParts.DeployApp(appQName, img.extModuleURLs, img.def, numAppPartitions, appImageEnginePoolSize, numAppWorkspaces)
+	partitionIDs := make([]istructs.PartitionID, numAppPartitions)
+	for id := range partitionIDs {
+		partitionIDs[id] = istructs.PartitionID(id) // nolint G115 checked by NumPartitions validation
+	}
+	appParts.DeployAppPartitions(appQName, partitionIDs)
+	logger.Info(fmt.Sprintf("app %s image deployed: NumPartitions=%d, NumAppWorkspaces=%d", appQName, numAppPartitions, numAppWorkspaces))
+}
+
+// copies static folders of the image to a new dir and deploys them, the dir of the previously deployed image of the app is removed
+//
+// folders are served at /static/<AppQName.owner>/<AppQName.name>/<package path>/<static folder name>/
+func (s *appStaticFolders) deploy(appQName appdef.AppQName, img *appImage) {
+	s.Lock()
+	defer s.Unlock()
+
+	dir, folders, err := copyAppImageStaticFolders(appQName, img)
+	if err != nil {
+		logger.Error(fmt.Sprintf("static folders of app %s are not deployed: %s", appQName, err))
+		return
+	}
+	s.folders.DeployAppStaticFolders(appQName, folders)
+	prevDir := s.dirs[appQName]
+	if len(dir) > 0 {
+		s.dirs[appQName] = dir
+	} else {
+		delete(s.dirs, appQName)
+	}
+	if len(prevDir) > 0 {
+		if err := os.RemoveAll(prevDir); err != nil {
+			// notest
+			logger.Error(fmt.Sprintf("failed to remove static folders dir of app %s: %s", appQName, err))
+		}
+	}
+	logger.Info(fmt.Sprintf("app %s static folders deployed: %d", appQName, len(folders)))
+}
+
+// returns empty dir if the image has no static folders
+func copyAppImageStaticFolders(appQName appdef.AppQName, img *appImage) (dir string, folders map[string]ihttp.StaticFolder, err error) {
+	imageFolders, err := AppImageStaticFolders(img.pkgPath)
+	if err != nil || len(imageFolders) == 0 {
+		// validated by c.cluster.DeployApp
+		return "", nil, err
+	}
+	if dir, err = os.MkdirTemp("", "voedger-static-"+appQName.Owner()+"."+appQName.Name()+"-*"); err != nil {
+		// notest
+		return "", nil, err
+	}
+	folders = map[string]ihttp.StaticFolder{}
+	for _, folder := range imageFolders {
+		folderDir := filepath.Join(dir, filepath.FromSlash(folder.PkgPath), folder.Name)
+		if err := os.CopyFS(folderDir, folder.FS); err != nil {
+			// notest
+			_ = os.RemoveAll(dir)
+			return "", nil, err
+		}
+		folders[appQName.Owner()+"/"+appQName.Name()+"/"+folder.PkgPath+"/"+folder.Name] = ihttp.StaticFolder{
+			FS:      os.DirFS(folderDir),
+			Options: ihttp.StaticFolderOptions{SPA: folder.SPA},
+		}
+	}
+	return dir, folders, nil
+}
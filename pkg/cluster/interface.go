@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package cluster
+
+import (
+	"github.com/voedger/voedger/pkg/iservices"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// IAppImagesDeployer deploys the application images accepted by c.cluster.DeployApp on the current VVM.
+//
+// Each VVM of the cluster follows the WLog of the cluster app workspace from the first event,
+// so the image is deployed on each VVM and is redeployed on the VVM launch.
+type IAppImagesDeployer interface {
+	// Periodically deploys the images accepted since the previous check, see [AppImagesDeployInterval]
+	iservices.IService
+
+	// Deploys the images accepted by c.cluster.DeployApp so far.
+	//
+	// Should be called once on the bootstrap after the builtin and sidecar apps are deployed.
+	Bootstrap(clusterAppWSID istructs.WSID) error
+
+	// Deploys the image accepted by the c.cluster.DeployApp event if the image is newer than the deployed one.
+	//
+	// Called by the projector on the VVM that holds the cluster app partition, so the image
+	// is deployed there right after the command is committed. Does nothing until the VVM is bootstrapped.
+	Deploy(event istructs.IPLogEvent) error
+}
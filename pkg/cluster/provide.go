@@ -10,6 +10,8 @@ import (
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/ihttp"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
@@ -17,9 +19,16 @@ import (
 )
 
 func Provide(cfg *istructsmem.AppConfigType, asp istructs.IAppStructsProvider, time timeu.ITime,
-	federation federation.IFederation, itokens itokens.ITokens, sidecarApps []appparts.SidecarApp) parser.PackageFS {
-	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName(ClusterPackage, "DeployApp"),
-		provideCmdDeployApp(asp, time, sidecarApps)))
+	federation federation.IFederation, itokens itokens.ITokens, sidecarApps []appparts.SidecarApp,
+	blobStorage iblobstorage.IBLOBStorage, appPartsPtr appparts.IAppPartitionsPtr, appImagesDeployer IAppImagesDeployer) parser.PackageFS {
+	cfg.Resources.Add(istructsmem.NewCommandFunction(qNameCmdDeployApp,
+		provideCmdDeployApp(asp, time, sidecarApps, blobStorage, appPartsPtr)))
+	cfg.AddAsyncProjectors(istructs.Projector{
+		Name: qNameProjectorApplyDeployAppImage,
+		Func: func(event istructs.IPLogEvent, _ istructs.IState, _ istructs.IIntents) error {
+			return appImagesDeployer.Deploy(event)
+		},
+	})
 	cfg.Resources.Add(istructsmem.NewCommandFunction(appdef.NewQName(ClusterPackage, "VSqlUpdate"),
 		provideExecCmdVSqlUpdate(federation, itokens, time, asp)))
 	cfg.Resources.Add(istructsmem.NewCommandFunction(qNameCmdLogVSqlUpdate, istructsmem.NullCommandExec))
@@ -30,3 +39,20 @@ func Provide(cfg *istructsmem.AppConfigType, asp istructs.IAppStructsProvider, t
 		FS:   schemaFS,
 	}
 }
+
+func ProvideAppImagesDeployer(asp istructs.IAppStructsProvider, time timeu.ITime, blobStorage iblobstorage.IBLOBStorage,
+	appPartsPtr appparts.IAppPartitionsPtr, staticFolders ihttp.IAppStaticFolders) IAppImagesDeployer {
+	return &appImagesDeployer{
+		asp:         asp,
+		time:        time,
+		blobStorage: blobStorage,
+		appPartsPtr: appPartsPtr,
+		staticFolders: &appStaticFolders{
+			folders: staticFolders,
+			dirs:    map[appdef.AppQName]string{},
+		},
+		wlogOffset: istructs.FirstOffset,
+		accepted:   map[appdef.AppQName]acceptedAppImage{},
+		deployed:   map[appdef.AppQName]istructs.Offset{},
+	}
+}
@@ -6,10 +6,18 @@
 package cluster
 
 import (
+	"io/fs"
+	"net/url"
+	"sync"
+
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/dml"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/ihttp"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/parser"
 )
 
 type update struct {
@@ -24,3 +32,46 @@ type update struct {
 	appParts      appparts.IAppPartitions
 	qNameTypeKind appdef.TypeKind
 }
+
+// application image parsed on c.cluster.DeployApp
+type appImage struct {
+	def           appdef.IAppDef
+	extModuleURLs map[string]*url.URL
+	pkgPath       string // `pkg` folder of the unzipped image
+}
+
+// static folders of the application images deployed on the VVM
+//
+// folders are copied out of the image dir because it is removed after the deployment
+type appStaticFolders struct {
+	sync.Mutex
+	folders ihttp.IAppStaticFolders
+	dirs    map[appdef.AppQName]string // dir with the copied static folders of the app
+}
+
+// application image accepted by c.cluster.DeployApp
+type acceptedAppImage struct {
+	wlogOffset       istructs.Offset   // offset of the c.cluster.DeployApp event in the cluster app workspace
+	blobID           istructs.RecordID // persistent BLOB with the image
+	numPartitions    istructs.NumAppPartitions
+	numAppWorkspaces istructs.NumAppWorkspaces
+}
+
+type appImagesDeployer struct {
+	sync.Mutex
+	asp           istructs.IAppStructsProvider
+	time          timeu.ITime
+	blobStorage   iblobstorage.IBLOBStorage
+	appPartsPtr   appparts.IAppPartitionsPtr
+	staticFolders *appStaticFolders
+	wsid          istructs.WSID                        // cluster app workspace, NullWSID until the bootstrap
+	wlogOffset    istructs.Offset                      // next WLog event of the cluster app workspace to read
+	accepted      map[appdef.AppQName]acceptedAppImage // the last accepted image of the app
+	deployed      map[appdef.AppQName]istructs.Offset  // wlogOffset of the deployed image of the app
+}
+
+// AppImageStaticFolder is a folder with static web content of the application image
+type AppImageStaticFolder struct {
+	parser.StaticFolder
+	FS fs.FS // content of the folder
+}
@@ -77,6 +77,7 @@ func compile(dir string, checkAppSchema bool) (*Result, error) {
 		ModulePath:   loadedPkgs.packagePath,
 		PkgFiles:     pkgFiles,
 		NotFoundDeps: maps.Keys(notFoundDeps),
+		Packages:     nonNilPackages,
 	}
 	// build app defs from app schema
 	if appAst != nil {
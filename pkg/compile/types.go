@@ -9,6 +9,7 @@ import (
 	"golang.org/x/tools/go/packages"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/parser"
 )
 
 // Result is a result of compilation
@@ -17,7 +18,8 @@ type Result struct {
 	PkgFiles      map[string][]string // map of package path to list of file paths belonging to the package
 	AppDef        appdef.IAppDef
 	AppDefBuilder appdef.IAppDefBuilder
-	NotFoundDeps  []string // list of not found dependencies faced during compilation
+	NotFoundDeps  []string                   // list of not found dependencies faced during compilation
+	Packages      []*parser.PackageSchemaAST // parsed packages, including dependencies. Packages with syntax errors are omitted
 }
 
 type loadedPackages struct {
@@ -18,4 +18,8 @@ const (
 	emailVerificationCodeAlphabet = "1234567890"
 	lowercaseDigitsAlphabet       = "abcdefghijklmnopqrstuvwxyz234567"
 	deviceLoginAndPwdLen          = 26
+	inviteLinkCodeLen             = 32
+	apiKeySecretLen               = 52
+	totpRecoveryCodeLen           = 10
+	unusablePasswordLen           = 52
 )
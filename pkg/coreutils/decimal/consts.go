@@ -0,0 +1,8 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package decimal
+
+// Maximum total number of decimal digits, 10^MaxPrecision-1 fits int64
+const MaxPrecision = 18
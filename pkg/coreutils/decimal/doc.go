@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+// Package decimal implements exact fixed-point arithmetic for sys.Currency values.
+//
+// The value is int64 scaled by 10^Scale and limited by Precision digits, both are declared by Spec.
+// The package has no dependencies, so it is used both by the server and by WASM extensions
+package decimal
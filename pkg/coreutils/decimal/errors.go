@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package decimal
+
+import "errors"
+
+var (
+	ErrSyntax         = errors.New("invalid decimal syntax")
+	ErrScale          = errors.New("too many fractional digits")
+	ErrOutOfRange     = errors.New("decimal value is out of range")
+	ErrDivisionByZero = errors.New("decimal division by zero")
+)
@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package decimal
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// One returns 1 scaled by 10^Scale
+func (t Spec) One() int64 {
+	return Pow10(t.Scale)
+}
+
+// MaxValue returns maximum value, e.g. 99_999_999_999.9999 scaled by 10^Scale for currency(15,4)
+func (t Spec) MaxValue() int64 {
+	return Pow10(t.Precision) - 1
+}
+
+// MinValue returns minimum value, e.g. -99_999_999_999.9999 scaled by 10^Scale for currency(15,4)
+func (t Spec) MinValue() int64 {
+	return -t.MaxValue()
+}
+
+// Check returns v as is if it is in [MinValue, MaxValue], ErrOutOfRange otherwise
+func (t Spec) Check(v int64) (int64, error) {
+	if v < t.MinValue() || v > t.MaxValue() {
+		return 0, ErrOutOfRange
+	}
+	return v, nil
+}
+
+// Parse parses decimal string, e.g. "-12.34", to value scaled by 10^Scale.
+//
+// Optional sign is allowed at the beginning only, the point must be followed by 1..Scale digits.
+// Returns ErrSyntax, ErrScale or ErrOutOfRange
+func (t Spec) Parse(s string) (int64, error) {
+	digits := s
+	neg := false
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		neg = digits[0] == '-'
+		digits = digits[1:]
+	}
+	intPart, fracPart, hasPoint := strings.Cut(digits, ".")
+	if !isDigits(intPart) || !isDigits(fracPart) || (len(intPart) == 0 && len(fracPart) == 0) || (hasPoint && len(fracPart) == 0) {
+		return 0, wrap(ErrSyntax, s)
+	}
+	if len(fracPart) > t.Scale {
+		return 0, wrap(ErrScale, s)
+	}
+	intPart = strings.TrimLeft(intPart, "0")
+	if len(intPart) > t.Precision-t.Scale {
+		return 0, wrap(ErrOutOfRange, s)
+	}
+	v := int64(0)
+	for _, d := range intPart + fracPart + strings.Repeat("0", t.Scale-len(fracPart)) {
+		v = v*10 + int64(d-'0')
+	}
+	if neg {
+		v = -v
+	}
+	return v, nil
+}
+
+// Format returns decimal string with Scale fractional digits, e.g. "-12.3400" for currency(15,4)
+func (t Spec) Format(v int64) string {
+	sign := ""
+	u := uint64(v)
+	if v < 0 {
+		sign = "-"
+		u = -u
+	}
+	one := uint64(t.One())
+	s := sign + strconv.FormatUint(u/one, 10)
+	if t.Scale > 0 {
+		frac := strconv.FormatUint(u%one, 10)
+		s += "." + strings.Repeat("0", t.Scale-len(frac)) + frac
+	}
+	return s
+}
+
+// FromInt returns n scaled by 10^Scale.
+//
+// Returns ErrOutOfRange if result is out of [MinValue, MaxValue]
+func (t Spec) FromInt(n int64) (int64, error) {
+	return t.mulDiv(n, t.One(), 1)
+}
+
+// Add returns a + b.
+//
+// Returns ErrOutOfRange if result is out of [MinValue, MaxValue]
+func (t Spec) Add(a, b int64) (int64, error) {
+	return t.Check(a + b)
+}
+
+// Sub returns a - b.
+//
+// Returns ErrOutOfRange if result is out of [MinValue, MaxValue]
+func (t Spec) Sub(a, b int64) (int64, error) {
+	return t.Check(a - b)
+}
+
+// MulInt returns a * n.
+//
+// Returns ErrOutOfRange if result is out of [MinValue, MaxValue]
+func (t Spec) MulInt(a, n int64) (int64, error) {
+	return t.mulDiv(a, n, 1)
+}
+
+// Mul returns a * b rounded half to even to Scale digits.
+//
+// Returns ErrOutOfRange if result is out of [MinValue, MaxValue]
+func (t Spec) Mul(a, b int64) (int64, error) {
+	return t.mulDiv(a, b, t.One())
+}
+
+// Div returns a / b rounded half to even to Scale digits.
+//
+// Returns ErrDivisionByZero if b is zero, ErrOutOfRange if result is out of [MinValue, MaxValue]
+func (t Spec) Div(a, b int64) (int64, error) {
+	return t.mulDiv(a, t.One(), b)
+}
+
+// Round returns v rounded half to even to specified number of fractional digits.
+//
+// Returns v as is if digits is not less than Scale, ErrOutOfRange if result is out of [MinValue, MaxValue]
+func (t Spec) Round(v int64, digits int) (int64, error) {
+	if digits >= t.Scale {
+		return v, nil
+	}
+	p := Pow10(t.Scale - max(digits, 0))
+	q, err := MulDiv(v, 1, p)
+	if err != nil {
+		// notest: quotient is less than v
+		return 0, err
+	}
+	return t.Check(q * p)
+}
+
+// Rescale converts v scaled by 10^scale to value of the type, rounding half to even if scale is greater than Scale.
+//
+// Returns ErrOutOfRange if result is out of [MinValue, MaxValue]
+func (t Spec) Rescale(v int64, scale int) (int64, error) {
+	if scale > t.Scale {
+		return t.mulDiv(v, 1, Pow10(scale-t.Scale))
+	}
+	return t.mulDiv(v, Pow10(t.Scale-scale), 1)
+}
+
+// returns a * b / c rounded half to even, checks the result range
+func (t Spec) mulDiv(a, b, c int64) (int64, error) {
+	v, err := MulDiv(a, b, c)
+	if err != nil {
+		return 0, err
+	}
+	return t.Check(v)
+}
+
+// MulDiv returns a * b / c rounded half to even, the product is calculated in 128 bits.
+//
+// Returns ErrDivisionByZero if c is zero, ErrOutOfRange if result exceeds MaxPrecision digits
+func MulDiv(a, b, c int64) (int64, error) {
+	if c == 0 {
+		return 0, ErrDivisionByZero
+	}
+	neg := (a < 0) != (b < 0) != (c < 0)
+	uc := abs(c)
+	hi, lo := bits.Mul64(abs(a), abs(b))
+	if hi >= uc {
+		return 0, ErrOutOfRange
+	}
+	q, r := bits.Div64(hi, lo, uc)
+	if r > uc-r || (r == uc-r && q%2 != 0) {
+		q++
+	}
+	if q >= uint64(Pow10(MaxPrecision)) {
+		return 0, ErrOutOfRange
+	}
+	if neg {
+		return -int64(q), nil
+	}
+	return int64(q), nil
+}
+
+// Pow10 returns 10^n, n must be in [0, MaxPrecision]
+func Pow10(n int) int64 {
+	p := int64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
+
+func abs(v int64) uint64 {
+	if v < 0 {
+		return -uint64(v)
+	}
+	return uint64(v)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func wrap(err error, s string) error {
+	return fmt.Errorf("%w: «%s»", err, s)
+}
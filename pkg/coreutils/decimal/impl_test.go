@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package decimal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// currency(15,4), the default sys.Currency type
+var cur = Spec{Precision: 15, Scale: 4}
+
+func TestParseFormat(t *testing.T) {
+	require := require.New(t)
+
+	for s, want := range map[string]string{
+		"12":                 "12.0000",
+		"+12.3":              "12.3000",
+		"-0.5":               "-0.5000",
+		".01":                "0.0100",
+		"-.01":               "-0.0100",
+		"-1.2345":            "-1.2345",
+		"0007":               "7.0000",
+		"99999999999.9999":   "99999999999.9999",
+		"-99999999999.9999":  "-99999999999.9999",
+		"00099999999999.999": "99999999999.9990",
+	} {
+		v, err := cur.Parse(s)
+		require.NoError(err, s)
+		require.Equal(want, cur.Format(v), s)
+	}
+
+	for s, wantErr := range map[string]error{
+		"":                 ErrSyntax,
+		".":                ErrSyntax,
+		"-":                ErrSyntax,
+		"12.":              ErrSyntax,
+		".+5":              ErrSyntax,
+		"1.-5":             ErrSyntax,
+		"--1":              ErrSyntax,
+		"1e5":              ErrSyntax,
+		" 1":               ErrSyntax,
+		"1.2.3":            ErrSyntax,
+		"1.23456":          ErrScale,
+		"100000000000":     ErrOutOfRange,
+		"-100000000000.01": ErrOutOfRange,
+	} {
+		_, err := cur.Parse(s)
+		require.ErrorIs(err, wantErr, s)
+	}
+
+	t.Run("declared precision and scale", func(t *testing.T) {
+		money := Spec{Precision: 18, Scale: 2}
+		v, err := money.Parse("-9999999999999999.99")
+		require.NoError(err)
+		require.Equal(int64(-999_999_999_999_999_999), v)
+		require.Equal("-9999999999999999.99", money.Format(v))
+		_, err = money.Parse("0.001")
+		require.ErrorIs(err, ErrScale)
+
+		qty := Spec{Precision: 5, Scale: 0}
+		v, err = qty.Parse("-12")
+		require.NoError(err)
+		require.Equal(int64(-12), v)
+		require.Equal("-12", qty.Format(v))
+		_, err = qty.Parse("1.0")
+		require.ErrorIs(err, ErrScale)
+		_, err = qty.Parse("100000")
+		require.ErrorIs(err, ErrOutOfRange)
+	})
+}
+
+func TestArithmetic(t *testing.T) {
+	require := require.New(t)
+
+	parse := func(s string) int64 {
+		v, err := cur.Parse(s)
+		require.NoError(err)
+		return v
+	}
+	check := func(want string) func(v int64, err error) {
+		return func(v int64, err error) {
+			require.NoError(err)
+			require.Equal(want, cur.Format(v))
+		}
+	}
+
+	price := parse("19.99")
+	check("20.0000")(cur.Add(price, parse("0.01")))
+	check("0.0000")(cur.Sub(price, price))
+	check("59.9700")(cur.MulInt(price, 3))
+	check("20.0000")(cur.FromInt(20))
+	check("1.4493")(cur.Mul(price, parse("0.0725"))) // 1.449275
+	check("6.6633")(cur.Div(price, parse("3")))
+	check("-6.6633")(cur.Div(price, parse("-3")))
+	check("-6.6633")(cur.Div(-price, parse("3")))
+	check("6.6633")(cur.Div(-price, parse("-3")))
+
+	t.Run("exact on large values", func(t *testing.T) {
+		check("99999999999.9999")(cur.Mul(cur.MaxValue(), cur.One()))
+		check("-99999999999.9999")(cur.Mul(cur.MaxValue(), -cur.One()))
+		check("33333333333.3333")(cur.Div(cur.MaxValue(), parse("3")))
+		check("9999999980.0000")(cur.Mul(parse("99999.9999"), parse("99999.9999"))) // 9999999980.00000001
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		for _, err := range []error{
+			func() error { _, err := cur.Add(cur.MaxValue(), 1); return err }(),
+			func() error { _, err := cur.Sub(cur.MinValue(), 1); return err }(),
+			func() error { _, err := cur.MulInt(cur.MaxValue(), 2); return err }(),
+			func() error { _, err := cur.FromInt(100_000_000_000); return err }(),
+			func() error { _, err := cur.Mul(cur.MaxValue(), cur.MaxValue()); return err }(),
+			func() error { _, err := cur.Mul(cur.MinValue(), parse("1.0001")); return err }(),
+			func() error { _, err := cur.Div(cur.MaxValue(), parse("0.5")); return err }(),
+			func() error { _, err := cur.Round(cur.MaxValue(), 0); return err }(),
+		} {
+			require.ErrorIs(err, ErrOutOfRange)
+		}
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+		_, err := cur.Div(cur.One(), 0)
+		require.ErrorIs(err, ErrDivisionByZero)
+	})
+}
+
+func TestRound(t *testing.T) {
+	require := require.New(t)
+
+	for s, want := range map[string]string{
+		"2.005":  "2.0000",
+		"2.015":  "2.0200",
+		"2.0051": "2.0100",
+		"-2.015": "-2.0200",
+		"-2.005": "-2.0000",
+		"2.5":    "2.0000",
+		"3.5":    "4.0000",
+	} {
+		v, err := cur.Parse(s)
+		require.NoError(err)
+		digits := 2
+		if s == "2.5" || s == "3.5" {
+			digits = 0
+		}
+		r, err := cur.Round(v, digits)
+		require.NoError(err)
+		require.Equal(want, cur.Format(r), s)
+	}
+
+	v, err := cur.Round(12345, cur.Scale)
+	require.NoError(err)
+	require.Equal(int64(12345), v)
+}
+
+func TestRescale(t *testing.T) {
+	require := require.New(t)
+
+	money := Spec{Precision: 18, Scale: 2}
+
+	v, err := money.Rescale(12_3456, cur.Scale) // 12.3456
+	require.NoError(err)
+	require.Equal("12.35", money.Format(v))
+
+	v, err = cur.Rescale(-12_35, money.Scale) // -12.35
+	require.NoError(err)
+	require.Equal("-12.3500", cur.Format(v))
+
+	_, err = cur.Rescale(money.MaxValue(), money.Scale)
+	require.ErrorIs(err, ErrOutOfRange)
+}
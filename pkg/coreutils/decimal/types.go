@@ -0,0 +1,17 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package decimal
+
+// Spec is the decimal type, e.g. currency(15,4).
+//
+// Values of the type are int64 scaled by 10^Scale and limited by Precision digits.
+// Precision must be in [1, MaxPrecision], Scale must be in [0, Precision]
+type Spec struct {
+	// Total number of decimal digits
+	Precision int
+
+	// Number of decimal digits after the point
+	Scale int
+}
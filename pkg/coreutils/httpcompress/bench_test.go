@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpcompress
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+)
+
+/*
+goos: linux
+goarch: amd64
+pkg: github.com/voedger/voedger/pkg/coreutils/httpcompress
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkCompress/identity         	   15996	     76876 ns/op	1207.09 MB/s	  262512 B/op	      21 allocs/op
+BenchmarkCompress/gzip             	    2814	    438606 ns/op	 211.57 MB/s	         0.08742 ratio	   20436 B/op	      24 allocs/op
+BenchmarkCompress/zstd             	    3394	    306768 ns/op	 302.50 MB/s	         0.03111 ratio	    7501 B/op	      18 allocs/op
+*/
+func BenchmarkCompress(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString(`{"results":[`)
+	for i := range 1000 {
+		fmt.Fprintf(&sb, `{"sys.ID":%d,"sys.QName":"app.Article","Name":"Article %d","Price":%d.5,"Active":true},`, 100000+i, i, i)
+	}
+	sb.WriteString(`{}]}`)
+	body := []byte(sb.String())
+
+	handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(httpu.ContentType, httpu.ContentType_ApplicationJSON)
+		// the same as the router does: row by row
+		for i := 0; i < len(body); i += 100 {
+			_, _ = w.Write(body[i:min(i+100, len(body))])
+		}
+	}), DefaultMinSize)
+
+	for _, encoding := range []string{"identity", encodingGzip, encodingZstd} {
+		b.Run(encoding, func(b *testing.B) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(headerAcceptEncoding, encoding)
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			compressed := 0
+			for b.Loop() {
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, req)
+				compressed = w.Body.Len()
+				_, _ = io.Copy(io.Discard, w.Body)
+			}
+			if encoding != "identity" {
+				b.ReportMetric(float64(compressed)/float64(len(body)), "ratio")
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpcompress
+
+import "time"
+
+// responses smaller than this are sent uncompressed: compression of small responses costs more than it saves
+const DefaultMinSize = 1024
+
+// flushes of the compressed response are coalesced within this interval:
+// the reply path flushes after each element and flushing the compressor each time ruins the compression ratio
+const flushDelay = 10 * time.Millisecond
+
+const (
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+	anyEncoding  = "*"
+)
+
+const (
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+	headerContentRange    = "Content-Range"
+	headerETag            = "ETag"
+	headerRange           = "Range"
+	headerUpgrade         = "Upgrade"
+	headerVary            = "Vary"
+	weakETagPrefix        = "W/"
+)
+
+// encodings supported by the server in the preference order
+var supportedEncodings = []string{encodingZstd, encodingGzip}
+
+// content types that are worth to compress, the rest (images, archives, binary blobs) are usually compressed already
+var compressibleContentTypes = []string{
+	"text/",
+	"application/json",
+	"application/x-ndjson",
+	"application/javascript",
+	"application/xml",
+	"application/wasm",
+	"image/svg+xml",
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpcompress
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+)
+
+var encoderPools = map[string]*sync.Pool{
+	encodingGzip: {New: func() any {
+		return gzip.NewWriter(io.Discard)
+	}},
+	encodingZstd: {New: func() any {
+		enc, err := zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+		if err != nil {
+			// notest
+			panic(err)
+		}
+		return enc
+	}},
+}
+
+func (h *compressHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Add(headerVary, headerAcceptEncoding)
+	encoding := negotiateEncoding(req.Header.Get(headerAcceptEncoding))
+	if encoding == "" || req.Method == http.MethodHead || req.Header.Get(headerRange) != "" || req.Header.Get(headerUpgrade) != "" {
+		// the response writer of the upgraded connection, e.g. WebSocket, must be hijackable
+		h.next.ServeHTTP(w, req)
+		return
+	}
+	cw := &compressWriter{ResponseWriter: w, minSize: h.minSize, encoding: encoding}
+	defer cw.close()
+	h.next.ServeHTTP(cw, req)
+}
+
+// returns the most preferred encoding supported by both the client and the server, empty string if there is no such encoding
+func negotiateEncoding(acceptEncoding string) (res string) {
+	if acceptEncoding == "" {
+		return ""
+	}
+	bestQ := 0.0
+	qValues := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if qStr, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(qStr, 64); err != nil {
+				continue
+			}
+		}
+		qValues[strings.ToLower(coding)] = q
+	}
+	for _, encoding := range supportedEncodings {
+		q, ok := qValues[encoding]
+		if !ok {
+			q = qValues[anyEncoding]
+		}
+		if q > bestQ {
+			res, bestQ = encoding, q
+		}
+	}
+	return res
+}
+
+func isCompressible(contentType string) bool {
+	if strings.HasPrefix(contentType, httpu.ContentType_TextEventStream) {
+		// events must be delivered immediately
+		return false
+	}
+	for _, prefix := range compressibleContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *compressWriter) WriteHeader(statusCode int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.writeHeader(statusCode)
+}
+
+func (w *compressWriter) writeHeader(statusCode int) {
+	if w.status != 0 {
+		// the same as http.ResponseWriter does: superfluous call is ignored
+		return
+	}
+	w.status = statusCode
+	if !w.canCompress() {
+		w.decide(false)
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.status == 0 {
+		w.writeHeader(http.StatusOK)
+	}
+	if w.decided {
+		if w.enc != nil {
+			return w.enc.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minSize {
+		if err := w.flushBuf(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// uncompressed response is flushed immediately, flushes of the compressed or the held response are delayed by flushDelay
+func (w *compressWriter) Flush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.decided && w.enc == nil {
+		w.flushResponseWriter()
+		return
+	}
+	if w.flushTimer == nil {
+		w.flushTimer = time.AfterFunc(flushDelay, w.delayedFlush)
+	}
+}
+
+func (w *compressWriter) delayedFlush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.flushTimer = nil
+	if w.closed || w.status == 0 {
+		return
+	}
+	if !w.decided {
+		// response is streamed slowly, no reason to wait for minSize bytes
+		if err := w.flushBuf(true); err != nil {
+			return
+		}
+	}
+	if w.enc != nil {
+		if err := w.enc.Flush(); err != nil {
+			return
+		}
+	}
+	w.flushResponseWriter()
+}
+
+func (w *compressWriter) flushResponseWriter() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// for http.ResponseController
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) canCompress() bool {
+	header := w.Header()
+	switch {
+	case w.status < http.StatusOK, w.status == http.StatusNoContent, w.status == http.StatusNotModified, w.status == http.StatusPartialContent:
+		return false
+	case header.Get(headerContentEncoding) != "", header.Get(headerContentRange) != "":
+		return false
+	}
+	return isCompressible(header.Get(httpu.ContentType))
+}
+
+// sends the response header, compress == false -> the response is sent as is
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+	if compress {
+		header := w.Header()
+		header.Set(headerContentEncoding, w.encoding)
+		header.Del(httpu.ContentLength)
+		if etag := header.Get(headerETag); etag != "" && !strings.HasPrefix(etag, weakETagPrefix) {
+			// compressed representation is not byte-to-byte equal to the original one
+			header.Set(headerETag, weakETagPrefix+etag)
+		}
+		w.enc = encoderPools[w.encoding].Get().(encoder)
+		w.enc.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *compressWriter) flushBuf(compress bool) error {
+	w.decide(compress && w.canCompress())
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// called when the handler is finished, the response writer must not be used after that
+func (w *compressWriter) close() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.closed = true
+	if w.flushTimer != nil {
+		w.flushTimer.Stop()
+	}
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		// the whole response is smaller than the threshold
+		if err := w.flushBuf(false); err != nil {
+			return
+		}
+	}
+	if w.enc != nil {
+		// error here means that the client is gone, nothing to do
+		_ = w.enc.Close()
+		w.enc.Reset(io.Discard)
+		encoderPools[w.encoding].Put(w.enc)
+		w.enc = nil
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpcompress
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+)
+
+const testMinSize = 100
+
+func serve(handler http.HandlerFunc, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	NewHandler(handler, testMinSize).ServeHTTP(rec, req)
+	return rec
+}
+
+func jsonHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(httpu.ContentType, httpu.ContentType_ApplicationJSON)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	var r io.Reader
+	switch rec.Header().Get(headerContentEncoding) {
+	case encodingGzip:
+		gr, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		r = gr
+	case encodingZstd:
+		zr, err := zstd.NewReader(rec.Body)
+		require.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	default:
+		r = rec.Body
+	}
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	large := `{"results":[` + strings.Repeat(`{"name":"value"},`, 100) + `{}]}`
+
+	t.Run("should negotiate encoding", func(t *testing.T) {
+		cases := map[string]string{
+			"":                       "",
+			"gzip":                   encodingGzip,
+			"gzip, deflate, br":      encodingGzip,
+			"zstd, gzip":             encodingZstd,
+			"gzip;q=1, zstd;q=0.5":   encodingGzip,
+			"zstd;q=0, gzip":         encodingGzip,
+			"*":                      encodingZstd,
+			"*;q=0.1, gzip;q=0.5":    encodingGzip,
+			"identity":               "",
+			"gzip;q=0, zstd;q=0":     "",
+			"GZIP":                   encodingGzip,
+			"gzip;q=wrong, zstd;q=1": encodingZstd,
+		}
+		for acceptEncoding, expected := range cases {
+			rec := serve(jsonHandler(large), map[string]string{headerAcceptEncoding: acceptEncoding})
+			require.Equal(expected, rec.Header().Get(headerContentEncoding), acceptEncoding)
+			require.Equal(large, decode(t, rec), acceptEncoding)
+			require.Equal(headerAcceptEncoding, rec.Header().Get(headerVary))
+		}
+	})
+
+	t.Run("small response should not be compressed", func(t *testing.T) {
+		rec := serve(jsonHandler(`{"small":true}`), map[string]string{headerAcceptEncoding: encodingGzip})
+		require.Empty(rec.Header().Get(headerContentEncoding))
+		require.Equal(`{"small":true}`, rec.Body.String())
+	})
+
+	t.Run("status code should be kept", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set(httpu.ContentType, httpu.ContentType_ApplicationJSON)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(large))
+		}, map[string]string{headerAcceptEncoding: encodingGzip})
+		require.Equal(http.StatusBadRequest, rec.Code)
+		require.Equal(encodingGzip, rec.Header().Get(headerContentEncoding))
+		require.Equal(large, decode(t, rec))
+
+		rec = serve(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}, map[string]string{headerAcceptEncoding: encodingGzip})
+		require.Equal(http.StatusNoContent, rec.Code)
+		require.Empty(rec.Header().Get(headerContentEncoding))
+	})
+
+	t.Run("should not compress", func(t *testing.T) {
+		cases := map[string]http.HandlerFunc{
+			"binary content": func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set(httpu.ContentType, "image/png")
+				_, _ = w.Write([]byte(large))
+			},
+			"event stream": func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set(httpu.ContentType, httpu.ContentType_TextEventStream)
+				_, _ = w.Write([]byte(large))
+			},
+			"already encoded": func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set(httpu.ContentType, httpu.ContentType_ApplicationJSON)
+				w.Header().Set(headerContentEncoding, "br")
+				_, _ = w.Write([]byte(large))
+			},
+		}
+		for name, handler := range cases {
+			rec := serve(handler, map[string]string{headerAcceptEncoding: encodingGzip})
+			require.NotEqual(encodingGzip, rec.Header().Get(headerContentEncoding), name)
+			require.Equal(large, rec.Body.String(), name)
+		}
+
+		rec := serve(jsonHandler(large), map[string]string{headerAcceptEncoding: encodingGzip, headerRange: "bytes=0-10"})
+		require.Empty(rec.Header().Get(headerContentEncoding), "ranged request")
+	})
+
+	t.Run("ETag should become weak, Content-Length should be removed", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set(httpu.ContentType, httpu.ContentType_ApplicationJSON)
+			w.Header().Set(headerETag, `"abc"`)
+			w.Header().Set(httpu.ContentLength, "1000")
+			_, _ = w.Write([]byte(large))
+		}, map[string]string{headerAcceptEncoding: encodingZstd})
+		require.Equal(`W/"abc"`, rec.Header().Get(headerETag))
+		require.Empty(rec.Header().Get(httpu.ContentLength))
+		require.Equal(large, decode(t, rec))
+	})
+}
+
+func TestStreaming(t *testing.T) {
+	for name, row := range map[string]string{
+		"large rows": `{"row":"` + strings.Repeat("x", testMinSize) + `"}` + "\n",
+		"small rows": `{"row":1}` + "\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			rowsWritten := make(chan struct{})
+			rowsRead := make(chan struct{})
+			server := httptest.NewServer(NewHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set(httpu.ContentType, "application/x-ndjson")
+				_, _ = w.Write([]byte(row))
+				w.(http.Flusher).Flush()
+				close(rowsWritten)
+				<-rowsRead
+				_, _ = w.Write([]byte(row))
+			}), testMinSize))
+			defer server.Close()
+
+			resp, err := http.Get(server.URL) // gzip is requested and decoded by the transport
+			require.NoError(err)
+			defer resp.Body.Close()
+			require.True(resp.Uncompressed)
+
+			<-rowsWritten
+			firstRow := make([]byte, len(row))
+			_, err = io.ReadFull(resp.Body, firstRow)
+			require.NoError(err, "flushed rows should be available before the response is finished")
+			require.Equal(row, string(firstRow))
+			close(rowsRead)
+
+			rest, err := io.ReadAll(resp.Body)
+			require.NoError(err)
+			require.Equal(row, string(rest))
+		})
+	}
+}
+
+func TestFlushesCoalescing(t *testing.T) {
+	require := require.New(t)
+	rec := serve(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(httpu.ContentType, httpu.ContentType_ApplicationJSON)
+		// the same as the router does: flush after each element
+		for range testMinSize {
+			_, _ = w.Write([]byte("1,"))
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte("1"))
+	}, map[string]string{headerAcceptEncoding: encodingGzip})
+	require.Equal(encodingGzip, rec.Header().Get(headerContentEncoding))
+	require.Less(rec.Body.Len(), testMinSize, "flushes should not ruin the compression")
+	require.Equal(strings.Repeat("1,", testMinSize)+"1", decode(t, rec))
+}
@@ -0,0 +1,18 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpcompress
+
+import "net/http"
+
+// NewHandler returns the handler that compresses responses of the next handler with zstd or gzip,
+// the encoding is negotiated by the request Accept-Encoding header.
+//
+// Responses smaller than minSize bytes, responses of not compressible content types (see compressibleContentTypes),
+// already encoded, ranged and event stream responses are sent as is.
+// Flushes of the compressed response are coalesced within a short interval to keep the compression ratio of
+// responses that are flushed element by element.
+func NewHandler(next http.Handler, minSize int) http.Handler {
+	return &compressHandler{next: next, minSize: minSize}
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpcompress
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type compressHandler struct {
+	next    http.Handler
+	minSize int
+}
+
+// encoder is a pooled compressor
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// holds the response beginning until minSize bytes are written or the handler is finished
+// then either compresses the response or sends it as is
+type compressWriter struct {
+	http.ResponseWriter
+	minSize    int
+	encoding   string // negotiated encoding
+	lock       sync.Mutex
+	status     int // status code passed to WriteHeader, 0 -> not called yet
+	buf        []byte
+	decided    bool
+	enc        encoder     // nil -> the response is sent as is
+	flushTimer *time.Timer // not nil -> delayed flush is scheduled
+	closed     bool
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpstatic
+
+import "regexp"
+
+// URL path prefix of static folders
+const StaticPath = "/static/"
+
+const (
+	indexFile              = "index.html"
+	defaultContentType     = "application/octet-stream"
+	etagHashLen            = 16
+	cacheControlImmutable  = "public, max-age=31536000, immutable"
+	cacheControlRevalidate = "no-cache"
+)
+
+const (
+	headerAllow           = "Allow"
+	headerVary            = "Vary"
+	headerETag            = "ETag"
+	headerCacheControl    = "Cache-Control"
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+	anyEncoding           = "*"
+)
+
+// in the server preference order
+var precompressedEncodings = []encoding{
+	{name: "br", ext: ".br"},
+	{name: "gzip", ext: ".gz"},
+}
+
+// name.<hash>.ext or name-<hash>.ext as produced by bundlers, see isImmutable
+var fingerprintedFileName = regexp.MustCompile(`[.-]([A-Za-z0-9_]{8,})\.[A-Za-z0-9]+$`)
@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpstatic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/ihttp"
+)
+
+func (f *folderHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set(headerAllow, "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	name, ok := f.resolve(req.URL.Path)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+	w.Header().Set(headerVary, headerAcceptEncoding)
+	servedName := name
+	for _, enc := range acceptedEncodings(req.Header.Get(headerAcceptEncoding)) {
+		if f.isFile(name + enc.ext) {
+			servedName = name + enc.ext
+			w.Header().Set(headerContentEncoding, enc.name)
+			break
+		}
+	}
+
+	if err := f.serveFile(w, req, servedName, contentType, isImmutable(name)); err != nil {
+		// the error text is not encoded and must not be cached
+		w.Header().Del(headerContentEncoding)
+		w.Header().Del(headerETag)
+		w.Header().Del(headerCacheControl)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// returns the name of the file in the folder fs to serve the url path
+func (f *folderHandler) resolve(urlPath string) (name string, ok bool) {
+	name = strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(urlPath, f.prefix)), "/")
+	if name == "" {
+		name = "."
+	}
+	if info, err := fs.Stat(f.fs, name); err == nil && info.IsDir() {
+		name = path.Join(name, indexFile)
+	}
+	if f.isFile(name) {
+		return name, true
+	}
+	if f.spa && path.Ext(name) == "" && f.isFile(indexFile) {
+		// history API route of the single-page application
+		return indexFile, true
+	}
+	return "", false
+}
+
+func (f *folderHandler) isFile(name string) bool {
+	info, err := fs.Stat(f.fs, name)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func (f *folderHandler) serveFile(w http.ResponseWriter, req *http.Request, name string, contentType string, immutable bool) error {
+	file, err := f.fs.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		// notest
+		return err
+	}
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		// notest: os.DirFS and embed.FS files are seekable
+		b, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(b)
+	}
+
+	etag, err := f.etag(name, info, content)
+	if err != nil {
+		return err
+	}
+	w.Header().Set(headerETag, etag)
+	w.Header().Set(httpu.ContentType, contentType)
+	if immutable {
+		w.Header().Set(headerCacheControl, cacheControlImmutable)
+	} else {
+		w.Header().Set(headerCacheControl, cacheControlRevalidate)
+	}
+	// checks If-None-Match against ETag, serves ranges and HEAD requests
+	http.ServeContent(w, req, name, info.ModTime(), content)
+	return nil
+}
+
+// returns ETag as a hash of the file content, hashes are cached while the file is not changed
+func (f *folderHandler) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	key := etagKey{name: name, size: info.Size(), modTime: info.ModTime()}
+	if etag, ok := f.etags.Load(key); ok {
+		return etag.(string), nil
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		// notest
+		return "", err
+	}
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(hash.Sum(nil)[:etagHashLen]))
+	f.etags.Store(key, etag)
+	return etag, nil
+}
+
+// returns precompressed encodings accepted by the client in the server preference order
+func acceptedEncodings(acceptEncoding string) (res []encoding) {
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok && isZeroQValue(q) {
+			continue
+		}
+		accepted[strings.ToLower(coding)] = true
+	}
+	for _, enc := range precompressedEncodings {
+		if accepted[enc.name] || accepted[anyEncoding] {
+			res = append(res, enc)
+		}
+	}
+	return res
+}
+
+func isZeroQValue(q string) bool {
+	return strings.Trim(q, "0.") == ""
+}
+
+// files with the content hash in the name could be cached forever
+func isImmutable(name string) bool {
+	m := fingerprintedFileName.FindStringSubmatch(path.Base(name))
+	return m != nil && isContentHash(m[1])
+}
+
+// hash produced by bundlers is either hex with digits and letters, e.g. app.3f2a9c1b.js,
+// or base64url-like with digits, lower and upper case letters, e.g. index-BdT3xYz1.css.
+// Words and dimensions are not hashes, e.g. my-component.js, background-1920x1080.jpg
+func isContentHash(s string) bool {
+	hasDigit, hasLower, hasUpper, isHex := false, false, false, true
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r >= 'a' && r <= 'f':
+			hasLower = true
+		case r >= 'g' && r <= 'z':
+			hasLower = true
+			isHex = false
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+			isHex = false
+		default:
+			isHex = false
+		}
+	}
+	if isHex {
+		return hasDigit && hasLower
+	}
+	return hasDigit && hasLower && hasUpper
+}
+
+func (a *appFolders) DeployAppStaticFolders(app appdef.AppQName, folders map[string]ihttp.StaticFolder) {
+	a.Lock()
+	defer a.Unlock()
+
+	for _, folderPath := range a.paths[app] {
+		delete(a.handlers, folderPath)
+	}
+	delete(a.paths, app)
+	for folderPath, folder := range folders {
+		folderPath = strings.Trim(folderPath, "/")
+		a.handlers[folderPath] = NewFolderHandler(StaticPath+folderPath, folder.FS, folder.Options.SPA)
+		a.paths[app] = append(a.paths[app], folderPath)
+	}
+}
+
+func (a *appFolders) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := a.handler(req.URL.Path)
+	if handler == nil {
+		http.NotFound(w, req)
+		return
+	}
+	handler.ServeHTTP(w, req)
+}
+
+// returns the handler of the folder with the longest path matched by the url path
+func (a *appFolders) handler(urlPath string) (handler http.Handler) {
+	a.RLock()
+	defer a.RUnlock()
+
+	folderURLPath, ok := strings.CutPrefix(urlPath, StaticPath)
+	if !ok {
+		return nil
+	}
+	matchedLen := 0
+	for folderPath, h := range a.handlers {
+		if len(folderPath) <= matchedLen {
+			continue
+		}
+		if folderURLPath == folderPath || strings.HasPrefix(folderURLPath, folderPath+"/") {
+			handler, matchedLen = h, len(folderPath)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpstatic
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/ihttp"
+)
+
+func serve(handler http.Handler, urlPath string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, urlPath, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIsImmutable(t *testing.T) {
+	require := require.New(t)
+
+	for _, name := range []string{
+		"app.3f2a9c1b.js",
+		"assets/main-0123abcd.css",
+		"index-BdT3xYz1.css",
+		"chunk.a1B2c3D4e5.js",
+	} {
+		require.True(isImmutable(name), name)
+	}
+
+	for _, name := range []string{
+		"index.html",
+		"my-component.js",
+		"background-1920x1080.jpg",
+		"report.20240101.pdf",
+		"deadbeef.cafebabe.js",
+		"logo-Component.svg",
+		"icon-ABCDEF12.png",
+	} {
+		require.False(isImmutable(name), name)
+	}
+}
+
+// files could be stat'ed but not opened
+type failingFS struct {
+	fstest.MapFS
+}
+
+func (f failingFS) Open(name string) (fs.File, error) {
+	if name == "." {
+		return f.MapFS.Open(name)
+	}
+	return nil, errors.New("test error")
+}
+
+func TestServeFileError(t *testing.T) {
+	require := require.New(t)
+
+	fsys := failingFS{fstest.MapFS{
+		"app.3f2a9c1b.js":    {Data: []byte("app")},
+		"app.3f2a9c1b.js.br": {Data: []byte("br")},
+	}}
+	rec := serve(NewFolderHandler("/static/app", fsys, false), "/static/app/app.3f2a9c1b.js", map[string]string{headerAcceptEncoding: "br"})
+
+	require.Equal(http.StatusInternalServerError, rec.Code)
+	require.Contains(rec.Body.String(), "test error")
+	require.Empty(rec.Header().Get(headerContentEncoding))
+	require.Empty(rec.Header().Get(headerETag))
+	require.Empty(rec.Header().Get(headerCacheControl))
+}
+
+func TestAppFolders(t *testing.T) {
+	require := require.New(t)
+
+	app1 := appdef.NewAppQName("test1", "app1")
+	app2 := appdef.NewAppQName("test1", "app2")
+	folder := func(content string, spa bool) ihttp.StaticFolder {
+		return ihttp.StaticFolder{
+			FS:      fstest.MapFS{"index.html": {Data: []byte(content)}},
+			Options: ihttp.StaticFolderOptions{SPA: spa},
+		}
+	}
+	folders := NewAppFolders()
+	folders.DeployAppStaticFolders(app1, map[string]ihttp.StaticFolder{
+		"test1/app1/pkg/Web":      folder("web", true),
+		"test1/app1/pkg/Web/Docs": folder("docs", false),
+	})
+	folders.DeployAppStaticFolders(app2, map[string]ihttp.StaticFolder{
+		"test1/app2/pkg/Web": folder("web2", false),
+	})
+
+	t.Run("folder with the longest path should serve the request", func(t *testing.T) {
+		tests := map[string]string{
+			"/static/test1/app1/pkg/Web/":           "web",
+			"/static/test1/app1/pkg/Web/orders/123": "web",
+			"/static/test1/app1/pkg/Web/Docs/":      "docs",
+			"/static/test1/app2/pkg/Web/":           "web2",
+		}
+		for urlPath, expected := range tests {
+			rec := serve(folders, urlPath, nil)
+			require.Equal(http.StatusOK, rec.Code, urlPath)
+			require.Equal(expected, rec.Body.String(), urlPath)
+		}
+	})
+
+	t.Run("404 on unknown folder", func(t *testing.T) {
+		for _, urlPath := range []string{"/static/test1/app1/pkg/", "/static/test1/app1/pkg/WebApp/", "/other/test1/app1/pkg/Web/"} {
+			require.Equal(http.StatusNotFound, serve(folders, urlPath, nil).Code, urlPath)
+		}
+	})
+
+	t.Run("folders of the app should be replaced", func(t *testing.T) {
+		folders.DeployAppStaticFolders(app1, map[string]ihttp.StaticFolder{
+			"test1/app1/pkg/Web": folder("web new", false),
+		})
+		require.Equal("web new", serve(folders, "/static/test1/app1/pkg/Web/", nil).Body.String())
+		require.Equal(http.StatusNotFound, serve(folders, "/static/test1/app1/pkg/Web/Docs/", nil).Code)
+		require.Equal("web2", serve(folders, "/static/test1/app2/pkg/Web/", nil).Body.String())
+	})
+
+	t.Run("empty folders should remove folders of the app", func(t *testing.T) {
+		folders.DeployAppStaticFolders(app1, nil)
+		require.Equal(http.StatusNotFound, serve(folders, "/static/test1/app1/pkg/Web/", nil).Code)
+		require.Equal("web2", serve(folders, "/static/test1/app2/pkg/Web/", nil).Body.String())
+	})
+}
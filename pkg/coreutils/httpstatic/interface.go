@@ -0,0 +1,20 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpstatic
+
+import (
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/ihttp"
+)
+
+// IAppFolders serves static folders of the applications at /static/<path>/
+//
+// @ConcurrentAccess
+type IAppFolders interface {
+	ihttp.IAppStaticFolders
+	// 404 on unknown folder
+	http.Handler
+}
@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpstatic
+
+import (
+	"io/fs"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// NewFolderHandler returns the handler that serves files of fsys, prefix is stripped from the request URL path.
+//
+// ETag is the hash of the file content, files with the content hash in the name (e.g. app.3f2a9c1b.js) are cached as immutable,
+// other files are revalidated. Precompressed variants (<file>.br, <file>.gz) are served according to Accept-Encoding.
+// spa: unknown paths without file extension are served with index.html of the folder
+func NewFolderHandler(prefix string, fsys fs.FS, spa bool) http.Handler {
+	return &folderHandler{prefix: prefix, fs: fsys, spa: spa}
+}
+
+func NewAppFolders() IAppFolders {
+	return &appFolders{
+		handlers: map[string]http.Handler{},
+		paths:    map[appdef.AppQName][]string{},
+	}
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package httpstatic
+
+import (
+	"io/fs"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+type folderHandler struct {
+	prefix string
+	fs     fs.FS
+	spa    bool
+	etags  sync.Map // etagKey -> ETag header value
+}
+
+type etagKey struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+// precompressed variant of the file
+type encoding struct {
+	name string // Content-Encoding
+	ext  string // file extension of the variant
+}
+
+type appFolders struct {
+	sync.RWMutex
+	handlers map[string]http.Handler      // folder path -> handler
+	paths    map[appdef.AppQName][]string // folder paths of the app
+}
@@ -8,6 +8,8 @@ package coreutils
 import (
 	"golang.org/x/text/language"
 	"golang.org/x/text/message/catalog"
+
+	"github.com/voedger/voedger/pkg/appdef"
 )
 
 type Translations map[string]map[language.Tag]string
@@ -23,3 +25,36 @@ func GetCatalogFromTranslations(t Translations) catalog.Catalog {
 	}
 	return ctlg
 }
+
+// Returns the application language that best matches the language tag or the Accept-Language header value, e.g. "nl-BE,nl;q=0.9,en;q=0.8"
+//
+// Returns empty string if the application has no translations to such languages
+func MatchLanguage(app appdef.IWithTranslations, acceptLanguage string) string {
+	langs := app.Languages()
+	if len(langs) == 0 || len(acceptLanguage) == 0 {
+		return ""
+	}
+	desired, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(desired) == 0 {
+		return ""
+	}
+	supported := make([]language.Tag, len(langs))
+	for i, lang := range langs {
+		supported[i] = language.Make(lang)
+	}
+	_, idx, confidence := language.NewMatcher(supported).Match(desired...)
+	if confidence == language.No {
+		return ""
+	}
+	return langs[idx]
+}
+
+// Returns the application translation of the text to the language that best matches the language tag or the Accept-Language header value
+//
+// Returns the text itself if there is no translation
+func Translate(app appdef.IWithTranslations, acceptLanguage, text string) string {
+	if tr, ok := app.Translation(MatchLanguage(app, acceptLanguage), text); ok {
+		return tr
+	}
+	return text
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package coreutils
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef/builder"
+)
+
+func TestTranslate(t *testing.T) {
+	require := require.New(t)
+
+	adb := builder.New()
+	adb.AddTranslation("nl", "Hello", "Hallo").
+		AddTranslation("pt-BR", "Hello", "Olá").
+		AddTranslation("nl", "Not found", "Niet gevonden")
+	app := adb.AppDef()
+
+	t.Run("MatchLanguage", func(t *testing.T) {
+		for acceptLanguage, expected := range map[string]string{
+			"nl":                      "nl",
+			"nl-BE":                   "nl",
+			"pt-BR":                   "pt-BR",
+			"pt":                      "pt-BR",
+			"fr-FR,nl;q=0.8,en;q=0.5": "nl",
+			"en":                      "",
+			"":                        "",
+			"not a language":          "",
+		} {
+			require.Equal(expected, MatchLanguage(app, acceptLanguage), acceptLanguage)
+		}
+	})
+
+	require.Equal("Hallo", Translate(app, "nl-NL", "Hello"))
+	require.Equal("Olá", Translate(app, "pt-BR", "Hello"))
+	require.Equal("Hello", Translate(app, "en", "Hello"))
+	require.Equal("Goodbye", Translate(app, "nl", "Goodbye"))
+
+	t.Run("SysError", func(t *testing.T) {
+		sysErr := NewHTTPErrorf(http.StatusNotFound, "Not found").Localize(app, "nl")
+		require.Equal("Niet gevonden", sysErr.Message)
+		require.Equal(http.StatusNotFound, sysErr.HTTPStatus)
+
+		sysErr = NewHTTPErrorf(http.StatusNotFound, "Not found").Localize(app, "en")
+		require.Equal("Not found", sysErr.Message)
+	})
+}
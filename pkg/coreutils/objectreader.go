@@ -83,7 +83,8 @@ func FieldsToMap(obj istructs.IRowReader, appDef appdef.IAppDef, optFuncs ...Map
 		optFunc(opts)
 	}
 
-	proceedField := func(fieldName appdef.FieldName, kind appdef.DataKind) {
+	proceedField := func(iField appdef.IField) {
+		fieldName, kind := iField.Name(), iField.DataKind()
 		if opts.filter != nil {
 			if !opts.filter(fieldName, kind) {
 				return
@@ -96,7 +97,7 @@ func FieldsToMap(obj istructs.IRowReader, appDef appdef.IAppDef, optFuncs ...Map
 				panic("DataKind_Record field met -> IValue must be provided")
 			}
 		} else {
-			res[fieldName] = ReadByKind(fieldName, kind, obj)
+			res[fieldName] = FormatSysDataValue(iField, ReadByKind(fieldName, kind, obj))
 		}
 	}
 
@@ -117,13 +118,13 @@ func FieldsToMap(obj istructs.IRowReader, appDef appdef.IAppDef, optFuncs ...Map
 						return true
 					}
 				}
-				res[iField.Name()] = val
+				res[iField.Name()] = FormatSysDataValue(iField, val)
 				return true
 			})
 			return res
 		}
 		for _, iField := range iFieldsToProcess {
-			proceedField(iField.Name(), iField.DataKind())
+			proceedField(iField)
 		}
 	}
 
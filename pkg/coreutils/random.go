@@ -17,6 +17,26 @@ func EmailVerificationCode() (verificationCode string) {
 	return randomString(emailVerificationCodeAlphabet, emailVerificationCodeLength)
 }
 
+// the secret part of the shareable invite link, 160 bits
+func InviteLinkCode() (code string) {
+	return randomString(lowercaseDigitsAlphabet, inviteLinkCodeLen)
+}
+
+// the secret part of the API key, 260 bits
+func APIKeySecret() (secret string) {
+	return randomString(lowercaseDigitsAlphabet, apiKeySecretLen)
+}
+
+// single-use code that replaces the TOTP code if the authenticator is lost, 50 bits
+func TOTPRecoveryCode() (code string) {
+	return randomString(lowercaseDigitsAlphabet, totpRecoveryCodeLen)
+}
+
+// password of the login that signs in by an external identity provider only, never disclosed, 260 bits
+func UnusablePassword() (pwd string) {
+	return randomString(lowercaseDigitsAlphabet, unusablePasswordLen)
+}
+
 func randomString(alphabet string, l int) string {
 	src := make([]byte, l)
 	_, err := rand.Read(src)
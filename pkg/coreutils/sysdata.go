@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package coreutils
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils/decimal"
+)
+
+// fixed width, so formatted timestamps are ordered as the values are
+const timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// ParseTimestamp parses ISO-8601 (RFC 3339) string to Unix time in milliseconds.
+//
+// Returns error if time is out of [appdef.MinTimestamp, appdef.MaxTimestamp]
+func ParseTimestamp(s string) (int64, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, err
+	}
+	ms := t.UnixMilli()
+	if ms < appdef.MinTimestamp || ms > appdef.MaxTimestamp {
+		return 0, appdef.ErrOutOfBounds("timestamp «%s»", s)
+	}
+	return ms, nil
+}
+
+// FormatTimestamp returns Unix time in milliseconds as ISO-8601 string in UTC, e.g. "2025-01-02T03:04:05.678Z"
+func FormatTimestamp(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format(timestampLayout)
+}
+
+// IsTimestamp returns true if field data is sys.Timestamp or inherits it
+func IsTimestamp(fld appdef.IField) bool {
+	return fld.DataKind() == appdef.DataKind_int64 && appdef.DataInherits(fld.Data(), appdef.SysData_Timestamp)
+}
+
+// IsCurrency returns true if field data is sys.Currency or inherits it
+func IsCurrency(fld appdef.IField) bool {
+	return fld.DataKind() == appdef.DataKind_int64 && appdef.DataInherits(fld.Data(), appdef.SysData_Currency)
+}
+
+// CurrencySpec returns precision and scale declared for currency field, e.g. currency(18,2).
+//
+// Returns sys.Currency defaults if the field data has no precision or scale constraint
+func CurrencySpec(fld appdef.IField) decimal.Spec {
+	spec := decimal.Spec{Precision: int(appdef.DefaultCurrencyPrecision), Scale: int(appdef.DefaultCurrencyScale)}
+	cc := fld.Constraints()
+	if c, ok := cc[appdef.ConstraintKind_Precision]; ok {
+		spec.Precision = int(c.Value().(uint16))
+	}
+	if c, ok := cc[appdef.ConstraintKind_Scale]; ok {
+		spec.Scale = int(c.Value().(uint16))
+	}
+	return spec
+}
+
+// ParseSysDataValue parses ISO-8601 string for timestamp field and decimal string for currency field.
+//
+// Returns ok == false if the field is neither timestamp nor currency
+func ParseSysDataValue(fld appdef.IField, s string) (value int64, ok bool, err error) {
+	switch {
+	case IsTimestamp(fld):
+		value, err = ParseTimestamp(s)
+	case IsCurrency(fld):
+		value, err = CurrencySpec(fld).Parse(s)
+	default:
+		return 0, false, nil
+	}
+	return value, true, err
+}
+
+// FormatSysDataValue returns ISO-8601 string for timestamp field and decimal string for currency field.
+//
+// Returns value as is for other fields
+func FormatSysDataValue(fld appdef.IField, value any) any {
+	v, ok := value.(int64)
+	if !ok {
+		return value
+	}
+	switch {
+	case IsTimestamp(fld):
+		return FormatTimestamp(v)
+	case IsCurrency(fld):
+		return CurrencySpec(fld).Format(v)
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package coreutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/appdef/constraints"
+	"github.com/voedger/voedger/pkg/coreutils/decimal"
+)
+
+func TestTimestamp(t *testing.T) {
+	require := require.New(t)
+
+	for s, want := range map[string]int64{
+		"2025-01-02T03:04:05.678Z":       1735787045678,
+		"2025-01-02T05:04:05+02:00":      1735787045000,
+		"0001-01-01T00:00:00Z":           appdef.MinTimestamp,
+		"9999-12-31T23:59:59.999999Z":    appdef.MaxTimestamp,
+		"1970-01-01T00:00:00.000000001Z": 0,
+	} {
+		ms, err := ParseTimestamp(s)
+		require.NoError(err, s)
+		require.Equal(want, ms, s)
+	}
+
+	_, err := ParseTimestamp("02.01.2025")
+	require.Error(err)
+
+	require.Equal("2025-01-02T03:04:05.678Z", FormatTimestamp(1735787045678))
+	require.Equal("2025-01-02T03:04:05.000Z", FormatTimestamp(1735787045000))
+	require.Equal("0001-01-01T00:00:00.000Z", FormatTimestamp(appdef.MinTimestamp))
+	require.Less(FormatTimestamp(1735787045000), FormatTimestamp(1735787045001), "formatted timestamps are ordered")
+}
+
+func TestSysDataValues(t *testing.T) {
+	require := require.New(t)
+
+	objName := appdef.NewQName("test", "obj")
+	adb := builder.New()
+	wsb := adb.AddWorkspace(testWS)
+	wsb.AddObject(objName).
+		AddDataField("ts", appdef.SysData_Timestamp, false).
+		AddDataField("price", appdef.SysData_Currency, false).
+		AddDataField("amount", appdef.SysData_Currency, false, constraints.Precision(18), constraints.Scale(2)).
+		AddField("int64", appdef.DataKind_int64, false)
+	app, err := adb.Build()
+	require.NoError(err)
+	obj := appdef.Object(app.Type, objName)
+
+	t.Run("parse", func(t *testing.T) {
+		v, ok, err := ParseSysDataValue(obj.Field("ts"), "2025-01-02T03:04:05.678Z")
+		require.NoError(err)
+		require.True(ok)
+		require.EqualValues(1735787045678, v)
+
+		v, ok, err = ParseSysDataValue(obj.Field("price"), "12.34")
+		require.NoError(err)
+		require.True(ok)
+		require.EqualValues(123400, v)
+
+		v, ok, err = ParseSysDataValue(obj.Field("amount"), "9999999999999999.99")
+		require.NoError(err)
+		require.True(ok)
+		require.EqualValues(999_999_999_999_999_999, v)
+
+		_, _, err = ParseSysDataValue(obj.Field("amount"), "12.345")
+		require.ErrorIs(err, decimal.ErrScale)
+
+		_, ok, err = ParseSysDataValue(obj.Field("price"), ".+5")
+		require.ErrorIs(err, decimal.ErrSyntax)
+		require.True(ok)
+
+		_, ok, _ = ParseSysDataValue(obj.Field("int64"), "1")
+		require.False(ok)
+	})
+
+	t.Run("FieldsToMap", func(t *testing.T) {
+		m := FieldsToMap(&TestObject{
+			Name: objName,
+			Data: map[string]interface{}{
+				"ts":                     int64(1735787045678),
+				"price":                  int64(-123400),
+				"amount":                 int64(-1234),
+				"int64":                  int64(42),
+				appdef.SystemField_QName: objName,
+			},
+		}, app, WithAllFields())
+		require.Equal("2025-01-02T03:04:05.678Z", m["ts"])
+		require.Equal("-12.3400", m["price"])
+		require.Equal("-12.34", m["amount"])
+		require.Equal(int64(42), m["int64"])
+	})
+
+	t.Run("CurrencySpec", func(t *testing.T) {
+		require.Equal(decimal.Spec{Precision: 15, Scale: 4}, CurrencySpec(obj.Field("price")))
+		require.Equal(decimal.Spec{Precision: 18, Scale: 2}, CurrencySpec(obj.Field("amount")))
+	})
+}
@@ -110,6 +110,14 @@ func (he SysError) IsNil() bool {
 	return he.HTTPStatus == 0 && len(he.Data) == 0 && len(he.Message) == 0 && he.QName == appdef.NullQName
 }
 
+// Returns the error with the message translated by the application translations, see Translate()
+func (he SysError) Localize(app appdef.IWithTranslations, acceptLanguage string) SysError {
+	if len(he.Message) > 0 {
+		he.Message = Translate(app, acceptLanguage, he.Message)
+	}
+	return he
+}
+
 func NewHTTPErrorf(httpStatus int, args ...interface{}) SysError {
 	return SysError{
 		HTTPStatus: httpStatus,
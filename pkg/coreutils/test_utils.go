@@ -298,7 +298,7 @@ const notImplemented = "not implemented"
 func (f *MockIField) Comment() string                               { panic(notImplemented) }
 func (f *MockIField) CommentLines() []string                        { panic(notImplemented) }
 func (f *MockIField) Name() appdef.FieldName                        { return f.FieldName }
-func (f *MockIField) Data() appdef.IData                            { panic(notImplemented) }
+func (f *MockIField) Data() appdef.IData                            { return nil }
 func (f *MockIField) DataKind() appdef.DataKind                     { return f.FieldDataKind }
 func (f *MockIField) Required() bool                                { return false }
 func (f *MockIField) Verifiable() bool                              { panic(notImplemented) }
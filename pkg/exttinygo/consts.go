@@ -23,6 +23,7 @@ const (
 	StorageFederationBlob    = "sys.FederationBlob"
 	StorageUniq              = "sys.Uniq"
 	StorageLogger            = "sys.Logger"
+	StorageTranslation       = "sys.Translation"
 
 	NullEntity = ""
 )
@@ -30,3 +30,15 @@ func NewProjectorTest(t *testing.T, extensionFunc func()) *teststate.ProjectorTe
 	internal.SafeStateAPI = safestate.Provide(ts, nil)
 	return ts
 }
+
+func NewQueryTest(t *testing.T, iQuery teststate.IQuery, extensionFunc func()) *teststate.QueryTestState {
+	ts := teststate.NewQueryTestState(t, iQuery, extensionFunc)
+	internal.SafeStateAPI = safestate.Provide(ts, nil)
+	return ts
+}
+
+func NewJobTest(t *testing.T, extensionFunc func()) *teststate.JobTestState {
+	ts := teststate.NewJobTestState(t, extensionFunc)
+	internal.SafeStateAPI = safestate.Provide(ts, nil)
+	return ts
+}
@@ -47,3 +47,16 @@ func updateValueImpl(key TKeyBuilder, existingValue TValue) TIntent {
 func newValueImpl(key TKeyBuilder) TIntent {
 	return TIntent(internal.SafeStateAPI.NewValue(safe.TKeyBuilder(key)))
 }
+
+func translateImpl(language, text string) string {
+	if len(text) == 0 {
+		return text
+	}
+	kb := KeyBuilder(StorageTranslation, NullEntity)
+	kb.PutString("Language", language)
+	kb.PutString("Text", text)
+	if value, ok := QueryValue(kb); ok {
+		return value.AsString("Translation")
+	}
+	return text
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package exttinygo
+
+import "github.com/voedger/voedger/pkg/coreutils/decimal"
+
+// DecimalFromInt returns decimal of specified precision and scale for integer value.
+//
+// Returns decimal.ErrOutOfRange if value exceeds precision
+func DecimalFromInt(v int64, precision, scale int) (Decimal, error) {
+	spec := decimal.Spec{Precision: precision, Scale: scale}
+	return result(spec)(spec.FromInt(v))
+}
+
+// ParseDecimal parses decimal string, e.g. "-12.34", to decimal of specified precision and scale.
+//
+// Returns decimal.ErrSyntax, decimal.ErrScale or decimal.ErrOutOfRange
+func ParseDecimal(s string, precision, scale int) (Decimal, error) {
+	spec := decimal.Spec{Precision: precision, Scale: scale}
+	return result(spec)(spec.Parse(s))
+}
+
+// Add returns d + v with precision and scale of d, v is rounded half to even to the scale of d
+func (d Decimal) Add(v Decimal) (Decimal, error) {
+	b, err := d.spec.Rescale(v.value, v.spec.Scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return result(d.spec)(d.spec.Add(d.value, b))
+}
+
+// Sub returns d - v with precision and scale of d, v is rounded half to even to the scale of d
+func (d Decimal) Sub(v Decimal) (Decimal, error) {
+	b, err := d.spec.Rescale(v.value, v.spec.Scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return result(d.spec)(d.spec.Sub(d.value, b))
+}
+
+// MulInt returns d * n
+func (d Decimal) MulInt(n int64) (Decimal, error) { return result(d.spec)(d.spec.MulInt(d.value, n)) }
+
+// Mul returns d * v rounded half to even to the scale of d
+func (d Decimal) Mul(v Decimal) (Decimal, error) {
+	p, err := decimal.MulDiv(d.value, v.value, v.spec.One())
+	if err != nil {
+		return Decimal{}, err
+	}
+	return result(d.spec)(d.spec.Check(p))
+}
+
+// Div returns d / v rounded half to even to the scale of d.
+//
+// Returns decimal.ErrDivisionByZero if v is zero
+func (d Decimal) Div(v Decimal) (Decimal, error) {
+	q, err := decimal.MulDiv(d.value, v.spec.One(), v.value)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return result(d.spec)(d.spec.Check(q))
+}
+
+// Round returns d rounded half to even to specified number of fractional digits
+func (d Decimal) Round(digits int) (Decimal, error) {
+	return result(d.spec)(d.spec.Round(d.value, digits))
+}
+
+// Rescale returns d converted to specified precision and scale, rounded half to even if scale is less than the scale of d
+func (d Decimal) Rescale(precision, scale int) (Decimal, error) {
+	spec := decimal.Spec{Precision: precision, Scale: scale}
+	return result(spec)(spec.Rescale(d.value, d.spec.Scale))
+}
+
+// String returns decimal string with fractional digits of the scale, e.g. "-12.3400" for scale 4
+func (d Decimal) String() string {
+	return d.spec.Format(d.value)
+}
+
+// AsDecimal returns value of currency field, precision and scale must be declared for the field, e.g. 15 and 4 for `currency`
+func (v TValue) AsDecimal(name string, precision, scale int) Decimal {
+	return Decimal{value: v.AsInt64(name), spec: decimal.Spec{Precision: precision, Scale: scale}}
+}
+
+// PutDecimal puts value to currency field, the value must have precision and scale declared for the field, see Decimal.Rescale
+func (i TIntent) PutDecimal(name string, value Decimal) {
+	i.PutInt64(name, value.value)
+}
+
+// all operations return decimal.ErrOutOfRange if result exceeds precision
+func result(spec decimal.Spec) func(v int64, err error) (Decimal, error) {
+	return func(v int64, err error) (Decimal, error) {
+		if err != nil {
+			return Decimal{}, err
+		}
+		return Decimal{value: v, spec: spec}, nil
+	}
+}
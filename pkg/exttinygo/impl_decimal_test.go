@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package exttinygo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils/decimal"
+)
+
+func TestDecimal(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("parse and string", func(t *testing.T) {
+		d, err := ParseDecimal("-12.3", 15, 4)
+		require.NoError(err)
+		require.Equal("-12.3000", d.String())
+
+		d, err = ParseDecimal("-12.3", 18, 2)
+		require.NoError(err)
+		require.Equal("-12.30", d.String())
+
+		_, err = ParseDecimal(".+5", 15, 4)
+		require.ErrorIs(err, decimal.ErrSyntax)
+		_, err = ParseDecimal("1.234", 18, 2)
+		require.ErrorIs(err, decimal.ErrScale)
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		price, err := ParseDecimal("19.99", 15, 4)
+		require.NoError(err)
+		rate, err := ParseDecimal("0.0725", 15, 4)
+		require.NoError(err)
+		cent, err := ParseDecimal("0.01", 18, 2)
+		require.NoError(err)
+		three, err := DecimalFromInt(3, 5, 0)
+		require.NoError(err)
+
+		for _, c := range []struct {
+			want string
+			op   func() (Decimal, error)
+		}{
+			{"20.0000", func() (Decimal, error) { return price.Add(cent) }},
+			{"0.0000", func() (Decimal, error) { return price.Sub(price) }},
+			{"59.9700", func() (Decimal, error) { return price.MulInt(3) }},
+			{"1.4493", func() (Decimal, error) { return price.Mul(rate) }}, // 1.449275
+			{"6.6633", func() (Decimal, error) { return price.Div(three) }},
+			{"20.0000", func() (Decimal, error) { return price.Round(0) }},
+			{"0.08", func() (Decimal, error) { return cent.Add(rate) }}, // 0.01 + 0.07
+			{"0.00", func() (Decimal, error) { return cent.Mul(rate) }}, // 0.000725
+			{"0.03", func() (Decimal, error) { return cent.MulInt(3) }},
+			{"19.99", func() (Decimal, error) { return price.Rescale(18, 2) }},
+		} {
+			d, err := c.op()
+			require.NoError(err)
+			require.Equal(c.want, d.String())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		maxValue, err := ParseDecimal("99999999999.9999", 15, 4)
+		require.NoError(err)
+		two, err := DecimalFromInt(2, 15, 4)
+		require.NoError(err)
+		_, err = maxValue.Mul(two)
+		require.ErrorIs(err, decimal.ErrOutOfRange)
+		_, err = maxValue.Rescale(12, 2)
+		require.ErrorIs(err, decimal.ErrOutOfRange)
+		_, err = two.Div(Decimal{})
+		require.ErrorIs(err, decimal.ErrDivisionByZero)
+	})
+}
@@ -6,6 +6,7 @@
 package exttinygo
 
 import (
+	"github.com/voedger/voedger/pkg/coreutils/decimal"
 	safe "github.com/voedger/voedger/pkg/state/isafestateapi"
 )
 
@@ -15,6 +16,14 @@ type TValue safe.TValue
 type TKey safe.TKey
 type QName safe.QName
 
+// Decimal is a fixed-point number of the declared precision and scale, see pkg/coreutils/decimal.
+//
+// Used for `currency(p,s)` (`money(p,s)`) fields, which are stored as int64 scaled by 10^s
+type Decimal struct {
+	value int64
+	spec  decimal.Spec
+}
+
 var KeyBuilder func(storage, entity string) (b TKeyBuilder) = keyBuilderImpl
 
 // QueryValue queries value. When not exists it returns exists=false and value=nil.
@@ -34,6 +43,12 @@ var UpdateValue func(key TKeyBuilder, existingValue TValue) TIntent = updateValu
 // NewValue creates intent for new value
 var NewValue func(key TKeyBuilder) TIntent = newValueImpl
 
+// Translate returns the application translation of the text declared by TRANSLATIONS statement.
+// Language is a language tag or Accept-Language header value, e.g. "nl-BE,nl;q=0.9".
+//
+// Returns the text itself if there is no translation
+var Translate func(language, text string) string = translateImpl
+
 /*
 type IKey interface {
 	AsString(name string) string
@@ -19,9 +19,11 @@ const (
 	ContentLength                                = "Content-Length"
 	ContentDisposition                           = "Content-Disposition"
 	Accept                                       = "Accept"
+	AcceptLanguage                               = "Accept-Language"
 	Origin                                       = "Origin"
 	RetryAfter                                   = "Retry-After"
 	ContentType_ApplicationJSON                  = "application/json"
+	ContentType_ApplicationXNDJSON               = "application/x-ndjson"
 	ContentType_ApplicationXBinary               = "application/x-binary"
 	ContentType_TextPlain                        = "text/plain"
 	ContentType_TextHTML                         = "text/html"
@@ -11,5 +11,6 @@ import (
 
 var (
 	ErrUnexpectedStatusCode = errors.New("unexpected status code")
+	ErrResponseBodyTooLarge = errors.New("response body is too large")
 	errRetry                = errors.New("retry")
 )
@@ -132,6 +132,14 @@ func (c *implIHTTPClient) req(ctx context.Context, urlStr string, body string, o
 		httpCtx = ctx
 	}
 
+	client := c.client
+	if opts.checkRedirect != nil {
+		// the client is shared, so the redirect policy of the request is set on its copy
+		clientCopy := *c.client
+		clientCopy.CheckRedirect = opts.checkRedirect
+		client = &clientCopy
+	}
+
 	retrierCfg := retrier.NewConfig(httpBaseRetryDelay, httpMaxRetryDelay)
 	retrierCfg.OnError = func(attempt int, delay time.Duration, opErr error) (retry bool, abortErr error) {
 		for _, matcher := range opts.retryOnErr {
@@ -151,7 +159,7 @@ func (c *implIHTTPClient) req(ctx context.Context, urlStr string, body string, o
 		if err != nil {
 			return nil, err
 		}
-		resp, err := c.client.Do(req) //nolint G704 URL is intentionally caller-provided
+		resp, err := client.Do(req) //nolint G704 URL is intentionally caller-provided
 		if err != nil {
 			return nil, err
 		}
@@ -199,7 +207,7 @@ func (c *implIHTTPClient) req(ctx context.Context, urlStr string, body string, o
 		opts.responseHandler(resp)
 		return httpResponse, nil
 	}
-	httpResponse.Body, err = readBody(resp)
+	httpResponse.Body, err = readBodyLimited(resp, opts.maxResponseBodySize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
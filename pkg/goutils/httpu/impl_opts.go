@@ -146,6 +146,21 @@ func WithMethod(m string) ReqOptFunc {
 	}
 }
 
+// WithCheckRedirect sets the redirect policy of the request, see http.Client.CheckRedirect
+func WithCheckRedirect(checkRedirect func(req *http.Request, via []*http.Request) error) ReqOptFunc {
+	return func(opts IReqOpts) {
+		opts.httpOpts().checkRedirect = checkRedirect
+	}
+}
+
+// WithMaxResponseBodySize limits the response body that is read out,
+// the larger body is not read further and wrapped ErrResponseBodyTooLarge is returned
+func WithMaxResponseBodySize(maxSize int64) ReqOptFunc {
+	return func(opts IReqOpts) {
+		opts.httpOpts().maxResponseBodySize = maxSize
+	}
+}
+
 func WithRetryOnError(matcher func(err error) (retry bool)) RetryPolicyOpt {
 	return func(opts IReqOpts) {
 		opts.httpOpts().retryOnErr = append(opts.httpOpts().retryOnErr, matcher)
@@ -7,6 +7,7 @@ package httpu
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -383,6 +384,44 @@ func TestHTTPReqWithOptions(t *testing.T) {
 		require.GreaterOrEqual(retryNum, 2) // Should have retried
 	})
 
+	t.Run("WithCheckRedirect", func(t *testing.T) {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/redirect" {
+				http.Redirect(w, r, "/target", http.StatusFound)
+				return
+			}
+			_, err := w.Write([]byte(r.URL.Path))
+			require.NoError(err)
+		}
+		errDenied := errors.New("denied")
+		redirects := []string{}
+		_, err := httpClient.Req(context.Background(), url+"/redirect", "", WithNoRetryPolicy(),
+			WithCheckRedirect(func(req *http.Request, via []*http.Request) error {
+				redirects = append(redirects, req.URL.Path)
+				return errDenied
+			}))
+		require.ErrorIs(err, errDenied)
+		require.Equal([]string{"/target"}, redirects)
+
+		// the other requests follow redirects by default
+		resp, err := httpClient.Req(context.Background(), url+"/redirect", "")
+		require.NoError(err)
+		require.Equal("/target", resp.Body)
+	})
+
+	t.Run("WithMaxResponseBodySize", func(t *testing.T) {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte("12345"))
+			require.NoError(err)
+		}
+		resp, err := httpClient.Req(context.Background(), url, "", WithMaxResponseBodySize(5))
+		require.NoError(err)
+		require.Equal("12345", resp.Body)
+
+		_, err = httpClient.Req(context.Background(), url, "", WithMaxResponseBodySize(4))
+		require.ErrorIs(err, ErrResponseBodyTooLarge)
+	})
+
 	t.Run("default options validation", func(t *testing.T) {
 		t.Run("WithDiscardResponse and WithResponseHandler", func(t *testing.T) {
 			require.Panics(func() {
@@ -56,6 +56,10 @@ type reqOpts struct {
 	retryOnStatus     []retryOnStatus
 	customOpts        map[any]any
 	urlStr            string
+
+	// see WithCheckRedirect and WithMaxResponseBodySize
+	checkRedirect       func(req *http.Request, via []*http.Request) error
+	maxResponseBodySize int64
 }
 
 type retryOnStatus struct {
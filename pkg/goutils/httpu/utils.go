@@ -21,6 +21,21 @@ func readBody(resp *http.Response) (string, error) {
 	return string(respBody), err
 }
 
+// maxSize is 0 -> the body is not limited
+func readBodyLimited(resp *http.Response, maxSize int64) (string, error) {
+	if maxSize <= 0 {
+		return readBody(resp)
+	}
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(respBody)) > maxSize {
+		return "", fmt.Errorf("%w: exceeds %d bytes", ErrResponseBodyTooLarge, maxSize)
+	}
+	return string(respBody), nil
+}
+
 func discardRespBody(resp *http.Response) error {
 	defer resp.Body.Close()
 	_, err := io.Copy(io.Discard, resp.Body)
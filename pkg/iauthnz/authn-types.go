@@ -18,6 +18,15 @@ type AuthnRequest struct {
 	RequestWSID istructs.WSID
 
 	Token string
+
+	// Inbound webhook the request is came to, the token is ignored then
+	// The sender must be verified by the caller
+	InboundWebhook appdef.QName
+
+	// Function or resource the request is issued for
+	// Used to check functions allowed for an API key
+	// Can be NullQName
+	Resource appdef.QName
 }
 
 type Principal struct {
@@ -78,6 +87,9 @@ var (
 
 	// assigned if a token is not provided
 	QNameRoleGuest = appdef.NewQName(appdef.SysPackage, "Guest")
+
+	// assigned if the sender of the request to an inbound webhook is verified
+	QNameRoleInboundWebhook = appdef.QNameRoleInboundWebhook
 )
 
 var SysRoles = []appdef.QName{
@@ -4,6 +4,28 @@
 
 package iauthnzimpl
 
+import "time"
+
 const (
 	field_OwnerWSID = "OwnerWSID"
 )
+
+const (
+	// API keys are distinguished from tokens by the prefix
+	APIKeyPrefix = "vak_"
+
+	// prefix of the subject logins of API key holders, see APIKeySubjectLogin
+	apiKeySubjectLoginPrefix = "sys.APIKey."
+
+	// number of bytes of the key hash used in the subject login of the API key holder
+	apiKeySubjectLoginHashLen = 8
+
+	// prefix of the application TTL storage keys that keep the last usage of API keys
+	keyPrefix_APIKeyUsage = "sys.apikey.used|"
+
+	// the usage of an API key is recorded not more often than once per the interval
+	apiKeyUsageRecordInterval = time.Minute
+
+	// the usage of an API key is forgotten if the key is not used within the period
+	apiKeyUsageTTLSeconds = 31536000
+)
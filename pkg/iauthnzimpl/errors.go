@@ -10,4 +10,7 @@ import "errors"
 var (
 	ErrPersonalAccessTokenOnSystemRole = errors.New("personal access token on a system role")
 	ErrPersonalAccessTokenOnNullWSID   = errors.New("personal access token on null WSID")
+	ErrAPIKeyInvalid                   = errors.New("API key is invalid or revoked")
+	ErrAPIKeyIPNotAllowed              = errors.New("API key is not allowed from the IP")
+	ErrAPIKeyFunctionNotAllowed        = errors.New("API key is not allowed for the function")
 )
@@ -7,8 +7,10 @@ package iauthnzimpl
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/istructs"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
@@ -16,6 +18,11 @@ import (
 )
 
 func (i *implIAuthenticator) Authenticate(requestContext context.Context, as istructs.IAppStructs, appTokens istructs.IAppTokens, req iauthnz.AuthnRequest) (principals []iauthnz.Principal, profileWSID istructs.WSID, err error) {
+	if req.InboundWebhook == appdef.NullQName && IsAPIKey(req.Token) {
+		// API key holder is not considered as a host, the same as for API tokens
+		return i.authenticateAPIKey(requestContext, as, req)
+	}
+
 	var principalPayload payloads.PrincipalPayload
 	defer func() {
 		if !principalPayload.IsAPIToken {
@@ -26,6 +33,16 @@ func (i *implIAuthenticator) Authenticate(requestContext context.Context, as ist
 		}
 	}()
 
+	if req.InboundWebhook != appdef.NullQName {
+		// role.sys.InboundWebhook
+		principals = append(principals, iauthnz.Principal{
+			Kind:  iauthnz.PrincipalKind_Role,
+			WSID:  req.RequestWSID,
+			QName: iauthnz.QNameRoleInboundWebhook,
+		})
+		return principals, istructs.NullWSID, nil
+	}
+
 	if len(req.Token) == 0 {
 		// add user with login "sys.Guest"
 		principals = append(principals, iauthnz.Principal{
@@ -231,3 +248,87 @@ func (i *implIAuthenticator) rolesFromSubjects(requestContext context.Context, n
 	}
 	return res, nil
 }
+
+// roles are taken from the key, the key holder is not a user or a device
+func (i *implIAuthenticator) authenticateAPIKey(requestContext context.Context, as istructs.IAppStructs, req iauthnz.AuthnRequest) (principals []iauthnz.Principal, profileWSID istructs.WSID, err error) {
+	key, ok, err := i.apiKeyGetter(requestContext, as, req.RequestWSID, req.Token)
+	if err != nil {
+		return nil, istructs.NullWSID, err
+	}
+	if !ok {
+		return nil, istructs.NullWSID, ErrAPIKeyInvalid
+	}
+	if !isIPAllowed(key.AllowedIPs, req.Host) {
+		return nil, istructs.NullWSID, fmt.Errorf("%w: %s", ErrAPIKeyIPNotAllowed, req.Host)
+	}
+	if len(key.Functions) > 0 && !slices.Contains(key.Functions, req.Resource) {
+		return nil, istructs.NullWSID, fmt.Errorf("%w: %s", ErrAPIKeyFunctionNotAllowed, req.Resource)
+	}
+
+	principals = append(principals, iauthnz.Principal{
+		Kind:  iauthnz.PrincipalKind_Role,
+		WSID:  req.RequestWSID,
+		QName: iauthnz.QNameRoleAuthenticatedUser,
+	})
+	for _, role := range key.Roles {
+		if iauthnz.IsSystemRole(role) {
+			// could not be granted by the key, checked on the key creation also
+			continue
+		}
+		principals = append(principals, iauthnz.Principal{
+			Kind:  iauthnz.PrincipalKind_Role,
+			WSID:  req.RequestWSID,
+			QName: role,
+		})
+	}
+
+	i.recordAPIKeyUsage(as, req.RequestWSID, key.ID, req.Host)
+	return principals, istructs.NullWSID, nil
+}
+
+// the usage is recorded on best-effort basis: errors are logged only and the request is not failed
+func (i *implIAuthenticator) recordAPIKeyUsage(as istructs.IAppStructs, wsid istructs.WSID, keyID istructs.RecordID, host string) {
+	usageKey := APIKeyUsageKey(wsid, keyID)
+	now := i.time.Now()
+	if !i.shouldRecordAPIKeyUsage(usageKey, now) {
+		return
+	}
+
+	storage := as.AppTTLStorage()
+	newValue := apiKeyUsageValue(now, host)
+	prevValue, exists, err := storage.TTLGet(usageKey)
+	if err == nil {
+		if exists {
+			_, err = storage.CompareAndSwap(usageKey, prevValue, newValue, apiKeyUsageTTLSeconds)
+		} else {
+			_, err = storage.InsertIfNotExists(usageKey, newValue, apiKeyUsageTTLSeconds)
+		}
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to record usage of API key %d in workspace %d: %v", keyID, wsid, err))
+	}
+}
+
+// Returns false if the usage is recorded already within the last apiKeyUsageRecordInterval.
+//
+// Entries older than the interval are purged once per the interval,
+// so only the keys used recently are kept in memory
+func (i *implIAuthenticator) shouldRecordAPIKeyUsage(usageKey string, now time.Time) bool {
+	i.apiKeyUsageMx.Lock()
+	defer i.apiKeyUsageMx.Unlock()
+
+	if now.Sub(i.apiKeyUsagePurgedAt) >= apiKeyUsageRecordInterval {
+		for key, recordedAt := range i.apiKeyUsageRecorded {
+			if now.Sub(recordedAt) >= apiKeyUsageRecordInterval {
+				delete(i.apiKeyUsageRecorded, key)
+			}
+		}
+		i.apiKeyUsagePurgedAt = now
+	}
+
+	if recordedAt, ok := i.apiKeyUsageRecorded[usageKey]; ok && now.Sub(recordedAt) < apiKeyUsageRecordInterval {
+		return false
+	}
+	i.apiKeyUsageRecorded[usageKey] = now
+	return true
+}
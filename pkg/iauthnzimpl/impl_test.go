@@ -6,11 +6,13 @@ package iauthnzimpl
 
 import (
 	"context"
+	"net/netip"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -71,7 +73,7 @@ func TestBasicUsage(t *testing.T) {
 			},
 		},
 	})
-	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs)
+	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs, TestAPIKeyGetter, testingu.MockTime)
 	t.Run("authenticate in the profile", func(t *testing.T) {
 		req := iauthnz.AuthnRequest{
 			Host:        "127.0.0.1",
@@ -263,6 +265,19 @@ func TestAuthenticate(t *testing.T) {
 				{Kind: iauthnz.PrincipalKind_Host, Name: "127.0.0.1"},
 			},
 		},
+		{
+			desc: "inbound webhook -> host + InboundWebhook role, token is ignored",
+			req: iauthnz.AuthnRequest{
+				Host:           "127.0.0.1",
+				RequestWSID:    2,
+				Token:          userToken,
+				InboundWebhook: appdef.NewQName("test", "hook"),
+			},
+			expectedPrincipals: []iauthnz.Principal{
+				{Kind: iauthnz.PrincipalKind_Role, WSID: 2, QName: iauthnz.QNameRoleInboundWebhook},
+				{Kind: iauthnz.PrincipalKind_Host, Name: "127.0.0.1"},
+			},
+		},
 		{
 			desc: "system token -> host and system role",
 			req: iauthnz.AuthnRequest{
@@ -389,7 +404,7 @@ func TestAuthenticate(t *testing.T) {
 	subjectsGetter := func(context.Context, string, istructs.IAppStructs, istructs.WSID) ([]appdef.QName, error) {
 		return *subjects, nil
 	}
-	authn := NewDefaultAuthenticator(subjectsGetter, TestIsDeviceAllowedFuncs)
+	authn := NewDefaultAuthenticator(subjectsGetter, TestIsDeviceAllowedFuncs, TestAPIKeyGetter, testingu.MockTime)
 	for _, tc := range testCases {
 		localVarSubjects := &tc.subjects
 		t.Run(tc.desc, func(t *testing.T) {
@@ -408,7 +423,7 @@ func TestErrors(t *testing.T) {
 	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(istructs.AppQName_test1_app1)
 
 	appStructs := &implIAppStructs{}
-	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs)
+	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs, TestAPIKeyGetter, testingu.MockTime)
 
 	t.Run("wrong token", func(t *testing.T) {
 		req := iauthnz.AuthnRequest{
@@ -482,7 +497,7 @@ func TestSubjectRolesResolveAgainstLoginAndAlias(t *testing.T) {
 		}
 		token, err := appTokens.IssueToken(time.Minute, &pp)
 		require.NoError(err)
-		authn := NewDefaultAuthenticator(rolesGetterFor(getterMatch), TestIsDeviceAllowedFuncs)
+		authn := NewDefaultAuthenticator(rolesGetterFor(getterMatch), TestIsDeviceAllowedFuncs, TestAPIKeyGetter, testingu.MockTime)
 		principals, _, err := authn.Authenticate(context.Background(), appStructs, appTokens, iauthnz.AuthnRequest{
 			Host:        "127.0.0.1",
 			RequestWSID: 1,
@@ -510,15 +525,127 @@ func TestSubjectRolesResolveAgainstLoginAndAlias(t *testing.T) {
 	})
 }
 
+func TestAPIKey(t *testing.T) {
+	require := require.New(t)
+
+	tokens := itokensjwt.ProvideITokens(itokensjwt.SecretKeyExample, timeu.NewITime())
+	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(istructs.AppQName_test1_app1)
+	ttlStorage := &implIAppTTLStorage{data: map[string]string{}}
+	appStructs := &implIAppStructs{ttlStorage: ttlStorage}
+
+	const (
+		apiKey          = APIKeyPrefix + "secret"
+		apiKeyID        = istructs.RecordID(100)
+		wsid            = istructs.WSID(42)
+		allowedHost     = "10.0.0.5"
+		notAllowedHost  = "192.168.0.1"
+		testRoleName    = "Integration"
+		testCmdName     = "Cmd"
+		testQueryName   = "Query"
+		testPackageName = "test"
+	)
+	testRole := appdef.NewQName(testPackageName, testRoleName)
+	testCmd := appdef.NewQName(testPackageName, testCmdName)
+	testQuery := appdef.NewQName(testPackageName, testQueryName)
+
+	apiKeyGetter := func(_ context.Context, _ istructs.IAppStructs, keyWSID istructs.WSID, key string) (APIKey, bool, error) {
+		if keyWSID != wsid || key != apiKey {
+			return APIKey{}, false, nil
+		}
+		return APIKey{
+			ID:         apiKeyID,
+			Roles:      []appdef.QName{testRole, iauthnz.QNameRoleSystem},
+			Functions:  []appdef.QName{testCmd},
+			AllowedIPs: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")},
+		}, true, nil
+	}
+	mockTime := testingu.NewMockTime()
+	authn := NewDefaultAuthenticator(TestSubjectRolesGetter, TestIsDeviceAllowedFuncs, apiKeyGetter, mockTime)
+
+	req := iauthnz.AuthnRequest{
+		Host:        allowedHost,
+		RequestWSID: wsid,
+		Token:       apiKey,
+		Resource:    testCmd,
+	}
+
+	t.Run("basic usage", func(t *testing.T) {
+		principals, profileWSID, err := authn.Authenticate(context.Background(), appStructs, appTokens, req)
+		require.NoError(err)
+		require.Equal(istructs.NullWSID, profileWSID)
+
+		// system role is not granted, no host principal
+		require.Equal([]iauthnz.Principal{
+			{Kind: iauthnz.PrincipalKind_Role, WSID: wsid, QName: iauthnz.QNameRoleAuthenticatedUser},
+			{Kind: iauthnz.PrincipalKind_Role, WSID: wsid, QName: testRole},
+		}, principals)
+
+		usedAt, host, ok := ParseAPIKeyUsage(ttlStorage.data[APIKeyUsageKey(wsid, apiKeyID)])
+		require.True(ok)
+		require.Equal(mockTime.Now().UnixMilli(), usedAt.UnixMilli())
+		require.Equal(allowedHost, host)
+	})
+
+	t.Run("usage is recorded not more often than once per interval", func(t *testing.T) {
+		prevUsage := ttlStorage.data[APIKeyUsageKey(wsid, apiKeyID)]
+		mockTime.Add(apiKeyUsageRecordInterval / 2)
+		_, _, err := authn.Authenticate(context.Background(), appStructs, appTokens, req)
+		require.NoError(err)
+		require.Equal(prevUsage, ttlStorage.data[APIKeyUsageKey(wsid, apiKeyID)])
+
+		mockTime.Add(apiKeyUsageRecordInterval)
+		_, _, err = authn.Authenticate(context.Background(), appStructs, appTokens, req)
+		require.NoError(err)
+		usedAt, _, ok := ParseAPIKeyUsage(ttlStorage.data[APIKeyUsageKey(wsid, apiKeyID)])
+		require.True(ok)
+		require.Equal(mockTime.Now().UnixMilli(), usedAt.UnixMilli())
+	})
+
+	t.Run("usage of keys not used within the interval is not kept in memory", func(t *testing.T) {
+		usages := authn.(*implIAuthenticator).apiKeyUsageRecorded
+		require.Len(usages, 1)
+
+		mockTime.Add(apiKeyUsageRecordInterval)
+		require.True(authn.(*implIAuthenticator).shouldRecordAPIKeyUsage(APIKeyUsageKey(wsid+1, apiKeyID), mockTime.Now()))
+		require.Len(usages, 1)
+		require.Contains(usages, APIKeyUsageKey(wsid+1, apiKeyID))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cases := []struct {
+			desc     string
+			modify   func(req *iauthnz.AuthnRequest)
+			expected error
+		}{
+			{"unknown key", func(req *iauthnz.AuthnRequest) { req.Token = APIKeyPrefix + "unknown" }, ErrAPIKeyInvalid},
+			{"key of an another workspace", func(req *iauthnz.AuthnRequest) { req.RequestWSID = wsid + 1 }, ErrAPIKeyInvalid},
+			{"IP is not allowed", func(req *iauthnz.AuthnRequest) { req.Host = notAllowedHost }, ErrAPIKeyIPNotAllowed},
+			{"malformed IP", func(req *iauthnz.AuthnRequest) { req.Host = "wrong" }, ErrAPIKeyIPNotAllowed},
+			{"function is not allowed", func(req *iauthnz.AuthnRequest) { req.Resource = testQuery }, ErrAPIKeyFunctionNotAllowed},
+			{"no function", func(req *iauthnz.AuthnRequest) { req.Resource = appdef.NullQName }, ErrAPIKeyFunctionNotAllowed},
+		}
+		for _, c := range cases {
+			t.Run(c.desc, func(t *testing.T) {
+				wrongReq := req
+				c.modify(&wrongReq)
+				principals, _, err := authn.Authenticate(context.Background(), appStructs, appTokens, wrongReq)
+				require.ErrorIs(err, c.expected)
+				require.Empty(principals)
+			})
+		}
+	})
+}
+
 func AppStructsWithTestStorage(appQName appdef.AppQName, data map[istructs.WSID]map[appdef.QName]map[istructs.RecordID]map[string]interface{}) istructs.IAppStructs {
 	recs := &implIRecords{data: data}
 	return &implIAppStructs{records: recs, views: &implIViewRecords{records: recs}, appQName: appQName}
 }
 
 type implIAppStructs struct {
-	records  *implIRecords
-	views    *implIViewRecords
-	appQName appdef.AppQName
+	records    *implIRecords
+	views      *implIViewRecords
+	appQName   appdef.AppQName
+	ttlStorage *implIAppTTLStorage
 }
 
 func (as *implIAppStructs) AppDef() appdef.IAppDef                                         { panic("") }
@@ -540,7 +667,7 @@ func (as *implIAppStructs) AppTokens() istructs.IAppTokens
 func (as *implIAppStructs) GetEventReapplier(istructs.IPLogEvent) istructs.IEventReapplier { panic("") }
 func (as *implIAppStructs) SeqTypes() map[istructs.QNameID]map[istructs.QNameID]uint64     { panic("") }
 func (as *implIAppStructs) QNameID(appdef.QName) (istructs.QNameID, error)                 { panic("") }
-func (as *implIAppStructs) AppTTLStorage() istructs.IAppTTLStorage                         { panic("") }
+func (as *implIAppStructs) AppTTLStorage() istructs.IAppTTLStorage                         { return as.ttlStorage }
 
 type implIRecords struct {
 	data map[istructs.WSID]map[appdef.QName]map[istructs.RecordID]map[string]interface{}
@@ -651,6 +778,10 @@ func (implIViewRecords) Read(context.Context, istructs.WSID, istructs.IKeyBuilde
 	panic("")
 }
 
+func (implIViewRecords) ReadReverse(context.Context, istructs.WSID, istructs.IKeyBuilder, istructs.ValuesCallback) error {
+	panic("")
+}
+
 type implIKeyBuilder struct {
 	coreutils.TestObject
 	qName appdef.QName
@@ -667,3 +798,37 @@ type implIValue struct {
 
 func (v *implIValue) AsRecord(name string) (record istructs.IRecord) { panic("") }
 func (v *implIValue) AsEvent(name string) (event istructs.IDbEvent)  { panic("") }
+
+// TTLs are not considered
+type implIAppTTLStorage struct {
+	data map[string]string
+}
+
+func (s *implIAppTTLStorage) TTLGet(key string) (string, bool, error) {
+	value, ok := s.data[key]
+	return value, ok, nil
+}
+
+func (s *implIAppTTLStorage) InsertIfNotExists(key, value string, _ int) (bool, error) {
+	if _, ok := s.data[key]; ok {
+		return false, nil
+	}
+	s.data[key] = value
+	return true, nil
+}
+
+func (s *implIAppTTLStorage) CompareAndSwap(key, expectedValue, newValue string, _ int) (bool, error) {
+	if s.data[key] != expectedValue {
+		return false, nil
+	}
+	s.data[key] = newValue
+	return true, nil
+}
+
+func (s *implIAppTTLStorage) CompareAndDelete(key, expectedValue string) (bool, error) {
+	if s.data[key] != expectedValue {
+		return false, nil
+	}
+	delete(s.data, key)
+	return true, nil
+}
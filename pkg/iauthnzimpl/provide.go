@@ -5,14 +5,19 @@
 package iauthnzimpl
 
 import (
+	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
-func NewDefaultAuthenticator(subjectRolesGetter SubjectGetterFunc, isDeviceAllowedFuncs IsDeviceAllowedFuncs) iauthnz.IAuthenticator {
+func NewDefaultAuthenticator(subjectRolesGetter SubjectGetterFunc, isDeviceAllowedFuncs IsDeviceAllowedFuncs,
+	apiKeyGetter APIKeyGetterFunc, time timeu.ITime) iauthnz.IAuthenticator {
 	return &implIAuthenticator{
 		subjectRolesGetter:   subjectRolesGetter,
 		isDeviceAllowedFuncs: isDeviceAllowedFuncs,
+		apiKeyGetter:         apiKeyGetter,
+		time:                 time,
+		apiKeyUsageRecorded:  apiKeyUsages{},
 	}
 }
 
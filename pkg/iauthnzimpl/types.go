@@ -6,17 +6,48 @@ package iauthnzimpl
 
 import (
 	"context"
+	"net/netip"
+	"sync"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
 type implIAuthenticator struct {
 	subjectRolesGetter   SubjectGetterFunc
 	isDeviceAllowedFuncs IsDeviceAllowedFuncs
+	apiKeyGetter         APIKeyGetterFunc
+	time                 timeu.ITime
+
+	// used to throttle writes of API keys usage
+	apiKeyUsageMx       sync.Mutex
+	apiKeyUsageRecorded apiKeyUsages
+	apiKeyUsagePurgedAt time.Time
 }
 
+// API key usage key -> time the usage is recorded last time
+type apiKeyUsages map[string]time.Time
+
 type SubjectGetterFunc = func(requestContext context.Context, name string, as istructs.IAppStructs, wsid istructs.WSID) ([]appdef.QName, error)
 
 type IsDeviceAllowedFunc = func(as istructs.IAppStructs, requestWSID istructs.WSID, deviceProfileWSID istructs.WSID) (ok bool, err error)
 type IsDeviceAllowedFuncs map[appdef.AppQName]IsDeviceAllowedFunc
+
+// Returns the active API key of the workspace by the key value presented by the client
+// ok is false if there is no such key or the key is revoked
+type APIKeyGetterFunc = func(requestContext context.Context, as istructs.IAppStructs, wsid istructs.WSID, apiKey string) (key APIKey, ok bool, err error)
+
+type APIKey struct {
+	ID istructs.RecordID
+
+	// roles granted to the key holder in the workspace
+	Roles []appdef.QName
+
+	// commands and queries the key is allowed for, any if empty
+	Functions []appdef.QName
+
+	// IPs and CIDRs the key is allowed from, any if empty
+	AllowedIPs []netip.Prefix
+}
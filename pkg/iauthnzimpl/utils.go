@@ -6,9 +6,15 @@ package iauthnzimpl
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/strconvu"
 	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/istructs"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
@@ -34,3 +40,64 @@ func IssueAPIToken(appTokens istructs.IAppTokens, duration time.Duration, roles
 	currentPrincipalPayload.IsAPIToken = true
 	return appTokens.IssueToken(duration, &currentPrincipalPayload)
 }
+
+// always returns "not found", i.e. API keys are not used
+var TestAPIKeyGetter = func(context.Context, istructs.IAppStructs, istructs.WSID, string) (APIKey, bool, error) {
+	return APIKey{}, false, nil
+}
+
+// Returns true if the token is an API key, not a principal token.
+//
+// API keys can not be validated by IAppTokens, they are checked by the authenticator only
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// Returns the subject login of the API key holder, e.g. to count n10n channels of the holder.
+//
+// The key can not be restored from the login
+func APIKeySubjectLogin(apiKey string) istructs.SubjectLogin {
+	hash := sha256.Sum256([]byte(apiKey))
+	return istructs.SubjectLogin(apiKeySubjectLoginPrefix + hex.EncodeToString(hash[:apiKeySubjectLoginHashLen]))
+}
+
+// the key of the application TTL storage that keeps the last usage of the API key
+func APIKeyUsageKey(wsid istructs.WSID, keyID istructs.RecordID) string {
+	return fmt.Sprintf("%s%d|%d", keyPrefix_APIKeyUsage, wsid, keyID)
+}
+
+// returns the time and the host the API key is used last time
+// ok is false if the value is malformed
+func ParseAPIKeyUsage(value string) (usedAt time.Time, host string, ok bool) {
+	usedAtStr, host, ok := strings.Cut(value, "|")
+	if !ok {
+		return time.Time{}, "", false
+	}
+	usedAtMs, err := strconvu.ParseInt64(usedAtStr)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return time.UnixMilli(usedAtMs), host, true
+}
+
+func apiKeyUsageValue(usedAt time.Time, host string) string {
+	return strconvu.IntToString(usedAt.UnixMilli()) + "|" + host
+}
+
+// allowed if no IPs are specified or the host matches one of them
+func isIPAllowed(allowedIPs []netip.Prefix, host string) bool {
+	if len(allowedIPs) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range allowedIPs {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -41,7 +41,7 @@ type ITTLStorage[K any, V any] interface {
 	// On storage error, returns (false, err).
 	CompareAndDelete(key K, val V) (bool, error)
 
-	// used in tests only
+	// used to find out the current leader
 	// actual TTL checking depends on driver
 	Get(key K) (ok bool, val V, err error)
 }
@@ -36,6 +36,15 @@ type IHTTPProcessor interface {
 	*/
 	DeployStaticContent(path string, fs fs.FS)
 
+	/*
+		The same as DeployStaticContent but with folder options, e.g. single-page application fallback to index.html
+
+		- ETag is the hash of the file content, files with the content hash in the name (e.g. app.3f2a9c1b.js) are cached as immutable
+		- precompressed variants (<file>.br, <file>.gz) are served according to Accept-Encoding
+		- folders are not listed
+	*/
+	DeployStaticFolder(path string, fs fs.FS, opts StaticFolderOptions)
+
 	/*
 		App Partitions
 
@@ -63,3 +72,14 @@ type ISender interface {
 	// NullHandler can be used as a reader
 	Send(ctx context.Context, request interface{}, sectionsHandler SectionsHandlerType) (response interface{}, status Status, err error)
 }
+
+// Static folders of the applications deployed at runtime, e.g. declared by STATIC statements of the application image
+//
+// @ConcurrentAccess
+type IAppStaticFolders interface {
+	// folders are served at /static/<path>/ the same way as by [IHTTPProcessor.DeployStaticFolder],
+	// e.g. path is <package path>/<static folder name>
+	//
+	// replaces all folders deployed for the app before, empty folders remove folders of the app
+	DeployAppStaticFolders(app appdef.AppQName, folders map[string]StaticFolder)
+}
@@ -6,6 +6,8 @@
 package ihttp
 
 import (
+	"io/fs"
+
 	"github.com/voedger/voedger/pkg/istorage"
 )
 
@@ -29,3 +31,16 @@ type CLIParams struct {
 	Port        int
 	AcmeDomains AcmeDomains
 }
+
+// behaviour of the static folder, see [IHTTPProcessor.DeployStaticFolder]
+type StaticFolderOptions struct {
+	// single-page application: unknown paths without file extension are served with index.html of the folder,
+	// so that history API routes of the application are opened on page reload
+	SPA bool
+}
+
+// StaticFolder is the folder content with the folder options
+type StaticFolder struct {
+	fs.FS
+	Options StaticFolderOptions
+}
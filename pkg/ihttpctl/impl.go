@@ -28,7 +28,11 @@ func (hc *httpProcessorController) Prepare() (err error) {
 
 func (hc *httpProcessorController) Run(ctx context.Context) {
 	for path, fs := range hc.staticResources {
-		hc.processor.DeployStaticContent(path, fs)
+		if folder, ok := fs.(StaticFolder); ok {
+			hc.processor.DeployStaticFolder(path, folder.FS, folder.Options)
+		} else {
+			hc.processor.DeployStaticContent(path, fs)
+		}
 		logger.Info(path, "deployed")
 	}
 	for src, dst := range hc.redirections {
@@ -9,10 +9,15 @@ import (
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/ihttp"
 	"github.com/voedger/voedger/pkg/istructs"
 )
 
 type StaticResourcesType map[string]fs.FS
+
+// StaticFolder could be used as a static resource to deploy it with the folder options
+type StaticFolder = ihttp.StaticFolder
+
 type RedirectRoutes map[string]string
 type DefaultRedirectRoute map[string]string // single record only
 type AppRequestHandler struct {
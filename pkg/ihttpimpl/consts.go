@@ -16,3 +16,4 @@ const (
 	defaultReadHeaderTimeout      = time.Second
 	staticPath                    = "/static/"
 )
+
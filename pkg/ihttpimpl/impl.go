@@ -30,6 +30,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/httpstatic"
 	"github.com/voedger/voedger/pkg/ihttp"
 	"github.com/voedger/voedger/pkg/istructs"
 	routerpkg "github.com/voedger/voedger/pkg/router"
@@ -158,6 +159,10 @@ func (p *httpProcessor) DeployStaticContent(resource string, fs fs.FS) {
 	p.router.addStaticContent(resource, fs)
 }
 
+func (p *httpProcessor) DeployStaticFolder(resource string, fs fs.FS, opts ihttp.StaticFolderOptions) {
+	p.router.deployStaticFolder(resource, fs, opts)
+}
+
 func (p *httpProcessor) DeployAppPartition(app appdef.AppQName, partNo istructs.PartitionID, appPartitionRequestHandler bus.RequestHandler) error {
 	p.Lock()
 	defer p.Unlock()
@@ -317,9 +322,24 @@ func (r *router) addStaticContent(resource string, fs fs.FS) {
 	r.Lock()
 	defer r.Unlock()
 
+	if fs == nil {
+		delete(r.staticContent, resource)
+		return
+	}
 	r.staticContent[resource] = staticContentHandler(staticPath+resource, fs)
 }
 
+func (r *router) deployStaticFolder(resource string, fs fs.FS, opts ihttp.StaticFolderOptions) {
+	r.Lock()
+	defer r.Unlock()
+
+	if fs == nil {
+		delete(r.staticContent, resource)
+		return
+	}
+	r.staticContent[resource] = httpstatic.NewFolderHandler(staticPath+resource, fs, opts.SPA).ServeHTTP
+}
+
 func (r *router) addReverseProxyRoute(srcRegExp, dstRegExp string) {
 	r.Lock()
 	defer r.Unlock()
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -262,6 +263,136 @@ func TestBasicUsage_HTTPProcessor(t *testing.T) {
 	})
 }
 
+func TestStaticFolder(t *testing.T) {
+	require := require.New(t)
+	testApp := setUp(t)
+	defer tearDown(testApp)
+
+	spaFS := fstest.MapFS{
+		"index.html":                 {Data: []byte("<html>index</html>")},
+		"assets/app.3f2a9c1b.js":     {Data: []byte("console.log('app')")},
+		"assets/app.3f2a9c1b.js.br":  {Data: []byte("br content")},
+		"assets/app.3f2a9c1b.js.gz":  {Data: []byte("gz content")},
+		"assets/my-component.js":     {Data: []byte("component")},
+		"assets/index-BdT3xYz1.css":  {Data: []byte("body{}")},
+		"docs/index.html":            {Data: []byte("<html>docs</html>")},
+		"docs/assets/other.js":       {Data: []byte("other")},
+		"docs/assets/other.js.gz":    {Data: []byte("other gz")},
+		"docs/assets/unknown.ext.gz": {Data: []byte("unknown gz")},
+	}
+	testApp.processor.DeployStaticFolder("spa", spaFS, ihttp.StaticFolderOptions{SPA: true})
+	testApp.processor.DeployStaticFolder("folder", spaFS, ihttp.StaticFolderOptions{})
+	testApp.processor.DeployStaticContent("plain", spaFS)
+
+	t.Run("single-page application", func(t *testing.T) {
+		t.Run("index.html should be served on history API routes", func(t *testing.T) {
+			for _, path := range []string{"/static/spa", "/static/spa/", "/static/spa/orders/123", "/static/spa/assets/unknown"} {
+				resp := testApp.request(path, nil)
+				require.Equal(http.StatusOK, resp.StatusCode, path)
+				require.Equal("<html>index</html>", string(resp.body), path)
+				require.Equal("text/html; charset=utf-8", resp.Header.Get(httpu.ContentType))
+			}
+		})
+
+		t.Run("index.html of the subfolder should be served", func(t *testing.T) {
+			resp := testApp.request("/static/spa/docs/", nil)
+			require.Equal("<html>docs</html>", string(resp.body))
+		})
+
+		t.Run("404 on unknown file with extension", func(t *testing.T) {
+			resp := testApp.request("/static/spa/assets/unknown.js", nil)
+			require.Equal(http.StatusNotFound, resp.StatusCode)
+		})
+
+		t.Run("no fallback if SPA is not enabled", func(t *testing.T) {
+			resp := testApp.request("/static/folder/orders/123", nil)
+			require.Equal(http.StatusNotFound, resp.StatusCode)
+			resp = testApp.request("/static/folder/", nil)
+			require.Equal("<html>index</html>", string(resp.body))
+		})
+	})
+
+	t.Run("static content should list folders", func(t *testing.T) {
+		resp := testApp.request("/static/plain/assets/", nil)
+		require.Equal(http.StatusOK, resp.StatusCode)
+		require.Contains(string(resp.body), `<a href="my-component.js">my-component.js</a>`)
+
+		resp = testApp.request("/static/folder/assets/", nil)
+		require.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("caching", func(t *testing.T) {
+		t.Run("fingerprinted files should be immutable", func(t *testing.T) {
+			for _, path := range []string{"/static/spa/assets/app.3f2a9c1b.js", "/static/spa/assets/index-BdT3xYz1.css"} {
+				resp := testApp.request(path, nil)
+				require.Equal("public, max-age=31536000, immutable", resp.Header.Get("Cache-Control"), path)
+			}
+		})
+
+		t.Run("other files should be revalidated", func(t *testing.T) {
+			for _, path := range []string{"/static/spa/", "/static/spa/assets/my-component.js"} {
+				resp := testApp.request(path, nil)
+				require.Equal("no-cache", resp.Header.Get("Cache-Control"), path)
+			}
+		})
+
+		t.Run("ETag should be the hash of the content", func(t *testing.T) {
+			resp := testApp.request("/static/spa/assets/my-component.js", nil)
+			etag := resp.Header.Get("ETag")
+			require.Regexp(`^"[0-9a-f]{32}"$`, etag)
+			require.Equal(etag, testApp.request("/static/folder/assets/my-component.js", nil).Header.Get("ETag"), "same content - same ETag")
+			require.NotEqual(etag, testApp.request("/static/spa/docs/assets/other.js", nil).Header.Get("ETag"))
+
+			resp = testApp.request("/static/spa/assets/my-component.js", map[string]string{"If-None-Match": etag})
+			require.Equal(http.StatusNotModified, resp.StatusCode)
+			require.Empty(resp.body)
+		})
+	})
+
+	t.Run("precompressed variants", func(t *testing.T) {
+		tests := []struct {
+			acceptEncoding string
+			path           string
+			body           string
+			encoding       string
+		}{
+			{"", "/static/spa/assets/app.3f2a9c1b.js", "console.log('app')", ""},
+			{"gzip, deflate, br", "/static/spa/assets/app.3f2a9c1b.js", "br content", "br"},
+			{"gzip, br;q=0", "/static/spa/assets/app.3f2a9c1b.js", "gz content", "gzip"},
+			{"*", "/static/spa/assets/app.3f2a9c1b.js", "br content", "br"},
+			{"br", "/static/spa/docs/assets/other.js", "other", ""},
+			{"gzip, br", "/static/spa/docs/assets/other.js", "other gz", "gzip"},
+		}
+		for _, test := range tests {
+			t.Run(test.acceptEncoding+" "+test.path, func(t *testing.T) {
+				// the client must not decompress the response itself
+				resp := testApp.request(test.path, map[string]string{"Accept-Encoding": test.acceptEncoding})
+				require.Equal(http.StatusOK, resp.StatusCode)
+				require.Equal(test.body, string(resp.body))
+				require.Equal(test.encoding, resp.Header.Get("Content-Encoding"))
+				require.Equal("text/javascript; charset=utf-8", resp.Header.Get(httpu.ContentType))
+				require.Equal("Accept-Encoding", resp.Header.Get("Vary"))
+			})
+		}
+
+		t.Run("variant without the original file should not be served", func(t *testing.T) {
+			resp := testApp.request("/static/spa/docs/assets/unknown.ext", map[string]string{"Accept-Encoding": "gzip"})
+			require.Equal(http.StatusNotFound, resp.StatusCode)
+		})
+	})
+
+	t.Run("only GET and HEAD are allowed", func(t *testing.T) {
+		body := testApp.post("/static/spa/index.html", "text/plain", "", nil)
+		require.Contains(string(body), http.StatusText(http.StatusMethodNotAllowed))
+	})
+
+	t.Run("nil fs should remove the folder", func(t *testing.T) {
+		testApp.processor.DeployStaticContent("plain", nil)
+		resp := testApp.request("/static/plain/", nil)
+		require.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+}
+
 func TestReverseProxy(t *testing.T) {
 	require := require.New(t)
 	testApp := setUp(t)
@@ -441,6 +572,29 @@ func (ta *testApp) get(resource string, expectedCodes ...int) []byte {
 	return body
 }
 
+type testResponse struct {
+	*http.Response
+	body []byte
+}
+
+// the response body is not decompressed
+func (ta *testApp) request(resource string, headers map[string]string) testResponse {
+	require := require.New(ta.t)
+	ta.t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d%s", ta.processor.ListeningPort(), resource), http.NoBody)
+	require.NoError(err)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := (&http.Client{Transport: &http.Transport{DisableCompression: true}}).Do(req)
+	require.NoError(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(err)
+	return testResponse{Response: resp, body: body}
+}
+
 func (ta *testApp) post(resource string, contentType string, requestText string, requestMap map[string]string) []byte {
 	require := require.New(ta.t)
 	ta.t.Helper()
@@ -11,6 +11,7 @@ import (
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/coreutils/httpcompress"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/ihttp"
@@ -29,7 +30,7 @@ func NewProcessor(params ihttp.CLIParams, routerStorage ihttp.IRouterStorage) (s
 		acmeDomains: &sync.Map{},
 		server: &http.Server{
 			Addr:              httpu.ListenAddr(params.Port),
-			Handler:           r,
+			Handler:           httpcompress.NewHandler(r, httpcompress.DefaultMinSize),
 			ReadHeaderTimeout: defaultReadHeaderTimeout,
 		},
 		apps:               make(map[appdef.AppQName]*appInfo),
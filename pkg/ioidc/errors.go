@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidc
+
+import "errors"
+
+var (
+	ErrProviderNotConfigured = errors.New("OpenID Connect provider is not configured for the app")
+	ErrDiscovery             = errors.New("failed to read OpenID Connect provider metadata")
+	ErrCodeExchange          = errors.New("failed to exchange authorization code")
+	ErrIDTokenInvalid        = errors.New("ID token is invalid")
+	ErrWrongProvidersConfig  = errors.New("wrong OpenID Connect providers config")
+)
@@ -0,0 +1,25 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidc
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+// Relying party of the OpenID Connect authorization code flow with PKCE
+// Each app could have its own identity provider, see [ProvidersConfig]
+type IRelyingParty interface {
+	// Generates state, nonce and PKCE code verifier and builds the URL of the authorization endpoint of the identity provider
+	// The request must be kept by the caller until the authorization code is returned to the redirect URL
+	// ErrProviderNotConfigured, ErrDiscovery might be returned
+	NewAuthRequest(ctx context.Context, appQName appdef.AppQName) (AuthRequest, error)
+
+	// Exchanges the authorization code to the ID token and validates the ID token:
+	// signature against JWKS of the identity provider, issuer, audience, expiration and nonce
+	// ErrProviderNotConfigured, ErrDiscovery, ErrCodeExchange, ErrIDTokenInvalid might be returned
+	Exchange(ctx context.Context, appQName appdef.AppQName, code string, authReq AuthRequest) (IDTokenClaims, error)
+}
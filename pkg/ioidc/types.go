@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidc
+
+import "github.com/voedger/voedger/pkg/appdef"
+
+type ProvidersConfig map[appdef.AppQName]ProviderConfig
+
+type ProviderConfig struct {
+	// discovery document is read from Issuer + "/.well-known/openid-configuration"
+	Issuer   string
+	ClientID string
+
+	// name of the secret the client secret is read from, empty for public clients
+	ClientSecretName string
+
+	// registered at the identity provider
+	// the page the identity provider redirects to must pass code and state to /api/v2/apps/{owner}/{app}/auth/oidc/callback
+	RedirectURL string
+
+	// requested in addition to "openid"
+	Scopes []string
+
+	// claim the display name of the profile is taken from on the first login, "name" by default
+	// "email" claim is used if the claim is missing in the ID token
+	DisplayNameClaim string
+}
+
+type AuthRequest struct {
+	URL          string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+type IDTokenClaims struct {
+	Issuer      string
+	Subject     string
+	DisplayName string
+	Claims      map[string]any // all claims of the ID token
+}
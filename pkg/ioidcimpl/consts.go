@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidcimpl
+
+import "time"
+
+const (
+	discoveryPath           = "/.well-known/openid-configuration"
+	scopeOpenID             = "openid"
+	codeChallengeMethodS256 = "S256"
+	defaultDisplayNameClaim = "name"
+	claimEmail              = "email"
+	claimNonce              = "nonce"
+	claimAuthorizedParty    = "azp"
+
+	contentTypeFormURLEncoded = "application/x-www-form-urlencoded"
+
+	// bytes of state, nonce and PKCE code verifier, the code verifier is 43 chars then as required by RFC 7636
+	flowSecretLen = 32
+
+	httpTimeout     = 10 * time.Second
+	maxResponseSize = 1 << 20
+
+	// JWKS is re-read on an unknown key ID not more often than this, e.g. on the key rotation at the identity provider
+	jwksMinRefreshInterval = time.Minute
+
+	// clock skew between VVM and the identity provider
+	idTokenLeeway = time.Minute
+)
+
+var idTokenSigningMethods = []string{"RS256", "ES256"}
@@ -0,0 +1,319 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidcimpl
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/ioidc"
+)
+
+func (rp *implRelyingParty) NewAuthRequest(ctx context.Context, appQName appdef.AppQName) (ioidc.AuthRequest, error) {
+	p, err := rp.provider(appQName)
+	if err != nil {
+		return ioidc.AuthRequest{}, err
+	}
+	md, err := p.getMetadata(ctx, rp.httpClient)
+	if err != nil {
+		return ioidc.AuthRequest{}, err
+	}
+	authReq := ioidc.AuthRequest{
+		State:        flowSecret(),
+		Nonce:        flowSecret(),
+		CodeVerifier: flowSecret(),
+	}
+	scopes := []string{scopeOpenID}
+	for _, scope := range p.cfg.Scopes {
+		if scope != scopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.cfg.ClientID)
+	params.Set("redirect_uri", p.cfg.RedirectURL)
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("state", authReq.State)
+	params.Set("nonce", authReq.Nonce)
+	params.Set("code_challenge", codeChallenge(authReq.CodeVerifier))
+	params.Set("code_challenge_method", codeChallengeMethodS256)
+	separator := "?"
+	if strings.Contains(md.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	authReq.URL = md.AuthorizationEndpoint + separator + params.Encode()
+	return authReq, nil
+}
+
+func (rp *implRelyingParty) Exchange(ctx context.Context, appQName appdef.AppQName, code string, authReq ioidc.AuthRequest) (ioidc.IDTokenClaims, error) {
+	p, err := rp.provider(appQName)
+	if err != nil {
+		return ioidc.IDTokenClaims{}, err
+	}
+	md, err := p.getMetadata(ctx, rp.httpClient)
+	if err != nil {
+		return ioidc.IDTokenClaims{}, err
+	}
+	rawIDToken, err := rp.exchangeCode(ctx, p, md, code, authReq.CodeVerifier)
+	if err != nil {
+		return ioidc.IDTokenClaims{}, err
+	}
+	return rp.validateIDToken(ctx, p, md, rawIDToken, authReq.Nonce)
+}
+
+func (rp *implRelyingParty) provider(appQName appdef.AppQName) (*provider, error) {
+	if p, ok := rp.providers.Load(appQName); ok {
+		return p.(*provider), nil
+	}
+	cfg, ok := rp.cfg[appQName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ioidc.ErrProviderNotConfigured, appQName)
+	}
+	p, _ := rp.providers.LoadOrStore(appQName, &provider{cfg: cfg})
+	return p.(*provider), nil
+}
+
+// client_secret_post authentication is used for confidential clients
+func (rp *implRelyingParty) exchangeCode(ctx context.Context, p *provider, md *providerMetadata, code string, codeVerifier string) (rawIDToken string, err error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if len(p.cfg.ClientSecretName) > 0 {
+		clientSecret, err := rp.secretReader.ReadSecret(p.cfg.ClientSecretName)
+		if err != nil {
+			return "", fmt.Errorf("failed to read client secret %s: %w", p.cfg.ClientSecretName, err)
+		}
+		form.Set("client_secret", strings.TrimSpace(string(clientSecret)))
+	}
+	body, statusCode, err := doRequest(ctx, rp.httpClient, md.TokenEndpoint, form.Encode(), httpu.WithMethod(http.MethodPost),
+		httpu.WithHeaders(httpu.ContentType, contentTypeFormURLEncoded, httpu.Accept, httpu.ContentType_ApplicationJSON))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ioidc.ErrCodeExchange, err)
+	}
+	tokenResp := tokenResponse{}
+	if err := json.Unmarshal(body, &tokenResp); err != nil && statusCode == http.StatusOK {
+		return "", fmt.Errorf("%w: %w", ioidc.ErrCodeExchange, err)
+	}
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: status %d: %s %s", ioidc.ErrCodeExchange, statusCode, tokenResp.Error, tokenResp.ErrorDescription)
+	}
+	if len(tokenResp.IDToken) == 0 {
+		return "", fmt.Errorf("%w: id_token is missing in the response", ioidc.ErrCodeExchange)
+	}
+	return tokenResp.IDToken, nil
+}
+
+// https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+func (rp *implRelyingParty) validateIDToken(ctx context.Context, p *provider, md *providerMetadata, rawIDToken string, nonce string) (ioidc.IDTokenClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, rp.httpClient, md.JWKSURI, kid, rp.time.Now())
+	},
+		jwt.WithValidMethods(idTokenSigningMethods),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+		jwt.WithTimeFunc(rp.time.Now),
+	)
+	if err != nil {
+		return ioidc.IDTokenClaims{}, fmt.Errorf("%w: %w", ioidc.ErrIDTokenInvalid, err)
+	}
+
+	tokenNonce, _ := claims[claimNonce].(string)
+	if subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return ioidc.IDTokenClaims{}, fmt.Errorf("%w: nonce mismatch", ioidc.ErrIDTokenInvalid)
+	}
+	audience, _ := claims.GetAudience()
+	if len(audience) > 1 {
+		if azp, _ := claims[claimAuthorizedParty].(string); azp != p.cfg.ClientID {
+			return ioidc.IDTokenClaims{}, fmt.Errorf("%w: authorized party %q mismatch", ioidc.ErrIDTokenInvalid, azp)
+		}
+	}
+	subject, _ := claims.GetSubject()
+	if len(subject) == 0 {
+		return ioidc.IDTokenClaims{}, fmt.Errorf("%w: subject is missing", ioidc.ErrIDTokenInvalid)
+	}
+
+	displayNameClaim := p.cfg.DisplayNameClaim
+	if len(displayNameClaim) == 0 {
+		displayNameClaim = defaultDisplayNameClaim
+	}
+	displayName, _ := claims[displayNameClaim].(string)
+	if len(displayName) == 0 {
+		displayName, _ = claims[claimEmail].(string)
+	}
+	return ioidc.IDTokenClaims{
+		Issuer:      p.cfg.Issuer,
+		Subject:     subject,
+		DisplayName: displayName,
+		Claims:      claims,
+	}, nil
+}
+
+// metadata is read once, failures are not cached
+func (p *provider) getMetadata(ctx context.Context, httpClient httpu.IHTTPClient) (*providerMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+	md := &providerMetadata{}
+	if err := getJSON(ctx, httpClient, strings.TrimSuffix(p.cfg.Issuer, "/")+discoveryPath, md); err != nil {
+		return nil, fmt.Errorf("%w: %w", ioidc.ErrDiscovery, err)
+	}
+	if md.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match the configured issuer %q", ioidc.ErrDiscovery, md.Issuer, p.cfg.Issuer)
+	}
+	if len(md.AuthorizationEndpoint) == 0 || len(md.TokenEndpoint) == 0 || len(md.JWKSURI) == 0 {
+		return nil, fmt.Errorf("%w: authorization_endpoint, token_endpoint and jwks_uri are required", ioidc.ErrDiscovery)
+	}
+	p.metadata = md
+	return md, nil
+}
+
+// JWKS is re-read if the key is unknown, e.g. the identity provider rotated the keys
+func (p *provider) getKey(ctx context.Context, httpClient httpu.IHTTPClient, jwksURI string, kid string, now time.Time) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+	if !p.keysReadAt.IsZero() && now.Sub(p.keysReadAt) < jwksMinRefreshInterval {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	keySet := jsonWebKeySet{}
+	if err := getJSON(ctx, httpClient, jwksURI, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range keySet.Keys {
+		if len(jwk.Use) > 0 && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// unsupported keys are skipped, the token signed by such key will be denied
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys, p.keysReadAt = keys, now
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+// key ID could be omitted in the token if JWKS contains the single key only
+func (p *provider) findKey(kid string) (crypto.PublicKey, bool) {
+	if len(kid) == 0 && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("wrong RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("wrong P-256 point")
+		}
+		uncompressed := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), uncompressed)
+	}
+	return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+}
+
+func getJSON(ctx context.Context, httpClient httpu.IHTTPClient, url string, target any) error {
+	body, statusCode, err := doRequest(ctx, httpClient, url, "", httpu.WithHeaders(httpu.Accept, httpu.ContentType_ApplicationJSON))
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %d", url, statusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// the status code is checked by the caller, the request is not retried since the authorization code could be used once only
+func doRequest(ctx context.Context, httpClient httpu.IHTTPClient, url string, body string, opts ...httpu.ReqOptFunc) (respBody []byte, statusCode int, err error) {
+	ctx, cancel := context.WithTimeout(ctx, httpTimeout)
+	defer cancel()
+	opts = append(opts, httpu.WithNoRetryPolicy(), httpu.WithMaxResponseBodySize(maxResponseSize))
+	resp, err := httpClient.Req(ctx, url, body, opts...)
+	if err != nil && !errors.Is(err, httpu.ErrUnexpectedStatusCode) {
+		return nil, 0, err
+	}
+	return []byte(resp.Body), resp.HTTPResp.StatusCode, nil
+}
+
+// https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func codeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func flowSecret() string {
+	secret := make([]byte, flowSecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		// notest
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(secret)
+}
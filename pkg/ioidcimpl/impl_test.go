@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidcimpl
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/ioidc"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+const testRedirectURL = "https://app.example.com/oidc/callback"
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	mockTime := testingu.NewMockTime()
+	httpClient, cleanup := httpu.NewIHTTPClient()
+	defer cleanup()
+	idp := NewFakeIdP("voedger-client", mockTime)
+	defer idp.Close()
+	idp.AddUser("user1", map[string]any{"name": "John Doe", "email": "john@example.com"})
+
+	rp := Provide(ioidc.ProvidersConfig{istructs.AppQName_test1_app1: idp.ProviderConfig(testRedirectURL)}, nil, httpClient, mockTime)
+
+	authReq, err := rp.NewAuthRequest(context.Background(), istructs.AppQName_test1_app1)
+	require.NoError(err)
+	authURL, err := url.Parse(authReq.URL)
+	require.NoError(err)
+	require.Equal(idp.Issuer()+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	require.Equal("openid email profile", authURL.Query().Get("scope"))
+	require.Equal(codeChallenge(authReq.CodeVerifier), authURL.Query().Get("code_challenge"))
+	require.Equal(testRedirectURL, authURL.Query().Get("redirect_uri"))
+
+	code, state, err := idp.Authorize(authReq.URL, "user1")
+	require.NoError(err)
+	require.Equal(authReq.State, state)
+
+	claims, err := rp.Exchange(context.Background(), istructs.AppQName_test1_app1, code, authReq)
+	require.NoError(err)
+	require.Equal(idp.Issuer(), claims.Issuer)
+	require.Equal("user1", claims.Subject)
+	require.Equal("John Doe", claims.DisplayName)
+	require.Equal("john@example.com", claims.Claims["email"])
+}
+
+func TestErrors(t *testing.T) {
+	require := require.New(t)
+	mockTime := testingu.NewMockTime()
+	httpClient, cleanup := httpu.NewIHTTPClient()
+	defer cleanup()
+	idp := NewFakeIdP("voedger-client", mockTime)
+	defer idp.Close()
+	idp.AddUser("user1", map[string]any{"email": "john@example.com"})
+	rp := Provide(ioidc.ProvidersConfig{istructs.AppQName_test1_app1: idp.ProviderConfig(testRedirectURL)}, nil, httpClient, mockTime)
+	ctx := context.Background()
+
+	authorize := func() (code string, authReq ioidc.AuthRequest) {
+		authReq, err := rp.NewAuthRequest(ctx, istructs.AppQName_test1_app1)
+		require.NoError(err)
+		code, _, err = idp.Authorize(authReq.URL, "user1")
+		require.NoError(err)
+		return code, authReq
+	}
+
+	t.Run("provider is not configured", func(t *testing.T) {
+		_, err := rp.NewAuthRequest(ctx, istructs.AppQName_test1_app2)
+		require.ErrorIs(err, ioidc.ErrProviderNotConfigured)
+		_, err = rp.Exchange(ctx, istructs.AppQName_test1_app2, "code", ioidc.AuthRequest{})
+		require.ErrorIs(err, ioidc.ErrProviderNotConfigured)
+	})
+
+	t.Run("provider is unavailable", func(t *testing.T) {
+		unavailableIdP := NewFakeIdP("voedger-client", mockTime)
+		unavailableIdP.Close()
+		rp := Provide(ioidc.ProvidersConfig{istructs.AppQName_test1_app1: unavailableIdP.ProviderConfig(testRedirectURL)}, nil, httpClient, mockTime)
+		_, err := rp.NewAuthRequest(ctx, istructs.AppQName_test1_app1)
+		require.ErrorIs(err, ioidc.ErrDiscovery)
+	})
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		cfg := idp.ProviderConfig(testRedirectURL)
+		cfg.Issuer += "/"
+		rp := Provide(ioidc.ProvidersConfig{istructs.AppQName_test1_app1: cfg}, nil, httpClient, mockTime)
+		_, err := rp.NewAuthRequest(ctx, istructs.AppQName_test1_app1)
+		require.ErrorIs(err, ioidc.ErrDiscovery)
+	})
+
+	t.Run("display name is taken from email if name claim is missing", func(t *testing.T) {
+		code, authReq := authorize()
+		claims, err := rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+		require.NoError(err)
+		require.Equal("john@example.com", claims.DisplayName)
+	})
+
+	t.Run("code could be used once", func(t *testing.T) {
+		code, authReq := authorize()
+		_, err := rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+		require.NoError(err)
+		_, err = rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+		require.ErrorIs(err, ioidc.ErrCodeExchange)
+		require.ErrorContains(err, "unknown or used code")
+	})
+
+	t.Run("wrong PKCE code verifier", func(t *testing.T) {
+		code, authReq := authorize()
+		authReq.CodeVerifier = flowSecret()
+		_, err := rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+		require.ErrorIs(err, ioidc.ErrCodeExchange)
+		require.ErrorContains(err, "PKCE verification failed")
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		code, authReq := authorize()
+		authReq.Nonce = flowSecret()
+		_, err := rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+		require.ErrorIs(err, ioidc.ErrIDTokenInvalid)
+		require.ErrorContains(err, "nonce mismatch")
+	})
+
+	t.Run("wrong claims", func(t *testing.T) {
+		cases := map[string]map[string]any{
+			"wrong audience":           {"aud": "another-client"},
+			"wrong issuer":             {"iss": "https://another.example.com"},
+			"expired":                  {"exp": mockTime.Now().Add(-time.Hour).Unix()},
+			"missing expiration":       {"exp": nil},
+			"missing subject":          {"sub": ""},
+			"another authorized party": {"aud": []string{"voedger-client", "another-client"}, "azp": "another-client"},
+			"issued in the future":     {"iat": mockTime.Now().Add(time.Hour).Unix()},
+		}
+		for name, claimsOverride := range cases {
+			t.Run(name, func(t *testing.T) {
+				idp.setClaimsOverride(claimsOverride)
+				defer idp.setClaimsOverride(nil)
+				code, authReq := authorize()
+				_, err := rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+				require.ErrorIs(err, ioidc.ErrIDTokenInvalid)
+			})
+		}
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		idp.RotateKey()
+
+		// JWKS was read recently -> the new key is not known yet
+		code, authReq := authorize()
+		_, err := rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+		require.ErrorIs(err, ioidc.ErrIDTokenInvalid)
+
+		// JWKS is re-read on the unknown key then
+		mockTime.Add(jwksMinRefreshInterval)
+		code, authReq = authorize()
+		_, err = rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+		require.NoError(err)
+	})
+}
+
+func TestClientSecret(t *testing.T) {
+	require := require.New(t)
+	mockTime := testingu.NewMockTime()
+	httpClient, cleanup := httpu.NewIHTTPClient()
+	defer cleanup()
+	idp := NewFakeIdP("voedger-client", mockTime)
+	defer idp.Close()
+	idp.ClientSecret = "client-secret"
+	idp.AddUser("user1", map[string]any{})
+	cfg := idp.ProviderConfig(testRedirectURL)
+	cfg.ClientSecretName = "oidc-client-secret"
+	ctx := context.Background()
+
+	exchange := func(secrets testSecretReader) error {
+		rp := Provide(ioidc.ProvidersConfig{istructs.AppQName_test1_app1: cfg}, secrets, httpClient, mockTime)
+		authReq, err := rp.NewAuthRequest(ctx, istructs.AppQName_test1_app1)
+		require.NoError(err)
+		code, _, err := idp.Authorize(authReq.URL, "user1")
+		require.NoError(err)
+		_, err = rp.Exchange(ctx, istructs.AppQName_test1_app1, code, authReq)
+		return err
+	}
+
+	require.NoError(exchange(testSecretReader{"oidc-client-secret": "client-secret\n"}))
+	require.ErrorIs(exchange(testSecretReader{"oidc-client-secret": "wrong"}), ioidc.ErrCodeExchange)
+	require.ErrorContains(exchange(testSecretReader{}), "failed to read client secret")
+}
+
+func TestReadProvidersConfig(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("basic usage", func(t *testing.T) {
+		cfg, err := ReadProvidersConfig(testSecretReader{"oidc-providers": `{"test1/app1": {
+			"Issuer": "https://idp.example.com", "ClientID": "voedger-client", "ClientSecretName": "oidc-client-secret",
+			"RedirectURL": "` + testRedirectURL + `", "Scopes": ["email"]}}`}, "oidc-providers")
+		require.NoError(err)
+		require.Equal(ioidc.ProvidersConfig{istructs.AppQName_test1_app1: {
+			Issuer:           "https://idp.example.com",
+			ClientID:         "voedger-client",
+			ClientSecretName: "oidc-client-secret",
+			RedirectURL:      testRedirectURL,
+			Scopes:           []string{"email"},
+		}}, cfg)
+	})
+
+	t.Run("no providers if the secret does not exist", func(t *testing.T) {
+		cfg, err := ReadProvidersConfig(testSecretReader{}, "oidc-providers")
+		require.NoError(err)
+		require.Empty(cfg)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, secret := range []string{
+			`wrong`,
+			`{"wrong app": {"Issuer": "https://idp.example.com", "ClientID": "voedger-client", "RedirectURL": "` + testRedirectURL + `"}}`,
+			`{"test1/app1": {"ClientID": "voedger-client", "RedirectURL": "` + testRedirectURL + `"}}`,
+			`{"test1/app1": {"Issuer": "https://idp.example.com", "RedirectURL": "` + testRedirectURL + `"}}`,
+			`{"test1/app1": {"Issuer": "https://idp.example.com", "ClientID": "voedger-client"}}`,
+		} {
+			_, err := ReadProvidersConfig(testSecretReader{"oidc-providers": secret}, "oidc-providers")
+			require.ErrorIs(err, ioidc.ErrWrongProvidersConfig, secret)
+		}
+	})
+}
+
+type testSecretReader map[string]string
+
+func (sr testSecretReader) ReadSecret(name string) ([]byte, error) {
+	if secret, ok := sr[name]; ok {
+		return []byte(secret), nil
+	}
+	return nil, fmt.Errorf("%w: %s", fs.ErrNotExist, name)
+}
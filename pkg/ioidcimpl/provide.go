@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidcimpl
+
+import (
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/ioidc"
+	"github.com/voedger/voedger/pkg/isecrets"
+)
+
+// provider metadata and JWKS are read on the first use
+func Provide(cfg ioidc.ProvidersConfig, secretReader isecrets.ISecretReader, httpClient httpu.IHTTPClient, time timeu.ITime) ioidc.IRelyingParty {
+	return &implRelyingParty{
+		cfg:          cfg,
+		secretReader: secretReader,
+		time:         time,
+		httpClient:   httpClient,
+	}
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidcimpl
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/ioidc"
+)
+
+// In-process OpenID Connect identity provider for tests
+// The user is authenticated by login_hint parameter of the authorization request, see [FakeIdP.Authorize]
+type FakeIdP struct {
+	ClientID     string
+	ClientSecret string // checked by the token endpoint if not empty
+	server       *httptest.Server
+	time         timeu.ITime
+	mu           sync.Mutex
+	key          *rsa.PrivateKey
+	kid          string
+	users        map[string]map[string]any // subject -> claims
+	codes        map[string]fakeAuthCode
+
+	// merged into the claims of the issued ID tokens, used to issue invalid tokens
+	claimsOverride map[string]any
+}
+
+type fakeAuthCode struct {
+	subject       string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+func NewFakeIdP(clientID string, time timeu.ITime) *FakeIdP {
+	idp := &FakeIdP{
+		ClientID: clientID,
+		time:     time,
+		users:    map[string]map[string]any{},
+		codes:    map[string]fakeAuthCode{},
+	}
+	idp.RotateKey()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+discoveryPath, idp.handleDiscovery)
+	mux.HandleFunc("GET /authorize", idp.handleAuthorize)
+	mux.HandleFunc("POST /token", idp.handleToken)
+	mux.HandleFunc("GET /jwks", idp.handleJWKS)
+	idp.server = httptest.NewServer(mux)
+	return idp
+}
+
+func (idp *FakeIdP) Issuer() string { return idp.server.URL }
+
+func (idp *FakeIdP) Close() { idp.server.Close() }
+
+func (idp *FakeIdP) ProviderConfig(redirectURL string) ioidc.ProviderConfig {
+	return ioidc.ProviderConfig{
+		Issuer:      idp.Issuer(),
+		ClientID:    idp.ClientID,
+		RedirectURL: redirectURL,
+		Scopes:      []string{"email", "profile"},
+	}
+}
+
+// claims are returned in the ID token issued to the user
+func (idp *FakeIdP) AddUser(subject string, claims map[string]any) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.users[subject] = claims
+}
+
+// new signing key, the previous one is not published in JWKS anymore
+func (idp *FakeIdP) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		// notest
+		panic(err)
+	}
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.key = key
+	idp.kid = flowSecret()
+}
+
+func (idp *FakeIdP) setClaimsOverride(claimsOverride map[string]any) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.claimsOverride = claimsOverride
+}
+
+// Acts as the user agent of the user signed in at the identity provider:
+// follows the authorization URL and returns code and state passed to the redirect URL
+func (idp *FakeIdP) Authorize(authURL string, subject string) (code string, state string, err error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	query := u.Query()
+	query.Set("login_hint", subject)
+	u.RawQuery = query.Encode()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("authorization failed: status %d", resp.StatusCode)
+	}
+	redirectURL, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+	return redirectURL.Query().Get("code"), redirectURL.Query().Get("state"), nil
+}
+
+func (idp *FakeIdP) handleDiscovery(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, providerMetadata{
+		Issuer:                idp.Issuer(),
+		AuthorizationEndpoint: idp.Issuer() + "/authorize",
+		TokenEndpoint:         idp.Issuer() + "/token",
+		JWKSURI:               idp.Issuer() + "/jwks",
+	})
+}
+
+func (idp *FakeIdP) handleAuthorize(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	_, userExists := idp.users[query.Get("login_hint")]
+	switch {
+	case query.Get("response_type") != "code", query.Get("client_id") != idp.ClientID, len(query.Get("redirect_uri")) == 0,
+		query.Get("code_challenge_method") != codeChallengeMethodS256, len(query.Get("code_challenge")) == 0:
+		http.Error(rw, "invalid_request", http.StatusBadRequest)
+		return
+	case !userExists:
+		http.Error(rw, "access_denied", http.StatusForbidden)
+		return
+	}
+	code := flowSecret()
+	idp.codes[code] = fakeAuthCode{
+		subject:       query.Get("login_hint"),
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	redirectParams := url.Values{}
+	redirectParams.Set("code", code)
+	redirectParams.Set("state", query.Get("state"))
+	http.Redirect(rw, req, query.Get("redirect_uri")+"?"+redirectParams.Encode(), http.StatusFound)
+}
+
+func (idp *FakeIdP) handleToken(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeJSON(rw, http.StatusBadRequest, tokenResponse{Error: "invalid_request", ErrorDescription: err.Error()})
+		return
+	}
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	code := req.PostForm.Get("code")
+	authCode, ok := idp.codes[code]
+	delete(idp.codes, code) // the code could be used once
+	if err := idp.checkTokenRequest(req.PostForm, authCode, ok); err != nil {
+		writeJSON(rw, http.StatusBadRequest, tokenResponse{Error: "invalid_grant", ErrorDescription: err.Error()})
+		return
+	}
+	now := idp.time.Now()
+	claims := jwt.MapClaims{
+		"iss":      idp.Issuer(),
+		"sub":      authCode.subject,
+		"aud":      idp.ClientID,
+		"iat":      now.Unix(),
+		"exp":      now.Add(time.Hour).Unix(),
+		claimNonce: authCode.nonce,
+	}
+	for name, value := range idp.users[authCode.subject] {
+		claims[name] = value
+	}
+	for name, value := range idp.claimsOverride {
+		claims[name] = value
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = idp.kid
+	idToken, err := token.SignedString(idp.key)
+	if err != nil {
+		// notest
+		writeJSON(rw, http.StatusInternalServerError, tokenResponse{Error: "server_error", ErrorDescription: err.Error()})
+		return
+	}
+	writeJSON(rw, http.StatusOK, map[string]any{
+		"access_token": flowSecret(),
+		"token_type":   "Bearer",
+		"expires_in":   int(time.Hour.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (idp *FakeIdP) checkTokenRequest(form url.Values, authCode fakeAuthCode, codeExists bool) error {
+	switch {
+	case form.Get("grant_type") != "authorization_code":
+		return errors.New("unsupported grant_type")
+	case !codeExists:
+		return errors.New("unknown or used code")
+	case form.Get("client_id") != idp.ClientID:
+		return errors.New("client_id mismatch")
+	case len(idp.ClientSecret) > 0 && subtle.ConstantTimeCompare([]byte(form.Get("client_secret")), []byte(idp.ClientSecret)) != 1:
+		return errors.New("client authentication failed")
+	case form.Get("redirect_uri") != authCode.redirectURI:
+		return errors.New("redirect_uri mismatch")
+	case codeChallenge(form.Get("code_verifier")) != authCode.codeChallenge:
+		return errors.New("PKCE verification failed")
+	}
+	return nil
+}
+
+func (idp *FakeIdP) handleJWKS(rw http.ResponseWriter, _ *http.Request) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	writeJSON(rw, http.StatusOK, jsonWebKeySet{
+		Keys: []jsonWebKey{{
+			Kty: "RSA",
+			Kid: idp.kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+		}},
+	})
+}
+
+func writeJSON(rw http.ResponseWriter, statusCode int, value any) {
+	rw.Header().Set(httpu.ContentType, httpu.ContentType_ApplicationJSON)
+	rw.WriteHeader(statusCode)
+	_ = json.NewEncoder(rw).Encode(value)
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidcimpl
+
+import (
+	"crypto"
+	"sync"
+	"time"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/ioidc"
+	"github.com/voedger/voedger/pkg/isecrets"
+)
+
+type implRelyingParty struct {
+	cfg          ioidc.ProvidersConfig
+	secretReader isecrets.ISecretReader
+	time         timeu.ITime
+	httpClient   httpu.IHTTPClient
+	providers    sync.Map // appdef.AppQName -> *provider
+}
+
+type provider struct {
+	cfg        ioidc.ProviderConfig
+	mu         sync.Mutex
+	metadata   *providerMetadata           // nil until read successfully
+	keys       map[string]crypto.PublicKey // key ID -> key
+	keysReadAt time.Time
+}
+
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// https://datatracker.ietf.org/doc/html/rfc7517
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package ioidcimpl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/ioidc"
+	"github.com/voedger/voedger/pkg/isecrets"
+)
+
+// ReadProvidersConfig reads the providers from the secret, there are no providers if the secret does not exist
+// the secret is JSON object of ioidc.ProviderConfig by app, e.g.
+// {"untill/airs-bp": {"Issuer": "https://idp.example.com", "ClientID": "airs-bp", "ClientSecretName": "oidc-airs-bp", "RedirectURL": "https://airs.example.com/oidc"}}
+func ReadProvidersConfig(secretReader isecrets.ISecretReader, secretName string) (ioidc.ProvidersConfig, error) {
+	secret, err := secretReader.ReadSecret(secretName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ioidc.ProvidersConfig{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	providers := map[string]ioidc.ProviderConfig{}
+	if err := json.Unmarshal(secret, &providers); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ioidc.ErrWrongProvidersConfig, secretName, err)
+	}
+	res := ioidc.ProvidersConfig{}
+	for appName, cfg := range providers {
+		appQName, err := appdef.ParseAppQName(appName)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ioidc.ErrWrongProvidersConfig, secretName, err)
+		}
+		if len(cfg.Issuer) == 0 || len(cfg.ClientID) == 0 || len(cfg.RedirectURL) == 0 {
+			return nil, fmt.Errorf("%w: %s: Issuer, ClientID and RedirectURL are required for %s", ioidc.ErrWrongProvidersConfig, secretName, appQName)
+		}
+		res[appQName] = cfg
+	}
+	return res, nil
+}
@@ -5,6 +5,8 @@
 package irates
 
 import (
+	"time"
+
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istructs"
 )
@@ -39,12 +41,19 @@ type IBuckets interface {
 
 	// returns ErrorRateLimitNotFound
 	GetBucketState(bucketKey BucketKey) (state BucketState, err error)
+
+	// Returns the time to wait until n tokens can be taken from the given bucket.
+	// Zero is returned if n tokens are available now
+	//
+	// returns ErrorRateLimitNotFound
+	RetryAfter(bucketKey BucketKey, n int) (wait time.Duration, err error)
 }
 
 type BucketKey struct {
 	RateLimitName appdef.QName
 	RemoteAddr    string
 	App           appdef.AppQName //?
+	Partition     istructs.PartitionID
 	Workspace     istructs.WSID
 	QName         appdef.QName
 	ID            istructs.RecordID //?
@@ -58,4 +67,8 @@ type BucketState struct {
 
 type NumTokensType = appdef.RateCount
 
-type BucketsFactoryType func() IBuckets
+// Returns buckets of the specified kind.
+//
+// Storage is the application TTL storage used to keep distributed buckets
+// (RateBuckets_Distributed, RateBuckets_SlidingWindow). Local buckets ignore it.
+type BucketsFactoryType func(buckets appdef.RateBuckets, storage istructs.IAppTTLStorage) IBuckets
@@ -159,6 +159,23 @@ func (b *bucketsType) GetBucketState(bucketKey irates.BucketKey) (state irates.B
 	return state, irates.ErrorRateLimitNotFound
 }
 
+// returns the time to wait until n tokens are refilled in the bucket corresponding to the transmitted key
+func (b *bucketsType) RetryAfter(bucketKey irates.BucketKey, n int) (wait time.Duration, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	buc := b.bucketByKey(&bucketKey)
+
+	if buc == nil {
+		return 0, irates.ErrorRateLimitNotFound
+	}
+
+	_, _, tokens := buc.limiter.advance(b.time.Now())
+	if deficit := float64(n) - tokens; deficit > 0 {
+		return buc.limiter.limit.durationFromTokens(deficit), nil
+	}
+	return 0, nil
+}
+
 func (b *bucketsType) SetBucketState(bucketKey irates.BucketKey, state irates.BucketState) (err error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -221,3 +221,35 @@ func TestBucketsNew(t *testing.T) {
 	require.Error(err)
 	require.True(BucketStateIsZero(&bs))
 }
+
+func TestRetryAfter(t *testing.T) {
+	require := require.New(t)
+
+	limitName := appdef.NewQName("test", "limit")
+	key := irates.BucketKey{RateLimitName: limitName, Workspace: 1}
+
+	buckets := Provide(testingu.MockTime)
+	buckets.SetDefaultBucketState(limitName, irates.BucketState{
+		Period:             time.Minute,
+		MaxTokensPerPeriod: 3,
+	})
+
+	wait, err := buckets.RetryAfter(key, 1)
+	require.NoError(err)
+	require.Zero(wait, "should be zero if bucket is full")
+
+	ok, _ := buckets.TakeTokens([]irates.BucketKey{key}, 3)
+	require.True(ok)
+
+	wait, err = buckets.RetryAfter(key, 1)
+	require.NoError(err)
+	require.InDelta(20*time.Second, wait, float64(time.Millisecond), "one token is refilled every 20 seconds")
+
+	testingu.MockTime.Add(5 * time.Second)
+	wait, err = buckets.RetryAfter(key, 2)
+	require.NoError(err)
+	require.InDelta(35*time.Second, wait, float64(time.Millisecond))
+
+	_, err = buckets.RetryAfter(irates.BucketKey{RateLimitName: appdef.NewQName("test", "unknown")}, 1)
+	require.ErrorIs(err, irates.ErrorRateLimitNotFound)
+}
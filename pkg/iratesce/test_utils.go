@@ -5,10 +5,13 @@
 package iratesce
 
 import (
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
 	irates "github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/istructs"
 )
 
-var TestBucketsFactory = func() irates.IBuckets {
+// Returns in-memory buckets for any kind of buckets
+var TestBucketsFactory = func(appdef.RateBuckets, istructs.IAppTTLStorage) irates.IBuckets {
 	return Provide(testingu.MockTime)
 }
@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+const (
+	// prefix of the application TTL storage keys used by token buckets
+	keyPrefix_TokenBucket = "sys.rate.tb|"
+
+	// prefix of the application TTL storage keys used by sliding windows
+	keyPrefix_SlidingWindow = "sys.rate.sw|"
+
+	// max attempts to update bucket if concurrent modifications occur
+	maxCASAttempts = 16
+
+	// max TTL accepted by application TTL storage, see vvm/storage.MaxTTLSeconds
+	maxTTLSeconds = 31536000
+)
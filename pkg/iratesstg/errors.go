@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import "errors"
+
+var ErrTooManyConflicts = errors.New("too many concurrent bucket modifications")
@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/irates"
+)
+
+// Try to take n tokens from the given buckets
+//
+// If some bucket is modified and the next one has no tokens then taken tokens are returned back.
+// If tokens can not be taken because of a storage error or too many concurrent modifications,
+// then the error is logged and the request is denied, so the limit is never exceeded.
+func (b *bucketsType) TakeTokens(buckets []irates.BucketKey, n int) (ok bool, excLimit appdef.QName) {
+	now := b.time.Now()
+	taken := make([]int, 0, len(buckets))
+	for i := range buckets {
+		state, exists := b.defaultState(buckets[i].RateLimitName)
+		if !exists || unlimited(state) {
+			continue
+		}
+		allowed, err := b.take(&buckets[i], state, now, n)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to take tokens from bucket «%s»: %v", b.key(&buckets[i]), err))
+		}
+		if !allowed {
+			b.giveBack(buckets, taken, now, n)
+			return false, buckets[i].RateLimitName
+		}
+		taken = append(taken, i)
+	}
+	return true, appdef.NullQName
+}
+
+func (b *bucketsType) SetDefaultBucketState(rateLimitName appdef.QName, bucketState irates.BucketState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.defaultStates[rateLimitName] = bucketState
+}
+
+// returns irates.ErrorRateLimitNotFound
+func (b *bucketsType) GetDefaultBucketsState(rateLimitName appdef.QName) (state irates.BucketState, err error) {
+	state, ok := b.defaultState(rateLimitName)
+	if !ok {
+		return state, irates.ErrorRateLimitNotFound
+	}
+	return state, nil
+}
+
+// Changes the default state of buckets with the name RateLimitName.
+//
+// Stored buckets can not be enumerated, so they are not reset.
+// New parameters are applied to them on the next tokens take.
+func (b *bucketsType) ResetRateBuckets(rateLimitName appdef.QName, bucketState irates.BucketState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.defaultStates[rateLimitName]; ok {
+		b.defaultStates[rateLimitName] = bucketState
+	}
+}
+
+// Stores number of taken tokens of the specified state to the bucket.
+//
+// Period and max tokens of stored buckets are always taken from the default state.
+//
+// returns irates.ErrorRateLimitNotFound
+func (b *bucketsType) SetBucketState(bucketKey irates.BucketKey, state irates.BucketState) (err error) {
+	defState, ok := b.defaultState(bucketKey.RateLimitName)
+	if !ok {
+		return irates.ErrorRateLimitNotFound
+	}
+	if unlimited(defState) {
+		return nil
+	}
+	now := b.time.Now()
+	return b.modify(b.key(&bucketKey), func(string) (string, time.Duration, bool) {
+		value, ttl := b.alg.value(defState, now, min(state.TakenTokens, defState.MaxTokensPerPeriod))
+		return value, ttl, true
+	})
+}
+
+// returns irates.ErrorRateLimitNotFound
+func (b *bucketsType) GetBucketState(bucketKey irates.BucketKey) (state irates.BucketState, err error) {
+	state, ok := b.defaultState(bucketKey.RateLimitName)
+	if !ok {
+		return state, irates.ErrorRateLimitNotFound
+	}
+	if unlimited(state) {
+		return state, nil
+	}
+	value, _, err := b.storage.TTLGet(b.key(&bucketKey))
+	if err != nil {
+		return state, err
+	}
+	state.TakenTokens = min(b.alg.taken(value, state, b.time.Now()), state.MaxTokensPerPeriod)
+	return state, nil
+}
+
+// returns irates.ErrorRateLimitNotFound
+func (b *bucketsType) RetryAfter(bucketKey irates.BucketKey, n int) (wait time.Duration, err error) {
+	state, ok := b.defaultState(bucketKey.RateLimitName)
+	if !ok {
+		return 0, irates.ErrorRateLimitNotFound
+	}
+	if unlimited(state) {
+		return 0, nil
+	}
+	value, _, err := b.storage.TTLGet(b.key(&bucketKey))
+	if err != nil {
+		return 0, err
+	}
+	_, _, _, wait = b.alg.take(value, state, b.time.Now(), n)
+	return wait, nil
+}
+
+func (b *bucketsType) defaultState(rateLimitName appdef.QName) (state irates.BucketState, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	state, ok = b.defaultStates[rateLimitName]
+	return state, ok
+}
+
+// takes n tokens from the bucket. Returns false if there are not enough tokens or tokens are not taken because of error
+func (b *bucketsType) take(key *irates.BucketKey, state irates.BucketState, now time.Time, n int) (ok bool, err error) {
+	err = b.modify(b.key(key), func(value string) (string, time.Duration, bool) {
+		newValue, ttl, allowed, _ := b.alg.take(value, state, now, n)
+		ok = allowed
+		return newValue, ttl, allowed
+	})
+	return ok && err == nil, err
+}
+
+// returns n tokens back to the buckets with the specified indexes
+func (b *bucketsType) giveBack(buckets []irates.BucketKey, idx []int, now time.Time, n int) {
+	for _, i := range idx {
+		state, _ := b.defaultState(buckets[i].RateLimitName)
+		if _, err := b.take(&buckets[i], state, now, -n); err != nil {
+			logger.Error(fmt.Sprintf("failed to return tokens to bucket «%s»: %v", b.key(&buckets[i]), err))
+		}
+	}
+}
+
+// Reads the bucket value by key, calculates new value by specified func and stores it.
+//
+// If func returns false then nothing is stored. If func returns empty value then bucket is deleted.
+// Concurrent modifications are detected by compare-and-swap, in this case calculation is repeated.
+//
+// Returns ErrTooManyConflicts if bucket is not modified after maxCASAttempts attempts.
+func (b *bucketsType) modify(key string, f func(value string) (newValue string, ttl time.Duration, write bool)) error {
+	for range maxCASAttempts {
+		value, exists, err := b.storage.TTLGet(key)
+		if err != nil {
+			return err
+		}
+		newValue, ttl, write := f(value)
+		if !write || newValue == value {
+			return nil
+		}
+		var ok bool
+		switch {
+		case newValue == "":
+			ok, err = b.storage.CompareAndDelete(key, value)
+		case exists:
+			ok, err = b.storage.CompareAndSwap(key, value, newValue, ttlSeconds(ttl))
+		default:
+			ok, err = b.storage.InsertIfNotExists(key, newValue, ttlSeconds(ttl))
+		}
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return ErrTooManyConflicts
+}
+
+// returns storage key for the bucket
+func (b *bucketsType) key(k *irates.BucketKey) string {
+	return fmt.Sprintf("%s%v|%d|%d|%s|%v|%d", b.alg.keyPrefix(), k.RateLimitName, k.Partition, k.Workspace, k.RemoteAddr, k.QName, k.ID)
+}
+
+// returns true if state does not limit anything
+func unlimited(state irates.BucketState) bool {
+	return state.Period <= 0 || state.MaxTokensPerPeriod == 0
+}
+
+// returns TTL in seconds clamped to the range accepted by the storage
+func ttlSeconds(ttl time.Duration) int {
+	return int(min(max(math.Ceil(ttl.Seconds()), 1), maxTTLSeconds))
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/irates"
+)
+
+func TestTokenBucket(t *testing.T) {
+	require := require.New(t)
+
+	mockTime := testingu.NewMockTime()
+	storage := newTestStorage(mockTime)
+	limitName := appdef.NewQName("test", "limit")
+	key := irates.BucketKey{RateLimitName: limitName, Workspace: 1}
+	defState := irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 3}
+
+	buckets := Provide(appdef.RateBuckets_Distributed, storage, mockTime)
+	buckets.SetDefaultBucketState(limitName, defState)
+
+	t.Run("should be ok to take tokens", func(t *testing.T) {
+		for range 3 {
+			ok, exc := buckets.TakeTokens([]irates.BucketKey{key}, 1)
+			require.True(ok)
+			require.Equal(appdef.NullQName, exc)
+		}
+		ok, exc := buckets.TakeTokens([]irates.BucketKey{key}, 1)
+		require.False(ok)
+		require.Equal(limitName, exc)
+
+		wait, err := buckets.RetryAfter(key, 1)
+		require.NoError(err)
+		require.Equal(20*time.Second, wait)
+
+		state, err := buckets.GetBucketState(key)
+		require.NoError(err)
+		require.EqualValues(3, state.TakenTokens)
+	})
+
+	t.Run("should be shared by buckets on the same storage", func(t *testing.T) {
+		other := Provide(appdef.RateBuckets_Distributed, storage, mockTime)
+		other.SetDefaultBucketState(limitName, defState)
+		ok, _ := other.TakeTokens([]irates.BucketKey{key}, 1)
+		require.False(ok)
+	})
+
+	t.Run("should be refilled by time", func(t *testing.T) {
+		mockTime.Add(20 * time.Second)
+		ok, _ := buckets.TakeTokens([]irates.BucketKey{key}, 1)
+		require.True(ok)
+		ok, _ = buckets.TakeTokens([]irates.BucketKey{key}, 1)
+		require.False(ok)
+
+		mockTime.Add(time.Minute)
+		state, err := buckets.GetBucketState(key)
+		require.NoError(err)
+		require.Zero(state.TakenTokens)
+	})
+
+	t.Run("should be ok to set bucket state", func(t *testing.T) {
+		require.NoError(buckets.SetBucketState(key, irates.BucketState{TakenTokens: 2}))
+		state, err := buckets.GetBucketState(key)
+		require.NoError(err)
+		require.EqualValues(2, state.TakenTokens)
+
+		require.NoError(buckets.SetBucketState(key, defState))
+		state, err = buckets.GetBucketState(key)
+		require.NoError(err)
+		require.Zero(state.TakenTokens)
+		require.Empty(storage.values, "full bucket should be deleted")
+	})
+
+	t.Run("should return tokens back if some bucket is exceeded", func(t *testing.T) {
+		ipLimitName := appdef.NewQName("test", "ipLimit")
+		buckets.SetDefaultBucketState(ipLimitName, irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 1})
+		ipKey := irates.BucketKey{RateLimitName: ipLimitName, RemoteAddr: "addr"}
+
+		ok, _ := buckets.TakeTokens([]irates.BucketKey{key, ipKey}, 1)
+		require.True(ok)
+		ok, exc := buckets.TakeTokens([]irates.BucketKey{key, ipKey}, 1)
+		require.False(ok)
+		require.Equal(ipLimitName, exc)
+
+		state, err := buckets.GetBucketState(key)
+		require.NoError(err)
+		require.EqualValues(1, state.TakenTokens)
+	})
+
+	t.Run("should return error for unknown limit", func(t *testing.T) {
+		unknown := irates.BucketKey{RateLimitName: appdef.NewQName("test", "unknown")}
+		_, err := buckets.GetBucketState(unknown)
+		require.ErrorIs(err, irates.ErrorRateLimitNotFound)
+		require.ErrorIs(buckets.SetBucketState(unknown, defState), irates.ErrorRateLimitNotFound)
+		_, err = buckets.RetryAfter(unknown, 1)
+		require.ErrorIs(err, irates.ErrorRateLimitNotFound)
+		_, err = buckets.GetDefaultBucketsState(unknown.RateLimitName)
+		require.ErrorIs(err, irates.ErrorRateLimitNotFound)
+
+		ok, _ := buckets.TakeTokens([]irates.BucketKey{unknown}, 1)
+		require.True(ok)
+	})
+}
+
+func TestSlidingWindow(t *testing.T) {
+	require := require.New(t)
+
+	mockTime := testingu.NewMockTime()
+	mockTime.Add(mockTime.Now().Truncate(time.Minute).Add(time.Minute).Sub(mockTime.Now())) // window start
+	storage := newTestStorage(mockTime)
+	limitName := appdef.NewQName("test", "limit")
+	key := irates.BucketKey{RateLimitName: limitName, RemoteAddr: "addr"}
+
+	buckets := Provide(appdef.RateBuckets_SlidingWindow, storage, mockTime)
+	buckets.SetDefaultBucketState(limitName, irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 4})
+
+	ok, _ := buckets.TakeTokens([]irates.BucketKey{key}, 4)
+	require.True(ok)
+	ok, _ = buckets.TakeTokens([]irates.BucketKey{key}, 1)
+	require.False(ok)
+
+	wait, err := buckets.RetryAfter(key, 1)
+	require.NoError(err)
+	require.Equal(time.Minute+15*time.Second, wait, "previous window weight should decrease to 3/4")
+
+	mockTime.Add(time.Minute + 15*time.Second)
+	state, err := buckets.GetBucketState(key)
+	require.NoError(err)
+	require.EqualValues(3, state.TakenTokens)
+
+	ok, _ = buckets.TakeTokens([]irates.BucketKey{key}, 1)
+	require.True(ok)
+	ok, _ = buckets.TakeTokens([]irates.BucketKey{key}, 1)
+	require.False(ok)
+
+	mockTime.Add(2 * time.Minute)
+	state, err = buckets.GetBucketState(key)
+	require.NoError(err)
+	require.Zero(state.TakenTokens)
+}
+
+func TestStorageErrors(t *testing.T) {
+	require := require.New(t)
+
+	mockTime := testingu.NewMockTime()
+	storage := newTestStorage(mockTime)
+	limitName := appdef.NewQName("test", "limit")
+	key := irates.BucketKey{RateLimitName: limitName}
+
+	buckets := Provide(appdef.RateBuckets_Distributed, storage, mockTime)
+	buckets.SetDefaultBucketState(limitName, irates.BucketState{Period: time.Minute, MaxTokensPerPeriod: 1})
+
+	t.Run("should deny if storage fails", func(t *testing.T) {
+		storage.err = errors.New("test error")
+		defer func() { storage.err = nil }()
+
+		ok, exc := buckets.TakeTokens([]irates.BucketKey{key}, 1)
+		require.False(ok)
+		require.Equal(limitName, exc)
+		_, err := buckets.GetBucketState(key)
+		require.ErrorIs(err, storage.err)
+	})
+
+	t.Run("should deny if bucket is modified concurrently", func(t *testing.T) {
+		storage.conflict = true
+		defer func() { storage.conflict = false }()
+
+		ok, exc := buckets.TakeTokens([]irates.BucketKey{key}, 1)
+		require.False(ok)
+		require.Equal(limitName, exc)
+	})
+}
+
+func TestProvidePanics(t *testing.T) {
+	require.Panics(t, func() { Provide(appdef.RateBuckets_Local, nil, testingu.NewMockTime()) })
+}
+
+// simple in-memory application TTL storage
+type testStorage struct {
+	mu       sync.Mutex
+	time     testingu.IMockTime
+	values   map[string]testValue
+	err      error
+	conflict bool
+}
+
+type testValue struct {
+	value   string
+	expires time.Time
+}
+
+func newTestStorage(time testingu.IMockTime) *testStorage {
+	return &testStorage{time: time, values: map[string]testValue{}}
+}
+
+func (s *testStorage) get(key string) (string, bool) {
+	v, ok := s.values[key]
+	if !ok || !s.time.Now().Before(v.expires) {
+		delete(s.values, key)
+		return "", false
+	}
+	return v.value, true
+}
+
+func (s *testStorage) put(key, value string, ttlSeconds int) {
+	s.values[key] = testValue{value, s.time.Now().Add(time.Duration(ttlSeconds) * time.Second)}
+}
+
+func (s *testStorage) TTLGet(key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return "", false, s.err
+	}
+	v, ok := s.get(key)
+	return v, ok, nil
+}
+
+func (s *testStorage) InsertIfNotExists(key, value string, ttlSeconds int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.get(key); ok || s.conflict {
+		return false, nil
+	}
+	s.put(key, value, ttlSeconds)
+	return true, nil
+}
+
+func (s *testStorage) CompareAndSwap(key, expectedValue, newValue string, ttlSeconds int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.get(key); !ok || v != expectedValue || s.conflict {
+		return false, nil
+	}
+	s.put(key, newValue, ttlSeconds)
+	return true, nil
+}
+
+func (s *testStorage) CompareAndDelete(key, expectedValue string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.get(key); !ok || v != expectedValue || s.conflict {
+		return false, nil
+	}
+	delete(s.values, key)
+	return true, nil
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Returns buckets kept in the specified application TTL storage.
+//
+// Buckets are shared by all VVMs which use the same storage and survive VVM restarts.
+//
+// # Panics:
+//   - if kind is not RateBuckets_Distributed or RateBuckets_SlidingWindow
+func Provide(kind appdef.RateBuckets, storage istructs.IAppTTLStorage, time timeu.ITime) irates.IBuckets {
+	var alg algorithm
+	switch kind {
+	case appdef.RateBuckets_Distributed:
+		alg = tokenBucket{}
+	case appdef.RateBuckets_SlidingWindow:
+		alg = slidingWindow{}
+	default:
+		panic(fmt.Errorf("unsupported rate buckets %v", kind))
+	}
+	return &bucketsType{
+		alg:           alg,
+		storage:       storage,
+		defaultStates: map[appdef.QName]irates.BucketState{},
+		time:          time,
+	}
+}
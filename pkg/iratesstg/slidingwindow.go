@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/voedger/voedger/pkg/irates"
+)
+
+// Sliding window counter.
+//
+// Time is split into fixed windows of the rate period. Bucket value keeps the start of the current window,
+// the number of tokens taken in the previous window and in the current one, formatted as "start|prev|curr".
+// Number of taken tokens is estimated as prev * (1 - elapsed / period) + curr,
+// where elapsed is the time passed from the current window start.
+type slidingWindow struct{}
+
+type window struct {
+	start      time.Time
+	prev, curr int64
+}
+
+func (slidingWindow) keyPrefix() string { return keyPrefix_SlidingWindow }
+
+func (sw slidingWindow) take(value string, state irates.BucketState, now time.Time, n int) (string, time.Duration, bool, time.Duration) {
+	w := sw.window(value, state, now)
+	if n > 0 {
+		if w.estimate(state, now)+float64(n) > float64(state.MaxTokensPerPeriod) {
+			return value, 0, false, w.wait(state, now, n)
+		}
+	}
+	w.curr = max(w.curr+int64(n), 0)
+	newValue, ttl := w.value(state, now)
+	return newValue, ttl, true, 0
+}
+
+func (sw slidingWindow) taken(value string, state irates.BucketState, now time.Time) irates.NumTokensType {
+	return irates.NumTokensType(math.Ceil(sw.window(value, state, now).estimate(state, now)))
+}
+
+func (slidingWindow) value(state irates.BucketState, now time.Time, taken irates.NumTokensType) (string, time.Duration) {
+	w := window{start: now.Truncate(state.Period), curr: int64(taken)}
+	return w.value(state, now)
+}
+
+// returns window from the bucket value shifted to contain the specified time.
+// Malformed value is treated as empty
+func (slidingWindow) window(value string, state irates.BucketState, now time.Time) window {
+	w := window{}
+	var start int64
+	if _, err := fmt.Sscanf(value, "%d|%d|%d", &start, &w.prev, &w.curr); err == nil {
+		w.start = time.Unix(0, start)
+	}
+	switch {
+	case w.start.IsZero() || !now.Before(w.start.Add(2*state.Period)):
+		w = window{start: now.Truncate(state.Period)}
+	case !now.Before(w.start.Add(state.Period)):
+		w = window{start: w.start.Add(state.Period), prev: w.curr}
+	}
+	return w
+}
+
+func (w window) estimate(state irates.BucketState, now time.Time) float64 {
+	elapsed := float64(now.Sub(w.start)) / float64(state.Period)
+	return float64(w.prev)*(1-elapsed) + float64(w.curr)
+}
+
+// returns time to wait until n tokens can be taken
+func (w window) wait(state irates.BucketState, now time.Time, n int) time.Duration {
+	period := float64(state.Period)
+	elapsed := float64(now.Sub(w.start))
+
+	// in the current window the weight of the previous window decreases
+	if free := float64(state.MaxTokensPerPeriod) - float64(w.curr) - float64(n); free >= 0 && w.prev > 0 {
+		return time.Duration(period*(1-free/float64(w.prev)) - elapsed)
+	}
+
+	// in the next window the current window becomes the previous one
+	if free := float64(state.MaxTokensPerPeriod) - float64(n); free >= 0 {
+		if w.curr == 0 {
+			return time.Duration(period - elapsed)
+		}
+		return time.Duration(period - elapsed + period*(1-free/float64(w.curr)))
+	}
+
+	return time.Duration(2*period - elapsed)
+}
+
+// returns bucket value and the time after which the window is empty
+func (w window) value(state irates.BucketState, now time.Time) (string, time.Duration) {
+	if w.prev == 0 && w.curr == 0 {
+		return "", 0
+	}
+	return fmt.Sprintf("%d|%d|%d", w.start.UnixNano(), w.prev, w.curr), w.start.Add(2 * state.Period).Sub(now)
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/voedger/voedger/pkg/irates"
+)
+
+// Token bucket implemented as generic cell rate algorithm (GCRA).
+//
+// Bucket value is the theoretical arrival time (TAT) in Unix nanoseconds:
+// the time when the bucket becomes full again.
+// Each token takes emission interval (period / max tokens) to refill.
+type tokenBucket struct{}
+
+func (tokenBucket) keyPrefix() string { return keyPrefix_TokenBucket }
+
+func (b tokenBucket) take(value string, state irates.BucketState, now time.Time, n int) (string, time.Duration, bool, time.Duration) {
+	tat := b.tat(value, now).Add(time.Duration(n) * emission(state))
+	if n > 0 {
+		if excess := tat.Sub(now) - state.Period; excess > 0 {
+			return value, 0, false, excess
+		}
+	}
+	if !tat.After(now) {
+		return "", 0, true, 0
+	}
+	return strconv.FormatInt(tat.UnixNano(), 10), tat.Sub(now), true, 0
+}
+
+func (b tokenBucket) taken(value string, state irates.BucketState, now time.Time) irates.NumTokensType {
+	return irates.NumTokensType(math.Ceil(float64(b.tat(value, now).Sub(now)) / float64(emission(state))))
+}
+
+func (tokenBucket) value(state irates.BucketState, now time.Time, taken irates.NumTokensType) (string, time.Duration) {
+	if taken == 0 {
+		return "", 0
+	}
+	tat := now.Add(time.Duration(taken) * emission(state))
+	return strconv.FormatInt(tat.UnixNano(), 10), tat.Sub(now)
+}
+
+// returns TAT from the bucket value. Returns now if value is empty, malformed or TAT is in the past
+func (tokenBucket) tat(value string, now time.Time) time.Time {
+	if ns, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if tat := time.Unix(0, ns); tat.After(now) {
+			return tat
+		}
+	}
+	return now
+}
+
+// returns time to refill one token
+func emission(state irates.BucketState) time.Duration {
+	return state.Period / time.Duration(state.MaxTokensPerPeriod)
+}
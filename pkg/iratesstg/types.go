@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package iratesstg
+
+import (
+	"sync"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/irates"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// Buckets kept in the application TTL storage.
+//
+// Default bucket states are kept in memory, each VVM sets them on application deployment.
+type bucketsType struct {
+	mu            sync.RWMutex
+	alg           algorithm
+	storage       istructs.IAppTTLStorage
+	defaultStates map[appdef.QName]irates.BucketState
+	time          timeu.ITime
+}
+
+// Rate limiting algorithm. Bucket is stored as string value.
+//
+// Empty value means the bucket is full (no tokens are taken).
+type algorithm interface {
+	// Prefix of the storage keys
+	keyPrefix() string
+
+	// Takes n tokens from the bucket.
+	//
+	// Negative n returns tokens back to the bucket, this is always allowed.
+	// If tokens can not be taken then ok is false and wait is the time to wait until it is possible.
+	// Else returns new value and the time after which the bucket becomes full.
+	take(value string, state irates.BucketState, now time.Time, n int) (newValue string, ttl time.Duration, ok bool, wait time.Duration)
+
+	// Returns number of tokens taken from the bucket
+	taken(value string, state irates.BucketState, now time.Time) irates.NumTokensType
+
+	// Returns value of the bucket from which the specified number of tokens is taken
+	// and the time after which the bucket becomes full.
+	value(state irates.BucketState, now time.Time, taken irates.NumTokensType) (value string, ttl time.Duration)
+}
@@ -201,7 +201,7 @@ func (s *implIAppStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bo
 }
 
 func (s *implIAppStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
-	return s.read(ctx, pKey, startCCols, finishCCols, cb, true)
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, true, false)
 }
 
 func (s *implIAppStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
@@ -298,7 +298,43 @@ func (s *implIAppStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) e
 }
 
 func (s *implIAppStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
-	return s.read(ctx, pKey, startCCols, finishCCols, cb, false)
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, false, false)
+}
+
+func (s *implIAppStorage) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, false, true)
+}
+
+// Scan returns all items of a partition one after another, so only consecutive duplicates are skipped
+func (s *implIAppStorage) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	paginator := dynamodb.NewScanPaginator(s.client, &dynamodb.ScanInput{
+		TableName:            aws.String(s.keySpace),
+		ProjectionExpression: aws.String(partitionKeyAttributeName),
+	})
+	var prevPKey []byte
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil // TCK contract
+			}
+			return err
+		}
+		for _, item := range page.Items {
+			if ctx.Err() != nil {
+				return nil // TCK contract
+			}
+			pKey := item[partitionKeyAttributeName].(*types.AttributeValueMemberB).Value
+			if prevPKey != nil && bytes.Equal(prevPKey, pKey) {
+				continue
+			}
+			prevPKey = pKey
+			if err := cb(pKey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func (s *implIAppStorage) get(pKey []byte, cCols []byte, data *[]byte, checkTTL bool) (ok bool, err error) {
@@ -375,7 +411,7 @@ func (s *implIAppStorage) put(pKey []byte, cCols []byte, value []byte, ttlSecond
 	return err
 }
 
-func (s *implIAppStorage) read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback, checkTTL bool) (err error) {
+func (s *implIAppStorage) read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback, checkTTL bool, reverse bool) (err error) {
 	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
 		return nil // absurd range
 	}
@@ -425,6 +461,7 @@ func (s *implIAppStorage) read(ctx context.Context, pKey []byte, startCCols, fin
 		ProjectionExpression:     aws.String(sortKeyAttributeName + ", #v"),
 		ExpressionAttributeNames: map[string]string{"#v": valueAttributeName},
 		KeyConditions:            keyConditions,
+		ScanIndexForward:         aws.Bool(!reverse),
 	}
 
 	if checkTTL {
@@ -268,7 +268,7 @@ func (s *appStorageType) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok boo
 
 //nolint:revive
 func (s *appStorageType) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
-	return s.read(ctx, pKey, startCCols, finishCCols, cb, true)
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, true, false)
 }
 
 //nolint:revive
@@ -348,7 +348,7 @@ func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
 			}
 
 			d := istorage.DataWithExpiration{Data: items[i].Value}
-			if err := bucket.Put(items[i].CCols, d.ToBytes()); err != nil {
+			if err := bucket.Put(safeKey(items[i].CCols), d.ToBytes()); err != nil {
 				return err
 			}
 		}
@@ -388,7 +388,37 @@ func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool,
 
 // istorage.IAppStorage.Read(ctx context.Context, pKey []byte, startCCols []byte, finishCCols []byte, cb ReadCallback) (err error)
 func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
-	return s.read(ctx, pKey, startCCols, finishCCols, cb, false)
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, false, false)
+}
+
+// istorage.IAppStorage.ReadReverse(ctx context.Context, pKey []byte, startCCols []byte, finishCCols []byte, cb ReadCallback) (err error)
+func (s *appStorageType) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, false, true)
+}
+
+// istorage.IAppStorage.ReadPartitionKeys(ctx context.Context, cb PartitionKeyCallback) (err error)
+func (s *appStorageType) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	return s.db.View(func(tx *bolt.Tx) error {
+		dataBucket := tx.Bucket([]byte(dataBucketName))
+		if dataBucket == nil {
+			return ErrDataBucketNotFound
+		}
+
+		cr := dataBucket.Cursor()
+		for k, v := cr.First(); k != nil; k, v = cr.Next() {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if v != nil {
+				continue // partitions are nested buckets
+			}
+			if err := cb(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 // istorage.IAppStorage.GetBatch(pKey []byte, items []GetBatchItem) (err error)
@@ -423,7 +453,7 @@ func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (e
 	})
 }
 
-func (s *appStorageType) read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback, checkTTL bool) (err error) {
+func (s *appStorageType) read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback, checkTTL bool, reverse bool) (err error) {
 	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
 		return nil // absurd range
 	}
@@ -448,14 +478,27 @@ func (s *appStorageType) read(ctx context.Context, pKey []byte, startCCols, fini
 		}
 
 		cr := bucket.Cursor()
-		if startCCols == nil {
+		next := cr.Next
+		inRange := func(k []byte) bool { return finishCCols == nil || string(k) <= string(finishCCols) }
+		switch {
+		case reverse:
+			next = cr.Prev
+			inRange = func(k []byte) bool { return startCCols == nil || string(k) >= string(startCCols) }
+			if finishCCols == nil {
+				k, v = cr.Last()
+			} else if k, v = cr.Seek(finishCCols); k == nil {
+				k, v = cr.Last()
+			} else if string(k) > string(finishCCols) {
+				k, v = cr.Prev()
+			}
+		case startCCols == nil:
 			k, v = cr.First()
-		} else {
+		default:
 			k, v = cr.Seek(safeKey(startCCols))
 		}
 
 		var d istorage.DataWithExpiration
-		for (k != nil) && (finishCCols == nil || string(k) <= string(finishCCols)) {
+		for (k != nil) && inRange(k) {
 
 			if ctx.Err() != nil {
 				return nil
@@ -463,7 +506,7 @@ func (s *appStorageType) read(ctx context.Context, pKey []byte, startCCols, fini
 
 			d = d.Update(v)
 			if checkTTL && d.IsExpired(s.iTime.Now()) {
-				k, v = cr.Next()
+				k, v = next()
 				continue
 			}
 
@@ -476,7 +519,7 @@ func (s *appStorageType) read(ctx context.Context, pKey []byte, startCCols, fini
 					return err
 				}
 			}
-			k, v = cr.Next()
+			k, v = next()
 		}
 
 		return nil
@@ -279,6 +279,14 @@ func scanViewQuery(ctx context.Context, q *gocql.Query, cb istorage.ReadCallback
 }
 
 func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, "")
+}
+
+func (s *appStorageType) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, " order by c_col desc")
+}
+
+func (s *appStorageType) read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback, orderBy string) (err error) {
 	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
 		return nil // absurd range
 	}
@@ -289,22 +297,40 @@ func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, fini
 	if len(startCCols) == 0 {
 		if len(finishCCols) == 0 {
 			// opened range
-			q = s.session.Query(qText, pKey)
+			q = s.session.Query(qText+orderBy, pKey)
 		} else {
 			// left-opened range
-			q = s.session.Query(qText+" and c_col<?", pKey, finishCCols)
+			q = s.session.Query(qText+" and c_col<?"+orderBy, pKey, finishCCols)
 		}
 	} else if len(finishCCols) == 0 {
 		// right-opened range
-		q = s.session.Query(qText+" and c_col>=?", pKey, startCCols)
+		q = s.session.Query(qText+" and c_col>=?"+orderBy, pKey, startCCols)
 	} else {
 		// closed range
-		q = s.session.Query(qText+" and c_col>=? and c_col<?", pKey, startCCols, finishCCols)
+		q = s.session.Query(qText+" and c_col>=? and c_col<?"+orderBy, pKey, startCCols, finishCCols)
 	}
 
 	return scanViewQuery(ctx, q, cb)
 }
 
+func (s *appStorageType) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	q := s.session.Query(fmt.Sprintf("select distinct p_key from %s.values", s.keyspace)).Consistency(gocql.Quorum)
+	scanner := q.Iter().Scanner()
+	for scanner.Next() {
+		pKey := make([]byte, 0)
+		if err = scanner.Scan(&pKey); err != nil {
+			return scannerCloser(scanner, err)
+		}
+		if err = cb(pKey); err != nil {
+			return scannerCloser(scanner, err)
+		}
+		if ctx.Err() != nil {
+			return scannerCloser(scanner, nil)
+		}
+	}
+	return scannerCloser(scanner, err)
+}
+
 func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
 	*data = (*data)[0:0]
 	q := fmt.Sprintf("select value from %s.values where p_key=? and c_col=?", s.keyspace)
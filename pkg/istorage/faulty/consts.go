@@ -0,0 +1,21 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package faulty
+
+const (
+	Operation_Get Operation = iota
+	Operation_GetBatch
+	Operation_Put
+	Operation_PutBatch
+	Operation_Read
+	Operation_ReadReverse
+	Operation_ReadPartitionKeys
+	Operation_InsertIfNotExists
+	Operation_CompareAndSwap
+	Operation_CompareAndDelete
+	Operation_TTLGet
+	Operation_TTLRead
+	Operation_QueryTTL
+)
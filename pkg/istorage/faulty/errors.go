@@ -0,0 +1,18 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package faulty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInjected = errors.New("injected storage fault")
+
+	// wraps context.DeadlineExceeded, use with Fault.Delay to emulate the storage timeout
+	ErrTimeout = fmt.Errorf("injected storage timeout: %w", context.DeadlineExceeded)
+)
@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package faulty
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func (f *implIFaults) Inject(fault Fault) (remove func()) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	ft := &injectedFault{Fault: fault}
+	f.faults = append(f.faults, ft)
+	return func() {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.remove(ft)
+	}
+}
+
+func (f *implIFaults) Reset() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.faults = nil
+}
+
+func (f *implIFaults) Injected() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.injected
+}
+
+// must be called under lock
+func (f *implIFaults) remove(ft *injectedFault) {
+	f.faults = slices.DeleteFunc(f.faults, func(existing *injectedFault) bool { return existing == ft })
+}
+
+// returns the fault to apply to the call or nil if the call is not faulty
+// matchPKey checks if the call matches the partition key prefix
+func (f *implIFaults) faultFor(keyspace string, op Operation, matchPKey func(prefix []byte) bool) *Fault {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, ft := range f.faults {
+		if len(ft.Keyspaces) > 0 && !slices.Contains(ft.Keyspaces, keyspace) {
+			continue
+		}
+		if len(ft.Operations) > 0 && !slices.Contains(ft.Operations, op) {
+			continue
+		}
+		if len(ft.PKeyPrefix) > 0 && !matchPKey(ft.PKeyPrefix) {
+			continue
+		}
+		ft.matched++
+		if ft.matched <= ft.Skip {
+			continue
+		}
+		if ft.Every > 1 && (ft.matched-ft.Skip)%ft.Every != 0 {
+			continue
+		}
+		if ft.Probability > 0 && f.random.Float64() >= ft.Probability {
+			continue
+		}
+		ft.injected++
+		f.injected++
+		if ft.Times > 0 && ft.injected >= ft.Times {
+			f.remove(ft)
+		}
+		res := ft.Fault
+		return &res
+	}
+	return nil
+}
+
+func (f *faultyAppStorageFactory) AppStorage(appName istorage.SafeAppName) (istorage.IAppStorage, error) {
+	storage, err := f.IAppStorageFactory.AppStorage(appName)
+	if err != nil {
+		return nil, err
+	}
+	return &faultyAppStorage{storage: storage, keyspace: appName.String(), faults: f.faults, stopCtx: f.stopCtx}, nil
+}
+
+// interrupts delays of calls without context
+func (f *faultyAppStorageFactory) StopGoroutines() {
+	f.stop()
+	f.IAppStorageFactory.StopGoroutines()
+}
+
+// applies the fault delay, returns the fault error
+func (s *faultyAppStorage) apply(ctx context.Context, ft *Fault) error {
+	if ft.Delay > 0 {
+		if err := delay(ctx, ft.Delay); err != nil {
+			return err
+		}
+	}
+	return ft.Err
+}
+
+// returns ctx error if ctx is done earlier
+func delay(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (s *faultyAppStorage) inject(ctx context.Context, op Operation, pKey []byte) error {
+	ft := s.faults.faultFor(s.keyspace, op, func(prefix []byte) bool { return bytes.HasPrefix(pKey, prefix) })
+	if ft == nil {
+		return nil
+	}
+	return s.apply(ctx, ft)
+}
+
+func (s *faultyAppStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	if err := s.inject(s.stopCtx, Operation_Put, pKey); err != nil {
+		return err
+	}
+	return s.storage.Put(pKey, cCols, value)
+}
+
+func (s *faultyAppStorage) PutBatch(items []istorage.BatchItem) (err error) {
+	ft := s.faults.faultFor(s.keyspace, Operation_PutBatch, func(prefix []byte) bool {
+		return slices.ContainsFunc(items, func(item istorage.BatchItem) bool { return bytes.HasPrefix(item.PKey, prefix) })
+	})
+	if ft != nil {
+		if ft.Delay > 0 {
+			if err := delay(s.stopCtx, ft.Delay); err != nil {
+				return err
+			}
+		}
+		if ft.PartialBatch > 0 {
+			if err := s.storage.PutBatch(items[:min(ft.PartialBatch, len(items))]); err != nil {
+				return err
+			}
+		}
+		if ft.Err != nil {
+			return ft.Err
+		}
+	}
+	return s.storage.PutBatch(items)
+}
+
+func (s *faultyAppStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	if err := s.inject(s.stopCtx, Operation_Get, pKey); err != nil {
+		return false, err
+	}
+	return s.storage.Get(pKey, cCols, data)
+}
+
+func (s *faultyAppStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	if err := s.inject(s.stopCtx, Operation_GetBatch, pKey); err != nil {
+		return err
+	}
+	return s.storage.GetBatch(pKey, items)
+}
+
+func (s *faultyAppStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	if err := s.inject(ctx, Operation_Read, pKey); err != nil {
+		return err
+	}
+	return s.storage.Read(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+func (s *faultyAppStorage) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	if err := s.inject(ctx, Operation_ReadReverse, pKey); err != nil {
+		return err
+	}
+	return s.storage.ReadReverse(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+func (s *faultyAppStorage) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	if ft := s.faults.faultFor(s.keyspace, Operation_ReadPartitionKeys, func([]byte) bool { return true }); ft != nil {
+		if err := s.apply(ctx, ft); err != nil {
+			return err
+		}
+	}
+	return s.storage.ReadPartitionKeys(ctx, cb)
+}
+
+func (s *faultyAppStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	if err := s.inject(s.stopCtx, Operation_InsertIfNotExists, pKey); err != nil {
+		return false, err
+	}
+	return s.storage.InsertIfNotExists(pKey, cCols, value, ttlSeconds)
+}
+
+func (s *faultyAppStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	if err := s.inject(s.stopCtx, Operation_CompareAndSwap, pKey); err != nil {
+		return false, err
+	}
+	return s.storage.CompareAndSwap(pKey, cCols, oldValue, newValue, ttlSeconds)
+}
+
+func (s *faultyAppStorage) CompareAndDelete(pKey []byte, cCols []byte, expectedValue []byte) (ok bool, err error) {
+	if err := s.inject(s.stopCtx, Operation_CompareAndDelete, pKey); err != nil {
+		return false, err
+	}
+	return s.storage.CompareAndDelete(pKey, cCols, expectedValue)
+}
+
+func (s *faultyAppStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	if err := s.inject(s.stopCtx, Operation_TTLGet, pKey); err != nil {
+		return false, err
+	}
+	return s.storage.TTLGet(pKey, cCols, data)
+}
+
+func (s *faultyAppStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	if err := s.inject(ctx, Operation_TTLRead, pKey); err != nil {
+		return err
+	}
+	return s.storage.TTLRead(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+func (s *faultyAppStorage) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok bool, err error) {
+	if err := s.inject(s.stopCtx, Operation_QueryTTL, pKey); err != nil {
+		return 0, false, err
+	}
+	return s.storage.QueryTTL(pKey, cCols)
+}
+
+// keeps vit.SetMemStorageGetDelay working
+func (s *faultyAppStorage) SetTestDelayGet(delay time.Duration) {
+	s.storage.(istorage.IStorageDelaySetter).SetTestDelayGet(delay)
+}
+
+func (s *faultyAppStorage) SetTestDelayPut(delay time.Duration) {
+	s.storage.(istorage.IStorageDelaySetter).SetTestDelayPut(delay)
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package faulty
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+)
+
+func TestTechnologyCompatibilityKit(t *testing.T) {
+	asf, faults := Provide(mem.Provide(testingu.MockTime), 0)
+	faults.Inject(Fault{Keyspaces: []string{"other"}, Err: ErrInjected})
+	istorage.TechnologyCompatibilityKit(t, asf)
+}
+
+func newTestStorage(t *testing.T, seed int64) (istorage.IAppStorage, IFaults, istorage.IAppStorageFactory) {
+	asf, faults := Provide(mem.Provide(testingu.MockTime), seed)
+	san, err := istorage.NewSafeAppName(appdef.NewAppQName("test", "app"), func(string) (bool, error) { return true, nil })
+	require.NoError(t, err)
+	require.NoError(t, asf.Init(san))
+	storage, err := asf.AppStorage(san)
+	require.NoError(t, err)
+	return storage, faults, asf
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	storage, faults, _ := newTestStorage(t, 0)
+
+	remove := faults.Inject(Fault{
+		Keyspaces:  []string{"testapp"},
+		Operations: []Operation{Operation_Put},
+		PKeyPrefix: []byte("faulty"),
+		Err:        ErrInjected,
+	})
+
+	require.ErrorIs(storage.Put([]byte("faulty-1"), []byte{1}, []byte{1}), ErrInjected)
+	ok, err := storage.Get([]byte("faulty-1"), []byte{1}, new([]byte))
+	require.NoError(err)
+	require.False(ok, "faulty call should not be passed to the storage")
+
+	require.NoError(storage.Put([]byte("ok"), []byte{1}, []byte{1}), "other partition key")
+	ok, err = storage.InsertIfNotExists([]byte("faulty-1"), []byte{1}, []byte{1}, 0)
+	require.NoError(err, "other operation")
+	require.True(ok)
+
+	remove()
+	require.NoError(storage.Put([]byte("faulty-1"), []byte{1}, []byte{1}))
+}
+
+func TestSchedule(t *testing.T) {
+	require := require.New(t)
+	storage, faults, _ := newTestStorage(t, 0)
+
+	faulty := func(count int) (res []int) {
+		for i := range count {
+			if err := storage.Put([]byte{1}, []byte{1}, []byte{1}); err != nil {
+				require.ErrorIs(err, ErrInjected)
+				res = append(res, i)
+			}
+		}
+		return res
+	}
+
+	t.Run("skip, every and times", func(t *testing.T) {
+		faults.Inject(Fault{Skip: 2, Every: 3, Times: 2, Err: ErrInjected})
+		require.Equal([]int{4, 7}, faulty(20))
+		require.Equal(2, faults.Injected())
+	})
+
+	t.Run("probability should be reproducible by seed", func(t *testing.T) {
+		defer faults.Reset()
+		faults.Inject(Fault{Probability: 0.5, Err: ErrInjected})
+		first := faulty(100)
+		require.Greater(len(first), 20)
+		require.Less(len(first), 80)
+
+		seeded, seededFaults, _ := newTestStorage(t, 0)
+		storage = seeded
+		seededFaults.Inject(Fault{Probability: 0.5, Err: ErrInjected})
+		require.Equal(first, faulty(100))
+	})
+}
+
+func TestPartialBatch(t *testing.T) {
+	require := require.New(t)
+	storage, faults, _ := newTestStorage(t, 0)
+
+	faults.Inject(Fault{Operations: []Operation{Operation_PutBatch}, PartialBatch: 2, Err: ErrInjected, Times: 1})
+	items := []istorage.BatchItem{
+		{PKey: []byte{1}, CCols: []byte{1}, Value: []byte{1}},
+		{PKey: []byte{1}, CCols: []byte{2}, Value: []byte{2}},
+		{PKey: []byte{1}, CCols: []byte{3}, Value: []byte{3}},
+	}
+	require.ErrorIs(storage.PutBatch(items), ErrInjected)
+
+	read := func() (cCols []byte) {
+		require.NoError(storage.Read(context.Background(), []byte{1}, nil, nil, func(c []byte, _ []byte) error {
+			cCols = append(cCols, c...)
+			return nil
+		}))
+		return cCols
+	}
+	require.Equal([]byte{1, 2}, read())
+
+	require.NoError(storage.PutBatch(items))
+	require.Equal([]byte{1, 2, 3}, read())
+}
+
+func TestTimeout(t *testing.T) {
+	require := require.New(t)
+	storage, faults, _ := newTestStorage(t, 0)
+
+	t.Run("delay then error", func(t *testing.T) {
+		defer faults.Reset()
+		faults.Inject(Fault{Operations: []Operation{Operation_Get}, Delay: 10 * time.Millisecond, Err: ErrTimeout})
+		start := time.Now()
+		_, err := storage.Get([]byte{1}, []byte{1}, new([]byte))
+		require.ErrorIs(err, context.DeadlineExceeded)
+		require.GreaterOrEqual(time.Since(start), 10*time.Millisecond)
+	})
+
+	t.Run("delay is interrupted by the context", func(t *testing.T) {
+		defer faults.Reset()
+		faults.Inject(Fault{Operations: []Operation{Operation_Read}, Delay: time.Hour})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := storage.Read(ctx, []byte{1}, nil, nil, func([]byte, []byte) error { return nil })
+		require.ErrorIs(err, context.DeadlineExceeded)
+	})
+
+	t.Run("delay of calls without context is interrupted on stop", func(t *testing.T) {
+		storage, faults, asf := newTestStorage(t, 0)
+		faults.Inject(Fault{Operations: []Operation{Operation_PutBatch}, Delay: time.Hour})
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			asf.StopGoroutines()
+		}()
+		err := storage.PutBatch([]istorage.BatchItem{{PKey: []byte{1}, CCols: []byte{1}, Value: []byte{1}}})
+		require.ErrorIs(err, context.Canceled)
+	})
+}
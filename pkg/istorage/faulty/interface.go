@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package faulty
+
+// Injects faults into storages provided by the factory returned by Provide
+// @ConcurrentAccess
+type IFaults interface {
+	// faults are checked in the order they are injected, the first faulty one is applied to the call
+	// returns the function which removes the fault
+	Inject(fault Fault) (remove func())
+
+	// removes all faults
+	Reset()
+
+	// returns the number of faulty calls since Provide
+	Injected() int
+}
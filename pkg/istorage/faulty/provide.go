@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package faulty
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// Provides the factory of storages which inject faults into calls to storages provided by asf
+// seed is used for probabilistic faults, see Fault.Probability
+func Provide(asf istorage.IAppStorageFactory, seed int64) (istorage.IAppStorageFactory, IFaults) {
+	faults := &implIFaults{
+		random: rand.New(rand.NewSource(seed)), // nolint G404: reproducible faults are needed, not secure ones
+	}
+	stopCtx, stop := context.WithCancel(context.Background())
+	return &faultyAppStorageFactory{IAppStorageFactory: asf, faults: faults, stopCtx: stopCtx, stop: stop}, faults
+}
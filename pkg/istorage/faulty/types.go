@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package faulty
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+type Operation uint8
+
+// Fault is applied to calls matched by Keyspaces, Operations and PKeyPrefix.
+// Skip, Every, Times and Probability define which of the matched calls are faulty.
+type Fault struct {
+	// istorage.SafeAppName strings. Any keyspace if empty
+	Keyspaces []string
+
+	// any operation if empty
+	Operations []Operation
+
+	// any partition key if empty. PutBatch is matched if any of its items is matched. ReadPartitionKeys is always matched
+	PKeyPrefix []byte
+
+	// first Skip matched calls are not faulty
+	Skip int
+
+	// each Every-th matched call after the skipped ones is faulty. Each one if zero
+	Every int
+
+	// the fault is removed after Times faulty calls. Never removed if zero
+	Times int
+
+	// the call is faulty with this probability, random is seeded by Provide. Always faulty if zero
+	Probability float64
+
+	// the faulty call is delayed. Calls with context are returned earlier if the context is done,
+	// the rest of calls are returned earlier if the factory goroutines are stopped
+	Delay time.Duration
+
+	// returned by the faulty call, the storage is not called. The storage is called after Delay if nil
+	Err error
+
+	// PutBatch only: the first PartialBatch items are written before Err is returned
+	PartialBatch int
+}
+
+type injectedFault struct {
+	Fault
+	matched  int
+	injected int
+}
+
+type implIFaults struct {
+	lock     sync.Mutex
+	faults   []*injectedFault
+	random   *rand.Rand
+	injected int
+}
+
+type faultyAppStorageFactory struct {
+	istorage.IAppStorageFactory
+	faults  *implIFaults
+	stopCtx context.Context
+	stop    context.CancelFunc
+}
+
+type faultyAppStorage struct {
+	storage  istorage.IAppStorage
+	keyspace string
+	faults   *implIFaults
+	stopCtx  context.Context // used for delays of calls without context
+}
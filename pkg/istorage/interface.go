@@ -68,6 +68,17 @@ type IAppStorage interface {
 	// finishCCols can be empty (nil or zero len) too. In this case reads to the end of partition
 	// @ConcurrentAccess
 	Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb ReadCallback) (err error)
+
+	// the same as Read but clustering columns are iterated in descending order
+	// @ConcurrentAccess
+	ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb ReadCallback) (err error)
+
+	// enumerates partition keys of the storage in an undefined order, each key once
+	// partitions which contain only expired TTL records could be enumerated until the records are actually removed
+	// scans the whole storage, intended for maintenance tools, e.g. migration between drivers
+	// @ConcurrentAccess
+	ReadPartitionKeys(ctx context.Context, cb PartitionKeyCallback) (err error)
+
 	// ********* Working with TTL records ***********************
 
 	// Implementors of this interface may choose to store TTL records in a separate keyspace
@@ -136,6 +147,9 @@ type IAppStorage interface {
 // ccols and viewRecord are temporary internal values, must NOT be changed
 type ReadCallback func(ccols []byte, viewRecord []byte) (err error)
 
+// pKey is temporary internal value, must NOT be changed
+type PartitionKeyCallback func(pKey []byte) (err error)
+
 type BatchItem struct {
 	PKey  []byte
 	CCols []byte
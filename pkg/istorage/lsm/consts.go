@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package lsm
+
+import "time"
+
+// key prefixes
+// data key: dataPrefix + uint32(len(pKey)) + pKey + cCols, so records of the partition are stored together ordered by cCols
+// ttl key: ttlPrefix + uint64(expireAt) + data key, so expired records are at the beginning of the ttl index
+const (
+	dataPrefix byte = 'd'
+	ttlPrefix  byte = 't'
+)
+
+const (
+	pKeyLenSize     = 4
+	expireAtSize    = 8
+	cleanupInterval = time.Hour
+	cleanupBatch    = 1000
+)
@@ -0,0 +1,407 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package lsm
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/voedger/voedger/pkg/goutils/filesu"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func (p *appStorageFactory) AppStorage(appName istorage.SafeAppName) (s istorage.IAppStorage, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if s, ok := p.storages[appName.String()]; ok {
+		return s, nil
+	}
+
+	dbDir := filepath.Join(p.params.DBDir, appName.String())
+	exists, err := filesu.Exists(dbDir)
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	if !exists {
+		return nil, istorage.ErrStorageDoesNotExist
+	}
+	db, err := leveldb.OpenFile(dbDir, &opt.Options{ErrorIfMissing: true})
+	if err != nil {
+		// notest
+		return nil, err
+	}
+
+	impl := &appStorageType{db: db, iTime: p.iTime, wo: &opt.WriteOptions{Sync: !p.params.NoSync}}
+	p.storages[appName.String()] = impl
+
+	// start background cleaner
+	p.wg.Add(1)
+	go impl.backgroundCleaner(p.ctx, p.wg)
+
+	return impl, nil
+}
+
+func (p *appStorageFactory) Init(appName istorage.SafeAppName) error {
+	dbDir := filepath.Join(p.params.DBDir, appName.String())
+	exists, err := filesu.Exists(dbDir)
+	if err != nil {
+		// notest
+		return err
+	}
+	if exists {
+		return istorage.ErrStorageAlreadyExists
+	}
+	if err = os.MkdirAll(p.params.DBDir, filesu.FileMode_DefaultForDir); err != nil {
+		// notest
+		return err
+	}
+	db, err := leveldb.OpenFile(dbDir, &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		// notest
+		return err
+	}
+	return db.Close()
+}
+
+func (p *appStorageFactory) Time() timeu.ITime {
+	return p.iTime
+}
+
+// stops background cleaners and closes opened databases
+func (p *appStorageFactory) StopGoroutines() {
+	p.cancel()
+	p.wg.Wait()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for appName, s := range p.storages {
+		if err := s.db.Close(); err != nil {
+			logger.Error("lsm storage: failed to close " + appName + ": " + err.Error())
+		}
+	}
+	clear(p.storages)
+}
+
+// returns dataPrefix + uint32(len(pKey)) + pKey
+func partitionKey(pKey []byte) []byte {
+	res := make([]byte, 0, 1+pKeyLenSize+len(pKey))
+	res = append(res, dataPrefix)
+	res = binary.BigEndian.AppendUint32(res, uint32(len(pKey))) // nolint G115
+	return append(res, pKey...)
+}
+
+func dataKey(pKey, cCols []byte) []byte {
+	return append(partitionKey(pKey), cCols...)
+}
+
+// returns pKey from the data key
+func pKeyOf(dataKey []byte) []byte {
+	pKeyLen := binary.BigEndian.Uint32(dataKey[1 : 1+pKeyLenSize])
+	return dataKey[1+pKeyLenSize : 1+pKeyLenSize+pKeyLen]
+}
+
+func ttlKey(dataKey []byte, expireAt int64) []byte {
+	res := make([]byte, 0, 1+expireAtSize+len(dataKey))
+	res = append(res, ttlPrefix)
+	res = binary.BigEndian.AppendUint64(res, uint64(expireAt)) // nolint G115
+	return append(res, dataKey...)
+}
+
+// returns nil if record does not exist or is expired
+func (s *appStorageType) get(key []byte) (d *istorage.DataWithExpiration, err error) {
+	v, err := s.db.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := istorage.ReadWithExpiration(v)
+	if res.IsExpired(s.iTime.Now()) {
+		return nil, nil
+	}
+	return &res, nil
+}
+
+func (s *appStorageType) putValue(pKey, cCols, value []byte, ttlSeconds int) error {
+	key := dataKey(pKey, cCols)
+	d := istorage.DataWithExpiration{Data: value}
+	batch := new(leveldb.Batch)
+	if ttlSeconds > 0 {
+		d.ExpireAt = s.iTime.Now().Add(time.Duration(ttlSeconds) * time.Second).UnixMilli()
+		batch.Put(ttlKey(key, d.ExpireAt), nil)
+	}
+	batch.Put(key, d.ToBytes())
+	return s.db.Write(batch, s.wo)
+}
+
+//nolint:revive
+func (s *appStorageType) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	d, err := s.get(dataKey(pKey, cCols))
+	if err != nil || d != nil {
+		return false, err
+	}
+
+	if err := s.putValue(pKey, cCols, value, ttlSeconds); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+//nolint:revive
+func (s *appStorageType) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	d, err := s.get(dataKey(pKey, cCols))
+	if err != nil || d == nil || !bytes.Equal(d.Data, oldValue) {
+		return false, err
+	}
+
+	if err := s.putValue(pKey, cCols, newValue, ttlSeconds); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+//nolint:revive
+func (s *appStorageType) CompareAndDelete(pKey []byte, cCols []byte, expectedValue []byte) (ok bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := dataKey(pKey, cCols)
+	d, err := s.get(key)
+	if err != nil || d == nil || !bytes.Equal(d.Data, expectedValue) {
+		return false, err
+	}
+
+	// ttl key, if any, will be removed by the background cleaner
+	if err := s.db.Delete(key, s.wo); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+//nolint:revive
+func (s *appStorageType) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.Get(pKey, cCols, data)
+}
+
+//nolint:revive
+func (s *appStorageType) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.Read(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+//nolint:revive
+func (s *appStorageType) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok bool, err error) {
+	d, err := s.get(dataKey(pKey, cCols))
+	if err != nil || d == nil {
+		return 0, false, err
+	}
+
+	// If no expiration is set
+	if d.ExpireAt == 0 {
+		return 0, true, nil
+	}
+
+	// Calculate remaining TTL
+	ttlInSeconds = int(time.UnixMilli(d.ExpireAt).Sub(s.iTime.Now()).Seconds())
+	if ttlInSeconds <= 0 {
+		return 0, false, nil
+	}
+	return ttlInSeconds, true, nil
+}
+
+// istorage.IAppStorage.Put(pKey []byte, cCols []byte, value []byte) (err error)
+func (s *appStorageType) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	d := istorage.DataWithExpiration{Data: value}
+	return s.db.Put(dataKey(pKey, cCols), d.ToBytes(), s.wo)
+}
+
+// istorage.IAppStorage.PutBatch(items []BatchItem) (err error)
+func (s *appStorageType) PutBatch(items []istorage.BatchItem) (err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	batch := new(leveldb.Batch)
+	for _, item := range items {
+		d := istorage.DataWithExpiration{Data: item.Value}
+		batch.Put(dataKey(item.PKey, item.CCols), d.ToBytes())
+	}
+	return s.db.Write(batch, s.wo)
+}
+
+// istorage.IAppStorage.Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error)
+func (s *appStorageType) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	*data = (*data)[0:0]
+
+	d, err := s.get(dataKey(pKey, cCols))
+	if err != nil || d == nil {
+		return false, err
+	}
+
+	*data = append(*data, d.Data...)
+	return true, nil
+}
+
+// istorage.IAppStorage.GetBatch(pKey []byte, items []GetBatchItem) (err error)
+func (s *appStorageType) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	for i := range items {
+		if items[i].Ok, err = s.Get(pKey, items[i].CCols, items[i].Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// istorage.IAppStorage.Read(ctx context.Context, pKey []byte, startCCols []byte, finishCCols []byte, cb ReadCallback) (err error)
+func (s *appStorageType) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, false)
+}
+
+// istorage.IAppStorage.ReadReverse(ctx context.Context, pKey []byte, startCCols []byte, finishCCols []byte, cb ReadCallback) (err error)
+func (s *appStorageType) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.read(ctx, pKey, startCCols, finishCCols, cb, true)
+}
+
+// istorage.IAppStorage.ReadPartitionKeys(ctx context.Context, cb PartitionKeyCallback) (err error)
+func (s *appStorageType) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	it := s.db.NewIterator(util.BytesPrefix([]byte{dataPrefix}), nil)
+	defer it.Release()
+
+	for ok := it.First(); ok && ctx.Err() == nil; {
+		pKey := slices.Clone(pKeyOf(it.Key()))
+		if err := cb(pKey); err != nil {
+			return err
+		}
+		// skip the rest of the partition
+		ok = it.Seek(util.BytesPrefix(partitionKey(pKey)).Limit)
+	}
+	return it.Error()
+}
+
+func (s *appStorageType) read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback, reverse bool) (err error) {
+	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
+		return nil // absurd range
+	}
+
+	prefix := partitionKey(pKey)
+	rng := util.BytesPrefix(prefix)
+	if len(startCCols) > 0 {
+		rng.Start = append(slices.Clip(prefix), startCCols...)
+	}
+	if len(finishCCols) > 0 {
+		rng.Limit = append(slices.Clip(prefix), finishCCols...)
+	}
+
+	it := s.db.NewIterator(rng, nil)
+	defer it.Release()
+
+	first, next := it.First, it.Next
+	if reverse {
+		first, next = it.Last, it.Prev
+	}
+
+	now := s.iTime.Now()
+	for ok := first(); ok && ctx.Err() == nil; ok = next() {
+		// iterator buffers are reused, so the callback receives copies
+		d := istorage.ReadWithExpiration(slices.Clone(it.Value()))
+		if d.IsExpired(now) {
+			continue
+		}
+		if err := cb(slices.Clone(it.Key()[len(prefix):]), d.Data); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// removes expired records, returns true if there could be more expired records
+func (s *appStorageType) cleanupExpired(ctx context.Context) (more bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	it := s.db.NewIterator(util.BytesPrefix([]byte{ttlPrefix}), nil)
+	defer it.Release()
+
+	now := s.iTime.Now().UnixMilli()
+	batch := new(leveldb.Batch)
+	for ok := it.First(); ok && ctx.Err() == nil; ok = it.Next() {
+		if batch.Len() >= cleanupBatch {
+			more = true
+			break
+		}
+		k := it.Key()
+		expireAt := int64(binary.BigEndian.Uint64(k[1 : 1+expireAtSize])) // nolint G115
+		if expireAt > now {
+			break
+		}
+		batch.Delete(slices.Clone(k))
+
+		// the record could be rewritten or deleted since the ttl key was written
+		key := k[1+expireAtSize:]
+		if err := deleteIfExpiresAt(s.db, batch, key, expireAt); err != nil {
+			return false, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return false, err
+	}
+	return more, s.db.Write(batch, s.wo)
+}
+
+func deleteIfExpiresAt(db *leveldb.DB, batch *leveldb.Batch, key []byte, expireAt int64) error {
+	v, err := db.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if istorage.ReadWithExpiration(v).ExpireAt == expireAt {
+		batch.Delete(slices.Clone(key))
+	}
+	return nil
+}
+
+func (s *appStorageType) backgroundCleaner(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for ctx.Err() == nil {
+		timerCh := s.iTime.NewTimerChan(cleanupInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timerCh:
+			for more := true; more && ctx.Err() == nil; {
+				var err error
+				if more, err = s.cleanupExpired(ctx); err != nil {
+					logger.Error("lsm storage: failed to cleanup expired records: " + err.Error())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package lsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+	params := ParamsType{DBDir: t.TempDir()}
+
+	storageProvider := istorageimpl.Provide(Provide(params, testingu.MockTime))
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	require.NoError(appStorage.Put([]byte("pKey"), []byte("cCols"), []byte("test data string")))
+
+	value := make([]byte, 0)
+	ok, err := appStorage.Get([]byte("pKey"), []byte("cCols"), &value)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal([]byte("test data string"), value)
+
+	t.Run("data should survive the restart", func(t *testing.T) {
+		storageProvider.Stop()
+
+		storageProvider := istorageimpl.Provide(Provide(params, testingu.MockTime))
+		defer storageProvider.Stop()
+		appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+		require.NoError(err)
+
+		value := make([]byte, 0)
+		ok, err := appStorage.Get([]byte("pKey"), []byte("cCols"), &value)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("test data string"), value)
+	})
+}
+
+func TestTCK(t *testing.T) {
+	factory := Provide(ParamsType{DBDir: t.TempDir()}, testingu.MockTime)
+	defer factory.StopGoroutines()
+	istorage.TechnologyCompatibilityKit(t, factory)
+}
+
+func TestTCK_NoSync(t *testing.T) {
+	factory := Provide(ParamsType{DBDir: t.TempDir(), NoSync: true}, testingu.MockTime)
+	defer factory.StopGoroutines()
+	istorage.TechnologyCompatibilityKit(t, factory)
+}
+
+func TestPartitionKeys(t *testing.T) {
+	require := require.New(t)
+
+	storageProvider := istorageimpl.Provide(Provide(ParamsType{DBDir: t.TempDir()}, testingu.MockTime))
+	defer storageProvider.Stop()
+	appStorage, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	// pKey is a prefix of the other pKey
+	pKeys := [][]byte{{1}, {1, 1}, {1, 2}, {2}}
+	for _, pKey := range pKeys {
+		for c := range 3 {
+			require.NoError(appStorage.Put(pKey, []byte{byte(c)}, []byte{1}))
+		}
+	}
+
+	t.Run("partitions should not be mixed", func(t *testing.T) {
+		cCols := [][]byte{}
+		require.NoError(appStorage.Read(context.Background(), []byte{1}, nil, nil, func(c []byte, _ []byte) error {
+			cCols = append(cCols, c)
+			return nil
+		}))
+		require.Equal([][]byte{{0}, {1}, {2}}, cCols)
+	})
+
+	t.Run("each partition key should be enumerated once", func(t *testing.T) {
+		res := [][]byte{}
+		require.NoError(appStorage.ReadPartitionKeys(context.Background(), func(pKey []byte) error {
+			res = append(res, pKey)
+			return nil
+		}))
+		require.ElementsMatch(pKeys, res)
+	})
+}
+
+func TestBackgroundCleaner(t *testing.T) {
+	require := require.New(t)
+	iTime := testingu.NewMockTime()
+	// factory is used directly, so there is the only background cleaner
+	factory := Provide(ParamsType{DBDir: t.TempDir()}, iTime)
+	defer factory.StopGoroutines()
+	appName, err := istorage.NewSafeAppName(istructs.AppQName_test1_app1, func(string) (bool, error) { return true, nil })
+	require.NoError(err)
+	require.NoError(factory.Init(appName))
+
+	firstTimerArmed := make(chan struct{})
+	iTime.SetOnNextTimerArmed(func() { close(firstTimerArmed) })
+
+	storage, err := factory.AppStorage(appName)
+	require.NoError(err)
+	<-firstTimerArmed
+
+	// expires in 1 hour (50*60 = 3000s < 3600s)
+	ok, err := storage.InsertIfNotExists([]byte("pKey"), []byte("cCols1"), []byte("value1"), 50*60)
+	require.NoError(err)
+	require.True(ok)
+	// does NOT expire in 1 hour (61*60 = 3660s > 3600s)
+	ok, err = storage.InsertIfNotExists([]byte("pKey"), []byte("cCols2"), []byte("value2"), 61*60)
+	require.NoError(err)
+	require.True(ok)
+	// rewritten without TTL, must not be removed
+	ok, err = storage.InsertIfNotExists([]byte("pKey"), []byte("cCols3"), []byte("value3"), 50*60)
+	require.NoError(err)
+	require.True(ok)
+	ok, err = storage.CompareAndSwap([]byte("pKey"), []byte("cCols3"), []byte("value3"), []byte("value3"), 0)
+	require.NoError(err)
+	require.True(ok)
+
+	cleanerDone := make(chan struct{})
+	iTime.SetOnNextNewTimerChan(func() { close(cleanerDone) })
+
+	iTime.Sleep(time.Hour)
+	<-cleanerDone
+
+	impl := storage.(*appStorageType)
+	isStored := func(cCols string) bool {
+		ok, err := impl.db.Has(dataKey([]byte("pKey"), []byte(cCols)), nil)
+		require.NoError(err)
+		return ok
+	}
+	require.False(isStored("cCols1"))
+	require.True(isStored("cCols2"))
+	require.True(isStored("cCols3"))
+
+	ttlKeys := 0
+	it := impl.db.NewIterator(nil, nil)
+	for it.Next() {
+		if it.Key()[0] == ttlPrefix {
+			ttlKeys++
+		}
+	}
+	it.Release()
+	require.Equal(1, ttlKeys, "only ttl key of cCols2 should remain")
+}
+
+func TestAppStorageFactory_StopGoroutines(t *testing.T) {
+	require := require.New(t)
+
+	factory := Provide(ParamsType{DBDir: t.TempDir()}, testingu.MockTime)
+	storageProvider := istorageimpl.Provide(factory)
+
+	_, err := storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	storageProvider.Stop()
+
+	implFactory := factory.(*appStorageFactory)
+	require.Error(implFactory.ctx.Err())
+	require.Empty(implFactory.storages)
+
+	_, err = storageProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.ErrorIs(err, istorageimpl.ErrStoppingState)
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package lsm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// embedded LSM-tree storage, intended for single-node deployments with high write throughput
+func Provide(params ParamsType, iTime timeu.ITime) istorage.IAppStorageFactory {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &appStorageFactory{
+		params:   params,
+		iTime:    iTime,
+		ctx:      ctx,
+		cancel:   cancel,
+		wg:       &sync.WaitGroup{},
+		storages: map[string]*appStorageType{},
+	}
+}
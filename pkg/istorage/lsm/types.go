@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package lsm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+)
+
+type ParamsType struct {
+	// each app storage is a separate database in DBDir/<safe app name>
+	DBDir string
+
+	// writes are not fsync'ed on commit, so higher write throughput is achieved
+	// process crash loses nothing, OS crash or power loss could lose the latest writes
+	NoSync bool
+}
+
+type appStorageFactory struct {
+	params ParamsType
+	iTime  timeu.ITime
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+
+	// database directory could be opened once per process, so opened storages are kept
+	lock     sync.Mutex
+	storages map[string]*appStorageType
+}
+
+// implemetation for istorage.IAppStorage
+type appStorageType struct {
+	db    *leveldb.DB
+	iTime timeu.ITime
+	wo    *opt.WriteOptions
+
+	// writes are taken under RLock, read-modify-write operations (InsertIfNotExists, CompareAndSwap etc) are taken under Lock
+	// so concurrent Put's are still group-committed by the database
+	lock sync.RWMutex
+}
@@ -338,6 +338,46 @@ func (s *appStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCC
 	return nil
 }
 
+func (s *appStorage) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+
+	if (len(startCCols) > 0) && (len(finishCCols) > 0) && (bytes.Compare(startCCols, finishCCols) >= 0) {
+		return nil // absurd range
+	}
+
+	if cCols, values := s.readPart(ctx, pKey, startCCols, finishCCols); cCols != nil {
+		for i := len(cCols) - 1; i >= 0; i-- {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err = cb(cCols[i], values[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *appStorage) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	s.lock.RLock()
+	pKeys := make([][]byte, 0, len(s.storage))
+	for pKey := range s.storage {
+		pKeys = append(pKeys, []byte(pKey))
+	}
+	s.lock.RUnlock()
+
+	for _, pKey := range pKeys {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err = cb(pKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *appStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -4,6 +4,14 @@
 
 package provider
 
+// records amount written by one PutBatch on migration
+const DefaultMigrateBatchSize = 100
+
+const (
+	journalRecord_Partition = "partition"
+	journalRecord_Keyspace  = "keyspace"
+)
+
 var (
 	cCols_AppStorageDesc = []byte{1}
 	cCols_SafeAppName    = []byte{2}
@@ -11,4 +11,6 @@ var (
 	ErrStorageInitError     = errors.New("storage init error")
 	ErrStorageInitedAlready = errors.New("strorage inited already")
 	ErrStoppingState        = errors.New("storage is in stopping state")
+	ErrChecksumMismatch     = errors.New("checksum mismatch")
+	ErrWrongJournal         = errors.New("wrong migration journal")
 )
@@ -0,0 +1,352 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/filesu"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+func sysMetaAppSafeName(keyspaceIsolationSuffix string) istorage.SafeAppName {
+	res, err := istorage.NewSafeAppName(appdef.NewAppQName(appdef.SysPackage, "meta"), func(name string) (bool, error) { return true, nil })
+	if err != nil {
+		// notest
+		panic(err)
+	}
+	res.ApplyKeyspaceIsolationSuffix(keyspaceIsolationSuffix)
+	return res
+}
+
+// sys/meta is migrated the last, so the destination is not used by VVM until all applications are migrated
+func migrate(ctx context.Context, src, dst istorage.IAppStorageFactory, metaSafeName istorage.SafeAppName, batchSize int,
+	journal *migrationJournal) (res []KeyspaceMigration, err error) {
+	srcMeta, err := src.AppStorage(metaSafeName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", metaSafeName, err)
+	}
+	keyspaces, err := appKeyspaces(ctx, srcMeta)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", metaSafeName, err)
+	}
+	keyspaces = append(keyspaces, metaSafeName)
+	for _, keyspace := range keyspaces {
+		m, err := migrateKeyspace(ctx, src, dst, keyspace, batchSize, journal)
+		if err != nil {
+			return res, fmt.Errorf("%s: %w", keyspace, err)
+		}
+		res = append(res, m)
+	}
+	return res, nil
+}
+
+// returns successfully initialized storages of applications described in sys/meta
+func appKeyspaces(ctx context.Context, metaStorage istorage.IAppStorage) (res []istorage.SafeAppName, err error) {
+	pKeys := [][]byte{}
+	if err = metaStorage.ReadPartitionKeys(ctx, func(pKey []byte) error {
+		pKeys = append(pKeys, bytes.Clone(pKey))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	for _, pKey := range pKeys {
+		appDescJSON := []byte{}
+		ok, err := metaStorage.Get(pKey, cCols_AppStorageDesc, &appDescJSON)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue // pKey is SafeAppName
+		}
+		appStorageDesc := istorage.AppStorageDesc{}
+		if err := json.Unmarshal(appDescJSON, &appStorageDesc); err != nil {
+			return nil, fmt.Errorf("%s: %w", pKey, err)
+		}
+		if appStorageDesc.Status == istorage.AppStorageStatus_Done && len(appStorageDesc.Error) == 0 {
+			res = append(res, appStorageDesc.SafeName)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].String() < res[j].String() })
+	return res, nil
+}
+
+func migrateKeyspace(ctx context.Context, src, dst istorage.IAppStorageFactory, safeName istorage.SafeAppName, batchSize int,
+	journal *migrationJournal) (res KeyspaceMigration, err error) {
+	if res, ok := journal.keyspaces[safeName.String()]; ok {
+		logger.Info(fmt.Sprintf("%s is migrated already", safeName))
+		return res, nil
+	}
+	srcStorage, err := src.AppStorage(safeName)
+	if err != nil {
+		return res, err
+	}
+	if err := dst.Init(safeName); err != nil && !errors.Is(err, istorage.ErrStorageAlreadyExists) {
+		return res, err
+	}
+	dstStorage, err := dst.AppStorage(safeName)
+	if err != nil {
+		return res, err
+	}
+
+	// partition keys are read first to not mix reading with writing within the driver scan
+	pKeys := [][]byte{}
+	if err = srcStorage.ReadPartitionKeys(ctx, func(pKey []byte) error {
+		pKeys = append(pKeys, bytes.Clone(pKey))
+		return nil
+	}); err != nil {
+		return res, err
+	}
+
+	res.Keyspace = safeName.String()
+	migrated := journal.partitions[res.Keyspace]
+	for _, pKey := range pKeys {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+		p, ok := migrated[string(pKey)]
+		if !ok {
+			if p, err = migratePartition(ctx, srcStorage, dstStorage, pKey, batchSize); err != nil {
+				return res, fmt.Errorf("partition %x: %w", pKey, err)
+			}
+			if err = journal.partitionMigrated(res.Keyspace, pKey, p); err != nil {
+				return res, err
+			}
+		}
+		res.Partitions++
+		res.Records += p.records
+		res.TTLRecords += p.ttlRecords
+		res.Checksum ^= p.checksum
+	}
+	if err = journal.keyspaceMigrated(res); err != nil {
+		return res, err
+	}
+	logger.Info(fmt.Sprintf("%s is migrated: %d partitions, %d records, %d TTL records, checksum %x", safeName, res.Partitions,
+		res.Records, res.TTLRecords, res.Checksum))
+	return res, nil
+}
+
+// copies the partition and reads it back to compare checksums
+func migratePartition(ctx context.Context, src, dst istorage.IAppStorage, pKey []byte, batchSize int) (res partitionMigration, err error) {
+	srcHash := fnv.New64a()
+	ttlCCols := map[string]bool{}
+	batch := make([]istorage.BatchItem, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := dst.PutBatch(batch)
+		batch = batch[:0]
+		return err
+	}
+	err = src.TTLRead(ctx, pKey, nil, nil, func(cCols []byte, value []byte) error {
+		ttlSeconds, ok, err := src.QueryTTL(pKey, cCols)
+		if err != nil || !ok {
+			return err // !ok -> expired just now
+		}
+		if ttlSeconds > 0 {
+			res.ttlRecords++
+			ttlCCols[string(normalizeCCols(cCols))] = true
+			return putTTLRecord(dst, pKey, bytes.Clone(cCols), bytes.Clone(value), ttlSeconds)
+		}
+		res.records++
+		hashRecord(srcHash, cCols, value)
+		batch = append(batch, istorage.BatchItem{PKey: pKey, CCols: bytes.Clone(cCols), Value: bytes.Clone(value)})
+		if len(batch) == batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return res, err
+	}
+	if ctx.Err() != nil {
+		return res, ctx.Err()
+	}
+	res.checksum = srcHash.Sum64()
+
+	dstHash := fnv.New64a()
+	dstRecords := 0
+	if err = dst.TTLRead(ctx, pKey, nil, nil, func(cCols []byte, value []byte) error {
+		if !ttlCCols[string(normalizeCCols(cCols))] {
+			dstRecords++
+			hashRecord(dstHash, cCols, value)
+		}
+		return nil
+	}); err != nil {
+		return res, err
+	}
+	if ctx.Err() != nil {
+		return res, ctx.Err()
+	}
+	if dstRecords != res.records || dstHash.Sum64() != res.checksum {
+		return res, fmt.Errorf("%w: %d records with checksum %x are read, %d records with checksum %x are written", ErrChecksumMismatch,
+			res.records, res.checksum, dstRecords, dstHash.Sum64())
+	}
+	return res, nil
+}
+
+// the record could exist already if the migration is resumed
+func putTTLRecord(dst istorage.IAppStorage, pKey, cCols, value []byte, ttlSeconds int) error {
+	ok, err := dst.InsertIfNotExists(pKey, cCols, value, ttlSeconds)
+	if err != nil || ok {
+		return err
+	}
+	existing := []byte{}
+	if ok, err = dst.TTLGet(pKey, cCols, &existing); err != nil {
+		return err
+	}
+	if !ok {
+		_, err = dst.InsertIfNotExists(pKey, cCols, value, ttlSeconds)
+		return err
+	}
+	_, err = dst.CompareAndSwap(pKey, cCols, existing, value, ttlSeconds)
+	return err
+}
+
+func hashRecord(h hash.Hash64, cCols, value []byte) {
+	cCols = normalizeCCols(cCols)
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(cCols)))) // nolint G115
+	h.Write(cCols)
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(value)))) // nolint G115
+	h.Write(value)
+}
+
+// bbolt driver does not distinguish nil, empty and {0} clustering columns
+func normalizeCCols(cCols []byte) []byte {
+	if len(cCols) == 1 && cCols[0] == 0 {
+		return nil
+	}
+	return cCols
+}
+
+// Journal lines are:
+//
+//	partition <keyspace> <base64 pKey> <records> <TTL records> <hex checksum>
+//	keyspace <keyspace> <partitions> <records> <TTL records> <hex checksum>
+//
+// Incomplete last line (e.g. the migration is killed while writing) is truncated
+func openMigrationJournal(path string) (*migrationJournal, error) {
+	j := &migrationJournal{
+		partitions: map[string]map[string]partitionMigration{},
+		keyspaces:  map[string]KeyspaceMigration{},
+	}
+	if len(path) == 0 {
+		return j, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	complete := content[:bytes.LastIndexByte(content, '\n')+1]
+	for lineNum, line := range strings.Split(string(complete), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		if err := j.parseLine(line); err != nil {
+			return nil, fmt.Errorf("%w: %s: line %d: %w", ErrWrongJournal, path, lineNum+1, err)
+		}
+	}
+	if len(complete) < len(content) {
+		if err := os.Truncate(path, int64(len(complete))); err != nil {
+			return nil, err
+		}
+	}
+	if j.file, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, filesu.FileMode_DefaultForFile); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *migrationJournal) parseLine(line string) (err error) {
+	fields := strings.Fields(line)
+	if len(fields) != 6 {
+		return errors.New("6 fields expected")
+	}
+	nums := [3]uint64{}
+	for i, s := range fields[3:5] {
+		if nums[i], err = strconv.ParseUint(s, 10, 31); err != nil {
+			return err
+		}
+	}
+	if nums[2], err = strconv.ParseUint(fields[5], 16, 64); err != nil {
+		return err
+	}
+	keyspace := fields[1]
+	switch fields[0] {
+	case journalRecord_Partition:
+		pKey, err := base64.StdEncoding.DecodeString(fields[2])
+		if err != nil {
+			return err
+		}
+		if j.partitions[keyspace] == nil {
+			j.partitions[keyspace] = map[string]partitionMigration{}
+		}
+		j.partitions[keyspace][string(pKey)] = partitionMigration{records: int(nums[0]), ttlRecords: int(nums[1]), checksum: nums[2]}
+	case journalRecord_Keyspace:
+		partitions, err := strconv.ParseUint(fields[2], 10, 31)
+		if err != nil {
+			return err
+		}
+		j.keyspaces[keyspace] = KeyspaceMigration{Keyspace: keyspace, Partitions: int(partitions), Records: int(nums[0]),
+			TTLRecords: int(nums[1]), Checksum: nums[2]}
+	default:
+		return fmt.Errorf("unknown record %s", fields[0])
+	}
+	return nil
+}
+
+func (j *migrationJournal) partitionMigrated(keyspace string, pKey []byte, p partitionMigration) error {
+	return j.write(fmt.Sprintf("%s %s %s %d %d %x\n", journalRecord_Partition, keyspace, base64.StdEncoding.EncodeToString(pKey),
+		p.records, p.ttlRecords, p.checksum))
+}
+
+func (j *migrationJournal) keyspaceMigrated(m KeyspaceMigration) error {
+	if err := j.write(fmt.Sprintf("%s %s %d %d %d %x\n", journalRecord_Keyspace, m.Keyspace, m.Partitions, m.Records, m.TTLRecords,
+		m.Checksum)); err != nil {
+		return err
+	}
+	if j.file == nil {
+		return nil
+	}
+	return j.file.Sync()
+}
+
+func (j *migrationJournal) write(line string) error {
+	if j.file == nil {
+		return nil
+	}
+	_, err := j.file.WriteString(line)
+	return err
+}
+
+func (j *migrationJournal) close() {
+	if j.file != nil {
+		if err := j.file.Close(); err != nil {
+			logger.Error(fmt.Sprintf("failed to close migration journal: %s", err))
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/bbolt"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+)
+
+// bbolt database file could be opened once per process
+type reusingAppStorageFactory struct {
+	istorage.IAppStorageFactory
+	storages map[string]istorage.IAppStorage
+}
+
+func (f *reusingAppStorageFactory) AppStorage(appName istorage.SafeAppName) (istorage.IAppStorage, error) {
+	if s, ok := f.storages[appName.String()]; ok {
+		return s, nil
+	}
+	s, err := f.IAppStorageFactory.AppStorage(appName)
+	if err == nil {
+		f.storages[appName.String()] = s
+	}
+	return s, err
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	app1 := appdef.NewAppQName("test1", "app1")
+	app2 := appdef.NewAppQName("test1", "app2")
+
+	src := mem.Provide(testingu.MockTime)
+	srcProvider := Provide(src)
+	for _, app := range []appdef.AppQName{app1, app2} {
+		storage, err := srcProvider.AppStorage(app)
+		require.NoError(err)
+		for p := range 3 {
+			for c := range 150 {
+				require.NoError(storage.Put([]byte(fmt.Sprint(app, p)), []byte(fmt.Sprint(c)), []byte(fmt.Sprint(app, p, c))))
+			}
+		}
+		require.NoError(storage.Put([]byte("empty cCols"), nil, []byte("value")))
+		ok, err := storage.InsertIfNotExists([]byte("ttl"), []byte{1}, []byte("ttl value"), 100)
+		require.NoError(err)
+		require.True(ok)
+	}
+
+	dst := &reusingAppStorageFactory{
+		IAppStorageFactory: bbolt.Provide(bbolt.ParamsType{DBDir: t.TempDir()}, testingu.MockTime),
+		storages:           map[string]istorage.IAppStorage{},
+	}
+	defer dst.StopGoroutines()
+	journalPath := filepath.Join(t.TempDir(), "migration.journal")
+
+	res, err := Migrate(ctx, src, dst, MigrateParams{JournalPath: journalPath})
+	require.NoError(err)
+	require.Len(res, 3)
+	for _, m := range res[:2] {
+		require.Equal(5, m.Partitions)
+		require.Equal(3*150+1, m.Records)
+		require.Equal(1, m.TTLRecords)
+	}
+	require.Equal(sysMetaAppSafeName("").String(), res[2].Keyspace)
+
+	t.Run("migrated data should be available through the provider", func(t *testing.T) {
+		dstProvider := Provide(dst)
+		for _, app := range []appdef.AppQName{app1, app2} {
+			storage, err := dstProvider.AppStorage(app)
+			require.NoError(err)
+
+			data := []byte{}
+			ok, err := storage.Get([]byte(fmt.Sprint(app, 2)), []byte(fmt.Sprint(149)), &data)
+			require.NoError(err)
+			require.True(ok)
+			require.Equal(fmt.Sprint(app, 2, 149), string(data))
+
+			ttl, ok, err := storage.QueryTTL([]byte("ttl"), []byte{1})
+			require.NoError(err)
+			require.True(ok)
+			require.Positive(ttl)
+			require.LessOrEqual(ttl, 100)
+		}
+	})
+
+	t.Run("migration should be resumed from the journal", func(t *testing.T) {
+		content, err := os.ReadFile(journalPath)
+		require.NoError(err)
+		lines := strings.SplitAfter(string(content), "\n")
+
+		t.Run("migrated keyspaces should not be migrated again", func(t *testing.T) {
+			resumed, err := Migrate(ctx, src, dst, MigrateParams{JournalPath: journalPath})
+			require.NoError(err)
+			require.Equal(res, resumed)
+		})
+
+		t.Run("migrated partitions should not be migrated again", func(t *testing.T) {
+			// keep first 3 migrated partitions of the first keyspace and the incomplete line
+			require.NoError(os.WriteFile(journalPath, []byte(strings.Join(lines[:3], "")+"partition incomplete"), 0600))
+			resumed, err := Migrate(ctx, src, dst, MigrateParams{JournalPath: journalPath})
+			require.NoError(err)
+			require.Equal(res, resumed)
+		})
+
+		t.Run("wrong journal", func(t *testing.T) {
+			require.NoError(os.WriteFile(journalPath, []byte("unknown record\n"), 0600))
+			_, err := Migrate(ctx, src, dst, MigrateParams{JournalPath: journalPath})
+			require.ErrorIs(err, ErrWrongJournal)
+		})
+	})
+
+	t.Run("should fail on checksum mismatch", func(t *testing.T) {
+		storage, err := Provide(dst).AppStorage(app1)
+		require.NoError(err)
+		require.NoError(storage.Put([]byte(fmt.Sprint(app1, 0)), []byte("extra"), []byte("value")))
+
+		_, err = Migrate(ctx, src, dst, MigrateParams{})
+		require.ErrorIs(err, ErrChecksumMismatch)
+	})
+
+	t.Run("should fail if there is nothing to migrate", func(t *testing.T) {
+		_, err := Migrate(ctx, mem.Provide(testingu.MockTime), dst, MigrateParams{})
+		require.ErrorIs(err, istorage.ErrStorageDoesNotExist)
+	})
+}
@@ -5,6 +5,8 @@
 package provider
 
 import (
+	"context"
+
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/istorage"
 )
@@ -22,12 +24,22 @@ func Provide(asf istorage.IAppStorageFactory, keyspaceIsolationSuffix ...string)
 	if len(keyspaceIsolationSuffix) > 0 && len(keyspaceIsolationSuffix[0]) > 0 {
 		res.keyspaceIsolationSuffix = keyspaceIsolationSuffix[0]
 	}
-	var err error
-	res.sysMetaAppSafeName, err = istorage.NewSafeAppName(appdef.NewAppQName(appdef.SysPackage, "meta"), func(name string) (bool, error) { return true, nil })
+	res.sysMetaAppSafeName = sysMetaAppSafeName(res.keyspaceIsolationSuffix)
+	return res
+}
+
+// Copies all keyspaces known to the provider from src to dst: sys/meta and storages of all applications.
+// Must be used offline, i.e. no VVM must use src or dst.
+// Records are copied with their TTLs. Written records are read back and checked by checksums.
+// Migration is resumed from params.JournalPath if it is specified and exists.
+func Migrate(ctx context.Context, src, dst istorage.IAppStorageFactory, params MigrateParams) ([]KeyspaceMigration, error) {
+	if params.BatchSize <= 0 {
+		params.BatchSize = DefaultMigrateBatchSize
+	}
+	journal, err := openMigrationJournal(params.JournalPath)
 	if err != nil {
-		// notest
-		panic(err)
+		return nil, err
 	}
-	res.sysMetaAppSafeName.ApplyKeyspaceIsolationSuffix(res.keyspaceIsolationSuffix)
-	return res
+	defer journal.close()
+	return migrate(ctx, src, dst, sysMetaAppSafeName(params.KeyspaceIsolationSuffix), params.BatchSize, journal)
 }
@@ -5,6 +5,7 @@
 package provider
 
 import (
+	"os"
 	"sync"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -20,3 +21,43 @@ type implIAppStorageProvider struct {
 	isStopping              bool
 	sysMetaAppSafeName      istorage.SafeAppName
 }
+
+type MigrateParams struct {
+	// file the migration progress is appended to. Migration is resumed from it if the file exists
+	// migration is not resumable if empty
+	JournalPath string
+
+	// records amount written by one PutBatch. DefaultMigrateBatchSize if zero
+	BatchSize int
+
+	// see Provide
+	KeyspaceIsolationSuffix string
+}
+
+// result of migration of one keyspace
+type KeyspaceMigration struct {
+	Keyspace   string
+	Partitions int
+
+	// records without TTL, covered by Checksum
+	Records int
+
+	// records with TTL are not covered by Checksum because could expire during migration
+	TTLRecords int
+
+	// XOR of partition checksums, so does not depend on the order partition keys are enumerated by the driver
+	Checksum uint64
+}
+
+type partitionMigration struct {
+	records    int
+	ttlRecords int
+	checksum   uint64
+}
+
+// migrated partitions and keyspaces, appended to the file line by line
+type migrationJournal struct {
+	file       *os.File
+	partitions map[string]map[string]partitionMigration // keyspace -> pKey -> migration
+	keyspaces  map[string]KeyspaceMigration
+}
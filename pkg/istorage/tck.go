@@ -29,6 +29,8 @@ func TechnologyCompatibilityKit(t *testing.T, storageFactory IAppStorageFactory)
 // need to test e.g. istoragecache
 func TechnologyCompatibilityKit_Storage(t *testing.T, storage IAppStorage, iTime timeu.ITime) {
 	t.Run("TestAppStorage_GetPutRead", func(t *testing.T) { testAppStorage_GetPutRead(t, storage) })
+	t.Run("TestAppStorage_ReadReverse", func(t *testing.T) { testAppStorage_ReadReverse(t, storage) })
+	t.Run("TestAppStorage_ReadPartitionKeys", func(t *testing.T) { testAppStorage_ReadPartitionKeys(t, storage) })
 	t.Run("TestAppStorage_PutBatch", func(t *testing.T) { testAppStorage_PutBatch(t, storage) })
 	t.Run("TestAppStorage_GetBatch", func(t *testing.T) { testAppStorage_GetBatch(t, storage) })
 	t.Run("TestAppStorage_InsertIfNotExists", func(t *testing.T) { testAppStorage_InsertIfNotExists(t, storage, iTime) })
@@ -918,6 +920,82 @@ func testAppStorage_TTLGet(t *testing.T, storage IAppStorage, iTime timeu.ITime)
 }
 
 //nolint:revive,goconst
+func testAppStorage_ReadReverse(t *testing.T, storage IAppStorage) {
+	require := require.New(t)
+	ctx := context.Background()
+	pKey := []byte("reverse")
+
+	require.NoError(storage.Put(pKey, []byte{0x10, 0x11, 0x17}, []byte("100$")))
+	require.NoError(storage.Put(pKey, []byte{0x10, 0x12, 0x12}, []byte("200$")))
+	require.NoError(storage.Put(pKey, []byte{0x10, 0x11, 0x16}, []byte("300$")))
+	require.NoError(storage.Put(pKey, []byte{0x10, 0x10, 0x12}, []byte("400$")))
+	require.NoError(storage.Put(pKey, []byte{0x09, 0x07, 0x00}, []byte("500$")))
+
+	read := func(startCCols, finishCCols []byte) (viewRecords []string) {
+		viewRecords = []string{}
+		require.NoError(storage.ReadReverse(ctx, pKey, startCCols, finishCCols, func(_, viewRecord []byte) (err error) {
+			viewRecords = append(viewRecords, string(viewRecord))
+			return nil
+		}))
+		return viewRecords
+	}
+
+	t.Run("read closed range", func(t *testing.T) {
+		require.Equal([]string{"100$", "300$", "400$"}, read([]byte{0x10, 0x10, 0x00}, []byte{0x10, 0x11, 0xff}))
+	})
+
+	t.Run("read left-open range", func(t *testing.T) {
+		require.Equal([]string{"100$", "300$", "400$", "500$"}, read(nil, []byte{0x10, 0x11, 0xff}))
+	})
+
+	t.Run("read right-open range", func(t *testing.T) {
+		require.Equal([]string{"200$", "100$", "300$"}, read([]byte{0x10, 0x11, 0x00}, nil))
+	})
+
+	t.Run("read open range", func(t *testing.T) {
+		require.Equal([]string{"200$", "100$", "300$", "400$", "500$"}, read(nil, nil))
+	})
+
+	t.Run("read range which finishes after the last clustering columns", func(t *testing.T) {
+		require.Equal([]string{"200$", "100$"}, read([]byte{0x10, 0x11, 0x17}, []byte{0x11}))
+	})
+
+	t.Run("read absurd range", func(t *testing.T) {
+		require.Empty(read([]byte{0x11, 0x11, 0x00}, []byte{0x10, 0x11, 0x00}))
+	})
+
+	t.Run("read not exists pKey", func(t *testing.T) {
+		require.NoError(storage.ReadReverse(ctx, []byte("reverse-not-exists"), nil, nil, func(_, _ []byte) error {
+			require.Fail("should not be called")
+			return nil
+		}))
+	})
+
+	t.Run("should handle callback error", func(t *testing.T) {
+		errCb := errors.New("callback error")
+		times := 0
+		err := storage.ReadReverse(ctx, pKey, nil, nil, func(ccols, _ []byte) (err error) {
+			times++
+			require.Equal([]byte{0x10, 0x12, 0x12}, ccols)
+			return errCb
+		})
+		require.ErrorIs(err, errCb)
+		require.Equal(1, times)
+	})
+
+	t.Run("should handle ctx error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		times := 0
+		err := storage.ReadReverse(ctx, pKey, nil, nil, func(_, _ []byte) (err error) {
+			times++
+			cancel()
+			return nil
+		})
+		require.NoError(err)
+		require.Equal(1, times)
+	})
+}
+
 func testAppStorage_TTLRead(t *testing.T, storage IAppStorage, iTime timeu.ITime) {
 	t.Run("Should read ttl records", func(t *testing.T) {
 		require := require.New(t)
@@ -1080,3 +1158,48 @@ func testAppStorage_QueryTTL(t *testing.T, storage IAppStorage, iTime timeu.ITim
 		require.LessOrEqual(seconds, newTTL)
 	})
 }
+
+func testAppStorage_ReadPartitionKeys(t *testing.T, storage IAppStorage) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	pKeys := []string{"pkeys-1", "pkeys-2", "pkeys-3"}
+	for _, pKey := range pKeys {
+		require.NoError(storage.Put([]byte(pKey), []byte{1}, []byte("value")))
+		require.NoError(storage.Put([]byte(pKey), []byte{2}, []byte("value")))
+	}
+
+	t.Run("should enumerate each partition key once", func(t *testing.T) {
+		read := map[string]int{}
+		require.NoError(storage.ReadPartitionKeys(ctx, func(pKey []byte) error {
+			read[string(pKey)]++
+			return nil
+		}))
+		for _, pKey := range pKeys {
+			require.Equal(1, read[pKey], pKey)
+		}
+	})
+
+	t.Run("should handle callback error", func(t *testing.T) {
+		errCb := errors.New("callback error")
+		times := 0
+		err := storage.ReadPartitionKeys(ctx, func([]byte) error {
+			times++
+			return errCb
+		})
+		require.ErrorIs(err, errCb)
+		require.Equal(1, times)
+	})
+
+	t.Run("should stop on context cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		times := 0
+		require.NoError(storage.ReadPartitionKeys(ctx, func([]byte) error {
+			times++
+			cancel()
+			return nil
+		}))
+		require.Equal(1, times)
+	})
+}
@@ -409,6 +409,21 @@ func (s *cachedAppStorage) Read(ctx context.Context, pKey []byte, startCCols, fi
 	return s.storage.Read(ctx, pKey, startCCols, finishCCols, cb)
 }
 
+func (s *cachedAppStorage) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	start := time.Now()
+	defer func() {
+		s.mReadSeconds.Increase(time.Since(start).Seconds())
+	}()
+	s.mReadTotal.Increase(1.0)
+
+	return s.storage.ReadReverse(ctx, pKey, startCCols, finishCCols, cb)
+}
+
+// not cached, scans the whole storage
+func (s *cachedAppStorage) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	return s.storage.ReadPartitionKeys(ctx, cb)
+}
+
 func (s *cachedAppStorage) SetTestDelayGet(delay time.Duration) {
 	s.storage.(istorage.IStorageDelaySetter).SetTestDelayGet(delay)
 }
@@ -689,3 +689,11 @@ func (s *testStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err
 func (s *testStorage) Read(context.Context, []byte, []byte, []byte, istorage.ReadCallback) (err error) {
 	return err
 }
+
+func (s *testStorage) ReadReverse(context.Context, []byte, []byte, []byte, istorage.ReadCallback) (err error) {
+	return err
+}
+
+func (s *testStorage) ReadPartitionKeys(context.Context, istorage.PartitionKeyCallback) (err error) {
+	return err
+}
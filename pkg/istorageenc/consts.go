@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorageenc
+
+import "time"
+
+const (
+	// secret with keys of the certain application is read by name "storage-keys-<owner>-<app>"
+	AppKeysSecretNamePrefix = "storage-keys-"
+
+	// secret with keys of applications which have no own secret
+	DefaultKeysSecretName = "storage-keys"
+)
+
+const (
+	// encrypted value starts with the marker, values without it are read as plaintext, e.g. written before the encryption is enabled
+	encryptedValueMarker = "\xffenc"
+
+	formatVersion_AESGCM byte = 1
+
+	keyVersionSize = 2 // uint16
+	headerSize     = len(encryptedValueMarker) + 1 + keyVersionSize
+
+	// version of plaintext values, key versions are 1..65535
+	plaintextKeyVersion KeyVersion = 0
+
+	// values written under old keys and met on reading are queued to be re-encrypted in background
+	// queue overflow means some values are left under old keys until the next read
+	reEncryptQueueSize = 1024
+
+	// keys are re-read periodically, so rotated keys are applied without restart
+	keysRefreshInterval = time.Minute
+
+	// keys are re-read on reading a value encrypted by an unknown key, e.g. the key is added on other VVM,
+	// but not more often than once per interval
+	keysReloadMinInterval = time.Second
+)
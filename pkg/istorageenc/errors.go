@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorageenc
+
+import "errors"
+
+var ErrNoKeys = errors.New("no storage encryption keys")
+
+var ErrWrongKeysSecret = errors.New("wrong storage encryption keys secret")
+
+var ErrUnknownKeyVersion = errors.New("unknown storage encryption key version")
+
+var ErrDecrypt = errors.New("failed to decrypt storage value")
@@ -0,0 +1,361 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorageenc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/isecrets"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+type implEncryptingAppStorageProvider struct {
+	storageProvider istorage.IAppStorageProvider
+	secretReader    isecrets.ISecretReader
+	iTime           timeu.ITime
+	reEncryptQueue  chan reEncryptItem
+	stop            chan struct{}
+	stopOnce        sync.Once
+	lock            sync.Mutex
+	cache           map[appdef.AppQName]*encryptedAppStorage
+}
+
+func (asp *implEncryptingAppStorageProvider) Prepare(work any) error {
+	return asp.storageProvider.Prepare(work)
+}
+
+// Re-encrypts values written under old keys and met on reading, re-reads keys periodically until ctx is done or Stop is called
+func (asp *implEncryptingAppStorageProvider) Run(ctx context.Context) {
+	asp.storageProvider.Run(ctx)
+	refreshKeys := asp.iTime.NewTimerChan(keysRefreshInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-asp.stop:
+			return
+		case <-refreshKeys:
+			for _, storage := range asp.storages() {
+				storage.reloadKeys()
+			}
+			refreshKeys = asp.iTime.NewTimerChan(keysRefreshInterval)
+		case item := <-asp.reEncryptQueue:
+			if _, err := item.storage.reEncrypt(item.pKey, item.cCols, item.value); err != nil {
+				logger.Error(fmt.Sprintf("failed to re-encrypt value of %s: %s", item.storage.appQName, err))
+			}
+		}
+	}
+}
+
+func (asp *implEncryptingAppStorageProvider) Stop() {
+	asp.stopOnce.Do(func() { close(asp.stop) })
+	asp.storageProvider.Stop()
+}
+
+// Keys are re-read every keysRefreshInterval while Run is running, so rotated keys are applied without restart
+func (asp *implEncryptingAppStorageProvider) AppStorage(appQName appdef.AppQName) (istorage.IAppStorage, error) {
+	asp.lock.Lock()
+	defer asp.lock.Unlock()
+	if storage, ok := asp.cache[appQName]; ok {
+		return storage, nil
+	}
+	storage, err := asp.storageProvider.AppStorage(appQName)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := readKeyRing(asp.secretReader, appQName)
+	if err != nil {
+		return nil, err
+	}
+	encStorage := &encryptedAppStorage{
+		storage:        storage,
+		appQName:       appQName,
+		secretReader:   asp.secretReader,
+		iTime:          asp.iTime,
+		keysReadAt:     asp.iTime.Now(),
+		reEncryptQueue: asp.reEncryptQueue,
+	}
+	encStorage.keys.Store(keys)
+	asp.cache[appQName] = encStorage
+	return encStorage, nil
+}
+
+func (asp *implEncryptingAppStorageProvider) ReEncryptApp(ctx context.Context, appQName appdef.AppQName) (reEncrypted int, err error) {
+	storage, err := asp.AppStorage(appQName)
+	if err != nil {
+		return 0, err
+	}
+	reEncryptor := storage.(IReEncryptor)
+	err = storage.ReadPartitionKeys(ctx, func(pKey []byte) error {
+		n, err := reEncryptor.ReEncrypt(ctx, pKey, nil, nil)
+		reEncrypted += n
+		return err
+	})
+	return reEncrypted, err
+}
+
+func (asp *implEncryptingAppStorageProvider) storages() []*encryptedAppStorage {
+	asp.lock.Lock()
+	defer asp.lock.Unlock()
+	res := make([]*encryptedAppStorage, 0, len(asp.cache))
+	for _, storage := range asp.cache {
+		res = append(res, storage)
+	}
+	return res
+}
+
+// Values are encrypted, partition keys and clustering columns are stored as is, so range reads work as usual.
+type encryptedAppStorage struct {
+	storage        istorage.IAppStorage
+	keys           atomic.Pointer[keyRing]
+	appQName       appdef.AppQName
+	secretReader   isecrets.ISecretReader
+	iTime          timeu.ITime
+	reloadLock     sync.Mutex
+	keysReadAt     time.Time
+	reEncryptQueue chan reEncryptItem
+}
+
+func (s *encryptedAppStorage) Put(pKey []byte, cCols []byte, value []byte) (err error) {
+	return s.storage.Put(pKey, cCols, s.keys.Load().encrypt(pKey, cCols, value))
+}
+
+func (s *encryptedAppStorage) PutBatch(items []istorage.BatchItem) (err error) {
+	encrypted := make([]istorage.BatchItem, len(items))
+	for i, item := range items {
+		encrypted[i] = istorage.BatchItem{PKey: item.PKey, CCols: item.CCols, Value: s.keys.Load().encrypt(item.PKey, item.CCols, item.Value)}
+	}
+	return s.storage.PutBatch(encrypted)
+}
+
+func (s *encryptedAppStorage) Get(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.get(s.storage.Get, pKey, cCols, data)
+}
+
+func (s *encryptedAppStorage) TTLGet(pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	return s.get(s.storage.TTLGet, pKey, cCols, data)
+}
+
+func (s *encryptedAppStorage) GetBatch(pKey []byte, items []istorage.GetBatchItem) (err error) {
+	encrypted := make([]istorage.GetBatchItem, len(items))
+	for i, item := range items {
+		encrypted[i] = istorage.GetBatchItem{CCols: item.CCols, Data: new([]byte)}
+	}
+	if err = s.storage.GetBatch(pKey, encrypted); err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].Ok = encrypted[i].Ok
+		if !items[i].Ok {
+			continue
+		}
+		if *items[i].Data, err = s.decrypt((*items[i].Data)[:0], pKey, items[i].CCols, *encrypted[i].Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *encryptedAppStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.storage.Read(ctx, pKey, startCCols, finishCCols, s.readCallback(pKey, cb))
+}
+
+func (s *encryptedAppStorage) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.storage.ReadReverse(ctx, pKey, startCCols, finishCCols, s.readCallback(pKey, cb))
+}
+
+func (s *encryptedAppStorage) TTLRead(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.storage.TTLRead(ctx, pKey, startCCols, finishCCols, s.readCallback(pKey, cb))
+}
+
+// Partition keys are not encrypted
+func (s *encryptedAppStorage) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	return s.storage.ReadPartitionKeys(ctx, cb)
+}
+
+func (s *encryptedAppStorage) InsertIfNotExists(pKey []byte, cCols []byte, value []byte, ttlSeconds int) (ok bool, err error) {
+	return s.storage.InsertIfNotExists(pKey, cCols, s.keys.Load().encrypt(pKey, cCols, value), ttlSeconds)
+}
+
+// Encryption is not deterministic, so the stored encrypted value is found by oldValue and then swapped.
+// Repeated if the stored value is changed concurrently by the value equal to oldValue.
+func (s *encryptedAppStorage) CompareAndSwap(pKey []byte, cCols []byte, oldValue, newValue []byte, ttlSeconds int) (ok bool, err error) {
+	for {
+		stored, ok, err := s.findEncrypted(pKey, cCols, oldValue)
+		if err != nil || !ok {
+			return false, err
+		}
+		if ok, err = s.storage.CompareAndSwap(pKey, cCols, stored, s.keys.Load().encrypt(pKey, cCols, newValue), ttlSeconds); err != nil || ok {
+			return ok, err
+		}
+	}
+}
+
+// See CompareAndSwap
+func (s *encryptedAppStorage) CompareAndDelete(pKey []byte, cCols []byte, expectedValue []byte) (ok bool, err error) {
+	if expectedValue == nil {
+		return s.storage.CompareAndDelete(pKey, cCols, nil)
+	}
+	for {
+		stored, ok, err := s.findEncrypted(pKey, cCols, expectedValue)
+		if err != nil || !ok {
+			return false, err
+		}
+		if ok, err = s.storage.CompareAndDelete(pKey, cCols, stored); err != nil || ok {
+			return ok, err
+		}
+	}
+}
+
+func (s *encryptedAppStorage) QueryTTL(pKey []byte, cCols []byte) (ttlInSeconds int, ok bool, err error) {
+	return s.storage.QueryTTL(pKey, cCols)
+}
+
+func (s *encryptedAppStorage) ReEncrypt(ctx context.Context, pKey []byte, startCCols, finishCCols []byte) (reEncrypted int, err error) {
+	type item struct{ cCols, value []byte }
+	oldItems := []item{}
+	current := s.keys.Load().current
+	err = s.storage.TTLRead(ctx, pKey, startCCols, finishCCols, func(cCols []byte, value []byte) error {
+		if ver, err := storedKeyVersion(value); err == nil && ver != current {
+			oldItems = append(oldItems, item{cCols: bytes.Clone(cCols), value: bytes.Clone(value)})
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, i := range oldItems {
+		if ctx.Err() != nil {
+			break
+		}
+		ok, err := s.reEncrypt(pKey, i.cCols, i.value)
+		if err != nil {
+			return reEncrypted, err
+		}
+		if ok {
+			reEncrypted++
+		}
+	}
+	return reEncrypted, nil
+}
+
+func (s *encryptedAppStorage) get(get func(pKey []byte, cCols []byte, data *[]byte) (bool, error), pKey []byte, cCols []byte, data *[]byte) (ok bool, err error) {
+	encrypted := []byte{}
+	if ok, err = get(pKey, cCols, &encrypted); err != nil || !ok {
+		return ok, err
+	}
+	if *data, err = s.decrypt((*data)[:0], pKey, cCols, encrypted); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *encryptedAppStorage) readCallback(pKey []byte, cb istorage.ReadCallback) istorage.ReadCallback {
+	return func(cCols []byte, encrypted []byte) error {
+		value, err := s.decrypt(nil, pKey, cCols, encrypted)
+		if err != nil {
+			return err
+		}
+		return cb(cCols, value)
+	}
+}
+
+// Values encrypted by old keys and plaintext values are queued to be re-encrypted by the current key
+func (s *encryptedAppStorage) decrypt(dst, pKey, cCols, encrypted []byte) ([]byte, error) {
+	res, ver, err := s.open(dst, pKey, cCols, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	if ver != s.keys.Load().current {
+		select {
+		case s.reEncryptQueue <- reEncryptItem{storage: s, pKey: bytes.Clone(pKey), cCols: bytes.Clone(cCols), value: bytes.Clone(encrypted)}:
+		default:
+		}
+	}
+	return res, nil
+}
+
+// Returns the stored encrypted value if it is decrypted to the expected one
+func (s *encryptedAppStorage) findEncrypted(pKey, cCols, expected []byte) (stored []byte, ok bool, err error) {
+	stored = []byte{}
+	if ok, err = s.storage.TTLGet(pKey, cCols, &stored); err != nil || !ok {
+		return nil, false, err
+	}
+	value, _, err := s.open(nil, pKey, cCols, stored)
+	if err != nil {
+		return nil, false, err
+	}
+	return stored, bytes.Equal(value, expected), nil
+}
+
+// Replaces the plaintext value or the value encrypted by an old key with the value encrypted by the current key.
+// Returns false if the value is changed concurrently, the record is removed or expired.
+func (s *encryptedAppStorage) reEncrypt(pKey, cCols, encrypted []byte) (ok bool, err error) {
+	value, ver, err := s.open(nil, pKey, cCols, encrypted)
+	if err != nil {
+		return false, err
+	}
+	if ver == s.keys.Load().current {
+		return false, nil
+	}
+	ttlSeconds, ok, err := s.storage.QueryTTL(pKey, cCols)
+	if err != nil || !ok {
+		return false, err
+	}
+	return s.storage.CompareAndSwap(pKey, cCols, encrypted, s.keys.Load().encrypt(pKey, cCols, value), ttlSeconds)
+}
+
+// Returns the value and the version of the key it is encrypted by, plaintextKeyVersion for plaintext values.
+// Keys are re-read if the value is encrypted by an unknown key, e.g. the key is added and used on other VVM.
+func (s *encryptedAppStorage) open(dst, pKey, cCols, stored []byte) ([]byte, KeyVersion, error) {
+	if !isEncrypted(stored) {
+		return append(dst, stored...), plaintextKeyVersion, nil
+	}
+	res, ver, err := s.keys.Load().decrypt(dst, pKey, cCols, stored)
+	if errors.Is(err, ErrUnknownKeyVersion) {
+		// keys could be re-read concurrently, so decrypted again even if not re-read here
+		s.reloadKeys()
+		res, ver, err = s.keys.Load().decrypt(dst, pKey, cCols, stored)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", s.appQName, err)
+	}
+	return res, ver, nil
+}
+
+// Re-reads keys of the application, not more often than keysReloadMinInterval.
+// Old keys are kept if reading fails.
+func (s *encryptedAppStorage) reloadKeys() {
+	s.reloadLock.Lock()
+	defer s.reloadLock.Unlock()
+	now := s.iTime.Now()
+	if now.Sub(s.keysReadAt) < keysReloadMinInterval {
+		return
+	}
+	s.keysReadAt = now
+	keys, err := readKeyRing(s.secretReader, s.appQName)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to re-read storage keys of %s, old keys are used: %s", s.appQName, err))
+		return
+	}
+	s.keys.Store(keys)
+}
+
+// Returns the version of the key the stored value is encrypted by, plaintextKeyVersion for plaintext values
+func storedKeyVersion(stored []byte) (KeyVersion, error) {
+	if !isEncrypted(stored) {
+		return plaintextKeyVersion, nil
+	}
+	return keyVersion(stored)
+}
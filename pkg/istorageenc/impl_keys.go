@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorageenc
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/isecrets"
+)
+
+// Reads keys of the application from the secret "storage-keys-<owner>-<app>" or from the default secret "storage-keys" if there is no own one.
+func readKeyRing(secretReader isecrets.ISecretReader, appQName appdef.AppQName) (*keyRing, error) {
+	secretName := AppKeysSecretNamePrefix + appQName.Owner() + "-" + appQName.Name()
+	secret, err := secretReader.ReadSecret(secretName)
+	if errors.Is(err, fs.ErrNotExist) {
+		secretName = DefaultKeysSecretName
+		secret, err = secretReader.ReadSecret(secretName)
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w for %s: neither %s nor %s secret exists", ErrNoKeys, appQName, AppKeysSecretNamePrefix+appQName.Owner()+"-"+appQName.Name(), DefaultKeysSecretName)
+		}
+		return nil, err
+	}
+	ring, err := parseKeyRing(secret)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", secretName, err)
+	}
+	return ring, nil
+}
+
+// Secret contains one key per line in form "<version>:<base64 key>". Version is 1..65535.
+// Key is 16, 24 or 32 bytes long for AES-128, AES-192 or AES-256. The key with the greatest version is the current one.
+// Empty lines and lines started with "#" are ignored.
+func parseKeyRing(secret []byte) (*keyRing, error) {
+	ring := &keyRing{aeads: map[KeyVersion]cipher.AEAD{}}
+	scanner := bufio.NewScanner(bytes.NewReader(secret))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		verStr, keyStr, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: <version>:<base64 key> expected", ErrWrongKeysSecret, lineNum)
+		}
+		ver, err := strconv.ParseUint(strings.TrimSpace(verStr), 10, 16)
+		if err != nil || ver == 0 {
+			return nil, fmt.Errorf("%w: line %d: key version must be 1..65535", ErrWrongKeysSecret, lineNum)
+		}
+		if _, ok := ring.aeads[KeyVersion(ver)]; ok {
+			return nil, fmt.Errorf("%w: line %d: duplicate key version %d", ErrWrongKeysSecret, lineNum, ver)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyStr))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrWrongKeysSecret, lineNum, err)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrWrongKeysSecret, lineNum, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			// notest
+			return nil, err
+		}
+		ring.aeads[KeyVersion(ver)] = aead
+		ring.current = max(ring.current, KeyVersion(ver))
+	}
+	if err := scanner.Err(); err != nil {
+		// notest
+		return nil, err
+	}
+	if len(ring.aeads) == 0 {
+		return nil, ErrNoKeys
+	}
+	return ring, nil
+}
+
+// Encrypted value is: marker, format version (1 byte), key version (2 bytes), nonce, sealed value.
+// Partition key and clustering columns are the additional data, so the value could not be moved to other record.
+func (r *keyRing) encrypt(pKey, cCols, value []byte) []byte {
+	aead := r.aeads[r.current]
+	res := make([]byte, headerSize+aead.NonceSize(), headerSize+aead.NonceSize()+len(value)+aead.Overhead())
+	copy(res, encryptedValueMarker)
+	res[len(encryptedValueMarker)] = formatVersion_AESGCM
+	binary.BigEndian.PutUint16(res[headerSize-keyVersionSize:headerSize], uint16(r.current))
+	nonce := res[headerSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		// notest
+		panic(err)
+	}
+	return aead.Seal(res, nonce, value, additionalData(pKey, cCols))
+}
+
+// Appends decrypted value to dst. Returns the version of the key the value is encrypted by.
+func (r *keyRing) decrypt(dst, pKey, cCols, value []byte) ([]byte, KeyVersion, error) {
+	ver, err := keyVersion(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	aead, ok := r.aeads[ver]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w %d", ErrUnknownKeyVersion, ver)
+	}
+	if len(value) < headerSize+aead.NonceSize() {
+		return nil, 0, ErrDecrypt
+	}
+	nonce, sealed := value[headerSize:headerSize+aead.NonceSize()], value[headerSize+aead.NonceSize():]
+	res, err := aead.Open(dst, nonce, sealed, additionalData(pKey, cCols))
+	if err != nil {
+		return nil, 0, ErrDecrypt
+	}
+	return res, ver, nil
+}
+
+// Returns the version of the key the value is encrypted by
+func keyVersion(value []byte) (KeyVersion, error) {
+	if len(value) < headerSize || value[len(encryptedValueMarker)] != formatVersion_AESGCM {
+		return 0, ErrDecrypt
+	}
+	return KeyVersion(binary.BigEndian.Uint16(value[headerSize-keyVersionSize : headerSize])), nil
+}
+
+func isEncrypted(value []byte) bool {
+	return bytes.HasPrefix(value, []byte(encryptedValueMarker))
+}
+
+func additionalData(pKey, cCols []byte) []byte {
+	if len(cCols) == 1 && cCols[0] == 0 {
+		cCols = nil // bbolt driver does not distinguish nil and {0} clustering columns
+	}
+	res := make([]byte, 2, 2+len(pKey)+len(cCols))
+	binary.BigEndian.PutUint16(res, uint16(len(pKey))) // nolint G115: partition key is shorter than 64K
+	res = append(res, pKey...)
+	return append(res, cCols...)
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorageenc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	istorageimpl "github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+type testSecretReader map[string]string
+
+func (r testSecretReader) ReadSecret(name string) ([]byte, error) {
+	if s, ok := r[name]; ok {
+		return []byte(s), nil
+	}
+	return nil, fmt.Errorf("secret %s: %w", name, fs.ErrNotExist)
+}
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestTechnologyCompatibilityKit(t *testing.T) {
+	asf := mem.Provide(testingu.MockTime)
+	asp := Provide(istorageimpl.Provide(asf), testSecretReader{DefaultKeysSecretName: "1:" + testKey(1)}, testingu.MockTime)
+	storage, err := asp.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(t, err)
+	istorage.TechnologyCompatibilityKit_Storage(t, storage, asf.Time())
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	asp := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	raw, err := asp.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	secrets := testSecretReader{AppKeysSecretNamePrefix + "test1-app1": "1:" + testKey(1)}
+	storage, err := Provide(asp, secrets, testingu.MockTime).AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	pKey, cCols, value := []byte("pKey"), []byte("cCols"), []byte("secret value")
+	require.NoError(storage.Put(pKey, cCols, value))
+
+	t.Run("value should be encrypted at rest", func(t *testing.T) {
+		stored := []byte{}
+		ok, err := raw.Get(pKey, cCols, &stored)
+		require.NoError(err)
+		require.True(ok)
+		require.NotContains(string(stored), string(value))
+	})
+
+	t.Run("value should be decrypted on read", func(t *testing.T) {
+		data := []byte{}
+		ok, err := storage.Get(pKey, cCols, &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal(value, data)
+	})
+
+	t.Run("value moved to other record should not be decrypted", func(t *testing.T) {
+		stored := []byte{}
+		_, err := raw.Get(pKey, cCols, &stored)
+		require.NoError(err)
+		require.NoError(raw.Put(pKey, []byte("other"), stored))
+
+		data := []byte{}
+		ok, err := storage.Get(pKey, []byte("other"), &data)
+		require.ErrorIs(err, ErrDecrypt)
+		require.False(ok)
+	})
+
+	t.Run("compare and swap should compare decrypted values", func(t *testing.T) {
+		ok, err := storage.CompareAndSwap(pKey, cCols, []byte("wrong"), []byte("new value"), 0)
+		require.NoError(err)
+		require.False(ok)
+
+		ok, err = storage.CompareAndSwap(pKey, cCols, value, []byte("new value"), 0)
+		require.NoError(err)
+		require.True(ok)
+
+		ok, err = storage.CompareAndDelete(pKey, cCols, []byte("new value"))
+		require.NoError(err)
+		require.True(ok)
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	require := require.New(t)
+
+	asp := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	raw, err := asp.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	pKey := []byte("pKey")
+	storedKeyVersion := func(cCols []byte) KeyVersion {
+		stored := []byte{}
+		ok, err := raw.Get(pKey, cCols, &stored)
+		require.NoError(err)
+		require.True(ok)
+		ver, err := keyVersion(stored)
+		require.NoError(err)
+		return ver
+	}
+
+	oldStorage, err := Provide(asp, testSecretReader{DefaultKeysSecretName: "1:" + testKey(1)}, testingu.MockTime).AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	for i := byte(0); i < 3; i++ {
+		require.NoError(oldStorage.Put(pKey, []byte{i}, []byte{i}))
+	}
+
+	newProvider := Provide(asp, testSecretReader{DefaultKeysSecretName: "1:" + testKey(1) + "\n2:" + testKey(2)}, testingu.MockTime)
+	newStorage, err := newProvider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	t.Run("values encrypted by old key should be read", func(t *testing.T) {
+		data := []byte{}
+		ok, err := newStorage.Get(pKey, []byte{0}, &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte{0}, data)
+	})
+
+	t.Run("values encrypted by old key should be re-encrypted in background after reading", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			newProvider.Run(ctx)
+			close(done)
+		}()
+		defer func() {
+			cancel()
+			<-done
+		}()
+		require.Eventually(func() bool { return storedKeyVersion([]byte{0}) == 2 }, time.Second, time.Millisecond)
+		require.Equal(KeyVersion(1), storedKeyVersion([]byte{1}))
+	})
+
+	t.Run("values encrypted by old key should be re-encrypted explicitly", func(t *testing.T) {
+		reEncrypted, err := newStorage.(IReEncryptor).ReEncrypt(context.Background(), pKey, nil, nil)
+		require.NoError(err)
+		require.Equal(2, reEncrypted)
+		for i := byte(0); i < 3; i++ {
+			require.Equal(KeyVersion(2), storedKeyVersion([]byte{i}))
+		}
+
+		_, err = oldStorage.Get(pKey, []byte{0}, new([]byte))
+		require.ErrorIs(err, ErrUnknownKeyVersion)
+	})
+}
+
+func TestPlaintextValues(t *testing.T) {
+	require := require.New(t)
+
+	asp := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	raw, err := asp.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	// written before the encryption is enabled
+	pKey := []byte("pKey")
+	for i := byte(0); i < 3; i++ {
+		require.NoError(raw.Put(pKey, []byte{i}, []byte("plaintext")))
+	}
+
+	provider := Provide(asp, testSecretReader{DefaultKeysSecretName: "1:" + testKey(1)}, testingu.MockTime)
+	storage, err := provider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	isStoredEncrypted := func(cCols []byte) bool {
+		stored := []byte{}
+		ok, err := raw.Get(pKey, cCols, &stored)
+		require.NoError(err)
+		require.True(ok)
+		return isEncrypted(stored)
+	}
+
+	t.Run("plaintext values should be read as is", func(t *testing.T) {
+		data := []byte{}
+		ok, err := storage.Get(pKey, []byte{0}, &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("plaintext"), data)
+
+		ok, err = storage.CompareAndSwap(pKey, []byte{1}, []byte("plaintext"), []byte("new value"), 0)
+		require.NoError(err)
+		require.True(ok)
+		require.True(isStoredEncrypted([]byte{1}))
+	})
+
+	t.Run("plaintext values should be encrypted in background after reading", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			provider.Run(ctx)
+			close(done)
+		}()
+		defer func() {
+			cancel()
+			<-done
+		}()
+		require.Eventually(func() bool { return isStoredEncrypted([]byte{0}) }, time.Second, time.Millisecond)
+		require.False(isStoredEncrypted([]byte{2}))
+	})
+
+	t.Run("plaintext values of the application should be encrypted explicitly", func(t *testing.T) {
+		reEncrypted, err := provider.ReEncryptApp(context.Background(), istructs.AppQName_test1_app1)
+		require.NoError(err)
+		require.Equal(1, reEncrypted)
+		require.True(isStoredEncrypted([]byte{2}))
+
+		data := []byte{}
+		ok, err := storage.Get(pKey, []byte{2}, &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte("plaintext"), data)
+	})
+}
+
+func TestKeysReload(t *testing.T) {
+	require := require.New(t)
+
+	asp := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	raw, err := asp.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	pKey := []byte("pKey")
+	secrets := testSecretReader{DefaultKeysSecretName: "1:" + testKey(1)}
+	provider := Provide(asp, secrets, testingu.MockTime)
+	storage, err := provider.AppStorage(istructs.AppQName_test1_app1)
+	require.NoError(err)
+
+	t.Run("keys should be re-read on reading value encrypted by unknown key", func(t *testing.T) {
+		// the key is added and used on other VVM
+		otherSecrets := testSecretReader{DefaultKeysSecretName: "1:" + testKey(1) + "\n2:" + testKey(2)}
+		other, err := Provide(asp, otherSecrets, testingu.MockTime).AppStorage(istructs.AppQName_test1_app1)
+		require.NoError(err)
+		require.NoError(other.Put(pKey, []byte{0}, []byte{0}))
+		secrets[DefaultKeysSecretName] = otherSecrets[DefaultKeysSecretName]
+
+		testingu.MockTime.Add(keysReloadMinInterval)
+		data := []byte{}
+		ok, err := storage.Get(pKey, []byte{0}, &data)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]byte{0}, data)
+	})
+
+	t.Run("rotated keys should be applied periodically without restart", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			provider.Run(ctx)
+			close(done)
+		}()
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		// changed before the timer fires, so Run reads the new keys
+		secrets[DefaultKeysSecretName] += "\n3:" + testKey(3)
+
+		require.Eventually(func() bool {
+			testingu.MockTime.Add(keysRefreshInterval)
+			require.NoError(storage.Put(pKey, []byte{1}, []byte{1}))
+			stored := []byte{}
+			_, err := raw.Get(pKey, []byte{1}, &stored)
+			require.NoError(err)
+			ver, err := keyVersion(stored)
+			require.NoError(err)
+			return ver == 3
+		}, time.Second, time.Millisecond)
+	})
+}
+
+func TestKeysErrors(t *testing.T) {
+	asp := istorageimpl.Provide(mem.Provide(testingu.MockTime))
+	tests := []struct {
+		name    string
+		secrets testSecretReader
+		err     error
+	}{
+		{"no secrets", testSecretReader{}, ErrNoKeys},
+		{"empty secret", testSecretReader{DefaultKeysSecretName: "# no keys\n"}, ErrNoKeys},
+		{"no version", testSecretReader{DefaultKeysSecretName: testKey(1)}, ErrWrongKeysSecret},
+		{"zero version", testSecretReader{DefaultKeysSecretName: "0:" + testKey(1)}, ErrWrongKeysSecret},
+		{"duplicate version", testSecretReader{DefaultKeysSecretName: "1:" + testKey(1) + "\n1:" + testKey(2)}, ErrWrongKeysSecret},
+		{"wrong base64", testSecretReader{DefaultKeysSecretName: "1:!!!"}, ErrWrongKeysSecret},
+		{"wrong key length", testSecretReader{DefaultKeysSecretName: "1:" + base64.StdEncoding.EncodeToString([]byte("short"))}, ErrWrongKeysSecret},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Provide(asp, test.secrets, testingu.MockTime).AppStorage(istructs.AppQName_test1_app1)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorageenc
+
+import (
+	"context"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// Returned by Provide. Encryption is usually placed below the storage cache, so IReEncryptor of storages is
+// unreachable through the cache, re-encryption of whole application is available here.
+type IAppStorageProvider interface {
+	istorage.IAppStorageProvider
+
+	// Re-encrypts plaintext values and values written under old keys of all partitions of the application by the current key.
+	// See IReEncryptor.ReEncrypt
+	ReEncryptApp(ctx context.Context, appQName appdef.AppQName) (reEncrypted int, err error)
+}
+
+// Implemented by the encrypting istorage.IAppStorage
+type IReEncryptor interface {
+	// Re-encrypts plaintext values and values written under old keys by the current key. Clustering columns range is the same as for istorage.IAppStorage.Read.
+	// Each value is replaced by CompareAndSwap, so values written concurrently are not overwritten.
+	// Returns the number of re-encrypted values.
+	// @ConcurrentAccess
+	ReEncrypt(ctx context.Context, pKey []byte, startCCols, finishCCols []byte) (reEncrypted int, err error)
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorageenc
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/isecrets"
+	"github.com/voedger/voedger/pkg/istorage"
+)
+
+// Provides storages which encrypt values by AES-GCM with keys of the application read by secretReader.
+// Plaintext values, e.g. written before the encryption is enabled, are read as is and re-encrypted.
+// Returned storages implement IReEncryptor.
+func Provide(storageProvider istorage.IAppStorageProvider, secretReader isecrets.ISecretReader, iTime timeu.ITime) IAppStorageProvider {
+	return &implEncryptingAppStorageProvider{
+		storageProvider: storageProvider,
+		secretReader:    secretReader,
+		iTime:           iTime,
+		reEncryptQueue:  make(chan reEncryptItem, reEncryptQueueSize),
+		stop:            make(chan struct{}),
+		cache:           map[appdef.AppQName]*encryptedAppStorage{},
+	}
+}
@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package istorageenc
+
+import "crypto/cipher"
+
+type KeyVersion uint16
+
+// Keys of the certain application. Values are written under the current key, the rest of keys are used to read old values.
+type keyRing struct {
+	current KeyVersion
+	aeads   map[KeyVersion]cipher.AEAD
+}
+
+// Value written under an old key, to be re-encrypted by the current key
+type reEncryptItem struct {
+	storage *encryptedAppStorage
+	pKey    []byte
+	cCols   []byte
+	value   []byte // encrypted value as it is stored
+}
@@ -240,6 +240,9 @@ type IViewRecords interface {
 	// Zero or more fields of key.ClusteringColumns can be specified
 	// If last clustering column has variable length it can be filled partially
 	Read(ctx context.Context, workspace WSID, key IKeyBuilder, cb ValuesCallback) (err error)
+
+	// The same as Read but records are iterated in descending order of clustering columns
+	ReadReverse(ctx context.Context, workspace WSID, key IKeyBuilder, cb ValuesCallback) (err error)
 }
 
 type ViewRecordGetBatchItem struct {
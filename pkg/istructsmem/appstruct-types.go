@@ -182,6 +182,17 @@ func (cfg *AppConfigType) prepare(appStorage istorage.IAppStorage) error {
 	return nil
 }
 
+// keeps extensions, projectors, validators, jobs and parameters of the previous configuration of the redeployed application
+func (cfg *AppConfigType) inheritExtensions(prev *AppConfigType) {
+	cfg.Resources = prev.Resources
+	cfg.Params = prev.Params
+	cfg.syncProjectors = prev.syncProjectors
+	cfg.asyncProjectors = prev.asyncProjectors
+	cfg.cudValidators = prev.cudValidators
+	cfg.eventValidators = prev.eventValidators
+	cfg.jobs = prev.jobs
+}
+
 func (cfg *AppConfigType) AddSyncProjectors(pp ...istructs.Projector) {
 	for _, p := range pp {
 		cfg.syncProjectors[p.Name] = p
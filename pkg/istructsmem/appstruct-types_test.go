@@ -77,6 +77,51 @@ func TestAppConfigsType_AddBuiltInConfig(t *testing.T) {
 		require.Equal(istructs.NumAppWorkspaces(42), as.NumAppWorkspaces())
 	})
 
+	t.Run("should be ok to redeploy builtin app with new definition", func(t *testing.T) {
+		cfgs := make(AppConfigsType)
+		adb := builder.New()
+		adb.AddPackage("test", "test.com/test")
+		cmdName := appdef.NewQName("test", "cmd")
+		wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+		wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+		wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+		wsb.AddCommand(cmdName)
+		cfg := cfgs.AddBuiltInAppConfig(appName, adb)
+		cfg.SetNumAppWorkspaces(42)
+		cfg.Resources.Add(NewCommandFunction(cmdName, NullCommandExec))
+		_, storageProvider := teststore.New(appName)
+		appStructs := Provide(cfgs, testTokensFactory(), storageProvider, isequencer.SequencesTrustLevel_0, nil)
+		prev, err := appStructs.BuiltIn(appName)
+		require.NoError(err)
+
+		def := func() appdef.IAppDef {
+			adb := builder.New()
+			adb.AddPackage("test", "test.com/test")
+			wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+			wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+			wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+			wsb.AddCommand(cmdName)
+			wsb.AddCommand(appdef.NewQName("test", "newCmd"))
+			return adb.MustBuild()
+		}()
+
+		as, err := appStructs.New(appName, def, appID, prev.NumAppWorkspaces())
+		require.NoError(err)
+		require.NotSame(prev, as)
+		require.Equal(def, as.AppDef())
+		require.Equal(cmdName, as.Resources().QueryResource(cmdName).QName(), "builtin extensions should be kept")
+
+		t.Run("should be the same structures for the same definition", func(t *testing.T) {
+			as1, err := appStructs.New(appName, def, appID, prev.NumAppWorkspaces())
+			require.NoError(err)
+			require.Same(as, as1)
+
+			as2, err := appStructs.BuiltIn(appName)
+			require.NoError(err)
+			require.Same(as, as2)
+		})
+	})
+
 	t.Run("should be error to make invalid changes in appDef after add config", func(t *testing.T) {
 		cfgs := make(AppConfigsType)
 		adb := builder.New()
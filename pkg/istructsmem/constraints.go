@@ -13,6 +13,7 @@ import (
 	"sort"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
 )
 
 // Checks value by field constraints. Return error if constraints violated
@@ -30,6 +31,9 @@ func checkConstraints(fld appdef.IField, value any) (err error) {
 		err = checkNumberConstraints(fld, value.(int32))
 	case appdef.DataKind_int64:
 		err = checkNumberConstraints(fld, value.(int64))
+		if coreutils.IsCurrency(fld) {
+			err = errors.Join(err, checkCurrencyPrecision(fld, value.(int64)))
+		}
 	case appdef.DataKind_float32:
 		err = checkNumberConstraints(fld, value.(float32))
 	case appdef.DataKind_float64:
@@ -133,3 +137,12 @@ func checkNumberConstraints[T number](fld appdef.IField, value T) (err error) {
 
 	return err
 }
+
+// Checks currency value by declared precision. Return error if value has more digits
+func checkCurrencyPrecision(fld appdef.IField, value int64) error {
+	spec := coreutils.CurrencySpec(fld)
+	if _, err := spec.Check(value); err != nil {
+		return ErrDataConstraintViolation(fld, fmt.Sprintf("currency(%d,%d)", spec.Precision, spec.Scale))
+	}
+	return nil
+}
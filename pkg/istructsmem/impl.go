@@ -73,11 +73,22 @@ func (provider *appStructsProviderType) BuiltIn(appName appdef.AppQName) (struct
 }
 
 // istructs.IAppStructsProvider.New
+//
+// Returns existing application structures if they are created for the same definition already.
+// If the application is redeployed with a new definition then the extensions of the previous configuration are kept.
 func (provider *appStructsProviderType) New(name appdef.AppQName, def appdef.IAppDef, id istructs.ClusterAppID, wsCount istructs.NumAppWorkspaces) (istructs.IAppStructs, error) {
 	provider.locker.Lock()
 	defer provider.locker.Unlock()
 
+	if app, ok := provider.structures[name]; ok && app.config.AppDef == def {
+		return app, nil
+	}
+
+	prev, redeploy := provider.configs[name]
 	cfg := provider.configs.AddAppConfig(name, id, def, wsCount)
+	if redeploy {
+		cfg.inheritExtensions(prev)
+	}
 	appTokens := provider.appTokensFactory.New(name)
 	appStorage, err := provider.storageProvider.AppStorage(name)
 	if err != nil {
@@ -91,7 +102,7 @@ func (provider *appStructsProviderType) New(name appdef.AppQName, def appdef.IAp
 		appTTLStorage = provider.appTTLStorageFactory(id)
 	}
 	app := newAppStructs(cfg, appTokens, provider.seqTrustLevel, appTTLStorage)
-	//provider.structures[name] = app
+	provider.structures[name] = app
 	return app, nil
 }
 
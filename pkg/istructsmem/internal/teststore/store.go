@@ -272,7 +272,20 @@ func (s *TestMemStorage) PutBatch(items []istorage.BatchItem) (err error) {
 }
 
 func (s *TestMemStorage) Read(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
-	cbWrap := func(cCols []byte, data []byte) (err error) {
+	return s.storage.Read(ctx, pKey, startCCols, finishCCols, s.readCallback(pKey, cb))
+}
+
+func (s *TestMemStorage) ReadReverse(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) (err error) {
+	return s.storage.ReadReverse(ctx, pKey, startCCols, finishCCols, s.readCallback(pKey, cb))
+}
+
+func (s *TestMemStorage) ReadPartitionKeys(ctx context.Context, cb istorage.PartitionKeyCallback) (err error) {
+	return s.storage.ReadPartitionKeys(ctx, cb)
+}
+
+// wraps read callback to emulate read errors and data damage
+func (s *TestMemStorage) readCallback(pKey []byte, cb istorage.ReadCallback) istorage.ReadCallback {
+	return func(cCols []byte, data []byte) (err error) {
 		if s.get.err != nil {
 			if s.get.match(pKey, cCols) {
 				err = s.get.err
@@ -290,6 +303,4 @@ func (s *TestMemStorage) Read(ctx context.Context, pKey []byte, startCCols, fini
 
 		return cb(cCols, data)
 	}
-
-	return s.storage.Read(ctx, pKey, startCCols, finishCCols, cbWrap)
 }
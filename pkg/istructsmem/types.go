@@ -16,6 +16,7 @@ import (
 	"github.com/untillpro/dynobuffers"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem/internal/containers"
 	"github.com/voedger/voedger/pkg/istructsmem/internal/dynobuf"
@@ -1009,6 +1010,17 @@ func (row *rowType) PutChars(name appdef.FieldName, value string) {
 			return
 		}
 		row.PutQName(name, qName)
+	case appdef.DataKind_int64:
+		v, ok, err := coreutils.ParseSysDataValue(fld, value)
+		if !ok {
+			row.collectError(ErrWrongFieldType("can not put string to %v", fld))
+			return
+		}
+		if err != nil {
+			row.collectError(enrichError(err, "can not parse value for %v", fld))
+			return
+		}
+		row.PutInt64(name, v)
 	default:
 		row.collectError(ErrWrongFieldType("can not put string to %v", fld))
 	}
@@ -6,6 +6,8 @@
 package istructsmem
 
 import (
+	"encoding/json"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appdef/builder"
 	"github.com/voedger/voedger/pkg/appdef/constraints"
+	"github.com/voedger/voedger/pkg/coreutils/decimal"
 	"github.com/voedger/voedger/pkg/goutils/testingu/require"
 	"github.com/voedger/voedger/pkg/isequencer"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -1030,3 +1033,83 @@ func Test_CharsFieldRestricts(t *testing.T) {
 		})
 	})
 }
+
+func Test_SysDataFields(t *testing.T) {
+	require := require.New(t)
+	test := newTest()
+
+	objName := appdef.NewQName("test", "obj")
+
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(appdef.NewQName("test", "workspace"))
+	wsb.AddCDoc(appdef.NewQName("test", "WSDesc"))
+	wsb.SetDescriptor(appdef.NewQName("test", "WSDesc"))
+	wsb.AddObject(objName).
+		AddDataField("ts", appdef.SysData_Timestamp, false).
+		AddDataField("price", appdef.SysData_Currency, false).
+		AddDataField("amount", appdef.SysData_Currency, false, constraints.Precision(18), constraints.Scale(2))
+
+	cfgs := make(AppConfigsType, 1)
+	cfg := cfgs.AddBuiltInAppConfig(test.appName, adb)
+	cfg.SetNumAppWorkspaces(istructs.DefaultNumAppWorkspaces)
+
+	asp := Provide(cfgs, testTokensFactory(), simpleStorageProvider(), isequencer.SequencesTrustLevel_0, nil)
+	_, err := asp.BuiltIn(test.appName)
+	require.NoError(err)
+
+	t.Run("should be ok to put ISO-8601 and decimal strings", func(t *testing.T) {
+		row := makeObject(cfg, objName, nil)
+		row.PutFromJSON(map[appdef.FieldName]any{
+			"ts":     "2025-01-02T05:04:05.678+02:00",
+			"price":  "-12.34",
+			"amount": "9999999999999999.99",
+		})
+		obj, err := row.Build()
+		require.NoError(err)
+		require.EqualValues(1735787045678, obj.AsInt64("ts"))
+		require.EqualValues(-123400, obj.AsInt64("price"))
+		require.EqualValues(999_999_999_999_999_999, obj.AsInt64("amount"))
+	})
+
+	t.Run("should be ok to put raw int64 values", func(t *testing.T) {
+		row := makeObject(cfg, objName, nil)
+		row.PutNumber("ts", json.Number("1735787045678"))
+		row.PutNumber("price", json.Number("123400"))
+		obj, err := row.Build()
+		require.NoError(err)
+		require.EqualValues(1735787045678, obj.AsInt64("ts"))
+		require.EqualValues(123400, obj.AsInt64("price"))
+	})
+
+	t.Run("should be error on wrong strings", func(t *testing.T) {
+		for field, value := range map[string]string{
+			"ts":    "02.01.2025",
+			"price": ".+5",
+		} {
+			row := makeObject(cfg, objName, nil)
+			row.PutChars(field, value)
+			_, err := row.Build()
+			require.Error(err, require.HasAll(field, value))
+		}
+
+		row := makeObject(cfg, objName, nil)
+		row.PutChars("price", "1.23456")
+		_, err := row.Build()
+		require.Error(err, require.Is(decimal.ErrScale))
+
+		row = makeObject(cfg, objName, nil)
+		row.PutChars("amount", "1.234")
+		_, err = row.Build()
+		require.Error(err, require.Is(decimal.ErrScale), require.Has("amount"))
+	})
+
+	t.Run("should be error if out of range", func(t *testing.T) {
+		row := makeObject(cfg, objName, nil)
+		row.PutInt64("ts", appdef.MaxTimestamp+1)
+		row.PutInt64("price", -1_000_000_000_000_000)
+		row.PutInt64("amount", math.MaxInt64)
+		_, err := row.Build()
+		require.Error(err, require.Is(ErrDataConstraintViolationError), require.HasAll("ts", "price", "currency(15,4)", "amount", "currency(18,2)"))
+	})
+}
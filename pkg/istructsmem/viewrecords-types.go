@@ -207,6 +207,16 @@ func (vr *appViewRecords) PutJSON(ws istructs.WSID, j map[appdef.FieldName]any)
 
 // istructs.IViewRecords.Read
 func (vr *appViewRecords) Read(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
+	return vr.read(ctx, workspace, key, cb, vr.app.config.storage.Read)
+}
+
+// istructs.IViewRecords.ReadReverse
+func (vr *appViewRecords) ReadReverse(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
+	return vr.read(ctx, workspace, key, cb, vr.app.config.storage.ReadReverse)
+}
+
+func (vr *appViewRecords) read(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback,
+	read func(ctx context.Context, pKey []byte, startCCols, finishCCols []byte, cb istorage.ReadCallback) error) (err error) {
 
 	k := key.(*keyType)
 	if err = k.build(); err != nil {
@@ -231,7 +241,7 @@ func (vr *appViewRecords) Read(ctx context.Context, workspace istructs.WSID, key
 	}
 
 	pKey, cKey := k.storeToBytes(workspace)
-	return vr.app.config.storage.Read(ctx, pKey, cKey, utils.IncBytes(cKey), readRecord)
+	return read(ctx, pKey, cKey, utils.IncBytes(cKey), readRecord)
 }
 
 // keyType is complex key from two parts (partition key and clustering key)
@@ -350,6 +350,22 @@ func TestCore_ViewRecords(t *testing.T) {
 		require.Equal("Meat;Bread;Cake;", names, "wrong read order!")
 	})
 
+	t.Run("should be ok to read three record from WSID = 3 in reverse order", func(t *testing.T) {
+		kb := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
+		kb.PutInt64("partitionKey1", 3)
+
+		counter, names := 0, ""
+		err := viewRecords.ReadReverse(context.Background(), 3, kb, func(key istructs.IKey, value istructs.IValue) (err error) {
+			counter++
+			names += value.AsString("name") + ";"
+			return nil
+		})
+		require.NoError(err)
+
+		require.Equal(3, counter)
+		require.Equal("Cake;Bread;Meat;", names, "wrong reverse read order!")
+	})
+
 	t.Run("should be ok to read two records by short clustering key and one by full", func(t *testing.T) {
 		kb := viewRecords.KeyBuilder(appdef.NewQName("test", "viewDrinks"))
 		kb.PutInt64("partitionKey1", 2)
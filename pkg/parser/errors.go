@@ -37,6 +37,7 @@ var ErrMustBeNotNull = errors.New("field has to be NOT NULL")
 var ErrCircularReferenceInInherits = errors.New("circular reference in INHERITS")
 var ErrRegexpCheckOnlyForVarcharField = errors.New("regexp CHECK only available for varchar field")
 var ErrMaxFieldLengthTooLarge = fmt.Errorf("maximum field length is %d", appdef.MaxFieldLength)
+var ErrCurrencyPrecisionOutOfRange = fmt.Errorf("currency precision must be in [1, %d] and scale must be in [0, precision]", appdef.MaxCurrencyPrecision)
 var ErrOnlyInsertForOdocOrORecord = errors.New("only INSERT allowed for ODoc or ORecord")
 var ErrPackageWithSameNameAlreadyIncludedInApp = errors.New("package with the same name already included in application")
 var ErrStorageDeclaredOnlyInSys = errors.New("storages are only declared in sys package")
@@ -48,6 +49,16 @@ var ErrPositiveValueOnly = errors.New("positive value only allowed")
 var ErrBlobFieldOnlyInTable = errors.New("BLOB field only allowed in table")
 var ErrJobWithoutCronSchedule = errors.New("job without cron schedule is not allowed")
 var ErrQueryMustHaveReturn = errors.New("query must have a return type")
+var ErrStaticSourceMustBeRelative = errors.New("static folder source must be a relative path inside the package folder")
+var ErrTranslationTextEmpty = errors.New("translation text must not be empty")
+var ErrScheduledWebhookNotAllowed = errors.New("scheduled webhook is not allowed")
+var ErrWebhookTriggersNotAllowed = errors.New("WEBHOOK TRIGGERS are allowed in projectors only")
+var ErrWebhookSecretEmpty = errors.New("webhook secret must not be empty")
+var ErrInboundWebhookHeaderEmpty = errors.New("inbound webhook header must not be empty")
+var ErrEgressSecretEmpty = errors.New("egress secret name must not be empty")
+var ErrEgressMaxMustBePositive = errors.New("egress MAX BODY and MAX TIMEOUT must be positive")
+var errNoTriggerOperations = errors.New("no trigger operations specified")
+var errNoTriggerNames = errors.New("no triggers names specified")
 
 func ErrInvalidLocalPackageName(name string) error {
 	return fmt.Errorf("invalid local package name %s", name)
@@ -69,6 +80,30 @@ func ErrAppDoesNotDefineUseOfPackage(name string) error {
 	return fmt.Errorf("application does not define use of package %s. Check if the package is defined in IMPORT SCHEMA and parsed under the same name", name)
 }
 
+func ErrInvalidLanguage(lang string) error {
+	return fmt.Errorf("invalid language tag %s", lang)
+}
+
+func ErrTranslationRedefined(text, lang string) error {
+	return fmt.Errorf("redefinition of translation of '%s' to %s", text, lang)
+}
+
+func ErrInvalidWebhookURL(url string) error {
+	return fmt.Errorf("invalid webhook URL %s: absolute http or https URL expected", url)
+}
+
+func ErrInvalidEgressTarget(target string) error {
+	return fmt.Errorf("invalid egress target %s: 'http://host' or 'https://host' expected", target)
+}
+
+func ErrEgressRuleRedefined(target string) error {
+	return fmt.Errorf("redefinition of egress rule %s", target)
+}
+
+func ErrInvalidInboundWebhookReplyStatus(status int) error {
+	return fmt.Errorf("invalid inbound webhook reply status %d: 2xx except 204 expected", status)
+}
+
 func ErrInvalidCronSchedule(schedule string) error {
 	return fmt.Errorf("invalid cron schedule: %s", schedule)
 }
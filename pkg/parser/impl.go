@@ -5,10 +5,12 @@
 package parser
 
 import (
+	"cmp"
 	"embed"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/alecthomas/participle/v2"
@@ -383,3 +385,17 @@ func buildAppDefs(appSchema *AppSchemaAST, builder appdef.IAppDefBuilder) error
 	ctx := newBuildContext(appSchema, builder)
 	return ctx.build()
 }
+
+func staticFolders(appSchema *AppSchemaAST) (res []StaticFolder) {
+	for _, pkg := range appSchema.Packages {
+		for _, stmt := range pkg.Ast.Statements {
+			if s := stmt.Static; s != nil {
+				res = append(res, StaticFolder{PkgPath: pkg.Path, Name: string(s.Name), Source: s.Source, SPA: s.SPA})
+			}
+		}
+	}
+	slices.SortFunc(res, func(a, b StaticFolder) int {
+		return cmp.Or(strings.Compare(a.PkgPath, b.PkgPath), strings.Compare(a.Name, b.Name))
+	})
+	return res
+}
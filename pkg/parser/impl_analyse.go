@@ -6,11 +6,15 @@ package parser
 
 import (
 	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/robfig/cron/v3"
+	"golang.org/x/text/language"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/goutils/set"
@@ -107,6 +111,16 @@ func analyse(c *basicContext, packages []*PackageSchemaAST) {
 				analyzeRate(v, ictx)
 			case *LimitStmt:
 				analyzeLimit(v, ictx)
+			case *StaticStmt:
+				analyzeStatic(v, ictx)
+			case *TranslationsStmt:
+				analyzeTranslations(v, ictx)
+			case *EgressStmt:
+				analyzeEgress(v, ictx)
+			case *WebhookStmt:
+				analyzeWebhook(v, ictx)
+			case *InboundWebhookStmt:
+				analyzeInboundWebhook(v, ictx)
 			}
 		})
 	}
@@ -507,6 +521,55 @@ func analyzeStorage(u *StorageStmt, c *iterateCtx) {
 	}
 }
 
+func analyzeStatic(s *StaticStmt, c *iterateCtx) {
+	if !fs.ValidPath(s.Source) || s.Source == "." {
+		c.stmtErr(&s.Pos, ErrStaticSourceMustBeRelative)
+	}
+}
+
+func analyzeTranslations(s *TranslationsStmt, c *iterateCtx) {
+	tag, err := language.Parse(s.Language)
+	if err != nil {
+		c.stmtErr(&s.Pos, ErrInvalidLanguage(s.Language))
+		return
+	}
+	s.lang = tag.String()
+	texts := make(map[string]bool, len(s.Items))
+	for i := range s.Items {
+		item := &s.Items[i]
+		if item.Text == "" {
+			c.stmtErr(&item.Pos, ErrTranslationTextEmpty)
+			continue
+		}
+		if texts[item.Text] {
+			c.stmtErr(&item.Pos, ErrTranslationRedefined(item.Text, s.lang))
+			continue
+		}
+		texts[item.Text] = true
+	}
+}
+
+func analyzeEgress(s *EgressStmt, c *iterateCtx) {
+	for i := range s.Rules {
+		rule := &s.Rules[i]
+		u, err := url.Parse(rule.Target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.Port() != "" ||
+			u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+			c.stmtErr(&rule.Pos, ErrInvalidEgressTarget(rule.Target))
+			continue
+		}
+		rule.scheme, rule.host = u.Scheme, strings.ToLower(u.Hostname())
+		for _, secret := range rule.Secrets {
+			if secret == "" {
+				c.stmtErr(&rule.Pos, ErrEgressSecretEmpty)
+			}
+		}
+	}
+	if (s.MaxBodySize != nil && *s.MaxBodySize == 0) || (s.MaxTimeout != nil && *s.MaxTimeout == 0) {
+		c.stmtErr(&s.Pos, ErrEgressMaxMustBePositive)
+	}
+}
+
 func analyzeRate(r *RateStmt, c *iterateCtx) {
 
 	if r.Value.Variable != nil {
@@ -690,6 +753,12 @@ func analyzeDatatype(dt *DataType, c *iterateCtx, isTable bool) {
 			c.stmtErr(&bb.Pos, ErrMaxFieldLengthTooLarge)
 		}
 	}
+	cur := dt.Currency
+	if cur != nil && cur.Precision != nil {
+		if *cur.Precision == 0 || *cur.Precision > uint64(appdef.MaxCurrencyPrecision) || *cur.Scale > *cur.Precision {
+			c.stmtErr(&cur.Pos, ErrCurrencyPrecisionOutOfRange)
+		}
+	}
 
 }
 
@@ -1045,12 +1114,66 @@ func preAnalyseAlterWorkspace(u *AlterWorkspaceStmt, c *iterateCtx) {
 
 func analyzeProjector(prj *ProjectorStmt, c *iterateCtx) {
 	for i := range prj.Triggers {
-		trigger := &prj.Triggers[i]
-
-		if trigger.CronSchedule != nil {
+		if prj.Triggers[i].CronSchedule != nil {
 			c.stmtErr(&prj.Pos, ErrScheduledProjectorDeprecated)
 		}
+	}
+	analyzeProjectorTriggers(prj.Triggers, c)
+
+	checkState(prj.State, c, func(sc *StorageScope) bool { return sc.Projectors })
+	checkIntents(prj.Intents, c, func(sc *StorageScope) bool { return sc.Projectors })
+
+	prj.workspace = c.mustCurrentWorkspace()
+}
+
+func analyzeWebhook(wh *WebhookStmt, c *iterateCtx) {
+	for i := range wh.Triggers {
+		if wh.Triggers[i].CronSchedule != nil {
+			c.stmtErr(&wh.Pos, ErrScheduledWebhookNotAllowed)
+		}
+		if wh.Triggers[i].WebhookTriggers {
+			c.stmtErr(&wh.Pos, ErrWebhookTriggersNotAllowed)
+		}
+	}
+	analyzeProjectorTriggers(wh.Triggers, c)
 
+	if _, ok := appdef.WebhookURLSecret(wh.URL); ok {
+		// URL is read from the application secret on delivery
+	} else if u, err := url.Parse(wh.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		c.stmtErr(&wh.Pos, ErrInvalidWebhookURL(wh.URL))
+	}
+	if wh.Secret == "" {
+		c.stmtErr(&wh.Pos, ErrWebhookSecretEmpty)
+	}
+
+	wh.workspace = c.mustCurrentWorkspace()
+}
+
+func analyzeInboundWebhook(wh *InboundWebhookStmt, c *iterateCtx) {
+	if err := resolveInCtx(wh.Command, c, func(cmd *CommandStmt, schema *PackageSchemaAST) error {
+		wh.Command.qName = schema.NewQName(cmd.Name)
+		return nil
+	}); err != nil {
+		c.stmtErr(&wh.Command.Pos, err)
+	}
+	if wh.Header == "" {
+		c.stmtErr(&wh.Pos, ErrInboundWebhookHeaderEmpty)
+	}
+	if wh.Secret == "" {
+		c.stmtErr(&wh.Pos, ErrWebhookSecretEmpty)
+	}
+	// the reply always has a body, so 204 is not allowed
+	if s := wh.ReplyStatus; s != nil && (*s < http.StatusOK || *s >= http.StatusMultipleChoices || *s == http.StatusNoContent) {
+		c.stmtErr(&wh.Pos, ErrInvalidInboundWebhookReplyStatus(*s))
+	}
+
+	wh.workspace = c.mustCurrentWorkspace()
+}
+
+// resolves names of the triggers of projectors and webhooks
+func analyzeProjectorTriggers(triggers []ProjectorTrigger, c *iterateCtx) {
+	for i := range triggers {
+		trigger := &triggers[i]
 		for i := range trigger.QNames {
 			defQName := &trigger.QNames[i]
 			if len(trigger.TableActions) > 0 {
@@ -1121,11 +1244,6 @@ func analyzeProjector(prj *ProjectorStmt, c *iterateCtx) {
 			}
 		}
 	}
-
-	checkState(prj.State, c, func(sc *StorageScope) bool { return sc.Projectors })
-	checkIntents(prj.Intents, c, func(sc *StorageScope) bool { return sc.Projectors })
-
-	prj.workspace = c.mustCurrentWorkspace()
 }
 
 func analyzeJob(j *JobStmt, c *iterateCtx) {
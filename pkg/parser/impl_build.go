@@ -52,6 +52,10 @@ func (c *buildContext) build() error {
 		c.grantsAndRevokes,
 		c.packages,
 		c.limits,
+		c.translations,
+		c.webhooks,
+		c.inboundWebhooks,
+		c.egress,
 	}
 	for _, step := range steps {
 		if err := step(); err != nil {
@@ -84,6 +88,98 @@ func (c *buildContext) packages() error {
 	return nil
 }
 
+func (c *buildContext) translations() error {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(s *TranslationsStmt, ictx *iterateCtx) {
+			for i := range s.Items {
+				item := &s.Items[i]
+				// translations are application-wide, so packages must not translate the same text differently
+				if tr, ok := c.adb.AppDef().Translation(s.lang, item.Text); ok && tr != item.Translation {
+					c.stmtErr(&item.Pos, ErrTranslationRedefined(item.Text, s.lang))
+					continue
+				}
+				c.adb.AddTranslation(s.lang, item.Text, item.Translation)
+			}
+		})
+	}
+	return nil
+}
+
+func (c *buildContext) webhooks() error {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(wh *WebhookStmt, ictx *iterateCtx) {
+			builder := c.adb.AddWebhook(schema.NewQName(wh.Name), wh.workspace.qName(), wh.URL, wh.Secret)
+			c.addComments(wh, builder)
+			if err := addTriggerEvents(wh.Triggers, func(ops []appdef.OperationKind, flt appdef.IFilter) {
+				builder.AddEvent(ops, flt)
+			}); err != nil {
+				c.errs = append(c.errs, fmt.Errorf("%w for webhook %s", err, wh.Name))
+			}
+		})
+	}
+	return errors.Join(c.errs...)
+}
+
+// the command of the inbound webhook is granted to sys.InboundWebhook role in the workspace of the inbound webhook
+func (c *buildContext) inboundWebhooks() error {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(wh *InboundWebhookStmt, ictx *iterateCtx) {
+			verify := appdef.InboundWebhookVerify_HMAC
+			if wh.Token {
+				verify = appdef.InboundWebhookVerify_Token
+			}
+			name := schema.NewQName(wh.Name)
+			builder := c.adb.AddInboundWebhook(name, wh.workspace.qName(), wh.Command.qName, verify, wh.Header, wh.Secret)
+			c.addComments(wh, builder)
+			if wh.ReplyStatus != nil {
+				builder.SetReplyStatus(*wh.ReplyStatus)
+			}
+		})
+	}
+	return errors.Join(c.errs...)
+}
+
+// egress policy is application-wide, so rules of all packages are joined and the strictest ceilings are taken
+func (c *buildContext) egress() error {
+	targets := make(map[string]bool)
+	var maxBodySize, maxTimeout uint64
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(s *EgressStmt, ictx *iterateCtx) {
+			for i := range s.Rules {
+				rule := &s.Rules[i]
+				target := rule.scheme + "://" + rule.host
+				if targets[target] {
+					c.stmtErr(&rule.Pos, ErrEgressRuleRedefined(rule.Target))
+					continue
+				}
+				targets[target] = true
+				c.adb.AllowEgress(rule.scheme, rule.host, rule.Secrets...)
+			}
+			if s.MaxBodySize != nil && (maxBodySize == 0 || *s.MaxBodySize < maxBodySize) {
+				maxBodySize = *s.MaxBodySize
+				c.adb.SetEgressMaxBodySize(maxBodySize)
+			}
+			if s.MaxTimeout != nil && (maxTimeout == 0 || *s.MaxTimeout < maxTimeout) {
+				maxTimeout = *s.MaxTimeout
+				c.adb.SetEgressMaxTimeout(time.Duration(maxTimeout) * time.Millisecond) // nolint G115
+			}
+		})
+	}
+	return errors.Join(c.errs...)
+}
+
+// projector declared AFTER WEBHOOK TRIGGERS is triggered by the events of all WEBHOOK statements of the application.
+// Errors of the triggers are reported by webhooks()
+func (c *buildContext) addWebhookTriggers(builder appdef.IProjectorBuilder) {
+	for _, schema := range c.app.Packages {
+		iteratePackageStmt(schema, &c.basicContext, func(wh *WebhookStmt, ictx *iterateCtx) {
+			_ = addTriggerEvents(wh.Triggers, func(ops []appdef.OperationKind, flt appdef.IFilter) {
+				builder.Events().Add(ops, flt, fmt.Sprintf("webhook %s", schema.NewQName(wh.Name)))
+			})
+		})
+	}
+}
+
 func (c *buildContext) rates() error {
 	for _, schema := range c.app.Packages {
 		iteratePackageStmt(schema, &c.basicContext, func(rate *RateStmt, ictx *iterateCtx) {
@@ -123,6 +219,13 @@ func (c *buildContext) rates() error {
 				}
 			}
 			wsb.AddRate(schema.NewQName(rate.Name), rate.Value.count, period, rateScopes, rate.Comments...)
+			if rate.Buckets != nil {
+				if rate.Buckets.SlidingWindow {
+					wsb.SetRateBuckets(schema.NewQName(rate.Name), appdef.RateBuckets_SlidingWindow)
+				} else {
+					wsb.SetRateBuckets(schema.NewQName(rate.Name), appdef.RateBuckets_Distributed)
+				}
+			}
 		})
 	}
 	return nil
@@ -411,67 +514,15 @@ func (c *buildContext) projectors() error {
 
 			wsb := proj.workspace.mustBuilder(c)
 			builder := wsb.AddProjector(pQname)
-			// Triggers
-			for _, trigger := range proj.Triggers {
-				ops := make([]appdef.OperationKind, 0)
-				if trigger.ExecuteAction != nil {
-					if trigger.ExecuteAction.WithParam {
-						ops = append(ops, appdef.OperationKind_ExecuteWithParam)
-					} else {
-						ops = append(ops, appdef.OperationKind_Execute)
-					}
-				} else {
-					if trigger.insert() {
-						ops = append(ops, appdef.OperationKind_Insert)
-					}
-					if trigger.update() {
-						ops = append(ops, appdef.OperationKind_Update)
-					}
-					if trigger.activate() {
-						ops = append(ops, appdef.OperationKind_Activate)
-					}
-					if trigger.deactivate() {
-						ops = append(ops, appdef.OperationKind_Deactivate)
-					}
-				}
-				if len(ops) == 0 {
-					c.errs = append(c.errs, fmt.Errorf("no trigger operations specified for projector %s", proj.Name))
-					return
-				}
-
-				flt := []appdef.IFilter{}
-				qNames := appdef.QNames{}
-				types := []appdef.TypeKind{}
-				for _, n := range trigger.QNames {
-					switch n.qName {
-					case istructs.QNameCRecord:
-						types = append(types, appdef.TypeKind_CDoc, appdef.TypeKind_CRecord)
-					case istructs.QNameWRecord:
-						types = append(types, appdef.TypeKind_WDoc, appdef.TypeKind_WRecord)
-					case istructs.QNameODoc:
-						types = append(types, appdef.TypeKind_ODoc)
-					default:
-						qNames.Add(n.qName)
-					}
-				} //Trigger qNames
-
-				if len(qNames) > 0 {
-					flt = append(flt, filter.QNames(qNames...))
-				}
-				if len(types) > 0 {
-					flt = append(flt, filter.Types(types...))
-				}
-
-				switch len(flt) {
-				case 0:
-					c.errs = append(c.errs, fmt.Errorf("no triggers names specified for projector %s", proj.Name))
-					return
-				case 1:
-					builder.Events().Add(ops, flt[0])
-				default:
-					builder.Events().Add(ops, filter.Or(flt...))
-				}
-			} //Triggers
+			if err := addTriggerEvents(proj.Triggers, func(ops []appdef.OperationKind, flt appdef.IFilter) {
+				builder.Events().Add(ops, flt)
+			}); err != nil {
+				c.errs = append(c.errs, fmt.Errorf("%w for projector %s", err, proj.Name))
+				return
+			}
+			if proj.webhookTriggers() {
+				c.addWebhookTriggers(builder)
+			}
 
 			if proj.IncludingErrors {
 				builder.SetWantErrors()
@@ -496,6 +547,72 @@ func (c *buildContext) projectors() error {
 	return errors.Join(c.errs...)
 }
 
+// calls add for the operations and the filter of each trigger
+func addTriggerEvents(triggers []ProjectorTrigger, add func([]appdef.OperationKind, appdef.IFilter)) error {
+	for _, trigger := range triggers {
+		if trigger.WebhookTriggers {
+			continue
+		}
+		ops := make([]appdef.OperationKind, 0)
+		if trigger.ExecuteAction != nil {
+			if trigger.ExecuteAction.WithParam {
+				ops = append(ops, appdef.OperationKind_ExecuteWithParam)
+			} else {
+				ops = append(ops, appdef.OperationKind_Execute)
+			}
+		} else {
+			if trigger.insert() {
+				ops = append(ops, appdef.OperationKind_Insert)
+			}
+			if trigger.update() {
+				ops = append(ops, appdef.OperationKind_Update)
+			}
+			if trigger.activate() {
+				ops = append(ops, appdef.OperationKind_Activate)
+			}
+			if trigger.deactivate() {
+				ops = append(ops, appdef.OperationKind_Deactivate)
+			}
+		}
+		if len(ops) == 0 {
+			return errNoTriggerOperations
+		}
+
+		flt := []appdef.IFilter{}
+		qNames := appdef.QNames{}
+		types := []appdef.TypeKind{}
+		for _, n := range trigger.QNames {
+			switch n.qName {
+			case istructs.QNameCRecord:
+				types = append(types, appdef.TypeKind_CDoc, appdef.TypeKind_CRecord)
+			case istructs.QNameWRecord:
+				types = append(types, appdef.TypeKind_WDoc, appdef.TypeKind_WRecord)
+			case istructs.QNameODoc:
+				types = append(types, appdef.TypeKind_ODoc)
+			default:
+				qNames.Add(n.qName)
+			}
+		} //Trigger qNames
+
+		if len(qNames) > 0 {
+			flt = append(flt, filter.QNames(qNames...))
+		}
+		if len(types) > 0 {
+			flt = append(flt, filter.Types(types...))
+		}
+
+		switch len(flt) {
+		case 0:
+			return errNoTriggerNames
+		case 1:
+			add(ops, flt[0])
+		default:
+			add(ops, filter.Or(flt...))
+		}
+	}
+	return nil
+}
+
 func (c *buildContext) views() error {
 	for _, schema := range c.app.Packages {
 		iteratePackageStmt(schema, &c.basicContext, func(view *ViewStmt, ictx *iterateCtx) {
@@ -517,6 +634,8 @@ func (c *buildContext) views() error {
 					if (f.Type.Varchar != nil) && (f.Type.Varchar.MaxLen != nil) {
 						cc = append(cc, constraints.MaxLen(uint16(*f.Type.Varchar.MaxLen))) // nolint G115: checked in [analyseFields]
 					}
+				case appdef.DataKind_int64:
+					cc = append(cc, dataTypeConstraints(f.Type)...)
 				}
 				return cc
 			}
@@ -545,8 +664,7 @@ func (c *buildContext) views() error {
 					}
 				}
 				if f.Field != nil {
-					k := dataTypeToDataKind(f.Field.Type)
-					vb().Key().ClustCols().AddDataField(string(f.Field.Name.Value), appdef.SysDataName(k), resolveConstraints(f.Field)...)
+					vb().Key().ClustCols().AddDataField(string(f.Field.Name.Value), dataTypeToDataName(f.Field.Type), resolveConstraints(f.Field)...)
 					comment(f.Field.Name.Value, f.Field.Statement)
 					return
 				}
@@ -563,8 +681,7 @@ func (c *buildContext) views() error {
 					}
 				}
 				if f.Field != nil {
-					k := dataTypeToDataKind(f.Field.Type)
-					vb().Value().AddDataField(string(f.Field.Name.Value), appdef.SysDataName(k), f.Field.NotNull, resolveConstraints(f.Field)...)
+					vb().Value().AddDataField(string(f.Field.Name.Value), dataTypeToDataName(f.Field.Type), f.Field.NotNull, resolveConstraints(f.Field)...)
 					comment(f.Field.Name.Value, f.Field.Statement)
 					return
 				}
@@ -740,7 +857,6 @@ func (c *buildContext) addDataTypeField(field *FieldExpr) {
 
 	bld := c.defCtx().defBuilder.(appdef.IFieldsBuilder)
 	fieldName := appdef.FieldName(field.Name)
-	sysDataKind := dataTypeToDataKind(*field.Type.DataType)
 
 	if field.Type.DataType.Bytes != nil {
 		if field.Type.DataType.Bytes.MaxLen != nil {
@@ -760,7 +876,7 @@ func (c *buildContext) addDataTypeField(field *FieldExpr) {
 	} else if field.Type.DataType.Blob {
 		bld.AddRefField(fieldName, field.NotNull, QNameWDocBLOB)
 	} else {
-		bld.AddField(fieldName, sysDataKind, field.NotNull)
+		bld.AddDataField(fieldName, dataTypeToDataName(*field.Type.DataType), field.NotNull, dataTypeConstraints(*field.Type.DataType)...)
 	}
 
 	if field.Verifiable {
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package parser
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+var (
+	defQNameType  = reflect.TypeFor[DefQName]()
+	namedStmtType = reflect.TypeFor[INamedStatement]()
+)
+
+func packageSymbols(pkg *PackageSchemaAST) (decls []Symbol, usages []SymbolUsage) {
+	if pkg == nil || pkg.Ast == nil {
+		return nil, nil
+	}
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Struct:
+			if v.Type() == defQNameType {
+				n := v.Interface().(DefQName)
+				usages = append(usages, SymbolUsage{
+					Pos:     n.Pos,
+					PkgPath: symbolUsagePkgPath(pkg, n.Package),
+					Name:    string(n.Name),
+				})
+				return
+			}
+			if v.CanAddr() && v.Addr().Type().Implements(namedStmtType) {
+				stmt := v.Addr().Interface().(INamedStatement)
+				decls = append(decls, Symbol{
+					Kind:     symbolKind(v.Type()),
+					PkgPath:  pkg.Path,
+					Name:     stmt.GetName(),
+					Pos:      *stmt.GetPos(),
+					Comments: stmt.GetComments(),
+				})
+			}
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).IsExported() {
+					walk(v.Field(i))
+				}
+			}
+		}
+	}
+	walk(reflect.ValueOf(pkg.Ast))
+	return decls, usages
+}
+
+// returns full path of the used package, empty string if package is unknown
+func symbolUsagePkgPath(pkg *PackageSchemaAST, pkgName Ident) string {
+	switch pkgName {
+	case "":
+		return pkg.Path
+	case appdef.SysPackage:
+		return appdef.SysPackage
+	}
+	if pkgName == Ident(pkg.Name) {
+		return pkg.Path
+	}
+	return GetQualifiedPackageName(pkgName, pkg.Ast)
+}
+
+// returns statement kind, e.g. "TABLE" for TableStmt
+func symbolKind(t reflect.Type) string {
+	return strings.ToUpper(strings.TrimSuffix(t.Name(), "Stmt"))
+}
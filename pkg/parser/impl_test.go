@@ -8,6 +8,7 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/acl"
 	"github.com/voedger/voedger/pkg/appdef/builder"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
@@ -760,6 +762,32 @@ func Test_Varchar(t *testing.T) {
 
 }
 
+func Test_CurrencyPrecision(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("file1.vsql", `APPLICATION test(); WORKSPACE MyWorkspace(
+	TYPE RootType (
+		Zero currency(0,0),
+		Huge currency(19,2),
+		Scale money(4,5),
+		Ok currency(18,18)
+	););
+	`)
+	require.NoError(err)
+	pkg, err := BuildPackageSchema("pkg/test", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	_, err = BuildAppSchema([]*PackageSchemaAST{
+		getSysPackageAST(),
+		pkg,
+	})
+	require.EqualError(err, strings.Join([]string{
+		fmt.Sprintf("file1.vsql:3:8: currency precision must be in [1, %d] and scale must be in [0, precision]", appdef.MaxCurrencyPrecision),
+		fmt.Sprintf("file1.vsql:4:8: currency precision must be in [1, %d] and scale must be in [0, precision]", appdef.MaxCurrencyPrecision),
+		fmt.Sprintf("file1.vsql:5:9: currency precision must be in [1, %d] and scale must be in [0, precision]", appdef.MaxCurrencyPrecision),
+	}, "\n"))
+}
+
 func Test_DupFieldsInTables(t *testing.T) {
 	require := require.New(t)
 
@@ -2777,6 +2805,27 @@ func Test_RatesAndLimits(t *testing.T) {
 
 }
 
+func Test_RateBuckets(t *testing.T) {
+	require := assertions(t)
+
+	app := require.Build(`APPLICATION app1();
+	WORKSPACE W(
+		RATE r1 1 PER HOUR;
+		RATE r2 3 PER MINUTE PER WORKSPACE PER IP DISTRIBUTED;
+		RATE r3 10 PER DAY PER SUBJECT DISTRIBUTED SLIDING WINDOW;
+	);`)
+
+	ws := app.Workspace(appdef.NewQName("pkg", "W"))
+	require.Equal(appdef.RateBuckets_Local, appdef.Rate(ws.Type, appdef.NewQName("pkg", "r1")).Buckets())
+
+	r2 := appdef.Rate(ws.Type, appdef.NewQName("pkg", "r2"))
+	require.Equal(appdef.RateBuckets_Distributed, r2.Buckets())
+	require.EqualValues(3, r2.Count())
+	require.Equal([]appdef.RateScope{appdef.RateScope_Workspace, appdef.RateScope_IP}, r2.Scopes())
+
+	require.Equal(appdef.RateBuckets_SlidingWindow, appdef.Rate(ws.Type, appdef.NewQName("pkg", "r3")).Buckets())
+}
+
 func Test_RefsFromInheritedWs(t *testing.T) {
 	require := assertions(t)
 
@@ -2939,6 +2988,7 @@ func Test_DataTypes(t *testing.T) {
 	t.Run("String", func(t *testing.T) {
 		varcharMaxLen := uint64(10)
 		bytesMaxLen := uint64(20)
+		precision, scale := uint64(18), uint64(2)
 
 		cases := []struct {
 			name string
@@ -2959,7 +3009,8 @@ func Test_DataTypes(t *testing.T) {
 			{name: "bytes default max len", typ: DataType{Bytes: &TypeBytes{}}, want: fmt.Sprintf("bytes[%d]", appdef.DefaultFieldMaxLength)},
 			{name: "blob", typ: DataType{Blob: true}, want: "blob"},
 			{name: "timestamp", typ: DataType{Timestamp: true}, want: "timestamp"},
-			{name: "currency", typ: DataType{Currency: true}, want: "currency"},
+			{name: "currency", typ: DataType{Currency: &TypeCurrency{}}, want: "currency"},
+			{name: "currency with precision and scale", typ: DataType{Currency: &TypeCurrency{Precision: &precision, Scale: &scale}}, want: "currency(18,2)"},
 			{name: "unknown", typ: DataType{}, want: "?"},
 		}
 
@@ -3006,6 +3057,8 @@ ALTER WORKSPACE sys.AppWorkspaceWS (
 
 		s7_2 money,
 		s7_3 currency,
+		s7_4 currency(18, 2),
+		s7_5 money(5,0),
 
 		s8_1 boolean,
 		s8_2 bool,
@@ -3020,7 +3073,9 @@ ALTER WORKSPACE sys.AppWorkspaceWS (
 		s11_2 smallint,
 
 		s12_1 int8,
-		s12_2 tinyint
+		s12_2 tinyint,
+
+		s13_1 timestamp
 	);
 );`)
 	require.NoError(err)
@@ -3060,9 +3115,23 @@ ALTER WORKSPACE sys.AppWorkspaceWS (
 	require.Equal(appdef.DataKind_bool, tbl.Field("s8_1").DataKind())
 	require.Equal(appdef.DataKind_bool, tbl.Field("s8_2").DataKind())
 
+	// timestamp
+	require.Equal(appdef.DataKind_int64, tbl.Field("s13_1").DataKind())
+	require.Equal(appdef.SysData_Timestamp, tbl.Field("s13_1").Data().QName())
+
 	//money
 	require.Equal(appdef.DataKind_int64, tbl.Field("s7_2").DataKind())
 	require.Equal(appdef.DataKind_int64, tbl.Field("s7_3").DataKind())
+	require.Equal(appdef.SysData_Currency, tbl.Field("s7_2").Data().QName())
+	require.Equal(appdef.SysData_Currency, tbl.Field("s7_3").Data().QName())
+	require.EqualValues(appdef.DefaultCurrencyScale, tbl.Field("s7_3").Constraints()[appdef.ConstraintKind_Scale].Value())
+	for name, want := range map[string][2]uint16{"s7_4": {18, 2}, "s7_5": {5, 0}} {
+		f := tbl.Field(name)
+		require.Equal(appdef.DataKind_int64, f.DataKind())
+		require.True(appdef.DataInherits(f.Data(), appdef.SysData_Currency))
+		require.EqualValues(want[0], f.Constraints()[appdef.ConstraintKind_Precision].Value())
+		require.EqualValues(want[1], f.Constraints()[appdef.ConstraintKind_Scale].Value())
+	}
 
 	// float64
 	require.Equal(appdef.DataKind_float64, tbl.Field("s6_1").DataKind())
@@ -3228,6 +3297,7 @@ func TestIsOperationAllowedOnNestedTable(t *testing.T) {
 				WASMConfig:         iextengine.WASMFactoryConfig{Compile: false},
 			}, "vvmName", imetrics.Provide()),
 		iratesce.TestBucketsFactory,
+		appparts.NullCompatibilityChecker,
 	)
 	require.NoError(err)
 	defer func() {
@@ -3291,6 +3361,7 @@ func TestIsOperationAllowedOnGrantRoleToRole(t *testing.T) {
 				WASMConfig:         iextengine.WASMFactoryConfig{Compile: false},
 			}, "vvmName", imetrics.Provide()),
 		iratesce.TestBucketsFactory,
+		appparts.NullCompatibilityChecker,
 	)
 	require.NoError(err)
 	defer func() {
@@ -3450,3 +3521,349 @@ func Test_NotAllowedTypes(t *testing.T) {
 			"file.vsql:7:13: t02: reference to WDoc/WRecord")
 	})
 }
+
+func Test_PackageSymbols(t *testing.T) {
+	require := require.New(t)
+
+	fs, err := ParseFile("file.vsql", `IMPORT SCHEMA 'github.com/company/pkg2' AS p2;
+	APPLICATION test();
+	WORKSPACE MyWS (
+		-- My table comment
+		TABLE MyTable INHERITS sys.CDoc (
+			Ref1 ref(p2.Table2)
+		);
+		ROLE MyRole;
+		GRANT SELECT ON TABLE MyTable TO MyRole;
+	);`)
+	require.NoError(err)
+	pkg, err := BuildPackageSchema("github.com/company/pkg1", []*FileSchemaAST{fs})
+	require.NoError(err)
+
+	decls, usages := PackageSymbols(pkg)
+
+	declared := map[string]Symbol{}
+	for _, d := range decls {
+		declared[d.Kind+" "+d.Name] = d
+	}
+	require.Contains(declared, "WORKSPACE MyWS")
+	require.Contains(declared, "ROLE MyRole")
+	require.Contains(declared, "TABLE MyTable")
+	require.Equal("github.com/company/pkg1", declared["TABLE MyTable"].PkgPath)
+	require.Equal([]string{"My table comment"}, declared["TABLE MyTable"].Comments)
+	require.Equal(5, declared["TABLE MyTable"].Pos.Line)
+
+	used := map[string]SymbolUsage{}
+	for _, u := range usages {
+		used[u.PkgPath+"."+u.Name] = u
+	}
+	require.Contains(used, "sys.CDoc")
+	require.Contains(used, "github.com/company/pkg2.Table2")
+	require.Equal(6, used["github.com/company/pkg2.Table2"].Pos.Line)
+	require.Contains(used, "github.com/company/pkg1.MyTable")
+	require.Contains(used, "github.com/company/pkg1.MyRole")
+
+	t.Run("should be no symbols for nil package", func(t *testing.T) {
+		decls, usages := PackageSymbols(nil)
+		require.Empty(decls)
+		require.Empty(usages)
+	})
+}
+
+func Test_StaticFolders(t *testing.T) {
+
+	t.Run("static folders", func(t *testing.T) {
+		require := assertions(t)
+		app, err := require.AppSchema(`APPLICATION test();
+			STATIC Web SOURCE 'web/dist' SPA;
+			STATIC Docs SOURCE 'docs';
+			WORKSPACE MyWS ();`)
+		require.NoError(err)
+		require.Equal([]StaticFolder{
+			{PkgPath: "github.com/company/pkg", Name: "Docs", Source: "docs"},
+			{PkgPath: "github.com/company/pkg", Name: "Web", Source: "web/dist", SPA: true},
+		}, StaticFolders(app))
+	})
+
+	t.Run("source must be relative", func(t *testing.T) {
+		for _, source := range []string{"/web", "../web", "web/../../web", ".", ""} {
+			require := assertions(t)
+			require.AppSchemaError(fmt.Sprintf(`APPLICATION test();
+			STATIC Web SOURCE '%s';`, source), "file.vsql:2:4: static folder source must be a relative path inside the package folder")
+		}
+	})
+
+	t.Run("redefinition", func(t *testing.T) {
+		require := require.New(t)
+		fs, err := ParseFile("file.vsql", `APPLICATION test();
+			STATIC Web SOURCE 'web';
+			STATIC Web SOURCE 'web2';`)
+		require.NoError(err)
+		_, err = BuildPackageSchema("github.com/company/pkg", []*FileSchemaAST{fs})
+		require.EqualError(err, "file.vsql:3:4: redefinition of Web")
+	})
+}
+
+func Test_Translations(t *testing.T) {
+
+	t.Run("translations", func(t *testing.T) {
+		require := assertions(t)
+		app := require.Build(`APPLICATION test();
+			TRANSLATIONS Dutch LANGUAGE 'nl' (
+				'Hello' = 'Hallo',
+				'Goodbye' = 'Tot ziens',
+			);
+			TRANSLATIONS Brazilian LANGUAGE 'pt-br' (
+				'Hello' = 'Olá'
+			);
+			WORKSPACE MyWS ();`)
+		require.Equal([]string{"nl", "pt-BR"}, app.Languages())
+		tr, ok := app.Translation("nl", "Goodbye")
+		require.True(ok)
+		require.Equal("Tot ziens", tr)
+		tr, ok = app.Translation("pt-BR", "Hello")
+		require.True(ok)
+		require.Equal("Olá", tr)
+		_, ok = app.Translation("pt-BR", "Goodbye")
+		require.False(ok)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+			TRANSLATIONS Unknown LANGUAGE 'not a language' ('Hello' = 'Hallo');`,
+			"file.vsql:2:4: invalid language tag not a language")
+		require.AppSchemaError(`APPLICATION test();
+			TRANSLATIONS Dutch LANGUAGE 'nl' (
+				'Hello' = 'Hallo',
+				'' = 'Leeg',
+				'Hello' = 'Hoi'
+			);`,
+			"file.vsql:4:5: translation text must not be empty",
+			"file.vsql:5:5: redefinition of translation of 'Hello' to nl")
+	})
+
+	t.Run("packages must not translate the same text differently", func(t *testing.T) {
+		require := require.New(t)
+		fs, err := ParseFile("file1.vsql", `IMPORT SCHEMA 'test/pkg1';
+			APPLICATION test(
+				USE pkg1;
+			);
+			TRANSLATIONS Dutch LANGUAGE 'nl' ('Hello' = 'Hallo', 'Goodbye' = 'Tot ziens');`)
+		require.NoError(err)
+		pkg, err := BuildPackageSchema("test/pkg2", []*FileSchemaAST{fs})
+		require.NoError(err)
+
+		fs2, err := ParseFile("file2.vsql", `
+			TRANSLATIONS Dutch LANGUAGE 'NL' ('Hello' = 'Hoi', 'Goodbye' = 'Tot ziens');`)
+		require.NoError(err)
+		pkg2, err := BuildPackageSchema("test/pkg1", []*FileSchemaAST{fs2})
+		require.NoError(err)
+
+		packages, err := BuildAppSchema([]*PackageSchemaAST{
+			getSysPackageAST(),
+			pkg,
+			pkg2,
+		})
+		require.NoError(err)
+
+		err = BuildAppDefs(packages, builder.New())
+		require.ErrorContains(err, "redefinition of translation of 'Hello' to nl")
+	})
+}
+
+func Test_Egress(t *testing.T) {
+
+	t.Run("egress", func(t *testing.T) {
+		require := assertions(t)
+		app := require.Build(`APPLICATION test();
+			EGRESS Payments (
+				ALLOW 'https://API.Example.com' SECRETS ('api-key', 'api-token'),
+				ALLOW 'https://*.example.org/',
+			) MAX BODY 1048576 MAX TIMEOUT 30000;
+			EGRESS Strict (
+				ALLOW 'http://example.net'
+			) MAX TIMEOUT 5000;
+			WORKSPACE MyWS ();`)
+		p := app.EgressPolicy()
+		require.NotNil(p)
+		require.Len(p.Rules(), 3)
+		r := p.Rule("https", "api.example.com")
+		require.NotNil(r)
+		require.Equal([]string{"api-key", "api-token"}, r.Secrets())
+		require.NotNil(p.Rule("https", "www.example.org"))
+		require.Nil(p.Rule("https", "example.net"))
+		require.EqualValues(1048576, p.MaxBodySize())
+		require.Equal(5*time.Second, p.MaxTimeout(), "should be the strictest timeout")
+	})
+
+	t.Run("no egress", func(t *testing.T) {
+		require := assertions(t)
+		app := require.Build(`APPLICATION test();
+			WORKSPACE MyWS ();`)
+		require.Nil(app.EgressPolicy())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+			EGRESS Bad (
+				ALLOW 'ftp://example.com',
+				ALLOW 'https://example.com:8080',
+				ALLOW 'https://example.com/path',
+				ALLOW 'https://example.com' SECRETS ('')
+			) MAX BODY 0;`,
+			"file.vsql:3:5: invalid egress target ftp://example.com: 'http://host' or 'https://host' expected",
+			"file.vsql:4:5: invalid egress target https://example.com:8080: 'http://host' or 'https://host' expected",
+			"file.vsql:5:5: invalid egress target https://example.com/path: 'http://host' or 'https://host' expected",
+			"file.vsql:6:5: egress secret name must not be empty",
+			"file.vsql:2:4: egress MAX BODY and MAX TIMEOUT must be positive")
+		schema, err := require.AppSchema(`APPLICATION test();
+			EGRESS First (ALLOW 'https://example.com');
+			EGRESS Second (
+				ALLOW 'https://EXAMPLE.com'
+			);`)
+		require.NoError(err)
+		err = BuildAppDefs(schema, builder.New())
+		require.ErrorContains(err, "file.vsql:4:5: redefinition of egress rule https://EXAMPLE.com")
+	})
+}
+
+func Test_Webhooks(t *testing.T) {
+
+	t.Run("webhooks", func(t *testing.T) {
+		require := assertions(t)
+		app := require.Build(`APPLICATION test();
+			WORKSPACE MyWS (
+				TABLE Orders INHERITS sys.CDoc ();
+				TABLE Receipt INHERITS sys.ODoc ();
+				EXTENSION ENGINE BUILTIN (
+					COMMAND MakeOrder();
+				);
+				-- notifies the shop
+				WEBHOOK OrderHook
+					AFTER EXECUTE ON (MakeOrder) OR
+					AFTER INSERT OR UPDATE ON (Orders) OR
+					AFTER EXECUTE WITH PARAM ON Receipt
+					URL 'https://example.com/hooks/orders' SECRET 'order-hook-secret';
+				WEBHOOK ReceiptHook AFTER INSERT ON (Receipt) URL '{{secret:receipt-hook-url}}' SECRET 'receipt-hook-secret';
+				EXTENSION ENGINE BUILTIN (
+					PROJECTOR Dispatcher AFTER EXECUTE ON (MakeOrder) OR AFTER WEBHOOK TRIGGERS;
+				);
+			);`)
+		wh := app.Webhook(appdef.NewQName("pkg", "OrderHook"))
+		require.NotNil(wh)
+		require.Equal(appdef.NewQName("pkg", "MyWS"), wh.Workspace())
+		require.Equal("https://example.com/hooks/orders", wh.URL())
+		require.Equal("order-hook-secret", wh.Secret())
+		require.Equal("notifies the shop", wh.Comment())
+		require.Len(wh.Events(), 3)
+
+		require.True(wh.Triggers(appdef.OperationKind_Execute, app.Type(appdef.NewQName("pkg", "MakeOrder"))))
+		require.True(wh.Triggers(appdef.OperationKind_Update, app.Type(appdef.NewQName("pkg", "Orders"))))
+		require.False(wh.Triggers(appdef.OperationKind_Deactivate, app.Type(appdef.NewQName("pkg", "Orders"))))
+		require.True(wh.Triggers(appdef.OperationKind_ExecuteWithParam, app.Type(appdef.NewQName("pkg", "Receipt"))))
+
+		wh = app.Webhook(appdef.NewQName("pkg", "ReceiptHook"))
+		require.Equal("{{secret:receipt-hook-url}}", wh.URL())
+		require.Len(app.Webhooks(), 2)
+
+		t.Run("projector should be triggered by events of all webhooks", func(t *testing.T) {
+			prj := appdef.Projector(app.Type, appdef.NewQName("pkg", "Dispatcher"))
+			require.NotNil(prj)
+			require.True(prj.Triggers(appdef.OperationKind_Execute, app.Type(appdef.NewQName("pkg", "MakeOrder"))))
+			require.True(prj.Triggers(appdef.OperationKind_Update, app.Type(appdef.NewQName("pkg", "Orders"))))
+			require.False(prj.Triggers(appdef.OperationKind_Deactivate, app.Type(appdef.NewQName("pkg", "Orders"))))
+			require.True(prj.Triggers(appdef.OperationKind_ExecuteWithParam, app.Type(appdef.NewQName("pkg", "Receipt"))))
+			require.True(prj.Triggers(appdef.OperationKind_Insert, app.Type(appdef.NewQName("pkg", "Receipt"))))
+			require.False(prj.Triggers(appdef.OperationKind_Insert, app.Type(appdef.NewQName("pkg", "MyWS"))))
+		})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+			WORKSPACE MyWS (
+				TABLE Orders INHERITS sys.CDoc ();
+				WEBHOOK Hook1 AFTER INSERT ON (Unknown) URL 'https://example.com' SECRET 'secret';
+				WEBHOOK Hook2 AFTER INSERT ON (Orders) URL 'ftp://example.com' SECRET 'secret';
+				WEBHOOK Hook3 AFTER INSERT ON (Orders) URL 'example.com/hook' SECRET '';
+				WEBHOOK Hook4 CRON '1 0 * * *' URL 'https://example.com' SECRET 'secret';
+				WEBHOOK Hook5 AFTER WEBHOOK TRIGGERS URL 'https://example.com' SECRET 'secret';
+				WEBHOOK Hook6 AFTER INSERT ON (Orders) URL 'https://{{secret:host}}/hook' SECRET 'secret';
+			);`,
+			"file.vsql:4:36: undefined table: Unknown",
+			"file.vsql:5:5: invalid webhook URL ftp://example.com: absolute http or https URL expected",
+			"file.vsql:6:5: invalid webhook URL example.com/hook: absolute http or https URL expected",
+			"file.vsql:6:5: webhook secret must not be empty",
+			"file.vsql:7:5: scheduled webhook is not allowed",
+			"file.vsql:8:5: WEBHOOK TRIGGERS are allowed in projectors only",
+			"file.vsql:9:5: invalid webhook URL https://{{secret:host}}/hook: absolute http or https URL expected")
+	})
+}
+
+func Test_InboundWebhooks(t *testing.T) {
+
+	t.Run("inbound webhooks", func(t *testing.T) {
+		require := assertions(t)
+		app := require.Build(`APPLICATION test();
+			WORKSPACE MyWS (
+				TYPE PaymentParams (Amount int64);
+				EXTENSION ENGINE BUILTIN (
+					COMMAND ProcessPayment(PaymentParams);
+					COMMAND ProcessDelivery(sys.Raw);
+				);
+				-- callbacks of the payment provider
+				INBOUND WEBHOOK PaymentHook EXECUTE ON COMMAND ProcessPayment
+					VERIFY HMAC HEADER 'X-Signature' SECRET 'payment-secret' REPLY 202;
+				INBOUND WEBHOOK DeliveryHook EXECUTE ON COMMAND ProcessDelivery
+					VERIFY TOKEN HEADER 'X-Token' SECRET 'delivery-secret';
+				GRANT EXECUTE ON COMMAND ProcessPayment TO sys.InboundWebhook;
+			);`)
+		wsName := appdef.NewQName("pkg", "MyWS")
+		cmdName := appdef.NewQName("pkg", "ProcessPayment")
+
+		wh := app.InboundWebhook(appdef.NewQName("pkg", "PaymentHook"))
+		require.NotNil(wh)
+		require.Equal(wsName, wh.Workspace())
+		require.Equal(cmdName, wh.Command())
+		require.Equal(appdef.InboundWebhookVerify_HMAC, wh.Verify())
+		require.Equal("X-Signature", wh.Header())
+		require.Equal("payment-secret", wh.Secret())
+		require.Equal(http.StatusAccepted, wh.ReplyStatus())
+		require.Equal("callbacks of the payment provider", wh.Comment())
+
+		wh = app.InboundWebhook(appdef.NewQName("pkg", "DeliveryHook"))
+		require.NotNil(wh)
+		require.Equal(appdef.InboundWebhookVerify_Token, wh.Verify())
+		require.Equal(http.StatusOK, wh.ReplyStatus())
+		require.Len(app.InboundWebhooks(), 2)
+
+		// the command is executed by sys.InboundWebhook if granted in VSQL only
+		ws := app.Workspace(wsName)
+		ok, err := acl.IsOperationAllowed(ws, appdef.OperationKind_Execute, cmdName, nil, []appdef.QName{appdef.QNameRoleInboundWebhook})
+		require.NoError(err)
+		require.True(ok)
+		ok, err = acl.IsOperationAllowed(ws, appdef.OperationKind_Execute, appdef.NewQName("pkg", "ProcessDelivery"), nil, []appdef.QName{appdef.QNameRoleInboundWebhook})
+		require.NoError(err)
+		require.False(ok)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require := assertions(t)
+		require.AppSchemaError(`APPLICATION test();
+			WORKSPACE MyWS (
+				EXTENSION ENGINE BUILTIN (
+					COMMAND Cmd();
+				);
+				INBOUND WEBHOOK Hook1 EXECUTE ON COMMAND Unknown VERIFY HMAC HEADER 'X-Signature' SECRET 'secret';
+				INBOUND WEBHOOK Hook2 EXECUTE ON COMMAND Cmd VERIFY TOKEN HEADER '' SECRET '';
+				INBOUND WEBHOOK Hook3 EXECUTE ON COMMAND Cmd VERIFY HMAC HEADER 'X-Signature' SECRET 'secret' REPLY 204;
+				INBOUND WEBHOOK Hook4 EXECUTE ON COMMAND Cmd VERIFY HMAC HEADER 'X-Signature' SECRET 'secret' REPLY 400;
+			);`,
+			"file.vsql:6:46: undefined command: Unknown",
+			"file.vsql:7:5: inbound webhook header must not be empty",
+			"file.vsql:7:5: webhook secret must not be empty",
+			"file.vsql:8:5: invalid inbound webhook reply status 204: 2xx except 204 expected",
+			"file.vsql:9:5: invalid inbound webhook reply status 400: 2xx except 204 expected")
+	})
+}
@@ -60,3 +60,15 @@ func BuildAppSchema(packages []*PackageSchemaAST, opts ...ParserOption) (*AppSch
 func BuildAppDefs(appSchema *AppSchemaAST, builder appdef.IAppDefBuilder) error {
 	return buildAppDefs(appSchema, builder)
 }
+
+// StaticFolders returns static web content folders declared in the application packages
+func StaticFolders(appSchema *AppSchemaAST) []StaticFolder {
+	return staticFolders(appSchema)
+}
+
+// PackageSymbols returns symbols declared in the package and usages of symbols in the package statements.
+//
+// Could be used by tools like language servers to navigate through VSQL sources
+func PackageSymbols(pkg *PackageSchemaAST) (decls []Symbol, usages []SymbolUsage) {
+	return packageSymbols(pkg)
+}
@@ -28,6 +28,30 @@ type PackageSchemaAST struct {
 	localNameToPkgPath map[string]string
 }
 
+// Symbol is a named statement declared in the package
+type Symbol struct {
+	Kind     string // statement kind, e.g. "TABLE", "ROLE", "PROJECTOR"
+	PkgPath  string // full path of the package where symbol is declared
+	Name     string
+	Pos      lexer.Position // statement position
+	Comments []string
+}
+
+// StaticFolder is a folder with static web content declared by STATIC statement
+type StaticFolder struct {
+	PkgPath string // full path of the package where folder is declared
+	Name    string
+	Source  string // path to the folder, relative to the package folder
+	SPA     bool   // single-page application
+}
+
+// SymbolUsage is a reference to the symbol from the package statement
+type SymbolUsage struct {
+	Pos     lexer.Position
+	PkgPath string // full path of the referenced package. Empty if package can not be resolved. For unqualified names this is the path of the package itself
+	Name    string
+}
+
 type AppSchemaAST struct {
 	// Application name
 	Name string
@@ -115,7 +139,10 @@ func (i *ImportStmt) GetLocalPkgName() string {
 
 type RootStatement struct {
 	// Only allowed in root
-	Template *TemplateStmt `parser:"@@"`
+	Template     *TemplateStmt     `parser:"@@"`
+	Static       *StaticStmt       `parser:"| @@"`
+	Translations *TranslationsStmt `parser:"| @@"`
+	Egress       *EgressStmt       `parser:"| @@"`
 
 	// Also allowed in root
 	Role           *RoleStmt           `parser:"| @@"`
@@ -136,13 +163,15 @@ type WorkspaceStatement struct {
 	UseWorkspace *UseWorkspaceStmt `parser:"| @@"`
 
 	// Also allowed in workspace
-	Role      *RoleStmt               `parser:"| @@"`
-	Tag       *TagStmt                `parser:"| @@"`
-	ExtEngine *WorkspaceExtEngineStmt `parser:"| @@"`
-	Workspace *WorkspaceStmt          `parser:"| @@"`
-	Table     *TableStmt              `parser:"| @@"`
-	Type      *TypeStmt               `parser:"| @@"`
-	Limit     *LimitStmt              `parser:"| @@"`
+	Role           *RoleStmt               `parser:"| @@"`
+	Tag            *TagStmt                `parser:"| @@"`
+	ExtEngine      *WorkspaceExtEngineStmt `parser:"| @@"`
+	Workspace      *WorkspaceStmt          `parser:"| @@"`
+	Table          *TableStmt              `parser:"| @@"`
+	Type           *TypeStmt               `parser:"| @@"`
+	Limit          *LimitStmt              `parser:"| @@"`
+	Webhook        *WebhookStmt            `parser:"| @@"`
+	InboundWebhook *InboundWebhookStmt     `parser:"| @@"`
 	// Sequence  *sequenceStmt  `parser:"| @@"`
 	Grant  *GrantStmt  `parser:"| @@"`
 	Revoke *RevokeStmt `parser:"| @@"`
@@ -313,6 +342,12 @@ type TypeVarchar struct {
 	MaxLen *uint64 `parser:"(('character' 'varying') | 'varchar' | 'text') ( '(' @Int ')' )?"`
 }
 
+type TypeCurrency struct {
+	Pos       lexer.Position
+	Precision *uint64 `parser:"('money' | 'currency') ( '(' @Int"`
+	Scale     *uint64 `parser:"',' @Int ')' )?"`
+}
+
 type TypeBytes struct {
 	Pos    lexer.Position
 	MaxLen *uint64 `parser:"(('binary' 'varying') | 'varbinary' | 'bytes') ( '(' @Int ')' )?"`
@@ -330,19 +365,19 @@ type VoidOrDef struct {
 
 type DataType struct {
 	Pos       lexer.Position
-	Varchar   *TypeVarchar `parser:"( @@"`
-	Bytes     *TypeBytes   `parser:"| @@"`
-	Int8      bool         `parser:"| @('tinyint' | 'int8')"`
-	Int16     bool         `parser:"| @('smallint' | 'int16')"`
-	Int32     bool         `parser:"| @('integer' | 'int' | 'int32')"`
-	Int64     bool         `parser:"| @('bigint' | 'int64')"`
-	Float32   bool         `parser:"| @('real' | 'float' | 'float32')"`
-	Float64   bool         `parser:"| @(('double' 'precision') | 'float64')"`
-	Timestamp bool         `parser:"| @'timestamp'"`
-	Currency  bool         `parser:"| @('money' | 'currency')"`
-	Bool      bool         `parser:"| @('boolean' | 'bool')"`
-	Blob      bool         `parser:"| @(('binary' 'large' 'object') | 'blob')"`
-	QName     bool         `parser:"| @(('qualified' 'name') | 'qname')  )"`
+	Varchar   *TypeVarchar  `parser:"( @@"`
+	Bytes     *TypeBytes    `parser:"| @@"`
+	Int8      bool          `parser:"| @('tinyint' | 'int8')"`
+	Int16     bool          `parser:"| @('smallint' | 'int16')"`
+	Int32     bool          `parser:"| @('integer' | 'int' | 'int32')"`
+	Int64     bool          `parser:"| @('bigint' | 'int64')"`
+	Float32   bool          `parser:"| @('real' | 'float' | 'float32')"`
+	Float64   bool          `parser:"| @(('double' 'precision') | 'float64')"`
+	Timestamp bool          `parser:"| @'timestamp'"`
+	Currency  *TypeCurrency `parser:"| @@"`
+	Bool      bool          `parser:"| @('boolean' | 'bool')"`
+	Blob      bool          `parser:"| @(('binary' 'large' 'object') | 'blob')"`
+	QName     bool          `parser:"| @(('qualified' 'name') | 'qname')  )"`
 }
 
 func (q DataType) String() (s string) {
@@ -376,7 +411,10 @@ func (q DataType) String() (s string) {
 		return "blob"
 	} else if q.Timestamp {
 		return "timestamp"
-	} else if q.Currency {
+	} else if q.Currency != nil {
+		if q.Currency.Precision != nil {
+			return fmt.Sprintf("currency(%d,%d)", *q.Currency.Precision, *q.Currency.Scale)
+		}
 		return "currency"
 	}
 
@@ -451,10 +489,11 @@ type ProjectorCommandAction struct {
 }
 
 type ProjectorTrigger struct {
-	CronSchedule  *string                 `parser:"('CRON' @String) | ("`
-	ExecuteAction *ProjectorCommandAction `parser:"'AFTER' (@@"`
-	TableActions  []ProjectionTableAction `parser:"| (@@ ('OR' @@)* ))"`
-	QNames        []DefQName              `parser:"'ON' (('(' @@ (',' @@)* ')') | @@)!)"`
+	CronSchedule    *string                 `parser:"('CRON' @String)"`
+	WebhookTriggers bool                    `parser:"| @('AFTER' 'WEBHOOK' 'TRIGGERS') | ("` // triggers of all WEBHOOK statements of the application
+	ExecuteAction   *ProjectorCommandAction `parser:"'AFTER' (@@"`
+	TableActions    []ProjectionTableAction `parser:"| (@@ ('OR' @@)* ))"`
+	QNames          []DefQName              `parser:"'ON' (('(' @@ (',' @@)* ')') | @@)!)"`
 }
 
 type ProjectorStmt struct {
@@ -472,6 +511,15 @@ type ProjectorStmt struct {
 func (s *ProjectorStmt) GetName() string            { return string(s.Name) }
 func (s *ProjectorStmt) SetEngineType(e EngineType) { s.Engine = e }
 
+func (s *ProjectorStmt) webhookTriggers() bool {
+	for i := range s.Triggers {
+		if s.Triggers[i].WebhookTriggers {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *ProjectorTrigger) update() bool {
 	for i := 0; i < len(t.TableActions); i++ {
 		if t.TableActions[i].Update {
@@ -530,6 +578,52 @@ type TemplateStmt struct {
 
 func (s TemplateStmt) GetName() string { return string(s.Name) }
 
+// Folder of the application image with static web content, e.g. single-page application
+type StaticStmt struct {
+	Statement
+	Name   Ident  `parser:"'STATIC' @Ident"`
+	Source string `parser:"'SOURCE' @String"` // path to the folder, relative to the package folder
+	SPA    bool   `parser:"@'SPA'?"`
+}
+
+func (s StaticStmt) GetName() string { return string(s.Name) }
+
+// Translations of the application texts to the language, e.g. e-mail subjects or error messages
+type TranslationsStmt struct {
+	Statement
+	Name     Ident             `parser:"'TRANSLATIONS' @Ident"`
+	Language string            `parser:"'LANGUAGE' @String"` // BCP 47 tag, e.g. 'nl', 'pt-BR'
+	Items    []TranslationItem `parser:"'(' @@ (',' @@)* ','? ')'"`
+	lang     string            // canonical language tag, filled on the analysis stage
+}
+
+func (s TranslationsStmt) GetName() string { return string(s.Name) }
+
+type TranslationItem struct {
+	Pos         lexer.Position
+	Text        string `parser:"@String"`
+	Translation string `parser:"'=' @String"`
+}
+
+// Egress policy restricts outgoing HTTP requests of the application extensions, see sys.Http storage
+type EgressStmt struct {
+	Statement
+	Name        Ident        `parser:"'EGRESS' @Ident"`
+	Rules       []EgressRule `parser:"'(' @@ (',' @@)* ','? ')'"`
+	MaxBodySize *uint64      `parser:"('MAX' 'BODY' @Int)?"`    // bytes
+	MaxTimeout  *uint64      `parser:"('MAX' 'TIMEOUT' @Int)?"` // milliseconds
+}
+
+func (s EgressStmt) GetName() string { return string(s.Name) }
+
+type EgressRule struct {
+	Pos     lexer.Position
+	Target  string   `parser:"'ALLOW' @String"` // e.g. 'https://api.example.com', 'https://*.example.com'
+	Secrets []string `parser:"('SECRETS' '(' @String (',' @String)* ')')?"`
+	scheme  string   // filled on the analysis stage
+	host    string   // filled on the analysis stage
+}
+
 type RoleStmt struct {
 	Statement
 	Published bool          `parser:"@'PUBLISHED'?"`
@@ -591,13 +685,19 @@ type RateSubjectScope struct {
 	PerIP      bool `parser:" | @('PER' 'IP')"`
 }
 
+type RateBucketsClause struct {
+	Distributed   bool `parser:"@'DISTRIBUTED'"`
+	SlidingWindow bool `parser:"@('SLIDING' 'WINDOW')?"`
+}
+
 type RateStmt struct {
 	Statement
-	Name         Ident             `parser:"'RATE' @Ident"`
-	Value        RateValue         `parser:"@@"`
-	ObjectScope  *RateObjectScope  `parser:"@@?"`
-	SubjectScope *RateSubjectScope `parser:"@@?"`
-	workspace    workspaceAddr     // filled on the analysis stage
+	Name         Ident              `parser:"'RATE' @Ident"`
+	Value        RateValue          `parser:"@@"`
+	ObjectScope  *RateObjectScope   `parser:"@@?"`
+	SubjectScope *RateSubjectScope  `parser:"@@?"`
+	Buckets      *RateBucketsClause `parser:"@@?"`
+	workspace    workspaceAddr      // filled on the analysis stage
 }
 
 func (s RateStmt) GetName() string { return string(s.Name) }
@@ -651,6 +751,33 @@ type LimitStmt struct {
 
 func (s LimitStmt) GetName() string { return string(s.Name) }
 
+// POSTs the JSON envelope of the triggering events to the URL, the envelope is signed by the application secret
+type WebhookStmt struct {
+	Statement
+	Name      Ident              `parser:"'WEBHOOK' @Ident"`
+	Triggers  []ProjectorTrigger `parser:"@@ ('OR' @@)*"`
+	URL       string             `parser:"'URL' @String"`
+	Secret    string             `parser:"'SECRET' @String"`
+	workspace workspaceAddr      // filled on the analysis stage
+}
+
+func (s WebhookStmt) GetName() string { return string(s.Name) }
+
+// Passes the body of the request to the inbound webhook to the command, the sender is verified by the application secret
+type InboundWebhookStmt struct {
+	Statement
+	Name        Ident         `parser:"'INBOUND' 'WEBHOOK' @Ident"`
+	Command     DefQName      `parser:"EXECUTE ONCOMMAND @@"`
+	HMAC        bool          `parser:"'VERIFY' (@'HMAC'"`
+	Token       bool          `parser:"| @'TOKEN')"`
+	Header      string        `parser:"'HEADER' @String"`
+	Secret      string        `parser:"'SECRET' @String"`
+	ReplyStatus *int          `parser:"('REPLY' @Int)?"`
+	workspace   workspaceAddr // filled on the analysis stage
+}
+
+func (s InboundWebhookStmt) GetName() string { return string(s.Name) }
+
 type GrantColumn struct {
 	Pos     lexer.Position
 	SysName string      `parser:"@(('sys' '.' 'ID') | 'sys' '.' 'ParentID' | 'sys' '.' 'IsActive' | 'sys' '.' 'QName' | 'sys' '.' 'Container')"`
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/constraints"
 )
 
 func extractStatement(s any) interface{} {
@@ -256,7 +257,7 @@ func iteratePackage(pkg *PackageSchemaAST, ctx *basicContext, callback func(stmt
 
 func iteratePackageStmt[stmtType *TableStmt | *TypeStmt | *ViewStmt | *CommandStmt | *QueryStmt |
 	*WorkspaceStmt | *AlterWorkspaceStmt | *ProjectorStmt | *JobStmt | *RateStmt | *GrantStmt |
-	*RevokeStmt | *RoleStmt | *TagStmt | *LimitStmt](pkg *PackageSchemaAST, ctx *basicContext, callback func(stmt stmtType, ctx *iterateCtx)) {
+	*RevokeStmt | *RoleStmt | *TagStmt | *LimitStmt | *TranslationsStmt | *EgressStmt | *WebhookStmt | *InboundWebhookStmt](pkg *PackageSchemaAST, ctx *basicContext, callback func(stmt stmtType, ctx *iterateCtx)) {
 	iteratePackage(pkg, ctx, func(stmt interface{}, ctx *iterateCtx) {
 		if s, ok := stmt.(stmtType); ok {
 			callback(s, ctx)
@@ -367,7 +368,7 @@ func dataTypeToDataKind(t DataType) appdef.DataKind {
 	if t.Bytes != nil {
 		return appdef.DataKind_bytes
 	}
-	if t.Currency {
+	if t.Currency != nil {
 		return appdef.DataKind_int64
 	}
 	if t.Float32 {
@@ -401,6 +402,30 @@ func dataTypeToDataKind(t DataType) appdef.DataKind {
 	return appdef.DataKind_null
 }
 
+// Returns name of system data type for specified data type.
+//
+// Timestamp and currency are mapped to semantic system data types over int64.
+func dataTypeToDataName(t DataType) appdef.QName {
+	if t.Timestamp {
+		return appdef.SysData_Timestamp
+	}
+	if t.Currency != nil {
+		return appdef.SysData_Currency
+	}
+	return appdef.SysDataName(dataTypeToDataKind(t))
+}
+
+// Returns precision and scale constraints for currency data type, if declared, e.g. currency(18,2)
+func dataTypeConstraints(t DataType) []appdef.IConstraint {
+	if t.Currency != nil && t.Currency.Precision != nil {
+		return []appdef.IConstraint{
+			constraints.Precision(uint16(*t.Currency.Precision)), // nolint G115: checked in [analyzeDatatype]
+			constraints.Scale(uint16(*t.Currency.Scale)),         // nolint G115: checked in [analyzeDatatype]
+		}
+	}
+	return nil
+}
+
 func buildQname(ctx *iterateCtx, pkg Ident, name Ident) appdef.QName {
 	if pkg == "" {
 		pkg = Ident(ctx.pkg.Name)
@@ -344,6 +344,7 @@ func deployTestAppEx(
 				WASMConfig:         iextengine.WASMFactoryConfig{Compile: false},
 			}, "", imetrics.Provide()),
 		iratesce.TestBucketsFactory,
+		appparts.NullCompatibilityChecker,
 	)
 	if err != nil {
 		panic(err)
@@ -5,6 +5,8 @@
 package commandprocessor
 
 import (
+	"time"
+
 	"github.com/voedger/voedger/pkg/appdef"
 )
 
@@ -15,4 +17,19 @@ var (
 
 const (
 	args = "args"
+
+	// parts of the signature of the request to the inbound webhook, see SignInboundWebhook
+	inboundWebhookSignatureTimestamp = "t"
+	inboundWebhookSignatureV1        = "v1"
+	inboundWebhookTolerance          = 5 * time.Minute
+)
+
+const (
+	field_Operations      = "operations"
+	field_OperationMethod = "method"
+	field_OperationPath   = "path"
+	field_OperationBody   = "body"
+	batchPathPrefix_Docs  = "docs"
+	batchPathPrefix_Cmds  = "commands"
+	batchMaxPathParts     = 3 // docs/{pkg}.{table}/{id}
 )
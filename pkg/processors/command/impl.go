@@ -51,24 +51,28 @@ func (cm *implICommandMessage) APIPath() processors.APIPath       { return cm.ap
 func (cm *implICommandMessage) DocID() istructs.RecordID          { return cm.docID }
 func (cm *implICommandMessage) Method() string                    { return cm.method }
 func (cm *implICommandMessage) Origin() string                    { return cm.origin }
+func (cm *implICommandMessage) AcceptLanguage() string            { return cm.acceptLanguage }
+func (cm *implICommandMessage) Header() map[string]string         { return cm.header }
 
 func NewCommandMessage(requestCtx context.Context, body []byte, appQName appdef.AppQName, wsid istructs.WSID,
 	responder bus.IResponder, partitionID istructs.PartitionID, qName appdef.QName, token string, host string, apiPath processors.APIPath,
-	docID istructs.RecordID, method string, origin string) ICommandMessage {
+	docID istructs.RecordID, method string, origin string, acceptLanguage string, header map[string]string) ICommandMessage {
 	return &implICommandMessage{
-		body:        body,
-		appQName:    appQName,
-		wsid:        wsid,
-		responder:   responder,
-		partitionID: partitionID,
-		requestCtx:  requestCtx,
-		qName:       qName,
-		token:       token,
-		host:        host,
-		apiPath:     apiPath,
-		docID:       docID,
-		method:      method,
-		origin:      origin,
+		body:           body,
+		appQName:       appQName,
+		wsid:           wsid,
+		responder:      responder,
+		partitionID:    partitionID,
+		requestCtx:     requestCtx,
+		qName:          qName,
+		token:          token,
+		host:           host,
+		apiPath:        apiPath,
+		docID:          docID,
+		method:         method,
+		origin:         origin,
+		acceptLanguage: acceptLanguage,
+		header:         header,
 	}
 }
 
@@ -185,7 +189,7 @@ func (cmdProc *cmdProc) getAppPartition(ctx context.Context, cmd *cmdWorkpiece)
 		cmdProc.appsPartitions[cmd.cmdMes.AppQName()] = appPartitions
 	}
 	appPartition, ok := appPartitions[cmd.cmdMes.PartitionID()]
-	if !ok {
+	if !ok || appPartition.deploymentID != cmd.appPart.DeploymentID() {
 		if appPartition, err = cmdProc.recovery(ctx, cmd); err != nil {
 			return fmt.Errorf("partition %d recovery failed: %w", cmd.cmdMes.PartitionID(), err)
 		}
@@ -209,9 +213,14 @@ func getIWorkspace(_ context.Context, cmd *cmdWorkpiece) (err error) {
 	return nil
 }
 func getCmdQName(_ context.Context, cmd *cmdWorkpiece) (err error) {
-	if cmd.cmdMes.APIPath() == processors.APIPath_Docs {
+	switch cmd.cmdMes.APIPath() {
+	case processors.APIPath_Docs:
 		cmd.cmdQName = istructs.QNameCommandCUD
-	} else {
+	case processors.APIPath_Batch:
+		return parseBatch(cmd)
+	case processors.APIPath_InboundWebhooks:
+		return getInboundWebhookCmdQName(cmd)
+	default:
 		cmd.cmdQName = cmd.cmdMes.QName()
 	}
 	return nil
@@ -291,6 +300,7 @@ func (cmdProc *cmdProc) recovery(ctx context.Context, cmd *cmdWorkpiece) (ap *ap
 	ap = &appPartition{
 		workspaces:     map[istructs.WSID]*workspace{},
 		nextPLogOffset: istructs.FirstOffset,
+		deploymentID:   cmd.appPart.DeploymentID(),
 	}
 	var lastPLogEvent istructs.IPLogEvent
 	var lastPLogOffset istructs.Offset
@@ -453,19 +463,26 @@ func limitCallRate(_ context.Context, cmd *cmdWorkpiece) (err error) {
 	if !exceeded {
 		return nil
 	}
-	retryAfter := processors.RetryAfterSecondsOnLimitExceeded(cmd.appStructs.AppDef(), limit)
+	retryAfter := processors.RetryAfterSecondsOnLimitExceeded(cmd.appStructs.AppDef(), limit,
+		cmd.appPart.LimitRetryAfter(limit, cmd.cmdQName, cmd.cmdMes.WSID(), cmd.cmdMes.Host()))
 	return coreutils.NewHTTPErrorf(http.StatusTooManyRequests).AddHeader(httpu.RetryAfter, strconv.Itoa(retryAfter))
 }
 
 func (cmdProc *cmdProc) authenticate(_ context.Context, cmd *cmdWorkpiece) (err error) {
-	if processors.SetPrincipalsForAnonymousOnlyFunc(cmd.appStructs.AppDef(), cmd.cmdQName, cmd.cmdMes.WSID(), cmd) {
-		// grant to anonymous -> set token == "" to avoid validating an expired token accidentally kept in cookies
-		return nil
-	}
 	req := iauthnz.AuthnRequest{
 		Host:        cmd.cmdMes.Host(),
 		RequestWSID: cmd.cmdMes.WSID(),
 		Token:       cmd.cmdMes.Token(),
+		Resource:    cmd.cmdQName,
+	}
+	if cmd.inboundWebhook != nil {
+		if err := cmdProc.verifyInboundWebhookSender(cmd); err != nil {
+			return err
+		}
+		req.InboundWebhook = cmd.inboundWebhook.QName()
+	} else if processors.SetPrincipalsForAnonymousOnlyFunc(cmd.appStructs.AppDef(), cmd.cmdQName, cmd.cmdMes.WSID(), cmd) {
+		// grant to anonymous -> set token == "" to avoid validating an expired token accidentally kept in cookies
+		return nil
 	}
 	if cmd.principals, _, err = cmdProc.authenticator.Authenticate(cmd.cmdMes.RequestCtx(), cmd.appStructs,
 		cmd.appStructs.AppTokens(), req); err != nil {
@@ -502,10 +519,15 @@ func (cmdProc *cmdProc) authorizeRequest(ctx context.Context, cmd *cmdWorkpiece)
 }
 
 func unmarshalRequestBody(_ context.Context, cmd *cmdWorkpiece) (err error) {
+	if cmd.cmdMes.APIPath() == processors.APIPath_Batch {
+		return unmarshalBatchRequestBody(cmd)
+	}
 	if cmd.iCommand.Param() != nil && cmd.iCommand.Param().QName() == istructs.QNameRaw {
 		cmd.requestData[args] = map[string]interface{}{
 			processors.Field_RawObject_Body: string(cmd.cmdMes.Body()),
 		}
+	} else if cmd.inboundWebhook != nil {
+		err = unmarshalInboundWebhookArgs(cmd)
 	} else if err = coreutils.JSONUnmarshal(cmd.cmdMes.Body(), &cmd.requestData); err != nil {
 		err = fmt.Errorf("failed to unmarshal request body: %w\n%s", err, cmd.cmdMes.Body())
 	}
@@ -748,8 +770,11 @@ func (cmdProc *cmdProc) validateCUDsQNames(ctx context.Context, cmd *cmdWorkpiec
 }
 
 func parseCUDs(_ context.Context, cmd *cmdWorkpiece) (err error) {
-	if cmd.cmdMes.APIPath() == processors.APIPath_Docs {
+	switch cmd.cmdMes.APIPath() {
+	case processors.APIPath_Docs:
 		return parseCUDs_v2(cmd)
+	case processors.APIPath_Batch:
+		return parseCUDs_batch(cmd)
 	}
 	cuds, _, err := cmd.requestData.AsObjects("cuds")
 	if err != nil {
@@ -831,6 +856,10 @@ func parseCUDs(_ context.Context, cmd *cmdWorkpiece) (err error) {
 }
 
 func checkCUDsAllowedInCUDCmdOnly(_ context.Context, cmd *cmdWorkpiece) (err error) {
+	if cmd.cmdMes.APIPath() == processors.APIPath_Batch {
+		// doc operations of the batch are executed within the same event as the command of the batch
+		return nil
+	}
 	if len(cmd.parsedCUDs) > 0 && cmd.cmdQName != istructs.QNameCommandCUD && cmd.cmdQName != builtin.QNameCommandInit { // nolint SA1019
 		return errors.New("CUDs allowed for c.sys.CUD command only")
 	}
@@ -851,6 +880,9 @@ func checkArgsRefIntegrity(_ context.Context, cmd *cmdWorkpiece) (err error) {
 
 func getStatusCodeOfSuccess(_ context.Context, cmd *cmdWorkpiece) (err error) {
 	cmd.statusCodeOfSuccess = http.StatusOK
+	if cmd.inboundWebhook != nil {
+		cmd.statusCodeOfSuccess = cmd.inboundWebhook.ReplyStatus()
+	}
 	if cmd.cmdMes.APIPath() == processors.APIPath_Docs {
 		switch cmd.cmdMes.Method() {
 		case http.MethodPost:
@@ -947,6 +979,10 @@ func sendResponse(cmd *cmdWorkpiece, handlingError error) {
 		if !cmd.syncProjectorsStart.IsZero() {
 			cmd.metrics.increase(ProjectorsSeconds, time.Since(cmd.syncProjectorsStart).Seconds())
 		}
+		if cmd.appStructs != nil {
+			handlingError = coreutils.WrapSysErrorToExact(handlingError, http.StatusInternalServerError).
+				Localize(cmd.appStructs.AppDef(), cmd.cmdMes.AcceptLanguage())
+		}
 		bus.ReplyErr(cmd.cmdMes.Responder(), handlingError)
 		return
 	}
@@ -959,13 +995,18 @@ func sendResponse(cmd *cmdWorkpiece, handlingError error) {
 		body.Truncate(body.Len() - 1)
 		body.WriteString("}")
 	}
+	var cmdResultBytes []byte
 	if cmd.cmdResult != nil {
 		cmdResult := coreutils.ObjectToMap(cmd.cmdResult, cmd.appStructs.AppDef())
-		cmdResultBytes, err := json.Marshal(cmdResult)
-		if err != nil {
+		var err error
+		if cmdResultBytes, err = json.Marshal(cmdResult); err != nil {
 			// notest: impossible
 			panic("failed to marshal response: " + err.Error())
 		}
+	}
+	if cmd.cmdMes.APIPath() == processors.APIPath_Batch {
+		writeBatchResults(body, cmd, cmdResultBytes)
+	} else if cmdResultBytes != nil {
 		body.WriteString(`,"Result":`)
 		body.Write(cmdResultBytes)
 	}
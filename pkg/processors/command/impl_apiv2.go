@@ -15,6 +15,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/processors"
 )
 
 func parseCUDs_v2(cmd *cmdWorkpiece) (err error) {
@@ -24,7 +25,7 @@ func parseCUDs_v2(cmd *cmdWorkpiece) (err error) {
 		cudNumber := 1
 		cmd.parsedCUDs, err = apiV2InsertToCUDs(cmd.requestData, 0, &firstRawID, &cudNumber, cmd.cmdMes.QName())
 	case http.MethodPatch, http.MethodDelete:
-		cmd.parsedCUDs, err = apiV2UpdateToCUDs(cmd)
+		cmd.parsedCUDs, err = apiV2UpdateToCUDs(cmd, cmd.cmdMes.Method(), cmd.cmdMes.DocID(), cmd.cmdMes.QName(), cmd.requestData, xPath(""))
 	default:
 		// notest
 		panic("unexpected APIv2 http method: " + cmd.cmdMes.Method())
@@ -32,18 +33,18 @@ func parseCUDs_v2(cmd *cmdWorkpiece) (err error) {
 	return err
 }
 
-func apiV2UpdateToCUDs(cmd *cmdWorkpiece) (res []parsedCUD, err error) {
-	if _, ok := cmd.requestData[appdef.SystemField_ID]; ok {
+func apiV2UpdateToCUDs(cmd *cmdWorkpiece, method string, docID istructs.RecordID, qName appdef.QName, requestData coreutils.MapObject,
+	cudXPath xPath) (res []parsedCUD, err error) {
+	if _, ok := requestData[appdef.SystemField_ID]; ok {
 		return nil, errors.New("sys.ID field is not allowed among fields to update")
 	}
-	cudXPath := xPath("")
 	updateCUD := parsedCUD{
-		id:     int64(cmd.cmdMes.DocID()), // nolint G115
+		id:     int64(docID), // nolint G115
 		opKind: appdef.OperationKind_Update,
-		fields: cmd.requestData,
+		fields: requestData,
 	}
-	if cmd.cmdMes.Method() == http.MethodDelete {
-		if len(cmd.requestData) > 0 {
+	if method == http.MethodDelete {
+		if len(requestData) > 0 {
 			return nil, errors.New("unexpected body is provided on delete")
 		}
 		updateCUD.opKind = appdef.OperationKind_Deactivate
@@ -58,8 +59,8 @@ func apiV2UpdateToCUDs(cmd *cmdWorkpiece) (res []parsedCUD, err error) {
 	if updateCUD.qName = updateCUD.existingRecord.QName(); updateCUD.qName == appdef.NullQName {
 		return nil, coreutils.NewHTTPError(http.StatusNotFound, cudXPath.Errorf("record with queried id %d does not exist", updateCUD.id))
 	}
-	if updateCUD.qName != cmd.cmdMes.QName() {
-		return nil, fmt.Errorf("record id %d leads to %s QName whereas %s QName is mentioned in the request", updateCUD.id, updateCUD.qName, cmd.cmdMes.QName())
+	if updateCUD.qName != qName {
+		return nil, fmt.Errorf("record id %d leads to %s QName whereas %s QName is mentioned in the request", updateCUD.id, updateCUD.qName, qName)
 	}
 	updateCUD.xPath = xPath(fmt.Sprintf("%s %s %s", cudXPath, updateCUD.opKind, updateCUD.qName))
 	res = append(res, updateCUD)
@@ -116,13 +117,28 @@ func apiV2InsertToCUDs(requestData coreutils.MapObject, parentSysID int64, nextR
 }
 
 func apiv2_denyODocCUD(_ context.Context, cmd *cmdWorkpiece) (err error) {
-	if cmd.iWorkspace == nil {
+	if cmd.iWorkspace == nil || cmd.inboundWebhook != nil {
 		return nil
 	}
-	tableType := cmd.iWorkspace.Type(cmd.cmdMes.QName())
+	if cmd.cmdMes.APIPath() == processors.APIPath_Batch {
+		for _, op := range cmd.batch {
+			if op.apiPath != processors.APIPath_Docs {
+				continue
+			}
+			if err := denyODocCUD(cmd, op.qName); err != nil {
+				return op.xPath.Error(err)
+			}
+		}
+		return nil
+	}
+	return denyODocCUD(cmd, cmd.cmdMes.QName())
+}
+
+func denyODocCUD(cmd *cmdWorkpiece, qName appdef.QName) error {
+	tableType := cmd.iWorkspace.Type(qName)
 	switch tableType.Kind() {
 	case appdef.TypeKind_null:
-		return fmt.Errorf("table %s not found in workspace %d:%s", cmd.cmdMes.QName(), cmd.eca.WSID, cmd.iWorkspace.Descriptor())
+		return fmt.Errorf("table %s not found in workspace %d:%s", qName, cmd.eca.WSID, cmd.iWorkspace.Descriptor())
 	case appdef.TypeKind_ODoc, appdef.TypeKind_ORecord:
 		return coreutils.NewHTTPErrorf(http.StatusMethodNotAllowed, "cannot operate on the ODoc\\ORecord in any way other than through command arguments")
 	}
@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package commandprocessor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/processors"
+	"github.com/voedger/voedger/pkg/sys/builtin"
+)
+
+// parses the APIv2 batch request body:
+//
+//	{"operations": [
+//		{"method": "POST", "path": "docs/{pkg}.{table}", "body": {...}},
+//		{"method": "PATCH", "path": "docs/{pkg}.{table}/{id}", "body": {...}},
+//		{"method": "DELETE", "path": "docs/{pkg}.{table}/{id}"},
+//		{"method": "POST", "path": "commands/{pkg}.{command}", "body": {"args": {...}, "unloggedArgs": {...}}}
+//	]}
+//
+// doc operations become CUDs of the single event, the event command is the command of the batch or c.sys.CUD if there is no command
+func parseBatch(cmd *cmdWorkpiece) error {
+	requestData := coreutils.MapObject{}
+	if err := coreutils.JSONUnmarshal(cmd.cmdMes.Body(), &requestData); err != nil {
+		return fmt.Errorf("failed to unmarshal request body: %w", err)
+	}
+	ops, _, err := requestData.AsObjects(field_Operations)
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return fmt.Errorf(`"%s" must be a non-empty array`, field_Operations)
+	}
+	cmd.cmdQName = istructs.QNameCommandCUD
+	var batchCmd *batchOperation
+	for i, opIntf := range ops {
+		opXPath := xPath(fmt.Sprintf("%s[%d]", field_Operations, i))
+		opMap, ok := opIntf.(map[string]interface{})
+		if !ok {
+			return opXPath.Errorf("not an object")
+		}
+		op, err := parseBatchOperation(coreutils.MapObject(opMap), opXPath)
+		if err != nil {
+			return err
+		}
+		if op.apiPath == processors.APIPath_Commands {
+			if batchCmd != nil {
+				return opXPath.Errorf("only one command is allowed in the batch, %s is already provided by %s", batchCmd.qName, batchCmd.xPath)
+			}
+			batchCmd = op
+			cmd.cmdQName = op.qName
+		}
+		cmd.batch = append(cmd.batch, op)
+	}
+	return nil
+}
+
+func parseBatchOperation(opData coreutils.MapObject, opXPath xPath) (*batchOperation, error) {
+	op := &batchOperation{xPath: opXPath}
+	var err error
+	if op.method, err = opData.AsStringRequired(field_OperationMethod); err != nil {
+		return nil, opXPath.Error(err)
+	}
+	path, err := opData.AsStringRequired(field_OperationPath)
+	if err != nil {
+		return nil, opXPath.Error(err)
+	}
+	if op.body, _, err = opData.AsObject(field_OperationBody); err != nil {
+		return nil, opXPath.Error(err)
+	}
+	if op.body == nil {
+		op.body = coreutils.MapObject{}
+	}
+
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) < 2 || len(pathParts) > batchMaxPathParts {
+		return nil, opXPath.Errorf("wrong path %q", path)
+	}
+	if op.qName, err = appdef.ParseQName(pathParts[1]); err != nil {
+		return nil, opXPath.Errorf("wrong path %q: %w", path, err)
+	}
+	hasDocID := len(pathParts) == batchMaxPathParts
+	if hasDocID {
+		docID, err := strconv.ParseUint(pathParts[2], 10, 64)
+		if err != nil || docID == 0 {
+			return nil, opXPath.Errorf("wrong path %q: wrong record ID %q", path, pathParts[2])
+		}
+		op.docID = istructs.RecordID(docID)
+	}
+
+	op.method = strings.ToUpper(op.method)
+	switch {
+	case pathParts[0] == batchPathPrefix_Docs && !hasDocID && op.method == http.MethodPost:
+		op.apiPath = processors.APIPath_Docs
+	case pathParts[0] == batchPathPrefix_Docs && hasDocID && (op.method == http.MethodPatch || op.method == http.MethodDelete):
+		op.apiPath = processors.APIPath_Docs
+	case pathParts[0] == batchPathPrefix_Cmds && !hasDocID && op.method == http.MethodPost:
+		op.apiPath = processors.APIPath_Commands
+	default:
+		return nil, opXPath.Errorf("%s %s is not supported in the batch", op.method, path)
+	}
+	return op, nil
+}
+
+// returns the command operation of the batch, nil if there is no command
+func batchCommand(cmd *cmdWorkpiece) *batchOperation {
+	for _, op := range cmd.batch {
+		if op.apiPath == processors.APIPath_Commands {
+			return op
+		}
+	}
+	return nil
+}
+
+// body of the command operation becomes the request data, i.e. args and unloggedArgs of the event
+func unmarshalBatchRequestBody(cmd *cmdWorkpiece) error {
+	op := batchCommand(cmd)
+	if op == nil {
+		return nil
+	}
+	if cmd.iCommand.Param() != nil && cmd.iCommand.Param().QName() == istructs.QNameRaw {
+		rawBody, err := json.Marshal(op.body)
+		if err != nil {
+			// notest
+			return err
+		}
+		cmd.requestData[args] = map[string]interface{}{
+			processors.Field_RawObject_Body: string(rawBody),
+		}
+		return nil
+	}
+	cmd.requestData = op.body
+	return nil
+}
+
+// doc operations are converted to CUDs in the order of the operations
+// raw IDs are assigned in the same manner as for the single doc create request but are unique within the whole batch
+func parseCUDs_batch(cmd *cmdWorkpiece) error {
+	nextRawID := int64(istructs.MinRawRecordID)
+	cudNumber := 1
+	for _, op := range cmd.batch {
+		if op.apiPath != processors.APIPath_Docs {
+			continue
+		}
+		var cuds []parsedCUD
+		var err error
+		if op.method == http.MethodPost {
+			if cuds, err = apiV2InsertToCUDs(op.body, 0, &nextRawID, &cudNumber, op.qName); err != nil {
+				return op.xPath.Error(err)
+			}
+			for _, cud := range cuds {
+				op.rawIDs = append(op.rawIDs, istructs.RecordID(cud.id)) // nolint G115
+			}
+		} else {
+			if cuds, err = apiV2UpdateToCUDs(cmd, op.method, op.docID, op.qName, op.body, op.xPath); err != nil {
+				if errors.As(err, new(coreutils.SysError)) {
+					// already contains the operation xPath
+					return err
+				}
+				return op.xPath.Error(err)
+			}
+		}
+		cmd.parsedCUDs = append(cmd.parsedCUDs, cuds...)
+	}
+	if len(cmd.parsedCUDs) > builtin.MaxCUDs {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "too many cuds: ", len(cmd.parsedCUDs), " is in the batch, max is ", builtin.MaxCUDs)
+	}
+	return nil
+}
+
+// writes the results of the batch operations in the order of the operations
+func writeBatchResults(body *bytes.Buffer, cmd *cmdWorkpiece, cmdResultBytes []byte) {
+	body.WriteString(`,"Results":[`)
+	for i, op := range cmd.batch {
+		if i > 0 {
+			body.WriteString(",")
+		}
+		status := http.StatusOK
+		if op.apiPath == processors.APIPath_Docs && op.method == http.MethodPost {
+			status = http.StatusCreated
+		}
+		fmt.Fprintf(body, `{"status":%d`, status)
+		if len(op.rawIDs) > 0 {
+			body.WriteString(`,"newIDs":{`)
+			for j, rawID := range op.rawIDs {
+				if j > 0 {
+					body.WriteString(",")
+				}
+				fmt.Fprintf(body, `"%d":%d`, rawID, cmd.idGeneratorReporter.generatedIDs[rawID])
+			}
+			body.WriteString("}")
+		}
+		if op.apiPath == processors.APIPath_Commands && cmdResultBytes != nil {
+			body.WriteString(`,"result":`)
+			body.Write(cmdResultBytes)
+		}
+		body.WriteString("}")
+	}
+	body.WriteString("]")
+}
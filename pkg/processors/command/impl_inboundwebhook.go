@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package commandprocessor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// the request to the inbound webhook executes the command of the inbound webhook
+func getInboundWebhookCmdQName(cmd *cmdWorkpiece) error {
+	cmd.inboundWebhook = cmd.appStructs.AppDef().InboundWebhook(cmd.cmdMes.QName())
+	if cmd.inboundWebhook == nil {
+		return coreutils.NewHTTPErrorf(http.StatusNotFound, "inbound webhook ", cmd.cmdMes.QName(), " does not exist")
+	}
+	cmd.cmdQName = cmd.inboundWebhook.Command()
+	return nil
+}
+
+// inbound webhook is available in the workspace it is declared in and its descendants only
+func checkInboundWebhookWorkspace(_ context.Context, cmd *cmdWorkpiece) error {
+	if cmd.inboundWebhook == nil {
+		return nil
+	}
+	if cmd.iWorkspace == nil || !cmd.iWorkspace.Inherits(cmd.inboundWebhook.Workspace()) {
+		return coreutils.NewHTTPErrorf(http.StatusNotFound, "inbound webhook ", cmd.inboundWebhook.QName(), " does not exist in the workspace")
+	}
+	return nil
+}
+
+// the sender is verified by the application secret instead of the principal token
+// the signature and the token are bound to the workspace and the inbound webhook, so the request can not be sent to the other ones
+func (cmdProc *cmdProc) verifyInboundWebhookSender(cmd *cmdWorkpiece) error {
+	hook := cmd.inboundWebhook
+	secret, err := cmdProc.secretReader.ReadSecret(hook.Secret())
+	if err != nil {
+		return coreutils.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("failed to read the secret of inbound webhook %s: %w", hook.QName(), err))
+	}
+	value := cmd.cmdMes.Header()[http.CanonicalHeaderKey(hook.Header())]
+	verified := false
+	switch hook.Verify() {
+	case appdef.InboundWebhookVerify_HMAC:
+		verified = validInboundWebhookSignature(cmdProc.time.Now(), secret, cmd.cmdMes.WSID(), hook.QName(), cmd.cmdMes.Body(), value)
+	case appdef.InboundWebhookVerify_Token:
+		token := InboundWebhookToken(secret, cmd.cmdMes.WSID(), hook.QName())
+		verified = len(value) > 0 && subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1
+	}
+	if !verified {
+		return coreutils.NewHTTPErrorf(http.StatusUnauthorized, "the sender of inbound webhook ", hook.QName(), " is not verified")
+	}
+	return nil
+}
+
+// signature is "t=<unix seconds>,v1=<hex>", see SignInboundWebhook
+// the stale signature is rejected, so the captured request can be replayed within the tolerance only
+func validInboundWebhookSignature(now time.Time, secret []byte, wsid istructs.WSID, hook appdef.QName, body []byte, signature string) bool {
+	var timestamp int64
+	var actual []byte
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		var err error
+		switch key {
+		case inboundWebhookSignatureTimestamp:
+			timestamp, err = strconv.ParseInt(value, 10, 64)
+		case inboundWebhookSignatureV1:
+			actual, err = hex.DecodeString(value)
+		}
+		if err != nil {
+			return false
+		}
+	}
+	if timestamp == 0 || len(actual) == 0 {
+		return false
+	}
+	if age := now.Sub(time.Unix(timestamp, 0)); age > inboundWebhookTolerance || age < -inboundWebhookTolerance {
+		return false
+	}
+	return hmac.Equal(actual, inboundWebhookMAC(secret, timestamp, wsid, hook, body))
+}
+
+// Returns the value of the signature header of the request to the inbound webhook verified by HMAC:
+// "t=<unix seconds>,v1=<hex>", where v1 is HMAC-SHA256 of "<t>.<wsid>.<hook>.<body>" signed by the secret
+func SignInboundWebhook(secret []byte, wsid istructs.WSID, hook appdef.QName, t time.Time, body []byte) string {
+	timestamp := t.Unix()
+	return fmt.Sprintf("%s=%d,%s=%s", inboundWebhookSignatureTimestamp, timestamp,
+		inboundWebhookSignatureV1, hex.EncodeToString(inboundWebhookMAC(secret, timestamp, wsid, hook, body)))
+}
+
+// Returns the token of the inbound webhook verified by TOKEN in the workspace:
+// hex encoded HMAC-SHA256 of "<wsid>.<hook>" signed by the secret
+func InboundWebhookToken(secret []byte, wsid istructs.WSID, hook appdef.QName) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d.%s", wsid, hook)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func inboundWebhookMAC(secret []byte, timestamp int64, wsid istructs.WSID, hook appdef.QName, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d.%d.%s.", timestamp, wsid, hook)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// the body is the argument of the command as is, it is ignored if the command has no parameter
+func unmarshalInboundWebhookArgs(cmd *cmdWorkpiece) error {
+	if cmd.iCommand.Param() == nil {
+		return nil
+	}
+	argsObj := map[string]interface{}{}
+	if err := coreutils.JSONUnmarshal(cmd.cmdMes.Body(), &argsObj); err != nil {
+		return fmt.Errorf("failed to unmarshal request body: %w\n%s", err, cmd.cmdMes.Body())
+	}
+	cmd.requestData[args] = argsObj
+	return nil
+}
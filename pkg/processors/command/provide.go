@@ -35,12 +35,14 @@ type cmdProc struct {
 	n10nBroker     in10n.IN10nBroker
 	time           timeu.ITime
 	authenticator  iauthnz.IAuthenticator
+	secretReader   isecrets.ISecretReader
 	storeOp        pipeline.ISyncOperator
 }
 
 type appPartition struct {
 	workspaces     map[istructs.WSID]*workspace
 	nextPLogOffset istructs.Offset
+	deploymentID   uint64 // partition is recovered again if it was undeployed and deployed again, e.g. handed over to another VVM and back
 }
 
 // syncActualizerFactory is a factory(partitionID) that returns a fork operator with a sync actualizer per each application. Inside of an each actualizer - projectors for each application
@@ -53,6 +55,7 @@ func ProvideServiceFactory(appParts appparts.IAppPartitions, tm timeu.ITime,
 			n10nBroker:     n10nBroker,
 			time:           tm,
 			authenticator:  authenticator,
+			secretReader:   secretReader,
 		}
 
 		return pipeline.NewService(func(vvmCtx context.Context) {
@@ -109,6 +112,7 @@ func ProvideServiceFactory(appParts appparts.IAppPartitions, tm timeu.ITime,
 				pipeline.WireFunc("checkWSInitialized", checkWSInitialized),
 				pipeline.WireFunc("checkWSActive", checkWSActive),
 				pipeline.WireFunc("getIWorkspace", getIWorkspace),
+				pipeline.WireFunc("checkInboundWebhookWorkspace", checkInboundWebhookWorkspace),
 				pipeline.WireFunc("getAppPartition", cmdProc.getAppPartition),
 				pipeline.WireFunc("getICommand", getICommand),
 				pipeline.WireFunc("authorizeRequest", cmdProc.authorizeRequest),
@@ -45,6 +45,8 @@ type ICommandMessage interface {
 	DocID() istructs.RecordID
 	Method() string
 	Origin() string
+	AcceptLanguage() string    // used to localize error messages
+	Header() map[string]string // used to verify the sender of the request to the inbound webhook
 }
 
 type xPath string
@@ -79,6 +81,7 @@ type cmdWorkpiece struct {
 	principals                   []iauthnz.Principal
 	roles                        []appdef.QName
 	parsedCUDs                   []parsedCUD
+	batch                        []*batchOperation
 	wsDesc                       istructs.IRecord
 	hostState                    *reusableHostState
 	wsInitialized                bool
@@ -86,6 +89,7 @@ type cmdWorkpiece struct {
 	cmdResult                    istructs.IObject
 	iCommand                     appdef.ICommand
 	iWorkspace                   appdef.IWorkspace
+	inboundWebhook               appdef.IInboundWebhook // request to the inbound webhook -> not nil
 	appPartitionRestartScheduled bool
 	cmdQName                     appdef.QName
 	statusCodeOfSuccess          int
@@ -112,20 +116,33 @@ type parsedCUD struct {
 	xPath          xPath
 }
 
+// operation of the APIv2 batch request
+type batchOperation struct {
+	xPath   xPath
+	method  string
+	apiPath processors.APIPath // APIPath_Docs or APIPath_Commands
+	qName   appdef.QName
+	docID   istructs.RecordID // update, deactivate -> ID of the existing record
+	body    coreutils.MapObject
+	rawIDs  []istructs.RecordID // create -> raw IDs of the created records
+}
+
 type implICommandMessage struct {
-	body        []byte
-	appQName    appdef.AppQName // need to determine where to send c.sys.Init request on create a new workspace
-	wsid        istructs.WSID
-	responder   bus.IResponder
-	partitionID istructs.PartitionID
-	requestCtx  context.Context
-	qName       appdef.QName // APIv1 -> cmd QName, APIv2 -> cmdQName or DocQName
-	token       string
-	host        string
-	apiPath     processors.APIPath
-	docID       istructs.RecordID
-	method      string
-	origin      string
+	body           []byte
+	appQName       appdef.AppQName // need to determine where to send c.sys.Init request on create a new workspace
+	wsid           istructs.WSID
+	responder      bus.IResponder
+	partitionID    istructs.PartitionID
+	requestCtx     context.Context
+	qName          appdef.QName // APIv1 -> cmd QName, APIv2 -> cmdQName or DocQName
+	token          string
+	host           string
+	apiPath        processors.APIPath
+	docID          istructs.RecordID
+	method         string
+	origin         string
+	acceptLanguage string
+	header         map[string]string
 }
 
 type wrongArgsCatcher struct {
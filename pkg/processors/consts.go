@@ -21,4 +21,8 @@ const (
 	APIPath_Auth_Refresh
 	APIPath_Users
 	APIPath_N10N_SubscribeAndWatch
+	APIPath_Batch
+	APIPath_InboundWebhooks
+	APIPath_Auth_OIDCAuthorize
+	APIPath_Auth_OIDCCallback
 )
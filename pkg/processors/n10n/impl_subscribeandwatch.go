@@ -18,6 +18,7 @@ import (
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/pipeline"
@@ -44,6 +45,10 @@ func subscribeAndWatchPipeline(requestCtx context.Context, p *implIN10NProc) pip
 
 func (p *implIN10NProc) validateToken(ctx context.Context, n10nWP *n10nWorkpiece) (err error) {
 	n10nWP.appTokens = p.appTokensFactory.New(n10nWP.appQName)
+	if iauthnzimpl.IsAPIKey(n10nWP.token) {
+		// API key is per workspace, so it is checked by the authenticator for each subscription, see authnzEntities
+		return nil
+	}
 	_, err = n10nWP.appTokens.ValidateToken(n10nWP.token, &n10nWP.principalPayload)
 
 	// [~server.n10n/err.routerCreateChannelInvalidToken~impl]
@@ -53,6 +58,10 @@ func (p *implIN10NProc) validateToken(ctx context.Context, n10nWP *n10nWorkpiece
 }
 
 func (p *implIN10NProc) getSubjectLogin(ctx context.Context, n10nWP *n10nWorkpiece) (err error) {
+	if iauthnzimpl.IsAPIKey(n10nWP.token) {
+		n10nWP.subjectLogin = iauthnzimpl.APIKeySubjectLogin(n10nWP.token)
+		return nil
+	}
 	subjectLogin := n10nWP.principalPayload.Login
 	n10nWP.subjectLogin = istructs.SubjectLogin(subjectLogin)
 	return nil
@@ -114,7 +123,7 @@ func (p *implIN10NProc) authnzEntities(ctx context.Context, n10nWP *n10nWorkpiec
 			// [~server.n10n/err.routerCreateChannelInvalidToken~impl]
 			// [~server.n10n/err.routerAddSubscriptionInvalidToken~impl]
 			// [~server.n10n/err.routerUnsubscribeInvalidToken~impl]
-			// notest: token is validated already, error could happen on e.g. subjects read failure
+			// e.g. API key is not valid for the workspace or subjects read failure
 			return coreutils.NewHTTPError(http.StatusUnauthorized, err)
 		}
 		roles := processors.GetRoles(principals)
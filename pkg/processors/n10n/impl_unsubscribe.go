@@ -11,6 +11,7 @@ import (
 
 	"github.com/voedger/voedger/pkg/bus"
 	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/pipeline"
 )
@@ -19,12 +20,28 @@ func unsubscribePipeline(requestCtx context.Context, p *implIN10NProc) pipeline.
 	return pipeline.NewSyncPipeline(requestCtx, "Unsubscribe Processor",
 		pipeline.WireFunc("validateToken", p.validateToken),
 		pipeline.WireFunc("denyBody", denyBody),
+		pipeline.WireFunc("authnzAPIKey", p.authnzAPIKey),
 		pipeline.WireFunc("unsubscribe", p.unsubscribe),
 		pipeline.WireFunc("logUnsubscribeSuccess", logUnsubscribeSuccess),
 		pipeline.WireFunc("reply204NoContent", reply204NoContent),
 	)
 }
 
+// API key is not validated by validateToken, so it is checked by the authenticator for the workspace from URL
+func (p *implIN10NProc) authnzAPIKey(ctx context.Context, n10nWP *n10nWorkpiece) (err error) {
+	if !iauthnzimpl.IsAPIKey(n10nWP.token) {
+		return nil
+	}
+	if err := p.getAppStructs(ctx, n10nWP); err != nil {
+		return err
+	}
+	if err := addProjectionKeyFromURL(ctx, n10nWP); err != nil {
+		// notest
+		return err
+	}
+	return p.authnzEntities(ctx, n10nWP)
+}
+
 func (p *implIN10NProc) unsubscribe(ctx context.Context, n10nWP *n10nWorkpiece) (err error) {
 	projectionKey := in10n.ProjectionKey{
 		App:        n10nWP.appQName,
@@ -74,7 +74,7 @@ var defaultACL = ACL{
 				qNameCmdStoreSubscriptionProfile, qNameCmdUpdateSubscription,
 
 				qNameCDocUnTillOrders, qNameCDocUnTillPBill,
-				qNameTestDeniedCmd, qNameTestDeniedCDoc, qNameCDocLogin, qNameCDocChildWorkspace, qNameTestDeniedQry, qNameTestDeniedCmd_it, qNameTestDeniedQry_it,
+				qNameTestDeniedCmd, qNameTestDeniedCDoc, qNameCDocLogin, qNameCDocChildWorkspace, qNameCDocInviteLink, qNameCDocAPIKey, qNameTestDeniedQry, qNameTestDeniedCmd_it, qNameTestDeniedQry_it,
 			},
 		},
 		policy: appdef.PolicyKind_Deny,
@@ -83,7 +83,7 @@ var defaultACL = ACL{
 		desc: "grant select only on few documents to WorkspaceOwner",
 		pattern: PatternType{
 			opKindsPattern:    []appdef.OperationKind{appdef.OperationKind_Select},
-			qNamesPattern:     []appdef.QName{qNameCDocChildWorkspace},
+			qNamesPattern:     []appdef.QName{qNameCDocChildWorkspace, qNameCDocInviteLink, qNameCDocAPIKey},
 			principalsPattern: [][]iauthnz.Principal{{{Kind: iauthnz.PrincipalKind_Role, QName: iauthnz.QNameRoleWorkspaceOwner}}},
 		},
 		policy: appdef.PolicyKind_Allow,
@@ -19,6 +19,8 @@ var (
 	qNameTestDeniedCDoc                         = appdef.NewQName("app1pkg", "TestDeniedCDoc")
 	qNameCDocLogin                              = appdef.NewQName(registryPackage, "Login")
 	qNameCDocChildWorkspace                     = appdef.NewQName(appdef.SysPackage, "ChildWorkspace")
+	qNameCDocInviteLink                         = appdef.NewQName(appdef.SysPackage, "InviteLink")
+	qNameCDocAPIKey                             = appdef.NewQName(appdef.SysPackage, "APIKey")
 	qNameCmdUpdateSubscription                  = appdef.NewQName(airPackage, "UpdateSubscription")
 	qNameCmdStoreSubscriptionProfile            = appdef.NewQName(airPackage, "StoreSubscriptionProfile")
 	qNameCmdLinkDeviceToRestaurant              = appdef.NewQName(airPackage, "LinkDeviceToRestaurant")
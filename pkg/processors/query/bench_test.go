@@ -75,7 +75,7 @@ func Benchmark_pipelineIService_Sequential(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, nil, body, qNameFunction, "", sysToken)
+		serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, nil, body, qNameFunction, "", sysToken, "")
 		<-res
 	}
 
@@ -143,7 +143,7 @@ func Benchmark_pipelineIService_Parallel(b *testing.B) {
 		b.ResetTimer()
 
 		for pb.Next() {
-			serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, nil, body, qNameFunction, "", sysToken)
+			serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, nil, body, qNameFunction, "", sysToken, "")
 			<-res
 		}
 	})
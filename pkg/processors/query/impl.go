@@ -150,8 +150,12 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel,
 					var respWriter bus.IResponseWriter
 					statusCode := http.StatusOK
 					if err != nil {
-						statusCode = err.(coreutils.SysError).HTTPStatus // nolint:errorlint
+						sysErr := err.(coreutils.SysError) // nolint:errorlint
+						statusCode = sysErr.HTTPStatus
 						logger.ErrorCtx(qwork.msg.RequestCtx(), "qp.error", err)
+						if qwork.appStructs != nil {
+							err = sysErr.Localize(qwork.appStructs.AppDef(), msg.AcceptLanguage())
+						}
 					}
 					if qwork.responseWriterGetter == nil || qwork.responseWriterGetter() == nil {
 						// have an error before 200ok is sent -> send the status from the actual error
@@ -194,7 +198,8 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 			if !exceeded {
 				return nil
 			}
-			retryAfter := processors.RetryAfterSecondsOnLimitExceeded(qw.appStructs.AppDef(), limit)
+			retryAfter := processors.RetryAfterSecondsOnLimitExceeded(qw.appStructs.AppDef(), limit,
+				qw.appPart.LimitRetryAfter(limit, qw.msg.QName(), qw.msg.WSID(), qw.msg.Host()))
 			return coreutils.NewHTTPErrorf(http.StatusTooManyRequests).AddHeader(httpu.RetryAfter, strconv.Itoa(retryAfter))
 		}),
 		operator("authenticate query request", func(ctx context.Context, qw *queryWork) (err error) {
@@ -206,6 +211,7 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 				Host:        qw.msg.Host(),
 				RequestWSID: qw.msg.WSID(),
 				Token:       qw.msg.Token(),
+				Resource:    qw.msg.QName(),
 			}
 			if qw.principals, _, err = authn.Authenticate(qw.msg.RequestCtx(), qw.appStructs, qw.appStructs.AppTokens(), req); err != nil {
 				return coreutils.WrapSysError(err, http.StatusUnauthorized)
@@ -548,15 +554,16 @@ func operator(name string, doSync func(ctx context.Context, qw *queryWork) (err
 }
 
 type queryMessage struct {
-	requestCtx context.Context
-	appQName   appdef.AppQName
-	wsid       istructs.WSID
-	partition  istructs.PartitionID
-	responder  bus.IResponder
-	body       []byte
-	qName      appdef.QName
-	host       string
-	token      string
+	requestCtx     context.Context
+	appQName       appdef.AppQName
+	wsid           istructs.WSID
+	partition      istructs.PartitionID
+	responder      bus.IResponder
+	body           []byte
+	qName          appdef.QName
+	host           string
+	token          string
+	acceptLanguage string
 }
 
 func (m queryMessage) AppQName() appdef.AppQName { return m.appQName }
@@ -568,6 +575,7 @@ func (m queryMessage) RequestCtx() context.Context     { return m.requestCtx }
 func (m queryMessage) QName() appdef.QName             { return m.qName }
 func (m queryMessage) Host() string                    { return m.host }
 func (m queryMessage) Token() string                   { return m.token }
+func (m queryMessage) AcceptLanguage() string          { return m.acceptLanguage }
 func (m queryMessage) Partition() istructs.PartitionID { return m.partition }
 func (m queryMessage) Body() []byte {
 	if len(m.body) != 0 {
@@ -577,17 +585,18 @@ func (m queryMessage) Body() []byte {
 }
 
 func NewQueryMessage(requestCtx context.Context, appQName appdef.AppQName, partID istructs.PartitionID, wsid istructs.WSID,
-	responder bus.IResponder, body []byte, qName appdef.QName, host string, token string) IQueryMessage {
+	responder bus.IResponder, body []byte, qName appdef.QName, host string, token string, acceptLanguage string) IQueryMessage {
 	return queryMessage{
-		appQName:   appQName,
-		wsid:       wsid,
-		partition:  partID,
-		responder:  responder,
-		body:       body,
-		requestCtx: requestCtx,
-		qName:      qName,
-		host:       host,
-		token:      token,
+		appQName:       appQName,
+		wsid:           wsid,
+		partition:      partID,
+		responder:      responder,
+		body:           body,
+		requestCtx:     requestCtx,
+		qName:          qName,
+		host:           host,
+		token:          token,
+		acceptLanguage: acceptLanguage,
 	}
 }
 
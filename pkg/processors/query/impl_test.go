@@ -353,7 +353,7 @@ func deployTestAppWithSecretToken(require *require.Assertions,
 				StatelessResources: statelessResources,
 				WASMConfig:         iextengine.WASMFactoryConfig{Compile: false},
 			}, "", imetrics.Provide()),
-		iratesce.TestBucketsFactory)
+		iratesce.TestBucketsFactory, appparts.NullCompatibilityChecker)
 	require.NoError(err)
 	appParts.DeployApp(appName, nil, appDef, partCount, appEngines, cfg.NumAppWorkspaces())
 	appParts.DeployAppPartitions(appName, []istructs.PartitionID{partID})
@@ -391,7 +391,7 @@ func TestBasicUsage_ServiceFactory(t *testing.T) {
 	appParts, cleanAppParts, appTokens, statelessResources := deployTestAppWithSecretToken(require, nil)
 	defer cleanAppParts()
 
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, iauthnzimpl.TestAPIKeyGetter, testingu.MockTime)
 	queryProcessor := ProvideServiceFactory()(
 		serviceChannel,
 		appParts,
@@ -406,7 +406,7 @@ func TestBasicUsage_ServiceFactory(t *testing.T) {
 	}()
 	systemToken := getSystemToken(appTokens)
 	requestSender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-		serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qNameFunction, "127.0.0.1", systemToken)
+		serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qNameFunction, "127.0.0.1", systemToken, "")
 	})
 	respCh, respMeta, respErr, err := requestSender.SendRequest(processorCtx, bus.Request{})
 	require.NoError(err)
@@ -1170,7 +1170,7 @@ func TestRateLimiter(t *testing.T) {
 
 	// create aquery processor
 	metrics := imetrics.Provide()
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, iauthnzimpl.TestAPIKeyGetter, testingu.MockTime)
 	queryProcessor := ProvideServiceFactory()(
 		serviceChannel,
 		appParts,
@@ -1183,7 +1183,7 @@ func TestRateLimiter(t *testing.T) {
 		"elements":[{"path":"","fields":["fld"]}]
 	}`)
 	requestSender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-		serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qName, "127.0.0.1", systemToken)
+		serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qName, "127.0.0.1", systemToken, "")
 	})
 
 	// execute query
@@ -1224,7 +1224,7 @@ func TestAuthnz(t *testing.T) {
 	appParts, cleanAppParts, appTokens, statelessResources := deployTestAppWithSecretToken(require, nil)
 	defer cleanAppParts()
 
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, iauthnzimpl.TestAPIKeyGetter, testingu.MockTime)
 	queryProcessor := ProvideServiceFactory()(
 		serviceChannel,
 		appParts,
@@ -1234,7 +1234,7 @@ func TestAuthnz(t *testing.T) {
 
 	t.Run("no token for a query that requires authorization -> 403 unauthorized", func(t *testing.T) {
 		requestSender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-			serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qNameFunction, "127.0.0.1", "")
+			serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qNameFunction, "127.0.0.1", "", "")
 		})
 		respCh, respMeta, respErr, err := requestSender.SendRequest(context.Background(), bus.Request{})
 
@@ -1253,7 +1253,7 @@ func TestAuthnz(t *testing.T) {
 		// make the token be expired
 		testingu.MockTime.Add(2 * time.Minute)
 		requestSender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-			serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qNameFunction, "127.0.0.1", systemToken)
+			serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qNameFunction, "127.0.0.1", systemToken, "")
 		})
 		respCh, respMeta, respErr, err := requestSender.SendRequest(context.Background(), bus.Request{})
 		require.NoError(err)
@@ -1269,7 +1269,7 @@ func TestAuthnz(t *testing.T) {
 	t.Run("token provided, query a denied func -> 403 forbidden", func(t *testing.T) {
 		token := getTestToken(appTokens, wsID)
 		requestSender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-			serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qNameQryDenied, "127.0.0.1", token)
+			serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, body, qNameQryDenied, "127.0.0.1", token, "")
 		})
 		respCh, respMeta, respErr, err := requestSender.SendRequest(context.Background(), bus.Request{})
 		require.NoError(err)
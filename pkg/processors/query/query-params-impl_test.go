@@ -25,7 +25,7 @@ func TestWrongTypes(t *testing.T) {
 	require := require.New(t)
 	serviceChannel := make(iprocbus.ServiceChannel)
 	done := make(chan struct{})
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, iauthnzimpl.TestAPIKeyGetter, testingu.MockTime)
 
 	appParts, cleanAppParts, appTokens, statelessResources := deployTestAppWithSecretToken(require, nil)
 
@@ -269,7 +269,7 @@ func TestWrongTypes(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			requestSender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-				serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, []byte(test.body), qNameFunction, "", sysToken)
+				serviceChannel <- NewQueryMessage(context.Background(), appName, partID, wsID, responder, []byte(test.body), qNameFunction, "", sysToken, "")
 			})
 			respCh, respMeta, respErr, err := requestSender.SendRequest(context.Background(), bus.Request{})
 			require.NoError(err)
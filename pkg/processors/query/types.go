@@ -100,6 +100,7 @@ type IQueryMessage interface {
 	Partition() istructs.PartitionID
 	Host() string
 	Token() string
+	AcceptLanguage() string // used to localize error messages
 }
 
 type IMetrics interface {
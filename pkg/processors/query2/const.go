@@ -59,6 +59,7 @@ const (
 	statusCode401 = "401"
 	statusCode403 = "403"
 	statusCode404 = "404"
+	statusCode409 = "409"
 	statusCode413 = "413"
 	statusCode415 = "415"
 	statusCode429 = "429"
@@ -85,6 +86,8 @@ const (
 	schemaFormatByte   = "byte"
 	schemaFormatBinary = "binary"
 
+	schemaFormatDateTime = "date-time"
+
 	schemaKeyType        = "type"
 	schemaKeyFormat      = "format"
 	schemaKeyDescription = "description"
@@ -128,6 +131,8 @@ const (
 	fieldPrincipalToken     = "principalToken"
 	fieldExpiresInSeconds   = "expiresInSeconds"
 	fieldProfileWSID        = "profileWSID"
+	fieldTOTPChallengeToken = "totpChallengeToken"
+	fieldAuthorizationURL   = "authorizationURL"
 	fieldCurrentWLogOffset  = "currentWLogOffset"
 	fieldNewIDs             = "newIDs"
 	fieldResults            = "results"
@@ -151,6 +156,23 @@ const (
 	paramArgs    = "args"
 )
 
+// OpenID Connect authorization response parameters
+const (
+	paramOIDCCode             = "code"
+	paramOIDCState            = "state"
+	paramOIDCError            = "error"
+	paramOIDCErrorDescription = "error_description"
+)
+
+// OpenID Connect flow
+const (
+	// prefix of the application TTL storage keys the authorization requests are kept by until the callback
+	keyPrefix_OIDCFlow = "query2.oidc.flow|"
+
+	// the user must sign in at the identity provider within the period
+	oidcFlowTTLSeconds = 600
+)
+
 // Parameter locations
 const (
 	paramInPath   = "path"
@@ -227,4 +249,6 @@ const (
 	descrDownloadBlob     = "Downloads BLOB from field '%s' of %s"
 	descrRefToDocRecord   = "Reference to a document or record"
 	descrIDOf             = "ID of: %s"
+	descrTimestamp        = "ISO-8601 date and time, e.g. \"2025-01-02T03:04:05.678Z\". Unix time in milliseconds is also accepted on write"
+	descrCurrency         = "Decimal with up to %d digits, %d of them fractional, e.g. \"12.34\". Integer scaled by 10^%[2]d is also accepted on write"
 )
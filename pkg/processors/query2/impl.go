@@ -19,6 +19,7 @@ import (
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/ioidc"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -37,7 +38,7 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel,
 	appParts appparts.IAppPartitions, maxPrepareQueries int, metrics imetrics.IMetrics, vvm string,
 	authn iauthnz.IAuthenticator, itokens itokens.ITokens, federation federation.IFederation,
 	statelessResources istructsmem.IStatelessResources, secretReader isecrets.ISecretReader,
-	stateOpts state.StateOpts, httpClient httpu.IHTTPClient) pipeline.IService {
+	stateOpts state.StateOpts, httpClient httpu.IHTTPClient, oidc ioidc.IRelyingParty) pipeline.IService {
 	return pipeline.NewService(func(ctx context.Context) {
 		var p pipeline.ISyncPipeline
 		for ctx.Err() == nil {
@@ -55,7 +56,7 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel,
 				func() { // borrowed application partition should be guaranteed to be freed
 					defer qwork.Release()
 					if p == nil {
-						p = newQueryProcessorPipeline(ctx, authn, itokens, federation, statelessResources, stateOpts, httpClient)
+						p = newQueryProcessorPipeline(ctx, authn, itokens, federation, statelessResources, stateOpts, httpClient, oidc)
 					}
 					err := p.SendSync(qwork)
 					if err != nil {
@@ -90,8 +91,12 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel,
 					var respWriter bus.IResponseWriter
 					statusCode := http.StatusOK
 					if err != nil {
-						statusCode = err.(coreutils.SysError).HTTPStatus // nolint:errorlint
+						sysErr := err.(coreutils.SysError) // nolint:errorlint
+						statusCode = sysErr.HTTPStatus
 						logger.ErrorCtx(qwork.msg.RequestCtx(), "qp.error", err)
+						if qwork.appStructs != nil {
+							err = sysErr.Localize(qwork.appStructs.AppDef(), msg.AcceptLanguage())
+						}
 					}
 					if qwork.apiPathHandler.isArrayResult {
 						if qwork.responseWriterGetter == nil || qwork.responseWriterGetter() == nil {
@@ -121,7 +126,7 @@ func implServiceFactory(serviceChannel iprocbus.ServiceChannel,
 // IStatelessResources need only for determine the exact result type of ANY
 func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthenticator,
 	itokens itokens.ITokens, federation federation.IFederation, statelessResources istructsmem.IStatelessResources,
-	stateOpts state.StateOpts, httpClient httpu.IHTTPClient) pipeline.ISyncPipeline {
+	stateOpts state.StateOpts, httpClient httpu.IHTTPClient, oidc ioidc.IRelyingParty) pipeline.ISyncPipeline {
 	ops := []*pipeline.WiredOperator{
 		operator("get api path handler", func(ctx context.Context, qw *queryWork) (err error) {
 			switch qw.msg.APIPath() {
@@ -148,6 +153,10 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 			case processors.APIPath_Auth_Refresh:
 				// [~server.authnz/cmp.provideAuthRefreshHandler~impl]
 				qw.apiPathHandler = authRefreshHandler()
+			case processors.APIPath_Auth_OIDCAuthorize:
+				qw.apiPathHandler = authOIDCAuthorizeHandler(oidc)
+			case processors.APIPath_Auth_OIDCCallback:
+				qw.apiPathHandler = authOIDCCallbackHandler(oidc, itokens)
 			default:
 				return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Sprintf("unsupported api path %v", qw.msg.APIPath()))
 			}
@@ -169,6 +178,7 @@ func newQueryProcessorPipeline(requestCtx context.Context, authn iauthnz.IAuthen
 				Host:        qw.msg.Host(),
 				RequestWSID: qw.msg.WSID(),
 				Token:       qw.msg.Token(),
+				Resource:    qw.msg.QName(),
 			}
 			qw.principals, qw.profileWSID, err = authn.Authenticate(qw.msg.RequestCtx(), qw.appStructs, qw.appStructs.AppTokens(), req)
 			return coreutils.WrapSysError(err, http.StatusUnauthorized)
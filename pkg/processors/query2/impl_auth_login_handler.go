@@ -61,6 +61,12 @@ func authLoginHandler() apiPathHandler {
 			wsError := resp.QPv2Response.Result()["WSError"].(string)
 			wsid := resp.QPv2Response.Result()["WSID"].(float64)
 
+			if totpChallengeToken, ok := resp.QPv2Response.Result()["TOTPChallengeToken"].(string); ok && len(totpChallengeToken) > 0 {
+				// second factor is required, the token is exchanged to the principal token by q.registry.IssuePrincipalTokenByTOTP
+				json := fmt.Sprintf(`{%q: %q}`, fieldTOTPChallengeToken, totpChallengeToken)
+				return qw.msg.Responder().Respond(bus.ResponseMeta{ContentType: httpu.ContentType_ApplicationJSON, StatusCode: http.StatusOK}, json)
+			}
+
 			if len(wsError) > 0 {
 				return errors.New("the login profile is created with an error: " + wsError)
 			}
@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package query2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/ioidc"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokens"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/registry"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+)
+
+// GET /api/v2/apps/{owner}/{app}/auth/oidc/authorize
+// returns the URL the user agent should be redirected to to sign in at the identity provider
+func authOIDCAuthorizeHandler(oidc ioidc.IRelyingParty) apiPathHandler {
+	return apiPathHandler{
+		exec: func(ctx context.Context, qw *queryWork) (err error) {
+			authReq, err := oidc.NewAuthRequest(ctx, qw.msg.AppQName())
+			if err != nil {
+				return oidcError(err)
+			}
+			flowJSON, err := json.Marshal(oidcFlow{
+				Nonce:        authReq.Nonce,
+				CodeVerifier: authReq.CodeVerifier,
+			})
+			if err != nil {
+				// notest
+				return err
+			}
+			ok, err := qw.appStructs.AppTTLStorage().InsertIfNotExists(keyPrefix_OIDCFlow+authReq.State, string(flowJSON), oidcFlowTTLSeconds)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				// notest: the state is random
+				return coreutils.NewHTTPErrorf(http.StatusConflict, "OpenID Connect state collision, try again")
+			}
+			json := fmt.Sprintf(`{%q: %q}`, fieldAuthorizationURL, authReq.URL)
+			return qw.msg.Responder().Respond(bus.ResponseMeta{ContentType: httpu.ContentType_ApplicationJSON, StatusCode: http.StatusOK}, json)
+		},
+	}
+}
+
+// GET /api/v2/apps/{owner}/{app}/auth/oidc/callback?code=...&state=...
+// the identity provider redirects the user agent here, the response is the same as for auth/login
+// the login is created on the first sign in, 409 is returned until its profile is ready, the same callback could be repeated then
+func authOIDCCallbackHandler(oidc ioidc.IRelyingParty, itokens itokens.ITokens) apiPathHandler {
+	return apiPathHandler{
+		exec: func(ctx context.Context, qw *queryWork) (err error) {
+			args := coreutils.MapObject(qw.queryParams.Argument)
+			idpError, _, err := args.AsString(paramOIDCError)
+			if err != nil {
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+			if len(idpError) > 0 {
+				idpErrorDescription, _, _ := args.AsString(paramOIDCErrorDescription)
+				return coreutils.NewHTTPErrorf(http.StatusUnauthorized, "identity provider denied the sign in: ", idpError, " ", idpErrorDescription)
+			}
+			code, _, err := args.AsString(paramOIDCCode)
+			if err != nil {
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+			state, _, err := args.AsString(paramOIDCState)
+			if err != nil {
+				return coreutils.NewHTTPError(http.StatusBadRequest, err)
+			}
+			if len(code) == 0 || len(state) == 0 {
+				return coreutils.NewHTTPErrorf(http.StatusBadRequest, "code and state must be provided")
+			}
+
+			ttlStorage := qw.appStructs.AppTTLStorage()
+			flowKey := keyPrefix_OIDCFlow + state
+			flowJSON, ok, err := ttlStorage.TTLGet(flowKey)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return coreutils.NewHTTPErrorf(http.StatusUnauthorized, "OpenID Connect state is unknown or expired")
+			}
+			flow := oidcFlow{}
+			if err := json.Unmarshal([]byte(flowJSON), &flow); err != nil {
+				// notest
+				return err
+			}
+
+			sysToken, err := payloads.GetSystemPrincipalToken(itokens, istructs.AppQName_sys_registry)
+			if err != nil {
+				// notest
+				return err
+			}
+
+			if len(flow.Login) == 0 {
+				claims, err := oidc.Exchange(ctx, qw.msg.AppQName(), code, ioidc.AuthRequest{
+					State:        state,
+					Nonce:        flow.Nonce,
+					CodeVerifier: flow.CodeVerifier,
+				})
+				if err != nil {
+					return oidcError(err)
+				}
+				flow.Login = registry.OIDCLogin(claims.Issuer, claims.Subject)
+				displayName := claims.DisplayName
+				if len(displayName) == 0 {
+					displayName = claims.Subject
+				}
+				if err := createOIDCLogin(qw, sysToken, flow.Login, displayName); err != nil {
+					return err
+				}
+				newFlowJSON, err := json.Marshal(flow)
+				if err != nil {
+					// notest
+					return err
+				}
+				ok, err := ttlStorage.CompareAndSwap(flowKey, flowJSON, string(newFlowJSON), oidcFlowTTLSeconds)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return coreutils.NewHTTPErrorf(http.StatusUnauthorized, "OpenID Connect state is used already")
+				}
+				flowJSON = string(newFlowJSON)
+			}
+
+			tokenArgs, err := json.Marshal(map[string]string{
+				"Login":   flow.Login,
+				"AppName": qw.msg.AppQName().String(),
+			})
+			if err != nil {
+				// notest
+				return err
+			}
+			pseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, flow.Login, istructs.CurrentClusterID())
+			reqURL := fmt.Sprintf(`api/v2/apps/%s/%s/workspaces/%d/queries/registry.IssuePrincipalTokenForOIDCLogin?args=%s`,
+				istructs.SysOwner, istructs.AppQName_sys_registry.Name(), pseudoWSID,
+				url.QueryEscape(string(tokenArgs)))
+			resp, err := qw.federation.Query(reqURL, httpu.WithAuthorizeBy(sysToken))
+			if err != nil {
+				return err
+			}
+			if resp.IsEmpty() {
+				return errors.New("registry.IssuePrincipalTokenForOIDCLogin response is empty")
+			}
+			result := resp.QPv2Response.Result()
+			token, okToken := result["PrincipalToken"].(string)
+			wsError, okWSError := result["WSError"].(string)
+			wsid, okWSID := result["WSID"].(float64)
+			if !okToken || !okWSError || !okWSID {
+				return errors.New("unexpected registry.IssuePrincipalTokenForOIDCLogin response")
+			}
+
+			if len(wsError) > 0 {
+				return errors.New("the login profile is created with an error: " + wsError)
+			}
+
+			if wsid == 0 {
+				return coreutils.NewHTTPError(http.StatusConflict, fmt.Errorf("profile workspace is not yet ready, try again later"))
+			}
+
+			// the state is for single sign in, the token is returned to the one who deleted it
+			ok, err = ttlStorage.CompareAndDelete(flowKey, flowJSON)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return coreutils.NewHTTPErrorf(http.StatusUnauthorized, "OpenID Connect state is used already")
+			}
+
+			expiresInSeconds := authnz.DefaultPrincipalTokenExpiration.Seconds()
+			json := fmt.Sprintf(`{%q: %q, %q: %d, %q: %d}`, fieldPrincipalToken, token, fieldExpiresInSeconds, int(expiresInSeconds),
+				fieldProfileWSID, int(wsid))
+			return qw.msg.Responder().Respond(bus.ResponseMeta{ContentType: httpu.ContentType_ApplicationJSON, StatusCode: http.StatusOK}, json)
+		},
+	}
+}
+
+// the login could exist already if the user signed in before
+func createOIDCLogin(qw *queryWork, sysToken string, login string, displayName string) error {
+	body, err := json.Marshal(map[string]any{
+		"args": map[string]any{
+			"Login":                    login,
+			"AppName":                  qw.msg.AppQName().String(),
+			"SubjectKind":              istructs.SubjectKind_User,
+			"WSKindInitializationData": fmt.Sprintf(`{"DisplayName":%q}`, displayName),
+			"ProfileCluster":           istructs.CurrentClusterID(),
+		},
+	})
+	if err != nil {
+		// notest
+		return err
+	}
+	pseudoWSID := coreutils.GetPseudoWSID(istructs.NullWSID, login, istructs.CurrentClusterID())
+	url := fmt.Sprintf("api/v2/apps/%s/%s/workspaces/%d/commands/registry.CreateOIDCLogin",
+		istructs.SysOwner, istructs.AppQName_sys_registry.Name(), pseudoWSID)
+	_, err = qw.federation.Func(url, string(body),
+		httpu.WithAuthorizeBy(sysToken),
+		httpu.WithMethod(http.MethodPost),
+		httpu.WithExpectedCode(http.StatusOK),
+		httpu.WithExpectedCode(http.StatusConflict),
+		httpu.WithDiscardResponse(),
+	)
+	return err
+}
+
+func oidcError(err error) error {
+	switch {
+	case errors.Is(err, ioidc.ErrProviderNotConfigured):
+		return coreutils.NewHTTPError(http.StatusNotFound, err)
+	case errors.Is(err, ioidc.ErrDiscovery):
+		return coreutils.NewHTTPError(http.StatusBadGateway, err)
+	case errors.Is(err, ioidc.ErrCodeExchange), errors.Is(err, ioidc.ErrIDTokenInvalid):
+		return coreutils.NewHTTPError(http.StatusUnauthorized, err)
+	}
+	return err
+}
@@ -166,6 +166,10 @@ func (g *schemaGenerator) generateComponents() {
 				schemaKeyType:   schemaTypeInteger,
 				schemaKeyFormat: schemaFormatInt64,
 			},
+			// returned instead of the fields above if the login requires the second factor
+			fieldTOTPChallengeToken: map[string]interface{}{
+				schemaKeyType: schemaTypeString,
+			},
 		},
 	}
 
@@ -347,6 +351,8 @@ func (g *schemaGenerator) isFieldAllowed(fieldName string, fieldNames *[]appdef.
 func (g *schemaGenerator) addAuthPaths() {
 	g.genAuthLoginPath()
 	g.genAuthRefreshPath()
+	g.genAuthOIDCAuthorizePath()
+	g.genAuthOIDCCallbackPath()
 }
 
 func (g *schemaGenerator) genCreateNewUserPath() {
@@ -491,6 +497,67 @@ func (g *schemaGenerator) genAuthRefreshPath() {
 	}
 }
 
+func (g *schemaGenerator) genAuthOIDCAuthorizePath() {
+	path := fmt.Sprintf("/api/v2/apps/%s/%s/auth/oidc/authorize", g.getOwner(), g.getApp())
+	g.paths[path] = map[string]interface{}{
+		schemaMethodGet: map[string]interface{}{
+			schemaKeyDescription: "Starts the sign in by the OpenID Connect identity provider, returns the URL the user agent should be redirected to",
+			schemaKeyTags:        []string{authenticationTag},
+			schemaKeyParameters:  g.generateParameters(path, nil),
+			schemaKeyResponses: map[string]interface{}{
+				statusCode200: map[string]interface{}{
+					schemaKeyDescription: descrOK,
+					schemaKeyContent: map[string]interface{}{
+						applicationJSON: map[string]interface{}{
+							schemaKeySchema: map[string]interface{}{
+								schemaKeyType: schemaTypeObject,
+								schemaKeyProperties: map[string]interface{}{
+									fieldAuthorizationURL: map[string]interface{}{
+										schemaKeyType: schemaTypeString,
+									},
+								},
+								schemaKeyRequired: []string{fieldAuthorizationURL},
+							},
+						},
+					},
+				},
+				statusCode404: g.genErrorResponse(http.StatusNotFound),
+				statusCode429: g.genErrorResponse(http.StatusTooManyRequests),
+			},
+		},
+	}
+}
+
+func (g *schemaGenerator) genAuthOIDCCallbackPath() {
+	path := fmt.Sprintf("/api/v2/apps/%s/%s/auth/oidc/callback", g.getOwner(), g.getApp())
+	parameters := g.generateParameters(path, nil)
+	for _, param := range []string{paramOIDCCode, paramOIDCState} {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     param,
+			"in":       paramInQuery,
+			"required": true,
+			"schema": map[string]interface{}{
+				schemaKeyType: schemaTypeString,
+			},
+		})
+	}
+	g.paths[path] = map[string]interface{}{
+		schemaMethodGet: map[string]interface{}{
+			schemaKeyDescription: "Issues (creates) a new principal token for the user signed in by the OpenID Connect identity provider. " +
+				"The login is created on the first sign in, 409 is returned until its profile is ready",
+			schemaKeyTags:       []string{authenticationTag},
+			schemaKeyParameters: parameters,
+			schemaKeyResponses: map[string]interface{}{
+				statusCode200: g.genOKResponse(principalTokenSchemaRef),
+				statusCode400: g.genErrorResponse(http.StatusBadRequest),
+				statusCode401: g.genErrorResponse(http.StatusUnauthorized),
+				statusCode409: g.genErrorResponse(http.StatusConflict),
+				statusCode429: g.genErrorResponse(http.StatusTooManyRequests),
+			},
+		},
+	}
+}
+
 func (g *schemaGenerator) genOKResponse(schemaRef string) map[string]interface{} {
 	if schemaRef == "" {
 		return map[string]interface{}{
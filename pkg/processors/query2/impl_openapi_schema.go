@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
 )
 
 func (g *schemaGenerator) generateSchema(ischema ischema, op appdef.OperationKind, fieldNames *[]appdef.FieldName) map[string]interface{} {
@@ -114,8 +115,23 @@ func (g *schemaGenerator) generateFieldSchema(field appdef.IField, op appdef.Ope
 		schema[schemaKeyType] = schemaTypeInteger
 		schema[schemaKeyFormat] = schemaFormatInt32
 	case appdef.DataKind_int64:
-		schema[schemaKeyType] = schemaTypeInteger
-		schema[schemaKeyFormat] = schemaFormatInt64
+		switch {
+		case coreutils.IsTimestamp(field):
+			schema[schemaKeyType] = schemaTypeString
+			schema[schemaKeyFormat] = schemaFormatDateTime
+			schema[schemaKeyDescription] = descrTimestamp
+		case coreutils.IsCurrency(field):
+			spec := coreutils.CurrencySpec(field)
+			schema[schemaKeyType] = schemaTypeString
+			schema[propertyPattern] = `^[+-]?\d+$`
+			if spec.Scale > 0 {
+				schema[propertyPattern] = fmt.Sprintf(`^[+-]?(\d+(\.\d{1,%[1]d})?|\.\d{1,%[1]d})$`, spec.Scale)
+			}
+			schema[schemaKeyDescription] = fmt.Sprintf(descrCurrency, spec.Precision, spec.Scale)
+		default:
+			schema[schemaKeyType] = schemaTypeInteger
+			schema[schemaKeyFormat] = schemaFormatInt64
+		}
 	case appdef.DataKind_float32:
 		schema[schemaKeyType] = schemaTypeNumber
 		schema[schemaKeyFormat] = schemaFormatFloat
@@ -69,11 +69,11 @@ func queryRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
 	if qw.queryParams.Constraints != nil && len(qw.queryParams.Constraints.Include) != 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Include", newInclude(qw, false)))
 	}
+	resultFields := qw.appStructs.AppDef().Type(result.QName()).(appdef.IWithFields).Fields()
 	if qw.queryParams.Constraints != nil && (len(qw.queryParams.Constraints.Order) != 0 || qw.queryParams.Constraints.Skip > 0 || qw.queryParams.Constraints.Limit > 0) {
-		oo = append(oo, pipeline.WireAsyncOperator("Aggregator", newAggregator(qw.queryParams)))
+		oo = append(oo, pipeline.WireAsyncOperator("Aggregator", newAggregator(qw.queryParams, resultFields)))
 	}
-	resultType := qw.appStructs.AppDef().Type(result.QName())
-	o, err := newFilter(qw, resultType.(appdef.IWithFields).Fields())
+	o, err := newFilter(qw, resultFields)
 	if err != nil {
 		return err
 	}
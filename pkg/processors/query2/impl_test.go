@@ -7,11 +7,16 @@
 package query2
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/appdef/constraints"
 )
 
 func Test_getCombinations(t *testing.T) {
@@ -186,3 +191,103 @@ func Test_splitPath(t *testing.T) {
 		})
 	}
 }
+
+func Test_storageOrder(t *testing.T) {
+	wsName := appdef.NewQName("test", "ws")
+	viewName := appdef.NewQName("test", "view")
+	adb := builder.New()
+	wsb := adb.AddWorkspace(wsName)
+	vb := wsb.AddView(viewName)
+	vb.Key().PartKey().AddField("pk", appdef.DataKind_int32)
+	vb.Key().ClustCols().AddField("cc1", appdef.DataKind_int32).AddField("cc2", appdef.DataKind_int32)
+	vb.Value().AddField("val", appdef.DataKind_int32, false)
+	view := appdef.View(adb.MustBuild().Type, viewName)
+
+	tests := []struct {
+		order   []string
+		ok      bool
+		reverse bool
+	}{
+		{order: nil},
+		{order: []string{"cc1"}, ok: true},
+		{order: []string{"-cc1"}, ok: true, reverse: true},
+		{order: []string{"cc1", "cc2"}, ok: true},
+		{order: []string{"-cc1", "-cc2"}, ok: true, reverse: true},
+		{order: []string{"cc1", "-cc2"}},
+		{order: []string{"cc2"}},
+		{order: []string{"val"}},
+		{order: []string{"cc1", "cc2", "val"}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.order), func(t *testing.T) {
+			ok, reverse := storageOrder(view, test.order)
+			require.Equal(t, test.ok, ok)
+			require.Equal(t, test.reverse, reverse)
+		})
+	}
+}
+
+func Test_sysDataWhere(t *testing.T) {
+	require := require.New(t)
+
+	objName := appdef.NewQName("test", "obj")
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+	wsb := adb.AddWorkspace(appdef.NewQName("test", "ws"))
+	wsb.AddObject(objName).
+		AddDataField("ts", appdef.SysData_Timestamp, false).
+		AddDataField("price", appdef.SysData_Currency, false).
+		AddDataField("amount", appdef.SysData_Currency, false, constraints.Precision(18), constraints.Scale(2))
+	app, err := adb.Build()
+	require.NoError(err)
+	obj := appdef.Object(app.Type, objName)
+
+	t.Run("range of timestamps", func(t *testing.T) {
+		c, err := Where{"ts": map[string]interface{}{
+			"$gte": "2025-01-01T00:00:00Z",
+			"$lt":  json.Number("1735776000000"), // 2025-01-02T00:00:00Z
+		}}.getAsSysData(obj.Field("ts"))
+		require.NoError(err)
+		for value, want := range map[string]bool{
+			"2024-12-31T23:59:59.999Z": false,
+			"2025-01-01T00:00:00.000Z": true,
+			"2025-01-01T23:59:59.999Z": true,
+			"2025-01-02T00:00:00.000Z": false,
+		} {
+			v, ok := sysDataValue(c.field, value)
+			require.True(ok)
+			require.Equal(want, c.match(v), value)
+		}
+	})
+
+	t.Run("currencies", func(t *testing.T) {
+		c, err := Where{"price": map[string]interface{}{"$in": []interface{}{"9.5", "100"}}}.getAsSysData(obj.Field("price"))
+		require.NoError(err)
+		for value, want := range map[string]bool{"9.5000": true, "100.0000": true, "12.3400": false} {
+			v, _ := sysDataValue(c.field, value)
+			require.Equal(want, c.match(v), value)
+		}
+
+		c, err = Where{"price": "12.34"}.getAsSysData(obj.Field("price"))
+		require.NoError(err)
+		require.True(c.match(123400))
+		require.False(c.match(123401))
+
+		c, err = Where{"amount": "12.34"}.getAsSysData(obj.Field("amount"))
+		require.NoError(err)
+		require.True(c.match(1234))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Where{"price": ".+5"}.getAsSysData(obj.Field("price"))
+		require.Error(err)
+		_, err = Where{"price": map[string]interface{}{"$ne": "1"}}.getAsSysData(obj.Field("price"))
+		require.ErrorIs(err, errUnsupportedConstraint)
+		_, err = Where{"price": true}.getAsSysData(obj.Field("price"))
+		require.ErrorIs(err, errUnsupportedType)
+
+		c, err := Where{}.getAsSysData(obj.Field("price"))
+		require.NoError(err)
+		require.Nil(c)
+	})
+}
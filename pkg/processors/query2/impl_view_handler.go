@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
@@ -75,12 +76,24 @@ func viewRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
 	if err != nil {
 		return
 	}
+	if qw.viewKeys, err = getKeys(qw); err != nil {
+		return
+	}
+	aggregatorParams := qw.queryParams
+	if len(qw.viewKeys) == 1 {
+		orderedByStorage := false
+		if orderedByStorage, qw.viewReverse = storageOrder(qw.iView, qw.queryParams.Constraints.Order); orderedByStorage {
+			constraints := *qw.queryParams.Constraints
+			constraints.Order = nil
+			aggregatorParams.Constraints = &constraints
+		}
+	}
 	oo := make([]*pipeline.WiredOperator, 0)
 	if len(qw.queryParams.Constraints.Include) != 0 {
 		oo = append(oo, pipeline.WireAsyncOperator("Include", newInclude(qw, false)))
 	}
-	if len(qw.queryParams.Constraints.Order) != 0 || qw.queryParams.Constraints.Skip > 0 || qw.queryParams.Constraints.Limit > 0 {
-		oo = append(oo, pipeline.WireAsyncOperator("Aggregator", newAggregator(qw.queryParams)))
+	if len(aggregatorParams.Constraints.Order) != 0 || aggregatorParams.Constraints.Skip > 0 || aggregatorParams.Constraints.Limit > 0 {
+		oo = append(oo, pipeline.WireAsyncOperator("Aggregator", newAggregator(aggregatorParams, qw.iView.Fields())))
 	}
 	fields := make([]appdef.IField, 0, 2)
 	fields = append(fields, qw.appStructs.AppDef().Type(qw.iView.QName()).(appdef.IView).Key().ClustCols().Fields()...)
@@ -102,12 +115,12 @@ func viewRowsProcessor(ctx context.Context, qw *queryWork) (err error) {
 	return
 }
 func viewExec(ctx context.Context, qw *queryWork) (err error) {
-	kk, err := getKeys(qw)
-	if err != nil {
-		return
+	read := qw.appStructs.ViewRecords().Read
+	if qw.viewReverse {
+		read = qw.appStructs.ViewRecords().ReadReverse
 	}
-	for i := range kk {
-		err = qw.appStructs.ViewRecords().Read(ctx, qw.msg.WSID(), kk[i], func(key istructs.IKey, value istructs.IValue) (err error) {
+	for i := range qw.viewKeys {
+		err = read(ctx, qw.msg.WSID(), qw.viewKeys[i], func(key istructs.IKey, value istructs.IValue) (err error) {
 			obj := objectBackedByMap{}
 			obj.data = coreutils.FieldsToMap(key, qw.appStructs.AppDef())
 			for k, v := range coreutils.FieldsToMap(value, qw.appStructs.AppDef()) {
@@ -121,6 +134,28 @@ func viewExec(ctx context.Context, qw *queryWork) (err error) {
 	}
 	return
 }
+
+// Returns true if records read from the storage are already ordered as requested, i.e. order fields are
+// the leading clustering columns of the view with the same direction. Returns reverse == true if records
+// should be read in descending order.
+func storageOrder(view appdef.IView, order []string) (ok, reverse bool) {
+	ccols := view.Key().ClustCols().Fields()
+	if len(order) == 0 || len(order) > len(ccols) {
+		return false, false
+	}
+	for i, o := range order {
+		name, desc := strings.CutPrefix(o, "-")
+		if name != ccols[i].Name() {
+			return false, false
+		}
+		if i == 0 {
+			reverse = desc
+		} else if desc != reverse {
+			return false, false
+		}
+	}
+	return true, reverse
+}
 func getKeys(qw *queryWork) (keys []istructs.IKeyBuilder, err error) {
 	fields := qw.appStructs.AppDef().Type(qw.iView.QName()).(appdef.IView).Key().Fields()
 	values := make([][]interface{}, 0, len(fields))
@@ -155,6 +190,23 @@ func getKeys(qw *queryWork) (keys []istructs.IKeyBuilder, err error) {
 			for _, v := range vv {
 				values[i] = append(values[i], v)
 			}
+		case appdef.DataKind_int64:
+			if !coreutils.IsTimestamp(field) && !coreutils.IsCurrency(field) {
+				continue
+			}
+			c, err := qw.queryParams.Constraints.Where.getAsSysData(field)
+			if err != nil {
+				return nil, err
+			}
+			if c == nil || c.in == nil {
+				// range is checked by filter
+				partialKey = true
+				continue
+			}
+			values = append(values, make([]interface{}, 0))
+			for v := range c.in {
+				values[i] = append(values[i], v)
+			}
 		default:
 			// do nothing
 		}
@@ -169,6 +221,8 @@ func getKeys(qw *queryWork) (keys []istructs.IKeyBuilder, err error) {
 				keys[i].PutInt32(fields[j].Name(), v)
 			case string:
 				keys[i].PutString(fields[j].Name(), v)
+			case int64:
+				keys[i].PutInt64(fields[j].Name(), v)
 			}
 		}
 	}
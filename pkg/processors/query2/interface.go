@@ -9,6 +9,7 @@ import (
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/ioidc"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructsmem"
@@ -23,4 +24,4 @@ type ServiceFactory func(serviceChannel iprocbus.ServiceChannel,
 	authn iauthnz.IAuthenticator, itokens itokens.ITokens,
 	federation federation.IFederation,
 	statelessResources istructsmem.IStatelessResources, secretReader isecrets.ISecretReader,
-	stateOpts state.StateOpts, httpClient httpu.IHTTPClient) pipeline.IService
+	stateOpts state.StateOpts, httpClient httpu.IHTTPClient, oidc ioidc.IRelyingParty) pipeline.IService
@@ -53,6 +53,7 @@ type IQueryMessage interface {
 	Token() string
 	WorkspaceQName() appdef.QName // actually wsKind
 	Accept() string
+	AcceptLanguage() string // used to localize error messages
 }
 
 type apiPathHandler struct {
@@ -101,6 +102,7 @@ type implIQueryMessage struct {
 	token          string
 	workspaceQName appdef.QName
 	headerAccept   string
+	acceptLanguage string
 }
 
 var _ IQueryMessage = (*implIQueryMessage)(nil)
@@ -108,6 +110,10 @@ var _ IQueryMessage = (*implIQueryMessage)(nil)
 func (qm *implIQueryMessage) Accept() string {
 	return qm.headerAccept
 }
+
+func (qm *implIQueryMessage) AcceptLanguage() string {
+	return qm.acceptLanguage
+}
 func (qm *implIQueryMessage) AppQName() appdef.AppQName {
 	return qm.appQName
 }
@@ -227,16 +233,24 @@ func (k *keys) DoAsync(_ context.Context, work pipeline.IWorkpiece) (outWork pip
 
 type aggregator struct {
 	pipeline.AsyncNOOP
-	params      QueryParams
-	orderParams map[string]bool
-	ww          []pipeline.IWorkpiece
+	params        QueryParams
+	orderParams   map[string]bool
+	sysDataFields map[string]appdef.IField
+	ww            []pipeline.IWorkpiece
 }
 
-func newAggregator(params QueryParams) pipeline.IAsyncOperator {
+// fields are used to order timestamp and currency values by their int64 values
+func newAggregator(params QueryParams, fields []appdef.IField) pipeline.IAsyncOperator {
 	a := &aggregator{
-		params:      params,
-		orderParams: make(map[string]bool),
-		ww:          make([]pipeline.IWorkpiece, 0),
+		params:        params,
+		orderParams:   make(map[string]bool),
+		sysDataFields: make(map[string]appdef.IField),
+		ww:            make([]pipeline.IWorkpiece, 0),
+	}
+	for _, f := range fields {
+		if coreutils.IsTimestamp(f) || coreutils.IsCurrency(f) {
+			a.sysDataFields[f.Name()] = f
+		}
 	}
 	for _, s := range params.Constraints.Order {
 		if strings.HasPrefix(s, "-") {
@@ -271,6 +285,11 @@ func (a *aggregator) order() (err error) {
 		for orderBy, asc := range a.orderParams {
 			vi := a.ww[i].(objectBackedByMap).data[orderBy]
 			vj := a.ww[j].(objectBackedByMap).data[orderBy]
+			if field, ok := a.sysDataFields[orderBy]; ok {
+				ii, _ := sysDataValue(field, vi)
+				ij, _ := sysDataValue(field, vj)
+				return a.compareInt64(ii, ij, asc)
+			}
 			switch typed := vi.(type) {
 			case int32:
 				return a.compareInt32(typed, vj.(int32), asc)
@@ -381,14 +400,16 @@ func (s *sender) OnError(_ context.Context, err error) {
 
 type filter struct {
 	pipeline.AsyncNOOP
-	Int32  map[string]map[int32]bool
-	String map[string]map[string]bool
+	Int32   map[string]map[int32]bool
+	String  map[string]map[string]bool
+	SysData map[string]*sysDataCondition
 }
 
 func newFilter(qw *queryWork, fields []appdef.IField) (o pipeline.IAsyncOperator, err error) {
 	f := &filter{
-		Int32:  make(map[string]map[int32]bool),
-		String: make(map[string]map[string]bool),
+		Int32:   make(map[string]map[int32]bool),
+		String:  make(map[string]map[string]bool),
+		SysData: make(map[string]*sysDataCondition),
 	}
 	if qw.queryParams.Constraints == nil || qw.queryParams.Constraints.Where == nil || len(qw.queryParams.Constraints.Where) == 0 {
 		return nil, nil
@@ -421,11 +442,22 @@ func newFilter(qw *queryWork, fields []appdef.IField) (o pipeline.IAsyncOperator
 				}
 				m[v] = true
 			}
+		case appdef.DataKind_int64:
+			if !coreutils.IsTimestamp(field) && !coreutils.IsCurrency(field) {
+				break
+			}
+			c, err := qw.queryParams.Constraints.Where.getAsSysData(field)
+			if err != nil {
+				return nil, err
+			}
+			if c != nil {
+				f.SysData[field.Name()] = c
+			}
 		default:
 			// Do nothing
 		}
 	}
-	if len(f.Int32) == 0 && len(f.String) == 0 {
+	if len(f.Int32) == 0 && len(f.String) == 0 && len(f.SysData) == 0 {
 		return nil, nil
 	}
 	return f, nil
@@ -442,9 +474,62 @@ func (f filter) DoAsync(_ context.Context, work pipeline.IWorkpiece) (outWork pi
 			return nil, nil
 		}
 	}
+	for fieldName, c := range f.SysData {
+		v, ok := sysDataValue(c.field, work.(objectBackedByMap).data[fieldName])
+		if !ok || !c.match(v) {
+			return nil, nil
+		}
+	}
 	return work, nil
 }
 
+// equality, $in and range conditions on timestamp or currency field.
+// Values are provided as ISO-8601 or decimal strings or as int64 numbers
+type sysDataCondition struct {
+	field            appdef.IField
+	in               map[int64]bool
+	gt, gte, lt, lte *int64
+}
+
+func (c *sysDataCondition) match(v int64) bool {
+	switch {
+	case c.in != nil && !c.in[v],
+		c.gt != nil && v <= *c.gt,
+		c.gte != nil && v < *c.gte,
+		c.lt != nil && v >= *c.lt,
+		c.lte != nil && v > *c.lte:
+		return false
+	}
+	return true
+}
+
+// returns int64 value of timestamp or currency field from the result data
+func sysDataValue(field appdef.IField, value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case string:
+		res, _, err := coreutils.ParseSysDataValue(field, v)
+		return res, err == nil
+	}
+	return 0, false
+}
+
+func parseSysDataParam(field appdef.IField, param interface{}) (int64, error) {
+	switch v := param.(type) {
+	case string:
+		res, _, err := coreutils.ParseSysDataValue(field, v)
+		return res, err
+	case json.Number:
+		res, err := coreutils.ClarifyJSONNumber(v, appdef.DataKind_int64)
+		if err != nil {
+			return 0, err
+		}
+		return res.(int64), nil
+	}
+	return 0, errUnsupportedType
+}
+
 type Where map[string]interface{}
 
 func (w Where) getAsInt32(k string) (vv []int32, err error) {
@@ -479,6 +564,57 @@ func (w Where) getAsInt32(k string) (vv []int32, err error) {
 		return nil, errUnsupportedType
 	}
 }
+func (w Where) getAsSysData(field appdef.IField) (c *sysDataCondition, err error) {
+	c = &sysDataCondition{field: field}
+	switch v := w[field.Name()].(type) {
+	case string, json.Number:
+		val, err := parseSysDataParam(field, v)
+		if err != nil {
+			return nil, err
+		}
+		c.in = map[int64]bool{val: true}
+		return c, nil
+	case map[string]interface{}:
+		for op, param := range v {
+			if op == "$in" {
+				params, ok := param.([]interface{})
+				if !ok {
+					return nil, errUnexpectedParams
+				}
+				c.in = make(map[int64]bool, len(params))
+				for _, p := range params {
+					val, err := parseSysDataParam(field, p)
+					if err != nil {
+						return nil, err
+					}
+					c.in[val] = true
+				}
+				continue
+			}
+			val, err := parseSysDataParam(field, param)
+			if err != nil {
+				return nil, err
+			}
+			switch op {
+			case "$gt":
+				c.gt = &val
+			case "$gte":
+				c.gte = &val
+			case "$lt":
+				c.lt = &val
+			case "$lte":
+				c.lte = &val
+			default:
+				return nil, errUnsupportedConstraint
+			}
+		}
+		return c, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, errUnsupportedType
+	}
+}
 func (w Where) getAsString(k string) (vv []string, err error) {
 	switch v := w[k].(type) {
 	case string:
@@ -689,3 +825,12 @@ func (i include) checkField(parent map[string]interface{}, refFieldOrContainer s
 	}
 	return fmt.Errorf("field expression - '%s', '%s' - %w", strings.Join(refFieldOrContainerExpression, "."), refFieldOrContainer, errUnexpectedField)
 }
+
+// kept in the application TTL storage between the authorization request and the callback
+type oidcFlow struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+
+	// set when the login is created already, the callback could be repeated then until the profile is ready
+	Login string `json:"login,omitempty"`
+}
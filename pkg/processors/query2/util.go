@@ -32,7 +32,8 @@ func queryRateLimitExceeded(ctx context.Context, qw *queryWork) error {
 	if !exceeded {
 		return nil
 	}
-	retryAfter := processors.RetryAfterSecondsOnLimitExceeded(qw.appStructs.AppDef(), limit)
+	retryAfter := processors.RetryAfterSecondsOnLimitExceeded(qw.appStructs.AppDef(), limit,
+		qw.appPart.LimitRetryAfter(limit, qw.msg.QName(), qw.msg.WSID(), qw.msg.Host()))
 	return coreutils.NewHTTPErrorf(http.StatusTooManyRequests).AddHeader(httpu.RetryAfter, strconv.Itoa(retryAfter))
 }
 func querySetRequestType(ctx context.Context, qw *queryWork) error {
@@ -76,6 +77,8 @@ type queryWork struct {
 	iWorkspace           appdef.IWorkspace
 	iQuery               appdef.IQuery
 	iView                appdef.IView
+	viewKeys             []istructs.IKeyBuilder
+	viewReverse          bool // read view records in descending order of clustering columns
 	iDoc                 appdef.IDoc
 	iRecord              appdef.IContainedRecord
 	wsDesc               istructs.IRecord
@@ -169,7 +172,8 @@ func operator(name string, doSync func(ctx context.Context, qw *queryWork) (err
 
 func NewIQueryMessage(requestCtx context.Context, appQName appdef.AppQName, wsid istructs.WSID, responder bus.IResponder,
 	rawParams map[string]string, docID istructs.IDType, apiPath processors.APIPath,
-	qName appdef.QName, partition istructs.PartitionID, host string, token string, workspaceQName appdef.QName, headerAccept string) IQueryMessage {
+	qName appdef.QName, partition istructs.PartitionID, host string, token string, workspaceQName appdef.QName, headerAccept string,
+	acceptLanguage string) IQueryMessage {
 	return &implIQueryMessage{
 		appQName:       appQName,
 		wsid:           wsid,
@@ -184,6 +188,7 @@ func NewIQueryMessage(requestCtx context.Context, appQName appdef.AppQName, wsid
 		token:          token,
 		workspaceQName: workspaceQName,
 		headerAccept:   headerAccept,
+		acceptLanguage: acceptLanguage,
 	}
 }
 
@@ -102,7 +102,7 @@ func (a *scheduler) runJob() {
 		a.conf.Federation,
 		func() int64 { return a.conf.Time.Now().Unix() },
 		a.conf.IntentsLimit,
-		a.conf.stateOpts,
+		a.conf.StateOpts,
 		a.conf.EmailSender,
 		a.conf.HTTPClient,
 	)
@@ -12,6 +12,7 @@ import (
 	"iter"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appdef/builder"
@@ -105,6 +106,9 @@ func (m *mockAppPartitions) DeployApp(_ appdef.AppQName, _ map[string]*url.URL,
 func (m *mockAppPartitions) DeployAppPartitions(_ appdef.AppQName, _ []istructs.PartitionID) {
 	panic("not implemented")
 }
+func (m *mockAppPartitions) UndeployAppPartitions(_ appdef.AppQName, _ []istructs.PartitionID) {
+	panic("not implemented")
+}
 func (m *mockAppPartitions) AppPartsCount(_ appdef.AppQName) (istructs.NumAppPartitions, error) {
 	panic("not implemented")
 }
@@ -120,7 +124,7 @@ func (m *mockAppPartitions) WorkedActualizers(_ appdef.AppQName) iter.Seq2[istru
 func (m *mockAppPartitions) WorkedSchedulers(_ appdef.AppQName) iter.Seq2[istructs.PartitionID, map[appdef.QName][]istructs.WSID] {
 	return nil
 }
-func (m *mockAppPartitions) UpgradeAppDef(_ appdef.AppQName, _ appdef.IAppDef) {
+func (m *mockAppPartitions) RedeployApp(_ appdef.AppQName, _ map[string]*url.URL, _ appdef.IAppDef, _ [appparts.ProcessorKind_Count]uint) error {
 	panic("not implemented")
 }
 
@@ -128,6 +132,7 @@ type mockAppPartition struct{}
 
 func (m *mockAppPartition) App() appdef.AppQName             { panic("not implemented") }
 func (m *mockAppPartition) ID() istructs.PartitionID         { panic("not implemented") }
+func (m *mockAppPartition) DeploymentID() uint64             { panic("not implemented") }
 func (m *mockAppPartition) AppStructs() istructs.IAppStructs { return nil }
 func (m *mockAppPartition) Release()                         {}
 func (m *mockAppPartition) DoSyncActualizer(_ context.Context, _ pipeline.IWorkpiece) error {
@@ -142,5 +147,8 @@ func (m *mockAppPartition) IsOperationAllowed(_ appdef.IWorkspace, _ appdef.Oper
 func (m *mockAppPartition) IsLimitExceeded(_ appdef.QName, _ appdef.OperationKind, _ istructs.WSID, _ string) (bool, appdef.QName) {
 	panic("not implemented")
 }
+func (m *mockAppPartition) LimitRetryAfter(_ appdef.QName, _ appdef.QName, _ istructs.WSID, _ string) time.Duration {
+	panic("not implemented")
+}
 func (m *mockAppPartition) ResetRateLimit(_ appdef.QName, _ appdef.OperationKind, _ istructs.WSID, _ string) {
 }
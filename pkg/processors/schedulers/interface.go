@@ -28,7 +28,7 @@ type BasicSchedulerConfig struct {
 	Federation   federation.IFederation
 	Time         timeu.ITime
 
-	stateOpts  state.StateOpts
+	StateOpts  state.StateOpts
 	HTTPClient httpu.IHTTPClient
 
 	//IntentsLimit top limit per event, optional, default value is 100
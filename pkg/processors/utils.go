@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
@@ -23,9 +24,15 @@ import (
 	"github.com/voedger/voedger/pkg/sys"
 )
 
-func RetryAfterSecondsOnLimitExceeded(appDef appdef.IAppDef, limit appdef.QName) int {
-	rate := appdef.Limit(appDef.Type, limit).Rate()
-	seconds := int(math.Ceil(rate.Period().Seconds() / float64(rate.Count())))
+// Returns seconds to wait before retry if the limit is exceeded.
+//
+// If the wait time reported by the rate buckets is unknown (zero), then it is estimated as the time to refill one token.
+func RetryAfterSecondsOnLimitExceeded(appDef appdef.IAppDef, limit appdef.QName, wait time.Duration) int {
+	if wait <= 0 {
+		rate := appdef.Limit(appDef.Type, limit).Rate()
+		wait = rate.Period() / time.Duration(rate.Count())
+	}
+	seconds := int(math.Ceil(wait.Seconds()))
 	if seconds < 1 {
 		return 1
 	}
@@ -39,21 +39,26 @@ func TestRetryAfterSecondsOnLimitExceeded(t *testing.T) {
 
 	t.Run("integer seconds", func(t *testing.T) {
 		app, limit := buildApp(6, time.Minute) // 60s / 6 = 10s
-		require.Equal(10, RetryAfterSecondsOnLimitExceeded(app, limit))
+		require.Equal(10, RetryAfterSecondsOnLimitExceeded(app, limit, 0))
 	})
 
 	t.Run("sub-second per token rounds up to 1", func(t *testing.T) {
 		app, limit := buildApp(1000, time.Minute) // 60s / 1000 = 0.06s -> 1
-		require.Equal(1, RetryAfterSecondsOnLimitExceeded(app, limit))
+		require.Equal(1, RetryAfterSecondsOnLimitExceeded(app, limit, 0))
 	})
 
 	t.Run("fractional seconds rounded up", func(t *testing.T) {
 		app, limit := buildApp(7, time.Minute) // 60/7 = 8.57... -> 9
-		require.Equal(9, RetryAfterSecondsOnLimitExceeded(app, limit))
+		require.Equal(9, RetryAfterSecondsOnLimitExceeded(app, limit, 0))
 	})
 
 	t.Run("long period", func(t *testing.T) {
 		app, limit := buildApp(1, time.Hour) // 3600s / 1 = 3600
-		require.Equal(3600, RetryAfterSecondsOnLimitExceeded(app, limit))
+		require.Equal(3600, RetryAfterSecondsOnLimitExceeded(app, limit, 0))
+	})
+	t.Run("exact wait rounded up", func(t *testing.T) {
+		app, limit := buildApp(1, time.Hour)
+		require.Equal(1, RetryAfterSecondsOnLimitExceeded(app, limit, 10*time.Millisecond))
+		require.Equal(43, RetryAfterSecondsOnLimitExceeded(app, limit, 42*time.Second+time.Millisecond))
 	})
 }
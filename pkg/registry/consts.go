@@ -7,42 +7,95 @@ package registry
 
 import (
 	"embed"
+	"errors"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
 )
 
 const (
-	RegistryPackage         = "registry"
-	RegistryPackageFQN      = "github.com/voedger/voedger/pkg/" + RegistryPackage
-	field_AppWSID           = "AppWSID"
-	field_AppIDLoginHash    = "AppIDLoginHash"
-	field_CDocLoginID       = "CDocLoginID"
-	field_PwdHash           = "PwdHash"
-	field_Passwrd           = "Password"
-	field_NewPassword       = "NewPassword"
-	field_OldPassword       = "OldPassword"
-	field_Email             = "Email"
-	field_Language          = "Language"
-	field_VerificationToken = "VerificationToken"
-	field_VerificationCode  = "VerificationCode"
-	field_ProfileWSID       = "ProfileWSID"
-	field_NewPwd            = "NewPwd"
-	field_AppName           = "AppName"
-	field_Login             = "Login"
-	field_Alias             = "Alias"
-	field_AliasInProc       = "AliasInProc"
-	field_AliasError        = "AliasError"
-	field_SourceAppWSID     = "SourceAppWSID"
-	field_TTLHours          = "TTLHours"
-	field_GlobalRoles       = "GlobalRoles"
-	maxTokenTTLHours        = 168 // 1 week
+	RegistryPackage          = "registry"
+	RegistryPackageFQN       = "github.com/voedger/voedger/pkg/" + RegistryPackage
+	field_AppWSID            = "AppWSID"
+	field_AppIDLoginHash     = "AppIDLoginHash"
+	field_CDocLoginID        = "CDocLoginID"
+	field_PwdHash            = "PwdHash"
+	field_Passwrd            = "Password"
+	field_NewPassword        = "NewPassword"
+	field_OldPassword        = "OldPassword"
+	field_Email              = "Email"
+	field_Language           = "Language"
+	field_VerificationToken  = "VerificationToken"
+	field_VerificationCode   = "VerificationCode"
+	field_ProfileWSID        = "ProfileWSID"
+	field_NewPwd             = "NewPwd"
+	field_AppName            = "AppName"
+	field_Login              = "Login"
+	field_Alias              = "Alias"
+	field_AliasInProc        = "AliasInProc"
+	field_AliasError         = "AliasError"
+	field_SourceAppWSID      = "SourceAppWSID"
+	field_TTLHours           = "TTLHours"
+	field_GlobalRoles        = "GlobalRoles"
+	field_TOTPSecret         = "TOTPSecret"
+	field_TOTPPendingSecret  = "TOTPPendingSecret"
+	field_TOTPRecoveryCodes  = "TOTPRecoveryCodes"
+	field_TOTPCode           = "Code"
+	field_TOTPChallengeToken = "TOTPChallengeToken"
+	field_ChallengeToken     = "ChallengeToken"
+	field_Secret             = "Secret"
+	field_ProvisioningURI    = "ProvisioningURI"
+	field_RecoveryCodes      = "RecoveryCodes"
+	field_CodeHash           = "CodeHash"
+	maxTokenTTLHours         = 168 // 1 week
+
+	// logins of users authenticated by OpenID Connect identity providers, see [OIDCLogin]
+	// could not be used as the sign-in identifier of other logins
+	oidcLoginPrefix = "oidc."
+)
+
+const (
+	// RFC 6238 parameters supported by the most of authenticator apps
+	totpSecretLen = 20
+	totpDigits    = 6
+	totpPeriod    = 30 * time.Second
+
+	// codes of the previous and of the next time steps are accepted also to tolerate the clock skew
+	totpSkewSteps = 1
+
+	totpRecoveryCodesAmount = 10
+
+	// the second factor must be provided within the period after the password is checked
+	totpChallengeTokenTTL = 5 * time.Minute
+
+	// the secret key of the tokens is hashed with this to get the key the TOTP secrets are encrypted by
+	totpEncryptionKeyContext = "registry.TOTPSecret"
+
+	// prefix of the application TTL storage keys used to deny the replay of accepted codes
+	keyPrefix_TOTPUsedCode = "registry.totp.used|"
+
+	// accepted code is kept longer than it is valid
+	totpUsedCodeTTLSeconds = 300
+
+	// prefix of the application TTL storage keys that count incorrect codes of the login
+	keyPrefix_TOTPFailedAttempts = "registry.totp.failed|"
+
+	// the second factor of the login is locked out after the amount of incorrect codes
+	// until no incorrect codes are provided within totpFailedAttemptsTTLSeconds
+	totpMaxFailedAttempts = 5
+
+	totpFailedAttemptsTTLSeconds = 300
+
+	// attempts to count an incorrect code if the counter is modified concurrently
+	totpFailedAttemptsCASAttempts = 10
 )
 
 var (
 	validLoginRegexp                                  = regexp.MustCompile(`^[a-z0-9!#$%&'*+\-\/=.?^_{|}~@]+$`) // https://dev.untill.com/projects/#!537026
+	validOIDCLoginRegexp                              = regexp.MustCompile(`^` + regexp.QuoteMeta(oidcLoginPrefix) + `[0-9a-f]{64}$`)
 	QNameViewLoginIdx                                 = appdef.NewQName(RegistryPackage, "LoginIdx")
 	qNameCmdChangePassword                            = appdef.NewQName(RegistryPackage, "ChangePassword")
 	QNameProjectorLoginIdx                            = appdef.NewQName(RegistryPackage, "ProjectorLoginIdx")
@@ -53,6 +106,15 @@ var (
 	QNameCommandDeactivateLoginAliasIndex             = appdef.NewQName(RegistryPackage, "DeactivateLoginAliasIndex")
 	QNameCommandResetPasswordByEmail                  = appdef.NewQName(RegistryPackage, "ResetPasswordByEmail")
 	QNameCommandUpdateGlobalRoles                     = appdef.NewQName(RegistryPackage, "UpdateGlobalRoles")
+	qNameCmdInitiateTOTPEnrolment                     = appdef.NewQName(RegistryPackage, "InitiateTOTPEnrolment")
+	qNameCmdConfirmTOTPEnrolment                      = appdef.NewQName(RegistryPackage, "ConfirmTOTPEnrolment")
+	QNameCommandResetTOTP                             = appdef.NewQName(RegistryPackage, "ResetTOTP")
+	qNameQryIssuePrincipalTokenByTOTP                 = appdef.NewQName(RegistryPackage, "IssuePrincipalTokenByTOTP")
+	qNameCmdUseTOTPRecoveryCode                       = appdef.NewQName(RegistryPackage, "UseTOTPRecoveryCode")
+	qNameInitiateTOTPEnrolmentResult                  = appdef.NewQName(RegistryPackage, "InitiateTOTPEnrolmentResult")
+	qNameConfirmTOTPEnrolmentResult                   = appdef.NewQName(RegistryPackage, "ConfirmTOTPEnrolmentResult")
+	QNameCommandCreateOIDCLogin                       = appdef.NewQName(RegistryPackage, "CreateOIDCLogin")
+	QNameQueryIssuePrincipalTokenForOIDCLogin         = appdef.NewQName(RegistryPackage, "IssuePrincipalTokenForOIDCLogin")
 	QNameCommandResetPasswordByEmailUnloggedParams    = appdef.NewQName(RegistryPackage, "ResetPasswordByEmailUnloggedParams")
 	QNameQueryInitiateResetPasswordByEmail            = appdef.NewQName(RegistryPackage, "InitiateResetPasswordByEmail")
 	QNameQueryIssueVerifiedValueTokenForResetPassword = appdef.NewQName(RegistryPackage, "IssueVerifiedValueTokenForResetPassword")
@@ -62,6 +124,13 @@ var (
 	qNameProjectorInvokeCreateWorkspaceID_registry    = appdef.NewQName(RegistryPackage, "InvokeCreateWorkspaceID_registry")
 	errPasswordIsIncorrect                            = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "password is incorrect")
 	errLoginOrPasswordIsIncorrect                     = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "login or password is incorrect")
+	errTOTPCodeIsIncorrect                            = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "second factor code is incorrect")
+	errTOTPChallengeTokenInvalid                      = coreutils.NewHTTPErrorf(http.StatusUnauthorized, "second factor challenge token is invalid or expired")
+	errTOTPAlreadyEnrolled                            = coreutils.NewHTTPErrorf(http.StatusConflict, "second factor is enrolled already, reset it first")
+	errTOTPEnrolmentNotInitiated                      = coreutils.NewHTTPErrorf(http.StatusBadRequest, "second factor enrolment is not initiated")
+	errTOTPLockedOut                                  = coreutils.NewHTTPErrorf(http.StatusTooManyRequests, "too many incorrect second factor codes, try again later")
+	errTOTPFailedAttemptsConflict                     = errors.New("failed to count incorrect second factor code: too many concurrent modifications")
+	errTOTPSecretDecrypt                              = errors.New("failed to decrypt TOTP secret")
 
 	//go:embed appws.vsql
 	schemasFS embed.FS
@@ -23,15 +23,15 @@ import (
 // sys/registry, pseudoProfileWSID translated to appWSID
 // creation of CDoc<Login> triggers opAsyncProjectorInvokeCreateWorkspaceID
 func execCmdCreateLogin(args istructs.ExecCommandArgs) error {
-	return createLogin(args, args.ArgumentObject.AsString(authnz.Field_Login))
+	return createLogin(args, args.ArgumentObject.AsString(authnz.Field_Login), args.ArgumentUnloggedObject.AsString(field_Passwrd), validateSignInIdentifier)
 }
 
 // [~server.users/cmp.registry.CreateEmailLogin.go~impl]
 func execCmdCreateEmailLogin(args istructs.ExecCommandArgs) error {
-	return createLogin(args, args.ArgumentObject.AsString(authnz.Field_Email))
+	return createLogin(args, args.ArgumentObject.AsString(authnz.Field_Email), args.ArgumentUnloggedObject.AsString(field_Passwrd), validateSignInIdentifier)
 }
 
-func createLogin(args istructs.ExecCommandArgs, login string) (err error) {
+func createLogin(args istructs.ExecCommandArgs, login string, pwd string, validateLogin func(login string) error) (err error) {
 	appName := args.ArgumentObject.AsString(authnz.Field_AppName)
 
 	subjectKind := args.ArgumentObject.AsInt32(authnz.Field_SubjectKind)
@@ -58,7 +58,7 @@ func createLogin(args istructs.ExecCommandArgs, login string) (err error) {
 		return
 	}
 
-	if err = validateSignInIdentifier(login); err != nil {
+	if err = validateLogin(login); err != nil {
 		return err
 	}
 
@@ -69,7 +69,7 @@ func createLogin(args istructs.ExecCommandArgs, login string) (err error) {
 	}
 
 	wsKindInitializationData := args.ArgumentObject.AsString(authnz.Field_WSKindInitializationData)
-	pwdSaltedHash, err := GetPasswordSaltedHash(args.ArgumentUnloggedObject.AsString(field_Passwrd))
+	pwdSaltedHash, err := GetPasswordSaltedHash(pwd)
 	if err != nil {
 		return err
 	}
@@ -27,12 +27,16 @@ type iptRR struct {
 	principalToken       string
 	profileWSID          int64
 	profileCreationError string // like wsError
+	totpChallengeToken   string
 }
 
 func (q *iptRR) AsInt64(string) int64 { return q.profileWSID }
 func (q *iptRR) AsString(name string) string {
-	if name == authnz.Field_WSError {
+	switch name {
+	case authnz.Field_WSError:
 		return q.profileCreationError
+	case field_TOTPChallengeToken:
+		return q.totpChallengeToken
 	}
 	return q.principalToken
 }
@@ -55,7 +59,7 @@ func provideIssuePrincipalTokenExec(itokens itokens.ITokens, federation federati
 		}
 		var loginForSignIn signInLogin
 		if doesLoginExist {
-			loginForSignIn = loginFromPrimaryCDoc(login, cdocLogin)
+			loginForSignIn = loginFromPrimaryCDoc(login, args.WSID, cdocLogin)
 		} else {
 			loginForSignIn, doesLoginExist, err = resolveAliasSignInLogin(login, appName, args.State, args.WSID, itokens, federation)
 			if err != nil {
@@ -83,39 +87,61 @@ func provideIssuePrincipalTokenExec(itokens itokens.ITokens, federation federati
 			return callback(result)
 		}
 
-		// read global globalRoles
-		globarRolesStr := loginForSignIn.globalRoles
-		var globalRoles []appdef.QName
-		if len(globarRolesStr) > 0 {
-			globalRolesStr := strings.SplitSeq(globarRolesStr, ",")
-			for role := range globalRolesStr {
-				roleQName, err := appdef.ParseQName(role)
-				if err != nil {
-					return err
-				}
-				globalRoles = append(globalRoles, roleQName)
-			}
+		ttlHours := args.ArgumentObject.AsInt32(field_TTLHours)
+		if ttlHours > maxTokenTTLHours {
+			return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Errorf("max token TTL hours is %d hours", maxTokenTTLHours))
 		}
 
-		// issue principal token
-		principalPayload := payloads.PrincipalPayload{
-			Login:       loginForSignIn.canonicalLogin,
-			Alias:       loginForSignIn.alias,
-			SubjectKind: istructs.SubjectKindType(loginForSignIn.subjectKind),
-			ProfileWSID: istructs.WSID(result.profileWSID), //nolint G115 since WSID is created by NewWSID()
-			GlobalRoles: globalRoles,                       // [~server.authnz.groles/cmp.c.registry.IssuePrincipalToken~impl]
-		}
-		ttl := time.Duration(args.ArgumentObject.AsInt32(field_TTLHours)) * time.Hour
-		if ttl == 0 {
-			ttl = authnz.DefaultPrincipalTokenExpiration
-		} else if ttl > maxTokenTTLHours*time.Hour {
-			return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Errorf("max token TTL hours is %d hours", maxTokenTTLHours))
+		if len(loginForSignIn.totpSecret) > 0 {
+			// second factor is required -> the principal token is issued by q.registry.IssuePrincipalTokenByTOTP
+			// nothing about the profile is returned until then
+			result.profileWSID = 0
+			result.totpChallengeToken, err = issueTOTPChallengeToken(itokens, args.State.App(), appName, ttlHours, loginForSignIn)
+			if err != nil {
+				return err
+			}
+			return callback(result)
 		}
 
-		if result.principalToken, err = itokens.IssueToken(appQName, ttl, &principalPayload); err != nil {
-			return fmt.Errorf("principal token issue failed: %w", err)
+		if result.principalToken, err = issuePrincipalToken(itokens, appQName, ttlHours, loginForSignIn); err != nil {
+			return err
 		}
 
 		return callback(result)
 	}
 }
+
+func issuePrincipalToken(itokens itokens.ITokens, appQName appdef.AppQName, ttlHours int32, loginForSignIn signInLogin) (string, error) {
+	// read global globalRoles
+	globarRolesStr := loginForSignIn.globalRoles
+	var globalRoles []appdef.QName
+	if len(globarRolesStr) > 0 {
+		globalRolesStr := strings.SplitSeq(globarRolesStr, ",")
+		for role := range globalRolesStr {
+			roleQName, err := appdef.ParseQName(role)
+			if err != nil {
+				return "", err
+			}
+			globalRoles = append(globalRoles, roleQName)
+		}
+	}
+
+	// issue principal token
+	principalPayload := payloads.PrincipalPayload{
+		Login:       loginForSignIn.canonicalLogin,
+		Alias:       loginForSignIn.alias,
+		SubjectKind: istructs.SubjectKindType(loginForSignIn.subjectKind),
+		ProfileWSID: istructs.WSID(loginForSignIn.profileWSID), //nolint G115 since WSID is created by NewWSID()
+		GlobalRoles: globalRoles,                               // [~server.authnz.groles/cmp.c.registry.IssuePrincipalToken~impl]
+	}
+	ttl := time.Duration(ttlHours) * time.Hour
+	if ttl == 0 {
+		ttl = authnz.DefaultPrincipalTokenExpiration
+	}
+
+	principalToken, err := itokens.IssueToken(appQName, ttl, &principalPayload)
+	if err != nil {
+		return "", fmt.Errorf("principal token issue failed: %w", err)
+	}
+	return principalToken, nil
+}
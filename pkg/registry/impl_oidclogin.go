@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+)
+
+func provideOIDCLogin(cfg *istructsmem.AppConfigType, itokens itokens.ITokens) {
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandCreateOIDCLogin,
+		execCmdCreateOIDCLogin,
+	))
+	cfg.Resources.Add(istructsmem.NewQueryFunction(
+		QNameQueryIssuePrincipalTokenForOIDCLogin,
+		provideIssuePrincipalTokenForOIDCLoginExec(itokens),
+	))
+}
+
+// OIDCLogin returns the login of the user authenticated by the OpenID Connect identity provider
+// the subject is unique within the issuer only and could contain any chars so the login is derived from the both
+func OIDCLogin(issuer string, subject string) string {
+	hash := sha256.Sum256([]byte(issuer + "\n" + subject))
+	return oidcLoginPrefix + hex.EncodeToString(hash[:])
+}
+
+func validateOIDCLogin(login string) error {
+	if !validOIDCLoginRegexp.MatchString(login) {
+		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "incorrect OpenID Connect login format: ", login)
+	}
+	return nil
+}
+
+// sys/registry/pseudoWSID
+// System auth, called on the first sign in by the OpenID Connect identity provider
+// the password is random and never disclosed so the login could sign in by the identity provider only
+func execCmdCreateOIDCLogin(args istructs.ExecCommandArgs) error {
+	return createLogin(args, args.ArgumentObject.AsString(authnz.Field_Login), coreutils.UnusablePassword(), validateOIDCLogin)
+}
+
+// sys/registry/pseudoWSID
+// System auth, the ID token is validated by the caller already
+// logins created by c.registry.CreateLogin could not be signed in by this query
+func provideIssuePrincipalTokenForOIDCLoginExec(itokens itokens.ITokens) istructsmem.ExecQueryClosure {
+	return func(_ context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		login := args.ArgumentObject.AsString(authnz.Field_Login)
+		appName := args.ArgumentObject.AsString(authnz.Field_AppName)
+		if err := validateOIDCLogin(login); err != nil {
+			return err
+		}
+		appQName, err := appdef.ParseAppQName(appName)
+		if err != nil {
+			return coreutils.NewHTTPError(http.StatusBadRequest, err)
+		}
+		ttlHours := args.ArgumentObject.AsInt32(field_TTLHours)
+		if ttlHours > maxTokenTTLHours {
+			return coreutils.NewHTTPErrorf(http.StatusBadRequest, fmt.Errorf("max token TTL hours is %d hours", maxTokenTTLHours))
+		}
+
+		cdocLogin, doesLoginExist, err := GetCDocLogin(login, args.State, args.WSID, appName)
+		if err != nil {
+			return err
+		}
+		if !doesLoginExist {
+			return errLoginDoesNotExist(login)
+		}
+		loginForSignIn := loginFromPrimaryCDoc(login, args.WSID, cdocLogin)
+
+		result := &iptRR{
+			profileWSID:          loginForSignIn.profileWSID,
+			profileCreationError: loginForSignIn.wsError,
+		}
+		if result.profileWSID == 0 || len(result.profileCreationError) > 0 {
+			return callback(result)
+		}
+		if result.principalToken, err = issuePrincipalToken(itokens, appQName, ttlHours, loginForSignIn); err != nil {
+			return err
+		}
+		return callback(result)
+	}
+}
@@ -43,6 +43,14 @@ type signInLogin struct {
 	alias          string
 	subjectKind    int32
 	globalRoles    string
+
+	// where cdoc.registry.Login of the canonical login is
+	sourceAppWSID istructs.WSID
+	cdocLoginID   istructs.RecordID
+
+	// encrypted, empty -> no second factor
+	totpSecret        []byte
+	totpRecoveryCodes string
 }
 
 func execCmdInitiateSetLoginAlias(args istructs.ExecCommandArgs) error {
@@ -236,7 +244,8 @@ func execCmdDeactivateLoginAliasIndex(args istructs.ExecCommandArgs) error {
 func validateSignInIdentifier(identifier string) error {
 	if strings.HasPrefix(identifier, "-") || strings.HasPrefix(identifier, ".") || strings.HasPrefix(identifier, " ") ||
 		strings.HasSuffix(identifier, "-") || strings.HasSuffix(identifier, ".") || strings.HasSuffix(identifier, " ") ||
-		strings.Contains(identifier, "..") || strings.HasPrefix(identifier, "sys.") || !validLoginRegexp.MatchString(identifier) {
+		strings.Contains(identifier, "..") || strings.HasPrefix(identifier, "sys.") || strings.HasPrefix(identifier, oidcLoginPrefix) ||
+		!validLoginRegexp.MatchString(identifier) {
 		return coreutils.NewHTTPErrorf(http.StatusBadRequest, "incorrect login format: ", identifier)
 	}
 	return nil
@@ -328,15 +337,19 @@ func updateSourceLoginAliasErrorViaCUD(fed federationCaller, tokens itokens.ITok
 	return err
 }
 
-func loginFromPrimaryCDoc(login string, cdocLogin istructs.IStateValue) signInLogin {
+func loginFromPrimaryCDoc(login string, appWSID istructs.WSID, cdocLogin istructs.IStateValue) signInLogin {
 	return signInLogin{
-		canonicalLogin: login,
-		pwdHash:        cdocLogin.AsBytes(field_PwdHash),
-		profileWSID:    cdocLogin.AsInt64(authnz.Field_WSID),
-		wsError:        cdocLogin.AsString(authnz.Field_WSError),
-		alias:          cdocLogin.AsString(field_Alias),
-		subjectKind:    cdocLogin.AsInt32(authnz.Field_SubjectKind),
-		globalRoles:    cdocLogin.AsString(authnz.Field_GlobalRoles),
+		canonicalLogin:    login,
+		pwdHash:           cdocLogin.AsBytes(field_PwdHash),
+		profileWSID:       cdocLogin.AsInt64(authnz.Field_WSID),
+		wsError:           cdocLogin.AsString(authnz.Field_WSError),
+		alias:             cdocLogin.AsString(field_Alias),
+		subjectKind:       cdocLogin.AsInt32(authnz.Field_SubjectKind),
+		globalRoles:       cdocLogin.AsString(authnz.Field_GlobalRoles),
+		sourceAppWSID:     appWSID,
+		cdocLoginID:       cdocLogin.AsRecordID(appdef.SystemField_ID),
+		totpSecret:        cdocLogin.AsBytes(field_TOTPSecret),
+		totpRecoveryCodes: cdocLogin.AsString(field_TOTPRecoveryCodes),
 	}
 }
 
@@ -359,27 +372,41 @@ func resolveAliasSignInLogin(submittedLogin, appName string, st istructs.IState,
 		return signInLogin{}, false, nil
 	}
 
-	pwdHash, err := bytesFromJSON(sourceLoginMap[field_PwdHash])
+	res, err := loginFromCDocMap(loginAlias.AsString(field_Login), sourceAppWSID, istructs.RecordID(cdocLoginID), sourceLoginMap) // nolint G115
+	return res, err == nil, err
+}
+
+// cdocLoginMap is cdoc.registry.Login got via federation
+func loginFromCDocMap(canonicalLogin string, sourceAppWSID istructs.WSID, cdocLoginID istructs.RecordID, cdocLoginMap map[string]interface{}) (signInLogin, error) {
+	pwdHash, err := bytesFromJSON(cdocLoginMap[field_PwdHash])
 	if err != nil {
-		return signInLogin{}, false, err
+		return signInLogin{}, err
 	}
-	profileWSID, err := int64FromJSON(sourceLoginMap[authnz.Field_WSID])
+	profileWSID, err := int64FromJSON(cdocLoginMap[authnz.Field_WSID])
 	if err != nil {
-		return signInLogin{}, false, err
+		return signInLogin{}, err
 	}
-	subjectKind, err := int32FromJSON(sourceLoginMap[authnz.Field_SubjectKind])
+	subjectKind, err := int32FromJSON(cdocLoginMap[authnz.Field_SubjectKind])
 	if err != nil {
-		return signInLogin{}, false, err
+		return signInLogin{}, err
+	}
+	totpSecret, err := bytesFromJSON(cdocLoginMap[field_TOTPSecret])
+	if err != nil {
+		return signInLogin{}, err
 	}
 	return signInLogin{
-		canonicalLogin: loginAlias.AsString(field_Login),
-		pwdHash:        pwdHash,
-		profileWSID:    profileWSID,
-		wsError:        str(sourceLoginMap[authnz.Field_WSError]),
-		alias:          str(sourceLoginMap[field_Alias]),
-		subjectKind:    subjectKind,
-		globalRoles:    str(sourceLoginMap[authnz.Field_GlobalRoles]),
-	}, true, nil
+		canonicalLogin:    canonicalLogin,
+		pwdHash:           pwdHash,
+		profileWSID:       profileWSID,
+		wsError:           str(cdocLoginMap[authnz.Field_WSError]),
+		alias:             str(cdocLoginMap[field_Alias]),
+		subjectKind:       subjectKind,
+		globalRoles:       str(cdocLoginMap[authnz.Field_GlobalRoles]),
+		sourceAppWSID:     sourceAppWSID,
+		cdocLoginID:       cdocLoginID,
+		totpSecret:        totpSecret,
+		totpRecoveryCodes: str(cdocLoginMap[field_TOTPRecoveryCodes]),
+	}, nil
 }
 
 func getCDocViaFederation(fed federationCaller, tokens itokens.ITokens, appQName appdef.AppQName, wsid istructs.WSID, id int64) (map[string]interface{}, error) {
@@ -419,6 +446,8 @@ func bytesFromJSON(v interface{}) ([]byte, error) {
 		return typed, nil
 	case string:
 		return base64.StdEncoding.DecodeString(typed)
+	case nil:
+		return nil, nil
 	default:
 		return nil, fmt.Errorf("unexpected bytes JSON value %T", v)
 	}
@@ -0,0 +1,397 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/jsonu"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/strconvu"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func provideTOTP(cfg *istructsmem.AppConfigType, itokens itokens.ITokens, federation federationCaller, time timeu.ITime) {
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		qNameCmdInitiateTOTPEnrolment,
+		execCmdInitiateTOTPEnrolment(itokens),
+	))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		qNameCmdConfirmTOTPEnrolment,
+		execCmdConfirmTOTPEnrolment(itokens, time),
+	))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCommandResetTOTP,
+		execCmdResetTOTP,
+	))
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		qNameCmdUseTOTPRecoveryCode,
+		execCmdUseTOTPRecoveryCode,
+	))
+	cfg.Resources.Add(istructsmem.NewQueryFunction(
+		qNameQryIssuePrincipalTokenByTOTP,
+		provideIssuePrincipalTokenByTOTPExec(itokens, federation, time),
+	))
+}
+
+// sys/registry/pseudoWSID
+// null auth, the login is authenticated by the password
+// the secret is kept pending until the code generated by the authenticator app is confirmed by c.registry.ConfirmTOTPEnrolment
+func execCmdInitiateTOTPEnrolment(itokens itokens.ITokens) func(args istructs.ExecCommandArgs) (err error) {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		login := args.ArgumentObject.AsString(field_Login)
+		appName := args.ArgumentObject.AsString(field_AppName)
+		cdocLogin, err := getCDocLoginByPassword(args, login, appName)
+		if err != nil {
+			return err
+		}
+		if len(cdocLogin.AsBytes(field_TOTPSecret)) > 0 {
+			return errTOTPAlreadyEnrolled
+		}
+
+		secret := newTOTPSecret()
+		kb, err := args.State.KeyBuilder(sys.Storage_Record, appdef.NullQName)
+		if err != nil {
+			return err
+		}
+		loginUpdater, err := args.Intents.UpdateValue(kb, cdocLogin)
+		if err != nil {
+			return err
+		}
+		loginUpdater.PutBytes(field_TOTPPendingSecret, encryptTOTPSecret(itokens, secret, appName, login))
+
+		kb, err = args.State.KeyBuilder(sys.Storage_Result, qNameInitiateTOTPEnrolmentResult)
+		if err != nil {
+			return err
+		}
+		result, err := args.Intents.NewValue(kb)
+		if err != nil {
+			return err
+		}
+		result.PutString(field_Secret, totpSecretEncoding.EncodeToString(secret))
+		result.PutString(field_ProvisioningURI, totpProvisioningURI(secret, login, appName))
+		return nil
+	}
+}
+
+// sys/registry/pseudoWSID
+// null auth, the login is authenticated by the password
+// recovery codes are returned once, only their hashes are stored
+func execCmdConfirmTOTPEnrolment(itokens itokens.ITokens, time timeu.ITime) func(args istructs.ExecCommandArgs) (err error) {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		login := args.ArgumentObject.AsString(field_Login)
+		appName := args.ArgumentObject.AsString(field_AppName)
+		cdocLogin, err := getCDocLoginByPassword(args, login, appName)
+		if err != nil {
+			return err
+		}
+		if len(cdocLogin.AsBytes(field_TOTPSecret)) > 0 {
+			return errTOTPAlreadyEnrolled
+		}
+		encryptedSecret := cdocLogin.AsBytes(field_TOTPPendingSecret)
+		if len(encryptedSecret) == 0 {
+			return errTOTPEnrolmentNotInitiated
+		}
+		secret, err := decryptTOTPSecret(itokens, encryptedSecret, appName, login)
+		if err != nil {
+			return err
+		}
+		attempts := newTOTPFailedAttempts(args.State, args.WSID, cdocLogin.AsRecordID(appdef.SystemField_ID))
+		if err := attempts.check(); err != nil {
+			return err
+		}
+		code := strings.TrimSpace(args.ArgumentUnloggedObject.AsString(field_TOTPCode))
+		if _, ok := checkTOTPCode(secret, code, time.Now()); !ok {
+			return attempts.fail()
+		}
+		if err := attempts.reset(); err != nil {
+			return err
+		}
+
+		recoveryCodes, recoveryCodesHashes := newTOTPRecoveryCodes(itokens)
+		kb, err := args.State.KeyBuilder(sys.Storage_Record, appdef.NullQName)
+		if err != nil {
+			return err
+		}
+		loginUpdater, err := args.Intents.UpdateValue(kb, cdocLogin)
+		if err != nil {
+			return err
+		}
+		loginUpdater.PutBytes(field_TOTPSecret, encryptedSecret)
+		loginUpdater.PutBytes(field_TOTPPendingSecret, nil)
+		loginUpdater.PutString(field_TOTPRecoveryCodes, recoveryCodesHashes)
+
+		kb, err = args.State.KeyBuilder(sys.Storage_Result, qNameConfirmTOTPEnrolmentResult)
+		if err != nil {
+			return err
+		}
+		result, err := args.Intents.NewValue(kb)
+		if err != nil {
+			return err
+		}
+		result.PutString(field_RecoveryCodes, strings.Join(recoveryCodes, ","))
+		return nil
+	}
+}
+
+// sys/registry/pseudoWSID
+// System auth, e.g. the administrator resets the second factor of the login that lost the authenticator and recovery codes
+func execCmdResetTOTP(args istructs.ExecCommandArgs) (err error) {
+	login := args.ArgumentObject.AsString(field_Login)
+	cdocLogin, loginExists, err := GetCDocLogin(login, args.State, args.WSID, args.ArgumentObject.AsString(field_AppName))
+	if err != nil {
+		return err
+	}
+	if !loginExists {
+		return errLoginDoesNotExist(login)
+	}
+	kb, err := args.State.KeyBuilder(sys.Storage_Record, appdef.NullQName)
+	if err != nil {
+		return err
+	}
+	loginUpdater, err := args.Intents.UpdateValue(kb, cdocLogin)
+	if err != nil {
+		return err
+	}
+	loginUpdater.PutBytes(field_TOTPSecret, nil)
+	loginUpdater.PutBytes(field_TOTPPendingSecret, nil)
+	loginUpdater.PutString(field_TOTPRecoveryCodes, "")
+	return nil
+}
+
+// sys/registry/appWS
+// System auth, called by q.registry.IssuePrincipalTokenByTOTP in the AppWS of cdoc.registry.Login
+// commands of the workspace are executed one by one, so the same recovery code could not be used twice even concurrently
+func execCmdUseTOTPRecoveryCode(args istructs.ExecCommandArgs) (err error) {
+	kb, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocLogin)
+	if err != nil {
+		return err
+	}
+	kb.PutRecordID(sys.Storage_Record_Field_ID, istructs.RecordID(args.ArgumentObject.AsInt64(field_CDocLoginID))) // nolint G115
+	cdocLogin, err := args.State.MustExist(kb)
+	if err != nil {
+		return err
+	}
+	remainingHashes, ok := removeTOTPRecoveryCode(cdocLogin.AsString(field_TOTPRecoveryCodes), args.ArgumentUnloggedObject.AsString(field_CodeHash))
+	if !ok {
+		return errTOTPCodeIsIncorrect
+	}
+	kb, err = args.State.KeyBuilder(sys.Storage_Record, appdef.NullQName)
+	if err != nil {
+		return err
+	}
+	loginUpdater, err := args.Intents.UpdateValue(kb, cdocLogin)
+	if err != nil {
+		return err
+	}
+	loginUpdater.PutString(field_TOTPRecoveryCodes, remainingHashes)
+	return nil
+}
+
+// sys/registry/pseudoWSID
+// null auth, the login is authenticated by the challenge token issued by q.registry.IssuePrincipalToken
+// cdoc.registry.Login is read via federation because it could be in an another AppWS if the alias is used to sign in
+func provideIssuePrincipalTokenByTOTPExec(itokens itokens.ITokens, federation federationCaller, time timeu.ITime) istructsmem.ExecQueryClosure {
+	return func(_ context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
+		challenge := totpChallengePayload{}
+		gp, err := itokens.ValidateToken(args.ArgumentObject.AsString(field_ChallengeToken), &challenge)
+		if err != nil || gp.AppQName != args.State.App() {
+			return errTOTPChallengeTokenInvalid
+		}
+		appQName, err := appdef.ParseAppQName(challenge.AppName)
+		if err != nil {
+			// notest: validated already on c.registry.CreateLogin
+			return err
+		}
+
+		cdocLoginMap, err := getCDocViaFederation(federation, itokens, args.State.App(), challenge.SourceAppWSID, int64(challenge.CDocLoginID)) // nolint G115
+		if err != nil {
+			return err
+		}
+		if active, ok := cdocLoginMap[appdef.SystemField_IsActive].(bool); ok && !active {
+			return errTOTPChallengeTokenInvalid
+		}
+		loginForSignIn, err := loginFromCDocMap(challenge.Login, challenge.SourceAppWSID, challenge.CDocLoginID, cdocLoginMap)
+		if err != nil {
+			return err
+		}
+		if len(loginForSignIn.totpSecret) == 0 {
+			// reset after the challenge token is issued
+			return errTOTPChallengeTokenInvalid
+		}
+
+		code := strings.TrimSpace(args.ArgumentObject.AsString(field_TOTPCode))
+		if err := checkSecondFactor(args.State, itokens, federation, time, challenge.AppName, loginForSignIn, code); err != nil {
+			return err
+		}
+
+		result := &iptRR{profileWSID: loginForSignIn.profileWSID}
+		if result.principalToken, err = issuePrincipalToken(itokens, appQName, challenge.TTLHours, loginForSignIn); err != nil {
+			return err
+		}
+		return callback(result)
+	}
+}
+
+func issueTOTPChallengeToken(itokens itokens.ITokens, registryAppQName appdef.AppQName, appName string, ttlHours int32, loginForSignIn signInLogin) (string, error) {
+	challenge := totpChallengePayload{
+		Login:         loginForSignIn.canonicalLogin,
+		AppName:       appName,
+		SourceAppWSID: loginForSignIn.sourceAppWSID,
+		CDocLoginID:   loginForSignIn.cdocLoginID,
+		TTLHours:      ttlHours,
+	}
+	token, err := itokens.IssueToken(registryAppQName, totpChallengeTokenTTL, &challenge)
+	if err != nil {
+		return "", fmt.Errorf("second factor challenge token issue failed: %w", err)
+	}
+	return token, nil
+}
+
+// the code is either the TOTP code or one of the recovery codes
+// incorrect codes are counted, the second factor of the login is locked out after totpMaxFailedAttempts of them
+func checkSecondFactor(st istructs.IState, itokens itokens.ITokens, federation federationCaller, time timeu.ITime, appName string,
+	loginForSignIn signInLogin, code string) error {
+	attempts := newTOTPFailedAttempts(st, loginForSignIn.sourceAppWSID, loginForSignIn.cdocLoginID)
+	if err := attempts.check(); err != nil {
+		return err
+	}
+	ok, err := checkSecondFactorCode(st, itokens, federation, time, appName, loginForSignIn, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return attempts.fail()
+	}
+	return attempts.reset()
+}
+
+// accepted TOTP codes are remembered in the application TTL storage so the same code could not be used twice even concurrently
+// recovery codes are removed from cdoc.registry.Login by c.registry.UseTOTPRecoveryCode
+func checkSecondFactorCode(st istructs.IState, itokens itokens.ITokens, federation federationCaller, time timeu.ITime, appName string,
+	loginForSignIn signInLogin, code string) (ok bool, err error) {
+	if isTOTPCodeFormat(code) {
+		secret, err := decryptTOTPSecret(itokens, loginForSignIn.totpSecret, appName, loginForSignIn.canonicalLogin)
+		if err != nil {
+			return false, err
+		}
+		step, ok := checkTOTPCode(secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		ok, err = st.AppStructs().AppTTLStorage().InsertIfNotExists(totpUsedCodeKey(loginForSignIn, fmt.Sprint(step)), code, totpUsedCodeTTLSeconds)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			logger.Verbose("TOTP code replay denied for cdoc.registry.Login", loginForSignIn.cdocLoginID)
+		}
+		return ok, nil
+	}
+
+	codeHash := totpRecoveryCodeHash(itokens, code)
+	if _, ok := removeTOTPRecoveryCode(loginForSignIn.totpRecoveryCodes, codeHash); !ok {
+		return false, nil
+	}
+	body := jsonu.Jprintf(`{"args":{%q:%d},"unloggedArgs":{%q:%q}}`, field_CDocLoginID, loginForSignIn.cdocLoginID, field_CodeHash, codeHash)
+	err = callRegistryCommand(federation, itokens, st.App(), loginForSignIn.sourceAppWSID, qNameCmdUseTOTPRecoveryCode, body)
+	if sysErr := (coreutils.SysError{}); errors.As(err, &sysErr) && sysErr.HTTPStatus == http.StatusUnauthorized {
+		logger.Verbose("TOTP recovery code replay denied for cdoc.registry.Login", loginForSignIn.cdocLoginID)
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func totpUsedCodeKey(loginForSignIn signInLogin, code string) string {
+	return fmt.Sprintf("%s%d|%d|%s", keyPrefix_TOTPUsedCode, loginForSignIn.sourceAppWSID, loginForSignIn.cdocLoginID, code)
+}
+
+func getCDocLoginByPassword(args istructs.ExecCommandArgs, login string, appName string) (istructs.IStateValue, error) {
+	cdocLogin, loginExists, err := GetCDocLogin(login, args.State, args.WSID, appName)
+	if err != nil {
+		return nil, err
+	}
+	if !loginExists {
+		return nil, errLoginDoesNotExist(login)
+	}
+	isPasswordOK, err := CheckPassword(cdocLogin, args.ArgumentUnloggedObject.AsString(field_Passwrd))
+	if err != nil {
+		return nil, err
+	}
+	if !isPasswordOK {
+		return nil, errPasswordIsIncorrect
+	}
+	return cdocLogin, nil
+}
+
+// counts incorrect codes of the login in the application TTL storage
+type totpFailedAttempts struct {
+	storage istructs.IAppTTLStorage
+	key     string
+}
+
+func newTOTPFailedAttempts(st istructs.IState, appWSID istructs.WSID, cdocLoginID istructs.RecordID) *totpFailedAttempts {
+	return &totpFailedAttempts{
+		storage: st.AppStructs().AppTTLStorage(),
+		key:     fmt.Sprintf("%s%d|%d", keyPrefix_TOTPFailedAttempts, appWSID, cdocLoginID),
+	}
+}
+
+// returns errTOTPLockedOut if the second factor of the login is locked out
+func (a *totpFailedAttempts) check() error {
+	value, _, err := a.storage.TTLGet(a.key)
+	if err != nil {
+		return err
+	}
+	if failed, _ := strconvu.ParseInt64(value); failed >= totpMaxFailedAttempts {
+		return errTOTPLockedOut
+	}
+	return nil
+}
+
+// counts the incorrect code, returns errTOTPCodeIsIncorrect if the code is counted
+func (a *totpFailedAttempts) fail() error {
+	for range totpFailedAttemptsCASAttempts {
+		value, exists, err := a.storage.TTLGet(a.key)
+		if err != nil {
+			return err
+		}
+		failed, _ := strconvu.ParseInt64(value)
+		newValue := strconvu.IntToString(failed + 1)
+		ok := false
+		if exists {
+			ok, err = a.storage.CompareAndSwap(a.key, value, newValue, totpFailedAttemptsTTLSeconds)
+		} else {
+			ok, err = a.storage.InsertIfNotExists(a.key, newValue, totpFailedAttemptsTTLSeconds)
+		}
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errTOTPCodeIsIncorrect
+		}
+	}
+	return errTOTPFailedAttemptsConflict
+}
+
+// forgets incorrect codes after the correct one is provided
+func (a *totpFailedAttempts) reset() error {
+	value, exists, err := a.storage.TTLGet(a.key)
+	if err != nil || !exists {
+		return err
+	}
+	_, err = a.storage.CompareAndDelete(a.key, value)
+	return err
+}
@@ -8,6 +8,7 @@ package registry
 import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
@@ -15,7 +16,7 @@ import (
 	_ "github.com/voedger/voedger/pkg/sys"
 )
 
-func Provide(cfg *istructsmem.AppConfigType, itokens itokens.ITokens, federation federation.IFederation) parser.PackageFS {
+func Provide(cfg *istructsmem.AppConfigType, itokens itokens.ITokens, federation federation.IFederation, time timeu.ITime) parser.PackageFS {
 	cfg.Resources.Add(istructsmem.NewCommandFunction(
 		QNameCommandCreateLogin,
 		execCmdCreateLogin,
@@ -43,6 +44,8 @@ func Provide(cfg *istructsmem.AppConfigType, itokens itokens.ITokens, federation
 	provideChangePassword(cfg)
 	provideResetPassword(cfg, itokens, federation)
 	provideUpdateGlobalRoles(cfg)
+	provideTOTP(cfg, itokens, federation, time)
+	provideOIDCLogin(cfg, itokens)
 	cfg.AddAsyncProjectors(
 		provideAsyncProjectorInvokeCreateWorkspaceID(federation.WithRetry(), itokens),
 		provideAsyncProjectorApplySetLoginAlias(federation.WithRetry(), itokens),
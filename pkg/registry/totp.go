@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package registry
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint G505: SHA1 is required by RFC 6238 and authenticator apps
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/itokens"
+)
+
+var totpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func newTOTPSecret() []byte {
+	secret := make([]byte, totpSecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		// notest
+		panic(err)
+	}
+	return secret
+}
+
+// otpauth://totp/<issuer>:<login>?secret=...&issuer=... as expected by authenticator apps
+// https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func totpProvisioningURI(secret []byte, login string, issuer string) string {
+	params := url.Values{}
+	params.Set("secret", totpSecretEncoding.EncodeToString(secret))
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(login)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// RFC 6238 time step the time belongs to
+func totpStep(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod.Seconds())
+}
+
+// RFC 4226 HOTP value for the time step
+func totpCode(secret []byte, step int64) string {
+	msg := make([]byte, 8)                        // counter is 8 bytes by RFC 4226
+	binary.BigEndian.PutUint64(msg, uint64(step)) // nolint G115
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for range totpDigits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// returns the time step the code is valid for considering the clock skew
+// ok is false if the code is not valid
+func checkTOTPCode(secret []byte, code string, now time.Time) (step int64, ok bool) {
+	current := totpStep(now)
+	for s := current - totpSkewSteps; s <= current+totpSkewSteps; s++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, s)), []byte(code)) == 1 {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+func isTOTPCodeFormat(code string) bool {
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// the key is derived from the secret key of the tokens so no extra secret is required
+func totpAEAD(tokens itokens.ITokens) cipher.AEAD {
+	key := tokens.CryptoHash256([]byte(totpEncryptionKeyContext))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		// notest: key length is always 32
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		// notest
+		panic(err)
+	}
+	return aead
+}
+
+// encrypted secret is: nonce, sealed secret
+// AppName and login are the additional data, so the secret could not be moved to another login
+func encryptTOTPSecret(tokens itokens.ITokens, secret []byte, appName, login string) []byte {
+	aead := totpAEAD(tokens)
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(secret)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		// notest
+		panic(err)
+	}
+	return aead.Seal(nonce, nonce, secret, []byte(appName+"/"+login))
+}
+
+func decryptTOTPSecret(tokens itokens.ITokens, encrypted []byte, appName, login string) ([]byte, error) {
+	aead := totpAEAD(tokens)
+	if len(encrypted) < aead.NonceSize() {
+		return nil, errTOTPSecretDecrypt
+	}
+	secret, err := aead.Open(nil, encrypted[:aead.NonceSize()], encrypted[aead.NonceSize():], []byte(appName+"/"+login))
+	if err != nil {
+		return nil, errors.Join(errTOTPSecretDecrypt, err)
+	}
+	return secret, nil
+}
+
+// returns the codes to be shown to the user once and comma-separated hashes to be stored
+func newTOTPRecoveryCodes(tokens itokens.ITokens) (codes []string, hashes string) {
+	codesHashes := make([]string, 0, totpRecoveryCodesAmount)
+	for range totpRecoveryCodesAmount {
+		code := coreutils.TOTPRecoveryCode()
+		codes = append(codes, code)
+		codesHashes = append(codesHashes, totpRecoveryCodeHash(tokens, code))
+	}
+	return codes, strings.Join(codesHashes, ",")
+}
+
+func totpRecoveryCodeHash(tokens itokens.ITokens, code string) string {
+	hash := tokens.CryptoHash256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return fmt.Sprintf("%x", hash)
+}
+
+// returns comma-separated hashes without the hash of the code
+// ok is false if the code is not among the recovery codes
+func removeTOTPRecoveryCode(hashes string, codeHash string) (remainingHashes string, ok bool) {
+	remaining := []string{}
+	for hash := range strings.SplitSeq(hashes, ",") {
+		if len(hash) == 0 {
+			continue
+		}
+		if !ok && subtle.ConstantTimeCompare([]byte(hash), []byte(codeHash)) == 1 {
+			ok = true
+			continue
+		}
+		remaining = append(remaining, hash)
+	}
+	return strings.Join(remaining, ","), ok
+}
@@ -12,3 +12,13 @@ type result struct {
 	token       string
 	profileWSID int64
 }
+
+// payload of the token returned by q.registry.IssuePrincipalToken if the login requires the second factor
+// the token is exchanged to the principal token by q.registry.IssuePrincipalTokenByTOTP
+type totpChallengePayload struct {
+	Login         string // canonical
+	AppName       string
+	SourceAppWSID istructs.WSID
+	CDocLoginID   istructs.RecordID
+	TTLHours      int32
+}
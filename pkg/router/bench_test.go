@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+)
+
+/*
+goos: linux
+goarch: amd64
+pkg: github.com/voedger/voedger/pkg/router
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkReply_v2/json         	     424	   2794717 ns/op	  606876 B/op	    7020 allocs/op
+BenchmarkReply_v2/ndjson       	     447	   2726186 ns/op	  533273 B/op	    6017 allocs/op
+*/
+func BenchmarkReply_v2(b *testing.B) {
+	const rowsCount = 1000
+	rows := make([]any, rowsCount)
+	for i := range rows {
+		rows[i] = map[string]any{"sys.ID": 100000 + i, "sys.QName": "app.Article", "Name": "Article", "Price": 10.5, "Active": true}
+	}
+	respMeta := bus.ResponseMeta{ContentType: httpu.ContentType_ApplicationJSON, StatusCode: http.StatusOK}
+
+	for name, reply := range map[string]func(context.Context, http.ResponseWriter, <-chan any, *error, func(), bus.ResponseMeta){
+		"json":   reply_v2,
+		"ndjson": reply_v2_ndjson,
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			var responseErr error
+			for b.Loop() {
+				ch := make(chan any, rowsCount)
+				for _, row := range rows {
+					ch <- row
+				}
+				close(ch)
+				reply(context.Background(), httptest.NewRecorder(), ch, &responseErr, func() {}, respMeta)
+			}
+		})
+	}
+}
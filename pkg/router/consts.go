@@ -8,6 +8,8 @@ package router
 import (
 	"sync/atomic"
 	"time"
+
+	"github.com/voedger/voedger/pkg/coreutils/httpcompress"
 )
 
 const (
@@ -30,6 +32,8 @@ const (
 	// goroutines and connections from being held open indefinitely.
 	DefaultRouterWriteTimeout = 0
 
+	DefaultCompressionMinSize = httpcompress.DefaultMinSize
+
 	URLPlaceholder_wsid           = "wsid"
 	URLPlaceholder_appOwner       = "appOwner"
 	URLPlaceholder_appName        = "appName"
@@ -46,6 +50,7 @@ const (
 	URLPlaceholder_role           = "role"
 	URLPlaceholder_channelID      = "channelID"
 	URLPlaceholder_field          = "field"
+	URLPlaceholder_hook           = "hook"
 	hours24                       = 24 * time.Hour
 	DefaultRetryAfterSecondsOn503 = 1
 	DefaultMaxQueriesPerWSLimit   = 10
@@ -55,6 +60,18 @@ const (
 	logAttrib_Projection          = "projection"
 	logAttrib_ChannelID           = "channelid"
 	n10nErrorStage                = "n10n.error"
+	ndjsonDelimiter               = "\n"
+	wsMaxInFlightRequests         = 16
+	wsMsgType_Channel             = "channel"
+	wsMsgType_Subscribe           = "subscribe"
+	wsMsgType_Unsubscribe         = "unsubscribe"
+	wsMsgType_Request             = "request"
+	wsMsgType_Response            = "response"
+	wsMsgType_Notification        = "notification"
+	wsRequestPathPrefix           = "workspaces/"
+
+	// set on requests forwarded to the partition owner VVM to avoid forwarding loops
+	headerForwardedToOwner = "X-Voedger-Forwarded-To-Owner"
 )
 
 var (
@@ -58,6 +58,19 @@ func (s *routerService) registerHandlersV2() {
 		corsHandler(requestHandlerV2_extension(s.requestSender, processors.APIPath_Commands, s.numsAppsWorkspaces, l))).
 		Methods(http.MethodOptions, http.MethodPost).Name("exec cmd")
 
+	// execute batch: /api/v2/apps/{owner}/{app}/workspaces/{wsid}/batch
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/workspaces/{%s:[0-9]+}/batch",
+		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_wsid),
+		corsHandler(requestHandlerV2_batch(s.requestSender, s.numsAppsWorkspaces, l))).
+		Methods(http.MethodOptions, http.MethodPost).Name("exec batch")
+
+	// inbound webhook: /api/v2/apps/{owner}/{app}/workspaces/{wsid}/hooks/{pkg}.{hook}
+	// called by third parties server-to-server, so CORS is not supported
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/workspaces/{%s:[0-9]+}/hooks/{%s}.{%s}",
+		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_wsid, URLPlaceholder_pkg, URLPlaceholder_hook),
+		requestHandlerV2_extension(s.requestSender, processors.APIPath_InboundWebhooks, s.numsAppsWorkspaces, l)).
+		Methods(http.MethodPost).Name("inbound webhook")
+
 	// execute query: /api/v2/apps/{owner}/{app}/workspaces/{wsid}/queries/{pkg}.{query}
 	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/workspaces/{%s:[0-9]+}/queries/{%s}.{%s}",
 		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_wsid, URLPlaceholder_pkg, URLPlaceholder_query),
@@ -100,6 +113,19 @@ func (s *routerService) registerHandlersV2() {
 		corsHandler(requestHandlerV2_auth_refresh(s.requestSender, s.numsAppsWorkspaces, l))).
 		Methods(http.MethodOptions, http.MethodPost).Name("auth refresh")
 
+	// auth/oidc/authorize: /api/v2/apps/{owner}/{app}/auth/oidc/authorize
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/auth/oidc/authorize",
+		URLPlaceholder_appOwner, URLPlaceholder_appName),
+		corsHandler(requestHandlerV2_auth_oidc(s.requestSender, processors.APIPath_Auth_OIDCAuthorize, s.numsAppsWorkspaces, l))).
+		Methods(http.MethodOptions, http.MethodGet).Name("auth oidc authorize")
+
+	// auth/oidc/callback: /api/v2/apps/{owner}/{app}/auth/oidc/callback
+	// the identity provider redirects the user agent here with the authorization code
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/auth/oidc/callback",
+		URLPlaceholder_appOwner, URLPlaceholder_appName),
+		corsHandler(requestHandlerV2_auth_oidc(s.requestSender, processors.APIPath_Auth_OIDCCallback, s.numsAppsWorkspaces, l))).
+		Methods(http.MethodOptions, http.MethodGet).Name("auth oidc callback")
+
 	// create user /api/v2/apps/{owner}/{app}/users
 	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/users",
 		URLPlaceholder_appOwner, URLPlaceholder_appName),
@@ -168,6 +194,12 @@ func (s *routerService) registerHandlersV2() {
 		URLPlaceholder_appOwner, URLPlaceholder_appName, URLPlaceholder_channelID, URLPlaceholder_wsid, URLPlaceholder_view),
 		corsHandler(requestHandlerV2_notifications(s.numsAppsWorkspaces, s.requestSender, l))).
 		Methods(http.MethodOptions, http.MethodPut).Name("notifications subscribe to an extra view")
+
+	// notifications and requests over WebSocket /api/v2/apps/{owner}/{app}/ws
+	s.router.HandleFunc(fmt.Sprintf("/api/v2/apps/{%s}/{%s}/ws",
+		URLPlaceholder_appOwner, URLPlaceholder_appName),
+		s.webSocketHandler()).
+		Methods(http.MethodGet).Name("websocket")
 }
 
 func requestHandlerV2_schemas(reqSender bus.IRequestSender, numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces,
@@ -366,6 +398,24 @@ func requestHandlerV2_auth_login(reqSender bus.IRequestSender, numsAppsWorkspace
 	})
 }
 
+// URL query params are passed as args since the callback params are defined by the identity provider
+func requestHandlerV2_auth_oidc(reqSender bus.IRequestSender, apiPath processors.APIPath,
+	numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, limiter *wsQueryLimiter) http.HandlerFunc {
+	return withValidateForFuncs(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
+		busRequest := createBusRequest(data, req)
+		busRequest.IsAPIV2 = true
+		busRequest.APIPath = int(apiPath)
+		args, err := json.Marshal(busRequest.Query)
+		if err != nil {
+			// notest
+			ReplyCommonError(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		busRequest.Query = map[string]string{"args": string(args)}
+		sendRequestAndReadResponse(req, busRequest, reqSender, rw, data, limiter)
+	})
+}
+
 func requestHandlerV2_blobs_read(blobRequestHandler blobprocessor.IRequestHandler, requestSender bus.IRequestSender,
 	numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces) http.HandlerFunc {
 	return withValidateForBLOBs(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
@@ -459,6 +509,8 @@ func requestHandlerV2_extension(reqSender bus.IRequestSender, apiPath processors
 			entity = data.vars[URLPlaceholder_command]
 		case processors.APIPath_Queries:
 			entity = data.vars[URLPlaceholder_query]
+		case processors.APIPath_InboundWebhooks:
+			entity = data.vars[URLPlaceholder_hook]
 		}
 		busRequest := createBusRequest(data, req)
 		busRequest.IsAPIV2 = true
@@ -468,6 +520,17 @@ func requestHandlerV2_extension(reqSender bus.IRequestSender, apiPath processors
 	})
 }
 
+// operations are parsed and executed by the command processor as a single event
+func requestHandlerV2_batch(reqSender bus.IRequestSender, numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces,
+	limiter *wsQueryLimiter) http.HandlerFunc {
+	return withValidateForFuncs(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
+		busRequest := createBusRequest(data, req)
+		busRequest.IsAPIV2 = true
+		busRequest.APIPath = int(processors.APIPath_Batch)
+		sendRequestAndReadResponse(req, busRequest, reqSender, rw, data, limiter)
+	})
+}
+
 func requestHandlerV2_table(reqSender bus.IRequestSender, apiPath processors.APIPath, numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces,
 	limiter *wsQueryLimiter) http.HandlerFunc {
 	return withValidateForFuncs(numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
@@ -534,6 +597,13 @@ func sendRequestAndReadResponse(req *http.Request, busRequest bus.Request, reqSe
 	}
 	logLatency(requestCtx, sentAt)
 
+	if respMeta.Mode() == bus.RespondMode_StreamJSON && acceptsNDJSON(req) {
+		respMeta.ContentType = httpu.ContentType_ApplicationXNDJSON
+		initResponse(rw, respMeta)
+		reply_v2_ndjson(requestCtx, rw, respCh, respErr, cancel, respMeta)
+		return
+	}
+
 	initResponse(rw, respMeta)
 	reply_v2(requestCtx, rw, respCh, respErr, cancel, respMeta)
 }
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// forwards requests to workspaces whose application partitions are owned by other VVMs of the cluster
+//
+// request is forwarded once: the owner VVM handles forwarded requests itself
+func (s *routerService) forwardToPartitionOwner(next http.Handler) http.Handler {
+	if s.ownerLocator == nil {
+		return next
+	}
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if len(req.Header.Get(headerForwardedToOwner)) == 0 {
+			if app, wsid, ok := parseAppWorkspace(req.URL.Path); ok {
+				if ownerURL, forward := s.ownerLocator(app, wsid); forward {
+					logger.VerboseCtx(req.Context(), "routing.forward", req.URL.Path, "to", ownerURL)
+					proxy := &httputil.ReverseProxy{
+						Rewrite: func(pr *httputil.ProxyRequest) {
+							pr.SetURL(ownerURL)
+							pr.SetXForwarded()
+							pr.Out.Header.Set(headerForwardedToOwner, "true")
+						},
+						FlushInterval: -1, // stream responses to the client as is
+					}
+					proxy.ServeHTTP(rw, req)
+					return
+				}
+			}
+		}
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// extracts application and workspace from:
+//   - /api/{owner}/{app}/{wsid}/...
+//   - /api/v2/apps/{owner}/{app}/workspaces/{wsid}/...
+//   - /blob/{owner}/{app}/{wsid}/...
+func parseAppWorkspace(path string) (app appdef.AppQName, wsid istructs.WSID, ok bool) {
+	parts := strings.Split(path, "/")
+	var owner, name, ws string
+	switch {
+	case len(parts) > 7 && parts[1] == "api" && parts[2] == "v2" && parts[3] == "apps" && parts[6] == "workspaces":
+		owner, name, ws = parts[4], parts[5], parts[7]
+	case len(parts) > 4 && (parts[1] == "api" || parts[1] == "blob"):
+		owner, name, ws = parts[2], parts[3], parts[4]
+	default:
+		return app, wsid, false
+	}
+	id, err := strconv.ParseUint(ws, 10, 64)
+	if err != nil {
+		return app, wsid, false
+	}
+	return appdef.NewAppQName(owner, name), istructs.WSID(id), true
+}
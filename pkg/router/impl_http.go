@@ -21,6 +21,8 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/httpcompress"
+	"github.com/voedger/voedger/pkg/coreutils/httpstatic"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/sys"
@@ -59,6 +61,8 @@ func (s *routerService) Prepare(work interface{}) error {
 	// https://dev.untill.com/projects/#!627072
 	s.router.SkipClean(true)
 
+	s.router.Use(s.localizeErrors)
+
 	s.registerRouterCheckerHandler()
 
 	s.registerHandlersV1()
@@ -72,7 +76,11 @@ func (s *routerService) Prepare(work interface{}) error {
 		return err
 	}
 
-	return s.prepareBasicServer(s.router)
+	handler := http.Handler(s.router)
+	if s.compressionMinSize > 0 {
+		handler = httpcompress.NewHandler(handler, s.compressionMinSize)
+	}
+	return s.prepareBasicServer(s.forwardToPartitionOwner(handler))
 }
 
 // pipeline.IServiceBase
@@ -215,6 +223,11 @@ func (s *routerService) registerHandlersV1() {
 	s.router.Handle("/n10n/subscribe", corsHandler(s.subscribeHandler())).Methods("GET")
 	s.router.Handle("/n10n/unsubscribe", corsHandler(s.unSubscribeHandler())).Methods("GET")
 	s.router.Handle("/n10n/update/{offset:[0-9]{1,10}}", corsHandler(s.updateHandler()))
+
+	if s.staticFolders != nil {
+		// e.g. static folders of the applications deployed by c.cluster.DeployApp
+		s.router.PathPrefix(httpstatic.StaticPath).Handler(s.staticFolders).Name("static")
+	}
 }
 
 func RequestHandler_V1(requestSender bus.IRequestSender, numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces,
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+)
+
+// error messages written by the router for the application requests are translated
+// to the language from Accept-Language header by the application translations
+func (s *routerService) localizeErrors(next http.Handler) http.Handler {
+	if s.appTranslations == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		acceptLanguage := req.Header.Get(httpu.AcceptLanguage)
+		vars := mux.Vars(req)
+		owner, name := vars[URLPlaceholder_appOwner], vars[URLPlaceholder_appName]
+		if len(acceptLanguage) > 0 && len(owner) > 0 && len(name) > 0 {
+			if tr := s.appTranslations(appdef.NewAppQName(owner, name)); tr != nil && len(tr.Languages()) > 0 {
+				w = &localizingResponseWriter{
+					ResponseWriter: w,
+					translate:      func(text string) string { return coreutils.Translate(tr, acceptLanguage, text) },
+				}
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// returns the message translated to the language of the request if w is wrapped by localizeErrors
+func localize(w http.ResponseWriter, msg string) string {
+	if lw, ok := w.(*localizingResponseWriter); ok && len(msg) > 0 {
+		return lw.translate(msg)
+	}
+	return msg
+}
+
+func localizeSysError(w http.ResponseWriter, sysErr coreutils.SysError) coreutils.SysError {
+	sysErr.Message = localize(w, sysErr.Message)
+	return sysErr
+}
+
+func (w *localizingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// websocket connections are hijacked
+func (w *localizingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func (w *localizingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -11,9 +11,12 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/voedger/voedger/pkg/bus"
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/goutils/jsonu"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
@@ -131,3 +134,62 @@ func reply_v2(requestCtx context.Context, w http.ResponseWriter, responseCh <-ch
 		sendSuccess = writeResponse(w, "}")
 	}
 }
+
+// replies StreamJSON response as newline-delimited JSON: one result per line, the error (if any) is the last line {"error":{...}}
+// so that the client could process results before the response is finished
+func reply_v2_ndjson(requestCtx context.Context, w http.ResponseWriter, responseCh <-chan any, responseErr *error, onSendFailed func(), respMeta bus.ResponseMeta) {
+	sendSuccess := true
+	defer func() {
+		if requestCtx.Err() != nil {
+			if onRequestCtxClosed != nil {
+				onRequestCtxClosed()
+			}
+			log.Println("client disconnected during results sending")
+			return
+		}
+		if !sendSuccess {
+			logger.ErrorCtx(requestCtx, "routing.response.error", "failed to write response")
+			onSendFailed()
+			for range responseCh {
+			}
+		}
+	}()
+
+	w.WriteHeader(respMeta.StatusCode)
+	for elem := range responseCh {
+		if requestCtx.Err() != nil {
+			// ctx.Done() must have the priority, see reply_v2
+			return
+		}
+		elemBytes, err := json.Marshal(&elem)
+		if err != nil {
+			// notest
+			panic(err)
+		}
+		if sendSuccess = writeResponse(w, string(elemBytes)+ndjsonDelimiter); !sendSuccess {
+			return
+		}
+	}
+
+	if *responseErr != nil {
+		var sysError coreutils.SysError
+		if errors.As(*responseErr, &sysError) {
+			sendSuccess = writeResponse(w, `{"error":`+sysError.ToJSON_APIV2()+"}"+ndjsonDelimiter)
+		} else {
+			sendSuccess = writeResponse(w, jsonu.Jprintf(`{"error":{"status":%d,"message":%q}}`, http.StatusInternalServerError, (*responseErr).Error())+ndjsonDelimiter)
+		}
+	}
+}
+
+// client asks for newline-delimited JSON by Accept: application/x-ndjson
+func acceptsNDJSON(req *http.Request) bool {
+	for _, accept := range req.Header.Values(httpu.Accept) {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, _ := strings.Cut(mediaRange, ";")
+			if strings.EqualFold(strings.TrimSpace(mediaType), httpu.ContentType_ApplicationXNDJSON) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -20,17 +20,21 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
 	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/httpstatic"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
 	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/ihttp"
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/in10nmem"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -189,6 +193,54 @@ func TestSysErrorHeaders(t *testing.T) {
 	require.Equal("10", resp.Header.Get(httpu.RetryAfter))
 }
 
+func TestForwardToPartitionOwner(t *testing.T) {
+	require := require.New(t)
+
+	const ownedByOtherWSID = testWSID + 1
+
+	owner := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal("true", req.Header.Get(headerForwardedToOwner))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(err)
+		_, _ = fmt.Fprintf(rw, "owner: %s %s", req.URL.Path, body)
+	}))
+	defer owner.Close()
+	ownerURL, err := url.Parse(owner.URL)
+	require.NoError(err)
+
+	router := setUpEx(t, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
+		bus.ReplyJSON(responder, http.StatusOK, "local")
+	}, nil, func(app appdef.AppQName, wsid istructs.WSID) (*url.URL, bool) {
+		require.Equal(istructs.AppQName_test1_app1, app)
+		return ownerURL, wsid == ownedByOtherWSID
+	})
+	defer tearDown(router)
+
+	post := func(path string) string {
+		resp, err := http.Post(fmt.Sprintf("http://127.0.0.1:%d%s", router.port(), path), httpu.ContentType_ApplicationJSON, strings.NewReader("{}"))
+		require.NoError(err)
+		defer resp.Body.Close()
+		require.Equal(http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(err)
+		return string(body)
+	}
+
+	t.Run("should handle locally if partition is owned by current VVM", func(t *testing.T) {
+		require.Equal("local", post(fmt.Sprintf("/api/test1/app1/%d/q.sys.Echo", testWSID)))
+	})
+
+	t.Run("should forward api v1 request to the partition owner", func(t *testing.T) {
+		path := fmt.Sprintf("/api/test1/app1/%d/q.sys.Echo", ownedByOtherWSID)
+		require.Equal("owner: "+path+" {}", post(path))
+	})
+
+	t.Run("should forward api v2 request to the partition owner", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v2/apps/test1/app1/workspaces/%d/commands/sys.Echo", ownedByOtherWSID)
+		require.Equal("owner: "+path+" {}", post(path))
+	})
+}
+
 func TestRetryAfter_BLOBServiceUnavailable(t *testing.T) {
 	require := require.New(t)
 	for _, c := range []struct {
@@ -511,11 +563,12 @@ type testRouter struct {
 	clientDisconnections chan struct{}
 }
 
-func startRouter(t *testing.T, router *testRouter, rp RouterParams, requestHandler bus.RequestHandler, blobRequestHandler blobprocessor.IRequestHandler) {
+func startRouter(t *testing.T, router *testRouter, rp RouterParams, requestHandler bus.RequestHandler, blobRequestHandler blobprocessor.IRequestHandler,
+	ownerLocator PartitionOwnerLocator) {
 	ctx, cancel := context.WithCancel(context.Background())
 	requestSender := bus.NewIRequestSender(testingu.MockTime, requestHandler)
 	httpSrv, acmeSrv, adminService := Provide(rp, nil, blobRequestHandler, nil, requestSender,
-		map[appdef.AppQName]istructs.NumAppWorkspaces{istructs.AppQName_test1_app1: 10}, nil, nil, nil)
+		map[appdef.AppQName]istructs.NumAppWorkspaces{istructs.AppQName_test1_app1: 10}, nil, nil, nil, ownerLocator, nil)
 	require.Nil(t, acmeSrv)
 	require.NoError(t, httpSrv.Prepare(nil))
 	require.NoError(t, adminService.Prepare(nil))
@@ -543,6 +596,10 @@ func setUp(t *testing.T, requestHandler bus.RequestHandler) *testRouter {
 }
 
 func setUpWithBlobHandler(t *testing.T, requestHandler bus.RequestHandler, blobRequestHandler blobprocessor.IRequestHandler) *testRouter {
+	return setUpEx(t, requestHandler, blobRequestHandler, nil)
+}
+
+func setUpEx(t *testing.T, requestHandler bus.RequestHandler, blobRequestHandler blobprocessor.IRequestHandler, ownerLocator PartitionOwnerLocator) *testRouter {
 	rp := RouterParams{
 		HTTPServerParams: HTTPServerParams{
 			Port:             0,
@@ -556,7 +613,7 @@ func setUpWithBlobHandler(t *testing.T, requestHandler bus.RequestHandler, blobR
 		clientDisconnections: make(chan struct{}, 1),
 	}
 
-	startRouter(t, router, rp, requestHandler, blobRequestHandler)
+	startRouter(t, router, rp, requestHandler, blobRequestHandler, ownerLocator)
 	return router
 }
 
@@ -776,3 +833,226 @@ func TestApiV1_PlainErrorOnStreamClose(t *testing.T) {
 		})
 	}
 }
+
+func TestBasicUsage_ApiArray_NDJSON(t *testing.T) {
+	require := require.New(t)
+
+	cases := []struct {
+		name     string
+		objs     []any
+		err      error
+		expected string
+	}{
+		{
+			name:     "empty",
+			expected: ``,
+		},
+		{
+			name:     "empty + error",
+			err:      errors.New("test error"),
+			expected: `{"error":{"status":500,"message":"test error"}}` + "\n",
+		},
+		{
+			name: "2 elems + SysError",
+			objs: []any{
+				map[string]interface{}{"IntFld": 42},
+				map[string]interface{}{"StrFld": "str"},
+			},
+			err: coreutils.SysError{HTTPStatus: http.StatusBadRequest, QName: appdef.NewQName("sys", "errQName"), Message: "test error"},
+			expected: `{"IntFld":42}` + "\n" +
+				`{"StrFld":"str"}` + "\n" +
+				`{"error":{"status":400,"message":"test error","qname":"sys.errQName"}}` + "\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := setUp(t, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
+				go func() {
+					respWriter := responder.StreamJSON(http.StatusOK)
+					for _, obj := range c.objs {
+						require.NoError(respWriter.Write(obj))
+					}
+					respWriter.Close(c.err)
+				}()
+			})
+			defer tearDown(router)
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d/api/v2/apps/test1/app1/workspaces/%d/queries/test.query", router.port(), testWSID), nil)
+			require.NoError(err)
+			req.Header.Set(httpu.Accept, httpu.ContentType_ApplicationXNDJSON+", application/json;q=0.9")
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(err)
+			defer resp.Body.Close()
+
+			require.Equal(httpu.ContentType_ApplicationXNDJSON, resp.Header.Get(httpu.ContentType))
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(err)
+			require.Equal(c.expected, string(body))
+		})
+	}
+
+	t.Run("single object response should not be affected", func(t *testing.T) {
+		router := setUp(t, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
+			go bus.ReplyJSON(responder, http.StatusOK, map[string]any{"IntFld": 42})
+		})
+		defer tearDown(router)
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d/api/v2/apps/test1/app1/workspaces/%d/docs/test.table/1", router.port(), testWSID), nil)
+		require.NoError(err)
+		req.Header.Set(httpu.Accept, httpu.ContentType_ApplicationXNDJSON)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		expectJSONResp(t, `{"IntFld":42}`, "", resp)
+	})
+}
+
+func TestCompression(t *testing.T) {
+	require := require.New(t)
+	const rowsCount = 100
+
+	router := &testRouter{
+		wg:                   &sync.WaitGroup{},
+		clientDisconnections: make(chan struct{}, 1),
+	}
+	startRouter(t, router, RouterParams{
+		HTTPServerParams: HTTPServerParams{
+			ReadTimeout:      DefaultRouterReadTimeout,
+			ConnectionsLimit: DefaultConnectionsLimit,
+		},
+		CompressionMinSize: DefaultCompressionMinSize,
+	}, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
+		go func() {
+			respWriter := responder.StreamJSON(http.StatusOK)
+			for i := range rowsCount {
+				require.NoError(respWriter.Write(map[string]any{"sys.ID": i, "Name": "Article"}))
+			}
+			respWriter.Close(nil)
+		}()
+	}, nil, nil)
+	defer tearDown(router)
+
+	get := func(acceptEncoding string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d/api/v2/apps/test1/app1/workspaces/%d/queries/test.query", router.port(), testWSID), nil)
+		require.NoError(err)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		return resp
+	}
+
+	t.Run("should be compressed if accepted", func(t *testing.T) {
+		for _, encoding := range []string{"gzip", "zstd"} {
+			resp := get(encoding)
+			defer resp.Body.Close()
+			require.Equal(http.StatusOK, resp.StatusCode)
+			require.Equal(encoding, resp.Header.Get("Content-Encoding"))
+			compressed, err := io.ReadAll(resp.Body)
+			require.NoError(err)
+			require.Less(len(compressed), rowsCount*10)
+		}
+	})
+
+	t.Run("should not be compressed if not accepted", func(t *testing.T) {
+		resp := get("")
+		defer resp.Body.Close()
+		require.Empty(resp.Header.Get("Content-Encoding"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(err)
+		require.True(strings.HasPrefix(string(body), `{"results":[{"Name":"Article","sys.ID":0}`))
+	})
+}
+
+func TestStaticFolders(t *testing.T) {
+	require := require.New(t)
+
+	router := &testRouter{
+		wg:                   &sync.WaitGroup{},
+		clientDisconnections: make(chan struct{}, 1),
+	}
+	staticFolders := httpstatic.NewAppFolders()
+	startRouter(t, router, RouterParams{
+		HTTPServerParams: HTTPServerParams{
+			ReadTimeout:      DefaultRouterReadTimeout,
+			ConnectionsLimit: DefaultConnectionsLimit,
+		},
+		StaticFolders: staticFolders,
+	}, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {}, nil, nil)
+	defer tearDown(router)
+
+	get := func(urlPath string) (int, string) {
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", router.port(), urlPath))
+		require.NoError(err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(err)
+		return resp.StatusCode, string(body)
+	}
+
+	staticFolders.DeployAppStaticFolders(appdef.NewAppQName("test1", "app1"), map[string]ihttp.StaticFolder{
+		"test1/app1/pkg/Web": {
+			FS:      fstest.MapFS{"index.html": {Data: []byte("<html>web</html>")}},
+			Options: ihttp.StaticFolderOptions{SPA: true},
+		},
+	})
+	code, body := get("/static/test1/app1/pkg/Web/orders/123")
+	require.Equal(http.StatusOK, code)
+	require.Equal("<html>web</html>", body)
+
+	code, _ = get("/static/test1/app1/pkg/Unknown/")
+	require.Equal(http.StatusNotFound, code)
+}
+
+type testTranslations map[string]map[string]string
+
+func (tt testTranslations) Languages() (langs []string) {
+	for lang := range tt {
+		langs = append(langs, lang)
+	}
+	return langs
+}
+
+func (tt testTranslations) Translation(lang, text string) (string, bool) {
+	tr, ok := tt[lang][text]
+	return tr, ok
+}
+
+func TestLocalizeErrors(t *testing.T) {
+	require := require.New(t)
+	rs := &routerService{
+		appTranslations: func(app appdef.AppQName) appdef.IWithTranslations {
+			if app != istructs.AppQName_test1_app1 {
+				return nil
+			}
+			return testTranslations{"nl": {"rate limit exceeded": "limiet overschreden"}}
+		},
+	}
+	r := mux.NewRouter()
+	r.Use(rs.localizeErrors)
+	r.HandleFunc(fmt.Sprintf("/api/{%s}/{%s}", URLPlaceholder_appOwner, URLPlaceholder_appName), func(w http.ResponseWriter, _ *http.Request) {
+		writeCommonError_V1(w, errors.New("rate limit exceeded"), http.StatusTooManyRequests)
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	get := func(path, acceptLanguage string) string {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, http.NoBody)
+		require.NoError(err)
+		if len(acceptLanguage) > 0 {
+			req.Header.Set(httpu.AcceptLanguage, acceptLanguage)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		require.Equal(http.StatusTooManyRequests, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(err)
+		return string(body)
+	}
+
+	require.Contains(get("/api/test1/app1", "nl-BE,nl;q=0.9"), "limiet overschreden")
+	require.Contains(get("/api/test1/app1", "fr"), "rate limit exceeded")
+	require.Contains(get("/api/test1/app1", ""), "rate limit exceeded")
+	require.Contains(get("/api/test1/app2", "nl"), "rate limit exceeded")
+}
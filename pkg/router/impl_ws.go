@@ -0,0 +1,260 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/goutils/jsonu"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+)
+
+// notifications and requests over WebSocket: /api/v2/apps/{owner}/{app}/ws
+// the connection has its own n10n channel that is subscribed to heartbeats, subscriptions are managed in-band
+// subscriptions and requests are served by the APIv2 handlers of the router, i.e. with the same authorization and limits
+func (s *routerService) webSocketHandler() http.HandlerFunc {
+	return withValidate(s.numsAppsWorkspaces, func(req *http.Request, rw http.ResponseWriter, data validatedData) {
+		logCtx := withLogAttribs(req.Context(), data, bus.Request{Resource: "sys._WebSocket"}, req)
+		authHeader := data.header[httpu.Authorization]
+		token, ok := strings.CutPrefix(authHeader, httpu.BearerPrefix)
+		if !ok {
+			ReplyCommonError(rw, "bearer token is missing", http.StatusUnauthorized)
+			return
+		}
+		if _, tokenInHeaders := req.Header[httpu.Authorization]; !tokenInHeaders && !isSameOrigin(req) {
+			// WebSocket is not a subject of CORS, so the browser sends cookies to any origin
+			ReplyCommonError(rw, "cross-origin connection is not allowed with the token from cookies", http.StatusForbidden)
+			return
+		}
+		var subjectLogin istructs.SubjectLogin
+		if iauthnzimpl.IsAPIKey(token) {
+			// API key is per workspace, so it is checked by the authenticator on each in-band request and subscription
+			subjectLogin = iauthnzimpl.APIKeySubjectLogin(token)
+		} else {
+			principalPayload := payloads.PrincipalPayload{}
+			if _, err := s.appTokensFactory.New(data.appQName).ValidateToken(token, &principalPayload); err != nil {
+				ReplyCommonError(rw, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			subjectLogin = istructs.SubjectLogin(principalPayload.Login)
+		}
+
+		channelID, channelCleanup, err := s.n10n.NewChannel(subjectLogin, hours24)
+		if err != nil {
+			logger.ErrorCtx(logCtx, n10nErrorStage, err)
+			ReplyCommonError(rw, "create new channel failed: "+err.Error(), n10nErrorToStatusCode(err))
+			return
+		}
+		defer channelCleanup()
+		logCtx = logger.WithContextAttrs(logCtx, map[string]any{
+			logAttrib_ChannelID: string(channelID),
+		})
+		if err := s.n10n.Subscribe(channelID, in10n.Heartbeat30ProjectionKey); err != nil {
+			logger.ErrorCtx(logCtx, n10nErrorStage, err)
+			ReplyCommonError(rw, "subscribe failed: "+err.Error(), n10nErrorToStatusCode(err))
+			return
+		}
+
+		websocket.Server{Handler: func(ws *websocket.Conn) {
+			c := &wsConn{
+				ws:         ws,
+				logCtx:     logCtx,
+				handler:    s.forwardToPartitionOwner(s.router),
+				appPath:    fmt.Sprintf("/api/v2/apps/%s/%s/", data.appQName.Owner(), data.appQName.Name()),
+				authHeader: authHeader,
+				remoteAddr: req.RemoteAddr,
+				host:       req.Host,
+				channelID:  channelID,
+				inFlight:   make(chan struct{}, wsMaxInFlightRequests),
+			}
+			c.serve(s.n10n)
+		}}.ServeHTTP(rw, req)
+	}, cookiesTokenToHeaders)
+}
+
+func isSameOrigin(req *http.Request) bool {
+	origin := req.Header.Get(httpu.Origin)
+	if len(origin) == 0 {
+		// not a browser
+		return true
+	}
+	originURL, err := url.Parse(origin)
+	return err == nil && originURL.Host == req.Host
+}
+
+// finishes when the client disconnects or the router is stopped
+func (c *wsConn) serve(n10n in10n.IN10nBroker) {
+	ctx, cancel := context.WithCancel(c.logCtx)
+	defer cancel()
+
+	// deadlines of the hijacked connection are set by http.Server
+	if err := c.ws.SetDeadline(time.Time{}); err != nil {
+		// notest
+		logger.ErrorCtx(c.logCtx, n10nErrorStage, "failed to reset deadlines:", err)
+		return
+	}
+	if err := c.send(wsChannelMessage{Type: wsMsgType_Channel, ChannelID: c.channelID}); err != nil {
+		return
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer cancel()
+		n10n.WatchChannel(ctx, c.channelID, func(projection in10n.ProjectionKey, offset istructs.Offset) {
+			err := c.send(wsNotificationMessage{
+				Type:       wsMsgType_Notification,
+				Projection: json.RawMessage(projection.ToJSON()),
+				Offset:     offset,
+			})
+			if err != nil {
+				logger.ErrorCtx(n10nProjectionLogCtx(c.logCtx, projection), "n10n.ws_send.error", err)
+				cancel()
+			}
+		})
+	}()
+
+	// Receive() is not cancellable, so the connection is closed to stop reading
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		<-ctx.Done()
+		c.ws.Close()
+	}()
+
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(c.ws, &data); err != nil {
+			if logger.IsVerbose() {
+				logger.VerboseCtx(c.logCtx, "n10n.ws.closed", err)
+			}
+			break
+		}
+		msg := wsClientMessage{}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.reply(msg.ID, http.StatusBadRequest, commonErrorBody(fmt.Sprintf("failed to unmarshal message: %s", err), http.StatusBadRequest))
+			continue
+		}
+		select {
+		case c.inFlight <- struct{}{}:
+		default:
+			c.reply(msg.ID, http.StatusTooManyRequests, commonErrorBody("too many requests in flight", http.StatusTooManyRequests))
+			continue
+		}
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			defer func() { <-c.inFlight }()
+			c.handleMessage(ctx, msg)
+		}()
+	}
+	cancel()
+	c.wg.Wait()
+}
+
+func (c *wsConn) handleMessage(ctx context.Context, msg wsClientMessage) {
+	switch msg.Type {
+	case wsMsgType_Subscribe, wsMsgType_Unsubscribe:
+		method := http.MethodPut
+		if msg.Type == wsMsgType_Unsubscribe {
+			method = http.MethodDelete
+		}
+		if len(msg.Subscriptions) == 0 {
+			c.reply(msg.ID, http.StatusBadRequest, commonErrorBody("no subscriptions provided", http.StatusBadRequest))
+			return
+		}
+		for _, subscr := range msg.Subscriptions {
+			path := fmt.Sprintf("notifications/%s/workspaces/%s/subscriptions/%s", c.channelID, subscr.WSID, url.PathEscape(subscr.Entity))
+			if status, body := c.serveHTTP(ctx, method, path, nil); status < http.StatusOK || status >= http.StatusMultipleChoices {
+				c.reply(msg.ID, status, body)
+				return
+			}
+		}
+		c.reply(msg.ID, http.StatusOK, nil)
+	case wsMsgType_Request:
+		if !strings.HasPrefix(msg.Path, wsRequestPathPrefix) || strings.Contains(msg.Path, "..") {
+			c.reply(msg.ID, http.StatusBadRequest, commonErrorBody(fmt.Sprintf("path must start with %q", wsRequestPathPrefix), http.StatusBadRequest))
+			return
+		}
+		status, body := c.serveHTTP(ctx, msg.Method, msg.Path, msg.Body)
+		c.reply(msg.ID, status, body)
+	default:
+		c.reply(msg.ID, http.StatusBadRequest, commonErrorBody(fmt.Sprintf("unknown message type %q", msg.Type), http.StatusBadRequest))
+	}
+}
+
+// serves the request by the router handlers, path is relative to the application
+func (c *wsConn) serveHTTP(ctx context.Context, method string, path string, body []byte) (status int, respBody []byte) {
+	req, err := http.NewRequestWithContext(ctx, method, c.appPath+path, bytes.NewReader(body))
+	if err != nil {
+		return http.StatusBadRequest, commonErrorBody(err.Error(), http.StatusBadRequest)
+	}
+	req.Header.Set(httpu.Authorization, c.authHeader)
+	req.RemoteAddr = c.remoteAddr
+	req.Host = c.host
+	rec := &wsResponseRecorder{header: http.Header{}}
+	c.handler.ServeHTTP(rec, req)
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.status, rec.body.Bytes()
+}
+
+func (c *wsConn) reply(id string, status int, body []byte) {
+	resp := wsResponseMessage{ID: id, Type: wsMsgType_Response, Status: status}
+	if len(body) > 0 {
+		if json.Valid(body) {
+			resp.Body = body
+		} else {
+			// e.g. text/plain error
+			resp.Body, _ = json.Marshal(string(body))
+		}
+	}
+	if err := c.send(resp); err != nil {
+		logger.ErrorCtx(c.logCtx, "n10n.ws_send.error", err)
+	}
+}
+
+// safe for concurrent use
+func (c *wsConn) send(msg any) error {
+	return websocket.JSON.Send(c.ws, msg)
+}
+
+// the same as writeCommonError_V2 writes
+func commonErrorBody(msg string, status int) []byte {
+	return []byte(jsonu.Jprintf(`{"status":%d,"message":%q}`, status, msg))
+}
+
+func (r *wsResponseRecorder) Header() http.Header { return r.header }
+
+func (r *wsResponseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
+
+func (r *wsResponseRecorder) WriteHeader(statusCode int) {
+	if r.status == 0 {
+		r.status = statusCode
+	}
+}
+
+func (r *wsResponseRecorder) Flush() {}
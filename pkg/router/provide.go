@@ -23,11 +23,14 @@ import (
 
 // port == 443 -> httpsService + ACMEService, otherwise -> HTTPService only, ACMEService is nil
 // where is VVM RequestHandler? bus.RequestHandler
+// ownerLocator == nil -> requests are never forwarded to other VVMs
+// appTranslations == nil -> error messages of the router are not translated
 func Provide(rp RouterParams, broker in10n.IN10nBroker, blobRequestHandler blobprocessor.IRequestHandler, autocertCache autocert.Cache,
 	requestSender bus.IRequestSender, numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, iTokens itokens.ITokens,
-	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory) (httpSrv IHTTPService, acmeSrv IACMEService, adminSrv IAdminService) {
+	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory, ownerLocator PartitionOwnerLocator,
+	appTranslations AppTranslations) (httpSrv IHTTPService, acmeSrv IACMEService, adminSrv IAdminService) {
 	httpServ := getRouterService("sys._HTTPServer", httpu.ListenAddr(rp.Port), rp, broker, blobRequestHandler,
-		requestSender, numsAppsWorkspaces, iTokens, federation, appTokensFactory)
+		requestSender, numsAppsWorkspaces, iTokens, federation, appTokensFactory, ownerLocator, appTranslations)
 	adminEndpoint := fmt.Sprintf("%s:%d", httpu.LocalhostIP, rp.AdminPort)
 	adminSrv = getRouterService("sys._AdminHTTPServer", adminEndpoint, RouterParams{
 		HTTPServerParams: HTTPServerParams{
@@ -35,7 +38,7 @@ func Provide(rp RouterParams, broker in10n.IN10nBroker, blobRequestHandler blobp
 			ReadTimeout:      rp.ReadTimeout,
 			ConnectionsLimit: rp.ConnectionsLimit,
 		},
-	}, broker, nil, requestSender, numsAppsWorkspaces, iTokens, federation, appTokensFactory)
+	}, broker, nil, requestSender, numsAppsWorkspaces, iTokens, federation, appTokensFactory, ownerLocator, appTranslations)
 
 	if rp.Port != HTTPSPort {
 		return httpServ, nil, adminSrv
@@ -77,7 +80,8 @@ func Provide(rp RouterParams, broker in10n.IN10nBroker, blobRequestHandler blobp
 func getRouterService(name string, listenAddress string, rp RouterParams, broker in10n.IN10nBroker,
 	blobRequestHandler blobprocessor.IRequestHandler, requestSender bus.IRequestSender,
 	numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, iTokens itokens.ITokens,
-	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory) *routerService {
+	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory, ownerLocator PartitionOwnerLocator,
+	appTranslations AppTranslations) *routerService {
 	return &routerService{
 		httpServer:         getHTTPServer(name, listenAddress, rp.HTTPServerParams),
 		routeDefault:       rp.RouteDefault,
@@ -91,6 +95,10 @@ func getRouterService(name string, listenAddress string, rp RouterParams, broker
 		iTokens:            iTokens,
 		federation:         federation,
 		appTokensFactory:   appTokensFactory,
+		ownerLocator:       ownerLocator,
+		compressionMinSize: rp.CompressionMinSize,
+		staticFolders:      rp.StaticFolders,
+		appTranslations:    appTranslations,
 		queryLimiter: &wsQueryLimiter{
 			maxQPerWS:  rp.MaxQueriesPerWS,
 			iTime:      rp.ITime,
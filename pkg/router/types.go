@@ -6,6 +6,7 @@
 package router
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/net/websocket"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/bus"
@@ -46,7 +48,9 @@ type RouterParams struct {
 	RoutesRewrite        map[string]string // /grafana-rewrite=http://10.0.0.3:3000/rewritten : https://alpha.dev.untill.ru/grafana-rewrite/foo -> http://10.0.0.3:3000/rewritten/foo
 	RouteDomains         map[string]string // resellerportal.dev.untill.ru=http://resellerportal : https://resellerportal.dev.untill.ru/foo -> http://resellerportal/foo
 	MaxQueriesPerWS      int
+	CompressionMinSize   int // responses smaller than this are sent uncompressed, 0 -> compression is disabled
 	ITime                timeu.ITime
+	StaticFolders        http.Handler // serves /static/ requests, nil -> not served
 }
 
 type httpServer struct {
@@ -74,6 +78,25 @@ type routerService struct {
 	federation         federation.IFederation
 	appTokensFactory   payloads.IAppTokensFactory
 	queryLimiter       *wsQueryLimiter
+	ownerLocator       PartitionOwnerLocator
+	compressionMinSize int
+	staticFolders      http.Handler
+	appTranslations    AppTranslations
+}
+
+// Locates the VVM that owns the application partition which handles the workspace.
+//
+// Returns the owner VVM URL and true if the request should be forwarded to the owner,
+// false if the request should be handled by the current VVM.
+type PartitionOwnerLocator func(app appdef.AppQName, wsid istructs.WSID) (ownerURL *url.URL, forward bool)
+
+// Returns translations of the application or nil if the application is unknown
+type AppTranslations func(app appdef.AppQName) appdef.IWithTranslations
+
+// translates error messages written by the router, see [routerService.localizeErrors]
+type localizingResponseWriter struct {
+	http.ResponseWriter
+	translate func(text string) string
 }
 
 type httpsService struct {
@@ -116,6 +139,65 @@ type validatedData struct {
 	body     []byte
 }
 
+// WebSocket connection, one in10n channel per connection
+type wsConn struct {
+	ws         *websocket.Conn
+	logCtx     context.Context
+	handler    http.Handler // serves subscriptions and multiplexed requests
+	appPath    string       // /api/v2/apps/{owner}/{app}/
+	authHeader string
+	remoteAddr string
+	host       string
+	channelID  in10n.ChannelID
+	inFlight   chan struct{}
+	wg         sync.WaitGroup
+}
+
+// message from the client
+//
+//	{"id": "1", "type": "subscribe", "subscriptions": [{"entity": "pkg.View", "wsid": 140737488486400}]}
+//	{"id": "2", "type": "unsubscribe", "subscriptions": [{"entity": "pkg.View", "wsid": 140737488486400}]}
+//	{"id": "3", "type": "request", "method": "GET", "path": "workspaces/140737488486400/queries/pkg.Query?args=..."}
+type wsClientMessage struct {
+	ID            string           `json:"id"`
+	Type          string           `json:"type"`
+	Subscriptions []wsSubscription `json:"subscriptions,omitempty"`
+	Method        string           `json:"method,omitempty"`
+	Path          string           `json:"path,omitempty"` // relative to /api/v2/apps/{owner}/{app}/
+	Body          json.RawMessage  `json:"body,omitempty"`
+}
+
+type wsSubscription struct {
+	Entity string      `json:"entity"`
+	WSID   json.Number `json:"wsid"`
+}
+
+type wsChannelMessage struct {
+	Type      string          `json:"type"`
+	ChannelID in10n.ChannelID `json:"channelId"`
+}
+
+// reply to the client message with the same ID
+type wsResponseMessage struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+type wsNotificationMessage struct {
+	Type       string          `json:"type"`
+	Projection json.RawMessage `json:"projection"` // the same as the SSE event name
+	Offset     istructs.Offset `json:"offset"`
+}
+
+// collects the response of the multiplexed request
+type wsResponseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
 type validatorFunc func(validateData validatedData, req *http.Request) (validatedData, error)
 
 type wsQueryLimiter struct {
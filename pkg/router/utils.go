@@ -33,7 +33,7 @@ var onBeforeWriteResponse func(w http.ResponseWriter) // not nil in tests only
 func WriteTextResponse(w http.ResponseWriter, msg string, code int) {
 	w.Header().Set(httpu.ContentType, "text/plain")
 	w.WriteHeader(code)
-	writeResponse(w, msg)
+	writeResponse(w, localize(w, msg))
 }
 
 func ReplyCommonError(w http.ResponseWriter, msg string, code int) {
@@ -49,11 +49,11 @@ func ReplyJSON(w http.ResponseWriter, data string, code int) {
 }
 
 func writeCommonError_V2(w http.ResponseWriter, err error, code int) bool {
-	return writeResponse(w, jsonu.Jprintf(`{"status":%d,"message":%q}`, code, err.Error()))
+	return writeResponse(w, jsonu.Jprintf(`{"status":%d,"message":%q}`, code, localize(w, err.Error())))
 }
 
 func writeCommonError_V1(w http.ResponseWriter, err error, code int) bool {
-	sysErr := coreutils.WrapSysErrorToExact(err, code)
+	sysErr := localizeSysError(w, coreutils.WrapSysErrorToExact(err, code))
 	w.Header().Set(httpu.ContentType, httpu.ContentType_ApplicationJSON)
 	applySysErrorHeaders(w, sysErr)
 	w.WriteHeader(code)
@@ -82,7 +82,7 @@ func replyErr(rw http.ResponseWriter, err error) {
 	var sysError coreutils.SysError
 	if errors.As(err, &sysError) {
 		applySysErrorHeaders(rw, sysError)
-		ReplyJSON(rw, sysError.ToJSON_APIV2(), sysError.HTTPStatus)
+		ReplyJSON(rw, localizeSysError(rw, sysError).ToJSON_APIV2(), sysError.HTTPStatus)
 	} else {
 		ReplyCommonError(rw, err.Error(), http.StatusInternalServerError)
 	}
@@ -191,6 +191,14 @@ func apiPathToExtension(apiPath processors.APIPath) string {
 		return "sys._Users"
 	case processors.APIPath_N10N_SubscribeAndWatch:
 		return "sys._N10N_SubscribeAndWatch"
+	case processors.APIPath_Batch:
+		return "sys._Batch"
+	case processors.APIPath_InboundWebhooks:
+		return "sys._InboundWebhooks"
+	case processors.APIPath_Auth_OIDCAuthorize:
+		return "sys._Auth_OIDCAuthorize"
+	case processors.APIPath_Auth_OIDCCallback:
+		return "sys._Auth_OIDCCallback"
 	}
 	return strconv.Itoa(int(apiPath))
 }
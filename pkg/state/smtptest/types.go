@@ -5,8 +5,12 @@
 package smtptest
 
 import (
+	"encoding/base64"
 	"errors"
 	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
 	"strings"
 
 	"github.com/emersion/go-sasl"
@@ -134,6 +138,14 @@ func (s *session) message() state.EmailMessage {
 		msg.BCC = append(msg.BCC, recipient)
 	}
 
+	if parsed, err := mail.ReadMessage(strings.NewReader(s.data)); err == nil {
+		mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+		if err == nil && strings.HasPrefix(mediaType, "multipart/") {
+			readParts(&msg, multipart.NewReader(parsed.Body, params["boundary"]))
+			return msg
+		}
+	}
+
 	body := strings.Builder{}
 	for i := bodyStartLine; i < len(lines); i++ {
 		body.WriteString(lines[i])
@@ -143,6 +155,41 @@ func (s *session) message() state.EmailMessage {
 	return msg
 }
 
+// text/plain part -> TextBody, text/html part -> Body, parts with file names -> Attachments
+func readParts(msg *state.EmailMessage, r *multipart.Reader) {
+	for {
+		part, err := r.NextPart()
+		if err != nil {
+			return
+		}
+		mediaType, params, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if strings.HasPrefix(mediaType, "multipart/") {
+			readParts(msg, multipart.NewReader(part, params["boundary"]))
+			continue
+		}
+		var partReader io.Reader = part // quoted-printable is decoded by multipart.Reader itself
+		if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+			partReader = base64.NewDecoder(base64.StdEncoding, part)
+		}
+		data, err := io.ReadAll(partReader)
+		if err != nil {
+			return
+		}
+		switch {
+		case part.FileName() != "":
+			msg.Attachments = append(msg.Attachments, state.EmailAttachment{
+				Name:        part.FileName(),
+				ContentType: mediaType,
+				Data:        data,
+			})
+		case mediaType == "text/plain":
+			msg.TextBody = string(data)
+		default:
+			msg.Body = string(data)
+		}
+	}
+}
+
 type Option func(s Server)
 
 func WithCredentials(username, password string) Option {
@@ -44,15 +44,18 @@ func implProvideAsyncActualizerState(ctx context.Context, appStructsFunc state.A
 		return appStructsFunc().Events()
 	}
 
+	federationCommands := storages.NewFederationCommandStorage(appStructsFunc, wsidFunc, federationFunc, tokensFunc, stateOpts.FederationCommandHandler)
+
 	state.addStorage(sys.Storage_View, storages.NewViewRecordsStorage(ctx, appStructsFunc, wsidFunc, n10nFunc), S_GET|S_GET_BATCH|S_READ|S_INSERT|S_UPDATE)
 	state.addStorage(sys.Storage_Record, storages.NewRecordsStorage(appStructsFunc, wsidFunc, nil), S_GET|S_GET_BATCH)
 	state.addStorage(sys.Storage_Event, storages.NewEventStorage(eventFunc), S_GET)
 	state.addStorage(sys.Storage_WLog, storages.NewWLogStorage(ctx, ieventsFunc, wsidFunc), S_GET|S_READ)
-	state.addStorage(sys.Storage_SendMail, storages.NewSendMailStorage(emailSender), S_GET|S_INSERT)
-	state.addStorage(sys.Storage_HTTP, storages.NewHTTPStorage(httpClient), S_READ)
-	state.addStorage(sys.Storage_FederationCommand, storages.NewFederationCommandStorage(appStructsFunc, wsidFunc, federationFunc, tokensFunc, stateOpts.FederationCommandHandler), S_GET)
+	state.addStorage(sys.Storage_SendMail, storages.NewSendMailStorage(ctx, emailSender, appStructsFunc, wsidFunc, secretReader, stateOpts.BLOBReader, federationCommands), S_GET|S_INSERT)
+	state.addStorage(sys.Storage_HTTP, storages.NewHTTPStorage(httpClient, appStructsFunc, secretReader, stateOpts.EgressDeniedHandler), S_READ)
+	state.addStorage(sys.Storage_FederationCommand, federationCommands, S_GET)
 	state.addStorage(sys.Storage_FederationBlob, storages.NewFederationBlobStorage(appStructsFunc, wsidFunc, federationFunc, tokensFunc, stateOpts.FederationBlobHandler), S_READ)
 	state.addStorage(sys.Storage_AppSecret, storages.NewAppSecretsStorage(secretReader), S_GET)
+	state.addStorage(sys.Storage_Translation, storages.NewTranslationStorage(appStructsFunc), S_GET)
 	state.addStorage(sys.Storage_Uniq, storages.NewUniquesStorage(appStructsFunc, wsidFunc, stateOpts.UniquesHandler), S_GET)
 	state.addStorage(sys.Storage_Logger, storages.NewLoggerStorage(), S_INSERT)
 
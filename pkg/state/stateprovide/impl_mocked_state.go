@@ -30,6 +30,7 @@ func implProvideMockedCommandProcessorState(ctx context.Context, intentsLimit in
 	ms.addStorage(sys.Storage_Record, storages.NewMockedStorage(sys.Storage_Record), S_GET|S_GET_BATCH|S_INSERT|S_UPDATE)
 	ms.addStorage(sys.Storage_WLog, storages.NewMockedStorage(sys.Storage_WLog), S_GET)
 	ms.addStorage(sys.Storage_AppSecret, storages.NewMockedStorage(sys.Storage_AppSecret), S_GET)
+	ms.addStorage(sys.Storage_Translation, storages.NewMockedStorage(sys.Storage_Translation), S_GET)
 	ms.addStorage(sys.Storage_RequestSubject, storages.NewMockedStorage(sys.Storage_RequestSubject), S_GET)
 	ms.addStorage(sys.Storage_Result, storages.NewMockedStorage(sys.Storage_Result), S_INSERT)
 	ms.addStorage(sys.Storage_Uniq, storages.NewMockedStorage(sys.Storage_Uniq), S_GET)
@@ -55,12 +56,60 @@ func implProvideMockedActualizerState(ctx context.Context, intentsLimit int, app
 	ms.addStorage(sys.Storage_FederationCommand, storages.NewMockedStorage(sys.Storage_FederationCommand), S_GET)
 	ms.addStorage(sys.Storage_FederationBlob, storages.NewMockedStorage(sys.Storage_FederationBlob), S_READ)
 	ms.addStorage(sys.Storage_AppSecret, storages.NewMockedStorage(sys.Storage_AppSecret), S_GET)
+	ms.addStorage(sys.Storage_Translation, storages.NewMockedStorage(sys.Storage_Translation), S_GET)
 	ms.addStorage(sys.Storage_Uniq, storages.NewMockedStorage(sys.Storage_Uniq), S_GET)
 	ms.addStorage(sys.Storage_Logger, storages.NewMockedStorage(sys.Storage_Logger), S_INSERT)
 
 	return ms
 }
 
+func implProvideMockedQueryProcessorState(ctx context.Context, intentsLimit int, appStructsFunc state.AppStructsFunc) state.IHostState {
+
+	ms := &MockedState{
+		ctx:       ctx,
+		hostState: newHostState(ctx, "MockedQueryProcessorState", intentsLimit, appStructsFunc),
+	}
+
+	ms.addStorage(sys.Storage_View, storages.NewMockedStorage(sys.Storage_View), S_GET|S_GET_BATCH|S_READ)
+	ms.addStorage(sys.Storage_Record, storages.NewMockedStorage(sys.Storage_Record), S_GET|S_GET_BATCH)
+	ms.addStorage(sys.Storage_WLog, storages.NewMockedStorage(sys.Storage_WLog), S_GET|S_READ)
+	ms.addStorage(sys.Storage_HTTP, storages.NewMockedStorage(sys.Storage_HTTP), S_READ)
+	ms.addStorage(sys.Storage_FederationCommand, storages.NewMockedStorage(sys.Storage_FederationCommand), S_GET)
+	ms.addStorage(sys.Storage_FederationBlob, storages.NewMockedStorage(sys.Storage_FederationBlob), S_READ)
+	ms.addStorage(sys.Storage_AppSecret, storages.NewMockedStorage(sys.Storage_AppSecret), S_GET)
+	ms.addStorage(sys.Storage_Translation, storages.NewMockedStorage(sys.Storage_Translation), S_GET)
+	ms.addStorage(sys.Storage_RequestSubject, storages.NewMockedStorage(sys.Storage_RequestSubject), S_GET)
+	ms.addStorage(sys.Storage_QueryContext, storages.NewMockedStorage(sys.Storage_QueryContext), S_GET)
+	ms.addStorage(sys.Storage_Result, storages.NewMockedStorage(sys.Storage_Result), S_INSERT)
+	ms.addStorage(sys.Storage_Uniq, storages.NewMockedStorage(sys.Storage_Uniq), S_GET)
+	ms.addStorage(sys.Storage_Logger, storages.NewMockedStorage(sys.Storage_Logger), S_INSERT)
+
+	return ms
+}
+
+func implProvideMockedSchedulerState(ctx context.Context, intentsLimit int, appStructsFunc state.AppStructsFunc) state.IHostState {
+
+	ms := &MockedState{
+		ctx:       ctx,
+		hostState: newHostState(ctx, "MockedSchedulerState", intentsLimit, appStructsFunc),
+	}
+
+	ms.addStorage(sys.Storage_View, storages.NewMockedStorage(sys.Storage_View), S_GET|S_GET_BATCH|S_READ|S_INSERT|S_UPDATE)
+	ms.addStorage(sys.Storage_Record, storages.NewMockedStorage(sys.Storage_Record), S_GET|S_GET_BATCH)
+	ms.addStorage(sys.Storage_WLog, storages.NewMockedStorage(sys.Storage_WLog), S_GET|S_READ)
+	ms.addStorage(sys.Storage_SendMail, storages.NewMockedStorage(sys.Storage_SendMail), S_INSERT)
+	ms.addStorage(sys.Storage_HTTP, storages.NewMockedStorage(sys.Storage_HTTP), S_READ)
+	ms.addStorage(sys.Storage_FederationCommand, storages.NewMockedStorage(sys.Storage_FederationCommand), S_GET)
+	ms.addStorage(sys.Storage_FederationBlob, storages.NewMockedStorage(sys.Storage_FederationBlob), S_READ)
+	ms.addStorage(sys.Storage_AppSecret, storages.NewMockedStorage(sys.Storage_AppSecret), S_GET)
+	ms.addStorage(sys.Storage_Translation, storages.NewMockedStorage(sys.Storage_Translation), S_GET)
+	ms.addStorage(sys.Storage_Uniq, storages.NewMockedStorage(sys.Storage_Uniq), S_GET)
+	ms.addStorage(sys.Storage_JobContext, storages.NewMockedStorage(sys.Storage_JobContext), S_GET)
+	ms.addStorage(sys.Storage_Logger, storages.NewMockedStorage(sys.Storage_Logger), S_INSERT)
+
+	return ms
+}
+
 func (ms *MockedState) GetMockedStorage(storageName appdef.QName) (*storages.MockedStorage, bool) {
 	st, ok := ms.storages[storageName]
 	if !ok {
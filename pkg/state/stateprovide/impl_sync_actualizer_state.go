@@ -36,6 +36,7 @@ func implProvideSyncActualizerState(ctx context.Context, appStructsFunc state.Ap
 	hs.addStorage(sys.Storage_Record, storages.NewRecordsStorage(appStructsFunc, wsidFunc, nil), S_GET|S_GET_BATCH)
 	hs.addStorage(sys.Storage_WLog, storages.NewWLogStorage(ctx, ieventsFunc, wsidFunc), S_GET)
 	hs.addStorage(sys.Storage_AppSecret, storages.NewAppSecretsStorage(secretReader), S_GET)
+	hs.addStorage(sys.Storage_Translation, storages.NewTranslationStorage(appStructsFunc), S_GET)
 	hs.addStorage(sys.Storage_Uniq, storages.NewUniquesStorage(appStructsFunc, wsidFunc, stateOpts.UniquesHandler), S_GET)
 	hs.addStorage(sys.Storage_Logger, storages.NewLoggerStorage(), S_INSERT)
 	return hs
@@ -146,6 +146,9 @@ func (r *mockViewRecords) PutBatch(workspace istructs.WSID, batch []istructs.Vie
 func (r *mockViewRecords) Read(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
 	return r.Called(ctx, workspace, key, cb).Error(0)
 }
+func (r *mockViewRecords) ReadReverse(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
+	return r.Called(ctx, workspace, key, cb).Error(0)
+}
 
 type mockRecord struct {
 	istructs.IRecord
@@ -18,6 +18,14 @@ func ProvideMockedActualizerStateFactory() state.MockedStateFactory {
 	return implProvideMockedActualizerState
 }
 
+func ProvideMockedQueryProcessorStateFactory() state.MockedStateFactory {
+	return implProvideMockedQueryProcessorState
+}
+
+func ProvideMockedSchedulerStateFactory() state.MockedStateFactory {
+	return implProvideMockedSchedulerState
+}
+
 func ProvideSyncActualizerStateFactory() state.SyncActualizerStateFactory {
 	return implProvideSyncActualizerState
 }
@@ -12,6 +12,7 @@ const (
 	ProcKind_Actualizer = iota
 	ProcKind_CommandProcessor
 	ProcKind_QueryProcessor
+	ProcKind_Scheduler
 )
 
 const (
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"runtime"
 	"slices"
 	"strings"
@@ -108,6 +109,20 @@ func (gts *generalTestState) stateSingletonRecord(fQName IFullQName, keyValueLis
 	gts.record(fQName, istructs.MinReservedRecordID, isSingleton, keyValueList...)
 }
 
+func (gts *generalTestState) stateView(fQName IFullQName, id istructs.RecordID, keyValueList ...any) {
+	if !gts.isView(fQName) {
+		panic("View method must be used for views only")
+	}
+
+	gts.viewRecords = append(gts.viewRecords, recordItem{
+		entity:       fQName,
+		qName:        gts.getQNameFromFQName(fQName),
+		id:           id,
+		isView:       true,
+		keyValueList: keyValueList,
+	})
+}
+
 func (gts *generalTestState) getQNameFromFQName(fQName IFullQName) appdef.QName {
 	localPkgName := gts.appDef.PackageLocalName(fQName.PkgPath())
 	return appdef.NewQName(localPkgName, fQName.Entity())
@@ -184,10 +199,8 @@ func (gts *generalTestState) addRequiredItems(
 
 // recoverPanicInTestState must be called in defer to recover panic in the test state
 func (gts *generalTestState) recoverPanicInTestState() {
-	r := recover()
-	recoveredError, ok := r.(error)
-	if ok {
-		require.Fail(gts.t, recoveredError.Error())
+	if r := recover(); r != nil {
+		require.Fail(gts.t, fmt.Sprint(r))
 	}
 }
 
@@ -483,37 +496,44 @@ func (cts *CommandTestState) StateSingletonRecord(fQName IFullQName, keyValueLis
 }
 
 func (cts *CommandTestState) putArgument() {
-	if cts.argumentObject == nil {
+	cts.putArgumentObject(sys.Storage_CommandContext, sys.Storage_CommandContext_Field_ArgumentObject, nil)
+}
+
+// putArgumentObject writes the argument object into the mocked context storage (CommandContext or QueryContext)
+// contextData is the data of the context object itself, e.g. the workspace
+func (gts *generalTestState) putArgumentObject(contextStorage appdef.QName, argumentField string, contextData map[string]any) {
+	if gts.argumentObject == nil {
 		return
 	}
 
-	mockedCommandContextStorage, ok := cts.getMockedStorage(sys.Storage_CommandContext)
+	mockedContextStorage, ok := gts.getMockedStorage(contextStorage)
 	if !ok {
-		panic("failed to get mocked command context storage")
+		panic(fmt.Sprintf("failed to get mocked %s storage", contextStorage.String()))
 	}
 
-	localPkgName := cts.appDef.PackageLocalName(cts.argumentType.PkgPath())
-	localQName := appdef.NewQName(localPkgName, cts.argumentType.Entity())
+	localPkgName := gts.appDef.PackageLocalName(gts.argumentType.PkgPath())
+	localQName := appdef.NewQName(localPkgName, gts.argumentType.Entity())
 
 	mockedObject := &coreutils.TestObject{
+		Data:        contextData,
 		Containers_: make(map[string][]*coreutils.TestObject),
 	}
 
 	// setting argument object
-	mockedObject.Containers_[sys.Storage_CommandContext_Field_ArgumentObject] = append(
-		mockedObject.Containers_[sys.Storage_CommandContext_Field_ArgumentObject],
+	mockedObject.Containers_[argumentField] = append(
+		mockedObject.Containers_[argumentField],
 		&coreutils.TestObject{
 			Name:        localQName,
-			Data:        cts.argumentObject,
+			Data:        gts.argumentObject,
 			Containers_: make(map[string][]*coreutils.TestObject),
 		},
 	)
 
-	for key, value := range cts.argumentObject {
+	for key, value := range gts.argumentObject {
 		if innerSlice, ok := value.([]any); ok {
 			for _, innerValue := range innerSlice {
-				mockedObject.Containers_[sys.Storage_CommandContext_Field_ArgumentObject][0].Containers_[key] = append(
-					mockedObject.Containers_[sys.Storage_CommandContext_Field_ArgumentObject][0].Containers_[key],
+				mockedObject.Containers_[argumentField][0].Containers_[key] = append(
+					mockedObject.Containers_[argumentField][0].Containers_[key],
 					&coreutils.TestObject{
 						Data:        innerValue.(map[string]any),
 						Containers_: make(map[string][]*coreutils.TestObject),
@@ -522,13 +542,18 @@ func (cts *CommandTestState) putArgument() {
 			}
 		}
 	}
-	// writing an event object directly to the storage
-	mockedCommandContextStorage.PutRecord(0, mockedObject)
+	// writing an argument object directly to the storage
+	mockedContextStorage.PutRecord(0, mockedObject)
 }
 
 // buildAppDef alternative way of building IAppDef
 func (gts *generalTestState) buildAppDef() {
-	compileResult, err := compile.Compile("..")
+	// the test runs in the extension dir, e.g. `wasm`, the application package is the parent one
+	dir, err := filepath.Abs("..")
+	if err != nil {
+		panic(err)
+	}
+	compileResult, err := compile.Compile(dir)
 	if err != nil {
 		panic(err)
 	}
@@ -571,31 +596,41 @@ func (gts *generalTestState) buildAppDef() {
 }
 
 func (cts *CommandTestState) ArgumentObject(id istructs.RecordID, keyValueList ...any) ICommandRunner {
+	cts.argumentObjectFields(id, keyValueList...)
+
+	return cts
+}
+
+func (cts *CommandTestState) ArgumentObjectRow(path string, id istructs.RecordID, keyValueList ...any) ICommandRunner {
+	cts.argumentObjectRow(path, id, keyValueList...)
+
+	return cts
+}
+
+func (gts *generalTestState) argumentObjectFields(id istructs.RecordID, keyValueList ...any) {
 	keyValueMap, err := parseKeyValues(keyValueList)
 	if err != nil {
 		panic(fmt.Errorf(fmtMsgFailedToParseKeyValues, err))
 	}
 
 	for key, value := range keyValueMap {
-		v, valueExist := cts.argumentObject[key]
+		v, valueExist := gts.argumentObject[key]
 		if valueExist {
 			panic(fmt.Errorf("key %s already exists in the argument object with value %v", key, v))
 		}
 
-		cts.argumentObject[key] = value
+		gts.argumentObject[key] = value
 		if intValue, ok := value.(int); ok {
-			cts.argumentObject[key] = json.Number(fmt.Sprintf("%d", intValue))
+			gts.argumentObject[key] = json.Number(fmt.Sprintf("%d", intValue))
 		}
 	}
-	cts.argumentObject[appdef.SystemField_ID] = id
-
-	return cts
+	gts.argumentObject[appdef.SystemField_ID] = id
 }
 
-func (cts *CommandTestState) ArgumentObjectRow(path string, id istructs.RecordID, keyValueList ...any) ICommandRunner {
+func (gts *generalTestState) argumentObjectRow(path string, id istructs.RecordID, keyValueList ...any) {
 	parts := strings.Split(path, "/")
 
-	innerTree := cts.argumentObject
+	innerTree := gts.argumentObject
 	for i, part := range parts {
 		if len(part) == 0 {
 			continue
@@ -609,8 +644,6 @@ func (cts *CommandTestState) ArgumentObjectRow(path string, id istructs.RecordID
 		innerTree = putToArgumentObjectTree(innerTree, part, keyValueList...)
 		innerTree[appdef.SystemField_ID] = id
 	}
-
-	return cts
 }
 
 func (cts *CommandTestState) IntentSingletonInsert(fQName IFullQName, keyValueList ...any) ICommandRunner {
@@ -791,17 +824,7 @@ func (pts *ProjectorTestState) IntentViewUpdate(fQName IFullQName, id istructs.R
 }
 
 func (pts *ProjectorTestState) StateView(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IProjectorRunner {
-	if !pts.isView(fQName) {
-		panic("View method must be used for views only")
-	}
-
-	pts.viewRecords = append(pts.viewRecords, recordItem{
-		entity:       fQName,
-		qName:        pts.getQNameFromFQName(fQName),
-		id:           id,
-		isView:       true,
-		keyValueList: keyValueList,
-	})
+	pts.stateView(fQName, id, keyValueList...)
 
 	return pts
 }
@@ -924,6 +947,225 @@ func (pts *ProjectorTestState) EventWSID(wsid istructs.WSID) IProjectorRunner {
 	return pts
 }
 
+// QueryTestState is a test state for query testing
+type QueryTestState struct {
+	generalTestState
+
+	// resultRows is to store expected result rows
+	resultRows []recordItem
+}
+
+// NewQueryTestState creates a new test state for query testing
+func NewQueryTestState(t *testing.T, iQuery IQuery, extensionFunc func()) *QueryTestState {
+	const wsid = istructs.WSID(1)
+
+	qts := &QueryTestState{}
+
+	qts.testData = make(map[string]any)
+	qts.t = t
+	qts.ctx = context.Background()
+	qts.processorKind = ProcKind_QueryProcessor
+	qts.commandWSID = wsid
+	qts.secretReader = &secretReader{secrets: make(map[string][]byte)}
+
+	// build appDef
+	qts.buildAppDef()
+	// build state
+	qts.IState = stateprovide.ProvideMockedQueryProcessorStateFactory()(
+		qts.ctx,
+		IntentsLimit,
+		func() istructs.IAppStructs { return qts.appStructs },
+	)
+
+	// initialize funcRunner and extensionFunc itself
+	qts.funcRunner = &sync.Once{}
+	qts.extensionFunc = extensionFunc
+
+	qts.argumentObject = make(map[string]any)
+	// set arguments for the query
+	if len(iQuery.ArgumentEntity()) > 0 {
+		qts.argumentType = appdef.NewFullQName(iQuery.ArgumentPkgPath(), iQuery.ArgumentEntity())
+	}
+
+	return qts
+}
+
+func (qts *QueryTestState) StateRecord(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IQueryRunner {
+	qts.stateRecord(fQName, id, keyValueList...)
+
+	return qts
+}
+
+func (qts *QueryTestState) StateSingletonRecord(fQName IFullQName, keyValueList ...any) IQueryRunner {
+	qts.stateSingletonRecord(fQName, keyValueList...)
+
+	return qts
+}
+
+func (qts *QueryTestState) StateView(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IQueryRunner {
+	qts.stateView(fQName, id, keyValueList...)
+
+	return qts
+}
+
+func (qts *QueryTestState) ArgumentObject(id istructs.RecordID, keyValueList ...any) IQueryRunner {
+	qts.argumentObjectFields(id, keyValueList...)
+
+	return qts
+}
+
+func (qts *QueryTestState) ArgumentObjectRow(path string, id istructs.RecordID, keyValueList ...any) IQueryRunner {
+	qts.argumentObjectRow(path, id, keyValueList...)
+
+	return qts
+}
+
+func (qts *QueryTestState) ResultRow(keyValueList ...any) IQueryRunner {
+	qts.resultRows = append(qts.resultRows, recordItem{
+		fileReference: getSourceFileReference(2),
+		keyValueList:  keyValueList,
+	})
+
+	return qts
+}
+
+func (qts *QueryTestState) Run() {
+	defer qts.recoverPanicInTestState()
+
+	qts.putViewRecords()
+	qts.putRecords()
+	qts.putArgumentObject(sys.Storage_QueryContext, sys.Storage_QueryContext_Field_ArgumentObject, map[string]any{
+		sys.Storage_QueryContext_Field_Workspace: int64(qts.commandWSID), // nolint G115
+	})
+
+	qts.runExtensionFunc()
+
+	qts.requireResultRows()
+}
+
+// requireResultRows checks out the result rows emitted by the query in the order they are emitted
+func (qts *QueryTestState) requireResultRows() {
+	emittedRows := make([]istructs.IStateValueBuilder, 0, len(qts.resultRows))
+	qts.IState.Intents(func(key istructs.IStateKeyBuilder, value istructs.IStateValueBuilder, _ bool) {
+		if key.Storage() == sys.Storage_Result {
+			emittedRows = append(emittedRows, value)
+		}
+	})
+
+	require.Len(qts.t, emittedRows, len(qts.resultRows), "unexpected number of result rows")
+
+	for i, row := range qts.resultRows {
+		m, err := parseKeyValues(row.keyValueList)
+		require.NoError(qts.t, err, msgFailedToParseKeyValues)
+
+		qts.equalValues(emittedRows[i], m, row.fileReference)
+	}
+
+	// clear result rows after they are processed
+	qts.resultRows = nil
+}
+
+// JobTestState is a test state for job testing
+type JobTestState struct {
+	generalTestState
+
+	jobTime time.Time
+}
+
+// NewJobTestState creates a new test state for job testing
+func NewJobTestState(t *testing.T, extensionFunc func()) *JobTestState {
+	const wsid = istructs.WSID(1)
+
+	jts := &JobTestState{}
+
+	jts.testData = make(map[string]any)
+	jts.t = t
+	jts.ctx = context.Background()
+	jts.processorKind = ProcKind_Scheduler
+	jts.commandWSID = wsid
+	jts.secretReader = &secretReader{secrets: make(map[string][]byte)}
+	jts.jobTime = testingu.MockTime.Now()
+
+	// build appDef
+	jts.buildAppDef()
+	// build state
+	jts.IState = stateprovide.ProvideMockedSchedulerStateFactory()(
+		jts.ctx,
+		IntentsLimit,
+		func() istructs.IAppStructs { return jts.appStructs },
+	)
+
+	// initialize funcRunner and extensionFunc itself
+	jts.funcRunner = &sync.Once{}
+	jts.extensionFunc = extensionFunc
+
+	return jts
+}
+
+func (jts *JobTestState) StateRecord(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IJobRunner {
+	jts.stateRecord(fQName, id, keyValueList...)
+
+	return jts
+}
+
+func (jts *JobTestState) StateSingletonRecord(fQName IFullQName, keyValueList ...any) IJobRunner {
+	jts.stateSingletonRecord(fQName, keyValueList...)
+
+	return jts
+}
+
+func (jts *JobTestState) StateView(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IJobRunner {
+	jts.stateView(fQName, id, keyValueList...)
+
+	return jts
+}
+
+func (jts *JobTestState) JobTime(jobTime time.Time) IJobRunner {
+	jts.jobTime = jobTime
+
+	return jts
+}
+
+func (jts *JobTestState) IntentViewInsert(fQName IFullQName, keyValueList ...any) IJobRunner {
+	jts.addRequiredItems(fQName, 0, false, true, true, getSourceFileReference(2), keyValueList...)
+
+	return jts
+}
+
+func (jts *JobTestState) IntentViewUpdate(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IJobRunner {
+	jts.addRequiredItems(fQName, id, false, false, true, getSourceFileReference(2), keyValueList...)
+
+	return jts
+}
+
+func (jts *JobTestState) Run() {
+	defer jts.recoverPanicInTestState()
+
+	jts.putViewRecords()
+	jts.putRecords()
+	jts.putJobContext()
+
+	jts.runExtensionFunc()
+
+	jts.require()
+}
+
+func (jts *JobTestState) putJobContext() {
+	mockedJobContextStorage, ok := jts.getMockedStorage(sys.Storage_JobContext)
+	if !ok {
+		panic("failed to get mocked job context storage")
+	}
+
+	// writing a job context object directly to the storage
+	mockedJobContextStorage.PutRecord(0, &coreutils.TestObject{
+		Data: map[string]any{
+			sys.Storage_JobContext_Field_Workspace: int64(jts.commandWSID), // nolint G115
+			sys.Storage_JobContext_Field_UnixTime:  jts.jobTime.Unix(),
+		},
+		Containers_: make(map[string][]*coreutils.TestObject),
+	})
+}
+
 func parseKeyValues(keyValues []any) (map[string]any, error) {
 	if len(keyValues)%2 != 0 {
 		return nil, errors.New("key-value list must be even")
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package teststate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+// test application is in testdata/app, the test state compiles the parent dir of the working dir
+const testAppPkgPath = "github.com/voedger/voedger/pkg/state/teststate/testdata/app"
+
+type testFullQName string
+
+func (n testFullQName) PkgPath() string { return testAppPkgPath }
+func (n testFullQName) Entity() string  { return string(n) }
+
+type testQuery struct {
+	testFullQName
+	argument string
+}
+
+func (q testQuery) ArgumentPkgPath() string     { return testAppPkgPath }
+func (q testQuery) ArgumentEntity() string      { return q.argument }
+func (q testQuery) WorkspaceDescriptor() string { return "" }
+
+type testView struct {
+	testFullQName
+	keys []string
+}
+
+func (v testView) Keys() []string { return v.keys }
+
+var (
+	testViewCounters = testView{testFullQName: "Counters", keys: []string{"Year", "Month"}}
+	testQueryCounter = testQuery{testFullQName: "Counter", argument: "CounterParams"}
+)
+
+func TestQueryTestState(t *testing.T) {
+	t.Chdir("testdata/app/wasm")
+
+	// returns the Count from the Counters view for the Year and Month from the arguments
+	counter := func(s istructs.IState, intents istructs.IIntents) {
+		kb, err := s.KeyBuilder(sys.Storage_QueryContext, appdef.NullQName)
+		require.NoError(t, err)
+		qc, err := s.MustExist(kb)
+		require.NoError(t, err)
+		args := qc.AsValue(sys.Storage_QueryContext_Field_ArgumentObject)
+
+		kb, err = s.KeyBuilder(sys.Storage_View, appdef.NewQName("app", "Counters"))
+		require.NoError(t, err)
+		kb.PutInt32("Year", args.AsInt32("Year"))
+		kb.PutInt32("Month", args.AsInt32("Month"))
+		counter, ok, err := s.CanExist(kb)
+		require.NoError(t, err)
+		if !ok {
+			return
+		}
+
+		kb, err = s.KeyBuilder(sys.Storage_Result, appdef.NullQName)
+		require.NoError(t, err)
+		res, err := intents.NewValue(kb)
+		require.NoError(t, err)
+		res.PutInt32("Count", counter.AsInt32("Count"))
+	}
+
+	t.Run("should check result rows", func(t *testing.T) {
+		var ts *QueryTestState
+		ts = NewQueryTestState(t, testQueryCounter, func() { counter(ts, ts) })
+		ts.
+			StateView(testViewCounters, 0, `Year`, 2025, `Month`, 3, `Count`, 42).
+			ArgumentObject(1, `Year`, 2025, `Month`, 3).
+			ResultRow(`Count`, 42).
+			Run()
+	})
+
+	t.Run("should be no result rows", func(t *testing.T) {
+		var ts *QueryTestState
+		ts = NewQueryTestState(t, testQueryCounter, func() { counter(ts, ts) })
+		ts.
+			StateView(testViewCounters, 0, `Year`, 2025, `Month`, 3, `Count`, 42).
+			ArgumentObject(1, `Year`, 2025, `Month`, 4).
+			Run()
+	})
+}
+
+func TestJobTestState(t *testing.T) {
+	t.Chdir("testdata/app/wasm")
+
+	// increments the Count in the Counters view for the year and month of the job run time
+	tick := func(s istructs.IState, intents istructs.IIntents) {
+		kb, err := s.KeyBuilder(sys.Storage_JobContext, appdef.NullQName)
+		require.NoError(t, err)
+		jc, err := s.MustExist(kb)
+		require.NoError(t, err)
+		runTime := time.Unix(jc.AsInt64(sys.Storage_JobContext_Field_UnixTime), 0).UTC()
+
+		kb, err = s.KeyBuilder(sys.Storage_View, appdef.NewQName("app", "Counters"))
+		require.NoError(t, err)
+		kb.PutInt32("Year", int32(runTime.Year()))   // nolint G115
+		kb.PutInt32("Month", int32(runTime.Month())) // nolint G115
+		counter, ok, err := s.CanExist(kb)
+		require.NoError(t, err)
+
+		var vb istructs.IStateValueBuilder
+		count := int32(1)
+		if ok {
+			vb, err = intents.UpdateValue(kb, counter)
+			count += counter.AsInt32("Count")
+		} else {
+			vb, err = intents.NewValue(kb)
+		}
+		require.NoError(t, err)
+		vb.PutInt32("Count", count)
+	}
+
+	runTime := time.Date(2025, time.March, 1, 13, 0, 0, 0, time.UTC)
+
+	t.Run("should check view insert intent", func(t *testing.T) {
+		var ts *JobTestState
+		ts = NewJobTestState(t, func() { tick(ts, ts) })
+		ts.
+			JobTime(runTime).
+			IntentViewInsert(testViewCounters, `Year`, 2025, `Month`, 3, `Count`, 1).
+			Run()
+	})
+
+	t.Run("should check view update intent", func(t *testing.T) {
+		var ts *JobTestState
+		ts = NewJobTestState(t, func() { tick(ts, ts) })
+		ts.
+			JobTime(runTime).
+			StateView(testViewCounters, 0, `Year`, 2025, `Month`, 3, `Count`, 41).
+			IntentViewUpdate(testViewCounters, 0, `Year`, 2025, `Month`, 3, `Count`, 42).
+			Run()
+	})
+}
@@ -67,6 +67,36 @@ type IProjectorRunner interface {
 	Run()
 }
 
+type IQueryRunner interface {
+	// methods to fulfill test state
+	StateRecord(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IQueryRunner
+	StateSingletonRecord(fQName IFullQName, keyValueList ...any) IQueryRunner
+	StateView(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IQueryRunner
+	ArgumentObject(id istructs.RecordID, keyValueList ...any) IQueryRunner
+	ArgumentObjectRow(path string, id istructs.RecordID, keyValueList ...any) IQueryRunner
+	// ResultRow adds the expected result row. Rows are checked out in the order they are emitted by the query
+	ResultRow(keyValueList ...any) IQueryRunner
+	// method to run the test
+	Run()
+}
+
+// IJobRunner runs a job test.
+//
+// Only View intents can be checked out, the job fails the test if it emits intents to other storages, e.g. SendMail or Logger.
+type IJobRunner interface {
+	// methods to fulfill test state
+	StateRecord(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IJobRunner
+	StateSingletonRecord(fQName IFullQName, keyValueList ...any) IJobRunner
+	StateView(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IJobRunner
+	// JobTime sets the time returned by JobContext storage
+	JobTime(jobTime time.Time) IJobRunner
+	// methods to check out the test state
+	IntentViewInsert(fQName IFullQName, keyValueList ...any) IJobRunner
+	IntentViewUpdate(fQName IFullQName, id istructs.RecordID, keyValueList ...any) IJobRunner
+	// method to run the test
+	Run()
+}
+
 type ICommand interface {
 	IFullQName
 	ArgumentPkgPath() string
@@ -78,3 +108,10 @@ type IProjector interface {
 	IFullQName
 	WorkspaceDescriptor() string
 }
+
+type IQuery interface {
+	IFullQName
+	ArgumentPkgPath() string
+	ArgumentEntity() string
+	WorkspaceDescriptor() string
+}
@@ -0,0 +1,6 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+// Package app is the application used to test the test state
+package app
@@ -10,6 +10,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/iblobstorage"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/itokens"
@@ -49,6 +50,12 @@ type FederationCommandHandler = func(owner, appname string, wsid istructs.WSID,
 type FederationBlobHandler = func(owner, appname string, wsid istructs.WSID, ownerRecord appdef.QName, ownerRecordField appdef.FieldName, ownerID istructs.RecordID) (result []byte, err error)
 type UniquesHandler = func(entity appdef.QName, wsid istructs.WSID, data map[string]interface{}) (istructs.RecordID, error)
 
+// reads the whole persistent BLOB, e.g. to attach it to an e-mail
+type BLOBReader = func(ctx context.Context, key iblobstorage.PersistentBLOBKeyType) (descr iblobstorage.DescrType, data []byte, err error)
+
+// called when the request of the Http storage is denied by the egress policy of the application
+type EgressDeniedHandler = func(app appdef.AppQName)
+
 type EventsFunc func() istructs.IEvents
 type RecordsFunc func() istructs.IRecords
 
@@ -62,13 +69,25 @@ type EmailMessage struct {
 	To      []string
 	CC      []string
 	BCC     []string
-	Body    string
+	Body    string // text/html
+
+	// optional, the message is sent as multipart/alternative if specified
+	TextBody    string
+	Attachments []EmailAttachment
+}
+
+type EmailAttachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
 }
 
 type StateOpts struct {
 	FederationCommandHandler FederationCommandHandler
 	FederationBlobHandler    FederationBlobHandler
 	UniquesHandler           UniquesHandler
+	BLOBReader               BLOBReader
+	EgressDeniedHandler      EgressDeniedHandler
 }
 
 type ApplyBatchItem struct {
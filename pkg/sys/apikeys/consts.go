@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apikeys
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+)
+
+var (
+	qNameCmdCreateAPIKey    = appdef.NewQName(appdef.SysPackage, "CreateAPIKey")
+	qNameCmdRevokeAPIKey    = appdef.NewQName(appdef.SysPackage, "RevokeAPIKey")
+	qNameQryAPIKeyUsage     = appdef.NewQName(appdef.SysPackage, "APIKeyUsage")
+	qNameCreateAPIKeyResult = appdef.NewQName(appdef.SysPackage, "CreateAPIKeyResult")
+	QNameCDocAPIKey         = appdef.NewQName(appdef.SysPackage, "APIKey")
+)
+
+const (
+	field_Name         = "Name"
+	field_KeyHash      = "KeyHash"
+	field_Roles        = "Roles"
+	field_Functions    = "Functions"
+	field_AllowedIPs   = "AllowedIPs"
+	field_Created      = "Created"
+	field_APIKey       = "APIKey"
+	field_APIKeyID     = "APIKeyID"
+	field_LastUsedAt   = "LastUsedAt"
+	field_LastUsedFrom = "LastUsedFrom"
+)
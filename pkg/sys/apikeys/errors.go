@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apikeys
+
+import (
+	"errors"
+)
+
+var (
+	ErrAPIKeyNotExists  = errors.New("API key not exists")
+	errAPIKeyRevoked    = errors.New("API key revoked")
+	errAPIKeyNameEmpty  = errors.New("API key name must not be empty")
+	ErrRolesEmpty       = errors.New("roles must not be empty")
+	ErrRoleInvalid      = errors.New("invalid role")
+	ErrRoleDuplicate    = errors.New("duplicate role")
+	ErrSystemRole       = errors.New("system roles cannot be granted to API key")
+	ErrRoleNotFound     = errors.New("role not found in workspace")
+	ErrFunctionInvalid  = errors.New("invalid function")
+	ErrFunctionNotFound = errors.New("command or query not found")
+	ErrAllowedIPInvalid = errors.New("invalid allowed IP or CIDR")
+)
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apikeys
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func provideQryAPIKeyUsage(sr istructsmem.IStatelessResources) {
+	sr.AddQueries(appdef.SysPackagePath, istructsmem.NewQueryFunction(
+		qNameQryAPIKeyUsage,
+		qryAPIKeyUsageExec,
+	))
+}
+
+// the usage is recorded by the authenticator, not more often than once per minute
+func qryAPIKeyUsageExec(_ context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) error {
+	apiKeyID := args.ArgumentObject.AsRecordID(field_APIKeyID)
+	skbCDocAPIKey, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocAPIKey)
+	if err != nil {
+		return err
+	}
+	skbCDocAPIKey.PutRecordID(sys.Storage_Record_Field_ID, apiKeyID)
+	svCDocAPIKey, ok, err := args.State.CanExist(skbCDocAPIKey)
+	if err != nil {
+		return err
+	}
+	if !ok || svCDocAPIKey.AsQName(appdef.SystemField_QName) != QNameCDocAPIKey {
+		return coreutils.NewHTTPError(http.StatusBadRequest, ErrAPIKeyNotExists)
+	}
+
+	res := &apiKeyUsageRR{}
+	usage, ok, err := args.State.AppStructs().AppTTLStorage().TTLGet(iauthnzimpl.APIKeyUsageKey(args.WSID, apiKeyID))
+	if err != nil {
+		return err
+	}
+	if ok {
+		if usedAt, host, ok := iauthnzimpl.ParseAPIKeyUsage(usage); ok {
+			res.lastUsedAt = usedAt.UnixMilli()
+			res.lastUsedFrom = host
+		}
+	}
+	return callback(res)
+}
+
+// q.sys.APIKeyUsage
+type apiKeyUsageRR struct {
+	istructs.NullObject
+	lastUsedAt   int64
+	lastUsedFrom string
+}
+
+func (r *apiKeyUsageRR) AsInt64(string) int64   { return r.lastUsedAt }
+func (r *apiKeyUsageRR) AsString(string) string { return r.lastUsedFrom }
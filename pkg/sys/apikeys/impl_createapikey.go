@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apikeys
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func provideCmdCreateAPIKey(sr istructsmem.IStatelessResources, time timeu.ITime, itokens itokens.ITokens) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(
+		qNameCmdCreateAPIKey,
+		execCmdCreateAPIKey(time, itokens),
+	))
+}
+
+// called in the workspace the key is created for
+// the key is returned once, only its hash is stored
+func execCmdCreateAPIKey(tm timeu.ITime, itokens itokens.ITokens) func(args istructs.ExecCommandArgs) (err error) {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		name := strings.TrimSpace(args.ArgumentObject.AsString(field_Name))
+		if len(name) == 0 {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errAPIKeyNameEmpty)
+		}
+		roles, err := normalizeRoles(args.ArgumentObject.AsString(field_Roles), args.Workspace)
+		if err != nil {
+			return err
+		}
+		functions, err := normalizeFunctions(args.ArgumentObject.AsString(field_Functions), args.Workspace)
+		if err != nil {
+			return err
+		}
+		allowedIPs, err := normalizeAllowedIPs(args.ArgumentObject.AsString(field_AllowedIPs))
+		if err != nil {
+			return err
+		}
+
+		skbCDocAPIKey, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocAPIKey)
+		if err != nil {
+			return err
+		}
+		svbCDocAPIKey, err := args.Intents.NewValue(skbCDocAPIKey)
+		if err != nil {
+			return err
+		}
+		apiKey := iauthnzimpl.APIKeyPrefix + coreutils.APIKeySecret()
+		svbCDocAPIKey.PutRecordID(appdef.SystemField_ID, istructs.RecordID(1))
+		svbCDocAPIKey.PutString(field_Name, name)
+		svbCDocAPIKey.PutString(field_KeyHash, apiKeyHash(itokens, apiKey))
+		svbCDocAPIKey.PutString(field_Roles, roles)
+		svbCDocAPIKey.PutString(field_Functions, functions)
+		svbCDocAPIKey.PutString(field_AllowedIPs, allowedIPs)
+		svbCDocAPIKey.PutInt64(field_Created, tm.Now().UnixMilli())
+
+		skbResult, err := args.State.KeyBuilder(sys.Storage_Result, qNameCreateAPIKeyResult)
+		if err != nil {
+			return err
+		}
+		svbResult, err := args.Intents.NewValue(skbResult)
+		if err != nil {
+			return err
+		}
+		svbResult.PutString(field_APIKey, apiKey)
+		return nil
+	}
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apikeys
+
+import (
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func provideCmdRevokeAPIKey(sr istructsmem.IStatelessResources) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(
+		qNameCmdRevokeAPIKey,
+		execCmdRevokeAPIKey,
+	))
+}
+
+// the key is checked on each request so it is not accepted anymore right after the command
+func execCmdRevokeAPIKey(args istructs.ExecCommandArgs) (err error) {
+	skbCDocAPIKey, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocAPIKey)
+	if err != nil {
+		return err
+	}
+	skbCDocAPIKey.PutRecordID(sys.Storage_Record_Field_ID, args.ArgumentObject.AsRecordID(field_APIKeyID))
+	svCDocAPIKey, ok, err := args.State.CanExist(skbCDocAPIKey)
+	if err != nil {
+		return err
+	}
+	if !ok || svCDocAPIKey.AsQName(appdef.SystemField_QName) != QNameCDocAPIKey {
+		return coreutils.NewHTTPError(http.StatusBadRequest, ErrAPIKeyNotExists)
+	}
+	if !svCDocAPIKey.AsBool(appdef.SystemField_IsActive) {
+		return coreutils.NewHTTPError(http.StatusBadRequest, errAPIKeyRevoked)
+	}
+	svbCDocAPIKey, err := args.Intents.UpdateValue(skbCDocAPIKey, svCDocAPIKey)
+	if err != nil {
+		return err
+	}
+	svbCDocAPIKey.PutBool(appdef.SystemField_IsActive, false)
+	return nil
+}
@@ -0,0 +1,17 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apikeys
+
+import (
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/itokens"
+)
+
+func Provide(sr istructsmem.IStatelessResources, time timeu.ITime, itokens itokens.ITokens) {
+	provideCmdCreateAPIKey(sr, time, itokens)
+	provideCmdRevokeAPIKey(sr)
+	provideQryAPIKeyUsage(sr)
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apikeys
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/itokens"
+	"github.com/voedger/voedger/pkg/sys/uniques"
+)
+
+// returns the getter that is used by the authenticator to find the active API key of the workspace
+func ProvideAPIKeyGetter(itokens itokens.ITokens) iauthnzimpl.APIKeyGetterFunc {
+	return func(_ context.Context, as istructs.IAppStructs, wsid istructs.WSID, apiKey string) (key iauthnzimpl.APIKey, ok bool, err error) {
+		if as.AppDef().Type(QNameCDocAPIKey).Kind() == appdef.TypeKind_null {
+			// notest: the application does not use sys package
+			return key, false, nil
+		}
+		apiKeyID, err := uniques.GetRecordIDByUniqueCombination(wsid, QNameCDocAPIKey, as, map[string]interface{}{
+			field_KeyHash: apiKeyHash(itokens, apiKey),
+		})
+		if err != nil || apiKeyID == istructs.NullRecordID {
+			return key, false, err
+		}
+		cdocAPIKey, err := as.Records().Get(wsid, true, apiKeyID)
+		if err != nil {
+			// notest
+			return key, false, err
+		}
+		if cdocAPIKey.QName() != QNameCDocAPIKey || !cdocAPIKey.AsBool(appdef.SystemField_IsActive) {
+			return key, false, nil
+		}
+
+		// the values are validated on the key creation
+		key.ID = apiKeyID
+		if key.Roles, err = parseQNames(cdocAPIKey.AsString(field_Roles)); err != nil {
+			// notest
+			return key, false, err
+		}
+		if key.Functions, err = parseQNames(cdocAPIKey.AsString(field_Functions)); err != nil {
+			// notest
+			return key, false, err
+		}
+		if key.AllowedIPs, err = parseAllowedIPs(cdocAPIKey.AsString(field_AllowedIPs)); err != nil {
+			// notest
+			return key, false, err
+		}
+		return key, true, nil
+	}
+}
+
+func apiKeyHash(itokens itokens.ITokens, apiKey string) string {
+	hash := itokens.CryptoHash256([]byte(apiKey))
+	return hex.EncodeToString(hash[:])
+}
+
+// validates the comma-separated roles to be granted by the key and returns them trimmed
+func normalizeRoles(rolesStr string, ws appdef.IWorkspace) (string, error) {
+	trimmed := strings.TrimSpace(rolesStr)
+	if len(trimmed) == 0 {
+		return "", coreutils.NewHTTPError(http.StatusBadRequest, ErrRolesEmpty)
+	}
+	roles := []string{}
+	seen := make(map[appdef.QName]struct{})
+	for role := range strings.SplitSeq(trimmed, ",") {
+		role = strings.TrimSpace(role)
+		if len(role) == 0 {
+			return "", coreutils.NewHTTPError(http.StatusBadRequest, ErrRolesEmpty)
+		}
+		qName, err := appdef.ParseQName(role)
+		if err != nil {
+			return "", coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: %s: %w", ErrRoleInvalid, role, err))
+		}
+		if _, ok := seen[qName]; ok {
+			return "", coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: %s", ErrRoleDuplicate, role))
+		}
+		seen[qName] = struct{}{}
+		if iauthnz.IsSystemRole(qName) {
+			return "", coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: %s", ErrSystemRole, role))
+		}
+		if appdef.Role(ws.Type, qName) == nil {
+			return "", coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: %s", ErrRoleNotFound, role))
+		}
+		roles = append(roles, role)
+	}
+	return strings.Join(roles, ","), nil
+}
+
+// validates the comma-separated commands and queries the key is allowed for and returns them trimmed
+// empty -> any function allowed by the roles
+func normalizeFunctions(functionsStr string, ws appdef.IWorkspace) (string, error) {
+	if len(strings.TrimSpace(functionsStr)) == 0 {
+		return "", nil
+	}
+	functions := []string{}
+	for function := range strings.SplitSeq(functionsStr, ",") {
+		function = strings.TrimSpace(function)
+		qName, err := appdef.ParseQName(function)
+		if err != nil {
+			return "", coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: %s: %w", ErrFunctionInvalid, function, err))
+		}
+		switch ws.Type(qName).Kind() {
+		case appdef.TypeKind_Command, appdef.TypeKind_Query:
+		default:
+			return "", coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: %s", ErrFunctionNotFound, function))
+		}
+		functions = append(functions, function)
+	}
+	return strings.Join(functions, ","), nil
+}
+
+// validates the comma-separated IPs and CIDRs the key is allowed from and returns them trimmed
+// empty -> any IP
+func normalizeAllowedIPs(allowedIPsStr string) (string, error) {
+	if len(strings.TrimSpace(allowedIPsStr)) == 0 {
+		return "", nil
+	}
+	allowedIPs := []string{}
+	for allowedIP := range strings.SplitSeq(allowedIPsStr, ",") {
+		allowedIP = strings.TrimSpace(allowedIP)
+		if _, err := parseAllowedIP(allowedIP); err != nil {
+			return "", coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: %s", ErrAllowedIPInvalid, allowedIP))
+		}
+		allowedIPs = append(allowedIPs, allowedIP)
+	}
+	return strings.Join(allowedIPs, ","), nil
+}
+
+func parseQNames(qNamesStr string) (res []appdef.QName, err error) {
+	if len(qNamesStr) == 0 {
+		return nil, nil
+	}
+	for qNameStr := range strings.SplitSeq(qNamesStr, ",") {
+		qName, err := appdef.ParseQName(qNameStr)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, qName)
+	}
+	return res, nil
+}
+
+func parseAllowedIPs(allowedIPsStr string) (res []netip.Prefix, err error) {
+	if len(allowedIPsStr) == 0 {
+		return nil, nil
+	}
+	for allowedIP := range strings.SplitSeq(allowedIPsStr, ",") {
+		prefix, err := parseAllowedIP(allowedIP)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, prefix)
+	}
+	return res, nil
+}
+
+// single IP is considered as the prefix of the full length
+func parseAllowedIP(allowedIP string) (netip.Prefix, error) {
+	if strings.Contains(allowedIP, "/") {
+		prefix, err := netip.ParsePrefix(allowedIP)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(allowedIP)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
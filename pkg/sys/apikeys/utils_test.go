@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package apikeys
+
+import (
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/iauthnz"
+)
+
+var testWSName = appdef.NewQName("test", "ws")
+
+func buildTestWS(t *testing.T) appdef.IWorkspace {
+	t.Helper()
+	adb := builder.New()
+	adb.AddPackage("test", "test.com/test")
+
+	wsb := adb.AddWorkspace(testWSName)
+	wsb.AddRole(appdef.NewQName("test", "Integration"))
+	wsb.AddCDoc(appdef.NewQName("test", "Doc"))
+	wsb.AddCommand(appdef.NewQName("test", "Cmd"))
+	wsb.AddQuery(appdef.NewQName("test", "Qry"))
+
+	app, err := adb.Build()
+	require.NoError(t, err)
+	return app.Workspace(testWSName)
+}
+
+func requireBadRequest(t *testing.T, err error, expectedErr error) {
+	t.Helper()
+	require := require.New(t)
+	var sysErr coreutils.SysError
+	require.ErrorAs(err, &sysErr)
+	require.Equal(http.StatusBadRequest, sysErr.HTTPStatus)
+	require.Contains(sysErr.Message, expectedErr.Error())
+}
+
+func TestNormalizeRoles(t *testing.T) {
+	ws := buildTestWS(t)
+
+	t.Run("basic usage", func(t *testing.T) {
+		roles, err := normalizeRoles(" test.Integration ", ws)
+		require.NoError(t, err)
+		require.Equal(t, "test.Integration", roles)
+	})
+
+	cases := []struct {
+		roles    string
+		expected error
+	}{
+		{"", ErrRolesEmpty},
+		{"test.Integration,", ErrRolesEmpty},
+		{"wrong", ErrRoleInvalid},
+		{"test.Integration,test.Integration", ErrRoleDuplicate},
+		{iauthnz.QNameRoleWorkspaceOwner.String(), ErrSystemRole},
+		{"test.Unknown", ErrRoleNotFound},
+		{"test.Doc", ErrRoleNotFound},
+	}
+	for _, c := range cases {
+		t.Run(c.roles, func(t *testing.T) {
+			_, err := normalizeRoles(c.roles, ws)
+			requireBadRequest(t, err, c.expected)
+		})
+	}
+}
+
+func TestNormalizeFunctions(t *testing.T) {
+	ws := buildTestWS(t)
+
+	t.Run("basic usage", func(t *testing.T) {
+		functions, err := normalizeFunctions("test.Cmd, test.Qry", ws)
+		require.NoError(t, err)
+		require.Equal(t, "test.Cmd,test.Qry", functions)
+
+		functions, err = normalizeFunctions(" ", ws)
+		require.NoError(t, err)
+		require.Empty(t, functions)
+	})
+
+	cases := []struct {
+		functions string
+		expected  error
+	}{
+		{"wrong", ErrFunctionInvalid},
+		{"test.Cmd,", ErrFunctionInvalid},
+		{"test.Unknown", ErrFunctionNotFound},
+		{"test.Doc", ErrFunctionNotFound},
+	}
+	for _, c := range cases {
+		t.Run(c.functions, func(t *testing.T) {
+			_, err := normalizeFunctions(c.functions, ws)
+			requireBadRequest(t, err, c.expected)
+		})
+	}
+}
+
+func TestAllowedIPs(t *testing.T) {
+	t.Run("basic usage", func(t *testing.T) {
+		require := require.New(t)
+		allowedIPs, err := normalizeAllowedIPs(" 10.0.0.1, 192.168.1.0/24 ,::ffff:172.16.0.1,2001:db8::/32")
+		require.NoError(err)
+		require.Equal("10.0.0.1,192.168.1.0/24,::ffff:172.16.0.1,2001:db8::/32", allowedIPs)
+
+		prefixes, err := parseAllowedIPs(allowedIPs)
+		require.NoError(err)
+		require.Equal([]netip.Prefix{
+			netip.MustParsePrefix("10.0.0.1/32"),
+			netip.MustParsePrefix("192.168.1.0/24"),
+			netip.MustParsePrefix("172.16.0.1/32"),
+			netip.MustParsePrefix("2001:db8::/32"),
+		}, prefixes)
+
+		allowedIPs, err = normalizeAllowedIPs("")
+		require.NoError(err)
+		require.Empty(allowedIPs)
+	})
+
+	for _, allowedIPs := range []string{"wrong", "10.0.0.1,", "10.0.0.1/33", "example.com"} {
+		t.Run(allowedIPs, func(t *testing.T) {
+			_, err := normalizeAllowedIPs(allowedIPs)
+			requireBadRequest(t, err, ErrAllowedIPInvalid)
+		})
+	}
+}
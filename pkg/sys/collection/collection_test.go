@@ -215,7 +215,7 @@ func deployTestApp(t *testing.T) (appParts appparts.IAppPartitions, appStructs i
 				StatelessResources: statelessResources,
 				WASMConfig:         iextengine.WASMFactoryConfig{},
 			}, "", imetrics.Provide()),
-		iratesce.TestBucketsFactory)
+		iratesce.TestBucketsFactory, appparts.NullCompatibilityChecker)
 	require.NoError(err)
 	appParts.DeployApp(test.appQName, nil, appDef, test.totalPartitions, test.appEngines, 1)
 	appParts.DeployAppPartitions(test.appQName, []istructs.PartitionID{test.partition})
@@ -490,7 +490,7 @@ func TestBasicUsage_QueryFunc_Collection(t *testing.T) {
 					}`)
 	serviceChannel := make(iprocbus.ServiceChannel)
 
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, iauthnzimpl.TestAPIKeyGetter, testingu.MockTime)
 	tokens := itokensjwt.TestTokensJWT()
 	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(test.appQName)
 	queryProcessor := queryprocessor.ProvideServiceFactory()(
@@ -502,7 +502,7 @@ func TestBasicUsage_QueryFunc_Collection(t *testing.T) {
 	sysToken, err := payloads.GetSystemPrincipalTokenApp(appTokens)
 	require.NoError(err)
 	sender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-		serviceChannel <- queryprocessor.NewQueryMessage(context.Background(), test.appQName, test.partition, test.workspace, responder, requestBody, qNameQueryCollection, "", sysToken)
+		serviceChannel <- queryprocessor.NewQueryMessage(context.Background(), test.appQName, test.partition, test.workspace, responder, requestBody, qNameQueryCollection, "", sysToken, "")
 	})
 
 	resultRows := getResultRows(sender, require)
@@ -607,7 +607,7 @@ func TestBasicUsage_QueryFunc_CDoc(t *testing.T) {
 
 	serviceChannel := make(iprocbus.ServiceChannel)
 
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, iauthnzimpl.TestAPIKeyGetter, testingu.MockTime)
 	tokens := itokensjwt.TestTokensJWT()
 	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(test.appQName)
 	queryProcessor := queryprocessor.ProvideServiceFactory()(serviceChannel, appParts, maxPrepareQueries, imetrics.Provide(),
@@ -617,7 +617,7 @@ func TestBasicUsage_QueryFunc_CDoc(t *testing.T) {
 	sysToken, err := payloads.GetSystemPrincipalTokenApp(appTokens)
 	require.NoError(err)
 	sender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-		serviceChannel <- queryprocessor.NewQueryMessage(context.Background(), test.appQName, test.partition, test.workspace, responder, []byte(requestBody), qNameQueryGetCDoc, "", sysToken)
+		serviceChannel <- queryprocessor.NewQueryMessage(context.Background(), test.appQName, test.partition, test.workspace, responder, []byte(requestBody), qNameQueryGetCDoc, "", sysToken, "")
 	})
 
 	resultRows := getResultRows(sender, require)
@@ -725,7 +725,7 @@ func TestBasicUsage_State(t *testing.T) {
 
 	serviceChannel := make(iprocbus.ServiceChannel)
 
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, iauthnzimpl.TestAPIKeyGetter, testingu.MockTime)
 	tokens := itokensjwt.TestTokensJWT()
 	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(test.appQName)
 	queryProcessor := queryprocessor.ProvideServiceFactory()(serviceChannel, appParts, maxPrepareQueries, imetrics.Provide(),
@@ -736,7 +736,7 @@ func TestBasicUsage_State(t *testing.T) {
 	require.NoError(err)
 	sender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
 		serviceChannel <- queryprocessor.NewQueryMessage(context.Background(), test.appQName, test.partition, test.workspace, responder, []byte(`{"args":{"After":0},"elements":[{"fields":["State"]}]}`),
-			qNameQueryState, "", sysToken)
+			qNameQueryState, "", sysToken, "")
 	})
 
 	resultRows := getResultRows(sender, require)
@@ -893,7 +893,7 @@ func TestState_withAfterArgument(t *testing.T) {
 
 	serviceChannel := make(iprocbus.ServiceChannel)
 
-	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs)
+	authn := iauthnzimpl.NewDefaultAuthenticator(iauthnzimpl.TestSubjectRolesGetter, iauthnzimpl.TestIsDeviceAllowedFuncs, iauthnzimpl.TestAPIKeyGetter, testingu.MockTime)
 	tokens := itokensjwt.TestTokensJWT()
 	appTokens := payloads.ProvideIAppTokensFactory(tokens).New(test.appQName)
 	queryProcessor := queryprocessor.ProvideServiceFactory()(serviceChannel, appParts, maxPrepareQueries, imetrics.Provide(),
@@ -904,7 +904,7 @@ func TestState_withAfterArgument(t *testing.T) {
 	require.NoError(err)
 	sender := bus.NewIRequestSender(testingu.MockTime, func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
 		serviceChannel <- queryprocessor.NewQueryMessage(context.Background(), test.appQName, test.partition, test.workspace, responder, []byte(`{"args":{"After":6},"elements":[{"fields":["State"]}]}`),
-			qNameQueryState, "", sysToken)
+			qNameQueryState, "", sysToken, "")
 	})
 
 	resultRows := getResultRows(sender, require)
@@ -37,6 +37,13 @@ var (
 	Storage_FederationBlob    = appdef.NewQName(PackageName, "FederationBlob")
 	Storage_Uniq              = appdef.NewQName(PackageName, "Uniq")
 	Storage_Logger            = appdef.NewQName(PackageName, "Logger")
+	Storage_Translation       = appdef.NewQName(PackageName, "Translation")
+
+	QNameViewOutboxEmail       = appdef.NewQName(PackageName, "OutboxEmail")
+	QNameViewOutboxRetry       = appdef.NewQName(PackageName, "OutboxRetry")
+	QNameCmdEnqueueOutboxEmail = appdef.NewQName(PackageName, "EnqueueOutboxEmail")
+	QNameProjectorOutboxEmail  = appdef.NewQName(PackageName, "ProjectorOutboxEmail")
+	QNameJobOutboxSender       = appdef.NewQName(PackageName, "OutboxSender")
 )
 
 const (
@@ -47,7 +54,8 @@ const (
 	Storage_Record_Field_Singleton   = "Singleton" // Deprecated: use Storage_Record_Field_IsSingleton instead
 	Storage_Record_Field_IsSingleton = "IsSingleton"
 
-	Storage_View_Field_WSID = "WSID"
+	Storage_View_Field_WSID    = "WSID"
+	Storage_View_Field_Reverse = "Reverse" // bool, read records in descending order of clustering columns
 
 	Storage_HTTP_Field_URL                           = "Url"
 	Storage_HTTP_Field_Method                        = "Method"
@@ -56,6 +64,7 @@ const (
 	Storage_HTTP_Field_HTTPClientTimeoutMilliseconds = "HTTPClientTimeoutMilliseconds"
 	Storage_HTTP_Field_StatusCode                    = "StatusCode"
 	Storage_HTTP_Field_HandleErrors                  = "HandleErrors"
+	Storage_HTTP_Field_NoRetries                     = "NoRetries"
 	Storage_HTTP_Field_Error                         = "Error"
 
 	Storage_WLog_Field_Offset         = "Offset"
@@ -98,18 +107,24 @@ const (
 
 	Storage_Uniq_Field_ID = "ID"
 
-	Storage_SendMail_Field_From         = "From"
-	Storage_SendMail_Field_To           = "To"
-	Storage_SendMail_Field_CC           = "CC"
-	Storage_SendMail_Field_BCC          = "BCC"
-	Storage_SendMail_Field_Subject      = "Subject"
-	Storage_SendMail_Field_Body         = "Body"
-	Storage_SendMail_Field_Username     = "Username"
-	Storage_SendMail_Field_Password     = "Password"
-	Storage_SendMail_Field_Host         = "Host"
-	Storage_SendMail_Field_Port         = "Port"
-	Storage_SendMail_Field_Success      = "Success"
-	Storage_SendMail_Field_ErrorMessage = "ErrorMessage"
+	Storage_SendMail_Field_From           = "From"
+	Storage_SendMail_Field_To             = "To"
+	Storage_SendMail_Field_CC             = "CC"
+	Storage_SendMail_Field_BCC            = "BCC"
+	Storage_SendMail_Field_Subject        = "Subject"
+	Storage_SendMail_Field_Body           = "Body"
+	Storage_SendMail_Field_Username       = "Username"
+	Storage_SendMail_Field_Password       = "Password"
+	Storage_SendMail_Field_Host           = "Host"
+	Storage_SendMail_Field_Port           = "Port"
+	Storage_SendMail_Field_Success        = "Success"
+	Storage_SendMail_Field_ErrorMessage   = "ErrorMessage"
+	Storage_SendMail_Field_Language       = "Language"
+	Storage_SendMail_Field_TextBody       = "TextBody"
+	Storage_SendMail_Field_Attachment     = "Attachment"
+	Storage_SendMail_Field_PasswordSecret = "PasswordSecret"
+	Storage_SendMail_Field_OutboxID       = "OutboxID"
+	Storage_SendMail_Field_OutboxDay      = "OutboxDay"
 
 	Storage_FederationCommand_Field_Command       = "Command"
 	Storage_FederationCommand_Field_Body          = "Body"
@@ -145,6 +160,25 @@ const (
 	Storage_Logger_Field_LogLevel = "LogLevel"
 	Storage_Logger_Field_Message  = "Message"
 
+	Storage_Translation_Field_Language    = "Language"
+	Storage_Translation_Field_Text        = "Text"
+	Storage_Translation_Field_Translation = "Translation"
+
+	// e-mail outbox, see sys.OutboxEmail view and c.sys.EnqueueOutboxEmail params
+	OutboxEmail_Field_Day        = "Day"
+	OutboxEmail_Field_ID         = "ID"
+	OutboxEmail_Field_Status     = "Status"
+	OutboxEmail_Field_Attempts   = "Attempts"
+	OutboxEmail_Field_RetryIn    = "RetryIn" // c.sys.EnqueueOutboxEmail param only
+	OutboxEmail_Field_LastError  = "LastError"
+	OutboxEmail_Field_Subject    = "Subject"
+	OutboxEmail_Field_Recipients = "Recipients"
+	OutboxEmail_Field_Message    = "Message"
+
+	OutboxEmail_Status_Pending = int32(1)
+	OutboxEmail_Status_Sent    = int32(2)
+	OutboxEmail_Status_Failed  = int32(3)
+
 	// Common Field names
 	CUDs_Field_IsNew = "IsNew"
 )
@@ -22,7 +22,12 @@ var (
 	qNameCmdDeactivateJoinedWorkspace    = appdef.NewQName(appdef.SysPackage, "DeactivateJoinedWorkspace")
 	qNameCmdInitiateLeaveWorkspace       = appdef.NewQName(appdef.SysPackage, "InitiateLeaveWorkspace")
 	qNameCmdCancelSentInvite             = appdef.NewQName(appdef.SysPackage, "CancelSentInvite")
+	qNameCmdCreateInviteLink             = appdef.NewQName(appdef.SysPackage, "CreateInviteLink")
+	qNameCmdRevokeInviteLink             = appdef.NewQName(appdef.SysPackage, "RevokeInviteLink")
+	qNameCmdJoinWorkspaceByLink          = appdef.NewQName(appdef.SysPackage, "JoinWorkspaceByLink")
+	qNameCreateInviteLinkResult          = appdef.NewQName(appdef.SysPackage, "CreateInviteLinkResult")
 	QNameCDocInvite                      = appdef.NewQName(appdef.SysPackage, "Invite")
+	QNameCDocInviteLink                  = appdef.NewQName(appdef.SysPackage, "InviteLink")
 	qNameViewInviteIndex                 = appdef.NewQName(appdef.SysPackage, "InviteIndexView")
 	qNameProjectorInviteIndex            = appdef.NewQName(appdef.SysPackage, "ProjectorInviteIndex")
 	QNameViewJoinedWorkspaceIndex        = appdef.NewQName(appdef.SysPackage, "JoinedWorkspaceIndexView")
@@ -47,6 +52,7 @@ const (
 	field_VerificationCode      = "VerificationCode"
 	field_EmailTemplate         = "EmailTemplate"
 	field_EmailSubject          = "EmailSubject"
+	field_Language              = "Language"
 	Field_Login                 = "Login"
 	Field_InvitingWorkspaceWSID = "InvitingWorkspaceWSID"
 	Field_InviteeProfileWSID    = "InviteeProfileWSID"
@@ -62,6 +68,11 @@ const (
 	Field_LoginHash             = "LoginHash"
 	field_ActualLogin           = "ActualLogin"
 	Field_Version               = "Version"
+	field_Code                  = "Code"
+	field_MaxUses               = "MaxUses"
+	field_UsesCount             = "UsesCount"
+	field_AllowedEmailDomains   = "AllowedEmailDomains"
+	field_InviteLinkID          = "InviteLinkID"
 )
 
 //go:generate stringer -type=State -output=stringer_state.go
@@ -136,6 +147,13 @@ var (
 			// legacy: cancel stuck record from old data
 			State_ToBeJoined: true,
 		},
+		// the existing invite of the login is reused on join by link
+		qNameCmdJoinWorkspaceByLink: {
+			State_Cancelled:   true,
+			State_Left:        true,
+			State_Invited:     true,
+			State_ToBeInvited: true,
+		},
 	}
 	reInviteAllowedForState = map[State]bool{
 		State_Cancelled:   true,
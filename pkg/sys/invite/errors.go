@@ -23,4 +23,14 @@ var (
 	ErrSystemRole    = errors.New("system roles cannot be assigned via invite")
 	ErrRoleNotFound  = errors.New("role not found in workspace")
 	ErrRoleDuplicate = errors.New("duplicate role")
+
+	ErrInviteLinkNotExists             = errors.New("invite link not exists")
+	errInviteLinkRevoked               = errors.New("invite link revoked")
+	errInviteLinkExpired               = errors.New("invite link expired")
+	errInviteLinkCodeInvalid           = errors.New("invite link code invalid")
+	errInviteLinkUsesExceeded          = errors.New("invite link max uses exceeded")
+	errInviteLinkEmailDomainNotAllowed = errors.New("email domain is not allowed by the invite link")
+	errInviteLinkMaxUsesInvalid        = errors.New("invite link max uses must be positive")
+	errInviteLinkExpireInvalid         = errors.New("invite link expire datetime must be in the future")
+	errAlreadyJoined                   = errors.New("already joined the workspace")
 )
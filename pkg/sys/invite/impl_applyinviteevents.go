@@ -19,7 +19,6 @@ import (
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
-	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/sys"
 	"github.com/voedger/voedger/pkg/sys/authnz"
 	"github.com/voedger/voedger/pkg/sys/smtp"
@@ -32,6 +31,7 @@ var projectorValidStates = map[appdef.QName]map[State]bool{
 	qNameCmdInitiateCancelAcceptedInvite: {State_Joined: true, State_ToBeCancelled: true, State_ToUpdateRoles: true},
 	qNameCmdInitiateLeaveWorkspace:       {State_Joined: true, State_ToBeLeft: true, State_ToUpdateRoles: true},
 	qNameCmdCancelSentInvite:             {State_ToBeInvited: true, State_Invited: true, State_ToBeJoined: true},
+	qNameCmdJoinWorkspaceByLink:          {State_ToBeJoined: true},
 }
 
 func asyncProjectorApplyInviteEvents(time timeu.ITime, fed federation.IFederation, tokens itokens.ITokens, smtpCfg smtp.Cfg) istructs.Projector {
@@ -68,7 +68,7 @@ func applyInviteEvents(time timeu.ITime, fed federation.IFederation, tokens itok
 		switch cmd {
 		case qNameCmdInitiateInvitationByEMail:
 			return handleApplyInvitation(event, s, intents, inviteID, time, fed, tokens, smtpCfg)
-		case qNameCmdInitiateJoinWorkspace:
+		case qNameCmdInitiateJoinWorkspace, qNameCmdJoinWorkspaceByLink:
 			return handleApplyJoinWorkspace(event, s, svCDocInvite, inviteID, time, fed, tokens)
 		case qNameCmdInitiateUpdateInviteRoles:
 			return handleApplyUpdateInviteRoles(event, s, intents, svCDocInvite, inviteID, time, fed, tokens, smtpCfg)
@@ -109,7 +109,7 @@ func inviteIDFromEvent(cmd appdef.QName, event istructs.IPLogEvent, s istructs.I
 			return istructs.NullRecordID, err
 		}
 		return sv.AsRecordID(field_InviteID), nil
-	case qNameCmdInitiateLeaveWorkspace:
+	case qNameCmdInitiateLeaveWorkspace, qNameCmdJoinWorkspaceByLink:
 		return inviteCUD.ID(), nil
 	default:
 		return event.ArgumentObject().AsRecordID(field_InviteID), nil
@@ -164,7 +164,10 @@ func deactivateSubjectAndJoinedWorkspace(fed federation.IFederation, appQName ap
 
 func handleApplyInvitation(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents, inviteID istructs.RecordID, time timeu.ITime, fed federation.IFederation, tokens itokens.ITokens, smtpCfg smtp.Cfg) error {
 	verificationCode := coreutils.EmailVerificationCode()
-	emailTemplate := coreutils.TruncateEmailTemplate(event.ArgumentObject().AsString(field_EmailTemplate))
+	emailTemplate, emailSubject, err := translateEmail(s, event.ArgumentObject())
+	if err != nil {
+		return err
+	}
 
 	skbCDocWorkspaceDescriptor, err := s.KeyBuilder(sys.Storage_Record, appdef.QNameCDocWorkspaceDescriptor)
 	if err != nil {
@@ -185,7 +188,7 @@ func handleApplyInvitation(event istructs.IPLogEvent, s istructs.IState, intents
 	)
 
 	if err = sendEmail(s, intents, smtpCfg,
-		event.ArgumentObject().AsString(field_EmailSubject),
+		emailSubject,
 		event.ArgumentObject().AsString(Field_Email),
 		replacer.Replace(emailTemplate)); err != nil {
 		return err
@@ -199,6 +202,42 @@ func handleApplyInvitation(event istructs.IPLogEvent, s istructs.IState, intents
 		jsonu.Jprintf(`"State":%d,"VerificationCode":%q,"Updated":%d`, State_Invited, verificationCode, time.Now().UnixMilli()))
 }
 
+// returns the email template and subject translated to the Language argument by the application translations
+//
+// the template is translated before placeholders are replaced so that the translation key is the template itself
+func translateEmail(s istructs.IState, args istructs.IObject) (emailTemplate, emailSubject string, err error) {
+	emailTemplate = coreutils.TruncateEmailTemplate(args.AsString(field_EmailTemplate))
+	emailSubject = args.AsString(field_EmailSubject)
+	lng := args.AsString(field_Language)
+	if len(lng) == 0 {
+		return emailTemplate, emailSubject, nil
+	}
+	if emailTemplate, err = translate(s, lng, emailTemplate); err != nil {
+		return "", "", err
+	}
+	if emailSubject, err = translate(s, lng, emailSubject); err != nil {
+		return "", "", err
+	}
+	return emailTemplate, emailSubject, nil
+}
+
+func translate(s istructs.IState, lng, text string) (string, error) {
+	if len(text) == 0 {
+		return text, nil
+	}
+	kb, err := s.KeyBuilder(sys.Storage_Translation, appdef.NullQName)
+	if err != nil {
+		return "", err
+	}
+	kb.PutString(sys.Storage_Translation_Field_Language, lng)
+	kb.PutString(sys.Storage_Translation_Field_Text, text)
+	sv, err := s.MustExist(kb)
+	if err != nil {
+		return "", err
+	}
+	return sv.AsString(sys.Storage_Translation_Field_Translation), nil
+}
+
 func sendEmail(s istructs.IState, intents istructs.IIntents, smtpCfg smtp.Cfg, subject, to, body string) error {
 	skbSendMail, err := s.KeyBuilder(sys.Storage_SendMail, appdef.NullQName)
 	if err != nil {
@@ -211,12 +250,8 @@ func sendEmail(s istructs.IState, intents istructs.IIntents, smtpCfg smtp.Cfg, s
 	skbSendMail.PutString(sys.Storage_SendMail_Field_Host, smtpCfg.Host)
 	skbSendMail.PutInt32(sys.Storage_SendMail_Field_Port, smtpCfg.Port)
 	skbSendMail.PutString(sys.Storage_SendMail_Field_Username, smtpCfg.Username)
-
-	pwd, err := state.ReadSecret(s, smtpCfg.PwdSecret)
-	if err != nil {
-		return err
-	}
-	skbSendMail.PutString(sys.Storage_SendMail_Field_Password, pwd)
+	// the password is read by the storage so the failed message could be retried
+	skbSendMail.PutString(sys.Storage_SendMail_Field_PasswordSecret, smtpCfg.PwdSecret)
 
 	_, err = intents.NewValue(skbSendMail)
 	return err
@@ -343,11 +378,14 @@ func handleApplyUpdateInviteRoles(event istructs.IPLogEvent, s istructs.IState,
 		return err
 	}
 
-	emailTemplate := coreutils.TruncateEmailTemplate(event.ArgumentObject().AsString(field_EmailTemplate))
+	emailTemplate, emailSubject, err := translateEmail(s, event.ArgumentObject())
+	if err != nil {
+		return err
+	}
 	replacer := strings.NewReplacer(EmailTemplatePlaceholder_Roles, event.ArgumentObject().AsString(Field_Roles))
 
 	if err = sendEmail(s, intents, smtpCfg,
-		event.ArgumentObject().AsString(field_EmailSubject),
+		emailSubject,
 		svCDocInvite.AsString(Field_Email),
 		replacer.Replace(emailTemplate)); err != nil {
 		return err
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package invite
+
+import (
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func provideCmdCreateInviteLink(sr istructsmem.IStatelessResources, time timeu.ITime) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(
+		qNameCmdCreateInviteLink,
+		execCmdCreateInviteLink(time),
+	))
+}
+
+// called in the workspace that we're inviting to
+// the link is {InviteLinkID, Code}, the client gets InviteLinkID from the new IDs and Code from the result
+func execCmdCreateInviteLink(tm timeu.ITime) func(args istructs.ExecCommandArgs) (err error) {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		if err := validateInviteRoles(args.ArgumentObject.AsString(Field_Roles), args.Workspace); err != nil {
+			return err
+		}
+		maxUses := args.ArgumentObject.AsInt32(field_MaxUses)
+		if maxUses <= 0 {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errInviteLinkMaxUsesInvalid)
+		}
+		now := tm.Now().UnixMilli()
+		expireDatetime := args.ArgumentObject.AsInt64(field_ExpireDatetime)
+		if expireDatetime <= now {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errInviteLinkExpireInvalid)
+		}
+		allowedEmailDomains, err := normalizeEmailDomains(args.ArgumentObject.AsString(field_AllowedEmailDomains))
+		if err != nil {
+			return err
+		}
+
+		skbCDocInviteLink, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocInviteLink)
+		if err != nil {
+			return err
+		}
+		svbCDocInviteLink, err := args.Intents.NewValue(skbCDocInviteLink)
+		if err != nil {
+			return err
+		}
+		code := coreutils.InviteLinkCode()
+		svbCDocInviteLink.PutRecordID(appdef.SystemField_ID, istructs.RecordID(1))
+		svbCDocInviteLink.PutString(field_Code, code)
+		svbCDocInviteLink.PutString(Field_Roles, args.ArgumentObject.AsString(Field_Roles))
+		svbCDocInviteLink.PutInt32(field_MaxUses, maxUses)
+		svbCDocInviteLink.PutInt32(field_UsesCount, 0)
+		svbCDocInviteLink.PutInt64(field_ExpireDatetime, expireDatetime)
+		svbCDocInviteLink.PutString(field_AllowedEmailDomains, allowedEmailDomains)
+		svbCDocInviteLink.PutInt64(field_Created, now)
+
+		skbResult, err := args.State.KeyBuilder(sys.Storage_Result, qNameCreateInviteLinkResult)
+		if err != nil {
+			return err
+		}
+		svbResult, err := args.Intents.NewValue(skbResult)
+		if err != nil {
+			return err
+		}
+		svbResult.PutString(field_Code, code)
+		return nil
+	}
+}
@@ -22,12 +22,23 @@ var inviteIndexProjector = func(event istructs.IPLogEvent, s istructs.IState, in
 			continue
 		}
 
+		var login string
+		if event.QName() == qNameCmdJoinWorkspaceByLink {
+			if !rec.IsNew() {
+				// the existing invite is indexed already
+				continue
+			}
+			login = rec.AsString(Field_Login)
+		} else {
+			login = event.ArgumentObject().AsString(Field_Email)
+		}
+
 		skbViewInviteIndex, err := s.KeyBuilder(sys.Storage_View, qNameViewInviteIndex)
 		if err != nil {
 			return err
 		}
 		skbViewInviteIndex.PutInt32(field_Dummy, value_Dummy_One)
-		skbViewInviteIndex.PutString(Field_Login, event.ArgumentObject().AsString(Field_Email))
+		skbViewInviteIndex.PutString(Field_Login, login)
 
 		svViewInviteIndex, err := intents.NewValue(skbViewInviteIndex)
 		if err != nil {
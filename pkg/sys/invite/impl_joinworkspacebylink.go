@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package invite
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+)
+
+func provideCmdJoinWorkspaceByLink(sr istructsmem.IStatelessResources, time timeu.ITime) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(
+		qNameCmdJoinWorkspaceByLink,
+		execCmdJoinWorkspaceByLink(time),
+	))
+}
+
+// called in the workspace that we're inviting to by any authenticated user who opened the link
+// cdoc.sys.Invite of the login is created or reused in State_ToBeJoined, then ap.sys.ApplyInviteEvents joins the login
+// the same way as for c.sys.InitiateJoinWorkspace
+func execCmdJoinWorkspaceByLink(tm timeu.ITime) func(args istructs.ExecCommandArgs) (err error) {
+	return func(args istructs.ExecCommandArgs) (err error) {
+		skbCDocInviteLink, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocInviteLink)
+		if err != nil {
+			return err
+		}
+		skbCDocInviteLink.PutRecordID(sys.Storage_Record_Field_ID, args.ArgumentObject.AsRecordID(field_InviteLinkID))
+		svCDocInviteLink, ok, err := args.State.CanExist(skbCDocInviteLink)
+		if err != nil {
+			return err
+		}
+		if !ok || svCDocInviteLink.AsQName(appdef.SystemField_QName) != QNameCDocInviteLink {
+			return coreutils.NewHTTPError(http.StatusBadRequest, ErrInviteLinkNotExists)
+		}
+		// check the code first to not to disclose the link state to ones who do not know the code
+		if subtle.ConstantTimeCompare([]byte(svCDocInviteLink.AsString(field_Code)), []byte(args.ArgumentObject.AsString(field_Code))) != 1 {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errInviteLinkCodeInvalid)
+		}
+		if !svCDocInviteLink.AsBool(appdef.SystemField_IsActive) {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errInviteLinkRevoked)
+		}
+		now := tm.Now().UnixMilli()
+		if svCDocInviteLink.AsInt64(field_ExpireDatetime) < now {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errInviteLinkExpired)
+		}
+
+		skbPrincipal, err := args.State.KeyBuilder(sys.Storage_RequestSubject, appdef.NullQName)
+		if err != nil {
+			return err
+		}
+		svPrincipal, err := args.State.MustExist(skbPrincipal)
+		if err != nil {
+			return err
+		}
+		login := svPrincipal.AsString(sys.Storage_RequestSubject_Field_Name)
+		// logins are not verified, so the domain of the email verified by the caller is checked
+		if !isEmailDomainAllowed(args.ArgumentObject.AsString(Field_Email), svCDocInviteLink.AsString(field_AllowedEmailDomains)) {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errInviteLinkEmailDomainNotAllowed)
+		}
+		_, subjectIsActive, err := SubjectExistsByLogin(login, args.State)
+		if err != nil {
+			return err
+		}
+		if subjectIsActive {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errAlreadyJoined)
+		}
+		usesCount := svCDocInviteLink.AsInt32(field_UsesCount)
+		if usesCount >= svCDocInviteLink.AsInt32(field_MaxUses) {
+			return coreutils.NewHTTPError(http.StatusBadRequest, errInviteLinkUsesExceeded)
+		}
+
+		svbCDocInvite, err := inviteForJoinByLink(args, login, now)
+		if err != nil {
+			return err
+		}
+		svbCDocInvite.PutString(Field_Roles, svCDocInviteLink.AsString(Field_Roles))
+		svbCDocInvite.PutInt64(field_ExpireDatetime, svCDocInviteLink.AsInt64(field_ExpireDatetime))
+		svbCDocInvite.PutInt32(Field_State, int32(State_ToBeJoined))
+		svbCDocInvite.PutInt64(Field_InviteeProfileWSID, svPrincipal.AsInt64(sys.Storage_RequestSubject_Field_ProfileWSID))
+		svbCDocInvite.PutInt32(authnz.Field_SubjectKind, svPrincipal.AsInt32(sys.Storage_RequestSubject_Field_Kind))
+		svbCDocInvite.PutString(field_ActualLogin, login)
+		svbCDocInvite.PutInt64(Field_Updated, now)
+		svbCDocInvite.PutInt32(Field_Version, 1)
+
+		svbCDocInviteLink, err := args.Intents.UpdateValue(skbCDocInviteLink, svCDocInviteLink)
+		if err != nil {
+			return err
+		}
+		svbCDocInviteLink.PutInt32(field_UsesCount, usesCount+1)
+		return nil
+	}
+}
+
+// returns the existing invite of the login for update or the new one
+func inviteForJoinByLink(args istructs.ExecCommandArgs, login string, now int64) (svbCDocInvite istructs.IStateValueBuilder, err error) {
+	skbViewInviteIndex, err := args.State.KeyBuilder(sys.Storage_View, qNameViewInviteIndex)
+	if err != nil {
+		return nil, err
+	}
+	skbViewInviteIndex.PutInt32(field_Dummy, value_Dummy_One)
+	skbViewInviteIndex.PutString(Field_Login, login)
+	svViewInviteIndex, ok, err := args.State.CanExist(skbViewInviteIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	skbCDocInvite, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocInvite)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		skbCDocInvite.PutRecordID(sys.Storage_Record_Field_ID, svViewInviteIndex.AsRecordID(field_InviteID))
+		svCDocInvite, err := args.State.MustExist(skbCDocInvite)
+		if err != nil {
+			return nil, err
+		}
+		if !isValidInviteState(svCDocInvite.AsInt32(Field_State), qNameCmdJoinWorkspaceByLink) {
+			return nil, coreutils.NewHTTPError(http.StatusBadRequest, ErrInviteStateInvalid)
+		}
+		return args.Intents.UpdateValue(skbCDocInvite, svCDocInvite)
+	}
+
+	if svbCDocInvite, err = args.Intents.NewValue(skbCDocInvite); err != nil {
+		return nil, err
+	}
+	svbCDocInvite.PutRecordID(appdef.SystemField_ID, istructs.RecordID(1))
+	svbCDocInvite.PutString(Field_Login, login)
+	svbCDocInvite.PutString(Field_Email, login)
+	svbCDocInvite.PutInt64(field_Created, now)
+	return svbCDocInvite, nil
+}
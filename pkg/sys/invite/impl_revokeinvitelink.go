@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package invite
+
+import (
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func provideCmdRevokeInviteLink(sr istructsmem.IStatelessResources) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(
+		qNameCmdRevokeInviteLink,
+		execCmdRevokeInviteLink,
+	))
+}
+
+// workspaces joined by the link already are not affected
+func execCmdRevokeInviteLink(args istructs.ExecCommandArgs) (err error) {
+	skbCDocInviteLink, err := args.State.KeyBuilder(sys.Storage_Record, QNameCDocInviteLink)
+	if err != nil {
+		return err
+	}
+	skbCDocInviteLink.PutRecordID(sys.Storage_Record_Field_ID, args.ArgumentObject.AsRecordID(field_InviteLinkID))
+	svCDocInviteLink, ok, err := args.State.CanExist(skbCDocInviteLink)
+	if err != nil {
+		return err
+	}
+	if !ok || svCDocInviteLink.AsQName(appdef.SystemField_QName) != QNameCDocInviteLink {
+		return coreutils.NewHTTPError(http.StatusBadRequest, ErrInviteLinkNotExists)
+	}
+	if !svCDocInviteLink.AsBool(appdef.SystemField_IsActive) {
+		return coreutils.NewHTTPError(http.StatusBadRequest, errInviteLinkRevoked)
+	}
+	svbCDocInviteLink, err := args.Intents.UpdateValue(skbCDocInviteLink, svCDocInviteLink)
+	if err != nil {
+		return err
+	}
+	svbCDocInviteLink.PutBool(appdef.SystemField_IsActive, false)
+	return nil
+}
@@ -21,6 +21,9 @@ func Provide(sr istructsmem.IStatelessResources, time timeu.ITime,
 	provideCmdInitiateCancelAcceptedInvite(sr, time)
 	provideCmdInitiateLeaveWorkspace(sr, time)
 	provideCmdCancelSentInvite(sr, time)
+	provideCmdCreateInviteLink(sr, time)
+	provideCmdRevokeInviteLink(sr)
+	provideCmdJoinWorkspaceByLink(sr, time)
 	provideCmdCreateJoinedWorkspace(sr)
 	provideCmdUpdateJoinedWorkspaceRoles(sr)
 	provideCmdDeactivateJoinedWorkspace(sr)
@@ -46,6 +46,40 @@ func validateInviteRoles(rolesStr string, ws appdef.IWorkspace) error {
 	return nil
 }
 
+// trims and lowercases the comma-separated email domains of the invite link
+func normalizeEmailDomains(domainsStr string) (string, error) {
+	if len(strings.TrimSpace(domainsStr)) == 0 {
+		return "", nil
+	}
+	domains := []string{}
+	for domain := range strings.SplitSeq(domainsStr, ",") {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if len(domain) == 0 || strings.ContainsAny(domain, "@ ") {
+			return "", coreutils.NewHTTPErrorf(http.StatusBadRequest, "invalid email domain: ", domain)
+		}
+		domains = append(domains, domain)
+	}
+	return strings.Join(domains, ","), nil
+}
+
+// allowedDomains is the normalized comma-separated domains, empty -> any email, even empty one, is allowed
+func isEmailDomainAllowed(email string, allowedDomains string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+	atIdx := strings.LastIndex(email, "@")
+	if atIdx < 0 {
+		return false
+	}
+	emailDomain := strings.ToLower(email[atIdx+1:])
+	for domain := range strings.SplitSeq(allowedDomains, ",") {
+		if domain == emailDomain {
+			return true
+		}
+	}
+	return false
+}
+
 func GetCDocJoinedWorkspaceForUpdateRequired(st istructs.IState, intents istructs.IIntents, invitingWorkspaceWSID int64) (svbCDocJoinedWorkspace istructs.IStateValueBuilder, err error) {
 	skbViewJoinedWorkspaceIndex, err := st.KeyBuilder(sys.Storage_View, QNameViewJoinedWorkspaceIndex)
 	if err != nil {
@@ -151,3 +151,34 @@ func TestValidateInviteRoles(t *testing.T) {
 		})
 	})
 }
+
+func TestInviteLinkEmailDomains(t *testing.T) {
+	t.Run("normalize", func(t *testing.T) {
+		require := require.New(t)
+		domains, err := normalizeEmailDomains(" Example.com ,sub.example.org")
+		require.NoError(err)
+		require.Equal("example.com,sub.example.org", domains)
+
+		domains, err = normalizeEmailDomains("  ")
+		require.NoError(err)
+		require.Empty(domains)
+
+		for _, wrong := range []string{"example.com,", "user@example.com", "exa mple.com"} {
+			_, err = normalizeEmailDomains(wrong)
+			var sysErr coreutils.SysError
+			require.ErrorAs(err, &sysErr, wrong)
+			require.Equal(http.StatusBadRequest, sysErr.HTTPStatus)
+		}
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		require := require.New(t)
+		require.True(isEmailDomainAllowed("any", ""))
+		require.True(isEmailDomainAllowed("user@Example.com", "example.com"))
+		require.True(isEmailDomainAllowed("user@sub.example.org", "example.com,sub.example.org"))
+		require.False(isEmailDomainAllowed("user@example.org", "example.com"))
+		require.False(isEmailDomainAllowed("user@sub.example.com", "example.com"))
+		require.False(isEmailDomainAllowed("device123", "example.com"))
+		require.False(isEmailDomainAllowed("", "example.com"))
+	})
+}
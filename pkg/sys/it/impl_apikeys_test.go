@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
+	"github.com/voedger/voedger/pkg/istructs"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestAPIKeys(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+
+	createAPIKey := func(functions, allowedIPs string) (apiKeyID istructs.RecordID, apiKey string) {
+		resp := vit.PostWS(ws, "c.sys.CreateAPIKey", fmt.Sprintf(`{"args":{"Name":"integration","Roles":"app1pkg.ApiRole","Functions":"%s","AllowedIPs":"%s"}}`,
+			functions, allowedIPs))
+		return resp.NewID(), resp.CmdResult["APIKey"].(string)
+	}
+
+	body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.category","name":"Awesome food"}}]}`
+	categoryID := vit.PostWS(ws, "c.sys.CUD", body).NewID()
+	qryBody := fmt.Sprintf(`{"args":{"CategoryID":%d},"elements":[{"fields":["CategoryID"]}]}`, categoryID)
+
+	t.Run("basic usage", func(t *testing.T) {
+		apiKeyID, apiKey := createAPIKey("", "")
+		require.True(strings.HasPrefix(apiKey, iauthnzimpl.APIKeyPrefix))
+
+		// the key is not stored
+		resp := vit.PostWS(ws, "q.sys.Collection", `{"args":{"Schema":"sys.APIKey"},"elements":[{"fields":["sys.ID","Name","KeyHash","Roles"]}]}`)
+		found := false
+		for i := range resp.NumRows() {
+			row := resp.SectionRow(i)
+			if istructs.RecordID(row[0].(float64)) != apiKeyID {
+				continue
+			}
+			found = true
+			require.Equal("integration", row[1])
+			require.Len(row[2], 64)
+			require.NotContains(row[2], apiKey)
+			require.Equal("app1pkg.ApiRole", row[3])
+		}
+		require.True(found)
+
+		// key is not used yet
+		usageBody := fmt.Sprintf(`{"args":{"APIKeyID":%d},"elements":[{"fields":["LastUsedAt","LastUsedFrom"]}]}`, apiKeyID)
+		resp = vit.PostWS(ws, "q.sys.APIKeyUsage", usageBody)
+		require.Equal(float64(0), resp.SectionRow()[0])
+
+		// use the key
+		resp = vit.PostWS(ws, "q.app1pkg.QryReturnsCategory", qryBody, httpu.WithAuthorizeBy(apiKey))
+		require.Equal(float64(categoryID), resp.SectionRow()[0])
+
+		// roles not granted by the key are not available
+		vit.PostWS(ws, "q.sys.Collection", `{"args":{"Schema":"sys.APIKey"}}`, httpu.WithAuthorizeBy(apiKey), it.Expect403())
+		vit.PostWS(ws, "c.sys.CreateAPIKey", `{"args":{"Name":"n","Roles":"app1pkg.ApiRole"}}`, httpu.WithAuthorizeBy(apiKey), it.Expect403())
+
+		// the usage is recorded
+		resp = vit.PostWS(ws, "q.sys.APIKeyUsage", usageBody)
+		require.Equal(float64(vit.Now().UnixMilli()), resp.SectionRow()[0])
+		require.Equal("127.0.0.1", resp.SectionRow()[1])
+
+		// the key is not accepted in an another workspace
+		anotherWS := vit.WS(istructs.AppQName_test1_app1, "test_ws2")
+		vit.PostWS(anotherWS, "q.app1pkg.QryReturnsCategory", qryBody, httpu.WithAuthorizeBy(apiKey), it.Expect401())
+
+		// revoke the key -> not accepted anymore
+		vit.PostWS(ws, "c.sys.RevokeAPIKey", fmt.Sprintf(`{"args":{"APIKeyID":%d}}`, apiKeyID))
+		vit.PostWS(ws, "q.app1pkg.QryReturnsCategory", qryBody, httpu.WithAuthorizeBy(apiKey), it.Expect401())
+
+		// revoke again -> 400
+		vit.PostWS(ws, "c.sys.RevokeAPIKey", fmt.Sprintf(`{"args":{"APIKeyID":%d}}`, apiKeyID), it.Expect400("API key revoked"))
+
+		t.Run("403 on direct CUDs", func(t *testing.T) {
+			// the key could be modified by the commands only, e.g. the revoked key could not be re-activated
+			vit.PostWS(ws, "c.sys.CUD", fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"sys.IsActive":true}}]}`, apiKeyID), it.Expect403())
+			vit.PostWS(ws, "c.sys.CUD", fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"AllowedIPs":""}}]}`, apiKeyID), it.Expect403())
+			vit.PostWS(ws, "c.sys.CUD", `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"sys.APIKey","Name":"n","KeyHash":"h","Roles":"sys.WorkspaceOwner","Created":1}}]}`, it.Expect403())
+			vit.PostWS(ws, "q.app1pkg.QryReturnsCategory", qryBody, httpu.WithAuthorizeBy(apiKey), it.Expect401())
+		})
+	})
+
+	t.Run("functions", func(t *testing.T) {
+		_, apiKey := createAPIKey("app1pkg.QryReturnsCategory", "")
+		vit.PostWS(ws, "q.app1pkg.QryReturnsCategory", qryBody, httpu.WithAuthorizeBy(apiKey))
+
+		// granted to the role but not allowed for the key
+		vit.PostWS(ws, "q.sys.SqlQuery", `{"args":{"Query":"select * from app1pkg.category"}}`, httpu.WithAuthorizeBy(apiKey), it.Expect401())
+	})
+
+	t.Run("allowed IPs", func(t *testing.T) {
+		_, apiKey := createAPIKey("", "10.0.0.0/8, 127.0.0.1")
+		vit.PostWS(ws, "q.app1pkg.QryReturnsCategory", qryBody, httpu.WithAuthorizeBy(apiKey))
+
+		_, apiKey = createAPIKey("", "10.0.0.0/8")
+		vit.PostWS(ws, "q.app1pkg.QryReturnsCategory", qryBody, httpu.WithAuthorizeBy(apiKey), it.Expect401())
+	})
+
+	t.Run("notifications", func(t *testing.T) {
+		_, apiKey := createAPIKey("", "")
+		subscribe := func(wsid istructs.WSID, view string, opts ...httpu.ReqOptFunc) *httpu.HTTPResponse {
+			body := fmt.Sprintf(`{"subscriptions":[{"entity":"%s","wsid":%d}]}`, view, wsid)
+			return vit.POST("api/v2/apps/test1/app1/notifications", body,
+				append([]httpu.ReqOptFunc{httpu.WithAuthorizeBy(apiKey), httpu.WithLongPolling()}, opts...)...)
+		}
+
+		resp := subscribe(ws.WSID, "app1pkg.Clients")
+		_, channelID, waitForDone := federation.ListenSSEEvents(resp.HTTPResp.Request.Context(), resp.HTTPResp.Body)
+		require.NotEmpty(channelID)
+
+		unsubscribeURL := fmt.Sprintf("api/v2/apps/test1/app1/notifications/%s/workspaces/%%d/subscriptions/app1pkg.Clients", channelID)
+		vit.POST(fmt.Sprintf(unsubscribeURL, ws.WSID), "", httpu.WithMethod(http.MethodDelete), httpu.WithAuthorizeBy(apiKey), httpu.Expect204())
+		resp.HTTPResp.Body.Close()
+		waitForDone()
+
+		// the view is not granted to the key roles
+		subscribe(ws.WSID, "app1pkg.CategoryIdx", httpu.Expect403())
+
+		// the key is not accepted in an another workspace
+		anotherWS := vit.WS(istructs.AppQName_test1_app1, "test_ws2")
+		subscribe(anotherWS.WSID, "app1pkg.Clients", httpu.Expect401())
+		vit.POST(fmt.Sprintf(unsubscribeURL, anotherWS.WSID), "", httpu.WithMethod(http.MethodDelete), httpu.WithAuthorizeBy(apiKey), httpu.Expect401())
+	})
+
+	t.Run("WebSocket", func(t *testing.T) {
+		_, apiKey := createAPIKey("", "")
+		conn := dialWS(t, vit, apiKey)
+		defer conn.Close()
+		msgs := receiveWSMessages(conn)
+		nextWSMessage(t, msgs, "channel")
+
+		sendWSMessage(t, conn, fmt.Sprintf(`{"id":"1","type":"subscribe","subscriptions":[{"entity":"app1pkg.Clients","wsid":%d}]}`, ws.WSID))
+		require.Equal(http.StatusOK, nextWSMessage(t, msgs, "response").Status)
+
+		sendWSMessage(t, conn, fmt.Sprintf(`{"id":"2","type":"request","method":"GET","path":"workspaces/%d/queries/app1pkg.QryReturnsCategory?args=%s"}`,
+			ws.WSID, url.QueryEscape(fmt.Sprintf(`{"CategoryID":%d}`, categoryID))))
+		require.Equal(http.StatusOK, nextWSMessage(t, msgs, "response").Status)
+
+		// the key is not accepted in an another workspace
+		anotherWS := vit.WS(istructs.AppQName_test1_app1, "test_ws2")
+		sendWSMessage(t, conn, fmt.Sprintf(`{"id":"3","type":"subscribe","subscriptions":[{"entity":"app1pkg.Clients","wsid":%d}]}`, anotherWS.WSID))
+		require.Equal(http.StatusUnauthorized, nextWSMessage(t, msgs, "response").Status)
+	})
+
+	t.Run("sql query to an another workspace or app", func(t *testing.T) {
+		_, apiKey := createAPIKey("", "")
+
+		// the key is forwarded to be checked in the target workspace
+		anotherWS := vit.WS(istructs.AppQName_test1_app1, "test_ws2")
+		vit.PostWS(ws, "q.sys.SqlQuery", fmt.Sprintf(`{"args":{"Query":"select * from %d.app1pkg.category"}}`, anotherWS.WSID),
+			httpu.WithAuthorizeBy(apiKey), it.Expect401())
+
+		// the key is forwarded to be checked by the target app
+		vit.PostWS(ws, "q.sys.SqlQuery", fmt.Sprintf(`{"args":{"Query":"select * from test1.app2.%d.app2pkg.test_ws"}}`, ws.WSID),
+			httpu.WithAuthorizeBy(apiKey), it.Expect401())
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		vit.PostWS(ws, "q.app1pkg.QryReturnsCategory", qryBody, httpu.WithAuthorizeBy(iauthnzimpl.APIKeyPrefix+"unknown"), it.Expect401())
+	})
+
+	t.Run("400 on create", func(t *testing.T) {
+		cases := map[string]string{
+			`{"args":{"Name":" ","Roles":"app1pkg.ApiRole"}}`:                                "API key name must not be empty",
+			`{"args":{"Name":"n","Roles":"sys.WorkspaceOwner"}}`:                             "system roles cannot be granted to API key",
+			`{"args":{"Name":"n","Roles":"app1pkg.Unknown"}}`:                                "role not found in workspace",
+			`{"args":{"Name":"n","Roles":"app1pkg.ApiRole","Functions":"app1pkg.category"}}`: "command or query not found",
+			`{"args":{"Name":"n","Roles":"app1pkg.ApiRole","AllowedIPs":"10.0.0.0/33"}}`:     "invalid allowed IP or CIDR",
+		}
+		for body, expectedMessage := range cases {
+			vit.PostWS(ws, "c.sys.CreateAPIKey", body, it.Expect400(expectedMessage))
+		}
+	})
+}
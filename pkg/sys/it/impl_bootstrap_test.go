@@ -65,7 +65,8 @@ func TestBoostrap_BasicUsage(t *testing.T) {
 		testBlobRequestHandler := blobprocessor.NewIRequestHandler(nil, 0, nil)
 		testRequestSender := bus.NewIRequestSender(testingu.MockTime, nil)
 		err := btstrp.Bootstrap(vit.IFederation, vit.IAppStructsProvider, vit.Time, appParts, clusterApp, otherApps,
-			nil, vit.ITokens, vit.IAppStorageProvider, postWiredInterfacePtrs, testBlobRequestHandler, testRequestSender)
+			nil, vit.ITokens, vit.IAppStorageProvider, postWiredInterfacePtrs, testBlobRequestHandler, testRequestSender,
+			newAppImagesDeployer(vit, postWiredInterfacePtrs))
 		require.NoError(err)
 		require.NotNil(*postWiredInterfacePtrs.BlobberAppStorage)
 		require.NotNil(*postWiredInterfacePtrs.RouterAppStorage)
@@ -88,7 +89,8 @@ func TestBoostrap_BasicUsage(t *testing.T) {
 		require.PanicsWithValue(fmt.Sprintf("failed to deploy app %[1]s: status 409, expected [200 201]: num partitions changed: app %[1]s declaring NumPartitions=%d but was previously deployed with NumPartitions=%d",
 			otherApps[0].Name, otherApps[0].AppDeploymentDescriptor.NumParts, otherApps[0].AppDeploymentDescriptor.NumParts-1), func() {
 			btstrp.Bootstrap(vit.IFederation, vit.IAppStructsProvider, vit.Time, appParts, clusterApp, otherApps,
-				nil, vit.ITokens, vit.IAppStorageProvider, postWiredInterfacePtrs, testBlobRequestHandler, testRequestSender)
+				nil, vit.ITokens, vit.IAppStorageProvider, postWiredInterfacePtrs, testBlobRequestHandler, testRequestSender,
+				newAppImagesDeployer(vit, postWiredInterfacePtrs))
 		})
 	})
 
@@ -107,7 +109,8 @@ func TestBoostrap_BasicUsage(t *testing.T) {
 			testBlobRequestHandler := blobprocessor.NewIRequestHandler(nil, 0, nil)
 			testRequestSender := bus.NewIRequestSender(testingu.MockTime, nil)
 			btstrp.Bootstrap(vit.IFederation, vit.IAppStructsProvider, vit.Time, appParts, clusterApp, otherApps,
-				nil, vit.ITokens, vit.IAppStorageProvider, postWiredInterfacePtrs, testBlobRequestHandler, testRequestSender)
+				nil, vit.ITokens, vit.IAppStorageProvider, postWiredInterfacePtrs, testBlobRequestHandler, testRequestSender,
+				newAppImagesDeployer(vit, postWiredInterfacePtrs))
 		})
 	})
 }
@@ -118,9 +121,14 @@ func newPostWiredInterfacePtrs() btstrp.PostWireInterfacePtrs {
 		RouterAppStorage:  dbcertcache.RouterAppStoragePtr(new(istorage.IAppStorage)),
 		BlobHandler:       blobprocessor.IRequestHandlerPtr(new(blobprocessor.IRequestHandler)),
 		RequestSender:     bus.IRequestSenderPtr(new(bus.IRequestSender)),
+		AppPartitions:     appparts.IAppPartitionsPtr(new(appparts.IAppPartitions)),
 	}
 }
 
+func newAppImagesDeployer(vit *it.VIT, postWiredInterfacePtrs btstrp.PostWireInterfacePtrs) cluster.IAppImagesDeployer {
+	return cluster.ProvideAppImagesDeployer(vit.IAppStructsProvider, vit.Time, vit.IBLOBStorage, postWiredInterfacePtrs.AppPartitions, vit.IAppStaticFolders)
+}
+
 func getTestCfg(numParts istructs.NumAppPartitions, numAppWS istructs.NumAppWorkspaces, storage istorage.IAppStorageFactory) it.VITConfig {
 	fs := fstest.MapFS{
 		"app.vsql": &fstest.MapFile{
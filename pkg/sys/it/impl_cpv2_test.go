@@ -463,3 +463,99 @@ func requireEqual(t *testing.T, expected map[string]interface{}, actualJSON stri
 	require.NoError(t, err)
 	require.JSONEq(t, string(expectedJSON), actualJSON)
 }
+
+func TestBasicUsage_CommandProcessorV2_Batch(t *testing.T) {
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	batchPath := fmt.Sprintf("api/v2/apps/test1/app1/workspaces/%d/batch", ws.WSID)
+
+	resp := vit.POST(fmt.Sprintf("api/v2/apps/test1/app1/workspaces/%d/docs/app1pkg.Root", ws.WSID), `{"FldRoot": 42}`,
+		httpu.WithAuthorizeBy(ws.Owner.Token),
+	)
+	rootID := newIDs(t, resp)["1"]
+
+	t.Run("basic usage", func(t *testing.T) {
+		body := fmt.Sprintf(`{"operations": [
+			{"method": "POST", "path": "docs/app1pkg.cdoc1", "body": {"sys.ID": 100}},
+			{"method": "POST", "path": "docs/app1pkg.cdoc2", "body": {"field2": 100}},
+			{"method": "PATCH", "path": "docs/app1pkg.Root/%[1]d", "body": {"FldRoot": 43}},
+			{"method": "POST", "path": "commands/app1pkg.TestCmd", "body": {"args": {"Arg1": 1}}}
+		]}`, rootID)
+		resp := vit.POST(batchPath, body, httpu.WithAuthorizeBy(ws.Owner.Token))
+		resp.Println()
+
+		respMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(resp.Body), &respMap))
+		results := respMap["results"].([]interface{})
+		require.Len(t, results, 4)
+
+		cdoc1Result := results[0].(map[string]interface{})
+		require.EqualValues(t, http.StatusCreated, cdoc1Result["status"])
+		cdoc1ID := istructs.RecordID(cdoc1Result["newIDs"].(map[string]interface{})["100"].(float64))
+		require.False(t, cdoc1ID.IsRaw())
+
+		cdoc2Result := results[1].(map[string]interface{})
+		require.EqualValues(t, http.StatusCreated, cdoc2Result["status"])
+		cdoc2ID := istructs.RecordID(cdoc2Result["newIDs"].(map[string]interface{})["1"].(float64))
+
+		require.EqualValues(t, http.StatusOK, results[2].(map[string]interface{})["status"])
+
+		cmdResult, err := json.Marshal(results[3].(map[string]interface{})["result"])
+		require.NoError(t, err)
+		require.JSONEq(t, `{"Int":42,"Str":"Str","sys.QName":"app1pkg.TestCmdResult"}`, string(cmdResult))
+
+		// raw ID reference is resolved to the ID of the record created in the same batch
+		resp = vit.GET(fmt.Sprintf("api/v2/apps/test1/app1/workspaces/%d/docs/app1pkg.cdoc2/%d", ws.WSID, cdoc2ID), httpu.WithAuthorizeBy(ws.Owner.Token))
+		require.Contains(t, resp.Body, fmt.Sprintf(`"field2":%d`, cdoc1ID))
+
+		resp = vit.GET(fmt.Sprintf("api/v2/apps/test1/app1/workspaces/%d/docs/app1pkg.Root/%d", ws.WSID, rootID), httpu.WithAuthorizeBy(ws.Owner.Token))
+		require.Contains(t, resp.Body, `"FldRoot":43`)
+	})
+
+	t.Run("failed operation rolls back the whole batch", func(t *testing.T) {
+		body := fmt.Sprintf(`{"operations": [
+			{"method": "PATCH", "path": "docs/app1pkg.Root/%[1]d", "body": {"FldRoot": 44}},
+			{"method": "PATCH", "path": "docs/app1pkg.Root/%[1]d", "body": {"sys.ID": 1}}
+		]}`, rootID)
+		vit.POST(batchPath, body, httpu.WithAuthorizeBy(ws.Owner.Token),
+			it.Expect400("operations[1]", "sys.ID field is not allowed among fields to update"),
+		).Println()
+
+		body = `{"operations": [
+			{"method": "POST", "path": "docs/app1pkg.Root", "body": {"FldRoot": 44}},
+			{"method": "PATCH", "path": "docs/app1pkg.Root/123456789", "body": {"FldRoot": 44}}
+		]}`
+		vit.POST(batchPath, body, httpu.WithAuthorizeBy(ws.Owner.Token), it.Expect404()).Println()
+
+		resp := vit.GET(fmt.Sprintf("api/v2/apps/test1/app1/workspaces/%d/docs/app1pkg.Root/%d", ws.WSID, rootID), httpu.WithAuthorizeBy(ws.Owner.Token))
+		require.Contains(t, resp.Body, `"FldRoot":43`)
+		resp = vit.GET(fmt.Sprintf("api/v2/apps/test1/app1/workspaces/%d/cdocs/app1pkg.Root", ws.WSID), httpu.WithAuthorizeBy(ws.Owner.Token))
+		require.NotContains(t, resp.Body, `"FldRoot":44`)
+	})
+
+	t.Run("400 bad request", func(t *testing.T) {
+		cases := map[string]string{
+			`{}`:                  `"operations" must be a non-empty array`,
+			`{"operations": [1]}`: "operations[0]: not an object",
+			`{"operations": [{"method": "GET", "path": "docs/app1pkg.Root/1"}]}`:  "GET docs/app1pkg.Root/1 is not supported in the batch",
+			`{"operations": [{"method": "POST", "path": "queries/app1pkg.Qry"}]}`: "is not supported in the batch",
+			`{"operations": [{"method": "POST", "path": "docs/wrong"}]}`:          `wrong path "docs/wrong"`,
+			`{"operations": [
+				{"method": "POST", "path": "commands/app1pkg.TestCmd", "body": {"args": {"Arg1": 1}}},
+				{"method": "POST", "path": "commands/app1pkg.TestCmd", "body": {"args": {"Arg1": 1}}}
+			]}`: "only one command is allowed in the batch",
+		}
+		for body, expectedMessage := range cases {
+			vit.POST(batchPath, body, httpu.WithAuthorizeBy(ws.Owner.Token), it.Expect400(expectedMessage)).Println()
+		}
+	})
+
+	t.Run("403 forbidden on operation that is not allowed", func(t *testing.T) {
+		body := `{"operations": [
+			{"method": "POST", "path": "docs/app1pkg.cdoc1", "body": {}},
+			{"method": "POST", "path": "commands/app1pkg.TestDeniedCmd"}
+		]}`
+		vit.POST(batchPath, body, httpu.WithAuthorizeBy(ws.Owner.Token), it.Expect403()).Println()
+	})
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/cluster"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	it "github.com/voedger/voedger/pkg/vit"
+	"github.com/voedger/voedger/pkg/vvm"
+	"github.com/voedger/voedger/pkg/vvm/builtin/clusterapp"
+)
+
+func TestDeployAppImage(t *testing.T) {
+	require := require.New(t)
+	vitCfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1),
+		it.WithVVMConfig(func(cfg *vvm.VVMConfig) {
+			// images are uploaded as BLOBs, SharedConfig_App1 limits BLOBs by 5 bytes
+			cfg.BLOBMaxSize = vvm.DefaultBLOBMaxSize
+		}),
+	)
+	vit := it.NewVIT(t, &vitCfg)
+	defer vit.TearDown()
+
+	sysToken, err := payloads.GetSystemPrincipalToken(vit.ITokens, istructs.AppQName_sys_cluster)
+	require.NoError(err)
+
+	// the same layout as `vpm build` produces
+	imageBLOBID := writeAppImage(vit, sysToken, filepath.Join("testdata", "apps", "test2.app1", "image"))
+	deployImage := func(imageBLOBID istructs.RecordID, appQName appdef.AppQName, numParts istructs.NumAppPartitions, numAppWorkspaces istructs.NumAppWorkspaces, opts ...httpu.ReqOptFunc) {
+		body := fmt.Sprintf(`{"args":{"AppQName":"%s","NumPartitions":%d,"NumAppWorkspaces":%d,"AppImage":%d}}`, appQName, numParts, numAppWorkspaces, imageBLOBID)
+		opts = append(opts, httpu.WithAuthorizeBy(sysToken))
+		vit.PostApp(istructs.AppQName_sys_cluster, clusterapp.ClusterAppPseudoWSID, "c.cluster.DeployApp", body, opts...)
+	}
+	deployApp := func(appQName appdef.AppQName, numParts istructs.NumAppPartitions, numAppWorkspaces istructs.NumAppWorkspaces, opts ...httpu.ReqOptFunc) {
+		deployImage(imageBLOBID, appQName, numParts, numAppWorkspaces, opts...)
+	}
+
+	t.Run("image is deployed after the command is committed", func(t *testing.T) {
+		deployApp(istructs.AppQName_test2_app2, 1, 1)
+
+		var def appdef.IAppDef
+		for def == nil {
+			def, _ = vit.IAppPartitions.AppDef(istructs.AppQName_test2_app2)
+			time.Sleep(100 * time.Millisecond)
+		}
+		require.NotNil(def.Workspace(appdef.NewQName("sidecartestapp", "test2app1WS")))
+
+		// the image is unzipped to a temporary dir that is removed after the deployment
+		require.Eventually(func() bool {
+			dirs, err := filepath.Glob(filepath.Join(os.TempDir(), "voedger-"+istructs.AppQName_test2_app2.Owner()+"."+istructs.AppQName_test2_app2.Name()+"-*"))
+			return err == nil && len(dirs) == 0
+		}, 5*time.Second, 100*time.Millisecond)
+
+		// app workspaces are initialized
+		as, err := vit.IAppPartitions.Borrow(istructs.AppQName_test2_app2, 0, appparts.ProcessorKind_Command)
+		require.NoError(err)
+		defer as.Release()
+		appWSID := istructs.NewWSID(istructs.CurrentClusterID(), istructs.FirstBaseAppWSID)
+		wsDesc, err := as.AppStructs().Records().GetSingleton(appWSID, appdef.QNameCDocWorkspaceDescriptor)
+		require.NoError(err)
+		require.Equal(appdef.QNameCDocWorkspaceDescriptor, wsDesc.QName())
+	})
+
+	t.Run("idempotency: the same image is not deployed twice", func(t *testing.T) {
+		deployApp(istructs.AppQName_test2_app2, 1, 1)
+	})
+
+	t.Run("409 conflict on the image incompatible with the deployed app", func(t *testing.T) {
+		for _, app := range vit.BuiltInAppsPackages {
+			if app.Name == istructs.AppQName_test1_app1 {
+				deployApp(app.Name, app.NumParts, app.NumAppWorkspaces, it.Expect409(cluster.ErrAppImageIncompatible.Error()))
+			}
+		}
+	})
+
+	t.Run("static folders of the image are served", func(t *testing.T) {
+		// the image with `STATIC Web SOURCE 'web/dist' SPA;`
+		imageDir := t.TempDir()
+		require.NoError(os.CopyFS(imageDir, os.DirFS(filepath.Join("testdata", "apps", "test2.app1", "image"))))
+		appPkgDir := filepath.Join(imageDir, "pkg", "github.com", "voedger", "sidecartestapp")
+		require.NoError(os.WriteFile(filepath.Join(appPkgDir, "static.vsql"), []byte(`STATIC Web SOURCE 'web/dist' SPA;`), 0600))
+
+		t.Run("400 bad request if the folder is not in the image", func(t *testing.T) {
+			deployImage(writeAppImage(vit, sysToken, imageDir), istructs.AppQName_test2_app2, 1, 1, it.Expect400("no web/dist folder"))
+		})
+
+		require.NoError(os.MkdirAll(filepath.Join(appPkgDir, "web", "dist", "assets"), 0700))
+		require.NoError(os.WriteFile(filepath.Join(appPkgDir, "web", "dist", "index.html"), []byte("<html>web</html>"), 0600))
+		require.NoError(os.WriteFile(filepath.Join(appPkgDir, "web", "dist", "assets", "app.3f2a9c1b.js"), []byte("app"), 0600))
+		deployImage(writeAppImage(vit, sysToken, imageDir), istructs.AppQName_test2_app2, 1, 1)
+
+		get := func(urlPath string) (*http.Response, string) {
+			resp, err := http.Get(vit.URLStr() + "/static/" + istructs.AppQName_test2_app2.String() + "/github.com/voedger/sidecartestapp/Web/" + urlPath)
+			require.NoError(err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(err)
+			return resp, string(body)
+		}
+		require.Eventually(func() bool {
+			resp, _ := get("")
+			return resp.StatusCode == http.StatusOK
+		}, 5*time.Second, 100*time.Millisecond)
+
+		resp, body := get("orders/123")
+		require.Equal(http.StatusOK, resp.StatusCode)
+		require.Equal("<html>web</html>", body)
+
+		resp, body = get("assets/app.3f2a9c1b.js")
+		require.Equal("app", body)
+		require.Contains(resp.Header.Get("Cache-Control"), "immutable")
+
+		t.Run("static folders are removed on redeployment of the image without them", func(t *testing.T) {
+			deployImage(writeAppImage(vit, sysToken, filepath.Join("testdata", "apps", "test2.app1", "image")), istructs.AppQName_test2_app2, 1, 1)
+			require.Eventually(func() bool {
+				resp, _ := get("")
+				return resp.StatusCode == http.StatusNotFound
+			}, 5*time.Second, 100*time.Millisecond)
+		})
+	})
+
+	t.Run("400 bad request on unknown image", func(t *testing.T) {
+		deployImage(istructs.MaxRawRecordID+1, istructs.AppQName_test2_app1, 1, 1, it.Expect400RefIntegrity_Existence())
+	})
+
+	t.Run("400 bad request on the BLOB that is not an image", func(t *testing.T) {
+		blobID := vit.UploadBLOB(istructs.AppQName_sys_cluster, clusterapp.ClusterAppWSID, "image.var", httpu.ContentType_ApplicationXBinary,
+			[]byte("not a zip"), appdef.NewQName(cluster.ClusterPackage, "App"), cluster.Field_AppImage, httpu.WithAuthorizeBy(sysToken))
+		deployImage(blobID, istructs.AppQName_test2_app1, 1, 1, it.Expect400("failed to parse image"))
+	})
+}
+
+// zips the image dir and uploads it to the cluster app workspace as the BLOB of cluster.App.AppImage
+func writeAppImage(vit *it.VIT, sysToken string, dir string) istructs.RecordID {
+	buf := bytes.NewBuffer(nil)
+	w := zip.NewWriter(buf)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, err := w.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(content)
+		return err
+	})
+	require.NoError(vit.T, err)
+	require.NoError(vit.T, w.Close())
+
+	return vit.UploadBLOB(istructs.AppQName_sys_cluster, clusterapp.ClusterAppWSID, "image.var", httpu.ContentType_ApplicationXBinary,
+		buf.Bytes(), appdef.NewQName(cluster.ClusterPackage, "App"), cluster.Field_AppImage, httpu.WithAuthorizeBy(sysToken))
+}
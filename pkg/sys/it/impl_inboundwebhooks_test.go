@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/istructs"
+	commandprocessor "github.com/voedger/voedger/pkg/processors/command"
+	"github.com/voedger/voedger/pkg/sys/webhooks"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestInboundWebhooks(t *testing.T) {
+	require := require.New(t)
+	inputs := make(chan string, 10)
+	it.MockCmdExec = func(input string, args istructs.ExecCommandArgs) error {
+		inputs <- input
+		return nil
+	}
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	hookURL := func(hook appdef.QName) string {
+		return fmt.Sprintf("api/v2/apps/%s/%s/workspaces/%d/hooks/%s",
+			istructs.AppQName_test1_app1.Owner(), istructs.AppQName_test1_app1.Name(), ws.WSID, hook)
+	}
+
+	// INBOUND WEBHOOK TestInboundWebhook executes app1pkg.MockCmd, verified by X-Test-Signature header
+	body := `{"Input":"paid"}`
+	secret := []byte(it.TestWebhookSecret)
+	sign := func(wsid istructs.WSID, hook appdef.QName, t time.Time, body string) string {
+		return commandprocessor.SignInboundWebhook(secret, wsid, hook, t, []byte(body))
+	}
+
+	t.Run("signed body is passed to the command", func(t *testing.T) {
+		resp := vit.POST(hookURL(it.QNameApp1_TestInboundWebhook), body,
+			httpu.WithHeaders("X-Test-Signature", sign(ws.WSID, it.QNameApp1_TestInboundWebhook, vit.Now(), body)),
+			httpu.WithExpectedCode(http.StatusAccepted))
+		require.Contains(resp.Body, "currentWLogOffset")
+		require.Equal("paid", <-inputs)
+	})
+
+	t.Run("401 on wrong signature", func(t *testing.T) {
+		cases := map[string]string{
+			"wrong secret":     commandprocessor.SignInboundWebhook([]byte("wrong secret"), ws.WSID, it.QNameApp1_TestInboundWebhook, vit.Now(), []byte(body)),
+			"other workspace":  sign(ws.WSID+1, it.QNameApp1_TestInboundWebhook, vit.Now(), body),
+			"other hook":       sign(ws.WSID, it.QNameApp1_TestInboundWebhookRaw, vit.Now(), body),
+			"other body":       sign(ws.WSID, it.QNameApp1_TestInboundWebhook, vit.Now(), `{"Input":"refunded"}`),
+			"stale timestamp":  sign(ws.WSID, it.QNameApp1_TestInboundWebhook, vit.Now().Add(-10*time.Minute), body),
+			"future timestamp": sign(ws.WSID, it.QNameApp1_TestInboundWebhook, vit.Now().Add(10*time.Minute), body),
+			"body only":        webhooks.Sign([]byte(body), it.TestWebhookSecret),
+			"no timestamp":     strings.Split(sign(ws.WSID, it.QNameApp1_TestInboundWebhook, vit.Now(), body), ",")[1],
+		}
+		for name, signature := range cases {
+			t.Run(name, func(t *testing.T) {
+				vit.POST(hookURL(it.QNameApp1_TestInboundWebhook), body,
+					httpu.WithHeaders("X-Test-Signature", signature),
+					httpu.Expect401())
+			})
+		}
+		vit.POST(hookURL(it.QNameApp1_TestInboundWebhook), body, httpu.Expect401())
+	})
+
+	t.Run("token", func(t *testing.T) {
+		// INBOUND WEBHOOK TestInboundWebhookRaw expects the token of the workspace in Authorization header, the body is passed as sys.Raw
+		vit.POST(hookURL(it.QNameApp1_TestInboundWebhookRaw), "not a JSON",
+			httpu.WithHeaders(httpu.Authorization, commandprocessor.InboundWebhookToken(secret, ws.WSID, it.QNameApp1_TestInboundWebhookRaw)))
+		for _, token := range []string{
+			it.TestWebhookSecret,
+			commandprocessor.InboundWebhookToken(secret, ws.WSID+1, it.QNameApp1_TestInboundWebhookRaw),
+			commandprocessor.InboundWebhookToken(secret, ws.WSID, it.QNameApp1_TestInboundWebhook),
+		} {
+			vit.POST(hookURL(it.QNameApp1_TestInboundWebhookRaw), "not a JSON",
+				httpu.WithHeaders(httpu.Authorization, token),
+				httpu.Expect401())
+		}
+	})
+
+	t.Run("404 on unknown inbound webhook", func(t *testing.T) {
+		vit.POST(hookURL(appdef.NewQName("app1pkg", "Unknown")), body, httpu.Expect404())
+	})
+}
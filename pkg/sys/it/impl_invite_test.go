@@ -9,9 +9,11 @@ import (
 	"log"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -669,3 +671,146 @@ func TestInvite_VersionMarker(t *testing.T) {
 		require.Equal(int32(1), getInviteVersion(ws, inviteID), "after InitiateCancelAcceptedInvite")
 	})
 }
+
+func TestInviteLink_BasicUsage(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+	wsName := "TestInviteLink_BasicUsage_ws"
+	prn := vit.GetPrincipal(istructs.AppQName_test1_app1, it.TestEmail)
+	ws := vit.CreateWorkspace(it.SimpleWSParams(wsName), prn)
+	expireDatetime := vit.Now().Add(time.Hour).UnixMilli()
+
+	signIn := func(domain string) *it.Principal {
+		email := fmt.Sprintf("testinvitelink_%d@%s", vit.NextNumber(), domain)
+		return vit.SignIn(vit.SignUp(email, "1", istructs.AppQName_test1_app1))
+	}
+	login1Prn := signIn("123.com")
+	login2Prn := signIn("123.com")
+	login3Prn := signIn("123.com")
+	otherDomainPrn := signIn("other.com")
+
+	createInviteLink := func(maxUses int, allowedEmailDomains string) (inviteLinkID istructs.RecordID, code string) {
+		resp := vit.PostWS(ws, "c.sys.CreateInviteLink", fmt.Sprintf(`{"args":{"Roles":"%s","MaxUses":%d,"ExpireDatetime":%d,"AllowedEmailDomains":"%s"}}`,
+			initialRoles, maxUses, expireDatetime, allowedEmailDomains))
+		return resp.NewID(), resp.CmdResult["Code"].(string)
+	}
+	// the email is verified because the login itself is not
+	verifyEmail := func(login *it.Principal) (emailToken string) {
+		token, code := InitiateEmailVerification(vit, login, appdef.NewQName(appdef.SysPackage, "JoinWorkspaceByLinkParams"), invite.Field_Email, login.Name, ws.WSID,
+			httpu.WithAuthorizeBy(login.Token))
+		body := fmt.Sprintf(`{"args":{"VerificationToken":"%s","VerificationCode":"%s"},"elements":[{"fields":["VerifiedValueToken"]}]}`, token, code)
+		return vit.PostProfile(login, "q.sys.IssueVerifiedValueToken", body).SectionRow()[0].(string)
+	}
+	login1EmailToken := verifyEmail(login1Prn)
+	login2EmailToken := verifyEmail(login2Prn)
+	login3EmailToken := verifyEmail(login3Prn)
+	otherDomainEmailToken := verifyEmail(otherDomainPrn)
+
+	// emailToken could be empty if the link does not restrict email domains
+	joinByLink := func(inviteLinkID istructs.RecordID, code string, login *it.Principal, emailToken string, opts ...httpu.ReqOptFunc) (inviteID istructs.RecordID) {
+		opts = append(opts, httpu.WithAuthorizeBy(login.Token))
+		emailArg := ""
+		if len(emailToken) > 0 {
+			emailArg = fmt.Sprintf(`,"Email":"%s"`, emailToken)
+		}
+		// new ID is returned if there was no invite for the login
+		return vit.PostWS(ws, "c.sys.JoinWorkspaceByLink", fmt.Sprintf(`{"args":{"InviteLinkID":%d,"Code":"%s"%s}}`, inviteLinkID, code, emailArg), opts...).NewID()
+	}
+
+	inviteLinkID, code := createInviteLink(2, "123.com")
+	require.Len(code, 32)
+
+	// join by the link
+	inviteID1 := joinByLink(inviteLinkID, code, login1Prn, login1EmailToken)
+	inviteID2 := joinByLink(inviteLinkID, code, login2Prn, login2EmailToken)
+	WaitForInviteState(vit, ws, inviteID1, invite.State_ToBeJoined, invite.State_Joined)
+	WaitForInviteState(vit, ws, inviteID2, invite.State_ToBeJoined, invite.State_Joined)
+
+	cDocJoinedWorkspace := FindCDocJoinedWorkspaceByInvitingWorkspaceWSIDAndLogin(vit, ws.WSID, login1Prn)
+	require.True(cDocJoinedWorkspace.isActive)
+	require.Equal(initialRoles, cDocJoinedWorkspace.roles)
+	require.Equal(wsName, cDocJoinedWorkspace.wsName)
+
+	// the joined login is authorized in the workspace
+	vit.PostWS(ws, "q.sys.Collection", `{"args":{"Schema":"sys.Invite"}}`, httpu.WithAuthorizeBy(login1Prn.Token), it.Expect403())
+
+	// list links
+	row := vit.PostWS(ws, "q.sys.Collection", fmt.Sprintf(`
+		{"args":{"Schema":"sys.InviteLink"},
+		"elements":[{"fields":["sys.ID","Roles","MaxUses","UsesCount","AllowedEmailDomains","sys.IsActive"]}],
+		"filters":[{"expr":"eq","args":{"field":"sys.ID","value":%d}}]}`, inviteLinkID)).SectionRow(0)
+	require.Equal([]interface{}{float64(inviteLinkID), initialRoles, float64(2), float64(2), "123.com", true}, row)
+
+	t.Run("400 on join", func(t *testing.T) {
+		t.Run("already joined", func(t *testing.T) {
+			joinByLink(inviteLinkID, code, login1Prn, login1EmailToken, it.Expect400("already joined"))
+		})
+		t.Run("max uses exceeded", func(t *testing.T) {
+			joinByLink(inviteLinkID, code, login3Prn, login3EmailToken, it.Expect400("max uses exceeded"))
+		})
+		t.Run("wrong code", func(t *testing.T) {
+			joinByLink(inviteLinkID, "wrong", login3Prn, login3EmailToken, it.Expect400("code invalid"))
+		})
+		t.Run("unknown link", func(t *testing.T) {
+			joinByLink(istructs.MaxRawRecordID+1, code, login3Prn, login3EmailToken, it.Expect400("referential integrity violation"))
+		})
+		t.Run("record of another type", func(t *testing.T) {
+			joinByLink(inviteID1, code, login3Prn, login3EmailToken, it.Expect400("invite link not exists"))
+		})
+		t.Run("email domain is not allowed", func(t *testing.T) {
+			inviteLinkID, code := createInviteLink(1, "123.com")
+			joinByLink(inviteLinkID, code, otherDomainPrn, otherDomainEmailToken, it.Expect400("email domain is not allowed"))
+		})
+		t.Run("email is not verified", func(t *testing.T) {
+			// the login of the allowed domain is not enough
+			inviteLinkID, code := createInviteLink(1, "123.com")
+			joinByLink(inviteLinkID, code, login3Prn, "", it.Expect400("email domain is not allowed"))
+			body := fmt.Sprintf(`{"args":{"InviteLinkID":%d,"Code":"%s","Email":"%s"}}`, inviteLinkID, code, login3Prn.Name)
+			vit.PostWS(ws, "c.sys.JoinWorkspaceByLink", body, httpu.WithAuthorizeBy(login3Prn.Token), it.Expect400("invalid token"))
+		})
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		inviteLinkID, code := createInviteLink(1, "")
+		vit.PostWS(ws, "c.sys.RevokeInviteLink", fmt.Sprintf(`{"args":{"InviteLinkID":%d}}`, inviteLinkID))
+		joinByLink(inviteLinkID, code, login3Prn, "", it.Expect400("invite link revoked"))
+		vit.PostWS(ws, "c.sys.RevokeInviteLink", fmt.Sprintf(`{"args":{"InviteLinkID":%d}}`, inviteLinkID), it.Expect400("invite link revoked"))
+		vit.PostWS(ws, "c.sys.RevokeInviteLink", fmt.Sprintf(`{"args":{"InviteLinkID":%d}}`, inviteID1), it.Expect400("invite link not exists"))
+
+		t.Run("403 on direct CUDs", func(t *testing.T) {
+			// the link could be modified by the commands only
+			vit.PostWS(ws, "c.sys.CUD", fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"sys.IsActive":true}}]}`, inviteLinkID), it.Expect403())
+			vit.PostWS(ws, "c.sys.CUD", fmt.Sprintf(`{"cuds":[{"sys.ID":%d,"fields":{"MaxUses":100}}]}`, inviteLinkID), it.Expect403())
+			vit.PostWS(ws, "c.sys.CUD", fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"sys.InviteLink","Code":"%s","Roles":"%s","MaxUses":1,"UsesCount":0,"ExpireDatetime":%d,"Created":1}}]}`,
+				code, initialRoles, expireDatetime), it.Expect403())
+		})
+	})
+
+	// join by link reuses the invite sent by email
+	email := fmt.Sprintf("testinvitelink_%d@123.com", vit.NextNumber())
+	loginPrn := vit.SignIn(vit.SignUp(email, "1", istructs.AppQName_test1_app1))
+	inviteID := InitiateInvitationByEMail(vit, ws, expireDatetime, email, initialRoles, inviteEmailTemplate, inviteEmailSubject)
+	_ = vit.CaptureEmail()
+	WaitForInviteState(vit, ws, inviteID, invite.State_ToBeInvited, invite.State_Invited)
+	inviteLinkID, code = createInviteLink(1, "")
+	require.Equal(istructs.NullRecordID, joinByLink(inviteLinkID, code, loginPrn, ""))
+	WaitForInviteState(vit, ws, inviteID, invite.State_ToBeJoined, invite.State_Joined)
+
+	t.Run("400 on create", func(t *testing.T) {
+		cases := map[string]string{
+			"max uses must be positive":      fmt.Sprintf(`{"args":{"Roles":"%s","MaxUses":0,"ExpireDatetime":%d}}`, initialRoles, expireDatetime),
+			"expire datetime must be in the": fmt.Sprintf(`{"args":{"Roles":"%s","MaxUses":1,"ExpireDatetime":%d}}`, initialRoles, vit.Now().UnixMilli()),
+			"invalid email domain":           fmt.Sprintf(`{"args":{"Roles":"%s","MaxUses":1,"ExpireDatetime":%d,"AllowedEmailDomains":"a@b.com"}}`, initialRoles, expireDatetime),
+			"system roles cannot be":         fmt.Sprintf(`{"args":{"Roles":"sys.WorkspaceOwner","MaxUses":1,"ExpireDatetime":%d}}`, expireDatetime),
+		}
+		for expectedMessage, body := range cases {
+			vit.PostWS(ws, "c.sys.CreateInviteLink", body, it.Expect400(expectedMessage))
+		}
+	})
+
+	t.Run("403 for non-owner", func(t *testing.T) {
+		vit.PostWS(ws, "c.sys.CreateInviteLink", fmt.Sprintf(`{"args":{"Roles":"%s","MaxUses":1,"ExpireDatetime":%d}}`, initialRoles, expireDatetime),
+			httpu.WithAuthorizeBy(login3Prn.Token), it.Expect403())
+	})
+}
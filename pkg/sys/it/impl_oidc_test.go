@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	"github.com/voedger/voedger/pkg/registry"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	idp := it.TestFakeIdP()
+	subject := vit.NextName()
+	idp.AddUser(subject, map[string]any{"name": "OIDC User", "email": subject + "@example.com"})
+
+	authorize := func(subject string) (code string, state string) {
+		resp := vit.GET("api/v2/apps/test1/app1/auth/oidc/authorize")
+		result := map[string]any{}
+		require.NoError(json.Unmarshal([]byte(resp.Body), &result))
+		code, state, err := idp.Authorize(result["authorizationURL"].(string), subject)
+		require.NoError(err)
+		return code, state
+	}
+	callbackURL := func(code string, state string) string {
+		return fmt.Sprintf("api/v2/apps/test1/app1/auth/oidc/callback?code=%s&state=%s", url.QueryEscape(code), url.QueryEscape(state))
+	}
+	signIn := func() map[string]any {
+		code, state := authorize(subject)
+		for {
+			// 409 until the profile of the login created on the first sign in is ready
+			resp := vit.GET(callbackURL(code, state), httpu.WithExpectedCode(http.StatusOK), httpu.WithExpectedCode(http.StatusConflict))
+			if resp.HTTPResp.StatusCode == http.StatusOK {
+				result := map[string]any{}
+				require.NoError(json.Unmarshal([]byte(resp.Body), &result))
+				return result
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	var profileWSID float64
+	t.Run("first sign in creates the login", func(t *testing.T) {
+		result := signIn()
+		require.Equal(3600.0, result["expiresInSeconds"])
+		profileWSID = result["profileWSID"].(float64)
+		require.Positive(profileWSID)
+
+		token := result["principalToken"].(string)
+		payload := payloads.PrincipalPayload{}
+		_, err := vit.ITokens.ValidateToken(token, &payload)
+		require.NoError(err)
+		require.Equal(registry.OIDCLogin(idp.Issuer(), subject), payload.Login)
+		require.Equal(istructs.SubjectKind_User, payload.SubjectKind)
+		require.Equal(istructs.WSID(profileWSID), payload.ProfileWSID)
+
+		// the token is a regular principal token
+		vit.POST("api/v2/apps/test1/app1/auth/refresh", "", httpu.WithAuthorizeBy(token))
+	})
+
+	t.Run("next sign in uses the same login", func(t *testing.T) {
+		result := signIn()
+		require.Equal(profileWSID, result["profileWSID"].(float64))
+	})
+
+	t.Run("state is used once", func(t *testing.T) {
+		code, state := authorize(subject)
+		vit.GET(callbackURL(code, state))
+		vit.GET(callbackURL(code, state), it.Expect401("OpenID Connect state is unknown or expired"))
+	})
+
+	t.Run("concurrent callbacks sign in once", func(t *testing.T) {
+		// the login is created by the first callback, the next ones skip the code exchange
+		newSubject := vit.NextName()
+		idp.AddUser(newSubject, map[string]any{"name": "OIDC User"})
+		code, state := authorize(newSubject)
+		vit.GET(callbackURL(code, state), httpu.Expect409())
+
+		signedIn := atomic.Int32{}
+		for signedIn.Load() == 0 {
+			wg := sync.WaitGroup{}
+			for range 5 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					resp := vit.GET(callbackURL(code, state), httpu.WithExpectedCode(http.StatusOK), httpu.WithExpectedCode(http.StatusConflict),
+						httpu.WithExpectedCode(http.StatusUnauthorized))
+					if resp.HTTPResp.StatusCode == http.StatusOK {
+						signedIn.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+			time.Sleep(100 * time.Millisecond)
+		}
+		require.Equal(int32(1), signedIn.Load())
+		vit.GET(callbackURL(code, state), it.Expect401("OpenID Connect state is unknown or expired"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		vit.GET(callbackURL("code", "unknown"), it.Expect401("OpenID Connect state is unknown or expired"))
+		vit.GET("api/v2/apps/test1/app1/auth/oidc/callback?state=unknown", it.Expect400("code and state must be provided"))
+		vit.GET("api/v2/apps/test1/app1/auth/oidc/callback?error=access_denied&state=unknown", it.Expect401("access_denied"))
+
+		_, state := authorize(subject)
+		vit.GET(callbackURL("wrong", state), it.Expect401("unknown or used code"))
+
+		vit.GET("api/v2/apps/test1/app2/auth/oidc/authorize", httpu.Expect404())
+	})
+
+	t.Run("logins of the identity provider users could not be registered with password", func(t *testing.T) {
+		vit.SignUp(registry.OIDCLogin(idp.Issuer(), vit.NextName()), "pwd", istructs.AppQName_test1_app1,
+			it.WithReqOpt(it.Expect400("incorrect login format")))
+	})
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/state"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/verifier"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+type outboxEmail struct {
+	ID         int64
+	Status     int32
+	Attempts   int32
+	LastError  string
+	Subject    string
+	Recipients string
+}
+
+func TestOutboxEmail_Retry(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	loginName := vit.NextName() + "@123.com"
+	login := vit.SignUp(loginName, "1", istructs.AppQName_test1_app1)
+	prn := vit.SignIn(login)
+	as, err := vit.IAppStructsProvider.BuiltIn(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	appWSID := coreutils.PseudoWSIDToAppWSID(prn.ProfileWSID, as.NumAppWorkspaces())
+	sysToken := vit.GetSystemPrincipal(istructs.AppQName_test1_app1).Token
+
+	// the first attempt fails
+	vit.FailEmailSending(1)
+	body := fmt.Sprintf(`{"args":{"Entity":"%s","Field":"EmailField","Email":"%s","TargetWSID":%d},"elements":[{"fields":["VerificationToken"]}]}`,
+		it.QNameApp1_TestEmailVerificationDoc, loginName, prn.ProfileWSID)
+	vit.PostProfile(prn, "q.sys.InitiateEmailVerification", body)
+
+	// the message is pending in the outbox
+	email := waitForOutboxEmail(vit, appWSID, sysToken, loginName, func(e outboxEmail) bool { return e.Attempts == 1 })
+	require.Equal(sys.OutboxEmail_Status_Pending, email.Status)
+	require.Equal(verifier.EmailSubject, email.Subject)
+	require.NotEmpty(email.LastError)
+
+	// the next run of the sys.OutboxSender job sends the message
+	vit.SchedulerTimeAdd(time.Minute)
+	captured := vit.CaptureEmail()
+	require.Equal(verifier.EmailSubject, captured.Subject)
+	require.Equal([]string{loginName}, captured.To)
+
+	email = waitForOutboxEmail(vit, appWSID, sysToken, loginName, func(e outboxEmail) bool { return e.Status != sys.OutboxEmail_Status_Pending })
+	require.Equal(sys.OutboxEmail_Status_Sent, email.Status)
+	require.Equal(int32(2), email.Attempts)
+	require.Empty(email.LastError)
+}
+
+func TestOutboxEmail_AttachmentsAndAppSMTPSettings(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	as, err := vit.IAppStructsProvider.BuiltIn(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	appWSID := coreutils.PseudoWSIDToAppWSID(ws.WSID, as.NumAppWorkspaces())
+	sysToken := vit.GetSystemPrincipal(istructs.AppQName_test1_app1).Token
+	blobID := vit.UploadBLOB(istructs.AppQName_test1_app1, ws.WSID, "test.txt", "text/plain", []byte("hello"),
+		it.QNameDocWithBLOB, it.Field_Blob, httpu.WithAuthorizeBy(ws.Owner.Token))
+
+	sendTestEmail := func(to string) {
+		body := fmt.Sprintf(`{"args":{"To":"%s","Attachment":%d}}`, to, blobID)
+		vit.PostWS(ws, "c."+it.QNameApp1_CmdSendTestEmail.String(), body)
+	}
+	requireSent := func(captured state.EmailMessage, to string) {
+		require.Equal([]string{to}, captured.To)
+		require.Equal(it.TestApp1SMTPFrom, captured.From)
+		require.Equal([]state.EmailAttachment{{Name: "test.txt", ContentType: "text/plain", Data: []byte("hello")}}, captured.Attachments)
+	}
+
+	t.Run("sent by per-app SMTP settings", func(t *testing.T) {
+		to := vit.NextName() + "@123.com"
+		sendTestEmail(to)
+		requireSent(vit.CaptureEmail(), to)
+
+		email := waitForOutboxEmail(vit, appWSID, sysToken, to, func(e outboxEmail) bool { return true })
+		require.Equal(sys.OutboxEmail_Status_Sent, email.Status)
+		require.Equal(int32(1), email.Attempts)
+	})
+
+	t.Run("attachments are read again on retry", func(t *testing.T) {
+		to := vit.NextName() + "@123.com"
+		vit.FailEmailSending(1)
+		sendTestEmail(to)
+		waitForOutboxEmail(vit, appWSID, sysToken, to, func(e outboxEmail) bool { return e.Attempts == 1 })
+
+		vit.SchedulerTimeAdd(time.Minute)
+		requireSent(vit.CaptureEmail(), to)
+
+		email := waitForOutboxEmail(vit, appWSID, sysToken, to, func(e outboxEmail) bool { return e.Status != sys.OutboxEmail_Status_Pending })
+		require.Equal(sys.OutboxEmail_Status_Sent, email.Status)
+		require.Equal(int32(2), email.Attempts)
+	})
+}
+
+// waits for the outbox message to the recipient that matches the condition
+func waitForOutboxEmail(vit *it.VIT, appWSID istructs.WSID, token string, recipient string, cond func(outboxEmail) bool) outboxEmail {
+	vit.T.Helper()
+	return WaitForOutboundMessage(vit, sys.QNameViewOutboxEmail, func(body string) *federation.FuncResponse {
+		return vit.PostApp(istructs.AppQName_test1_app1, appWSID, "q.sys.SqlQuery", body, httpu.WithAuthorizeBy(token))
+	}, func(e outboxEmail) bool { return e.Recipients == recipient && cond(e) })
+}
@@ -197,6 +197,21 @@ func TestQueryProcessor2_Views(t *testing.T) {
 			{"Day":2,"Month":1,"StringValue":"2023-01-02","Year":2023,"offs":%[1]d,"sys.QName":"app1pkg.DailyIdx"}
 		]}`, expectedOffset), resp.Body)
 	})
+	t.Run("Read with order by clustering columns desc", func(t *testing.T) {
+		resp := vit.GET(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where={"Year":2022}&order=-Month,-Day&limit=3`, ws.WSID, it.QNameApp1_ViewDailyIdx), httpu.WithAuthorizeBy(ws.Owner.Token))
+		require.JSONEq(fmt.Sprintf(`{"results":[
+			{"Day":5,"Month":4,"StringValue":"2022-04-05","Year":2022,"offs":%[1]d,"sys.QName":"app1pkg.DailyIdx"},
+			{"Day":4,"Month":4,"StringValue":"2022-04-04","Year":2022,"offs":%[1]d,"sys.QName":"app1pkg.DailyIdx"},
+			{"Day":3,"Month":4,"StringValue":"2022-04-03","Year":2022,"offs":%[1]d,"sys.QName":"app1pkg.DailyIdx"}
+		]}`, expectedOffset), resp.Body)
+	})
+	t.Run("Read with order by partial clustering columns desc", func(t *testing.T) {
+		resp := vit.GET(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where={"Year":2022,"Month":2}&order=-Month&skip=1&limit=2`, ws.WSID, it.QNameApp1_ViewDailyIdx), httpu.WithAuthorizeBy(ws.Owner.Token))
+		require.JSONEq(fmt.Sprintf(`{"results":[
+			{"Day":4,"Month":2,"StringValue":"2022-02-04","Year":2022,"offs":%[1]d,"sys.QName":"app1pkg.DailyIdx"},
+			{"Day":3,"Month":2,"StringValue":"2022-02-03","Year":2022,"offs":%[1]d,"sys.QName":"app1pkg.DailyIdx"}
+		]}`, expectedOffset), resp.Body)
+	})
 	t.Run("Use keys constraint", func(t *testing.T) {
 		resp := vit.GET(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where={"Year":{"$in":[2021,2022,2023,2024,2025]},"Month":1,"Day":2}&keys=Year,Month,Day`, ws.WSID, it.QNameApp1_ViewDailyIdx), httpu.WithAuthorizeBy(ws.Owner.Token))
 		require.JSONEq(`{"results":[
@@ -2471,6 +2486,49 @@ func TestQueryProcessor2_CDocs(t *testing.T) {
 	})
 }
 
+func TestQueryProcessor2_TimestampAndCurrency(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	docsURL := fmt.Sprintf("api/v2/apps/test1/app1/workspaces/%d/docs/%s", ws.WSID, it.QNameApp1_CDocPayment)
+
+	// lexicographic order of amounts would be 100, 12.34, 9.5
+	ids := []istructs.RecordID{}
+	for _, body := range []string{
+		`{"Amount": "12.34", "PaidAt": "2025-01-02T05:04:05.678+02:00"}`,
+		`{"Amount": "100", "PaidAt": "2025-01-03T00:00:00Z"}`,
+		`{"Amount": 95000, "PaidAt": 1735689600000}`, // raw values: 9.5, 2025-01-01T00:00:00Z
+	} {
+		resp := vit.POST(docsURL, body, httpu.WithAuthorizeBy(ws.Owner.Token))
+		ids = append(ids, newIDs(t, resp)["1"])
+	}
+
+	t.Run("ISO-8601 and decimal strings are returned", func(t *testing.T) {
+		resp := vit.GET(fmt.Sprintf("%s/%d", docsURL, ids[0]), httpu.WithAuthorizeBy(ws.Owner.Token))
+		require.JSONEq(fmt.Sprintf(`{"Amount":"12.3400","PaidAt":"2025-01-02T03:04:05.678Z","sys.ID":%d,"sys.IsActive":true,"sys.QName":"app1pkg.Payment"}`, ids[0]), resp.Body)
+	})
+
+	t.Run("order by values", func(t *testing.T) {
+		resp := vit.GET(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/cdocs/%s?keys=Amount,PaidAt&order=Amount`, ws.WSID, it.QNameApp1_CDocPayment),
+			httpu.WithAuthorizeBy(ws.Owner.Token))
+		require.JSONEq(`{"results":[
+			{"Amount":"9.5000","PaidAt":"2025-01-01T00:00:00.000Z"},
+			{"Amount":"12.3400","PaidAt":"2025-01-02T03:04:05.678Z"},
+			{"Amount":"100.0000","PaidAt":"2025-01-03T00:00:00.000Z"}
+		]}`, resp.Body)
+	})
+
+	t.Run("400 on wrong values", func(t *testing.T) {
+		vit.POST(docsURL, `{"Amount": ".+5"}`, httpu.WithAuthorizeBy(ws.Owner.Token), it.Expect400("invalid decimal syntax"))
+		vit.POST(docsURL, `{"Amount": "1.23456"}`, httpu.WithAuthorizeBy(ws.Owner.Token), it.Expect400("too many fractional digits"))
+		vit.POST(docsURL, `{"Amount": "100000000000"}`, httpu.WithAuthorizeBy(ws.Owner.Token), it.Expect400("out of range"))
+		vit.POST(docsURL, `{"PaidAt": "02.01.2025"}`, httpu.WithAuthorizeBy(ws.Owner.Token), it.Expect400("PaidAt"))
+		vit.POST(docsURL, `{"PaidAt": 253402300800000}`, httpu.WithAuthorizeBy(ws.Owner.Token), it.Expect400("MaxIncl"))
+	})
+}
+
 // [~server.authnz/it.TestLogin~impl]
 func TestQueryProcessor2_AuthLogin(t *testing.T) {
 	vit := it.NewVIT(t, &it.SharedConfig_App1)
@@ -57,11 +57,12 @@ func TestRates_BasicUsage(t *testing.T) {
 	// proceed to the next minute to restore per-minute rates
 	vit.TimeAdd(time.Minute)
 
-	// next are failed again because per-hour rate is exceeded (RatedPerHour: 4 PER HOUR -> 900s)
+	// next are failed again because per-hour rate is exceeded (RatedPerHour: 4 PER HOUR -> one token per 900s)
+	// 2 minutes passed since the first call, so 2/15 of the token is already refilled -> 780s
 	respQry = vit.PostWS(ws, "q.app1pkg.RatedQry", bodyQry, httpu.Expect429())
-	require.Equal("900", respQry.HTTPResp.Header.Get(httpu.RetryAfter))
+	require.Equal("780", respQry.HTTPResp.Header.Get(httpu.RetryAfter))
 	respCmd = vit.PostWS(ws, "c.app1pkg.RatedCmd", bodyCmd, httpu.Expect429())
-	require.Equal("900", respCmd.HTTPResp.Header.Get(httpu.RetryAfter))
+	require.Equal("780", respCmd.HTTPResp.Header.Get(httpu.RetryAfter))
 
 	// proceed to the next hour to restore per-hour rates
 	vit.TimeAdd(time.Hour)
@@ -104,6 +105,36 @@ func TestRates_PerIP(t *testing.T) {
 	vit.PostWS(ws, "c.app1pkg.IPRatedCmd", bodyCmd, httpu.Expect429())
 }
 
+func TestRates_Distributed(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	bodyCmd := `{"args":{}}`
+
+	for range 2 {
+		vit.PostWS(ws, "c.app1pkg.DistributedRatedCmd", bodyCmd)
+	}
+
+	// DistributedPerMinute: 2 PER MINUTE -> 30s
+	respCmd := vit.PostWS(ws, "c.app1pkg.DistributedRatedCmd", bodyCmd, httpu.Expect429())
+	require.Equal("30", respCmd.HTTPResp.Header.Get(httpu.RetryAfter))
+
+	// one token is refilled in 30s
+	vit.TimeAdd(10 * time.Second)
+	respCmd = vit.PostWS(ws, "c.app1pkg.DistributedRatedCmd", bodyCmd, httpu.Expect429())
+	require.Equal("20", respCmd.HTTPResp.Header.Get(httpu.RetryAfter))
+
+	vit.TimeAdd(20 * time.Second)
+	vit.PostWS(ws, "c.app1pkg.DistributedRatedCmd", bodyCmd)
+	vit.PostWS(ws, "c.app1pkg.DistributedRatedCmd", bodyCmd, httpu.Expect429())
+
+	// other workspace is limited independently
+	ws3 := vit.WS(istructs.AppQName_test1_app1, "test_ws3")
+	vit.PostWS(ws3, "c.app1pkg.DistributedRatedCmd", bodyCmd)
+}
+
 func TestQueryLimiter_BasicUsage(t *testing.T) {
 	vit := it.NewVIT(t, &it.SharedConfig_App1)
 	defer vit.TearDown()
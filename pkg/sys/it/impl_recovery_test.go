@@ -6,11 +6,18 @@
 package sys_it
 
 import (
+	"encoding/binary"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/faulty"
 	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istructs"
 	it "github.com/voedger/voedger/pkg/vit"
@@ -79,3 +86,87 @@ func TestCorrectIDsIssueAfterRecovery(t *testing.T) {
 	resp = vit.PostWS(ws, "c.sys.CUD", body)
 	resp.Println()
 }
+
+func TestCommandProcessorRecoveryOnStorageFault(t *testing.T) {
+	require := require.New(t)
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1,
+			it.WithUserLogin("login", "pwd"),
+			it.WithChildWorkspace(it.QNameApp1_TestWSKind, "test_ws", "", "", "login", map[string]interface{}{"IntFld": 42}),
+		),
+		it.WithStorageFaults(0),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+
+	// PLog event is the first value inserted on the command handling
+	vit.InjectStorageFault(istructs.AppQName_test1_app1, faulty.Fault{
+		Operations: []faulty.Operation{faulty.Operation_InsertIfNotExists},
+		Times:      1,
+		Err:        faulty.ErrInjected,
+	})
+	body := `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.articles","name":"faulty","article_manual":1,"article_hash":2,"hideonhold":3,"time_active":4,"control_active":5}}]}`
+	vit.PostWS(ws, "c.sys.CUD", body, httpu.Expect500())
+
+	// the partition is recovered on the next command
+	body = `{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"app1pkg.articles","name":"recovered","article_manual":1,"article_hash":2,"hideonhold":3,"time_active":4,"control_active":5}}]}`
+	id := vit.PostWS(ws, "c.sys.CUD", body).NewID()
+
+	resp := vit.PostWS(ws, "q.sys.Collection", `{"args":{"Schema":"app1pkg.articles"},"elements":[{"fields":["name","sys.ID"]}]}`)
+	require.Len(resp.Sections[0].Elements, 1)
+	require.Equal("recovered", resp.SectionRow()[0])
+	require.EqualValues(id, resp.SectionRow()[1])
+}
+
+func TestAsyncActualizerRetryOnStorageFault(t *testing.T) {
+	require := require.New(t)
+	cfg := it.NewOwnVITConfig(
+		it.WithApp(istructs.AppQName_test1_app1, it.ProvideApp1,
+			it.WithUserLogin("login", "pwd"),
+			it.WithChildWorkspace(it.QNameApp1_TestWSKind, "test_ws", "", "", "login", map[string]interface{}{"IntFld": 42}),
+		),
+		it.WithStorageFaults(0),
+	)
+	vit := it.NewVIT(t, &cfg)
+	defer vit.TearDown()
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+
+	// view partition key starts with view QNameID and WSID
+	as, err := vit.BuiltIn(istructs.AppQName_test1_app1)
+	require.NoError(err)
+	viewID, err := as.QNameID(it.QNameApp1_ViewClients)
+	require.NoError(err)
+	viewPKeyPrefix := binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint16(nil, viewID), uint64(ws.WSID))
+
+	// async projector ApplyClient fails to write the view twice
+	vit.InjectStorageFault(istructs.AppQName_test1_app1, faulty.Fault{
+		Operations: []faulty.Operation{faulty.Operation_PutBatch},
+		PKeyPrefix: viewPKeyPrefix,
+		Times:      2,
+		Err:        faulty.ErrInjected,
+	})
+
+	offsetsChan, unsubscribe := vit.SubscribeForN10nUnsubscribe(in10n.ProjectionKey{
+		App:        istructs.AppQName_test1_app1,
+		Projection: it.QNameApp1_ViewClients,
+		WS:         ws.WSID,
+	})
+	cuds := coreutils.CUDs{{Fields: map[string]interface{}{
+		appdef.SystemField_ID:    istructs.RecordID(1),
+		appdef.SystemField_QName: it.QNameApp1_WDocClient,
+		it.Field_FirstName:       "Juan",
+		it.Field_DOB:             int64(568209600000), // 1988-01-03
+	}}}
+	resp := vit.PostWS(ws, "c.sys.CUD", cuds.ToJSON())
+	clientID := resp.NewID()
+
+	// the view is written by the actualizer retried after faults
+	waitForOffset(t, resp.CurrentWLogOffset, offsetsChan)
+	unsubscribe()
+	require.Equal(2, vit.StorageFaultsInjected())
+
+	viewResp := vit.GET(fmt.Sprintf(`api/v2/apps/test1/app1/workspaces/%d/views/%s?where={"Year":{"$in":[1988]},"Month":{"$in":[1]}}`, ws.WSID, it.QNameApp1_ViewClients),
+		httpu.WithAuthorizeBy(ws.Owner.Token))
+	require.Contains(viewResp.Body, fmt.Sprintf(`"Client":%d`, clientID))
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // nolint G505
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestTOTP(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	login := vit.SignUp(vit.NextName(), "pwd", istructs.AppQName_test1_app1)
+	prn := vit.SignIn(login)
+
+	enrolmentBody := func(unloggedArgs string) string {
+		return fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"},"unloggedArgs":%s}`, login.Name, login.AppQName, unloggedArgs)
+	}
+	issuePrincipalToken := func() (principalToken string, challengeToken string) {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","Password":"%s","AppName":"%s"},"elements":[{"fields":["PrincipalToken","WSID","TOTPChallengeToken"]}]}`,
+			login.Name, login.Pwd, login.AppQName)
+		resp := vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.IssuePrincipalToken", body)
+		if ct, ok := resp.SectionRow()[2].(string); ok {
+			challengeToken = ct
+		}
+		return resp.SectionRow()[0].(string), challengeToken
+	}
+	issuePrincipalTokenByTOTP := func(challengeToken string, code string, opts ...httpu.ReqOptFunc) *federation.FuncResponse {
+		body := fmt.Sprintf(`{"args":{"ChallengeToken":"%s","Code":"%s"},"elements":[{"fields":["PrincipalToken","WSID"]}]}`, challengeToken, code)
+		return vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "q.registry.IssuePrincipalTokenByTOTP", body, opts...)
+	}
+
+	t.Run("no second factor before the enrolment", func(t *testing.T) {
+		principalToken, challengeToken := issuePrincipalToken()
+		require.NotEmpty(principalToken)
+		require.Empty(challengeToken)
+	})
+
+	var secret []byte
+	var recoveryCodes []string
+	t.Run("enrol", func(t *testing.T) {
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.InitiateTOTPEnrolment",
+			enrolmentBody(`{"Password":"wrong"}`), httpu.Expect401())
+
+		resp := vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.InitiateTOTPEnrolment",
+			enrolmentBody(`{"Password":"pwd"}`))
+		encodedSecret := resp.CmdResult["Secret"].(string)
+		require.Contains(resp.CmdResult["ProvisioningURI"].(string), "otpauth://totp/")
+		require.Contains(resp.CmdResult["ProvisioningURI"].(string), "secret="+encodedSecret)
+		var err error
+		secret, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(encodedSecret)
+		require.NoError(err)
+
+		// no second factor until the enrolment is confirmed
+		_, challengeToken := issuePrincipalToken()
+		require.Empty(challengeToken)
+
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.ConfirmTOTPEnrolment",
+			enrolmentBody(`{"Password":"pwd","Code":"000000x"}`), httpu.Expect401())
+
+		resp = vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.ConfirmTOTPEnrolment",
+			enrolmentBody(fmt.Sprintf(`{"Password":"pwd","Code":"%s"}`, testTOTPCode(secret, vit.Now()))))
+		recoveryCodes = strings.Split(resp.CmdResult["RecoveryCodes"].(string), ",")
+		require.Len(recoveryCodes, 10)
+
+		// already enrolled
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.InitiateTOTPEnrolment",
+			enrolmentBody(`{"Password":"pwd"}`), httpu.Expect409())
+	})
+
+	t.Run("sign in with TOTP code", func(t *testing.T) {
+		vit.TimeAdd(time.Minute) // next time step and rate limit period
+
+		principalToken, challengeToken := issuePrincipalToken()
+		require.Empty(principalToken)
+		require.NotEmpty(challengeToken)
+
+		// the challenge token is not the principal token
+		vit.PostApp(istructs.AppQName_test1_app1, prn.ProfileWSID, "q.sys.Collection", `{"args":{"Schema":"sys.UserProfile"}}`,
+			httpu.WithAuthorizeBy(challengeToken), httpu.Expect401())
+
+		issuePrincipalTokenByTOTP(challengeToken, "000000", httpu.Expect401())
+		issuePrincipalTokenByTOTP("wrong", testTOTPCode(secret, vit.Now()), httpu.Expect401())
+
+		code := testTOTPCode(secret, vit.Now())
+		resp := issuePrincipalTokenByTOTP(challengeToken, code)
+		principalToken = resp.SectionRow()[0].(string)
+		require.Equal(float64(prn.ProfileWSID), resp.SectionRow()[1])
+		var p payloads.PrincipalPayload
+		_, err := vit.ITokens.ValidateToken(principalToken, &p)
+		require.NoError(err)
+		require.Equal(login.Name, p.Login)
+
+		// the code could not be used twice
+		issuePrincipalTokenByTOTP(challengeToken, code, httpu.Expect401())
+	})
+
+	t.Run("sign in with recovery code", func(t *testing.T) {
+		vit.TimeAdd(time.Minute)
+		_, challengeToken := issuePrincipalToken()
+		resp := issuePrincipalTokenByTOTP(challengeToken, strings.ToUpper(recoveryCodes[0]))
+		require.NotEmpty(resp.SectionRow()[0].(string))
+
+		// recovery code could be used once
+		issuePrincipalTokenByTOTP(challengeToken, recoveryCodes[0], httpu.Expect401())
+
+		// other recovery codes are still valid
+		resp = issuePrincipalTokenByTOTP(challengeToken, recoveryCodes[1])
+		require.NotEmpty(resp.SectionRow()[0].(string))
+
+		// recovery code could be used once even concurrently
+		vit.TimeAdd(time.Minute)
+		_, challengeToken = issuePrincipalToken()
+		wg := sync.WaitGroup{}
+		succeeded := atomic.Int32{}
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp := issuePrincipalTokenByTOTP(challengeToken, recoveryCodes[2],
+					httpu.WithExpectedCode(http.StatusOK), httpu.WithExpectedCode(http.StatusUnauthorized))
+				if resp.HTTPResp.StatusCode == http.StatusOK {
+					succeeded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		require.Equal(int32(1), succeeded.Load())
+
+		// forget incorrect codes
+		vit.TimeAdd(time.Minute)
+		resp = issuePrincipalTokenByTOTP(challengeToken, recoveryCodes[3])
+		require.NotEmpty(resp.SectionRow()[0].(string))
+	})
+
+	t.Run("rate limit and lockout", func(t *testing.T) {
+		vit.TimeAdd(time.Minute)
+		_, challengeToken := issuePrincipalToken()
+		for range 5 {
+			issuePrincipalTokenByTOTP(challengeToken, "000000", httpu.Expect401())
+		}
+		issuePrincipalTokenByTOTP(challengeToken, testTOTPCode(secret, vit.Now()), httpu.Expect429())
+
+		// incorrect codes are counted per login, so the second factor is still locked out after the rate limit period
+		// even with the new challenge token
+		vit.TimeAdd(2 * time.Minute)
+		_, challengeToken = issuePrincipalToken()
+		issuePrincipalTokenByTOTP(challengeToken, testTOTPCode(secret, vit.Now()), httpu.Expect429())
+
+		vit.TimeAdd(5 * time.Minute)
+		_, challengeToken = issuePrincipalToken()
+		resp := issuePrincipalTokenByTOTP(challengeToken, testTOTPCode(secret, vit.Now()))
+		require.NotEmpty(resp.SectionRow()[0].(string))
+
+		// the correct code resets the counter
+		for range 2 {
+			for range 4 {
+				vit.TimeAdd(time.Minute)
+				issuePrincipalTokenByTOTP(challengeToken, "000000", httpu.Expect401())
+			}
+			vit.TimeAdd(time.Minute)
+			_, challengeToken = issuePrincipalToken()
+			resp = issuePrincipalTokenByTOTP(challengeToken, testTOTPCode(secret, vit.Now()))
+			require.NotEmpty(resp.SectionRow()[0].(string))
+		}
+	})
+
+	t.Run("challenge token on api/v2 auth/login", func(t *testing.T) {
+		body := fmt.Sprintf(`{"login": "%s","password": "%s"}`, login.Name, login.Pwd)
+		resp := vit.POST("api/v2/apps/test1/app1/auth/login", body)
+		result := map[string]interface{}{}
+		require.NoError(json.Unmarshal([]byte(resp.Body), &result))
+		require.NotEmpty(result["totpChallengeToken"])
+		require.NotContains(result, "principalToken")
+	})
+
+	t.Run("reset by System", func(t *testing.T) {
+		body := fmt.Sprintf(`{"args":{"Login":"%s","AppName":"%s"}}`, login.Name, login.AppQName)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.ResetTOTP", body, httpu.Expect403())
+
+		sysPrn := vit.GetSystemPrincipal(istructs.AppQName_sys_registry)
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.ResetTOTP", body, httpu.WithAuthorizeBy(sysPrn.Token))
+
+		principalToken, challengeToken := issuePrincipalToken()
+		require.NotEmpty(principalToken)
+		require.Empty(challengeToken)
+
+		// could be enrolled again
+		vit.PostApp(istructs.AppQName_sys_registry, login.PseudoProfileWSID, "c.registry.InitiateTOTPEnrolment", enrolmentBody(`{"Password":"pwd"}`))
+	})
+}
+
+// RFC 6238 code as generated by authenticator apps
+func testTOTPCode(secret []byte, now time.Time) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(now.Unix()/30)) // nolint G115
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[offset:offset+4])&0x7fffffff)%1000000)
+}
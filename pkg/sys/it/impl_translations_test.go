@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/istructs"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+func TestBasicUsage_Translations(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	// translations are declared in the app1 schema by TRANSLATIONS statements
+
+	// builtin extensions use the application translations
+	userPrincipal := vit.GetPrincipal(istructs.AppQName_test1_app1, it.TestEmail)
+	body := fmt.Sprintf(`{"args":{"Entity":"%s","Field":"EmailField","Email":"%s","TargetWSID":%d,"Language":"de-AT"},
+		"elements":[{"fields":["VerificationToken"]}]}`, it.QNameApp1_TestEmailVerificationDoc, it.TestEmail, userPrincipal.ProfileWSID)
+	vit.PostProfile(userPrincipal, "q.sys.InitiateEmailVerification", body)
+	email := vit.CaptureEmail()
+	require.Equal("Ihr Bestätigungscode", email.Subject)
+	require.Contains(email.Body, "Hier ist Ihr Bestätigungscode")
+	require.Contains(email.Body, "Please, enter this code on") // not translated by the app and there is no builtin German translation
+
+	// error messages are translated to the language from Accept-Language header
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	expireDatetime := vit.Now().Add(time.Hour).UnixMilli()
+	body = fmt.Sprintf(`{"args":{"Roles":"app1pkg.LimitedAccessRole","MaxUses":0,"ExpireDatetime":%d}}`, expireDatetime)
+	vit.PostWS(ws, "c.sys.CreateInviteLink", body, httpu.WithHeaders(httpu.AcceptLanguage, "nl-BE,nl;q=0.9,en;q=0.8"),
+		it.Expect400("het maximale aantal keren dat de uitnodigingslink gebruikt kan worden moet positief zijn"))
+
+	vit.PostWS(ws, "c.sys.CreateInviteLink", body, httpu.WithHeaders(httpu.AcceptLanguage, "fr"),
+		it.Expect400("invite link max uses must be positive"))
+	vit.PostWS(ws, "c.sys.CreateInviteLink", body, it.Expect400("invite link max uses must be positive"))
+
+	// formatted error messages of the query processor are translated too
+	vit.PostWS(ws, "q.sys.Collection", `{"args":{"Schema":"app1pkg.articles"},"unknown":1}`,
+		httpu.WithHeaders(httpu.AcceptLanguage, "nl"), it.Expect400("onverwachte velden: unknown"))
+	vit.PostWS(ws, "q.sys.Collection", `{"args":{"Schema":"app1pkg.articles"},"unknown":1}`,
+		it.Expect400("unexpected field(s): unknown"))
+
+	// invite email template and subject are translated to the Language argument
+	inviteeEmail := fmt.Sprintf("testtranslations_%d@123.com", vit.NextNumber())
+	body = fmt.Sprintf(`{"args":{"Email":"%s","Roles":"%s","ExpireDatetime":%d,"EmailTemplate":"text:Invite to ${WSName}","EmailSubject":"you are invited","Language":"nl"}}`,
+		inviteeEmail, initialRoles, expireDatetime)
+	vit.PostWS(ws, "c.sys.InitiateInvitationByEMail", body)
+	inviteEmail := vit.CaptureEmail()
+	require.Equal("u bent uitgenodigd", inviteEmail.Subject)
+	require.Equal("Uitnodiging voor "+ws.Name, inviteEmail.Body)
+}
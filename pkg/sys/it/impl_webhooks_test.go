@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys/webhooks"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+type webhookDelivery struct {
+	Day        int32
+	ID         int64
+	Webhook    string
+	Status     int32
+	Attempts   int32
+	StatusCode int32
+	LastError  string
+	RetryID    int64
+}
+
+func TestWebhooks(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	receiver := it.TestWebhookReceiver()
+	receiver.StatusCode.Store(http.StatusInternalServerError)
+	defer receiver.StatusCode.Store(http.StatusOK)
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	str1 := vit.NextName()
+	body := fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"%s","Str1":"%s"}}]}`, it.QNameApp1_CDocWebhookTestDoc, str1)
+	docID := vit.PostWS(ws, "c.sys.CUD", body).NewID()
+
+	// the first attempt fails
+	req := waitForWebhookRequest(t, receiver.Requests)
+	require.Equal("application/json", req.Header.Get("Content-Type"))
+	require.Equal(it.QNameApp1_TestWebhook.String(), req.Header.Get(webhooks.Header_Webhook))
+	require.Equal(webhooks.Sign(req.Body, it.TestWebhookSecret), req.Header.Get(webhooks.Header_Signature))
+	envelope := map[string]interface{}{}
+	require.NoError(json.Unmarshal(req.Body, &envelope))
+	require.Equal(it.QNameApp1_TestWebhook.String(), envelope["Webhook"])
+	require.EqualValues(ws.WSID, envelope["WSID"])
+	require.Equal("sys.CUD", envelope["QName"])
+	require.NotZero(envelope["WLogOffset"])
+	cuds := envelope["CUDs"].([]interface{})
+	require.Len(cuds, 1)
+	cud := cuds[0].(map[string]interface{})
+	require.EqualValues(docID, cud["sys.ID"])
+	require.Equal(it.QNameApp1_CDocWebhookTestDoc.String(), cud["sys.QName"])
+	require.Equal(str1, cud["fields"].(map[string]interface{})["Str1"])
+
+	deliveryID := req.Header.Get(webhooks.Header_Delivery)
+	delivery := waitForWebhookDelivery(vit, ws, deliveryID, func(d webhookDelivery) bool { return d.Attempts == 1 })
+	require.Equal(webhooks.Status_Pending, delivery.Status)
+	require.Equal(int32(http.StatusInternalServerError), delivery.StatusCode)
+	require.NotEmpty(delivery.LastError)
+
+	// the next run of the sys.WebhookSender job delivers the event
+	receiver.StatusCode.Store(http.StatusOK)
+	vit.SchedulerTimeAdd(time.Minute)
+	req = waitForWebhookRequest(t, receiver.Requests)
+	require.Equal(deliveryID, req.Header.Get(webhooks.Header_Delivery))
+	require.Equal(webhooks.Sign(req.Body, it.TestWebhookSecret), req.Header.Get(webhooks.Header_Signature))
+	delivery = waitForWebhookDelivery(vit, ws, deliveryID, func(d webhookDelivery) bool { return d.Status != webhooks.Status_Pending })
+	require.Equal(webhooks.Status_Delivered, delivery.Status)
+	require.Equal(int32(2), delivery.Attempts)
+	require.Equal(int32(http.StatusOK), delivery.StatusCode)
+	require.Empty(delivery.LastError)
+
+	t.Run("redeliver", func(t *testing.T) {
+		vit.PostWS(ws, "c.sys.RedeliverWebhook", fmt.Sprintf(`{"args":{"Day":%d,"ID":%d}}`, delivery.Day, delivery.ID))
+		req := waitForWebhookRequest(t, receiver.Requests)
+		require.Equal(deliveryID, req.Header.Get(webhooks.Header_Delivery))
+		redelivered := waitForWebhookDelivery(vit, ws, deliveryID, func(d webhookDelivery) bool { return d.Attempts == 1 })
+		require.Equal(webhooks.Status_Delivered, redelivered.Status)
+	})
+
+	t.Run("400 on unknown delivery", func(t *testing.T) {
+		vit.PostWS(ws, "c.sys.RedeliverWebhook", fmt.Sprintf(`{"args":{"Day":%d,"ID":1}}`, delivery.Day),
+			it.Expect400("webhook delivery not found"))
+	})
+
+	t.Run("stale retry is ignored", func(t *testing.T) {
+		receiver.StatusCode.Store(http.StatusInternalServerError)
+		defer receiver.StatusCode.Store(http.StatusOK)
+		body := fmt.Sprintf(`{"cuds":[{"fields":{"sys.ID":1,"sys.QName":"%s","Str1":"%s"}}]}`, it.QNameApp1_CDocWebhookTestDoc, vit.NextName())
+		vit.PostWS(ws, "c.sys.CUD", body)
+		deliveryID := waitForWebhookRequest(t, receiver.Requests).Header.Get(webhooks.Header_Delivery)
+		failed := waitForWebhookDelivery(vit, ws, deliveryID, func(d webhookDelivery) bool { return d.Attempts == 1 })
+		require.NotZero(failed.RetryID)
+
+		// the redelivery schedules the new retry, the first one becomes stale
+		vit.PostWS(ws, "c.sys.RedeliverWebhook", fmt.Sprintf(`{"args":{"Day":%d,"ID":%d}}`, failed.Day, failed.ID))
+		waitForWebhookRequest(t, receiver.Requests)
+		redelivered := waitForWebhookDelivery(vit, ws, deliveryID, func(d webhookDelivery) bool { return d.RetryID != failed.RetryID })
+		require.Equal(int32(1), redelivered.Attempts)
+		require.NotZero(redelivered.RetryID)
+
+		// both retries are due, the stale one makes no attempt
+		vit.SchedulerTimeAdd(time.Minute)
+		waitForWebhookRequest(t, receiver.Requests)
+		retried := waitForWebhookDelivery(vit, ws, deliveryID, func(d webhookDelivery) bool { return d.Attempts == 2 })
+		require.Equal(webhooks.Status_Pending, retried.Status)
+		select {
+		case req := <-receiver.Requests:
+			t.Fatalf("unexpected attempt of delivery %s", req.Header.Get(webhooks.Header_Delivery))
+		case <-time.After(time.Second):
+		}
+
+		// make the delivery done, so its retries do not affect the other tests
+		receiver.StatusCode.Store(http.StatusOK)
+		vit.PostWS(ws, "c.sys.RedeliverWebhook", fmt.Sprintf(`{"args":{"Day":%d,"ID":%d}}`, failed.Day, failed.ID))
+		waitForWebhookRequest(t, receiver.Requests)
+		waitForWebhookDelivery(vit, ws, deliveryID, func(d webhookDelivery) bool { return d.Status == webhooks.Status_Delivered })
+	})
+}
+
+func waitForWebhookRequest(t *testing.T, requests chan it.WebhookRequest) it.WebhookRequest {
+	t.Helper()
+	select {
+	case req := <-requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook request is not received")
+	}
+	return it.WebhookRequest{}
+}
+
+// waits for the delivery that matches the condition
+func waitForWebhookDelivery(vit *it.VIT, ws *it.AppWorkspace, deliveryID string, cond func(webhookDelivery) bool) webhookDelivery {
+	vit.T.Helper()
+	return WaitForOutboundMessage(vit, webhooks.QNameViewWebhookDelivery, func(body string) *federation.FuncResponse {
+		return vit.PostWS(ws, "q.sys.SqlQuery", body)
+	}, func(d webhookDelivery) bool { return fmt.Sprint(d.ID) == deliveryID && cond(d) })
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package sys_it
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+	it "github.com/voedger/voedger/pkg/vit"
+)
+
+type wsTestMessage struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	ChannelID  string          `json:"channelId"`
+	Status     int             `json:"status"`
+	Body       json.RawMessage `json:"body"`
+	Projection json.RawMessage `json:"projection"`
+	Offset     istructs.Offset `json:"offset"`
+}
+
+func TestBasicUsage_WebSocket(t *testing.T) {
+	require := require.New(t)
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+	conn := dialWS(t, vit, ws.Owner.Token)
+	defer conn.Close()
+	msgs := receiveWSMessages(conn)
+
+	// channel is created on connect
+	msg := nextWSMessage(t, msgs, "channel")
+	require.NotEmpty(msg.ChannelID)
+
+	// subscribe
+	sendWSMessage(t, conn, fmt.Sprintf(`{"id":"1","type":"subscribe","subscriptions":[{"entity":"app1pkg.CategoryIdx","wsid":%d}]}`, ws.WSID))
+	msg = nextWSMessage(t, msgs, "response")
+	require.Equal("1", msg.ID)
+	require.Equal(http.StatusOK, msg.Status)
+
+	// execute the command over the same connection
+	sendWSMessage(t, conn, fmt.Sprintf(`{"id":"2","type":"request","method":"POST","path":"workspaces/%d/docs/app1pkg.category","body":{"name":"Awesome food"}}`, ws.WSID))
+	msg = nextWSMessage(t, msgs, "response")
+	require.Equal("2", msg.ID)
+	require.Equal(http.StatusCreated, msg.Status)
+	resp := map[string]any{}
+	require.NoError(json.Unmarshal(msg.Body, &resp))
+	offset := istructs.Offset(resp["currentWLogOffset"].(float64))
+
+	// notification about the projection update
+	for {
+		msg = nextWSMessage(t, msgs, "notification")
+		projectionKey := in10n.ProjectionKey{}
+		require.NoError(json.Unmarshal(msg.Projection, &projectionKey))
+		if projectionKey.Projection == in10n.QNameHeartbeat30 {
+			continue
+		}
+		require.Equal("app1pkg.CategoryIdx", projectionKey.Projection.String())
+		require.Equal(ws.WSID, projectionKey.WS)
+		if msg.Offset == offset {
+			break
+		}
+	}
+
+	// query over the same connection
+	sendWSMessage(t, conn, fmt.Sprintf(`{"id":"3","type":"request","method":"GET","path":"workspaces/%d/docs/app1pkg.category/%d"}`,
+		ws.WSID, int(resp["newIDs"].(map[string]any)["1"].(float64))))
+	msg = nextWSMessage(t, msgs, "response")
+	require.Equal("3", msg.ID)
+	require.Equal(http.StatusOK, msg.Status)
+	require.Contains(string(msg.Body), `"name":"Awesome food"`)
+
+	// unsubscribe
+	sendWSMessage(t, conn, fmt.Sprintf(`{"id":"4","type":"unsubscribe","subscriptions":[{"entity":"app1pkg.CategoryIdx","wsid":%d}]}`, ws.WSID))
+	msg = nextWSMessage(t, msgs, "response")
+	require.Equal("4", msg.ID)
+	require.Equal(http.StatusOK, msg.Status)
+
+	// errors are replied, the connection stays open
+	cases := map[string]struct {
+		msg            string
+		expectedStatus int
+	}{
+		"not a json":             {`{"id":"e1"`, http.StatusBadRequest},
+		"unknown message type":   {`{"id":"e1","type":"unknown"}`, http.StatusBadRequest},
+		"no subscriptions":       {`{"id":"e1","type":"subscribe"}`, http.StatusBadRequest},
+		"path out of workspaces": {`{"id":"e1","type":"request","method":"GET","path":"notifications"}`, http.StatusBadRequest},
+		"path traversal":         {fmt.Sprintf(`{"id":"e1","type":"request","method":"GET","path":"workspaces/%d/../../../ws"}`, ws.WSID), http.StatusBadRequest},
+		"unknown path":           {fmt.Sprintf(`{"id":"e1","type":"request","method":"GET","path":"workspaces/%d/unknown"}`, ws.WSID), http.StatusNotFound},
+		"wrong view":             {fmt.Sprintf(`{"id":"e1","type":"subscribe","subscriptions":[{"entity":"app1pkg.unknown","wsid":%d}]}`, ws.WSID), http.StatusBadRequest},
+	}
+	for name, c := range cases {
+		sendWSMessage(t, conn, c.msg)
+		msg := nextWSMessage(t, msgs, "response")
+		require.Equal(c.expectedStatus, msg.Status, name, string(msg.Body))
+	}
+}
+
+func TestWebSocketAuth(t *testing.T) {
+	vit := it.NewVIT(t, &it.SharedConfig_App1)
+	defer vit.TearDown()
+
+	t.Run("401 no token", func(t *testing.T) {
+		vit.GET("api/v2/apps/test1/app1/ws", httpu.Expect401())
+	})
+
+	t.Run("401 wrong token", func(t *testing.T) {
+		vit.GET("api/v2/apps/test1/app1/ws", httpu.WithAuthorizeBy("wrong"), httpu.Expect401())
+	})
+
+	t.Run("403 on request to the foreign workspace", func(t *testing.T) {
+		ws := vit.WS(istructs.AppQName_test1_app1, "test_ws")
+		prn := vit.GetPrincipal(istructs.AppQName_test1_app1, it.TestEmail2)
+		conn := dialWS(t, vit, prn.Token)
+		defer conn.Close()
+		msgs := receiveWSMessages(conn)
+		nextWSMessage(t, msgs, "channel")
+
+		sendWSMessage(t, conn, fmt.Sprintf(`{"id":"1","type":"request","method":"POST","path":"workspaces/%d/docs/app1pkg.category","body":{"name":"Awesome food"}}`, ws.WSID))
+		msg := nextWSMessage(t, msgs, "response")
+		require.Equal(t, http.StatusForbidden, msg.Status)
+	})
+}
+
+func dialWS(t *testing.T, vit *it.VIT, token string) *websocket.Conn {
+	t.Helper()
+	url := strings.Replace(vit.URLStr(), "http", "ws", 1) + "/api/v2/apps/test1/app1/ws"
+	config, err := websocket.NewConfig(url, vit.URLStr())
+	require.NoError(t, err)
+	config.Header.Set(httpu.Authorization, httpu.BearerPrefix+token)
+	conn, err := websocket.DialConfig(config)
+	require.NoError(t, err)
+	return conn
+}
+
+func receiveWSMessages(conn *websocket.Conn) chan wsTestMessage {
+	msgs := make(chan wsTestMessage, 100)
+	go func() {
+		defer close(msgs)
+		for {
+			msg := wsTestMessage{}
+			if err := websocket.JSON.Receive(conn, &msg); err != nil {
+				return
+			}
+			msgs <- msg
+		}
+	}()
+	return msgs
+}
+
+func sendWSMessage(t *testing.T, conn *websocket.Conn, msg string) {
+	t.Helper()
+	require.NoError(t, websocket.Message.Send(conn, msg))
+}
+
+// skips heartbeats if the notification is not expected
+func nextWSMessage(t *testing.T, msgs chan wsTestMessage, expectedType string) wsTestMessage {
+	t.Helper()
+	for {
+		select {
+		case msg, ok := <-msgs:
+			require.True(t, ok, "connection is closed")
+			if msg.Type == "notification" && expectedType != "notification" {
+				continue
+			}
+			require.Equal(t, expectedType, msg.Type)
+			return msg
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for", expectedType)
+		}
+	}
+}
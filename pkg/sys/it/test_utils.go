@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -19,6 +20,7 @@ import (
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/sys/invite"
+	"github.com/voedger/voedger/pkg/sys/outbound"
 	it "github.com/voedger/voedger/pkg/vit"
 )
 
@@ -132,3 +134,29 @@ func FindCDocJoinedWorkspaceByInvitingWorkspaceWSIDAndLogin(vit *it.VIT, invitin
 		wsName:                resp.SectionRow()[wsNameIdx].(string),
 	}
 }
+
+// WaitForOutboundMessage waits for the message of the view partitioned by outbound.Day that matches the condition
+// query is called with the body of q.sys.SqlQuery
+func WaitForOutboundMessage[T any](vit *it.VIT, view appdef.QName, query func(body string) *federation.FuncResponse, match func(T) bool) T {
+	vit.T.Helper()
+	start := time.Now()
+	for time.Since(start) < 5*time.Second {
+		// check yesterday too to not to fail at midnight
+		today := outbound.Day(vit.Now())
+		for day := today - 1; day <= today; day++ {
+			body := fmt.Sprintf(`{"args":{"Query":"select * from %s where Day = %d"},"elements":[{"fields":["Result"]}]}`, view, day)
+			resp := query(body)
+			for i := 0; i < resp.NumRows(); i++ {
+				var msg T
+				require.NoError(vit.T, json.Unmarshal([]byte(resp.SectionRow(i)[0].(string)), &msg))
+				if match(msg) {
+					return msg
+				}
+			}
+		}
+		time.Sleep(awaitTime)
+	}
+	vit.T.Fatal("message of", view, "is not found")
+	var msg T
+	return msg
+}
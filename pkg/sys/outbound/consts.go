@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package outbound
+
+// statuses of the outbound message
+const (
+	Status_Pending   = int32(1)
+	Status_Succeeded = int32(2)
+	Status_Failed    = int32(3)
+)
+
+// failed attempts are retried by a job that runs every minute, so the backoff is measured in its runs
+const (
+	FirstRetryInRuns = 1
+	MaxRetryInRuns   = 60
+	MaxAttempts      = 10
+	LastErrorMaxLen  = 1024
+)
+
+// fields of the views that index the messages to be retried, PRIMARY KEY ((Day), ID)
+const (
+	Field_Day     = "Day"
+	Field_ID      = "ID"
+	Field_RetryIn = "RetryIn"
+)
+
+const secondsPerDay = 24 * 60 * 60
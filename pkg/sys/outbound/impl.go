@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package outbound
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+// Returns the number of days since the Unix epoch, outbound messages are partitioned by it
+func Day(t time.Time) int32 {
+	return int32(t.Unix() / secondsPerDay) // nolint G115: days since the Unix epoch fit int32
+}
+
+// Registers the result of the attempt, the backoff is doubled on each failed one.
+// retriable is false -> the message is failed at once.
+//
+// Returns the number of job runs before the next attempt, 0 -> no more attempts
+func (a *Attempts) Register(attemptErr error, retriable bool) (retryIn int32) {
+	a.Count++
+	if attemptErr == nil {
+		a.Status = Status_Succeeded
+		a.LastError = ""
+		return 0
+	}
+	a.LastError = Truncate(attemptErr.Error(), LastErrorMaxLen)
+	if !retriable || a.Count >= MaxAttempts {
+		a.Status = Status_Failed
+		return 0
+	}
+	a.Status = Status_Pending
+	return min(int32(FirstRetryInRuns)<<(a.Count-1), MaxRetryInRuns)
+}
+
+// Cuts s to maxLen bytes keeping the last UTF-8 rune whole
+func Truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	for maxLen > 0 && !utf8.RuneStart(s[maxLen]) {
+		maxLen--
+	}
+	return s[:maxLen]
+}
+
+// Calls cb for each message of the retries view that is still to be retried.
+// RetryIn of the view is the number of job runs left before the next attempt, 0 -> not pending anymore.
+//
+// Messages are retried for a few hours, so the entries of today and yesterday only are read
+func ReadRetries(st istructs.IState, view appdef.QName, now time.Time, cb func(day int32, id int64, retryIn int32, value istructs.IStateValue) error) error {
+	today := Day(now)
+	for day := today - 1; day <= today; day++ {
+		kb, err := st.KeyBuilder(sys.Storage_View, view)
+		if err != nil {
+			// notest
+			return err
+		}
+		kb.PutInt32(Field_Day, day)
+		err = st.Read(kb, func(key istructs.IKey, value istructs.IStateValue) error {
+			if retryIn := value.AsInt32(Field_RetryIn); retryIn > 0 {
+				return cb(day, key.AsInt64(Field_ID), retryIn, value)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Puts the number of job runs before the next attempt to the retries view, 0 -> the message is not retried anymore.
+// wsid is NullWSID -> the view of the current workspace
+func PutRetry(st istructs.IState, intents istructs.IIntents, view appdef.QName, wsid istructs.WSID, day int32, id int64, retryIn int32) (istructs.IStateValueBuilder, error) {
+	kb, err := st.KeyBuilder(sys.Storage_View, view)
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	if wsid != istructs.NullWSID {
+		kb.PutInt64(sys.Storage_View_Field_WSID, int64(wsid)) // nolint G115
+	}
+	kb.PutInt32(Field_Day, day)
+	kb.PutInt64(Field_ID, id)
+	vb, err := intents.NewValue(kb)
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	vb.PutInt32(Field_RetryIn, retryIn)
+	return vb, nil
+}
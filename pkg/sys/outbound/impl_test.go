@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package outbound
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttempts_Register(t *testing.T) {
+	require := require.New(t)
+	errAttempt := errors.New("connection refused")
+
+	t.Run("backoff is doubled up to the max", func(t *testing.T) {
+		a := Attempts{Status: Status_Pending}
+		expectedRetryIns := []int32{1, 2, 4, 8, 16, 32, 60, 60, 60}
+		for i, expected := range expectedRetryIns {
+			require.Equal(expected, a.Register(errAttempt, true))
+			require.Equal(int32(i+1), a.Count)
+			require.Equal(Status_Pending, a.Status)
+			require.Equal(errAttempt.Error(), a.LastError)
+		}
+		require.Zero(a.Register(errAttempt, true))
+		require.Equal(int32(MaxAttempts), a.Count)
+		require.Equal(Status_Failed, a.Status)
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		a := Attempts{Status: Status_Pending}
+		a.Register(errAttempt, true)
+		require.Zero(a.Register(nil, true))
+		require.Equal(Status_Succeeded, a.Status)
+		require.Equal(int32(2), a.Count)
+		require.Empty(a.LastError)
+	})
+
+	t.Run("not retriable message is failed at once", func(t *testing.T) {
+		a := Attempts{Status: Status_Pending}
+		require.Zero(a.Register(errAttempt, false))
+		require.Equal(Status_Failed, a.Status)
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	require := require.New(t)
+	require.Equal("abc", Truncate("abc", 3))
+	require.Equal("ab", Truncate("abc", 2))
+	require.Equal("a", Truncate("aпривет", 2)) // 2nd byte is in the middle of the rune
+	require.Empty(Truncate("привет", 1))
+}
+
+func TestDay(t *testing.T) {
+	require := require.New(t)
+	require.Zero(Day(time.Unix(0, 0)))
+	require.Equal(int32(1), Day(time.Unix(secondsPerDay, 0)))
+	require.Equal(int32(1), Day(time.Unix(2*secondsPerDay-1, 0)))
+}
@@ -0,0 +1,12 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package outbound
+
+// Attempts made to send the outbound message
+type Attempts struct {
+	Status    int32
+	Count     int32
+	LastError string
+}
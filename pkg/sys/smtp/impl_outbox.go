@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package smtp
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/outbound"
+)
+
+// the message enqueued by sys.SendMail storage is stored in the outbox, the pending one is scheduled to be retried
+// works in the application workspace
+func syncProjectorOutboxEmail() istructs.Projector {
+	return istructs.Projector{
+		Name: sys.QNameProjectorOutboxEmail,
+		Func: func(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) error {
+			args := event.ArgumentObject()
+			day := outbound.Day(time.UnixMilli(int64(event.RegisteredAt())))
+			id := int64(event.WLogOffset()) // nolint G115
+			kb, err := outboxEmailKey(st, day, id)
+			if err != nil {
+				// notest
+				return err
+			}
+			vb, err := intents.NewValue(kb)
+			if err != nil {
+				// notest
+				return err
+			}
+			status := args.AsInt32(sys.OutboxEmail_Field_Status)
+			vb.PutInt32(sys.OutboxEmail_Field_Status, status)
+			vb.PutInt32(sys.OutboxEmail_Field_Attempts, args.AsInt32(sys.OutboxEmail_Field_Attempts))
+			vb.PutString(sys.OutboxEmail_Field_LastError, args.AsString(sys.OutboxEmail_Field_LastError))
+			vb.PutString(sys.OutboxEmail_Field_Subject, args.AsString(sys.OutboxEmail_Field_Subject))
+			vb.PutString(sys.OutboxEmail_Field_Recipients, args.AsString(sys.OutboxEmail_Field_Recipients))
+			vb.PutString(sys.OutboxEmail_Field_Message, args.AsString(sys.OutboxEmail_Field_Message))
+			if status != sys.OutboxEmail_Status_Pending {
+				return nil
+			}
+			_, err = outbound.PutRetry(st, intents, sys.QNameViewOutboxRetry, istructs.NullWSID, day, id, args.AsInt32(sys.OutboxEmail_Field_RetryIn))
+			return err
+		},
+	}
+}
+
+// works in each application workspace, reads the messages to be retried only
+// the message is sent by sys.SendMail storage if its time has come, otherwise the remaining number of runs is decreased
+func outboxSenderJob(tm timeu.ITime) func(st istructs.IState, intents istructs.IIntents) error {
+	return func(st istructs.IState, intents istructs.IIntents) error {
+		return outbound.ReadRetries(st, sys.QNameViewOutboxRetry, tm.Now(), func(day int32, id int64, retryIn int32, _ istructs.IStateValue) error {
+			if retryIn > 1 {
+				_, err := outbound.PutRetry(st, intents, sys.QNameViewOutboxRetry, istructs.NullWSID, day, id, retryIn-1)
+				return err
+			}
+			retryIn, err := retryOutboxEmail(st, intents, day, id)
+			if err != nil {
+				return err
+			}
+			_, err = outbound.PutRetry(st, intents, sys.QNameViewOutboxRetry, istructs.NullWSID, day, id, retryIn)
+			return err
+		})
+	}
+}
+
+// the attempt is registered by outbound.Attempts.Register
+func retryOutboxEmail(st istructs.IState, intents istructs.IIntents, day int32, id int64) (retryIn int32, err error) {
+	kbEmail, err := outboxEmailKey(st, day, id)
+	if err != nil {
+		// notest
+		return 0, err
+	}
+	svEmail, ok, err := st.CanExist(kbEmail)
+	if err != nil || !ok || svEmail.AsInt32(sys.OutboxEmail_Field_Status) != sys.OutboxEmail_Status_Pending {
+		return 0, err
+	}
+
+	kbSendMail, err := st.KeyBuilder(sys.Storage_SendMail, appdef.NullQName)
+	if err != nil {
+		// notest
+		return 0, err
+	}
+	kbSendMail.PutInt32(sys.Storage_SendMail_Field_OutboxDay, day)
+	kbSendMail.PutInt64(sys.Storage_SendMail_Field_OutboxID, id)
+	svSendMail, err := st.MustExist(kbSendMail)
+	if err != nil {
+		return 0, err
+	}
+	var sendErr error
+	if !svSendMail.AsBool(sys.Storage_SendMail_Field_Success) {
+		sendErr = errors.New(svSendMail.AsString(sys.Storage_SendMail_Field_ErrorMessage))
+	}
+
+	attempts := outbound.Attempts{
+		Status:    svEmail.AsInt32(sys.OutboxEmail_Field_Status),
+		Count:     svEmail.AsInt32(sys.OutboxEmail_Field_Attempts),
+		LastError: svEmail.AsString(sys.OutboxEmail_Field_LastError),
+	}
+	retryIn = attempts.Register(sendErr, true)
+	if sendErr != nil {
+		logger.Verbose(fmt.Sprintf("outbox mail %d/%d attempt %d failed: %s", day, id, attempts.Count, sendErr))
+	}
+	svbEmail, err := intents.UpdateValue(kbEmail, svEmail)
+	if err != nil {
+		// notest
+		return 0, err
+	}
+	svbEmail.PutInt32(sys.OutboxEmail_Field_Status, attempts.Status)
+	svbEmail.PutInt32(sys.OutboxEmail_Field_Attempts, attempts.Count)
+	svbEmail.PutString(sys.OutboxEmail_Field_LastError, attempts.LastError)
+	return retryIn, nil
+}
+
+func outboxEmailKey(st istructs.IState, day int32, id int64) (istructs.IStateKeyBuilder, error) {
+	kb, err := st.KeyBuilder(sys.Storage_View, sys.QNameViewOutboxEmail)
+	if err != nil {
+		return nil, err
+	}
+	kb.PutInt32(sys.OutboxEmail_Field_Day, day)
+	kb.PutInt64(sys.OutboxEmail_Field_ID, id)
+	return kb, nil
+}
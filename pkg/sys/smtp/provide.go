@@ -0,0 +1,21 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package smtp
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructsmem"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func ProvideOutbox(sr istructsmem.IStatelessResources, time timeu.ITime) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(sys.QNameCmdEnqueueOutboxEmail, istructsmem.NullCommandExec))
+	sr.AddProjectors(appdef.SysPackagePath, syncProjectorOutboxEmail())
+	sr.AddJobs(appdef.SysPackagePath, istructsmem.BuiltinJob{
+		Name: sys.QNameJobOutboxSender,
+		Func: outboxSenderJob(time),
+	})
+}
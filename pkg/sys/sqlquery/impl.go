@@ -23,6 +23,7 @@ import (
 	"github.com/voedger/voedger/pkg/goutils/jsonu"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/goutils/strconvu"
+	"github.com/voedger/voedger/pkg/iauthnzimpl"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
@@ -78,8 +79,9 @@ func provideExecQrySQLQuery(federation federation.IFederation, itokens itokens.I
 			}
 
 			tokenForTargetApp := subj.AsString(sys.Storage_RequestSubject_Field_Token)
-			if targetAppQName != args.State.App() {
+			if targetAppQName != args.State.App() && !iauthnzimpl.IsAPIKey(tokenForTargetApp) {
 				// query is for a foreign app -> re-issue token for the target app
+				// API key is forwarded as is, it is checked by the authenticator of the target app
 				var pp payloads.PrincipalPayload
 				if _, err = itokens.ValidateToken(tokenForTargetApp, &pp); err != nil {
 					// notest: validated already by the processor
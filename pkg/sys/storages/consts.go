@@ -5,12 +5,27 @@
 package storages
 
 import (
+	"regexp"
 	"time"
 )
 
 const (
 	defaultHTTPClientTimeout              = 20_000 * time.Millisecond
+	maxHTTPRedirects                      = 10 // as by http.Client by default
 	httpStorageKeyBuilderStringerSliceCap = 3
 	field_WSKind                          = "WSKind"
 	wsidTypeValidatorCacheSize            = 100
+
+	// secret with SMTP settings of the certain application is read by name "smtp-<owner>-<app>"
+	appSMTPSettingsSecretNamePrefix = "smtp-"
+	// sizes of sys.OutboxEmail fields
+	outboxSubjectMaxLen    = 1024
+	outboxRecipientsMaxLen = 1024
 )
+
+// header value of the Http storage request can refer to the application secret as {{secret:name}},
+// the secret is injected by the host so it is never visible to the extension
+var httpSecretPlaceholder = regexp.MustCompile(`\{\{secret:([^{}]+)\}\}`)
+
+// requests of the Http storage denied by the egress policy of the application
+const Metric_HTTPEgressDeniedTotal = "voedger_http_egress_denied_total"
@@ -99,3 +99,25 @@ var errCommandNotSpecified = errors.New("command not specified")
 var errOwnerRecordNotSpecified = errors.New("owner record not specified")
 var errOwnerRecordFieldNotSpecified = errors.New("owner record field not specified")
 var errOwnerIDNotSpecified = errors.New("owner ID not specified")
+var errBLOBReaderNotAvailable = errors.New("BLOB reader is not available to read attachments")
+var errOutboxMessageNotFound = errors.New("outbox message not found")
+var errHTTPTooManyRedirects = errors.New("too many redirects")
+
+// request of the Http storage is denied by the egress policy of the application
+var ErrEgressDenied = errors.New("egress denied")
+
+func errEgressTargetNotAllowed(scheme, host string) error {
+	return fmt.Errorf("%s://%s is not allowed", scheme, host)
+}
+
+func errEgressRequestBodyTooLarge(size int, maxSize uint64) error {
+	return fmt.Errorf("request body size %d exceeds %d bytes", size, maxSize)
+}
+
+func errEgressResponseBodyTooLarge(maxSize uint64) error {
+	return fmt.Errorf("response body exceeds %d bytes", maxSize)
+}
+
+func errEgressSecretNotAllowed(secret, host string) error {
+	return fmt.Errorf("secret %s is not allowed to be sent to %s", secret, host)
+}
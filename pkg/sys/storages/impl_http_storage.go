@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -18,6 +20,7 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/sys"
@@ -26,12 +29,19 @@ import (
 var requestNumber atomic.Uint64
 
 type httpStorage struct {
-	httpClient httpu.IHTTPClient
+	httpClient     httpu.IHTTPClient
+	appStructsFunc state.AppStructsFunc
+	secretReader   isecrets.ISecretReader
+	egressDenied   state.EgressDeniedHandler
 }
 
-func NewHTTPStorage(httpClient httpu.IHTTPClient) state.IStateStorage {
+func NewHTTPStorage(httpClient httpu.IHTTPClient, appStructsFunc state.AppStructsFunc, secretReader isecrets.ISecretReader,
+	egressDenied state.EgressDeniedHandler) state.IStateStorage {
 	return &httpStorage{
-		httpClient: httpClient,
+		httpClient:     httpClient,
+		appStructsFunc: appStructsFunc,
+		secretReader:   secretReader,
+		egressDenied:   egressDenied,
 	}
 }
 
@@ -43,6 +53,7 @@ type httpStorageKeyBuilder struct {
 	body         []byte
 	headers      map[string]string
 	handleErrors bool
+	noRetries    bool
 }
 
 func (b *httpStorageKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
@@ -60,6 +71,9 @@ func (b *httpStorageKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
 	if b.handleErrors != kb.handleErrors {
 		return false
 	}
+	if b.noRetries != kb.noRetries {
+		return false
+	}
 	if b.url != kb.url {
 		return false
 	}
@@ -104,6 +118,8 @@ func (b *httpStorageKeyBuilder) PutBool(name string, value bool) {
 	switch name {
 	case sys.Storage_HTTP_Field_HandleErrors:
 		b.handleErrors = value
+	case sys.Storage_HTTP_Field_NoRetries:
+		b.noRetries = value
 	default:
 		b.baseKeyBuilder.PutBool(name, value)
 	}
@@ -144,15 +160,44 @@ func (s *httpStorage) Read(key istructs.IStateKeyBuilder, callback istructs.Valu
 	if kb.method != "" {
 		method = kb.method
 	}
-	opts := []httpu.ReqOptFunc{httpu.WithMethod(method)}
-	for k, v := range kb.headers {
+
+	errorResult := func(err error) error {
+		if !kb.handleErrors {
+			return err
+		}
+		return callback(nil, &httpValue{
+			error: err.Error(),
+			body:  []byte(err.Error()),
+		})
+	}
+
+	policy := s.appStructsFunc().AppDef().EgressPolicy()
+	headers, secretHeaders, err := s.checkEgress(policy, kb)
+	if err != nil {
+		if errors.Is(err, ErrEgressDenied) {
+			s.denied(method, kb.url, err)
+		}
+		return errorResult(err)
+	}
+
+	opts := []httpu.ReqOptFunc{httpu.WithMethod(method), httpu.WithCheckRedirect(checkEgressRedirect(policy, secretHeaders))}
+	for k, v := range headers {
 		opts = append(opts, httpu.WithHeaders(k, v))
 	}
+	if kb.noRetries {
+		opts = append(opts, httpu.WithNoRetryPolicy())
+	}
+	if policy != nil && policy.MaxBodySize() > 0 {
+		opts = append(opts, httpu.WithMaxResponseBodySize(int64(policy.MaxBodySize()))) // nolint G115
+	}
 
 	timeout := defaultHTTPClientTimeout
 	if kb.timeout != 0 {
 		timeout = kb.timeout
 	}
+	if policy != nil && policy.MaxTimeout() > 0 && timeout > policy.MaxTimeout() {
+		timeout = policy.MaxTimeout()
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -161,16 +206,6 @@ func (s *httpStorage) Read(key istructs.IStateKeyBuilder, callback istructs.Valu
 		body = bytes.NewReader(kb.body)
 	}
 
-	errorResult := func(err error) error {
-		if !kb.handleErrors {
-			return err
-		}
-		return callback(nil, &httpValue{
-			error: err.Error(),
-			body:  []byte(err.Error()),
-		})
-	}
-
 	var reqNumber uint64
 	if logger.IsVerbose() {
 		reqNumber = requestNumber.Add(1)
@@ -178,6 +213,12 @@ func (s *httpStorage) Read(key istructs.IStateKeyBuilder, callback istructs.Valu
 	}
 
 	resp, err := s.httpClient.ReqReader(ctx, kb.url, body, opts...)
+	if errors.Is(err, httpu.ErrResponseBodyTooLarge) {
+		err = fmt.Errorf("%w: %w", ErrEgressDenied, errEgressResponseBodyTooLarge(policy.MaxBodySize()))
+	}
+	if errors.Is(err, ErrEgressDenied) {
+		s.denied(method, kb.url, err)
+	}
 	if err != nil && !errors.Is(err, httpu.ErrUnexpectedStatusCode) {
 		return errorResult(err)
 	}
@@ -193,6 +234,76 @@ func (s *httpStorage) Read(key istructs.IStateKeyBuilder, callback istructs.Valu
 	})
 }
 
+// checks the request by the egress policy of the application and returns request headers with injected secrets
+// and the names of these headers.
+//
+// If the application has no egress policy, then any request is allowed, but secrets can not be injected
+func (s *httpStorage) checkEgress(policy appdef.IEgressPolicy, kb *httpStorageKeyBuilder) (headers map[string]string, secretHeaders []string, err error) {
+	u, err := url.Parse(kb.url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rule appdef.IEgressRule
+	if policy != nil {
+		if rule = policy.Rule(u.Scheme, u.Hostname()); rule == nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrEgressDenied, errEgressTargetNotAllowed(u.Scheme, u.Hostname()))
+		}
+		if policy.MaxBodySize() > 0 && uint64(len(kb.body)) > policy.MaxBodySize() {
+			return nil, nil, fmt.Errorf("%w: %w", ErrEgressDenied, errEgressRequestBodyTooLarge(len(kb.body), policy.MaxBodySize()))
+		}
+	}
+
+	headers = make(map[string]string, len(kb.headers))
+	for k, v := range kb.headers {
+		matches := httpSecretPlaceholder.FindAllStringSubmatch(v, -1)
+		for _, m := range matches {
+			secretName := m[1]
+			if rule == nil || !slices.Contains(rule.Secrets(), secretName) {
+				return nil, nil, fmt.Errorf("%w: %w", ErrEgressDenied, errEgressSecretNotAllowed(secretName, u.Hostname()))
+			}
+			secret, err := s.secretReader.ReadSecret(secretName)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read secret %s: %w", secretName, err)
+			}
+			v = strings.ReplaceAll(v, m[0], string(secret))
+		}
+		if len(matches) > 0 {
+			secretHeaders = append(secretHeaders, k)
+		}
+		headers[k] = v
+	}
+	return headers, secretHeaders, nil
+}
+
+// each redirect target is checked by the egress policy as the request itself,
+// headers with injected secrets are not sent to the other host
+func checkEgressRedirect(policy appdef.IEgressPolicy, secretHeaders []string) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxHTTPRedirects {
+			return errHTTPTooManyRedirects
+		}
+		if policy != nil && policy.Rule(req.URL.Scheme, req.URL.Hostname()) == nil {
+			return fmt.Errorf("%w: redirect: %w", ErrEgressDenied, errEgressTargetNotAllowed(req.URL.Scheme, req.URL.Hostname()))
+		}
+		if req.URL.Host != via[0].URL.Host {
+			for _, h := range secretHeaders {
+				req.Header.Del(h)
+			}
+		}
+		return nil
+	}
+}
+
+// denied requests are logged and counted
+func (s *httpStorage) denied(method, url string, err error) {
+	app := s.appStructsFunc().AppQName()
+	logger.Warning(fmt.Sprintf("%s: %s %s: %v", app, method, url, err))
+	if s.egressDenied != nil {
+		s.egressDenied(app)
+	}
+}
+
 type httpValue struct {
 	istructs.IStateValue
 	body       []byte
@@ -5,26 +5,37 @@
 package storages
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/sys"
 )
 
+func httpAppStructsFunc(adb appdef.IAppDefBuilder) state.AppStructsFunc {
+	appStructs := &mockAppStructs{}
+	appStructs.On("AppDef").Return(adb.AppDef())
+	appStructs.On("AppQName").Return(istructs.AppQName_test1_app1)
+	return func() istructs.IAppStructs { return appStructs }
+}
+
 func TestHTTPStorage_BasicUsage(t *testing.T) {
 	require := require.New(t)
 	httpClient, cleanup := httpu.NewIHTTPClient()
 	defer cleanup()
-	storage := NewHTTPStorage(httpClient)
+	storage := NewHTTPStorage(httpClient, httpAppStructsFunc(builder.New()), nil, nil)
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		require.Equal(http.MethodPost, r.Method)
 		require.Equal("my-value", r.Header.Get("my-header"))
@@ -59,7 +70,7 @@ func TestHTTPStorage_Timeout(t *testing.T) {
 		require := require.New(t)
 		httpClient, cleanup := httpu.NewIHTTPClient()
 		defer cleanup()
-		storage := NewHTTPStorage(httpClient)
+		storage := NewHTTPStorage(httpClient, httpAppStructsFunc(builder.New()), nil, nil)
 		k := storage.NewKeyBuilder(appdef.NullQName, nil)
 		err := storage.(state.IWithRead).Read(k, func(istructs.IKey, istructs.IStateValue) error { return nil })
 		require.ErrorIs(err, ErrNotFound)
@@ -68,7 +79,7 @@ func TestHTTPStorage_Timeout(t *testing.T) {
 		require := require.New(t)
 		httpClient, cleanup := httpu.NewIHTTPClient()
 		defer cleanup()
-		storage := NewHTTPStorage(httpClient)
+		storage := NewHTTPStorage(httpClient, httpAppStructsFunc(builder.New()), nil, nil)
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			time.Sleep(time.Millisecond * 20)
 		}))
@@ -83,7 +94,7 @@ func TestHTTPStorage_Timeout(t *testing.T) {
 		require := require.New(t)
 		httpClient, cleanup := httpu.NewIHTTPClient()
 		defer cleanup()
-		storage := NewHTTPStorage(httpClient)
+		storage := NewHTTPStorage(httpClient, httpAppStructsFunc(builder.New()), nil, nil)
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			time.Sleep(time.Millisecond * 20)
 		}))
@@ -99,6 +110,160 @@ func TestHTTPStorage_Timeout(t *testing.T) {
 		require.NoError(err)
 	})
 }
+func TestHTTPStorage_NoRetries(t *testing.T) {
+	require := require.New(t)
+	httpClient, cleanup := httpu.NewIHTTPClient()
+	defer cleanup()
+	storage := NewHTTPStorage(httpClient, httpAppStructsFunc(builder.New()), nil, nil)
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	k := storage.NewKeyBuilder(appdef.NullQName, nil)
+	k.PutString(sys.Storage_HTTP_Field_URL, ts.URL)
+	k.PutBool(sys.Storage_HTTP_Field_NoRetries, true)
+	err := storage.(state.IWithRead).Read(k, func(_ istructs.IKey, v istructs.IStateValue) error {
+		require.Equal(int32(http.StatusServiceUnavailable), v.AsInt32(sys.Storage_HTTP_Field_StatusCode))
+		return nil
+	})
+	require.NoError(err)
+	require.Equal(1, requests)
+}
+
+func TestHTTPStorage_Egress(t *testing.T) {
+	require := require.New(t)
+	httpClient, cleanup := httpu.NewIHTTPClient()
+	defer cleanup()
+	echoKey := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("key:" + r.Header.Get("X-Api-Key")))
+	}
+	// the other host for the egress policy, since the port differs
+	otherTS := httptest.NewServer(http.HandlerFunc(echoKey))
+	defer otherTS.Close()
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large":
+			_, _ = w.Write([]byte("the response body is too large"))
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+		case "/key":
+			echoKey(w, r)
+		case "/redirect/same":
+			http.Redirect(w, r, "/key", http.StatusFound)
+		case "/redirect/other":
+			http.Redirect(w, r, otherTS.URL+"/key", http.StatusFound)
+		case "/redirect/denied":
+			http.Redirect(w, r, strings.Replace(ts.URL, "127.0.0.1", "localhost", 1)+"/key", http.StatusFound)
+		default:
+			_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+		}
+	}))
+	defer ts.Close()
+
+	adb := builder.New()
+	adb.AllowEgress("http", "127.0.0.1", "api-key").
+		SetEgressMaxBodySize(24).
+		SetEgressMaxTimeout(20 * time.Millisecond)
+	secretReader := &isecrets.SecretReaderMock{}
+	secretReader.On("ReadSecret", "api-key").Return([]byte("secret-value"), nil)
+	denied := []appdef.AppQName{}
+	storage := NewHTTPStorage(httpClient, httpAppStructsFunc(adb), secretReader, func(app appdef.AppQName) { denied = append(denied, app) })
+
+	read := func(url string, prepare ...func(istructs.IStateKeyBuilder)) (v istructs.IStateValue, err error) {
+		k := storage.NewKeyBuilder(appdef.NullQName, nil)
+		k.PutString(sys.Storage_HTTP_Field_URL, url)
+		k.PutBool(sys.Storage_HTTP_Field_NoRetries, true)
+		for _, p := range prepare {
+			p(k)
+		}
+		err = storage.(state.IWithRead).Read(k, func(_ istructs.IKey, value istructs.IStateValue) error {
+			v = value
+			return nil
+		})
+		return v, err
+	}
+
+	t.Run("Should inject secret into header", func(t *testing.T) {
+		v, err := read(ts.URL, func(k istructs.IStateKeyBuilder) {
+			k.PutString(sys.Storage_HTTP_Field_Header, "Authorization: Bearer {{secret:api-key}}")
+		})
+		require.NoError(err)
+		require.Equal("Bearer secret-value", v.AsString(sys.Storage_HTTP_Field_Body))
+	})
+
+	t.Run("Should deny", func(t *testing.T) {
+		denied = denied[:0]
+		for name, c := range map[string]struct {
+			url     string
+			prepare func(istructs.IStateKeyBuilder)
+			err     string
+		}{
+			"not allowed host":   {url: strings.Replace(ts.URL, "127.0.0.1", "localhost", 1), err: "http://localhost is not allowed"},
+			"not allowed scheme": {url: strings.Replace(ts.URL, "http:", "https:", 1), err: "https://127.0.0.1 is not allowed"},
+			"large request body": {url: ts.URL, prepare: func(k istructs.IStateKeyBuilder) {
+				k.PutBytes(sys.Storage_HTTP_Field_Body, []byte("the request body is too large"))
+			}, err: "request body size 29 exceeds 24 bytes"},
+			"large response body":          {url: ts.URL + "/large", err: "response body exceeds 24 bytes"},
+			"redirect to not allowed host": {url: ts.URL + "/redirect/denied", err: "redirect: http://localhost is not allowed"},
+			"not allowed secret": {url: ts.URL, prepare: func(k istructs.IStateKeyBuilder) {
+				k.PutString(sys.Storage_HTTP_Field_Header, "Authorization: Bearer {{secret:other-key}}")
+			}, err: "secret other-key is not allowed to be sent to 127.0.0.1"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				prepare := []func(istructs.IStateKeyBuilder){}
+				if c.prepare != nil {
+					prepare = append(prepare, c.prepare)
+				}
+				_, err := read(c.url, prepare...)
+				require.ErrorIs(err, ErrEgressDenied)
+				require.ErrorContains(err, c.err)
+			})
+		}
+		require.Len(denied, 6, "denied requests should be counted")
+		require.Equal(istructs.AppQName_test1_app1, denied[0])
+	})
+
+	t.Run("Should not send secrets to the other host on redirect", func(t *testing.T) {
+		withKey := func(k istructs.IStateKeyBuilder) {
+			k.PutString(sys.Storage_HTTP_Field_Header, "X-Api-Key: {{secret:api-key}}")
+		}
+		v, err := read(ts.URL+"/redirect/same", withKey)
+		require.NoError(err)
+		require.Equal("key:secret-value", string(v.AsBytes(sys.Storage_HTTP_Field_Body)))
+
+		v, err = read(ts.URL+"/redirect/other", withKey)
+		require.NoError(err)
+		require.Equal("key:", string(v.AsBytes(sys.Storage_HTTP_Field_Body)))
+	})
+
+	t.Run("Should return denial as error value if errors are handled", func(t *testing.T) {
+		v, err := read(ts.URL+"/large", func(k istructs.IStateKeyBuilder) {
+			k.PutBool(sys.Storage_HTTP_Field_HandleErrors, true)
+		})
+		require.NoError(err)
+		require.Contains(v.AsString(sys.Storage_HTTP_Field_Error), ErrEgressDenied.Error())
+	})
+
+	t.Run("Should limit timeout by ceiling", func(t *testing.T) {
+		_, err := read(ts.URL+"/slow", func(k istructs.IStateKeyBuilder) {
+			k.PutInt64(sys.Storage_HTTP_Field_HTTPClientTimeoutMilliseconds, 10_000)
+		})
+		require.ErrorIs(err, context.DeadlineExceeded)
+	})
+
+	t.Run("Should not inject secrets if there is no egress policy", func(t *testing.T) {
+		storage := NewHTTPStorage(httpClient, httpAppStructsFunc(builder.New()), secretReader, nil)
+		k := storage.NewKeyBuilder(appdef.NullQName, nil)
+		k.PutString(sys.Storage_HTTP_Field_URL, ts.URL)
+		k.PutString(sys.Storage_HTTP_Field_Header, "Authorization: Bearer {{secret:api-key}}")
+		err := storage.(state.IWithRead).Read(k, func(istructs.IKey, istructs.IStateValue) error { return nil })
+		require.ErrorIs(err, ErrEgressDenied)
+	})
+}
+
 func TestHTTPStorage_NewKeyBuilder_should_refresh_key_builder(t *testing.T) {
 	require := require.New(t)
 	s := &httpStorage{}
@@ -620,6 +620,12 @@ func (m *mockedStateValue) AsInt64(name appdef.FieldName) int64 {
 	switch t := m.TestObjects[0].Data[name].(type) {
 	case int:
 		return int64(t)
+	case int8:
+		return int64(t)
+	case int16:
+		return int64(t)
+	case int32:
+		return int64(t)
 	case int64:
 		return t
 	case istructs.Offset:
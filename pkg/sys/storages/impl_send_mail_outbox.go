@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/outbound"
+)
+
+type smtpSettings struct {
+	Host     string
+	Port     int32
+	Username string
+	Password string
+	From     string
+}
+
+// From of the message overrides From of the settings
+func (s smtpSettings) withFrom(from string) smtpSettings {
+	if from != "" {
+		s.From = from
+	}
+	return s
+}
+
+// persisted to sys.OutboxEmail.Message to retry sending
+// contains neither passwords nor BLOB contents: the password is read from PasswordSecret, attachments are read by BLOB IDs on each attempt
+type outboxMessage struct {
+	Subject        string
+	From           string
+	To             []string
+	CC             []string
+	BCC            []string
+	Body           string
+	TextBody       string
+	Host           string // empty -> per-app SMTP settings are used
+	Port           int32
+	Username       string
+	PasswordSecret string
+	WSID           istructs.WSID // workspace the attachments are read from
+	Attachments    []istructs.RecordID
+}
+
+type sendResult struct {
+	sendErr   error
+	willRetry bool
+}
+
+// sends the message and enqueues it to the outbox of the application workspace by c.sys.EnqueueOutboxEmail
+// the message is enqueued after the first attempt to not to race with sys.OutboxSender job: if VVM fails during the attempt
+// then the projector will handle the event again
+// the failed message is not retried if the literal Password is specified or if it is too long to be persisted
+func (s *sendMailStorage) enqueueAndSend(k *mailKeyBuilder) (res sendResult, err error) {
+	m := s.outboxMessage(k)
+	res.sendErr = s.sendMail(m, k.password)
+	if s.federationCommands == nil || s.wsidFunc == nil {
+		return res, nil
+	}
+
+	message := ""
+	if k.password == "" {
+		messageBytes, err := json.Marshal(m)
+		if err != nil {
+			// notest
+			return res, err
+		}
+		if len(messageBytes) <= int(appdef.MaxFieldLength) {
+			message = string(messageBytes)
+		}
+	}
+	attempts := outbound.Attempts{Status: outbound.Status_Pending}
+	retryIn := attempts.Register(res.sendErr, len(message) > 0)
+	if err := s.enqueue(m, message, attempts, retryIn); err != nil {
+		return res, err
+	}
+	res.willRetry = attempts.Status == outbound.Status_Pending
+	return res, nil
+}
+
+func (s *sendMailStorage) enqueue(m outboxMessage, message string, attempts outbound.Attempts, retryIn int32) error {
+	body, err := json.Marshal(map[string]any{
+		"args": map[string]any{
+			sys.OutboxEmail_Field_Status:     attempts.Status,
+			sys.OutboxEmail_Field_Attempts:   attempts.Count,
+			sys.OutboxEmail_Field_RetryIn:    retryIn,
+			sys.OutboxEmail_Field_LastError:  attempts.LastError,
+			sys.OutboxEmail_Field_Subject:    outbound.Truncate(m.Subject, outboxSubjectMaxLen),
+			sys.OutboxEmail_Field_Recipients: outbound.Truncate(strings.Join(slices.Concat(m.To, m.CC, m.BCC), ","), outboxRecipientsMaxLen),
+			sys.OutboxEmail_Field_Message:    message,
+		},
+	})
+	if err != nil {
+		// notest
+		return err
+	}
+	as := s.appStructsFunc()
+	_, err = s.federationCommands.Get(&federationCommandKeyBuilder{
+		baseKeyBuilder: baseKeyBuilder{storage: sys.Storage_FederationCommand},
+		wsid:           coreutils.PseudoWSIDToAppWSID(s.wsidFunc(), as.NumAppWorkspaces()),
+		command:        sys.QNameCmdEnqueueOutboxEmail,
+		body:           string(body),
+	})
+	return err
+}
+
+// sends the pending message from the outbox of the current application workspace
+// the result is registered by sys.OutboxSender job
+func (s *sendMailStorage) retry(day int32, id int64) error {
+	as := s.appStructsFunc()
+	kb := as.ViewRecords().KeyBuilder(sys.QNameViewOutboxEmail)
+	kb.PutInt32(sys.OutboxEmail_Field_Day, day)
+	kb.PutInt64(sys.OutboxEmail_Field_ID, id)
+	value, err := as.ViewRecords().Get(s.wsidFunc(), kb)
+	if err != nil {
+		if errors.Is(err, istructs.ErrRecordNotFound) {
+			return fmt.Errorf("%w: day %d, id %d", errOutboxMessageNotFound, day, id)
+		}
+		return err
+	}
+	m := outboxMessage{}
+	if err := json.Unmarshal([]byte(value.AsString(sys.OutboxEmail_Field_Message)), &m); err != nil {
+		return fmt.Errorf("outbox message day %d, id %d: %w", day, id, err)
+	}
+	return s.sendMail(m, "")
+}
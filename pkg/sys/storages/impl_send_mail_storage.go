@@ -5,10 +5,19 @@
 package storages
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"slices"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/sys"
@@ -16,16 +25,32 @@ import (
 )
 
 type sendMailStorage struct {
-	emailSender state.IEmailSender
+	ctx                context.Context
+	emailSender        state.IEmailSender
+	appStructsFunc     state.AppStructsFunc
+	wsidFunc           state.WSIDFunc
+	secretReader       isecrets.ISecretReader
+	blobReader         state.BLOBReader
+	federationCommands state.IWithGet
 }
 
 type implIEmailSender_SMTP struct {
 	defaultOpts []mail.Option
 }
 
-func NewSendMailStorage(emailSender state.IEmailSender) state.IStateStorage {
+// Sent messages are enqueued to the sys.OutboxEmail view of the application workspace by c.sys.EnqueueOutboxEmail
+// called through federationCommands, failed ones are retried by the sys.OutboxSender job.
+// federationCommands or wsidFunc is nil -> the outbox is not used, messages are sent synchronously only
+func NewSendMailStorage(ctx context.Context, emailSender state.IEmailSender, appStructsFunc state.AppStructsFunc, wsidFunc state.WSIDFunc,
+	secretReader isecrets.ISecretReader, blobReader state.BLOBReader, federationCommands state.IWithGet) state.IStateStorage {
 	return &sendMailStorage{
-		emailSender: emailSender,
+		ctx:                ctx,
+		emailSender:        emailSender,
+		appStructsFunc:     appStructsFunc,
+		wsidFunc:           wsidFunc,
+		secretReader:       secretReader,
+		blobReader:         blobReader,
+		federationCommands: federationCommands,
 	}
 }
 
@@ -41,11 +66,16 @@ func NewIEmailSenderSMTPForTests() state.IEmailSender {
 
 type mailKeyBuilder struct {
 	baseKeyBuilder
-	message  state.EmailMessage
-	host     string
-	port     int32
-	username string
-	password string
+	message        state.EmailMessage
+	host           string
+	port           int32
+	username       string
+	password       string
+	passwordSecret string
+	language       string // Subject and Body are translated by the application translations if specified
+	attachments    []istructs.RecordID
+	outboxDay      int32
+	outboxID       int64
 }
 
 func (b *mailKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
@@ -99,6 +129,21 @@ func (b *mailKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
 	if b.message.Body != vb.message.Body {
 		return false
 	}
+	if b.message.TextBody != vb.message.TextBody {
+		return false
+	}
+	if b.language != vb.language {
+		return false
+	}
+	if b.passwordSecret != vb.passwordSecret {
+		return false
+	}
+	if !slices.Equal(b.attachments, vb.attachments) {
+		return false
+	}
+	if b.outboxDay != vb.outboxDay || b.outboxID != vb.outboxID {
+		return false
+	}
 	return true
 }
 
@@ -122,19 +167,47 @@ func (b *mailKeyBuilder) PutString(name string, value string) {
 		b.message.Subject = value
 	case sys.Storage_SendMail_Field_Body:
 		b.message.Body = value
+	case sys.Storage_SendMail_Field_Language:
+		b.language = value
+	case sys.Storage_SendMail_Field_TextBody:
+		b.message.TextBody = value
+	case sys.Storage_SendMail_Field_PasswordSecret:
+		b.passwordSecret = value
 	default:
 		b.baseKeyBuilder.PutString(name, value)
 	}
 }
 
 func (b *mailKeyBuilder) PutInt32(name string, value int32) {
-	if name == sys.Storage_SendMail_Field_Port {
+	switch name {
+	case sys.Storage_SendMail_Field_Port:
 		b.port = value
-	} else {
+	case sys.Storage_SendMail_Field_OutboxDay:
+		b.outboxDay = value
+	default:
 		b.baseKeyBuilder.PutInt32(name, value)
 	}
 }
 
+func (b *mailKeyBuilder) PutInt64(name string, value int64) {
+	switch name {
+	case sys.Storage_SendMail_Field_Attachment:
+		b.attachments = append(b.attachments, istructs.RecordID(value)) // nolint G115
+	case sys.Storage_SendMail_Field_OutboxID:
+		b.outboxID = value
+	default:
+		b.baseKeyBuilder.PutInt64(name, value)
+	}
+}
+
+func (b *mailKeyBuilder) PutRecordID(name string, value istructs.RecordID) {
+	if name == sys.Storage_SendMail_Field_Attachment {
+		b.attachments = append(b.attachments, value)
+	} else {
+		b.baseKeyBuilder.PutRecordID(name, value)
+	}
+}
+
 type sendMailValueBuilder struct {
 	baseValueBuilder
 }
@@ -146,13 +219,27 @@ func (s *sendMailStorage) NewKeyBuilder(appdef.QName, istructs.IStateKeyBuilder)
 }
 func (s *sendMailStorage) validateKey(k *mailKeyBuilder) (err error) {
 	const errMsg = "'%s': %w"
+	if k.outboxID != 0 {
+		// retry of the message from the outbox
+		return nil
+	}
+	settings := smtpSettings{Host: k.host, Port: k.port, From: k.message.From}
 	if k.host == "" {
+		appSettings, ok, err := s.appSMTPSettings()
+		if err != nil {
+			return err
+		}
+		if ok {
+			settings = appSettings.withFrom(k.message.From)
+		}
+	}
+	if settings.Host == "" {
 		return fmt.Errorf(errMsg, sys.Storage_SendMail_Field_Host, ErrNotFound)
 	}
-	if k.port == 0 {
+	if settings.Port == 0 {
 		return fmt.Errorf(errMsg, sys.Storage_SendMail_Field_Port, ErrNotFound)
 	}
-	if k.message.From == "" {
+	if settings.From == "" {
 		return fmt.Errorf(errMsg, sys.Storage_SendMail_Field_From, ErrNotFound)
 	}
 	if len(k.message.To) == 0 {
@@ -168,34 +255,160 @@ func (s *sendMailStorage) Validate(items []state.ApplyBatchItem) (err error) {
 	}
 	return nil
 }
-func (s *sendMailStorage) sendMail(k *mailKeyBuilder) error {
+
+// returns error if the message is not sent and will not be retried
+func (s *sendMailStorage) ApplyBatch(items []state.ApplyBatchItem) (err error) {
+	for _, item := range items {
+		res, err := s.enqueueAndSend(item.Key.(*mailKeyBuilder))
+		if err != nil {
+			return err
+		}
+		if res.sendErr != nil && !res.willRetry {
+			return res.sendErr
+		}
+	}
+	return nil
+}
+
+// translates the message and reads per-app SMTP settings if Host is not specified
+func (s *sendMailStorage) outboxMessage(k *mailKeyBuilder) outboxMessage {
+	message := k.message
+	if k.language != "" {
+		appDef := s.appStructsFunc().AppDef()
+		message.Subject = coreutils.Translate(appDef, k.language, message.Subject)
+		message.Body = coreutils.Translate(appDef, k.language, message.Body)
+		message.TextBody = coreutils.Translate(appDef, k.language, message.TextBody)
+	}
+	res := outboxMessage{
+		Subject:        message.Subject,
+		From:           message.From,
+		To:             message.To,
+		CC:             message.CC,
+		BCC:            message.BCC,
+		Body:           message.Body,
+		TextBody:       message.TextBody,
+		Host:           k.host,
+		Port:           k.port,
+		Username:       k.username,
+		PasswordSecret: k.passwordSecret,
+		Attachments:    k.attachments,
+	}
+	if s.wsidFunc != nil {
+		res.WSID = s.wsidFunc()
+	}
+	return res
+}
+
+func (s *sendMailStorage) sendMail(m outboxMessage, password string) error {
+	settings, err := s.smtpSettings(m, password)
+	if err != nil {
+		return err
+	}
+	message := state.EmailMessage{
+		Subject:  m.Subject,
+		From:     settings.From,
+		To:       m.To,
+		CC:       m.CC,
+		BCC:      m.BCC,
+		Body:     m.Body,
+		TextBody: m.TextBody,
+	}
+	if message.Attachments, err = s.readAttachments(m); err != nil {
+		return err
+	}
 
 	opts := []mail.Option{
-		mail.WithPort(int(k.port)),
-		mail.WithUsername(k.username),
-		mail.WithPassword(k.password),
+		mail.WithPort(int(settings.Port)),
+		mail.WithUsername(settings.Username),
+		mail.WithPassword(settings.Password),
 		mail.WithSMTPAuth(mail.SMTPAuthPlain),
 	}
 
 	logger.Info(fmt.Sprintf("send mail '%s' from '%s' to %s, cc %s, bcc %s",
-		k.message.Subject, k.message.From, k.message.To, k.message.CC, k.message.BCC))
-	if err := s.emailSender.Send(k.host, k.message, opts...); err != nil {
+		message.Subject, message.From, message.To, message.CC, message.BCC))
+	if err := s.emailSender.Send(settings.Host, message, opts...); err != nil {
 		return err
 	}
 	logger.Info(fmt.Sprintf("mail '%s' from '%s' to %s, cc %s, bcc %s successfully sent",
-		k.message.Subject, k.message.From, k.message.To, k.message.CC, k.message.BCC))
-		
+		message.Subject, message.From, message.To, message.CC, message.BCC))
+
 	return nil
 }
-func (s *sendMailStorage) ApplyBatch(items []state.ApplyBatchItem) (err error) {
-	for _, item := range items {
-		err = s.sendMail(item.Key.(*mailKeyBuilder))
+
+// per-app settings are used if Host is not specified, password is read from PasswordSecret if specified
+func (s *sendMailStorage) smtpSettings(m outboxMessage, password string) (smtpSettings, error) {
+	if m.Host == "" {
+		settings, ok, err := s.appSMTPSettings()
 		if err != nil {
-			return err
+			return smtpSettings{}, err
+		}
+		if !ok {
+			return smtpSettings{}, fmt.Errorf("'%s': %w", sys.Storage_SendMail_Field_Host, ErrNotFound)
 		}
+		return settings.withFrom(m.From), nil
 	}
-	return nil
+	settings := smtpSettings{Host: m.Host, Port: m.Port, Username: m.Username, Password: password, From: m.From}
+	if m.PasswordSecret != "" {
+		pwd, err := s.secretReader.ReadSecret(m.PasswordSecret)
+		if err != nil {
+			return smtpSettings{}, err
+		}
+		settings.Password = string(pwd)
+	}
+	return settings, nil
+}
+
+// reads JSON-encoded settings from the secret "smtp-<owner>-<app>"
+// ok is false if there is no such secret
+func (s *sendMailStorage) appSMTPSettings() (settings smtpSettings, ok bool, err error) {
+	if s.secretReader == nil || s.appStructsFunc == nil {
+		return settings, false, nil
+	}
+	appQName := s.appStructsFunc().AppQName()
+	secretName := appSMTPSettingsSecretNamePrefix + appQName.Owner() + "-" + appQName.Name()
+	secret, err := s.secretReader.ReadSecret(secretName)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return settings, false, nil
+		}
+		return settings, false, err
+	}
+	if err := json.Unmarshal(secret, &settings); err != nil {
+		return settings, false, fmt.Errorf("%s: %w", secretName, err)
+	}
+	return settings, true, nil
 }
+
+func (s *sendMailStorage) readAttachments(m outboxMessage) (attachments []state.EmailAttachment, err error) {
+	if len(m.Attachments) == 0 {
+		return nil, nil
+	}
+	if s.blobReader == nil {
+		return nil, errBLOBReaderNotAvailable
+	}
+	for _, blobID := range m.Attachments {
+		// BLOBs are stored by the blobber, see processors/blobber
+		descr, data, err := s.blobReader(s.ctx, iblobstorage.PersistentBLOBKeyType{
+			ClusterAppID: istructs.ClusterAppID_sys_blobber,
+			WSID:         m.WSID,
+			BlobID:       blobID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("attachment BLOB %d: %w", blobID, err)
+		}
+		contentType := descr.ContentType
+		if contentType == "" {
+			contentType = string(mail.TypeAppOctetStream)
+		}
+		attachments = append(attachments, state.EmailAttachment{
+			Name:        descr.Name,
+			ContentType: contentType,
+			Data:        data,
+		})
+	}
+	return attachments, nil
+}
+
 func (s *sendMailStorage) ProvideValueBuilder(istructs.IStateKeyBuilder, istructs.IStateValueBuilder) (istructs.IStateValueBuilder, error) {
 	return &sendMailValueBuilder{}, nil
 }
@@ -224,18 +437,32 @@ func (v *sendMailValue) AsString(name string) string {
 	}
 }
 
+// OutboxDay and OutboxID in the key -> the message from the outbox is retried, used by sys.OutboxSender job
 func (s *sendMailStorage) Get(key istructs.IStateKeyBuilder) (istructs.IStateValue, error) {
-	err := s.validateKey(key.(*mailKeyBuilder))
-	if err == nil {
-		err = s.sendMail(key.(*mailKeyBuilder))
-	}
-	if err != nil {
+	k := key.(*mailKeyBuilder)
+	if err := s.validateKey(k); err != nil {
 		return &sendMailValue{
 			success: false,
 			error:   err.Error(),
 		}, nil
 	}
-	return &sendMailValue{success: true}, nil
+	var res sendResult
+	var err error
+	if k.outboxID != 0 {
+		res.sendErr = s.retry(k.outboxDay, k.outboxID)
+	} else {
+		res, err = s.enqueueAndSend(k)
+	}
+	if err != nil {
+		return nil, err
+	}
+	value := &sendMailValue{
+		success: res.sendErr == nil,
+	}
+	if res.sendErr != nil {
+		value.error = res.sendErr.Error()
+	}
+	return value, nil
 }
 
 func (s *implIEmailSender_SMTP) Send(host string, m state.EmailMessage, opts ...mail.Option) error {
@@ -258,7 +485,17 @@ func (s *implIEmailSender_SMTP) Send(host string, m state.EmailMessage, opts ...
 	if err = msg.Bcc(m.BCC...); err != nil {
 		return err
 	}
-	msg.SetBodyString(mail.TypeTextHTML, m.Body)
+	if m.TextBody != "" {
+		msg.SetBodyString(mail.TypeTextPlain, m.TextBody)
+		msg.AddAlternativeString(mail.TypeTextHTML, m.Body)
+	} else {
+		msg.SetBodyString(mail.TypeTextHTML, m.Body)
+	}
+	for _, a := range m.Attachments {
+		if err = msg.AttachReader(a.Name, bytes.NewReader(a.Data), mail.WithFileContentType(mail.ContentType(a.ContentType))); err != nil {
+			return err
+		}
+	}
 	msg.SetCharset(mail.CharsetUTF8)
 	return client.DialAndSend(msg)
 }
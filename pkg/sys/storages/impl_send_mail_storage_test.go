@@ -5,11 +5,14 @@
 package storages
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/state/smtptest"
@@ -20,7 +23,7 @@ func TestSendMailStorage_BasicUsage(t *testing.T) {
 	require := require.New(t)
 	ts := smtptest.NewServer(smtptest.WithCredentials("user", "pwd"))
 	defer ts.Close()
-	storage := NewSendMailStorage(NewIEmailSenderSMTPForTests())
+	storage := NewSendMailStorage(context.Background(), NewIEmailSenderSMTPForTests(), nil, nil, nil, nil, nil)
 	k := storage.NewKeyBuilder(appdef.NullQName, nil)
 
 	k.PutInt32(sys.Storage_SendMail_Field_Port, ts.Port())
@@ -68,6 +71,79 @@ func TestSendMailStorage_BasicUsage(t *testing.T) {
 		require.NotNil(msg)
 		verifyMsg(msg)
 	})
+
+	t.Run("Sending translated", func(t *testing.T) {
+		storage := NewSendMailStorage(context.Background(), NewIEmailSenderSMTPForTests(), translationsAppStructsFunc(), nil, nil, nil, nil)
+		k := storage.NewKeyBuilder(appdef.NullQName, nil)
+		k.PutInt32(sys.Storage_SendMail_Field_Port, ts.Port())
+		k.PutString(sys.Storage_SendMail_Field_Host, "localhost")
+		k.PutString(sys.Storage_SendMail_Field_Username, "user")
+		k.PutString(sys.Storage_SendMail_Field_Password, "pwd")
+		k.PutString(sys.Storage_SendMail_Field_Subject, "Greeting")
+		k.PutString(sys.Storage_SendMail_Field_From, "from@email.com")
+		k.PutString(sys.Storage_SendMail_Field_To, "to0@email.com")
+		k.PutString(sys.Storage_SendMail_Field_Body, "Hello world")
+		k.PutString(sys.Storage_SendMail_Field_Language, "nl-NL")
+
+		v, err := storage.(state.IWithGet).Get(k)
+		require.NoError(err)
+		require.True(v.AsBool(sys.Storage_SendMail_Field_Success))
+		msg := <-ts.Messages("user", "pwd")
+		require.Equal("Begroeting", msg.Subject)
+		require.Equal("Hallo wereld", msg.Body)
+	})
+
+	t.Run("Sending multipart with attachments", func(t *testing.T) {
+		appStructs := &mockAppStructs{}
+		blobReader := func(_ context.Context, key iblobstorage.PersistentBLOBKeyType) (iblobstorage.DescrType, []byte, error) {
+			require.Equal(istructs.ClusterAppID_sys_blobber, key.ClusterAppID)
+			require.Equal(istructs.WSID(42), key.WSID)
+			require.Equal(istructs.RecordID(1), key.BlobID)
+			return iblobstorage.DescrType{Name: "report.txt", ContentType: "text/csv"}, []byte("a,b,c"), nil
+		}
+		storage := NewSendMailStorage(context.Background(), NewIEmailSenderSMTPForTests(), func() istructs.IAppStructs { return appStructs }, nil, nil, blobReader, nil)
+		k := storage.NewKeyBuilder(appdef.NullQName, nil).(*mailKeyBuilder)
+		k.PutInt32(sys.Storage_SendMail_Field_Port, ts.Port())
+		k.PutString(sys.Storage_SendMail_Field_Host, "localhost")
+		k.PutString(sys.Storage_SendMail_Field_Username, "user")
+		k.PutString(sys.Storage_SendMail_Field_Password, "pwd")
+		k.PutString(sys.Storage_SendMail_Field_Subject, "Report")
+		k.PutString(sys.Storage_SendMail_Field_From, "from@email.com")
+		k.PutString(sys.Storage_SendMail_Field_To, "to0@email.com")
+		k.PutString(sys.Storage_SendMail_Field_Body, "<p>Hello world</p>")
+		k.PutString(sys.Storage_SendMail_Field_TextBody, "Hello world")
+		k.PutInt64(sys.Storage_SendMail_Field_Attachment, 1)
+
+		// no outbox in this test, so the workspace of the attachments is specified directly
+		m := storage.(*sendMailStorage).outboxMessage(k)
+		m.WSID = 42
+		require.NoError(storage.(*sendMailStorage).sendMail(m, k.password))
+		msg := <-ts.Messages("user", "pwd")
+		require.Equal("Report", msg.Subject)
+		require.Equal("<p>Hello world</p>", msg.Body)
+		require.Equal("Hello world", msg.TextBody)
+		require.Equal([]state.EmailAttachment{{Name: "report.txt", ContentType: "text/csv", Data: []byte("a,b,c")}}, msg.Attachments)
+	})
+
+	t.Run("Sending by per-app SMTP settings", func(t *testing.T) {
+		appStructs := &mockAppStructs{}
+		appStructs.On("AppQName").Return(istructs.AppQName_test1_app1)
+		secretReader := &isecrets.SecretReaderMock{}
+		secretReader.On("ReadSecret", "smtp-test1-app1").
+			Return([]byte(fmt.Sprintf(`{"Host":"localhost","Port":%d,"Username":"user","Password":"pwd","From":"app@email.com"}`, ts.Port())), nil)
+		storage := NewSendMailStorage(context.Background(), NewIEmailSenderSMTPForTests(), func() istructs.IAppStructs { return appStructs }, nil, secretReader, nil, nil)
+		k := storage.NewKeyBuilder(appdef.NullQName, nil)
+		k.PutString(sys.Storage_SendMail_Field_Subject, "Greeting")
+		k.PutString(sys.Storage_SendMail_Field_To, "to0@email.com")
+		k.PutString(sys.Storage_SendMail_Field_Body, "Hello world")
+
+		v, err := storage.(state.IWithGet).Get(k)
+		require.NoError(err)
+		require.True(v.AsBool(sys.Storage_SendMail_Field_Success), v.AsString(sys.Storage_SendMail_Field_ErrorMessage))
+		msg := <-ts.Messages("user", "pwd")
+		require.Equal("app@email.com", msg.From)
+		require.Equal("Hello world", msg.Body)
+	})
 }
 
 func TestSendMailStorage_Validate(t *testing.T) {
@@ -105,7 +181,7 @@ func TestSendMailStorage_Validate(t *testing.T) {
 			},
 		},
 	}
-	storage := NewSendMailStorage(nil)
+	storage := NewSendMailStorage(context.Background(), nil, nil, nil, nil, nil, nil)
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("Send with intents: error when mandatory field '%s' not found", test.mandatoryField), func(t *testing.T) {
 			require := require.New(t)
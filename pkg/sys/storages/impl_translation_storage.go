@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storages
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/state"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func NewTranslationStorage(appStructsFunc state.AppStructsFunc) state.IStateStorage {
+	return &translationStorage{
+		appStructsFunc: appStructsFunc,
+	}
+}
+
+type translationStorage struct {
+	appStructsFunc state.AppStructsFunc
+}
+
+type translationKeyBuilder struct {
+	baseKeyBuilder
+	language string
+	text     string
+}
+
+func (b *translationKeyBuilder) String() string {
+	return fmt.Sprintf("%s, language:%s, text:%s", b.baseKeyBuilder.String(), b.language, b.text)
+}
+func (b *translationKeyBuilder) Equals(src istructs.IKeyBuilder) bool {
+	kb, ok := src.(*translationKeyBuilder)
+	if !ok {
+		return false
+	}
+	return b.language == kb.language && b.text == kb.text
+}
+func (b *translationKeyBuilder) PutString(name string, value string) {
+	switch name {
+	case sys.Storage_Translation_Field_Language:
+		b.language = value
+	case sys.Storage_Translation_Field_Text:
+		b.text = value
+	default:
+		b.baseKeyBuilder.PutString(name, value)
+	}
+}
+
+type translationValue struct {
+	baseStateValue
+	translation string
+}
+
+func (v *translationValue) AsString(name string) string {
+	if name == sys.Storage_Translation_Field_Translation {
+		return v.translation
+	}
+	return v.baseStateValue.AsString(name)
+}
+
+func (s *translationStorage) NewKeyBuilder(appdef.QName, istructs.IStateKeyBuilder) istructs.IStateKeyBuilder {
+	return &translationKeyBuilder{
+		baseKeyBuilder: baseKeyBuilder{storage: sys.Storage_Translation},
+	}
+}
+
+// text itself is returned if the application has no translation
+func (s *translationStorage) Get(key istructs.IStateKeyBuilder) (istructs.IStateValue, error) {
+	k := key.(*translationKeyBuilder)
+	if k.text == "" {
+		return nil, errors.New("text to translate is not specified")
+	}
+	return &translationValue{
+		translation: coreutils.Translate(s.appStructsFunc().AppDef(), k.language, k.text),
+	}, nil
+}
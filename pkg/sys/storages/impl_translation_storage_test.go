@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/state"
+	"github.com/voedger/voedger/pkg/sys"
+)
+
+func translationsAppStructsFunc() state.AppStructsFunc {
+	adb := builder.New()
+	adb.AddTranslation("nl", "Greeting", "Begroeting").
+		AddTranslation("nl", "Hello world", "Hallo wereld")
+	appStructs := &mockAppStructs{}
+	appStructs.On("AppDef").Return(adb.AppDef())
+	return func() istructs.IAppStructs { return appStructs }
+}
+
+func TestTranslationStorage_BasicUsage(t *testing.T) {
+	require := require.New(t)
+	storage := NewTranslationStorage(translationsAppStructsFunc())
+
+	for lang, expected := range map[string]string{
+		"nl":             "Hallo wereld",
+		"nl-BE,en;q=0.8": "Hallo wereld",
+		"en":             "Hello world",
+		"":               "Hello world",
+	} {
+		kb := storage.NewKeyBuilder(appdef.NullQName, nil)
+		kb.PutString(sys.Storage_Translation_Field_Language, lang)
+		kb.PutString(sys.Storage_Translation_Field_Text, "Hello world")
+		sv, err := storage.(state.IWithGet).Get(kb)
+		require.NoError(err)
+		require.Equal(expected, sv.AsString(sys.Storage_Translation_Field_Translation), lang)
+	}
+
+	t.Run("Should return error when text is not specified", func(t *testing.T) {
+		kb := storage.NewKeyBuilder(appdef.NullQName, nil)
+		kb.PutString(sys.Storage_Translation_Field_Language, "nl")
+		_, err := storage.(state.IWithGet).Get(kb)
+		require.ErrorContains(err, "text to translate is not specified")
+	})
+}
@@ -107,6 +107,9 @@ func (s *viewRecordsStorage) Read(kb istructs.IStateKeyBuilder, callback istruct
 	if err = s.wsTypeVailidator.validate(k.wsid, k.view); err != nil {
 		return err
 	}
+	if k.reverse {
+		return s.appStructsFunc().ViewRecords().ReadReverse(s.ctx, k.wsid, k.IKeyBuilder, cb)
+	}
 	return s.appStructsFunc().ViewRecords().Read(s.ctx, k.wsid, k.IKeyBuilder, cb)
 }
 func (s *viewRecordsStorage) Validate([]state.ApplyBatchItem) (err error) { return err }
@@ -173,8 +176,9 @@ func (s *viewRecordsStorage) ProvideValueBuilderForUpdate(kb istructs.IStateKeyB
 
 type viewKeyBuilder struct {
 	istructs.IKeyBuilder
-	wsid istructs.WSID
-	view appdef.QName
+	wsid    istructs.WSID
+	view    appdef.QName
+	reverse bool
 }
 
 func (b *viewKeyBuilder) PutInt64(name string, value int64) {
@@ -188,6 +192,13 @@ func (b *viewKeyBuilder) PutInt64(name string, value int64) {
 	}
 	b.IKeyBuilder.PutInt64(name, value)
 }
+func (b *viewKeyBuilder) PutBool(name string, value bool) {
+	if name == sys.Storage_View_Field_Reverse {
+		b.reverse = value
+		return
+	}
+	b.IKeyBuilder.PutBool(name, value)
+}
 func (b *viewKeyBuilder) PutQName(name string, value appdef.QName) {
 	if name == appdef.SystemField_QName {
 		b.wsid = istructs.NullWSID
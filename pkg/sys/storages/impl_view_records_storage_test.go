@@ -98,6 +98,31 @@ func TestViewRecordsStorage_Read(t *testing.T) {
 		err := storage.(state.IWithRead).Read(k, func(istructs.IKey, istructs.IStateValue) error { return nil })
 		require.ErrorIs(err, errTest)
 	})
+	t.Run("Should read in reverse order", func(t *testing.T) {
+		require := require.New(t)
+		mockedStructs, mockedViews := mockedStructs(t)
+		touched := false
+		mockedViews.
+			On("ReadReverse", context.Background(), istructs.WSID(1), mock.Anything, mock.AnythingOfType("istructs.ValuesCallback")).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				require.NoError(args.Get(3).(istructs.ValuesCallback)(nil, nil))
+			})
+		appStructsFunc := func() istructs.IAppStructs {
+			return mockedStructs
+		}
+		storage := NewViewRecordsStorage(context.Background(), appStructsFunc, state.SimpleWSIDFunc(istructs.WSID(1)), nil)
+		k := storage.NewKeyBuilder(testViewRecordQName1, nil)
+		k.PutBool(sys.Storage_View_Field_Reverse, true)
+
+		err := storage.(state.IWithRead).Read(k, func(istructs.IKey, istructs.IStateValue) error {
+			touched = true
+			return nil
+		})
+		require.NoError(err)
+		require.True(touched)
+		mockedViews.AssertNotCalled(t, "Read", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 func TestViewRecordsStorage_ApplyBatch_should_return_error_on_put_batch(t *testing.T) {
 	require := require.New(t)
@@ -50,6 +50,9 @@ type mockAppStructs struct {
 func (s *mockAppStructs) AppQName() appdef.AppQName {
 	return s.Called().Get(0).(appdef.AppQName)
 }
+func (s *mockAppStructs) ClusterAppID() istructs.ClusterAppID {
+	return s.Called().Get(0).(istructs.ClusterAppID)
+}
 func (s *mockAppStructs) AppDef() appdef.IAppDef {
 	return s.Called().Get(0).(appdef.IAppDef)
 }
@@ -111,6 +114,9 @@ func (r *mockViewRecords) PutBatch(workspace istructs.WSID, batch []istructs.Vie
 func (r *mockViewRecords) Read(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
 	return r.Called(ctx, workspace, key, cb).Error(0)
 }
+func (r *mockViewRecords) ReadReverse(ctx context.Context, workspace istructs.WSID, key istructs.IKeyBuilder, cb istructs.ValuesCallback) (err error) {
+	return r.Called(ctx, workspace, key, cb).Error(0)
+}
 
 type nilViewRecords struct {
 	istructs.IViewRecords
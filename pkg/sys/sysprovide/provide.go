@@ -20,6 +20,7 @@ import (
 	"github.com/voedger/voedger/pkg/parser"
 	blobprocessor "github.com/voedger/voedger/pkg/processors/blobber"
 	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/apikeys"
 	"github.com/voedger/voedger/pkg/sys/authnz"
 	"github.com/voedger/voedger/pkg/sys/blobber"
 	"github.com/voedger/voedger/pkg/sys/builtin"
@@ -31,6 +32,7 @@ import (
 	"github.com/voedger/voedger/pkg/sys/sqlquery"
 	"github.com/voedger/voedger/pkg/sys/uniques"
 	"github.com/voedger/voedger/pkg/sys/verifier"
+	"github.com/voedger/voedger/pkg/sys/webhooks"
 	"github.com/voedger/voedger/pkg/sys/workspace"
 )
 
@@ -47,8 +49,11 @@ func ProvideStateless(sr istructsmem.IStatelessResources, smtpCfg smtp.Cfg, eps
 	verifier.Provide(sr, itokens, federation, asp, smtpCfg)
 	authnz.Provide(sr, itokens, atf)
 	invite.Provide(sr, time, federation, itokens, smtpCfg)
+	apikeys.Provide(sr, time, itokens)
 	uniques.Provide(sr)
 	describe.Provide(sr)
+	smtp.ProvideOutbox(sr, time)
+	webhooks.Provide(sr, time)
 }
 
 func Provide(cfg *istructsmem.AppConfigType) parser.PackageFS {
@@ -11,7 +11,6 @@ import (
 
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
-	"golang.org/x/text/message/catalog"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
@@ -23,7 +22,6 @@ import (
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 	"github.com/voedger/voedger/pkg/processors"
-	"github.com/voedger/voedger/pkg/state"
 	"github.com/voedger/voedger/pkg/sys"
 	"github.com/voedger/voedger/pkg/sys/smtp"
 )
@@ -99,19 +97,24 @@ func applySendEmailVerificationCode(federation federation.IFederation, smtpCfg s
 			return
 		}
 		reason := event.ArgumentObject().AsString(field_Reason)
-		translatedEmailSubject := message.NewPrinter(language.Make(lng), message.Catalog(translationsCatalog)).Sprintf(EmailSubject)
+		translate := func(text string) (string, error) { return translateText(st, lng, text) }
+		translatedEmailSubject, err := translate(EmailSubject)
+		if err != nil {
+			return err
+		}
+		body, err := getVerificationEmailBody(federation, event.ArgumentObject().AsString(field_VerificationCode), reason, translate)
+		if err != nil {
+			return err
+		}
 		kb.PutString(sys.Storage_SendMail_Field_Subject, translatedEmailSubject)
 		kb.PutString(sys.Storage_SendMail_Field_To, event.ArgumentObject().AsString(Field_Email))
-		kb.PutString(sys.Storage_SendMail_Field_Body, getVerificationEmailBody(federation, event.ArgumentObject().AsString(field_VerificationCode), reason, language.Make(lng), translationsCatalog))
+		kb.PutString(sys.Storage_SendMail_Field_Body, body)
 		kb.PutString(sys.Storage_SendMail_Field_From, smtpCfg.GetFrom())
 		kb.PutString(sys.Storage_SendMail_Field_Host, smtpCfg.Host)
 		kb.PutInt32(sys.Storage_SendMail_Field_Port, smtpCfg.Port)
 		kb.PutString(sys.Storage_SendMail_Field_Username, smtpCfg.Username)
-		pwd, err := state.ReadSecret(st, smtpCfg.PwdSecret)
-		if err != nil {
-			return err
-		}
-		kb.PutString(sys.Storage_SendMail_Field_Password, pwd)
+		// the password is read by the storage so the failed message could be retried
+		kb.PutString(sys.Storage_SendMail_Field_PasswordSecret, smtpCfg.PwdSecret)
 
 		_, err = intents.NewValue(kb)
 
@@ -180,10 +183,34 @@ func execCmdSendEmailVerificationCode(args istructs.ExecCommandArgs) (err error)
 	return coreutils.ValidateEMail(email)
 }
 
-func getVerificationEmailBody(federation federation.IFederation, verificationCode string, reason string, lng language.Tag, ctlg catalog.Catalog) string {
-	text1 := message.NewPrinter(lng, message.Catalog(ctlg)).Sprintf(`Here is your verification code`)
-	text2 := message.NewPrinter(lng, message.Catalog(ctlg)).Sprintf(`Please, enter this code on`)
-	text3 := message.NewPrinter(lng, message.Catalog(ctlg)).Sprintf(reason)
+// application translations declared by TRANSLATIONS statements take precedence over the builtin ones
+func translateText(st istructs.IState, lng string, text string) (string, error) {
+	kb, err := st.KeyBuilder(sys.Storage_Translation, appdef.NullQName)
+	if err != nil {
+		return "", err
+	}
+	kb.PutString(sys.Storage_Translation_Field_Language, lng)
+	kb.PutString(sys.Storage_Translation_Field_Text, text)
+	sv, err := st.MustExist(kb)
+	if err != nil {
+		return "", err
+	}
+	if translation := sv.AsString(sys.Storage_Translation_Field_Translation); translation != text {
+		return translation, nil
+	}
+	return message.NewPrinter(language.Make(lng), message.Catalog(translationsCatalog)).Sprintf(text), nil
+}
+
+func getVerificationEmailBody(federation federation.IFederation, verificationCode string, reason string, translate func(text string) (string, error)) (string, error) {
+	texts := []string{`Here is your verification code`, `Please, enter this code on`, reason}
+	for i, text := range texts {
+		translation, err := translate(text)
+		if err != nil {
+			return "", err
+		}
+		texts[i] = translation
+	}
+	text1, text2, text3 := texts[0], texts[1], texts[2]
 	return fmt.Sprintf(`
 <div style="font-family: Arial, Helvetica, sans-serif;">
 	<div
@@ -202,5 +229,5 @@ func getVerificationEmailBody(federation federation.IFederation, verificationCod
 		%d &copy; unTill
 	</div>
 </div>
-`, text1, verificationCode, text2, federation.URLStr(), text3, time.Now().Year())
+`, text1, verificationCode, text2, federation.URLStr(), text3, time.Now().Year()), nil
 }
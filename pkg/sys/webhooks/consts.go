@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/sys/outbound"
+)
+
+var (
+	QNameViewWebhookDelivery        = appdef.NewQName(appdef.SysPackage, "WebhookDelivery")
+	qNameViewWebhookRetry           = appdef.NewQName(appdef.SysPackage, "WebhookRetry")
+	qNameCmdRedeliverWebhook        = appdef.NewQName(appdef.SysPackage, "RedeliverWebhook")
+	qNameCmdRetryWebhook            = appdef.NewQName(appdef.SysPackage, "RetryWebhook")
+	qNameProjectorWebhookDispatcher = appdef.NewQName(appdef.SysPackage, "WebhookDispatcher")
+	qNameJobWebhookSender           = appdef.NewQName(appdef.SysPackage, "WebhookSender")
+)
+
+// sys.WebhookDelivery, sys.WebhookRetry, c.sys.RedeliverWebhook and c.sys.RetryWebhook fields
+const (
+	Field_Day         = outbound.Field_Day
+	Field_ID          = outbound.Field_ID
+	Field_Webhook     = "Webhook"
+	Field_WLogOffset  = "WLogOffset"
+	Field_Status      = "Status"
+	Field_Attempts    = "Attempts"
+	Field_StatusCode  = "StatusCode"
+	Field_LastError   = "LastError"
+	Field_Envelope    = "Envelope"
+	Field_RetryID     = "RetryID"
+	field_WSID        = "WSID"
+	field_DeliveryDay = "DeliveryDay"
+	field_DeliveryID  = "DeliveryID"
+)
+
+const (
+	Status_Pending    = outbound.Status_Pending
+	Status_Delivered  = outbound.Status_Succeeded
+	Status_DeadLetter = outbound.Status_Failed
+)
+
+// signed by HMAC-SHA256 of the body with the webhook secret
+const (
+	Header_Webhook   = "X-Voedger-Webhook"
+	Header_Delivery  = "X-Voedger-Delivery"
+	Header_Signature = "X-Voedger-Signature"
+	signaturePrefix  = "sha256="
+)
+
+const (
+	idRandomBits     = 20
+	deliveryTimeout  = 10 * time.Second
+	responseBodySnip = 256
+)
@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import "errors"
+
+var (
+	errWebhookDeliveryNotFound       = errors.New("webhook delivery not found")
+	errWebhookDeliveryNotRedelivered = errors.New("webhook delivery can not be redelivered: the envelope was too long to be persisted")
+	errWebhookNotDeclared            = errors.New("webhook is not declared in the application")
+	errWebhookSecretNotFound         = errors.New("webhook secret not found")
+	errUnexpectedStatusCode          = errors.New("unexpected status code")
+)
@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/outbound"
+)
+
+// persisted to sys.WebhookDelivery view
+type delivery struct {
+	outbound.Attempts
+	day        int32
+	id         int64
+	webhook    appdef.QName
+	wLogOffset istructs.Offset
+	statusCode int32
+	envelope   string // not persisted if too long, the delivery can not be retried then
+	retryID    int64  // ID of the pending sys.WebhookRetry entry, the other entries of the delivery are stale
+}
+
+// sys.WebhookRetry entry of the application workspace, refers to the delivery in the workspace wsid
+type retry struct {
+	wsid        istructs.WSID
+	deliveryDay int32
+	deliveryID  int64
+}
+
+// JSON body POSTed to the webhook URL
+type envelope struct {
+	Webhook      string
+	WSID         istructs.WSID
+	WLogOffset   istructs.Offset
+	QName        string
+	RegisteredAt istructs.UnixMilli
+	Argument     map[string]interface{} `json:",omitempty"`
+	CUDs         []map[string]interface{}
+}
+
+func newDelivery(now time.Time, webhook appdef.QName, wLogOffset istructs.Offset) *delivery {
+	return &delivery{
+		Attempts:   outbound.Attempts{Status: Status_Pending},
+		day:        outbound.Day(now),
+		id:         newID(now),
+		webhook:    webhook,
+		wLogOffset: wLogOffset,
+	}
+}
+
+// IDs of deliveries and retries
+func newID(now time.Time) int64 {
+	return now.UnixMilli()<<idRandomBits | rand.Int64N(1<<idRandomBits) // nolint G404: uniquity only, not a secret
+}
+
+// POSTs the envelope to the webhook URL and registers the attempt, see outbound.Attempts.Register
+func (d *delivery) deliver(st istructs.IState) (retryIn int32, err error) {
+	statusCode, attemptErr, err := d.post(st)
+	if err != nil {
+		return 0, err
+	}
+	return d.registerAttempt(statusCode, attemptErr), nil
+}
+
+// attemptErr is the error of the attempt itself, err is the error of the state
+func (d *delivery) post(st istructs.IState) (statusCode int32, attemptErr error, err error) {
+	wh := st.AppStructs().AppDef().Webhook(d.webhook)
+	if wh == nil {
+		// the webhook is removed from the application after the event
+		return 0, fmt.Errorf("%w: %s", errWebhookNotDeclared, d.webhook), nil
+	}
+
+	secret, ok, err := readSecret(st, wh.Secret())
+	if err != nil {
+		return 0, nil, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", errWebhookSecretNotFound, wh.Secret()), nil
+	}
+	url := wh.URL()
+	if name, isSecret := appdef.WebhookURLSecret(url); isSecret {
+		if url, ok, err = readSecret(st, name); err != nil {
+			return 0, nil, err
+		}
+		if !ok {
+			return 0, fmt.Errorf("%w: %s", errWebhookSecretNotFound, name), nil
+		}
+		url = strings.TrimSpace(url)
+	}
+
+	kbHTTP, err := st.KeyBuilder(sys.Storage_HTTP, appdef.NullQName)
+	if err != nil {
+		// notest
+		return 0, nil, err
+	}
+	kbHTTP.PutString(sys.Storage_HTTP_Field_URL, url)
+	kbHTTP.PutString(sys.Storage_HTTP_Field_Method, http.MethodPost)
+	kbHTTP.PutString(sys.Storage_HTTP_Field_Header, "Content-Type: application/json")
+	kbHTTP.PutString(sys.Storage_HTTP_Field_Header, Header_Webhook+": "+d.webhook.String())
+	kbHTTP.PutString(sys.Storage_HTTP_Field_Header, Header_Delivery+": "+strconv.FormatInt(d.id, 10))
+	kbHTTP.PutString(sys.Storage_HTTP_Field_Header, Header_Signature+": "+Sign([]byte(d.envelope), secret))
+	kbHTTP.PutBytes(sys.Storage_HTTP_Field_Body, []byte(d.envelope))
+	kbHTTP.PutInt64(sys.Storage_HTTP_Field_HTTPClientTimeoutMilliseconds, deliveryTimeout.Milliseconds())
+	kbHTTP.PutBool(sys.Storage_HTTP_Field_HandleErrors, true)
+	kbHTTP.PutBool(sys.Storage_HTTP_Field_NoRetries, true) // backoff is made by sys.WebhookSender job
+	err = st.Read(kbHTTP, func(_ istructs.IKey, value istructs.IStateValue) error {
+		if e := value.AsString(sys.Storage_HTTP_Field_Error); e != "" {
+			attemptErr = fmt.Errorf("%s", e)
+			return nil
+		}
+		statusCode = value.AsInt32(sys.Storage_HTTP_Field_StatusCode)
+		if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+			attemptErr = fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, statusCode, outbound.Truncate(string(value.AsBytes(sys.Storage_HTTP_Field_Body)), responseBodySnip))
+		}
+		return nil
+	})
+	return statusCode, attemptErr, err
+}
+
+// ok is false -> the application secret is not found
+func readSecret(st istructs.IState, name string) (secret string, ok bool, err error) {
+	kb, err := st.KeyBuilder(sys.Storage_AppSecret, appdef.NullQName)
+	if err != nil {
+		// notest
+		return "", false, err
+	}
+	kb.PutString(sys.Storage_AppSecretField_Secret, name)
+	sv, ok, err := st.CanExist(kb)
+	if err != nil || !ok {
+		return "", false, err
+	}
+	return sv.AsString(""), true, nil
+}
+
+// the delivery without the persisted envelope is dead letter at once
+func (d *delivery) registerAttempt(statusCode int32, attemptErr error) (retryIn int32) {
+	d.statusCode = statusCode
+	return d.Register(attemptErr, d.retriable())
+}
+
+func (d *delivery) retriable() bool {
+	return len(d.envelope) > 0 && len(d.envelope) <= int(appdef.MaxFieldLength)
+}
+
+// wsid is NullWSID -> the current workspace
+func deliveryKey(st istructs.IState, wsid istructs.WSID, day int32, id int64) (istructs.IStateKeyBuilder, error) {
+	kb, err := st.KeyBuilder(sys.Storage_View, QNameViewWebhookDelivery)
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	if wsid != istructs.NullWSID {
+		kb.PutInt64(sys.Storage_View_Field_WSID, int64(wsid)) // nolint G115
+	}
+	kb.PutInt32(Field_Day, day)
+	kb.PutInt64(Field_ID, id)
+	return kb, nil
+}
+
+func readDelivery(st istructs.IState, wsid istructs.WSID, day int32, id int64) (d *delivery, ok bool, err error) {
+	kb, err := deliveryKey(st, wsid, day, id)
+	if err != nil {
+		// notest
+		return nil, false, err
+	}
+	value, ok, err := st.CanExist(kb)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	return &delivery{
+		Attempts: outbound.Attempts{
+			Status:    value.AsInt32(Field_Status),
+			Count:     value.AsInt32(Field_Attempts),
+			LastError: value.AsString(Field_LastError),
+		},
+		day:        day,
+		id:         id,
+		webhook:    appdef.MustParseQName(value.AsString(Field_Webhook)),
+		wLogOffset: istructs.Offset(value.AsInt64(Field_WLogOffset)), // nolint G115
+		statusCode: value.AsInt32(Field_StatusCode),
+		envelope:   value.AsString(Field_Envelope),
+		retryID:    value.AsInt64(Field_RetryID),
+	}, true, nil
+}
+
+func putDelivery(st istructs.IState, intents istructs.IIntents, wsid istructs.WSID, d *delivery) error {
+	kb, err := deliveryKey(st, wsid, d.day, d.id)
+	if err != nil {
+		// notest
+		return err
+	}
+	vb, err := intents.NewValue(kb)
+	if err != nil {
+		// notest
+		return err
+	}
+	vb.PutString(Field_Webhook, d.webhook.String())
+	vb.PutInt64(Field_WLogOffset, int64(d.wLogOffset)) // nolint G115
+	vb.PutInt32(Field_Status, d.Status)
+	vb.PutInt32(Field_Attempts, d.Count)
+	vb.PutInt32(Field_StatusCode, d.statusCode)
+	vb.PutString(Field_LastError, d.LastError)
+	vb.PutInt64(Field_RetryID, d.retryID)
+	if d.retriable() {
+		vb.PutString(Field_Envelope, d.envelope)
+	}
+	return nil
+}
+
+// appWSID is NullWSID -> the current workspace
+func putRetry(st istructs.IState, intents istructs.IIntents, appWSID istructs.WSID, day int32, id int64, retryIn int32, r retry) error {
+	vb, err := outbound.PutRetry(st, intents, qNameViewWebhookRetry, appWSID, day, id, retryIn)
+	if err != nil {
+		// notest
+		return err
+	}
+	vb.PutInt64(field_WSID, int64(r.wsid)) // nolint G115
+	vb.PutInt32(field_DeliveryDay, r.deliveryDay)
+	vb.PutInt64(field_DeliveryID, r.deliveryID)
+	return nil
+}
+
+// Returns the value of the X-Voedger-Signature header: "sha256=" + hex of HMAC-SHA256 of the body
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
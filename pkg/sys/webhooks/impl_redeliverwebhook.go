@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+// called in the workspace of the delivery, the delivery itself is made by ap.sys.WebhookDispatcher
+func execCmdRedeliverWebhook(args istructs.ExecCommandArgs) (err error) {
+	day := args.ArgumentObject.AsInt32(Field_Day)
+	id := args.ArgumentObject.AsInt64(Field_ID)
+	d, ok, err := readDelivery(args.State, istructs.NullWSID, day, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return coreutils.NewHTTPError(http.StatusBadRequest, fmt.Errorf("%w: day %d, id %d", errWebhookDeliveryNotFound, day, id))
+	}
+	if !d.retriable() {
+		return coreutils.NewHTTPError(http.StatusBadRequest, errWebhookDeliveryNotRedelivered)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/sys/outbound"
+)
+
+func TestRegisterAttempt(t *testing.T) {
+	t.Run("pending", func(t *testing.T) {
+		require := require.New(t)
+		d := &delivery{Attempts: outbound.Attempts{Status: Status_Pending}, envelope: "{}"}
+		require.Equal(int32(outbound.FirstRetryInRuns), d.registerAttempt(http.StatusInternalServerError, errors.New("failed")))
+		require.Equal(Status_Pending, d.Status)
+		require.Equal(int32(http.StatusInternalServerError), d.statusCode)
+	})
+
+	t.Run("delivered", func(t *testing.T) {
+		require := require.New(t)
+		d := &delivery{Attempts: outbound.Attempts{Status: Status_Pending, LastError: "failed"}, envelope: "{}"}
+		require.Zero(d.registerAttempt(http.StatusOK, nil))
+		require.Equal(Status_Delivered, d.Status)
+		require.Equal(int32(http.StatusOK), d.statusCode)
+		require.Empty(d.LastError)
+	})
+
+	t.Run("dead letter at once if the envelope is too long", func(t *testing.T) {
+		require := require.New(t)
+		d := &delivery{Attempts: outbound.Attempts{Status: Status_Pending}, envelope: strings.Repeat("x", int(appdef.MaxFieldLength)+1)}
+		require.Zero(d.registerAttempt(0, errors.New("connection refused")))
+		require.Equal(Status_DeadLetter, d.Status)
+		require.Equal("connection refused", d.LastError)
+	})
+}
+
+func TestSign(t *testing.T) {
+	require := require.New(t)
+	// echo -n '{"a":1}' | openssl dgst -sha256 -hmac secret
+	require.Equal("sha256=aa9e2e3575f5d7098b6caccd790888c36d5fdb63342a73bada2d6a51747a8494", Sign([]byte(`{"a":1}`), "secret"))
+}
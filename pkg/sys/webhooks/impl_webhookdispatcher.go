@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/coreutils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/authnz"
+	"github.com/voedger/voedger/pkg/sys/outbound"
+)
+
+func asyncProjectorWebhookDispatcher(time timeu.ITime) istructs.Projector {
+	return istructs.Projector{
+		Name: qNameProjectorWebhookDispatcher,
+		Func: dispatchWebhooks(time),
+	}
+}
+
+// the projector is triggered by the events of all WEBHOOK statements (AFTER WEBHOOK TRIGGERS), so each webhook is matched against the event here
+// the workspace descriptor is read only if the event triggers some webhook
+// the first attempt is made right here, the failed ones are retried by c.sys.RetryWebhook called by sys.WebhookSender job
+// the delivery is at-least-once: the event is handled again if VVM fails before the actualizer offset is saved
+func dispatchWebhooks(tm timeu.ITime) func(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) (err error) {
+	return func(event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) (err error) {
+		switch event.QName() {
+		case qNameCmdRedeliverWebhook:
+			return redeliverWebhook(tm, event, st, intents)
+		case qNameCmdRetryWebhook:
+			return retryWebhook(tm, event, st, intents)
+		}
+		appDef := st.AppStructs().AppDef()
+		var triggered []appdef.IWebhook
+		for _, wh := range appDef.Webhooks() {
+			if webhookTriggered(wh, appDef, event) {
+				triggered = append(triggered, wh)
+			}
+		}
+		if len(triggered) == 0 {
+			return nil
+		}
+		ws, err := eventWorkspace(event, st, appDef)
+		if err != nil {
+			return err
+		}
+		for _, wh := range triggered {
+			if !ws.Inherits(wh.Workspace()) {
+				continue
+			}
+			d := newDelivery(tm.Now(), wh.QName(), event.WLogOffset())
+			envelopeBytes, err := json.Marshal(envelope{
+				Webhook:      wh.QName().String(),
+				WSID:         event.Workspace(),
+				WLogOffset:   event.WLogOffset(),
+				QName:        event.QName().String(),
+				RegisteredAt: event.RegisteredAt(),
+				Argument:     coreutils.ObjectToMap(event.ArgumentObject(), appDef),
+				CUDs:         coreutils.CUDsToMap(event, appDef),
+			})
+			if err != nil {
+				// notest
+				return err
+			}
+			d.envelope = string(envelopeBytes)
+			if !d.retriable() {
+				logger.Error(fmt.Sprintf("webhook %s: envelope of event %d is too long to be retried", wh.QName(), event.WLogOffset()))
+			}
+			retryIn, err := d.deliver(st)
+			if err != nil {
+				return err
+			}
+			if err := scheduleRetry(tm, st, intents, event.Workspace(), d, retryIn); err != nil {
+				return err
+			}
+			if err := putDelivery(st, intents, istructs.NullWSID, d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// the delivery is attempted again from the beginning, the attempts counter is reset
+// the pending retry of the delivery, if any, becomes stale
+func redeliverWebhook(tm timeu.ITime, event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) error {
+	day := event.ArgumentObject().AsInt32(Field_Day)
+	id := event.ArgumentObject().AsInt64(Field_ID)
+	d, ok, err := readDelivery(st, istructs.NullWSID, day, id)
+	if err != nil {
+		return err
+	}
+	if !ok || !d.retriable() {
+		// checked by the command already
+		return nil
+	}
+	d.Count = 0
+	return attemptAgain(tm, event, st, intents, d)
+}
+
+// the retry is ignored if the delivery is redelivered or retried by the other entry of sys.WebhookRetry meanwhile
+func retryWebhook(tm timeu.ITime, event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents) error {
+	day := event.ArgumentObject().AsInt32(Field_Day)
+	id := event.ArgumentObject().AsInt64(Field_ID)
+	d, ok, err := readDelivery(st, istructs.NullWSID, day, id)
+	if err != nil {
+		return err
+	}
+	if !ok || d.Status != Status_Pending || d.retryID != event.ArgumentObject().AsInt64(Field_RetryID) {
+		return nil
+	}
+	if err := attemptAgain(tm, event, st, intents, d); err != nil {
+		return err
+	}
+	if d.Status != Status_Delivered {
+		logger.Verbose(fmt.Sprintf("webhook %s delivery %d/%d attempt %d failed: %s", d.webhook, day, id, d.Count, d.LastError))
+	}
+	return nil
+}
+
+func attemptAgain(tm timeu.ITime, event istructs.IPLogEvent, st istructs.IState, intents istructs.IIntents, d *delivery) error {
+	retryIn, err := d.deliver(st)
+	if err != nil {
+		return err
+	}
+	if err := scheduleRetry(tm, st, intents, event.Workspace(), d, retryIn); err != nil {
+		return err
+	}
+	return putDelivery(st, intents, istructs.NullWSID, d)
+}
+
+// puts the new entry to sys.WebhookRetry of the application workspace, retryIn is 0 -> the delivery is not retried anymore
+func scheduleRetry(tm timeu.ITime, st istructs.IState, intents istructs.IIntents, wsid istructs.WSID, d *delivery, retryIn int32) error {
+	if retryIn == 0 {
+		d.retryID = 0
+		return nil
+	}
+	now := tm.Now()
+	d.retryID = newID(now)
+	appWSID := coreutils.PseudoWSIDToAppWSID(wsid, st.AppStructs().NumAppWorkspaces())
+	return putRetry(st, intents, appWSID, outbound.Day(now), d.retryID, retryIn, retry{wsid: wsid, deliveryDay: d.day, deliveryID: d.id})
+}
+
+func eventWorkspace(event istructs.IPLogEvent, st istructs.IState, appDef appdef.IAppDef) (appdef.IWorkspace, error) {
+	kb, err := st.KeyBuilder(sys.Storage_Record, appdef.QNameCDocWorkspaceDescriptor)
+	if err != nil {
+		// notest
+		return nil, err
+	}
+	kb.PutBool(sys.Storage_Record_Field_IsSingleton, true)
+	kb.PutInt64(sys.Storage_Record_Field_WSID, int64(event.Workspace())) // nolint G115
+	sv, err := st.MustExist(kb)
+	if err != nil {
+		return nil, err
+	}
+	return appDef.WorkspaceByDescriptor(sv.AsQName(authnz.Field_WSKind)), nil
+}
+
+// the same rules as for projectors, see actualizers.ProjectorEvent
+func webhookTriggered(wh appdef.IWebhook, appDef appdef.IAppDef, event istructs.IPLogEvent) bool {
+	switch event.QName() {
+	case istructs.QNameForError, istructs.QNameForCorruptedData:
+		return false
+	}
+	if wh.Triggers(appdef.OperationKind_Execute, appDef.Type(event.QName())) {
+		return true
+	}
+	if arg := event.ArgumentObject().QName(); arg != appdef.NullQName {
+		if wh.Triggers(appdef.OperationKind_ExecuteWithParam, appDef.Type(arg)) {
+			return true
+		}
+	}
+	for rec := range event.CUDs {
+		t := appDef.Type(rec.QName())
+		switch {
+		case rec.IsNew():
+			if wh.Triggers(appdef.OperationKind_Insert, t) {
+				return true
+			}
+		case wh.Triggers(appdef.OperationKind_Update, t):
+			return true
+		case rec.IsDeactivated():
+			if wh.Triggers(appdef.OperationKind_Deactivate, t) {
+				return true
+			}
+		case rec.IsActivated():
+			if wh.Triggers(appdef.OperationKind_Activate, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/sys"
+	"github.com/voedger/voedger/pkg/sys/outbound"
+)
+
+// the due delivery is retried by c.sys.RetryWebhook in the workspace of the delivery, so all attempts of the delivery
+// are made by sys.WebhookDispatcher in the partition of that workspace, one by one with c.sys.RedeliverWebhook
+func webhookSenderJob(tm timeu.ITime) func(st istructs.IState, intents istructs.IIntents) error {
+	return func(st istructs.IState, intents istructs.IIntents) error {
+		return outbound.ReadRetries(st, qNameViewWebhookRetry, tm.Now(), func(day int32, id int64, retryIn int32, value istructs.IStateValue) error {
+			r := retry{
+				wsid:        istructs.WSID(value.AsInt64(field_WSID)), // nolint G115
+				deliveryDay: value.AsInt32(field_DeliveryDay),
+				deliveryID:  value.AsInt64(field_DeliveryID),
+			}
+			if retryIn > 1 {
+				return putRetry(st, intents, istructs.NullWSID, day, id, retryIn-1, r)
+			}
+			if err := callRetryWebhook(st, r, id); err != nil {
+				// the entry is kept, so the command is called again on the next run
+				logger.Error(fmt.Sprintf("webhook delivery %d/%d in workspace %d: %s", r.deliveryDay, r.deliveryID, r.wsid, err))
+				return nil
+			}
+			return putRetry(st, intents, istructs.NullWSID, day, id, 0, r)
+		})
+	}
+}
+
+func callRetryWebhook(st istructs.IState, r retry, retryID int64) error {
+	body, err := json.Marshal(map[string]any{
+		"args": map[string]any{
+			Field_Day:     r.deliveryDay,
+			Field_ID:      r.deliveryID,
+			Field_RetryID: retryID,
+		},
+	})
+	if err != nil {
+		// notest
+		return err
+	}
+	kb, err := st.KeyBuilder(sys.Storage_FederationCommand, appdef.NullQName)
+	if err != nil {
+		// notest
+		return err
+	}
+	kb.PutInt64(sys.Storage_FederationCommand_Field_WSID, int64(r.wsid)) // nolint G115
+	kb.PutQName(sys.Storage_FederationCommand_Field_Command, qNameCmdRetryWebhook)
+	kb.PutString(sys.Storage_FederationCommand_Field_Body, string(body))
+	_, err = st.MustExist(kb)
+	return err
+}
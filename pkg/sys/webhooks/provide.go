@@ -0,0 +1,26 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package webhooks
+
+import (
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istructsmem"
+)
+
+func Provide(sr istructsmem.IStatelessResources, time timeu.ITime) {
+	sr.AddCommands(appdef.SysPackagePath, istructsmem.NewCommandFunction(
+		qNameCmdRedeliverWebhook,
+		execCmdRedeliverWebhook,
+	), istructsmem.NewCommandFunction(
+		qNameCmdRetryWebhook,
+		istructsmem.NullCommandExec,
+	))
+	sr.AddProjectors(appdef.SysPackagePath, asyncProjectorWebhookDispatcher(time))
+	sr.AddJobs(appdef.SysPackagePath, istructsmem.BuiltinJob{
+		Name: qNameJobWebhookSender,
+		Func: webhookSenderJob(time),
+	})
+}
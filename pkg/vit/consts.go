@@ -26,6 +26,7 @@ const (
 	testEmailsAwaitingTimeout                  = 5 * time.Second
 	testRegistryPartsNum                       = 2
 	testAppPartsNum                            = 5
+	webhookReceiverRequestsBuf                 = 100
 	WSAECONNREFUSED              syscall.Errno = 10061
 )
 
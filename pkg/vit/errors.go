@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vit
+
+import "errors"
+
+var errTestEmailSendFailure = errors.New("test email send failure")
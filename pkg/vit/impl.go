@@ -38,6 +38,7 @@ import (
 
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorage/cas"
+	"github.com/voedger/voedger/pkg/istorage/faulty"
 	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istructs"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
@@ -160,6 +161,7 @@ func newVit(t testing.TB, vitCfg *VITConfig, useCas bool, vvmLaunchOnly bool) *V
 		configCleanupsAmount: len(vitPreConfig.cleanups),
 		emailCaptor:          emailCaptor,
 		mockTime:             testingu.MockTime,
+		storageFaults:        vitPreConfig.faults,
 	}
 	httpClient, httpClientCleanup := httpu.NewIHTTPClient(httpu.WithRetryPolicy(vitHTTPClientRetryPolicy...))
 	vit.httpClient = httpClient
@@ -656,6 +658,15 @@ func (vit *VIT) CaptureEmail() (msg state.EmailMessage) {
 	return
 }
 
+// next `times` emails will fail to be sent and will not be captured
+// will be automatically reset to 0 on TearDown
+func (vit *VIT) FailEmailSending(times int32) {
+	vit.emailCaptor.failuresLeft.Store(times)
+	vit.cleanups = append(vit.cleanups, func(vit *VIT) {
+		vit.emailCaptor.failuresLeft.Store(0)
+	})
+}
+
 // sets delay on IAppStorage.Get() in mem implementation
 // will be automatically reset to 0 on TearDown
 // need to e.g. investigate slow workspace create, see https://github.com/voedger/voedger/issues/1663
@@ -682,6 +693,32 @@ func (vit *VIT) SetMemStoragePutDelay(delay time.Duration) {
 	})
 }
 
+// injects the fault into the storage of the app, see [WithStorageFaults]
+// will be automatically removed on TearDown
+// keyspace of the app is defined as for the app deployed first, i.e. without SafeAppName uniquity suffix
+func (vit *VIT) InjectStorageFault(appQName appdef.AppQName, fault faulty.Fault) (remove func()) {
+	vit.T.Helper()
+	if vit.storageFaults == nil {
+		vit.T.Fatal("storage faults are not enabled, use vit.WithStorageFaults()")
+	}
+	safeAppName, err := istorage.NewSafeAppName(appQName, func(string) (bool, error) { return true, nil })
+	require.NoError(vit.T, err)
+	safeAppName.ApplyKeyspaceIsolationSuffix(vit.KeyspaceIsolationSuffix)
+	fault.Keyspaces = []string{safeAppName.String()}
+	remove = vit.storageFaults.Inject(fault)
+	vit.cleanups = append(vit.cleanups, func(*VIT) { remove() })
+	return remove
+}
+
+// returns the number of faulty storage calls, see [VIT.InjectStorageFault]
+func (vit *VIT) StorageFaultsInjected() int {
+	vit.T.Helper()
+	if vit.storageFaults == nil {
+		vit.T.Fatal("storage faults are not enabled, use vit.WithStorageFaults()")
+	}
+	return vit.storageFaults.Injected()
+}
+
 func (vit *VIT) iterateDelaySetters(cb func(delaySetter istorage.IStorageDelaySetter)) {
 	vit.T.Helper()
 	for anyAppQName := range vit.VVMAppsBuilder {
@@ -723,6 +760,11 @@ func (vit *VIT) checkVVMProblemCtx() {
 }
 
 func (c *implIEmailSender_captor) Send(host string, msg state.EmailMessage, opts ...mail.Option) error {
+	for left := c.failuresLeft.Load(); left > 0; left = c.failuresLeft.Load() {
+		if c.failuresLeft.CompareAndSwap(left, left-1) {
+			return errTestEmailSendFailure
+		}
+	}
 	c.emailCaptorCh <- msg
 	return nil
 }
@@ -11,6 +11,9 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/extensionpoints"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/faulty"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/sys/smtp"
 	"github.com/voedger/voedger/pkg/sys/workspace"
@@ -152,6 +155,22 @@ func WithVVMConfig(configurer func(cfg *vvm.VVMConfig)) VITConfigOptFunc {
 	}
 }
 
+// storage of the VVM injects faults, see [VIT.InjectStorageFault]
+// seed is used for probabilistic faults
+func WithStorageFaults(seed int64) VITConfigOptFunc {
+	return func(vpc *vitPreConfig) {
+		storageFactory := vpc.vvmCfg.StorageFactory
+		vpc.vvmCfg.StorageFactory = func(time timeu.ITime) (istorage.IAppStorageFactory, error) {
+			asf, err := storageFactory(time)
+			if err != nil {
+				return nil, err
+			}
+			asf, vpc.faults = faulty.Provide(asf, seed)
+			return asf, nil
+		}
+	}
+}
+
 func WithCleanup(cleanup func(*VIT)) VITConfigOptFunc {
 	return func(hpc *vitPreConfig) {
 		hpc.cleanups = append(hpc.cleanups, cleanup)
@@ -7,14 +7,20 @@ package vit
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"time"
 
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/extensionpoints"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
 	"github.com/voedger/voedger/pkg/iauthnz"
+	"github.com/voedger/voedger/pkg/ioidc"
+	"github.com/voedger/voedger/pkg/ioidcimpl"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/parser"
@@ -66,6 +72,29 @@ const (
 	Field_Blob            = "Blob"
 	Field_BlobReadDenied  = "BlobReadDenied"
 	testSMTPPwdSecretName = "smtp-pwd-secret-name"
+
+	// per-app SMTP settings of test1/app1, used if the Host is not specified in sys.SendMail
+	testApp1SMTPSecretName = "smtp-test1-app1"
+	TestApp1SMTPFrom       = "app1@test1.com"
+
+	// see WEBHOOK TestWebhook in schemaTestApp1.vsql, the URL is the one of TestWebhookReceiver
+	testWebhookURLSecretName = "test-webhook-url"
+	TestWebhookSecretName    = "webhook-secret-name"
+	TestWebhookSecret        = "webhookSecret"
+
+	// test1/app1 users could sign in by the OpenID Connect identity provider, see TestFakeIdP
+	// the callback is not requested by the identity provider so the redirect URL is not the actual one
+	testOIDCClientID         = "test1.app1"
+	testOIDCClientSecret     = "oidcClientSecret"
+	testOIDCClientSecretName = "oidc-client-secret-name"
+	testOIDCRedirectURL      = "http://localhost/api/v2/apps/test1/app1/auth/oidc/callback"
+)
+
+var (
+	testFakeIdP             *ioidcimpl.FakeIdP
+	testFakeIdPOnce         sync.Once
+	testWebhookReceiver     *WebhookReceiver
+	testWebhookReceiverOnce sync.Once
 )
 
 var (
@@ -85,6 +114,7 @@ var (
 	QNameApp1_CDocDaily                      = appdef.NewQName(app1PkgName, "Daily")
 	QNameApp1_CDocCurrency                   = appdef.NewQName(app1PkgName, "Currency")
 	QNameApp1_CDocCountry                    = appdef.NewQName(app1PkgName, "Country")
+	QNameApp1_CDocPayment                    = appdef.NewQName(app1PkgName, "Payment")
 	QNameApp1_CDocCfg                        = appdef.NewQName(app1PkgName, "Cfg")
 	QNameApp1_CDocBatch                      = appdef.NewQName(app1PkgName, "Batch")
 	QNameApp1_CRecordTask                    = appdef.NewQName(app1PkgName, "Task")
@@ -95,8 +125,14 @@ var (
 	QNameQryRated                            = appdef.NewQName(app1PkgName, "RatedQry")
 	QNameCmdIPRated                          = appdef.NewQName(app1PkgName, "IPRatedCmd")
 	QNameQryIPRated                          = appdef.NewQName(app1PkgName, "IPRatedQry")
+	QNameCmdDistributedRated                 = appdef.NewQName(app1PkgName, "DistributedRatedCmd")
 	QNameODoc1                               = appdef.NewQName(app1PkgName, "odoc1")
 	QNameODoc2                               = appdef.NewQName(app1PkgName, "odoc2")
+	QNameApp1_CDocWebhookTestDoc             = appdef.NewQName(app1PkgName, "WebhookTestDoc")
+	QNameApp1_TestWebhook                    = appdef.NewQName(app1PkgName, "TestWebhook")
+	QNameApp1_TestInboundWebhook             = appdef.NewQName(app1PkgName, "TestInboundWebhook")
+	QNameApp1_TestInboundWebhookRaw          = appdef.NewQName(app1PkgName, "TestInboundWebhookRaw")
+	QNameApp1_CmdSendTestEmail               = appdef.NewQName(app1PkgName, "SendTestEmail")
 	TestSMTPCfg                              = smtp.Cfg{
 		Host:      "smtp.testserver.com",
 		Port:      1,
@@ -127,8 +163,19 @@ var (
 			cfg.BLOBMaxSize = app1_BLOBMaxSize
 
 			cfg.SMTPConfig = TestSMTPCfg
+
+			oidcProvider := TestFakeIdP().ProviderConfig(testOIDCRedirectURL)
+			oidcProvider.ClientSecretName = testOIDCClientSecretName
+			cfg.OIDCProviders = ioidc.ProvidersConfig{istructs.AppQName_test1_app1: oidcProvider}
 		}),
 		WithSecret(testSMTPPwdSecretName, []byte("smtpPassword")),
+		WithSecret(testApp1SMTPSecretName, []byte(`{"Host":"localhost","Port":1,"Username":"app1","Password":"app1Password","From":"`+TestApp1SMTPFrom+`"}`)),
+		WithSecret(testOIDCClientSecretName, []byte(testOIDCClientSecret)),
+		WithSecret(TestWebhookSecretName, []byte(TestWebhookSecret)),
+		func(vpc *vitPreConfig) {
+			// the receiver is started lazily, so the secret can not be set by WithSecret
+			vpc.secrets[testWebhookURLSecretName] = []byte(TestWebhookReceiver().URL + "/webhook")
+		},
 		WithCleanup(func(_ *VIT) {
 			MockCmdExec = func(input string, args istructs.ExecCommandArgs) error { panic("") }
 			MockQryExec = func(input string, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) error { panic("") }
@@ -138,6 +185,40 @@ var (
 	MockCmdExec func(input string, args istructs.ExecCommandArgs) error
 )
 
+// TestFakeIdP returns the OpenID Connect identity provider configured for test1/app1 in SharedConfig_App1
+// started on the first call, kept running until the tests process exits
+func TestFakeIdP() *ioidcimpl.FakeIdP {
+	testFakeIdPOnce.Do(func() {
+		testFakeIdP = ioidcimpl.NewFakeIdP(testOIDCClientID, testingu.MockTime)
+		testFakeIdP.ClientSecret = testOIDCClientSecret
+	})
+	return testFakeIdP
+}
+
+// TestWebhookReceiver returns the receiver of WEBHOOK TestWebhook configured for test1/app1 in SharedConfig_App1
+// started on the first call on a random port, kept running until the tests process exits
+func TestWebhookReceiver() *WebhookReceiver {
+	testWebhookReceiverOnce.Do(func() {
+		receiver := &WebhookReceiver{Requests: make(chan WebhookRequest, webhookReceiverRequestsBuf)}
+		receiver.StatusCode.Store(http.StatusOK)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			select {
+			case receiver.Requests <- WebhookRequest{Header: r.Header, Body: body}:
+			default:
+			}
+			w.WriteHeader(int(receiver.StatusCode.Load()))
+		}))
+		receiver.URL = server.URL
+		testWebhookReceiver = receiver
+	})
+	return testWebhookReceiver
+}
+
 func ProvideApp2(apis builtinapps.APIs, cfg *istructsmem.AppConfigType, ep extensionpoints.IExtensionPoint) builtinapps.Def {
 	sysPackageFS := sysprovide.Provide(cfg)
 	app2PackageFS := parser.PackageFS{
@@ -252,6 +333,11 @@ func ProvideApp1(apis builtinapps.APIs, cfg *istructsmem.AppConfigType, ep exten
 		istructsmem.NullCommandExec,
 	))
 
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameCmdDistributedRated,
+		istructsmem.NullCommandExec,
+	))
+
 	cfg.Resources.Add(istructsmem.NewQueryFunction(
 		appdef.NewQName(app1PkgName, "MockQry"),
 		func(_ context.Context, args istructs.ExecQueryArgs, callback istructs.ExecQueryCallback) (err error) {
@@ -298,6 +384,11 @@ func ProvideApp1(apis builtinapps.APIs, cfg *istructsmem.AppConfigType, ep exten
 		},
 	))
 
+	cfg.Resources.Add(istructsmem.NewCommandFunction(
+		QNameApp1_CmdSendTestEmail,
+		istructsmem.NullCommandExec,
+	))
+
 	cfg.Resources.Add(istructsmem.NewCommandFunction(
 		appdef.NewQName(app1PkgName, "CmdODocOne"),
 		istructsmem.NullCommandExec,
@@ -321,6 +412,24 @@ func ProvideApp1(apis builtinapps.APIs, cfg *istructsmem.AppConfigType, ep exten
 			Name: appdef.NewQName(app1PkgName, "ProjDummyTestView"),
 			Func: func(istructs.IPLogEvent, istructs.IState, istructs.IIntents) (err error) { return nil },
 		},
+		istructs.Projector{
+			// Host is not specified -> per-app SMTP settings are used
+			Name: appdef.NewQName(app1PkgName, "ProjectorSendTestEmail"),
+			Func: func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) (err error) {
+				kb, err := s.KeyBuilder(sys.Storage_SendMail, appdef.NullQName)
+				if err != nil {
+					return err
+				}
+				kb.PutString(sys.Storage_SendMail_Field_To, event.ArgumentObject().AsString("To"))
+				kb.PutString(sys.Storage_SendMail_Field_Subject, "Test email")
+				kb.PutString(sys.Storage_SendMail_Field_Body, "Test body")
+				if attachment := event.ArgumentObject().AsInt64("Attachment"); attachment != 0 {
+					kb.PutInt64(sys.Storage_SendMail_Field_Attachment, attachment)
+				}
+				_, err = intents.NewValue(kb)
+				return err
+			},
+		},
 		istructs.Projector{
 			Name: appdef.NewQName(app1PkgName, "ApplyClient"),
 			Func: func(event istructs.IPLogEvent, s istructs.IState, intents istructs.IIntents) (err error) {
@@ -6,7 +6,9 @@ package vit
 
 import (
 	"context"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/voedger/voedger/pkg/appdef"
@@ -14,6 +16,7 @@ import (
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/testingu"
 	"github.com/voedger/voedger/pkg/isecrets"
+	"github.com/voedger/voedger/pkg/istorage/faulty"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/parser"
 	"github.com/voedger/voedger/pkg/state"
@@ -38,6 +41,23 @@ type VIT struct {
 	httpClient           httpu.IHTTPClient
 	mockTime             testingu.IMockTime
 	vvmProblemCtx        context.Context
+	storageFaults        faulty.IFaults
+}
+
+// WebhookReceiver receives the deliveries of WEBHOOK TestWebhook, see TestWebhookReceiver
+type WebhookReceiver struct {
+	URL string
+
+	// received requests, the request is dropped if nobody reads the channel
+	Requests chan WebhookRequest
+
+	// status code of the replies, 200 by default
+	StatusCode atomic.Int32
+}
+
+type WebhookRequest struct {
+	Header http.Header
+	Body   []byte
 }
 
 type VITConfig struct {
@@ -54,6 +74,7 @@ type vitPreConfig struct {
 	initFuncs    []func()
 	postInitFunc func(vit *VIT)
 	secrets      map[string][]byte
+	faults       faulty.IFaults
 }
 
 type VITConfigOptFunc func(*vitPreConfig)
@@ -157,6 +178,7 @@ type implVITISecretsReader struct {
 
 type implIEmailSender_captor struct {
 	emailCaptorCh chan state.EmailMessage
+	failuresLeft  atomic.Int32
 }
 
 // cache for sys/registry, sys/cluster
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vsqllsp
+
+import "github.com/voedger/voedger/pkg/appdef"
+
+// ServerName is the name the server reports to clients
+const ServerName = "vsql-lsp"
+
+const headerContentLength = "Content-Length"
+
+// max message body size, larger messages are rejected
+const maxContentLength = 16 * 1024 * 1024
+
+const jsonRPCVersion = "2.0"
+
+// LSP methods handled by the server
+const (
+	methodInitialize         = "initialize"
+	methodInitialized        = "initialized"
+	methodShutdown           = "shutdown"
+	methodExit               = "exit"
+	methodDidOpen            = "textDocument/didOpen"
+	methodDidChange          = "textDocument/didChange"
+	methodDidSave            = "textDocument/didSave"
+	methodDidClose           = "textDocument/didClose"
+	methodDefinition         = "textDocument/definition"
+	methodHover              = "textDocument/hover"
+	methodCompletion         = "textDocument/completion"
+	methodReferences         = "textDocument/references"
+	methodPublishDiagnostics = "textDocument/publishDiagnostics"
+)
+
+// JSON-RPC error codes
+const (
+	errCodeParseError     = -32700
+	errCodeInvalidRequest = -32600
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+)
+
+const (
+	textDocumentSyncFull     = 1
+	diagnosticSeverityError  = 1
+	markupKindMarkdown       = "markdown"
+	diagnosticSource         = "vsql"
+	completionTriggerPackage = "."
+	fileURIScheme            = "file"
+)
+
+// LSP completion item kinds
+const (
+	completionKindModule    = 9
+	completionKindClass     = 7
+	completionKindInterface = 8
+	completionKindFunction  = 3
+	completionKindEvent     = 23
+	completionKindConstant  = 21
+	completionKindStruct    = 22
+	completionKindReference = 18
+)
+
+// VSQL names of system data types
+var vsqlDataTypes = map[appdef.QName]string{
+	appdef.SysData_int8:      "int8",
+	appdef.SysData_int16:     "int16",
+	appdef.SysData_int32:     "int32",
+	appdef.SysData_int64:     "int64",
+	appdef.SysData_float32:   "float32",
+	appdef.SysData_float64:   "float64",
+	appdef.SysData_bytes:     "varbinary",
+	appdef.SysData_String:    "varchar",
+	appdef.SysData_QName:     "qname",
+	appdef.SysData_bool:      "bool",
+	appdef.SysData_RecordID:  "ref",
+	appdef.SysData_Timestamp: "timestamp",
+	appdef.SysData_Currency:  "currency",
+}
+
+// completion item kinds by VSQL statement kind, see [parser.Symbol.Kind]
+var completionKinds = map[string]int{
+	"WORKSPACE": completionKindModule,
+	"TABLE":     completionKindClass,
+	"VIEW":      completionKindClass,
+	"TYPE":      completionKindStruct,
+	"COMMAND":   completionKindFunction,
+	"QUERY":     completionKindFunction,
+	"FUNCTION":  completionKindFunction,
+	"PROJECTOR": completionKindEvent,
+	"JOB":       completionKindEvent,
+	"ROLE":      completionKindConstant,
+	"TAG":       completionKindConstant,
+	"RATE":      completionKindConstant,
+	"LIMIT":     completionKindConstant,
+	"STORAGE":   completionKindInterface,
+	"DECLARE":   completionKindConstant,
+	"TEMPLATE":  completionKindReference,
+}
@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vsqllsp
+
+import "errors"
+
+var (
+	ErrInvalidHeader   = errors.New("invalid message header")
+	ErrInvalidMessage  = errors.New("invalid message")
+	ErrMessageTooLarge = errors.New("message is too large")
+)
@@ -0,0 +1,435 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vsqllsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/compile"
+	"github.com/voedger/voedger/pkg/coreutils"
+)
+
+func newServer(version string, compileFn func(dir string) (*compile.Result, error), out io.Writer) *server {
+	return &server{
+		version:  version,
+		compile:  compileFn,
+		out:      out,
+		docs:     map[string]string{},
+		packages: map[string]*packageIndex{},
+	}
+}
+
+// reads and handles messages until exit notification is received, input is closed or context is done
+func (s *server) serve(ctx context.Context, in io.Reader) error {
+	r := bufio.NewReader(in)
+	for ctx.Err() == nil {
+		msg, err := readMessage(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if errors.Is(err, ErrInvalidMessage) || errors.Is(err, ErrInvalidHeader) {
+				if err := s.reply(nil, nil, &responseError{Code: errCodeParseError, Message: err.Error()}); err != nil {
+					return err
+				}
+				continue
+			}
+			if errors.Is(err, ErrMessageTooLarge) {
+				if err := s.reply(nil, nil, &responseError{Code: errCodeInvalidRequest, Message: err.Error()}); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+		if msg.Method == methodExit {
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) handle(msg *message) error {
+	if len(msg.ID) == 0 {
+		s.notification(msg.Method, msg.Params)
+		return nil
+	}
+	result, err := s.request(msg.Method, msg.Params)
+	return s.reply(msg.ID, result, err)
+}
+
+func (s *server) request(method string, params json.RawMessage) (any, *responseError) {
+	switch method {
+	case methodInitialize:
+		return s.initialize(), nil
+	case methodShutdown:
+		return nil, nil
+	case methodDefinition:
+		p := textDocumentPositionParams{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.definition(p), nil
+	case methodHover:
+		p := textDocumentPositionParams{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.hover(p), nil
+	case methodCompletion:
+		p := textDocumentPositionParams{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.completion(p), nil
+	case methodReferences:
+		p := referenceParams{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.references(p), nil
+	}
+	return nil, &responseError{Code: errCodeMethodNotFound, Message: "method not found: " + method}
+}
+
+// notifications have no response, so errors in params are ignored
+func (s *server) notification(method string, params json.RawMessage) {
+	switch method {
+	case methodDidOpen:
+		p := didOpenParams{}
+		if json.Unmarshal(params, &p) == nil {
+			file := uriToPath(p.TextDocument.URI)
+			s.docs[file] = p.TextDocument.Text
+			s.check(file)
+		}
+	case methodDidChange:
+		p := didChangeParams{}
+		if json.Unmarshal(params, &p) == nil && len(p.ContentChanges) > 0 {
+			// full document sync, the last change contains the whole text
+			s.docs[uriToPath(p.TextDocument.URI)] = p.ContentChanges[len(p.ContentChanges)-1].Text
+		}
+	case methodDidSave:
+		p := didSaveParams{}
+		if json.Unmarshal(params, &p) == nil {
+			s.check(uriToPath(p.TextDocument.URI))
+		}
+	case methodDidClose:
+		p := didSaveParams{}
+		if json.Unmarshal(params, &p) == nil {
+			delete(s.docs, uriToPath(p.TextDocument.URI))
+		}
+	}
+}
+
+func (s *server) reply(id json.RawMessage, result any, respErr *responseError) error {
+	msg := &message{ID: id, Error: respErr}
+	if id == nil {
+		msg.ID = json.RawMessage("null")
+	}
+	if respErr == nil {
+		res, err := json.Marshal(result)
+		if err != nil {
+			// notest
+			return err
+		}
+		msg.Result = res
+	}
+	return writeMessage(s.out, msg)
+}
+
+func (s *server) notify(method string, params any) error {
+	p, err := json.Marshal(params)
+	if err != nil {
+		// notest
+		return err
+	}
+	return writeMessage(s.out, &message{Method: method, Params: p})
+}
+
+func (s *server) initialize() initializeResult {
+	res := initializeResult{ServerInfo: serverInfo{Name: ServerName, Version: s.version}}
+	res.Capabilities.TextDocumentSync.OpenClose = true
+	res.Capabilities.TextDocumentSync.Change = textDocumentSyncFull
+	res.Capabilities.TextDocumentSync.Save = true
+	res.Capabilities.DefinitionProvider = true
+	res.Capabilities.HoverProvider = true
+	res.Capabilities.ReferencesProvider = true
+	res.Capabilities.CompletionProvider.TriggerCharacters = []string{completionTriggerPackage}
+	return res
+}
+
+// compiles the package of the file and publishes diagnostics for the package files
+func (s *server) check(file string) {
+	dir := filepath.Dir(file)
+	res, err := s.compile(dir)
+	idx := newPackageIndex(dir, res, err)
+	s.packages[dir] = idx
+
+	diagnostics := map[string][]diagnostic{file: {}}
+	if res != nil {
+		for _, f := range res.PkgFiles[idx.pkgPath] {
+			diagnostics[f] = []diagnostic{}
+		}
+	}
+	if err != nil {
+		for _, e := range coreutils.SplitErrors(err) {
+			f, d := errorDiagnostic(dir, e.Error())
+			if _, ok := diagnostics[f]; !ok {
+				// error in the dependency or without position
+				f = file
+			}
+			diagnostics[f] = append(diagnostics[f], d)
+		}
+	}
+	files := make([]string, 0, len(diagnostics))
+	for f := range diagnostics {
+		files = append(files, f)
+	}
+	slices.Sort(files)
+	for _, f := range files {
+		_ = s.notify(methodPublishDiagnostics, publishDiagnosticsParams{URI: pathToURI(f), Diagnostics: diagnostics[f]})
+	}
+}
+
+// returns index of the file package, compiles package if not compiled yet
+func (s *server) index(file string) *packageIndex {
+	dir := filepath.Dir(file)
+	if idx, ok := s.packages[dir]; ok {
+		return idx
+	}
+	res, err := s.compile(dir)
+	idx := newPackageIndex(dir, res, err)
+	s.packages[dir] = idx
+	return idx
+}
+
+func (s *server) definition(p textDocumentPositionParams) []location {
+	file := uriToPath(p.TextDocument.URI)
+	idx := s.index(file)
+	key, _, ok := idx.symbolAt(file, p.Position)
+	if !ok {
+		return []location{}
+	}
+	if d, ok := idx.decls[key]; ok {
+		return []location{d.location()}
+	}
+	return []location{}
+}
+
+func (s *server) references(p referenceParams) []location {
+	file := uriToPath(p.TextDocument.URI)
+	idx := s.index(file)
+	key, _, ok := idx.symbolAt(file, p.Position)
+	if !ok {
+		return []location{}
+	}
+	locs := []location{}
+	if d, ok := idx.decls[key]; ok && p.Context.IncludeDeclaration {
+		locs = append(locs, d.location())
+	}
+	for _, u := range idx.usages {
+		if u.key == key {
+			locs = append(locs, u.location())
+		}
+	}
+	return locs
+}
+
+func (s *server) hover(p textDocumentPositionParams) *hover {
+	file := uriToPath(p.TextDocument.URI)
+	idx := s.index(file)
+	key, rng, ok := idx.symbolAt(file, p.Position)
+	if !ok {
+		return nil
+	}
+	b := strings.Builder{}
+	if d, ok := idx.decls[key]; ok {
+		fmt.Fprintf(&b, "**%s** `%s`  \n", d.symbol.Kind, key.name)
+	} else {
+		fmt.Fprintf(&b, "`%s`  \n", key.name)
+	}
+	fmt.Fprintf(&b, "package `%s`\n", key.pkgPath)
+	if t := idx.appDefType(key); t != nil {
+		fmt.Fprintf(&b, "\n%s `%s`\n", t.Kind().TrimString(), t.QName())
+		if ff, ok := t.(appdef.IWithFields); ok {
+			for _, f := range ff.UserFields() {
+				fmt.Fprintf(&b, "- `%s` %s", f.Name(), fieldTypeName(f))
+				if f.Required() {
+					b.WriteString(" NOT NULL")
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+	if d, ok := idx.decls[key]; ok && len(d.symbol.Comments) > 0 {
+		b.WriteString("\n")
+		b.WriteString(strings.Join(d.symbol.Comments, "\n"))
+		b.WriteString("\n")
+	}
+	return &hover{
+		Contents: markupContent{Kind: markupKindMarkdown, Value: b.String()},
+		Range:    &rng,
+	}
+}
+
+// returns the field type name as declared in VSQL, e.g. `currency`, `varchar(100)` or `ref(mypkg.Table)`
+func fieldTypeName(f appdef.IField) string {
+	if ref, ok := f.(appdef.IRefField); ok && len(ref.Refs()) > 0 {
+		refs := make([]string, 0, len(ref.Refs()))
+		for _, r := range ref.Refs() {
+			refs = append(refs, r.String())
+		}
+		return fmt.Sprintf("ref(%s)", strings.Join(refs, ", "))
+	}
+	d := f.Data()
+	if d == nil {
+		return f.DataKind().TrimString()
+	}
+	// anonymous data type, e.g. varchar(100) or currency(18,2), has constraints and system ancestor
+	args := ""
+	if d.QName() == appdef.NullQName {
+		cc := d.Constraints(true)
+		if c, ok := cc[appdef.ConstraintKind_MaxLen]; ok {
+			args = fmt.Sprint("(", c.Value(), ")")
+		}
+		p, okP := cc[appdef.ConstraintKind_Precision]
+		s, okS := cc[appdef.ConstraintKind_Scale]
+		if okP && okS {
+			args = fmt.Sprint("(", p.Value(), ",", s.Value(), ")")
+		}
+	}
+	for d.QName() == appdef.NullQName && d.Ancestor() != nil {
+		d = d.Ancestor()
+	}
+	if name, ok := vsqlDataTypes[d.QName()]; ok {
+		return name + args
+	}
+	return d.QName().String()
+}
+
+// returns symbols visible from the file package: declared in the package itself, in sys package and in imported packages
+func (s *server) completion(p textDocumentPositionParams) []completionItem {
+	file := uriToPath(p.TextDocument.URI)
+	idx := s.index(file)
+
+	// prefix of the (possibly qualified) identifier before the cursor is replaced by the completion
+	rng := textRange{Start: p.Position, End: p.Position}
+	if text, ok := s.text(file); ok {
+		end := offsetOf(text, p.Position)
+		start := end
+		for start > 0 && (isIdentChar(text[start-1]) || text[start-1] == '.') {
+			start--
+		}
+		rng = rangeOf(text, start, end)
+	}
+
+	items := []completionItem{}
+	for key, d := range idx.decls {
+		label, ok := idx.localName(key, idx.pkgPath)
+		if !ok {
+			continue
+		}
+		item := completionItem{
+			Label:    label,
+			Kind:     completionKind(d.symbol.Kind),
+			Detail:   d.symbol.Kind,
+			TextEdit: &textEdit{Range: rng, NewText: label},
+		}
+		if len(d.symbol.Comments) > 0 {
+			item.Documentation = &markupContent{Kind: markupKindMarkdown, Value: strings.Join(d.symbol.Comments, "\n")}
+		}
+		items = append(items, item)
+	}
+	slices.SortFunc(items, func(a, b completionItem) int { return strings.Compare(a.Label, b.Label) })
+	return items
+}
+
+// returns opened document text or file content
+func (s *server) text(file string) (string, bool) {
+	if text, ok := s.docs[file]; ok {
+		return text, true
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", false
+	}
+	return string(content), true
+}
+
+func (l symbolLocation) location() location {
+	return location{URI: pathToURI(l.file), Range: l.rng}
+}
+
+func completionKind(stmtKind string) int {
+	if kind, ok := completionKinds[stmtKind]; ok {
+		return kind
+	}
+	return completionKindReference
+}
+
+// error position prefix, e.g. "schema.vsql:6:25: "
+var errorPosRegexp = regexp.MustCompile(`^(.+?\.(?i:vsql|sql)):(\d+):(\d+):\s*`)
+
+// returns file and diagnostic for the compilation error.
+//
+// Errors without position are located at the beginning of the unknown file
+func errorDiagnostic(dir string, errMsg string) (string, diagnostic) {
+	d := diagnostic{Severity: diagnosticSeverityError, Source: diagnosticSource, Message: errMsg}
+	m := errorPosRegexp.FindStringSubmatch(errMsg)
+	if m == nil {
+		return "", d
+	}
+	line, _ := strconv.Atoi(m[2])
+	col, _ := strconv.Atoi(m[3])
+	pos := position{Line: max(line-1, 0), Character: max(col-1, 0)}
+	d.Range = textRange{Start: pos, End: pos}
+	d.Message = strings.TrimPrefix(errMsg, m[0])
+	file := m[1]
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+	return file, d
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: errCodeInvalidParams, Message: err.Error()}
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != fileURIScheme {
+		return uri
+	}
+	path := u.Path
+	// file:///C:/dir on Windows
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.Clean(filepath.FromSlash(path))
+}
+
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: fileURIScheme, Path: path}).String()
+}
@@ -0,0 +1,234 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vsqllsp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/participle/v2/lexer"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/compile"
+	"github.com/voedger/voedger/pkg/parser"
+)
+
+// builds index of the compiled package directory.
+//
+// Compilation result could be partial if compilation failed
+func newPackageIndex(dir string, result *compile.Result, err error) *packageIndex {
+	idx := &packageIndex{
+		dir:    dir,
+		result: result,
+		err:    err,
+		decls:  map[symbolKey]*declaration{},
+		asts:   map[string]*parser.PackageSchemaAST{},
+	}
+	if result == nil {
+		return idx
+	}
+	idx.pkgPath = result.ModulePath
+
+	sources := map[string]string{}
+	source := func(file string) string {
+		text, ok := sources[file]
+		if !ok {
+			if content, err := os.ReadFile(file); err == nil {
+				text = string(content)
+			}
+			sources[file] = text
+		}
+		return text
+	}
+
+	usages := map[string][]parser.SymbolUsage{}
+	for _, pkg := range result.Packages {
+		pkgDir := idx.packageDir(pkg.Path)
+		if pkgDir == "" {
+			// dummy application package has no files
+			continue
+		}
+		idx.asts[pkg.Path] = pkg
+		decls, pkgUsages := parser.PackageSymbols(pkg)
+		for _, d := range decls {
+			file := filepath.Join(pkgDir, d.Pos.Filename)
+			key := symbolKey{d.PkgPath, d.Name}
+			if _, exists := idx.decls[key]; exists {
+				continue
+			}
+			idx.decls[key] = &declaration{
+				symbolLocation: symbolLocation{
+					key:  key,
+					file: file,
+					rng:  nameRange(source(file), d.Pos, d.Name),
+				},
+				symbol: d,
+			}
+		}
+		usages[pkgDir] = pkgUsages
+	}
+
+	// usages are resolved after all declarations are collected
+	for pkgDir, pkgUsages := range usages {
+		for _, u := range pkgUsages {
+			if u.PkgPath == "" {
+				continue
+			}
+			key := idx.resolve(symbolKey{u.PkgPath, u.Name})
+			file := filepath.Join(pkgDir, u.Pos.Filename)
+			idx.usages = append(idx.usages, symbolLocation{
+				key:  key,
+				file: file,
+				rng:  identRange(source(file), u.Pos),
+			})
+		}
+	}
+	return idx
+}
+
+// returns directory of the package with the specified full path or empty string if package has no files
+func (idx *packageIndex) packageDir(pkgPath string) string {
+	if files := idx.result.PkgFiles[pkgPath]; len(files) > 0 {
+		return filepath.Dir(files[0])
+	}
+	return ""
+}
+
+// unqualified names could refer sys package symbols
+func (idx *packageIndex) resolve(key symbolKey) symbolKey {
+	if _, ok := idx.decls[key]; !ok {
+		sysKey := symbolKey{appdef.SysPackage, key.name}
+		if _, ok := idx.decls[sysKey]; ok {
+			return sysKey
+		}
+	}
+	return key
+}
+
+// returns symbol declared or used at the specified position of the file
+func (idx *packageIndex) symbolAt(file string, pos position) (symbolKey, textRange, bool) {
+	for _, d := range idx.decls {
+		if d.file == file && d.rng.contains(pos) {
+			return d.key, d.rng, true
+		}
+	}
+	for _, u := range idx.usages {
+		if u.file == file && u.rng.contains(pos) {
+			return u.key, u.rng, true
+		}
+	}
+	return symbolKey{}, textRange{}, false
+}
+
+// returns name of the symbol qualified by the local package name used in the specified package,
+// false if package of the symbol is not imported
+func (idx *packageIndex) localName(key symbolKey, pkgPath string) (string, bool) {
+	switch key.pkgPath {
+	case pkgPath:
+		return key.name, true
+	case appdef.SysPackage:
+		return appdef.SysPackage + "." + key.name, true
+	}
+	if pkg, ok := idx.asts[pkgPath]; ok {
+		for _, imp := range pkg.Ast.Imports {
+			if imp.Name == key.pkgPath {
+				return imp.GetLocalPkgName() + "." + key.name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// returns type of the application definition for the symbol, nil if not found
+func (idx *packageIndex) appDefType(key symbolKey) appdef.IType {
+	if idx.result == nil || idx.result.AppDef == nil {
+		return nil
+	}
+	def := idx.result.AppDef
+	var name appdef.QName
+	if key.pkgPath == appdef.SysPackage {
+		name = appdef.NewQName(appdef.SysPackage, key.name)
+	} else {
+		name = def.LocalQName(appdef.NewFullQName(key.pkgPath, key.name))
+	}
+	if t := def.Type(name); t.Kind() != appdef.TypeKind_null {
+		return t
+	}
+	return nil
+}
+
+// returns range of the name which is declared by statement at the specified position
+func nameRange(text string, pos lexer.Position, name string) textRange {
+	start := pos.Offset
+	if start < 0 || start > len(text) {
+		start = 0
+	}
+	for i := strings.Index(text[start:], name); i >= 0; {
+		at := start + i
+		end := at + len(name)
+		if (at == 0 || !isIdentChar(text[at-1])) && (end == len(text) || !isIdentChar(text[end])) {
+			return rangeOf(text, at, end)
+		}
+		start = end
+		i = strings.Index(text[start:], name)
+	}
+	return identRange(text, pos)
+}
+
+// returns range of the (possibly qualified) identifier at the specified position
+func identRange(text string, pos lexer.Position) textRange {
+	start := pos.Offset
+	if start < 0 || start > len(text) {
+		return textRange{
+			Start: position{Line: pos.Line - 1, Character: pos.Column - 1},
+			End:   position{Line: pos.Line - 1, Character: pos.Column - 1},
+		}
+	}
+	end := start
+	for end < len(text) && (isIdentChar(text[end]) || text[end] == '.') {
+		end++
+	}
+	return rangeOf(text, start, end)
+}
+
+// returns range between byte offsets of the text
+func rangeOf(text string, start, end int) textRange {
+	return textRange{Start: positionOf(text, start), End: positionOf(text, end)}
+}
+
+// converts byte offset of the text to LSP position.
+//
+// Characters are counted as bytes, which is correct for identifiers
+func positionOf(text string, offset int) position {
+	line := strings.Count(text[:offset], "\n")
+	lineStart := strings.LastIndex(text[:offset], "\n") + 1
+	return position{Line: line, Character: offset - lineStart}
+}
+
+// converts LSP position to byte offset of the text
+func offsetOf(text string, pos position) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		i := strings.IndexByte(text[offset:], '\n')
+		if i < 0 {
+			return len(text)
+		}
+		offset += i + 1
+	}
+	return min(offset+pos.Character, len(text))
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (r textRange) contains(p position) bool {
+	return !p.before(r.Start) && !r.End.before(p)
+}
+
+func (p position) before(other position) bool {
+	return p.Line < other.Line || p.Line == other.Line && p.Character < other.Character
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vsqllsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+)
+
+// reads the next message framed by the Content-Length header
+func readMessage(r *bufio.Reader) (*message, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if protoErr := textproto.ProtocolError(""); errors.As(err, &protoErr) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+		}
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get(headerContentLength))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("%w: %s: %q", ErrInvalidHeader, headerContentLength, header.Get(headerContentLength))
+	}
+	if length > maxContentLength {
+		// message is skipped to keep reading the next ones
+		if _, err := io.CopyN(io.Discard, r, int64(length)); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s %d exceeds %d", ErrMessageTooLarge, headerContentLength, length, maxContentLength)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	msg := &message{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+	return msg, nil
+}
+
+// writes the message framed by the Content-Length header
+func writeMessage(w io.Writer, msg *message) error {
+	msg.JSONRPC = jsonRPCVersion
+	body, err := json.Marshal(msg)
+	if err != nil {
+		// notest
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s: %d\r\n\r\n", headerContentLength, len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,261 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vsqllsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/compile"
+)
+
+// runs the server session with the specified client messages and returns server messages
+func session(t *testing.T, compileFn func(string) (*compile.Result, error), msgs ...*message) []*message {
+	in := bytes.NewBuffer(nil)
+	for _, m := range msgs {
+		require.NoError(t, writeMessage(in, m))
+	}
+	require.NoError(t, writeMessage(in, &message{Method: methodExit}))
+
+	out := bytes.NewBuffer(nil)
+	require.NoError(t, newServer("test", compileFn, out).serve(context.Background(), in))
+
+	res := []*message{}
+	r := bufio.NewReader(out)
+	for {
+		m, err := readMessage(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		res = append(res, m)
+	}
+	return res
+}
+
+func request(id int, method string, params any) *message {
+	p, err := json.Marshal(params)
+	if err != nil {
+		panic(err)
+	}
+	return &message{ID: json.RawMessage(fmt.Sprint(id)), Method: method, Params: p}
+}
+
+func notification(method string, params any) *message {
+	m := request(0, method, params)
+	m.ID = nil
+	return m
+}
+
+// returns result of the response with the specified id unmarshalled into result
+func response(t *testing.T, msgs []*message, id int, result any) {
+	for _, m := range msgs {
+		if string(m.ID) == fmt.Sprint(id) {
+			require.Nil(t, m.Error)
+			require.NoError(t, json.Unmarshal(m.Result, result))
+			return
+		}
+	}
+	require.Fail(t, "no response", "id %d", id)
+}
+
+func diagnostics(t *testing.T, msgs []*message) map[string][]diagnostic {
+	res := map[string][]diagnostic{}
+	for _, m := range msgs {
+		if m.Method == methodPublishDiagnostics {
+			p := publishDiagnosticsParams{}
+			require.NoError(t, json.Unmarshal(m.Params, &p))
+			res[uriToPath(p.URI)] = p.Diagnostics
+		}
+	}
+	return res
+}
+
+func TestBasicUsage(t *testing.T) {
+	require := require.New(t)
+
+	appDir, err := filepath.Abs(filepath.Join("..", "..", "cmd", "vpm", "testdata", "myapp"))
+	require.NoError(err)
+	pkg1File := filepath.Join(appDir, "mypkg1", "schema1.vsql")
+	pkg2File := filepath.Join(appDir, "mypkg2", "schema2.vsql")
+	pkg2URI := textDocumentIdentifier{URI: pathToURI(pkg2File)}
+	pkg2Text, err := os.ReadFile(pkg2File)
+	require.NoError(err)
+
+	at := func(line, character int) textDocumentPositionParams {
+		return textDocumentPositionParams{TextDocument: pkg2URI, Position: position{Line: line, Character: character}}
+	}
+	refs := referenceParams{textDocumentPositionParams: at(5, 43)} // mypkg1.MyWS1
+	refs.Context.IncludeDeclaration = true
+
+	msgs := session(t, compile.Compile,
+		request(1, methodInitialize, struct{}{}),
+		notification(methodInitialized, struct{}{}),
+		notification(methodDidOpen, didOpenParams{TextDocument: textDocumentItem{URI: pkg2URI.URI, Text: string(pkg2Text)}}),
+		request(2, methodDefinition, at(8, 25)), // ref(mypkg1.MyTable1)
+		request(3, methodHover, at(6, 35)),      // sys.ODoc
+		request(4, methodReferences, refs),
+		request(5, methodCompletion, at(8, 28)), // ref(mypkg1.|
+		request(7, methodHover, at(6, 12)),      // MyTable2
+		request(6, methodShutdown, nil),
+	)
+
+	t.Run("initialize", func(t *testing.T) {
+		res := initializeResult{}
+		response(t, msgs, 1, &res)
+		require.Equal(ServerName, res.ServerInfo.Name)
+		require.True(res.Capabilities.DefinitionProvider)
+		require.True(res.Capabilities.HoverProvider)
+		require.True(res.Capabilities.ReferencesProvider)
+	})
+
+	t.Run("diagnostics on open", func(t *testing.T) {
+		d := diagnostics(t, msgs)
+		require.Contains(d, pkg2File)
+		require.Empty(d[pkg2File])
+	})
+
+	t.Run("definition in imported package", func(t *testing.T) {
+		locs := []location{}
+		response(t, msgs, 2, &locs)
+		require.Len(locs, 1)
+		require.Equal(pkg1File, uriToPath(locs[0].URI))
+		require.Equal(textRange{Start: position{4, 10}, End: position{4, 18}}, locs[0].Range)
+	})
+
+	t.Run("hover on sys table", func(t *testing.T) {
+		h := hover{}
+		response(t, msgs, 3, &h)
+		require.Equal(markupKindMarkdown, h.Contents.Kind)
+		require.Contains(h.Contents.Value, "**TABLE** `ODoc`")
+		require.Contains(h.Contents.Value, "ODoc `sys.ODoc`")
+	})
+
+	t.Run("hover shows declared field types", func(t *testing.T) {
+		h := hover{}
+		response(t, msgs, 7, &h)
+		require.Contains(h.Contents.Value, "- `myfield2` int32 NOT NULL")
+		require.Contains(h.Contents.Value, "- `myfield3` ref(mypkg1.MyTable1) NOT NULL")
+	})
+
+	t.Run("references", func(t *testing.T) {
+		locs := []location{}
+		response(t, msgs, 4, &locs)
+		require.Len(locs, 2)
+		require.Equal(pkg1File, uriToPath(locs[0].URI), "declaration is the first")
+		require.Equal(pkg2File, uriToPath(locs[1].URI))
+		require.Equal(textRange{Start: position{5, 34}, End: position{5, 46}}, locs[1].Range)
+	})
+
+	t.Run("completion", func(t *testing.T) {
+		items := []completionItem{}
+		response(t, msgs, 5, &items)
+		labels := map[string]completionItem{}
+		for _, i := range items {
+			labels[i.Label] = i
+		}
+		require.Contains(labels, "MyTable2")
+		require.Contains(labels, "mypkg1.MyTable1")
+		require.Contains(labels, "sys.ODoc")
+		require.Equal(completionKindClass, labels["mypkg1.MyTable1"].Kind)
+		require.Equal(textRange{Start: position{8, 21}, End: position{8, 28}}, labels["MyTable2"].TextEdit.Range, "typed prefix is replaced")
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		var res any
+		response(t, msgs, 6, &res)
+		require.Nil(res)
+	})
+}
+
+func TestDiagnostics(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "schema.vsql")
+	compileFn := func(string) (*compile.Result, error) {
+		return &compile.Result{PkgFiles: map[string][]string{"test": {file}}, ModulePath: "test"},
+			errors.Join(
+				errors.New("schema.vsql:6:25: unexpected token"),
+				errors.New("sys: cannot find dependency"))
+	}
+
+	msgs := session(t, compileFn,
+		notification(methodDidSave, didSaveParams{TextDocument: textDocumentIdentifier{URI: pathToURI(file)}}))
+
+	d := diagnostics(t, msgs)
+	require.Len(d[file], 2)
+	require.Equal(diagnostic{
+		Range:    textRange{Start: position{5, 24}, End: position{5, 24}},
+		Severity: diagnosticSeverityError,
+		Source:   diagnosticSource,
+		Message:  "unexpected token",
+	}, d[file][0])
+	require.Equal("sys: cannot find dependency", d[file][1].Message)
+}
+
+func TestErrors(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("should be error response for unknown method", func(t *testing.T) {
+		msgs := session(t, nil, request(1, "unknown", nil))
+		require.Len(msgs, 1)
+		require.Equal(errCodeMethodNotFound, msgs[0].Error.Code)
+	})
+
+	t.Run("should be error response for invalid params", func(t *testing.T) {
+		msgs := session(t, nil, &message{ID: json.RawMessage("1"), Method: methodHover, Params: json.RawMessage(`"str"`)})
+		require.Len(msgs, 1)
+		require.Equal(errCodeInvalidParams, msgs[0].Error.Code)
+	})
+
+	t.Run("should be parse error response for invalid message", func(t *testing.T) {
+		out := bytes.NewBuffer(nil)
+		in := strings.NewReader("Content-Length: 3\r\n\r\n{{{")
+		require.NoError(newServer("test", nil, out).serve(context.Background(), in))
+		m, err := readMessage(bufio.NewReader(out))
+		require.NoError(err)
+		require.Equal(errCodeParseError, m.Error.Code)
+	})
+
+	t.Run("should be parse error response for invalid header", func(t *testing.T) {
+		out := bytes.NewBuffer(nil)
+		in := strings.NewReader("Content-Length: abc\r\n\r\n")
+		require.NoError(newServer("test", nil, out).serve(context.Background(), in))
+		m, err := readMessage(bufio.NewReader(out))
+		require.NoError(err)
+		require.Equal(errCodeParseError, m.Error.Code)
+	})
+
+	t.Run("should be error response for too large message and next message is served", func(t *testing.T) {
+		out := bytes.NewBuffer(nil)
+		large := fmt.Sprintf("Content-Length: %d\r\n\r\n%s", maxContentLength+1, strings.Repeat(" ", maxContentLength+1))
+		next := bytes.NewBuffer(nil)
+		require.NoError(writeMessage(next, request(1, "unknown", nil)))
+		in := io.MultiReader(strings.NewReader(large), next)
+		require.NoError(newServer("test", nil, out).serve(context.Background(), in))
+
+		r := bufio.NewReader(out)
+		m, err := readMessage(r)
+		require.NoError(err)
+		require.Equal(errCodeInvalidRequest, m.Error.Code)
+		require.Contains(m.Error.Message, ErrMessageTooLarge.Error())
+
+		m, err = readMessage(r)
+		require.NoError(err)
+		require.Equal(errCodeMethodNotFound, m.Error.Code)
+	})
+}
@@ -0,0 +1,20 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vsqllsp
+
+import (
+	"context"
+	"io"
+
+	"github.com/voedger/voedger/pkg/compile"
+)
+
+// Serve runs VSQL language server. The server communicates with the client over in and out, usually stdin and stdout.
+//
+// Packages are compiled on open and save the same way `vpm compile` does, so dependencies are resolved from the module cache.
+// Returns nil when exit notification is received or in is closed
+func Serve(ctx context.Context, version string, in io.Reader, out io.Writer) error {
+	return newServer(version, compile.Compile, out).serve(ctx, in)
+}
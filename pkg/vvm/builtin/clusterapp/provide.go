@@ -23,7 +23,7 @@ func Provide() builtinapps.Builder {
 			FS:   schemaFS,
 		}
 		clusterPackageFS := cluster.Provide(cfg, apis.IAppStructsProvider, apis.ITime, apis.IFederation,
-			apis.ITokens, apis.SidecarApps)
+			apis.ITokens, apis.SidecarApps, apis.IBLOBStorage, apis.AppPartitions, apis.AppImagesDeployer)
 		sysPackageFS := sysprovide.Provide(cfg)
 		return builtinapps.Def{
 			AppQName: istructs.AppQName_sys_cluster,
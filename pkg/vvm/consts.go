@@ -32,8 +32,13 @@ const (
 	DefaultLeadershipAcquisitionDuration                                   = LeadershipAcquisitionDuration(120 * time.Second)
 	DefaultCommandProcessorChannelBufferSize uint                          = uint(DefaultNumCommandProcessors)
 	DefaultAdminPort                                                       = 55555
+	n10nBroadcastQueueSize                                                 = 10000
+	n10nBroadcastTimeout                                                   = 5 * time.Second
 )
 
+// OpenID Connect providers are read from the secret if VVMConfig.OIDCProviders is nil
+const OIDCProvidersSecretName = "oidc-providers"
+
 const (
 	ProcessorChannel_Command ProcessorChannelType = iota
 	ProcessorChannel_Query_V1
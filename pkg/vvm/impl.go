@@ -18,3 +18,13 @@ func (srv *AppPartsCtlPipelineService) Run(ctx context.Context) {
 }
 
 func (srv *AppPartsCtlPipelineService) Stop() {}
+
+func (srv *AppImagesDeployerPipelineService) Prepare(_ interface{}) error {
+	return srv.IAppImagesDeployer.Prepare()
+}
+
+func (srv *AppImagesDeployerPipelineService) Run(ctx context.Context) {
+	srv.IAppImagesDeployer.Run(ctx)
+}
+
+func (srv *AppImagesDeployerPipelineService) Stop() {}
@@ -37,6 +37,7 @@ func NewVVMDefaultConfig() VVMConfig {
 		RouterReadTimeout:                 router.DefaultRouterWriteTimeout, // same
 		RouterConnectionsLimit:            router.DefaultConnectionsLimit,
 		RouterMaxQueriesPerWS:             router.DefaultMaxQueriesPerWSLimit,
+		RouterCompressionMinSize:          router.DefaultCompressionMinSize,
 		BLOBMaxSize:                       DefaultBLOBMaxSize,
 		Time:                              timeu.NewITime(),
 		Name:                              processors.VVMName(hostname),
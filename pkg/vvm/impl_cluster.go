@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vvm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/voedger/voedger/pkg/apppartsctl"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/router"
+)
+
+// address other VVMs of the cluster send requests to, e.g. `http://10.0.0.1:8822`
+func vvmAddress(cfg *VVMConfig) apppartsctl.VVMAddress {
+	scheme := "http"
+	if cfg.VVMPort == router.HTTPSPort {
+		scheme = "https"
+	}
+	return apppartsctl.VVMAddress(fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(cfg.IP.String(), strconv.Itoa(int(cfg.VVMPort)))))
+}
+
+// n10nBroadcaster sends n10n updates of projections of partitions owned by the current VVM to other VVMs of the cluster,
+// so subscribers connected to any VVM are notified.
+//
+// Updates received from other VVMs are not sent further since their partitions are not owned by the current VVM.
+type n10nBroadcaster struct {
+	in10n.IN10nBroker
+	peers   *N10nPeersSource
+	client  httpu.IHTTPClient
+	updates chan n10nUpdate
+}
+
+type n10nUpdate struct {
+	key    in10n.ProjectionKey
+	offset istructs.Offset
+}
+
+func newN10nBroadcaster(broker in10n.IN10nBroker, peers *N10nPeersSource) (*n10nBroadcaster, func()) {
+	client, clientCleanup := httpu.NewIHTTPClient(httpu.WithNoRetryPolicy())
+	b := &n10nBroadcaster{
+		IN10nBroker: broker,
+		peers:       peers,
+		client:      client,
+		updates:     make(chan n10nUpdate, n10nBroadcastQueueSize),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		b.broadcast(ctx)
+	}()
+	return b, func() {
+		cancel()
+		wg.Wait()
+		clientCleanup()
+	}
+}
+
+// Notifies local subscribers and then queues the update to be sent to other VVMs
+//
+// Update is not sent if the queue is full, the next update of the projection will notify the subscribers
+func (b *n10nBroadcaster) Update(key in10n.ProjectionKey, offset istructs.Offset) {
+	b.IN10nBroker.Update(key, offset)
+	select {
+	case b.updates <- n10nUpdate{key: key, offset: offset}:
+	default:
+		// notest
+		logger.Error(fmt.Sprintf("n10n broadcast queue is full, update %s/%s/%d:%d is not sent to other VVMs", key.App, key.Projection, key.WS, offset))
+	}
+}
+
+func (b *n10nBroadcaster) broadcast(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u := <-b.updates:
+			if b.peers.getter == nil {
+				// notest: VVM is not launched yet
+				continue
+			}
+			peers := b.peers.getter(u.key.App, u.key.WS)
+			if len(peers) == 0 {
+				continue
+			}
+			body, err := json.Marshal(u.key)
+			if err != nil {
+				// notest
+				logger.Error("failed to marshal n10n projection key:", err)
+				continue
+			}
+			for _, peer := range peers {
+				b.send(ctx, peer, string(body), u.offset)
+			}
+		}
+	}
+}
+
+func (b *n10nBroadcaster) send(ctx context.Context, peer apppartsctl.VVMAddress, body string, offset istructs.Offset) {
+	ctx, cancel := context.WithTimeout(ctx, n10nBroadcastTimeout)
+	defer cancel()
+	url := fmt.Sprintf("%s/n10n/update/%d", peer, offset)
+	if _, err := b.client.Req(ctx, url, body, httpu.WithMethod(http.MethodPost), httpu.WithDiscardResponse()); err != nil {
+		logger.Error(fmt.Sprintf("failed to send n10n update to %s: %s", peer, err))
+	}
+}
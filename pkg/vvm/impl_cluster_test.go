@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vvm
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/apppartsctl"
+	"github.com/voedger/voedger/pkg/cluster"
+	"github.com/voedger/voedger/pkg/goutils/httpu"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/goutils/timeu"
+	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/in10n"
+	"github.com/voedger/voedger/pkg/istorage"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
+	imetrics "github.com/voedger/voedger/pkg/metrics"
+	"github.com/voedger/voedger/pkg/vvm/builtin/clusterapp"
+	"github.com/voedger/voedger/pkg/vvm/storage"
+)
+
+// two VVMs on the shared storage: partitions are distributed among VVMs, n10n updates are sent to the other VVM,
+// app images are deployed on each VVM
+func TestClusterOfVVMs(t *testing.T) {
+	require := require.New(t)
+	iTime := testingu.MockTime
+
+	suffix := provider.NewTestKeyspaceIsolationSuffix()
+	var sharedStorageFactory istorage.IAppStorageFactory
+
+	launch := func() (*VoedgerVM, *VVMConfig) {
+		cfg := GetTestVVMCfg(net.IPv4(127, 0, 0, 1))
+		cfg.NumVVM = 2
+		cfg.VVMPort = VVMPortType(freePort(t))
+		cfg.AdminPort = 0
+		if sharedStorageFactory == nil {
+			var err error
+			sharedStorageFactory, err = cfg.StorageFactory(iTime)
+			require.NoError(err)
+		}
+		cfg.KeyspaceIsolationSuffix = suffix
+		cfg.StorageFactory = func(timeu.ITime) (istorage.IAppStorageFactory, error) {
+			return sharedStorageFactory, nil
+		}
+		vvm, err := Provide(cfg)
+		require.NoError(err)
+		problemCtx := vvm.Launch(DefaultLeadershipDurationSeconds, DefaultLeadershipAcquisitionDuration)
+		require.NoError(problemCtx.Err())
+		return vvm, cfg
+	}
+
+	vvm1, cfg1 := launch()
+	defer func() { require.NoError(vvm1.Shutdown()) }()
+	vvm2, cfg2 := launch()
+	vvm2Stopped := false
+	defer func() {
+		if !vvm2Stopped {
+			require.NoError(vvm2.Shutdown())
+		}
+	}()
+
+	// not cached, as VVMs see it
+	sysVvmStorage, err := provider.Provide(sharedStorageFactory, suffix).AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(err)
+	owners := storage.NewPartitionOwnersTTLStorage(sysVvmStorage)
+	ownerOf := func(app appdef.AppQName) string {
+		ok, owner, err := owners.Get(apppartsctl.PartitionKey{App: app, Partition: 0})
+		require.NoError(err)
+		if !ok {
+			return ""
+		}
+		return owner
+	}
+
+	apps := []appdef.AppQName{istructs.AppQName_sys_cluster, istructs.AppQName_sys_registry, istructs.AppQName_test1_app1}
+
+	vvmAddr1, vvmAddr2 := string(vvmAddress(cfg1)), string(vvmAddress(cfg2))
+	ownedBy := func() map[string]int {
+		owned := map[string]int{}
+		for _, app := range apps {
+			owned[ownerOf(app)]++
+		}
+		return owned
+	}
+	rebalanceUntil := func(cond func() bool) {
+		for !cond() {
+			iTime.Sleep(apppartsctl.RebalanceInterval)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	t.Run("should distribute partitions among VVMs", func(t *testing.T) {
+		rebalanceUntil(func() bool {
+			owned := ownedBy()
+			return owned[vvmAddr1] > 0 && owned[vvmAddr2] > 0 && owned[vvmAddr1]+owned[vvmAddr2] == len(apps)
+		})
+	})
+
+	t.Run("should notify subscribers of the other VVM", func(t *testing.T) {
+		app := istructs.AppQName_test1_app1
+		owner, other := cfg1, cfg2
+		if ownerOf(app) == string(vvmAddress(cfg2)) {
+			owner, other = cfg2, cfg1
+		}
+		key := in10n.ProjectionKey{App: app, Projection: appdef.NewQName("app1pkg", "View"), WS: istructs.FirstBaseUserWSID}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// subscribe on the VVM that does not own the partition
+		payload, err := json.Marshal(map[string]any{"SubjectLogin": "test", "ProjectionKey": []in10n.ProjectionKey{key}})
+		require.NoError(err)
+		subscribeURL := fmt.Sprintf("%s/n10n/channel?payload=%s", vvmAddress(other), url.QueryEscape(string(payload)))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, subscribeURL, http.NoBody)
+		require.NoError(err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		require.Equal(http.StatusOK, resp.StatusCode)
+		events := bufio.NewScanner(resp.Body)
+		readEvent := func() (event, data string) {
+			for events.Scan() {
+				line := events.Text()
+				switch {
+				case strings.HasPrefix(line, "event: "):
+					event = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					data = strings.TrimPrefix(line, "data: ")
+				case len(line) == 0 && len(event) > 0:
+					return event, data
+				}
+			}
+			require.Fail("n10n channel is closed", events.Err())
+			return "", ""
+		}
+		event, _ := readEvent()
+		require.Equal("channelId", event)
+
+		// update on the owner VVM
+		body, err := json.Marshal(key)
+		require.NoError(err)
+		updateURL := fmt.Sprintf("%s/n10n/update/%d", vvmAddress(owner), 42)
+		updateResp, err := http.Post(updateURL, "application/json", strings.NewReader(string(body)))
+		require.NoError(err)
+		require.NoError(updateResp.Body.Close())
+		require.Equal(http.StatusOK, updateResp.StatusCode)
+
+		for {
+			event, data := readEvent()
+			if data == "42" {
+				require.Equal(key.ToJSON(), event)
+				break
+			}
+		}
+	})
+
+	deployedOn := func(vvm *VoedgerVM) bool {
+		_, err := vvm.IAppPartitions.AppDef(istructs.AppQName_test2_app2)
+		return err == nil
+	}
+
+	t.Run("should deploy the app image on each VVM", func(t *testing.T) {
+		sysToken, err := payloads.GetSystemPrincipalToken(vvm1.ITokens, istructs.AppQName_sys_cluster)
+		require.NoError(err)
+		blobID, err := vvm1.IFederation.UploadBLOB(istructs.AppQName_sys_cluster, clusterapp.ClusterAppWSID, iblobstorage.BLOBReader{
+			DescrType: iblobstorage.DescrType{
+				Name:             "image.var",
+				ContentType:      httpu.ContentType_ApplicationXBinary,
+				OwnerRecord:      appdef.NewQName(cluster.ClusterPackage, "App"),
+				OwnerRecordField: cluster.Field_AppImage,
+			},
+			ReadCloser: io.NopCloser(bytes.NewReader(zipAppImage(t, filepath.Join("..", "sys", "it", "testdata", "apps", "test2.app1", "image")))),
+		}, httpu.WithAuthorizeBy(sysToken))
+		require.NoError(err)
+		body := fmt.Sprintf(`{"args":{"AppQName":"%s","NumPartitions":1,"NumAppWorkspaces":1,"AppImage":%d}}`, istructs.AppQName_test2_app2, blobID)
+		_, err = vvm1.IFederation.Func(fmt.Sprintf("api/%s/%d/c.cluster.DeployApp", istructs.AppQName_sys_cluster, clusterapp.ClusterAppPseudoWSID), body,
+			httpu.WithAuthorizeBy(sysToken))
+		require.NoError(err)
+
+		// each VVM knows the app, the partition is deployed on one of them
+		for !deployedOn(vvm1) || !deployedOn(vvm2) || len(ownerOf(istructs.AppQName_test2_app2)) == 0 {
+			iTime.Sleep(cluster.AppImagesDeployInterval)
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	t.Run("should take partitions over if VVM left", func(t *testing.T) {
+		require.NoError(vvm2.Shutdown())
+		vvm2Stopped = true
+
+		rebalanceUntil(func() bool { return ownedBy()[vvmAddr1] == len(apps) })
+	})
+
+	t.Run("should deploy the app image on VVM launch", func(t *testing.T) {
+		vvm3, _ := launch()
+		defer func() { require.NoError(vvm3.Shutdown()) }()
+		require.True(deployedOn(vvm3))
+	})
+}
+
+// sys_vvm storage of a cluster of VVMs is got from the same non-caching provider that is run and stopped under the cache
+func TestClusterSysVvmStorageProvider(t *testing.T) {
+	require := require.New(t)
+
+	cfg := GetTestVVMCfg(net.IPv4(127, 0, 0, 1))
+	cfg.NumVVM = 2
+	factory, err := cfg.StorageFactory(testingu.MockTime)
+	require.NoError(err)
+	nonCaching := &testAppStorageProvider{IAppStorageProvider: provider.Provide(factory, provider.NewTestKeyspaceIsolationSuffix())}
+	caching := provideCachingAppStorageProvider(cfg.StorageCacheSize, imetrics.Provide(), cfg.Name, nonCaching, testingu.MockTime)
+
+	_, err = provideIVVMAppTTLStorage(caching, nonCaching, cfg)
+	require.NoError(err)
+	require.Equal([]appdef.AppQName{istructs.AppQName_sys_vvm}, nonCaching.apps)
+
+	caching.Run(context.Background())
+	caching.Stop()
+	require.Equal(1, nonCaching.runs)
+	require.Equal(1, nonCaching.stops)
+}
+
+type testAppStorageProvider struct {
+	istorage.IAppStorageProvider
+	apps        []appdef.AppQName
+	runs, stops int
+}
+
+func (p *testAppStorageProvider) AppStorage(app appdef.AppQName) (istorage.IAppStorage, error) {
+	p.apps = append(p.apps, app)
+	return p.IAppStorageProvider.AppStorage(app)
+}
+
+func (p *testAppStorageProvider) Run(context.Context) { p.runs++ }
+
+func (p *testAppStorageProvider) Stop() { p.stops++ }
+
+// zips the image dir the same way as `vpm build` does
+func zipAppImage(t *testing.T, dir string) []byte {
+	buf := bytes.NewBuffer(nil)
+	w := zip.NewWriter(buf)
+	require.NoError(t, w.AddFS(os.DirFS(dir)))
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
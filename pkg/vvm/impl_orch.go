@@ -134,7 +134,7 @@ func (vvm *VoedgerVM) tryToAcquireLeadership(leadershipDurationSeconds ielection
 			return ErrVVMLeadershipAcquisition
 		default:
 			// Try to acquire leadership
-			vvm.leadershipCtx = elections.AcquireLeadership(vvmIdx, vvm.ip.String(), leadershipDurationSeconds)
+			vvm.leadershipCtx = elections.AcquireLeadership(vvmIdx, string(vvm.address), leadershipDurationSeconds)
 			if vvm.leadershipCtx != nil {
 				// If leadership is acquired
 				vvm.monitorShutWg.Add(1)
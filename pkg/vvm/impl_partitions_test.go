@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package vvm
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appparts"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/ielections"
+	"github.com/voedger/voedger/pkg/istructs"
+	"github.com/voedger/voedger/pkg/vvm/storage"
+)
+
+type mockVVMLeaders struct {
+	ielections.ITTLStorage[storage.TTLStorageImplKey, string]
+	leaders map[storage.TTLStorageImplKey]string
+}
+
+func (m *mockVVMLeaders) Get(key storage.TTLStorageImplKey) (bool, string, error) {
+	val, ok := m.leaders[key]
+	return ok, val, nil
+}
+
+type mockAppParts struct {
+	appparts.IAppPartitions
+}
+
+func (m *mockAppParts) AppWorkspacePartitionID(appdef.AppQName, istructs.WSID) (istructs.PartitionID, error) {
+	return 1, nil
+}
+
+func TestProvideAppPartitionsController(t *testing.T) {
+	require := require.New(t)
+
+	parts := &mockAppParts{}
+	leaders := &mockVVMLeaders{leaders: map[storage.TTLStorageImplKey]string{1: "http://192.168.0.1:8080", 2: "http://192.168.0.2:8080"}}
+
+	t.Run("single VVM", func(t *testing.T) {
+		cfg := GetTestVVMCfg(net.IPv4(192, 168, 0, 1))
+		n10nPeers := provideN10nPeersSource()
+		ctl, cleanup, err := provideAppPartitionsController(cfg, parts, nil, leaders, testingu.MockTime, n10nPeers)
+		require.NoError(err)
+		defer cleanup()
+
+		_, ok := ctl.PartitionOwner(istructs.AppQName_test1_app1, 1)
+		require.False(ok)
+		require.Nil(providePartitionOwnerLocator(cfg, parts, ctl), "requests should not be forwarded")
+		require.Nil(n10nPeers.getter, "n10n updates should not be sent")
+	})
+
+	t.Run("cluster of VVMs", func(t *testing.T) {
+		cfg := GetTestVVMCfg(net.IPv4(192, 168, 0, 1))
+		cfg.NumVVM = 2
+
+		t.Run("should fail if VVM port is not specified", func(t *testing.T) {
+			cfg.VVMPort = 0
+			_, _, err := provideAppPartitionsController(cfg, parts, nil, leaders, testingu.MockTime, provideN10nPeersSource())
+			require.Error(err)
+		})
+
+		cfg.VVMPort = 8080
+		n10nPeers := provideN10nPeersSource()
+		ctl, cleanup, err := provideAppPartitionsController(cfg, parts, nil, leaders, testingu.MockTime, n10nPeers)
+		require.NoError(err)
+		defer cleanup()
+		require.NotNil(providePartitionOwnerLocator(cfg, parts, ctl))
+
+		require.NotNil(n10nPeers.getter)
+		require.Empty(n10nPeers.getter(istructs.AppQName_test1_app1, 1), "n10n updates of partitions not owned should not be sent")
+	})
+}
@@ -30,10 +30,14 @@ func provideRequestHandler(appParts appparts.IAppPartitions, procbus iprocbus.IP
 	cpAmount istructs.NumCommandProcessors, vvmApps VVMApps, n10nProc n10n.IN10NProc,
 	busyLogMode BusyProcessorLogMode) bus.RequestHandler {
 	return func(requestCtx context.Context, request bus.Request, responder bus.IResponder) {
-		token, err := bus.GetPrincipalToken(request)
-		if err != nil {
-			bus.ReplyAccessDeniedUnauthorized(responder, err.Error())
-			return
+		token := ""
+		if processors.APIPath(request.APIPath) != processors.APIPath_InboundWebhooks {
+			// the sender of the request to the inbound webhook is verified by the command processor, Authorization header is not a principal token then
+			var err error
+			if token, err = bus.GetPrincipalToken(request); err != nil {
+				bus.ReplyAccessDeniedUnauthorized(responder, err.Error())
+				return
+			}
 		}
 		if request.IsN10N {
 			n10nArgs := n10n.N10NProcArgs{
@@ -76,7 +80,7 @@ func provideRequestHandler(appParts appparts.IAppPartitions, procbus iprocbus.IP
 			if request.Method == http.MethodGet {
 				// QP
 				iqm := query2.NewIQueryMessage(requestCtx, request.AppQName, request.WSID, responder, request.Query, request.DocID, processors.APIPath(request.APIPath), request.QName,
-					partitionID, request.Host, token, request.WorkspaceQName, request.Header[httpu.Accept])
+					partitionID, request.Host, token, request.WorkspaceQName, request.Header[httpu.Accept], request.Header[httpu.AcceptLanguage])
 				if !procbus.Submit(uint(qpcgIdx_v2), 0, iqm) {
 					replyQueryBusy(requestCtx, request.IsAPIV2, responder, busyLogMode)
 				}
@@ -86,7 +90,7 @@ func provideRequestHandler(appParts appparts.IAppPartitions, procbus iprocbus.IP
 				// TODO: use appQName to calculate cmdProcessorIdx in solid range [0..cpCount)
 				cmdProcessorIdx := uint(partitionID) % uint(cpAmount)
 				icm := commandprocessor.NewCommandMessage(requestCtx, request.Body, request.AppQName, request.WSID, responder, partitionID, request.QName, token,
-					request.Host, processors.APIPath(request.APIPath), istructs.RecordID(request.DocID), request.Method, request.Header[httpu.Origin])
+					request.Host, processors.APIPath(request.APIPath), istructs.RecordID(request.DocID), request.Method, request.Header[httpu.Origin], request.Header[httpu.AcceptLanguage], request.Header)
 				if !procbus.Submit(uint(cpchIdx), cmdProcessorIdx, icm) {
 					replyCommandBusy(requestCtx, responder, partitionID, busyLogMode)
 				}
@@ -104,7 +108,7 @@ func provideRequestHandler(appParts appparts.IAppPartitions, procbus iprocbus.IP
 
 			switch request.Resource[:1] {
 			case "q":
-				iqm := queryprocessor.NewQueryMessage(requestCtx, request.AppQName, partitionID, request.WSID, responder, request.Body, funcQName, request.Host, token)
+				iqm := queryprocessor.NewQueryMessage(requestCtx, request.AppQName, partitionID, request.WSID, responder, request.Body, funcQName, request.Host, token, request.Header[httpu.AcceptLanguage])
 				if !procbus.Submit(uint(qpcgIdx_v1), 0, iqm) {
 					replyQueryBusy(requestCtx, request.IsAPIV2, responder, busyLogMode)
 				}
@@ -112,7 +116,7 @@ func provideRequestHandler(appParts appparts.IAppPartitions, procbus iprocbus.IP
 				// TODO: use appQName to calculate cmdProcessorIdx in solid range [0..cpCount)
 				cmdProcessorIdx := uint(partitionID) % uint(cpAmount)
 				icm := commandprocessor.NewCommandMessage(requestCtx, request.Body, request.AppQName, request.WSID, responder, partitionID, funcQName, token,
-					request.Host, processors.APIPath(request.APIPath), istructs.RecordID(request.DocID), request.Method, request.Header[httpu.Origin])
+					request.Host, processors.APIPath(request.APIPath), istructs.RecordID(request.DocID), request.Method, request.Header[httpu.Origin], request.Header[httpu.AcceptLanguage], request.Header)
 				if !procbus.Submit(uint(cpchIdx), cmdProcessorIdx, icm) {
 					replyCommandBusy(requestCtx, responder, partitionID, busyLogMode)
 				}
@@ -9,6 +9,7 @@
 package vvm
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -24,20 +25,22 @@ import (
 	"github.com/google/wire"
 	"golang.org/x/crypto/acme/autocert"
 
-	"github.com/voedger/voedger/pkg/appdef/builder"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/apppartsctl"
 	"github.com/voedger/voedger/pkg/btstrp"
+	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/extensionpoints"
-	"github.com/voedger/voedger/pkg/goutils/filesu"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/iblobstorage"
+	"github.com/voedger/voedger/pkg/ielections"
 	"github.com/voedger/voedger/pkg/iextengine"
+	"github.com/voedger/voedger/pkg/ihttp"
+	"github.com/voedger/voedger/pkg/ioidc"
+	"github.com/voedger/voedger/pkg/ioidcimpl"
 	"github.com/voedger/voedger/pkg/isequencer"
 	"github.com/voedger/voedger/pkg/itokens"
-	"github.com/voedger/voedger/pkg/parser"
 	"github.com/voedger/voedger/pkg/processors"
 	"github.com/voedger/voedger/pkg/processors/actualizers"
 	blobprocessor "github.com/voedger/voedger/pkg/processors/blobber"
@@ -49,9 +52,11 @@ import (
 	"github.com/voedger/voedger/pkg/vvm/engines"
 
 	"github.com/voedger/voedger/pkg/appdef"
+	"github.com/voedger/voedger/pkg/appdefcompat"
 	"github.com/voedger/voedger/pkg/bus"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/coreutils/httpstatic"
 	"github.com/voedger/voedger/pkg/iauthnz"
 	"github.com/voedger/voedger/pkg/iauthnzimpl"
 	"github.com/voedger/voedger/pkg/iblobstoragestg"
@@ -61,10 +66,12 @@ import (
 	"github.com/voedger/voedger/pkg/iprocbusmem"
 	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/iratesstg"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istoragecache"
+	"github.com/voedger/voedger/pkg/istorageenc"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
@@ -74,7 +81,9 @@ import (
 	commandprocessor "github.com/voedger/voedger/pkg/processors/command"
 	queryprocessor "github.com/voedger/voedger/pkg/processors/query"
 	"github.com/voedger/voedger/pkg/state"
+	"github.com/voedger/voedger/pkg/sys/apikeys"
 	"github.com/voedger/voedger/pkg/sys/invite"
+	"github.com/voedger/voedger/pkg/sys/storages"
 	"github.com/voedger/voedger/pkg/sys/sysprovide"
 	dbcertcache "github.com/voedger/voedger/pkg/vvm/db_cert_cache"
 	"github.com/voedger/voedger/pkg/vvm/metrics"
@@ -92,7 +101,7 @@ func Provide(vvmCfg *VVMConfig) (voedgerVM *VoedgerVM, err error) {
 	voedgerVM = &VoedgerVM{
 		vvmCtxCancel:                    vvmCtxCancel,
 		numVVM:                          vvmCfg.NumVVM,
-		ip:                              vvmCfg.IP,
+		address:                         vvmAddress(vvmCfg),
 		problemCtx:                      problemCtx,
 		problemCtxCancel:                problemCtxCancel,
 		problemErrCh:                    make(chan error, 1),
@@ -156,7 +165,7 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	panic(wire.Build(
 		wire.Struct(new(VVM), "*"),
 		wire.Struct(new(builtinapps.APIs), "*"),
-		wire.Struct(new(schedulers.BasicSchedulerConfig), "VvmName", "SecretReader", "Tokens", "Metrics", "Broker", "Federation", "Time", "EmailSender"),
+		wire.Struct(new(schedulers.BasicSchedulerConfig), "VvmName", "SecretReader", "Tokens", "Metrics", "Broker", "Federation", "Time", "StateOpts", "EmailSender", "HTTPClient"),
 		provideServicePipeline,
 		provideCommandProcessors,
 		provideQueryProcessors_V1,
@@ -178,6 +187,8 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		provideRequestHandler,
 		provideBLOBChannel,
 		provideRouterParams,
+		httpstatic.NewAppFolders,
+		provideIAppStaticFolders,
 		provideRouterAppStoragePtr,
 		provideIFederation,
 		provideCachingAppStorageProvider,  // IAppStorageProvider
@@ -185,7 +196,8 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		provideIAppStructsProvider,        // IAppStructsProvider
 		payloads.ProvideIAppTokensFactory, // IAppTokensFactory
 		provideAppPartitions,
-		in10nmem.NewN10nBroker,
+		provideN10nBroker,
+		provideN10nPeersSource,
 		queryprocessor.ProvideServiceFactory,
 		query2.ProvideServiceFactory,
 		commandprocessor.ProvideServiceFactory,
@@ -204,15 +216,19 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		provideSecretKeyJWT,
 		provideBucketsFactory,
 		provideSubjectGetterFunc,
+		apikeys.ProvideAPIKeyGetter,
 		provideStorageFactory,
 		provideIAppStorageUncachingProviderFactory,
+		provideNonCachingAppStorageProvider,
 		provideAppPartsCtlPipelineService,
 		provideIsDeviceAllowedFunc,
 		provideBuiltInApps,
 		provideBasicAsyncActualizerConfig, // actualizers.BasicAsyncActualizerConfig
 		actualizers.ProvideActualizers,    // appparts.IActualizerRunner
 		provideSchedulerRunner,
-		apppartsctl.New,
+		provideAppPartitionsController,
+		providePartitionOwnerLocator,
+		provideAppTranslations,
 		provideAppConfigsTypeEmpty,
 		provideBuiltInAppPackages,
 		provideBootstrapOperator,
@@ -232,7 +248,9 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		provideStateOpts,
 		n10n.NewIN10NProc,
 		provideHTTPClient,
+		provideOIDCRelyingParty,
 		provideIRequestSenderPtr,
+		provideIAppPartitionsPtr,
 		provideBlobHandlerPtr,
 		// wire.Value(vvmConfig.NumCommandProcessors) -> (wire bug?) value github.com/untillpro/airs-bp3/vvm.CommandProcessorsCount can't be used: vvmConfig is not declared in package scope
 		wire.FieldsOf(&vvmConfig,
@@ -256,15 +274,44 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	))
 }
 
-func provideStateOpts() state.StateOpts {
-	return state.StateOpts{}
+func provideStateOpts(blobStorage iblobstorage.IBLOBStorage, metrics imetrics.IMetrics, vvmName processors.VVMName) state.StateOpts {
+	return state.StateOpts{
+		BLOBReader: func(ctx context.Context, key iblobstorage.PersistentBLOBKeyType) (descr iblobstorage.DescrType, data []byte, err error) {
+			buf := bytes.NewBuffer(nil)
+			err = blobStorage.ReadBLOB(ctx, &key, func(state iblobstorage.BLOBState) error {
+				descr = state.Descr
+				return nil
+			}, buf, iblobstoragestg.RLimiter_Null)
+			return descr, buf.Bytes(), err
+		},
+		EgressDeniedHandler: func(app appdef.AppQName) {
+			metrics.IncreaseApp(storages.Metric_HTTPEgressDeniedTotal, string(vvmName), app, 1)
+		},
+	}
 }
 
 func provideHTTPClient() (httpu.IHTTPClient, func()) {
 	return httpu.NewIHTTPClient()
 }
 
-func provideIVVMAppTTLStorage(prov istorage.IAppStorageProvider) (storage.ISysVvmStorage, error) {
+func provideOIDCRelyingParty(vvmConfig *VVMConfig, secretReader isecrets.ISecretReader, httpClient httpu.IHTTPClient,
+	time timeu.ITime) (ioidc.IRelyingParty, error) {
+	providers := vvmConfig.OIDCProviders
+	if providers == nil {
+		var err error
+		if providers, err = ioidcimpl.ReadProvidersConfig(secretReader, OIDCProvidersSecretName); err != nil {
+			return nil, err
+		}
+	}
+	return ioidcimpl.Provide(providers, secretReader, httpClient, time), nil
+}
+
+// cluster of VVMs -> not cached: leases and sequences are shared among VVMs, cached values would be stale
+func provideIVVMAppTTLStorage(prov istorage.IAppStorageProvider, aspNonCaching IAppStorageNonCachingProvider,
+	vvmConfig *VVMConfig) (storage.ISysVvmStorage, error) {
+	if vvmConfig.NumVVM > 1 {
+		prov = aspNonCaching
+	}
 	return prov.AppStorage(istructs.AppQName_sys_vvm)
 }
 
@@ -287,9 +334,10 @@ func provideSchedulerRunner(cfg schedulers.BasicSchedulerConfig) appparts.ISched
 	return schedulers.ProvideSchedulers(cfg)
 }
 
-func provideBootstrapOperator(federation federation.IFederation, asp istructs.IAppStructsProvider, time timeu.ITime, apppar appparts.IAppPartitions,
+func provideBootstrapOperator(federation federation.IFederation, asp istructs.IAppStructsProvider, time timeu.ITime, appPartsCtl apppartsctl.IAppPartitionsController,
 	builtinApps []appparts.BuiltInApp, sidecarApps []appparts.SidecarApp, itokens itokens.ITokens, storageProvider istorage.IAppStorageProvider,
-	postWireInterfacePtrs btstrp.PostWireInterfacePtrs, blobHandler blobprocessor.IRequestHandler, requestSender bus.IRequestSender) (BootstrapOperator, error) {
+	postWireInterfacePtrs btstrp.PostWireInterfacePtrs, blobHandler blobprocessor.IRequestHandler, requestSender bus.IRequestSender,
+	appImagesDeployer cluster.IAppImagesDeployer) (BootstrapOperator, error) {
 	var clusterBuiltinApp btstrp.ClusterBuiltInApp
 	otherApps := make([]appparts.BuiltInApp, 0, len(builtinApps))
 	for _, app := range builtinApps {
@@ -308,10 +356,116 @@ func provideBootstrapOperator(federation federation.IFederation, asp istructs.IA
 		return nil, fmt.Errorf("%s app should be added to VVM builtin apps", istructs.AppQName_sys_cluster)
 	}
 	return pipeline.NewSyncOp(func(ctx context.Context, work pipeline.IWorkpiece) (err error) {
-		return btstrp.Bootstrap(federation, asp, time, apppar, clusterBuiltinApp, otherApps, sidecarApps, itokens, storageProvider, postWireInterfacePtrs, blobHandler, requestSender)
+		return btstrp.Bootstrap(federation, asp, time, appPartsCtl, clusterBuiltinApp, otherApps, sidecarApps, itokens, storageProvider, postWireInterfacePtrs, blobHandler, requestSender, appImagesDeployer)
 	}), nil
 }
 
+// single VVM -> all partitions are deployed on the current VVM
+// cluster of VVMs -> partitions are distributed among alive VVMs, see [apppartsctl.NewDistributed]
+func provideAppPartitionsController(cfg *VVMConfig, parts appparts.IAppPartitions, sysVvmStorage storage.ISysVvmStorage,
+	vvmLeaders ielections.ITTLStorage[storage.TTLStorageImplKey, string], tm timeu.ITime, n10nPeers *N10nPeersSource) (apppartsctl.IAppPartitionsController, func(), error) {
+	if cfg.NumVVM <= 1 {
+		return apppartsctl.New(parts)
+	}
+	if cfg.VVMPort <= 0 {
+		return nil, nil, errors.New("VVMPort must be specified if NumVVM > 1: other VVMs forward requests to it")
+	}
+	aliveVVMs := func() (uint, error) {
+		alive := uint(0)
+		for vvmIdx := storage.TTLStorageImplKey(1); vvmIdx <= cfg.NumVVM; vvmIdx++ {
+			ok, _, err := vvmLeaders.Get(vvmIdx)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				alive++
+			}
+		}
+		return alive, nil
+	}
+	self := vvmAddress(cfg)
+	ctl, cleanup, err := apppartsctl.NewDistributed(parts, storage.NewPartitionOwnersTTLStorage(sysVvmStorage), aliveVVMs, self, tm)
+	if err != nil {
+		// notest
+		return nil, nil, err
+	}
+
+	// n10n updates of owned partitions are sent to other alive VVMs, VVM leadership value is the VVM address
+	n10nPeers.getter = func(app appdef.AppQName, wsid istructs.WSID) (peers []apppartsctl.VVMAddress) {
+		partitionID, err := parts.AppWorkspacePartitionID(app, wsid)
+		if err != nil || !ctl.OwnsPartition(app, partitionID) {
+			return nil
+		}
+		for vvmIdx := storage.TTLStorageImplKey(1); vvmIdx <= cfg.NumVVM; vvmIdx++ {
+			ok, addr, err := vvmLeaders.Get(vvmIdx)
+			if err != nil {
+				// notest
+				logger.Error(fmt.Sprintf("failed to get VVM %d address: %s", vvmIdx, err))
+				continue
+			}
+			if ok && addr != string(self) {
+				peers = append(peers, apppartsctl.VVMAddress(addr))
+			}
+		}
+		return peers
+	}
+	return ctl, cleanup, nil
+}
+
+// single VVM -> in-memory n10n broker
+// cluster of VVMs -> updates of owned partitions are sent to other VVMs as well, see [n10nBroadcaster]
+func provideN10nBroker(cfg *VVMConfig, quotas in10n.Quotas, tm timeu.ITime, n10nPeers *N10nPeersSource) (in10n.IN10nBroker, func()) {
+	broker, cleanup := in10nmem.NewN10nBroker(quotas, tm)
+	if cfg.NumVVM <= 1 {
+		return broker, cleanup
+	}
+	broadcaster, broadcasterCleanup := newN10nBroadcaster(broker, n10nPeers)
+	return broadcaster, func() {
+		broadcasterCleanup()
+		cleanup()
+	}
+}
+
+func provideN10nPeersSource() *N10nPeersSource {
+	return &N10nPeersSource{}
+}
+
+// single VVM -> nil, requests are never forwarded
+// error messages of the router are translated by the application translations
+func provideAppTranslations(appParts appparts.IAppPartitions) router.AppTranslations {
+	return func(app appdef.AppQName) appdef.IWithTranslations {
+		appDef, err := appParts.AppDef(app)
+		if err != nil {
+			// unknown app
+			return nil
+		}
+		return appDef
+	}
+}
+
+func providePartitionOwnerLocator(cfg *VVMConfig, parts appparts.IAppPartitions, ctl apppartsctl.IAppPartitionsController) router.PartitionOwnerLocator {
+	if cfg.NumVVM <= 1 {
+		return nil
+	}
+	return func(app appdef.AppQName, wsid istructs.WSID) (*url.URL, bool) {
+		partitionID, err := parts.AppWorkspacePartitionID(app, wsid)
+		if err != nil {
+			return nil, false // will be replied by the request handler
+		}
+		owner, ok := ctl.PartitionOwner(app, partitionID)
+		if !ok {
+			return nil, false
+		}
+		ownerURL, err := url.Parse(owner)
+		if err != nil {
+			// notest
+			logger.Error(fmt.Sprintf("wrong owner %q of partition %s/%d: %s", owner, app, partitionID, err))
+			return nil, false
+		}
+		return ownerURL, true
+	}
+}
+
 func provideBuiltInAppPackages(builtInAppsArtefacts BuiltInAppsArtefacts) []BuiltInAppPackages {
 	return builtInAppsArtefacts.builtInAppPackages
 }
@@ -412,9 +566,18 @@ func provideAppPartitions(
 		sch,
 		eef,
 		bf,
+		checkAppDefCompatibility,
 	)
 }
 
+// rejects redeploy of application with definition which is not backward compatible with the deployed one
+func checkAppDefCompatibility(old, new appdef.IAppDef) error {
+	if cerrs := appdefcompat.CheckBackwardCompatibility(old, new); cerrs != nil && len(cerrs.Errors) > 0 {
+		return cerrs
+	}
+	return nil
+}
+
 func provideIsDeviceAllowedFunc(appEPs map[appdef.AppQName]extensionpoints.IExtensionPoint) iauthnzimpl.IsDeviceAllowedFuncs {
 	res := iauthnzimpl.IsDeviceAllowedFuncs{}
 	for appQName, appEP := range appEPs {
@@ -445,6 +608,10 @@ func provideAppPartsCtlPipelineService(ctl apppartsctl.IAppPartitionsController)
 	return &AppPartsCtlPipelineService{IAppPartitionsController: ctl}
 }
 
+func provideAppImagesDeployerPipelineService(deployer cluster.IAppImagesDeployer) IAppImagesDeployerPipelineService {
+	return &AppImagesDeployerPipelineService{IAppImagesDeployer: deployer}
+}
+
 func provideIAppStorageUncachingProviderFactory(factory istorage.IAppStorageFactory, vvmCfg *VVMConfig) IAppStorageUncachingProviderFactory {
 	return func() istorage.IAppStorageProvider {
 		return provider.Provide(factory, vvmCfg.KeyspaceIsolationSuffix)
@@ -493,8 +660,11 @@ func provideSubjectGetterFunc() iauthnzimpl.SubjectGetterFunc {
 }
 
 func provideBucketsFactory(time timeu.ITime) irates.BucketsFactoryType {
-	return func() irates.IBuckets {
-		return iratesce.Provide(time)
+	return func(buckets appdef.RateBuckets, storage istructs.IAppTTLStorage) irates.IBuckets {
+		if buckets == appdef.RateBuckets_Local || storage == nil {
+			return iratesce.Provide(time)
+		}
+		return iratesstg.Provide(buckets, storage, time)
 	}
 }
 
@@ -560,7 +730,7 @@ func provideMetricsServicePortGetter(ms metrics.MetricsService) func() metrics.M
 	}
 }
 
-func provideRouterParams(cfg *VVMConfig, port VVMPortType) router.RouterParams {
+func provideRouterParams(cfg *VVMConfig, port VVMPortType, staticFolders httpstatic.IAppFolders) router.RouterParams {
 	res := router.RouterParams{
 		HTTPServerParams: router.HTTPServerParams{
 			Port:             int(port),
@@ -575,11 +745,17 @@ func provideRouterParams(cfg *VVMConfig, port VVMPortType) router.RouterParams {
 		RoutesRewrite:        cfg.RoutesRewrite,
 		RouteDomains:         cfg.RouteDomains,
 		MaxQueriesPerWS:      cfg.RouterMaxQueriesPerWS,
+		CompressionMinSize:   cfg.RouterCompressionMinSize,
 		ITime:                cfg.Time,
+		StaticFolders:        staticFolders,
 	}
 	return res
 }
 
+func provideIAppStaticFolders(staticFolders httpstatic.IAppFolders) ihttp.IAppStaticFolders {
+	return staticFolders
+}
+
 func provideVVMApps(builtInApps []appparts.BuiltInApp) (vvmApps VVMApps) {
 	for _, builtInApp := range builtInApps {
 		vvmApps = append(vvmApps, builtInApp.Name)
@@ -592,46 +768,6 @@ func provideBuiltInAppsArtefacts(vvmConfig *VVMConfig, apis builtinapps.APIs, cf
 	return vvmConfig.VVMAppsBuilder.BuildAppsArtefacts(apis, cfgs, appEPs, schemasCache)
 }
 
-// extModuleURLs is filled here
-func parseSidecarAppSubDir(fullPath string, basePath string, out_extModuleURLs map[string]*url.URL) (asts []*parser.PackageSchemaAST, err error) {
-	dirEntries, err := os.ReadDir(fullPath)
-	if err != nil {
-		// notest
-		return nil, err
-	}
-	modulePath := strings.ReplaceAll(fullPath, basePath, "")
-	modulePath = strings.TrimPrefix(modulePath, string(os.PathSeparator))
-	modulePath = strings.ReplaceAll(modulePath, string(os.PathSeparator), "/")
-	for _, dirEntry := range dirEntries {
-		if dirEntry.IsDir() {
-			subASTs, err := parseSidecarAppSubDir(filepath.Join(fullPath, dirEntry.Name()), basePath, out_extModuleURLs)
-			if err != nil {
-				return nil, err
-			}
-			asts = append(asts, subASTs...)
-			continue
-		}
-		if filepath.Ext(dirEntry.Name()) == ".wasm" {
-			moduleURL, err := url.Parse("file:///" + filepath.Join(fullPath, dirEntry.Name()))
-			if err != nil {
-				// notest
-				return nil, err
-			}
-
-			out_extModuleURLs[modulePath] = moduleURL
-			continue
-		}
-	}
-
-	dirAST, err := parser.ParsePackageDir(modulePath, os.DirFS(fullPath).(filesu.IReadFS), ".")
-	if err == nil {
-		asts = append(asts, dirAST)
-	} else if !errors.Is(err, parser.ErrDirContainsNoSchemaFiles) {
-		return nil, err
-	}
-	return asts, nil
-}
-
 func provideSidecarApps(vvmConfig *VVMConfig) (res []appparts.SidecarApp, err error) {
 	if len(vvmConfig.DataPath) == 0 {
 		return nil, nil
@@ -658,8 +794,8 @@ func provideSidecarApps(vvmConfig *VVMConfig) (res []appparts.SidecarApp, err er
 			return nil, err
 		}
 		var appDD *appparts.AppDeploymentDescriptor
-		appASTs := []*parser.PackageSchemaAST{}
-		extModuleURLs := map[string]*url.URL{}
+		var appDef appdef.IAppDef
+		var extModuleURLs map[string]*url.URL
 		for _, appDirEntry := range appDirEntries {
 			// descriptor.json file and image/pkg/ folder here
 			if !appDirEntry.IsDir() && appDirEntry.Name() == "descriptor.json" {
@@ -675,7 +811,7 @@ func provideSidecarApps(vvmConfig *VVMConfig) (res []appparts.SidecarApp, err er
 			if appDirEntry.IsDir() && appDirEntry.Name() == "image" {
 				// how to consider that could be >1 ExtensionModules here?
 				pkgPath := filepath.Join(appPath, "image", "pkg")
-				appASTs, err = parseSidecarAppSubDir(pkgPath, pkgPath, extModuleURLs)
+				appDef, extModuleURLs, err = cluster.ParseAppImageDir(pkgPath)
 				if err != nil {
 					return nil, err
 				}
@@ -685,18 +821,8 @@ func provideSidecarApps(vvmConfig *VVMConfig) (res []appparts.SidecarApp, err er
 			return nil, fmt.Errorf("no descriptor for sidecar app %s", appQName)
 		}
 
-		appSchemaAST, err := parser.BuildAppSchema(appASTs)
-		if err != nil {
-			return nil, err
-		}
-		appDefBuilder := builder.New()
-		if err := parser.BuildAppDefs(appSchemaAST, appDefBuilder); err != nil {
-			return nil, err
-		}
-
-		appDef, err := appDefBuilder.Build()
-		if err != nil {
-			return nil, err
+		if appDef == nil {
+			return nil, fmt.Errorf("no image for sidecar app %s", appQName)
 		}
 
 		// TODO: implement sidecar apps schemas compatibility check (baseline_schemas)
@@ -750,11 +876,20 @@ func provideChannelGroups(cfg *VVMConfig) (res []iprocbusmem.ChannelGroup) {
 }
 
 func provideCachingAppStorageProvider(storageCacheSize StorageCacheSizeType, metrics imetrics.IMetrics,
-	vvmName processors.VVMName, uncachingProvider IAppStorageUncachingProviderFactory, iTime timeu.ITime) istorage.IAppStorageProvider {
-	aspNonCaching := uncachingProvider()
+	vvmName processors.VVMName, aspNonCaching IAppStorageNonCachingProvider, iTime timeu.ITime) istorage.IAppStorageProvider {
 	return istoragecache.Provide(int(storageCacheSize), aspNonCaching, metrics, string(vvmName), iTime)
 }
 
+func provideNonCachingAppStorageProvider(uncachingProvider IAppStorageUncachingProviderFactory, vvmConfig *VVMConfig,
+	secretReader isecrets.ISecretReader, iTime timeu.ITime) IAppStorageNonCachingProvider {
+	aspNonCaching := uncachingProvider()
+	if vvmConfig.StorageEncryption {
+		// below the cache: values are cached decrypted
+		aspNonCaching = istorageenc.Provide(aspNonCaching, secretReader, iTime)
+	}
+	return aspNonCaching
+}
+
 func provideBlobHandlerPtr() blobprocessor.IRequestHandlerPtr {
 	return new(blobprocessor.IRequestHandler)
 }
@@ -763,13 +898,18 @@ func provideIRequestSenderPtr() bus.IRequestSenderPtr {
 	return new(bus.IRequestSender)
 }
 
+func provideIAppPartitionsPtr() appparts.IAppPartitionsPtr {
+	return new(appparts.IAppPartitions)
+}
+
 func providePostWireInterfacePtrs(blobberAppStoragePtr iblobstoragestg.BlobAppStoragePtr, routerAppStoragePtr dbcertcache.RouterAppStoragePtr,
-	blobHandlerPtr blobprocessor.IRequestHandlerPtr, requestSenderPtr bus.IRequestSenderPtr) btstrp.PostWireInterfacePtrs {
+	blobHandlerPtr blobprocessor.IRequestHandlerPtr, requestSenderPtr bus.IRequestSenderPtr, appPartsPtr appparts.IAppPartitionsPtr) btstrp.PostWireInterfacePtrs {
 	return btstrp.PostWireInterfacePtrs{
 		BlobberAppStorage: blobberAppStoragePtr,
 		RouterAppStorage:  routerAppStoragePtr,
 		BlobHandler:       blobHandlerPtr,
 		RequestSender:     requestSenderPtr,
+		AppPartitions:     appPartsPtr,
 	}
 }
 
@@ -790,9 +930,10 @@ func provideRouterServices(rp router.RouterParams, broker in10n.IN10nBroker, blo
 	wLimiterFactory blobprocessor.WLimiterFactory, blobStorage iblobstorage.IBLOBStorage,
 	autocertCache autocert.Cache, requestSender bus.IRequestSender, vvmPortSource *VVMPortSource,
 	numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, iTokens itokens.ITokens,
-	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory) RouterServices {
+	federation federation.IFederation, appTokensFactory payloads.IAppTokensFactory, ownerLocator router.PartitionOwnerLocator,
+	appTranslations router.AppTranslations) RouterServices {
 	httpSrv, acmeSrv, adminSrv := router.Provide(rp, broker, blobRequestHandler, autocertCache, requestSender, numsAppsWorkspaces,
-		iTokens, federation, appTokensFactory)
+		iTokens, federation, appTokensFactory, ownerLocator, appTranslations)
 	vvmPortSource.getter = func() VVMPortType {
 		return VVMPortType(httpSrv.GetPort())
 	}
@@ -861,11 +1002,11 @@ func provideQueryProcessors_V1(qpCount istructs.NumQueryProcessors, qc QueryChan
 func provideQueryProcessors_V2(qpCount istructs.NumQueryProcessors, qc QueryChannel_V2, appParts appparts.IAppPartitions, qpFactory query2.ServiceFactory,
 	imetrics imetrics.IMetrics, vvm processors.VVMName, mpq MaxPrepareQueriesType, authn iauthnz.IAuthenticator,
 	tokens itokens.ITokens, federation federation.IFederation, statelessResources istructsmem.IStatelessResources, secretReader isecrets.ISecretReader,
-	stateOpts state.StateOpts, httpClient httpu.IHTTPClient) OperatorQueryProcessors_V2 {
+	stateOpts state.StateOpts, httpClient httpu.IHTTPClient, oidc ioidc.IRelyingParty) OperatorQueryProcessors_V2 {
 	forks := make([]pipeline.ForkOperatorOptionFunc, qpCount)
 	for i := 0; i < int(qpCount); i++ {
 		forks[i] = pipeline.ForkBranch(pipeline.ServiceOperator(qpFactory(iprocbus.ServiceChannel(qc), appParts, int(mpq), imetrics,
-			string(vvm), authn, tokens, federation, statelessResources, secretReader, stateOpts, httpClient)))
+			string(vvm), authn, tokens, federation, statelessResources, secretReader, stateOpts, httpClient, oidc)))
 	}
 	return pipeline.ForkOperator(pipeline.ForkSame, forks[0], forks[1:]...)
 }
@@ -885,6 +1026,7 @@ func provideServicePipeline(
 	opQueryProcessors_v2 OperatorQueryProcessors_V2,
 	opBLOBProcessors OperatorBLOBProcessors,
 	appPartsCtl IAppPartsCtlPipelineService,
+	appImagesDeployer IAppImagesDeployerPipelineService,
 	bootstrapSyncOp BootstrapOperator,
 	adminEndpoint AdminEndpointServiceOperator,
 	publicEndpoint PublicEndpointServiceOperator,
@@ -897,6 +1039,7 @@ func provideServicePipeline(
 			pipeline.ForkBranch(opCommandProcessors),
 			pipeline.ForkBranch(opBLOBProcessors),
 			pipeline.ForkBranch(pipeline.ServiceOperator(appPartsCtl)),
+			pipeline.ForkBranch(pipeline.ServiceOperator(appImagesDeployer)),
 			pipeline.ForkBranch(pipeline.ServiceOperator(appStorageProvider)), // is service to stop goroutines in bbolt driver
 		)),
 		pipeline.WireSyncOperator("admin endpoint", adminEndpoint),
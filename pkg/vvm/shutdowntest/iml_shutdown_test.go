@@ -40,10 +40,14 @@ func TestAutomaticShutdownOnLeadershipLoss(t *testing.T) {
 
 	vvmAppTTLStorage, err := vvm1.APIs.IAppStorageProvider.AppStorage(istructs.AppQName_sys_vvm)
 	require.NoError(t, err)
-	ok, err := vvmAppTTLStorage.CompareAndSwap(
+	leader := []byte{}
+	ok, err := vvmAppTTLStorage.TTLGet(pKey, cCols, &leader)
+	r.NoError(err)
+	r.True(ok)
+	ok, err = vvmAppTTLStorage.CompareAndSwap(
 		pKey,
 		cCols,
-		[]byte(vvmCfg.IP.String()),
+		leader,
 		[]byte("another_value"),
 		50,
 	)
@@ -17,6 +17,7 @@ const (
 	pKeyPrefix_SeqStorage_WS
 
 	pKeyPrefix_AppTTL
+	pKeyPrefix_PartitionOwner
 )
 
 const (
@@ -28,5 +29,6 @@ const (
 	MaxValueLength              = 65536
 	MaxTTLSeconds               = 31536000
 	appTTLPKSize                = 8
+	partitionIDSize             = 2
 	appTTLValidationErrTemplate = "%w: %w"
 )
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storage
+
+import (
+	"encoding/binary"
+
+	"github.com/voedger/voedger/pkg/apppartsctl"
+	"github.com/voedger/voedger/pkg/coreutils/utils"
+)
+
+type implPartitionOwnersTTLStorage struct {
+	sysVVMStorage ISysVvmStorage
+}
+
+// pKey: prefix, cCols: partitionID + application name
+func (s *implPartitionOwnersTTLStorage) buildKeys(key apppartsctl.PartitionKey) (pKey, cCols []byte) {
+	pKey = make([]byte, utils.Uint32Size)
+	binary.BigEndian.PutUint32(pKey, pKeyPrefix_PartitionOwner)
+	cCols = make([]byte, partitionIDSize, partitionIDSize+len(key.App.String()))
+	binary.BigEndian.PutUint16(cCols, uint16(key.Partition))
+	cCols = append(cCols, key.App.String()...)
+	return
+}
+
+func (s *implPartitionOwnersTTLStorage) InsertIfNotExist(key apppartsctl.PartitionKey, val string, ttlSeconds int) (bool, error) {
+	pKey, cCols := s.buildKeys(key)
+	return s.sysVVMStorage.InsertIfNotExists(pKey, cCols, []byte(val), ttlSeconds)
+}
+
+func (s *implPartitionOwnersTTLStorage) CompareAndSwap(key apppartsctl.PartitionKey, oldVal, newVal string, ttlSeconds int) (bool, error) {
+	pKey, cCols := s.buildKeys(key)
+	return s.sysVVMStorage.CompareAndSwap(pKey, cCols, []byte(oldVal), []byte(newVal), ttlSeconds)
+}
+
+func (s *implPartitionOwnersTTLStorage) CompareAndDelete(key apppartsctl.PartitionKey, val string) (bool, error) {
+	pKey, cCols := s.buildKeys(key)
+	return s.sysVVMStorage.CompareAndDelete(pKey, cCols, []byte(val))
+}
+
+func (s *implPartitionOwnersTTLStorage) Get(key apppartsctl.PartitionKey) (bool, string, error) {
+	pKey, cCols := s.buildKeys(key)
+	data := []byte{}
+	ok, err := s.sysVVMStorage.TTLGet(pKey, cCols, &data)
+	if err != nil {
+		return false, "", err
+	}
+	return ok, string(data), err
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2025-present unTill Software Development Group B.V.
+ */
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/voedger/voedger/pkg/apppartsctl"
+	"github.com/voedger/voedger/pkg/goutils/testingu"
+	"github.com/voedger/voedger/pkg/istorage/mem"
+	"github.com/voedger/voedger/pkg/istorage/provider"
+	"github.com/voedger/voedger/pkg/istructs"
+)
+
+func TestPartitionOwnersTTLStorage(t *testing.T) {
+	require := require.New(t)
+	appStorageProvider := provider.Provide(mem.Provide(testingu.MockTime))
+	sysVvmAppStorage, err := appStorageProvider.AppStorage(istructs.AppQName_sys_vvm)
+	require.NoError(err)
+
+	owners := NewPartitionOwnersTTLStorage(sysVvmAppStorage)
+
+	key1 := apppartsctl.PartitionKey{App: istructs.AppQName_test1_app1, Partition: 1}
+	key2 := apppartsctl.PartitionKey{App: istructs.AppQName_test1_app2, Partition: 1}
+
+	ok, err := owners.InsertIfNotExist(key1, "vvm1", seconds10)
+	require.NoError(err)
+	require.True(ok)
+
+	t.Run("should not insert if already owned", func(t *testing.T) {
+		ok, err := owners.InsertIfNotExist(key1, "vvm2", seconds10)
+		require.NoError(err)
+		require.False(ok)
+	})
+
+	t.Run("should be different keys for different apps", func(t *testing.T) {
+		ok, _, err := owners.Get(key2)
+		require.NoError(err)
+		require.False(ok)
+
+		ok, err = owners.InsertIfNotExist(key2, "vvm2", seconds10)
+		require.NoError(err)
+		require.True(ok)
+	})
+
+	t.Run("should prolong lease by compare and swap", func(t *testing.T) {
+		testingu.MockTime.Sleep(seconds10 / 2 * time.Second)
+		ok, err := owners.CompareAndSwap(key1, "vvm1", "vvm1", seconds10)
+		require.NoError(err)
+		require.True(ok)
+
+		testingu.MockTime.Sleep(seconds10 / 2 * time.Second)
+		ok, owner, err := owners.Get(key1)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal("vvm1", owner)
+	})
+
+	t.Run("should expire lease", func(t *testing.T) {
+		testingu.MockTime.Sleep(seconds10 * time.Second)
+		for _, key := range []apppartsctl.PartitionKey{key1, key2} {
+			ok, _, err := owners.Get(key)
+			require.NoError(err)
+			require.False(ok)
+		}
+	})
+
+	t.Run("should delete lease by owner only", func(t *testing.T) {
+		ok, err := owners.InsertIfNotExist(key1, "vvm1", seconds10)
+		require.NoError(err)
+		require.True(ok)
+
+		ok, err = owners.CompareAndDelete(key1, "vvm2")
+		require.NoError(err)
+		require.False(ok)
+
+		ok, err = owners.CompareAndDelete(key1, "vvm1")
+		require.NoError(err)
+		require.True(ok)
+	})
+}
@@ -5,6 +5,7 @@
 package storage
 
 import (
+	"github.com/voedger/voedger/pkg/apppartsctl"
 	"github.com/voedger/voedger/pkg/ielections"
 	"github.com/voedger/voedger/pkg/isequencer"
 	"github.com/voedger/voedger/pkg/istructs"
@@ -17,6 +18,13 @@ func NewElectionsTTLStorage(sysVVMStorage ISysVvmStorage) ielections.ITTLStorage
 	}
 }
 
+// Returns storage of application partitions ownership leases
+func NewPartitionOwnersTTLStorage(sysVVMStorage ISysVvmStorage) ielections.ITTLStorage[apppartsctl.PartitionKey, string] {
+	return &implPartitionOwnersTTLStorage{
+		sysVVMStorage: sysVVMStorage,
+	}
+}
+
 func NewVVMSeqStorageAdapter(sysVVMStorage ISysVvmStorage) isequencer.IVVMSeqStorageAdapter {
 	return &implVVMSeqStorageAdapter{
 		sysVVMStorage: sysVVMStorage,
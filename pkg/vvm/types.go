@@ -15,11 +15,13 @@ import (
 	"github.com/voedger/voedger/pkg/appdef"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/apppartsctl"
+	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
 	"github.com/voedger/voedger/pkg/extensionpoints"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
 	"github.com/voedger/voedger/pkg/iblobstorage"
 	"github.com/voedger/voedger/pkg/ielections"
+	"github.com/voedger/voedger/pkg/ioidc"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/iprocbusmem"
 	"github.com/voedger/voedger/pkg/isecrets"
@@ -99,11 +101,25 @@ type VVMPortSource struct {
 	getter      func() VVMPortType
 	adminGetter func() int
 }
+
+// n10n broker is required to build app partitions, but VVMs to send n10n updates to are known by app partitions controller only
+// so N10nPeersSource is in the middle: provideAppPartitionsController writes it, n10n broker reads it
+type N10nPeersSource struct {
+	getter func(app appdef.AppQName, wsid istructs.WSID) (peers []apppartsctl.VVMAddress)
+}
 type IAppStorageUncachingProviderFactory func() (provider istorage.IAppStorageProvider)
+
+// storage provider below the cache, the only instance is shared by the caching provider and by sys_vvm storage of a cluster of VVMs
+// so it is run and stopped by the caching provider
+type IAppStorageNonCachingProvider istorage.IAppStorageProvider
 type AppPartsCtlPipelineService struct {
 	apppartsctl.IAppPartitionsController
 }
 type IAppPartsCtlPipelineService pipeline.IService
+type AppImagesDeployerPipelineService struct {
+	cluster.IAppImagesDeployer
+}
+type IAppImagesDeployerPipelineService pipeline.IService
 
 type NumVVM = uint32
 
@@ -142,6 +158,7 @@ type VVMConfig struct {
 	RouterReadTimeout                 int
 	RouterConnectionsLimit            int
 	RouterMaxQueriesPerWS             int
+	RouterCompressionMinSize          int // responses smaller than this are sent uncompressed, 0 -> compression is disabled
 	RouterUseProxyProtocol            bool
 	RouterHTTP01ChallengeHosts        []string
 	RouteDefault                      string
@@ -189,6 +206,15 @@ type VVMConfig struct {
 	IP     net.IP // current IP of the VVM. Used as the value for leaderhsip elections
 
 	SequencesTrustLevel isequencer.SequencesTrustLevel
+
+	// values are encrypted in the storage by keys read from "storage-keys-<owner>-<app>" or "storage-keys" secret
+	// see [istorageenc]
+	StorageEncryption bool
+
+	// OpenID Connect identity providers the users of apps could sign in by
+	// read from OIDCProvidersSecretName secret if nil, see [ioidcimpl.ReadProvidersConfig]
+	// client secrets are read by SecretsReader
+	OIDCProviders ioidc.ProvidersConfig
 }
 
 type VoedgerVM struct {
@@ -222,7 +248,7 @@ type VoedgerVM struct {
 	shutdownedCtx       context.Context
 	shutdownedCtxCancel context.CancelFunc
 	numVVM              NumVVM
-	ip                  net.IP
+	address             apppartsctl.VVMAddress // leadership value
 	leadershipCtx       context.Context
 
 	// used in tests only
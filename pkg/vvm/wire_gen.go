@@ -7,6 +7,7 @@
 package vvm
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -20,15 +21,16 @@ import (
 	"strings"
 
 	"github.com/voedger/voedger/pkg/appdef"
-	"github.com/voedger/voedger/pkg/appdef/builder"
+	"github.com/voedger/voedger/pkg/appdefcompat"
 	"github.com/voedger/voedger/pkg/appparts"
 	"github.com/voedger/voedger/pkg/apppartsctl"
 	"github.com/voedger/voedger/pkg/btstrp"
 	"github.com/voedger/voedger/pkg/bus"
+	"github.com/voedger/voedger/pkg/cluster"
 	"github.com/voedger/voedger/pkg/coreutils"
 	"github.com/voedger/voedger/pkg/coreutils/federation"
+	"github.com/voedger/voedger/pkg/coreutils/httpstatic"
 	"github.com/voedger/voedger/pkg/extensionpoints"
-	"github.com/voedger/voedger/pkg/goutils/filesu"
 	"github.com/voedger/voedger/pkg/goutils/httpu"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"github.com/voedger/voedger/pkg/goutils/timeu"
@@ -36,25 +38,30 @@ import (
 	"github.com/voedger/voedger/pkg/iauthnzimpl"
 	"github.com/voedger/voedger/pkg/iblobstorage"
 	"github.com/voedger/voedger/pkg/iblobstoragestg"
+	"github.com/voedger/voedger/pkg/ielections"
 	"github.com/voedger/voedger/pkg/iextengine"
+	"github.com/voedger/voedger/pkg/ihttp"
 	"github.com/voedger/voedger/pkg/in10n"
 	"github.com/voedger/voedger/pkg/in10nmem"
+	"github.com/voedger/voedger/pkg/ioidc"
+	"github.com/voedger/voedger/pkg/ioidcimpl"
 	"github.com/voedger/voedger/pkg/iprocbus"
 	"github.com/voedger/voedger/pkg/iprocbusmem"
 	"github.com/voedger/voedger/pkg/irates"
 	"github.com/voedger/voedger/pkg/iratesce"
+	"github.com/voedger/voedger/pkg/iratesstg"
 	"github.com/voedger/voedger/pkg/isecrets"
 	"github.com/voedger/voedger/pkg/isequencer"
 	"github.com/voedger/voedger/pkg/istorage"
 	"github.com/voedger/voedger/pkg/istorage/provider"
 	"github.com/voedger/voedger/pkg/istoragecache"
+	"github.com/voedger/voedger/pkg/istorageenc"
 	"github.com/voedger/voedger/pkg/istructs"
 	"github.com/voedger/voedger/pkg/istructsmem"
 	"github.com/voedger/voedger/pkg/itokens"
 	payloads "github.com/voedger/voedger/pkg/itokens-payloads"
 	"github.com/voedger/voedger/pkg/itokensjwt"
 	imetrics "github.com/voedger/voedger/pkg/metrics"
-	"github.com/voedger/voedger/pkg/parser"
 	"github.com/voedger/voedger/pkg/pipeline"
 	"github.com/voedger/voedger/pkg/processors"
 	"github.com/voedger/voedger/pkg/processors/actualizers"
@@ -66,7 +73,9 @@ import (
 	"github.com/voedger/voedger/pkg/processors/schedulers"
 	"github.com/voedger/voedger/pkg/router"
 	"github.com/voedger/voedger/pkg/state"
+	"github.com/voedger/voedger/pkg/sys/apikeys"
 	"github.com/voedger/voedger/pkg/sys/invite"
+	"github.com/voedger/voedger/pkg/sys/storages"
 	"github.com/voedger/voedger/pkg/sys/sysprovide"
 	builtinapps "github.com/voedger/voedger/pkg/vvm/builtin"
 	dbcertcache "github.com/voedger/voedger/pkg/vvm/db_cert_cache"
@@ -102,16 +111,18 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		return nil, nil, err
 	}
 	iAppStorageUncachingProviderFactory := provideIAppStorageUncachingProviderFactory(iAppStorageFactory, vvmConfig)
-	iAppStorageProvider := provideCachingAppStorageProvider(storageCacheSizeType, iMetrics, vvmName, iAppStorageUncachingProviderFactory, iTime)
+	iAppStorageNonCachingProvider := provideNonCachingAppStorageProvider(iAppStorageUncachingProviderFactory, vvmConfig, iSecretReader, iTime)
+	iAppStorageProvider := provideCachingAppStorageProvider(storageCacheSizeType, iMetrics, vvmName, iAppStorageNonCachingProvider, iTime)
 	sequencesTrustLevel := vvmConfig.SequencesTrustLevel
-	iSysVvmStorage, err := provideIVVMAppTTLStorage(iAppStorageProvider)
+	iSysVvmStorage, err := provideIVVMAppTTLStorage(iAppStorageProvider, iAppStorageNonCachingProvider, vvmConfig)
 	if err != nil {
 		return nil, nil, err
 	}
 	iAppStructsProvider := provideIAppStructsProvider(appConfigsTypeEmpty, iAppTokensFactory, iAppStorageProvider, sequencesTrustLevel, iSysVvmStorage)
 	syncActualizerFactory := actualizers.ProvideSyncActualizerFactory()
 	quotas := provideN10NQuotas(vvmConfig)
-	in10nBroker, cleanup := in10nmem.NewN10nBroker(quotas, iTime)
+	n10nPeersSource := provideN10nPeersSource()
+	in10nBroker, cleanup := provideN10nBroker(vvmConfig, quotas, iTime, n10nPeersSource)
 	v2 := provideAppsExtensionPoints(vvmConfig)
 	buildInfo, err := provideBuildInfo()
 	if err != nil {
@@ -125,10 +136,12 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	routerAppStoragePtr := provideRouterAppStoragePtr(iAppStorageProvider)
 	iRequestHandlerPtr := provideBlobHandlerPtr()
 	iRequestSenderPtr := provideIRequestSenderPtr()
-	postWireInterfacePtrs := providePostWireInterfacePtrs(blobAppStoragePtr, routerAppStoragePtr, iRequestHandlerPtr, iRequestSenderPtr)
+	iAppPartitionsPtr := provideIAppPartitionsPtr()
+	postWireInterfacePtrs := providePostWireInterfacePtrs(blobAppStoragePtr, routerAppStoragePtr, iRequestHandlerPtr, iRequestSenderPtr, iAppPartitionsPtr)
 	iStatelessResources := provideStatelessResources(appConfigsTypeEmpty, vvmConfig, v2, buildInfo, iAppStorageProvider, iTokens, iFederation, iAppStructsProvider, iAppTokensFactory, postWireInterfacePtrs)
 	v3 := actualizers.NewSyncActualizerFactoryFactory(syncActualizerFactory, iSecretReader, in10nBroker, iStatelessResources)
-	stateOpts := provideStateOpts()
+	iblobStorage := provideBlobStorage(blobAppStoragePtr, iTime)
+	stateOpts := provideStateOpts(iblobStorage, iMetrics, vvmName)
 	iEmailSender := vvmConfig.EmailSender
 	ihttpClient, cleanup3 := provideHTTPClient()
 	basicAsyncActualizerConfig := provideBasicAsyncActualizerConfig(vvmName, iSecretReader, iTokens, iMetrics, in10nBroker, iFederation, stateOpts, iEmailSender, ihttpClient)
@@ -141,7 +154,9 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		Broker:       in10nBroker,
 		Federation:   iFederation,
 		Time:         iTime,
+		StateOpts:    stateOpts,
 		EmailSender:  iEmailSender,
+		HTTPClient:   ihttpClient,
 	}
 	iSchedulerRunner := provideSchedulerRunner(basicSchedulerConfig)
 	bucketsFactoryType := provideBucketsFactory(iTime)
@@ -152,7 +167,9 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		cleanup()
 		return nil, nil, err
 	}
-	iblobStorage := provideBlobStorage(blobAppStoragePtr, iTime)
+	iAppFolders := httpstatic.NewAppFolders()
+	iAppStaticFolders := provideIAppStaticFolders(iAppFolders)
+	iAppImagesDeployer := cluster.ProvideAppImagesDeployer(iAppStructsProvider, iTime, iblobStorage, iAppPartitionsPtr, iAppStaticFolders)
 	apIs := builtinapps.APIs{
 		ITokens:             iTokens,
 		IAppStructsProvider: iAppStructsProvider,
@@ -162,6 +179,9 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		ITime:               iTime,
 		SidecarApps:         v4,
 		IBLOBStorage:        iblobStorage,
+		AppPartitions:       iAppPartitionsPtr,
+		IAppStaticFolders:   iAppStaticFolders,
+		AppImagesDeployer:   iAppImagesDeployer,
 	}
 	iSchemasCache := vvmConfig.SchemasCache
 	builtInAppsArtefacts, err := provideBuiltInAppsArtefacts(vvmConfig, apIs, appConfigsTypeEmpty, v2, iSchemasCache)
@@ -180,7 +200,8 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	}
 	v5 := provideSubjectGetterFunc()
 	isDeviceAllowedFuncs := provideIsDeviceAllowedFunc(v2)
-	iAuthenticator := iauthnzimpl.NewDefaultAuthenticator(v5, isDeviceAllowedFuncs)
+	v6 := apikeys.ProvideAPIKeyGetter(iTokens)
+	iAuthenticator := iauthnzimpl.NewDefaultAuthenticator(v5, isDeviceAllowedFuncs, v6, iTime)
 	serviceFactory := commandprocessor.ProvideServiceFactory(iAppPartitions, iTime, in10nBroker, iMetrics, vvmName, iAuthenticator, iSecretReader)
 	operatorCommandProcessors := provideCommandProcessors(numCommandProcessors, commandChannelFactory, serviceFactory)
 	numQueryProcessors := vvmConfig.NumQueryProcessors
@@ -190,13 +211,22 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 	operatorQueryProcessors_V1 := provideQueryProcessors_V1(numQueryProcessors, queryChannel_V1, iAppPartitions, queryprocessorServiceFactory, iMetrics, vvmName, maxPrepareQueriesType, iAuthenticator, iTokens, iFederation, iStatelessResources, iSecretReader, stateOpts, ihttpClient)
 	queryChannel_V2 := provideQueryChannel_V2(serviceChannelFactory)
 	query2ServiceFactory := query2.ProvideServiceFactory()
-	operatorQueryProcessors_V2 := provideQueryProcessors_V2(numQueryProcessors, queryChannel_V2, iAppPartitions, query2ServiceFactory, iMetrics, vvmName, maxPrepareQueriesType, iAuthenticator, iTokens, iFederation, iStatelessResources, iSecretReader, stateOpts, ihttpClient)
+	iRelyingParty, err := provideOIDCRelyingParty(vvmConfig, iSecretReader, ihttpClient, iTime)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	operatorQueryProcessors_V2 := provideQueryProcessors_V2(numQueryProcessors, queryChannel_V2, iAppPartitions, query2ServiceFactory, iMetrics, vvmName, maxPrepareQueriesType, iAuthenticator, iTokens, iFederation, iStatelessResources, iSecretReader, stateOpts, ihttpClient, iRelyingParty)
 	numBLOBProcessors := vvmConfig.NumBLOBProcessors
 	blobServiceChannel := provideBLOBChannel(serviceChannelFactory)
 	blobMaxSizeType := vvmConfig.BLOBMaxSize
 	wLimiterFactory := provideWLimiterFactory(blobMaxSizeType)
 	operatorBLOBProcessors := provideOpBLOBProcessors(numBLOBProcessors, blobServiceChannel, iblobStorage, wLimiterFactory)
-	iAppPartitionsController, cleanup5, err := apppartsctl.New(iAppPartitions)
+	ittlStorage := storage.NewElectionsTTLStorage(iSysVvmStorage)
+	iAppPartitionsController, cleanup5, err := provideAppPartitionsController(vvmConfig, iAppPartitions, iSysVvmStorage, ittlStorage, iTime, n10nPeersSource)
 	if err != nil {
 		cleanup4()
 		cleanup3()
@@ -205,18 +235,19 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		return nil, nil, err
 	}
 	iAppPartsCtlPipelineService := provideAppPartsCtlPipelineService(iAppPartitionsController)
-	v6 := provideBuiltInApps(builtInAppsArtefacts, v4)
+	iAppImagesDeployerPipelineService := provideAppImagesDeployerPipelineService(iAppImagesDeployer)
+	v7 := provideBuiltInApps(builtInAppsArtefacts, v4)
 	blobServiceChannelGroupIdx := provideProcessorChannelGroupIdxBLOB(vvmConfig)
 	iRequestHandler := blobprocessor.NewIRequestHandler(iProcBus, blobServiceChannelGroupIdx, iAppPartitions)
 	commandProcessorsChannelGroupIdxType := provideProcessorChannelGroupIdxCommand(vvmConfig)
 	queryProcessorsChannelGroupIdxType_V1 := provideProcessorChannelGroupIdxQuery_V1(vvmConfig)
 	queryProcessorsChannelGroupIdxType_V2 := provideProcessorChannelGroupIdxQuery_V2(vvmConfig)
-	vvmApps := provideVVMApps(v6)
+	vvmApps := provideVVMApps(v7)
 	in10NProc, cleanup6 := n10n.NewIN10NProc(vvmCtx, in10nBroker, iAuthenticator, iAppTokensFactory, iAppStructsProvider)
 	busyProcessorLogMode := vvmConfig.BusyProcessorLogMode
 	requestHandler := provideRequestHandler(iAppPartitions, iProcBus, commandProcessorsChannelGroupIdxType, queryProcessorsChannelGroupIdxType_V1, queryProcessorsChannelGroupIdxType_V2, numCommandProcessors, vvmApps, in10NProc, busyProcessorLogMode)
 	iRequestSender := bus.NewIRequestSender(iTime, requestHandler)
-	bootstrapOperator, err := provideBootstrapOperator(iFederation, iAppStructsProvider, iTime, iAppPartitions, v6, v4, iTokens, iAppStorageProvider, postWireInterfacePtrs, iRequestHandler, iRequestSender)
+	bootstrapOperator, err := provideBootstrapOperator(iFederation, iAppStructsProvider, iTime, iAppPartitionsController, v7, v4, iTokens, iAppStorageProvider, postWireInterfacePtrs, iRequestHandler, iRequestSender, iAppImagesDeployer)
 	if err != nil {
 		cleanup6()
 		cleanup5()
@@ -227,9 +258,9 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		return nil, nil, err
 	}
 	vvmPortType := vvmConfig.VVMPort
-	routerParams := provideRouterParams(vvmConfig, vvmPortType)
+	routerParams := provideRouterParams(vvmConfig, vvmPortType, iAppFolders)
 	cache := dbcertcache.ProvideDBCache(routerAppStoragePtr)
-	v7, err := provideNumsAppsWorkspaces(vvmApps, iAppStructsProvider, v4)
+	v8, err := provideNumsAppsWorkspaces(vvmApps, iAppStructsProvider, v4)
 	if err != nil {
 		cleanup6()
 		cleanup5()
@@ -239,23 +270,24 @@ func wireVVM(vvmCtx context.Context, vvmConfig *VVMConfig) (*VVM, func(), error)
 		cleanup()
 		return nil, nil, err
 	}
-	routerServices := provideRouterServices(routerParams, in10nBroker, iRequestHandler, quotas, wLimiterFactory, iblobStorage, cache, iRequestSender, vvmPortSource, v7, iTokens, iFederation, iAppTokensFactory)
+	partitionOwnerLocator := providePartitionOwnerLocator(vvmConfig, iAppPartitions, iAppPartitionsController)
+	appTranslations := provideAppTranslations(iAppPartitions)
+	routerServices := provideRouterServices(routerParams, in10nBroker, iRequestHandler, quotas, wLimiterFactory, iblobStorage, cache, iRequestSender, vvmPortSource, v8, iTokens, iFederation, iAppTokensFactory, partitionOwnerLocator, appTranslations)
 	adminEndpointServiceOperator := provideAdminEndpointServiceOperator(routerServices)
 	metricsServicePort := vvmConfig.MetricsServicePort
 	metricsService := metrics.ProvideMetricsService(vvmCtx, metricsServicePort, iMetrics)
 	metricsServiceOperator := provideMetricsServiceOperator(metricsService)
 	publicEndpointServiceOperator := providePublicEndpointServiceOperator(routerServices, metricsServiceOperator)
-	servicePipeline := provideServicePipeline(vvmCtx, operatorCommandProcessors, operatorQueryProcessors_V1, operatorQueryProcessors_V2, operatorBLOBProcessors, iAppPartsCtlPipelineService, bootstrapOperator, adminEndpointServiceOperator, publicEndpointServiceOperator, iAppStorageProvider)
-	v8 := provideMetricsServicePortGetter(metricsService)
-	v9 := provideBuiltInAppPackages(builtInAppsArtefacts)
-	ittlStorage := storage.NewElectionsTTLStorage(iSysVvmStorage)
+	servicePipeline := provideServicePipeline(vvmCtx, operatorCommandProcessors, operatorQueryProcessors_V1, operatorQueryProcessors_V2, operatorBLOBProcessors, iAppPartsCtlPipelineService, iAppImagesDeployerPipelineService, bootstrapOperator, adminEndpointServiceOperator, publicEndpointServiceOperator, iAppStorageProvider)
+	v9 := provideMetricsServicePortGetter(metricsService)
+	v10 := provideBuiltInAppPackages(builtInAppsArtefacts)
 	vvm := &VVM{
 		ServicePipeline:     servicePipeline,
 		APIs:                apIs,
 		IAppPartitions:      iAppPartitions,
 		AppsExtensionPoints: v2,
-		MetricsServicePort:  v8,
-		BuiltInAppsPackages: v9,
+		MetricsServicePort:  v9,
+		BuiltInAppsPackages: v10,
 		TTLStorage:          ittlStorage,
 		BuildInfo:           buildInfo,
 		ISchedulerRunner:    iSchedulerRunner,
@@ -283,7 +315,7 @@ func Provide(vvmCfg *VVMConfig) (voedgerVM *VoedgerVM, err error) {
 	voedgerVM = &VoedgerVM{
 		vvmCtxCancel:                    vvmCtxCancel,
 		numVVM:                          vvmCfg.NumVVM,
-		ip:                              vvmCfg.IP,
+		address:                         vvmAddress(vvmCfg),
 		problemCtx:                      problemCtx,
 		problemCtxCancel:                problemCtxCancel,
 		problemErrCh:                    make(chan error, 1),
@@ -328,15 +360,44 @@ func Provide(vvmCfg *VVMConfig) (voedgerVM *VoedgerVM, err error) {
 	return voedgerVM, nil
 }
 
-func provideStateOpts() state.StateOpts {
-	return state.StateOpts{}
+func provideStateOpts(blobStorage iblobstorage.IBLOBStorage, metrics2 imetrics.IMetrics, vvmName processors.VVMName) state.StateOpts {
+	return state.StateOpts{
+		BLOBReader: func(ctx context.Context, key iblobstorage.PersistentBLOBKeyType) (descr iblobstorage.DescrType, data []byte, err error) {
+			buf := bytes.NewBuffer(nil)
+			err = blobStorage.ReadBLOB(ctx, &key, func(state iblobstorage.BLOBState) error {
+				descr = state.Descr
+				return nil
+			}, buf, iblobstoragestg.RLimiter_Null)
+			return descr, buf.Bytes(), err
+		},
+		EgressDeniedHandler: func(app appdef.AppQName) {
+			metrics2.IncreaseApp(storages.Metric_HTTPEgressDeniedTotal, string(vvmName), app, 1)
+		},
+	}
 }
 
 func provideHTTPClient() (httpu.IHTTPClient, func()) {
 	return httpu.NewIHTTPClient()
 }
 
-func provideIVVMAppTTLStorage(prov istorage.IAppStorageProvider) (storage.ISysVvmStorage, error) {
+func provideOIDCRelyingParty(vvmConfig *VVMConfig, secretReader isecrets.ISecretReader, httpClient httpu.IHTTPClient,
+	time timeu.ITime) (ioidc.IRelyingParty, error) {
+	providers := vvmConfig.OIDCProviders
+	if providers == nil {
+		var err error
+		if providers, err = ioidcimpl.ReadProvidersConfig(secretReader, OIDCProvidersSecretName); err != nil {
+			return nil, err
+		}
+	}
+	return ioidcimpl.Provide(providers, secretReader, httpClient, time), nil
+}
+
+// cluster of VVMs -> not cached: leases and sequences are shared among VVMs, cached values would be stale
+func provideIVVMAppTTLStorage(prov istorage.IAppStorageProvider, aspNonCaching IAppStorageNonCachingProvider,
+	vvmConfig *VVMConfig) (storage.ISysVvmStorage, error) {
+	if vvmConfig.NumVVM > 1 {
+		prov = aspNonCaching
+	}
 	return prov.AppStorage(istructs.AppQName_sys_vvm)
 }
 
@@ -359,9 +420,10 @@ func provideSchedulerRunner(cfg schedulers.BasicSchedulerConfig) appparts.ISched
 	return schedulers.ProvideSchedulers(cfg)
 }
 
-func provideBootstrapOperator(federation2 federation.IFederation, asp istructs.IAppStructsProvider, time timeu.ITime, apppar appparts.IAppPartitions,
+func provideBootstrapOperator(federation2 federation.IFederation, asp istructs.IAppStructsProvider, time timeu.ITime, appPartsCtl apppartsctl.IAppPartitionsController,
 	builtinApps []appparts.BuiltInApp, sidecarApps []appparts.SidecarApp, itokens2 itokens.ITokens, storageProvider istorage.IAppStorageProvider,
-	postWireInterfacePtrs btstrp.PostWireInterfacePtrs, blobHandler blobprocessor.IRequestHandler, requestSender bus.IRequestSender) (BootstrapOperator, error) {
+	postWireInterfacePtrs btstrp.PostWireInterfacePtrs, blobHandler blobprocessor.IRequestHandler, requestSender bus.IRequestSender,
+	appImagesDeployer cluster.IAppImagesDeployer) (BootstrapOperator, error) {
 	var clusterBuiltinApp btstrp.ClusterBuiltInApp
 	otherApps := make([]appparts.BuiltInApp, 0, len(builtinApps))
 	for _, app := range builtinApps {
@@ -380,10 +442,116 @@ func provideBootstrapOperator(federation2 federation.IFederation, asp istructs.I
 		return nil, fmt.Errorf("%s app should be added to VVM builtin apps", istructs.AppQName_sys_cluster)
 	}
 	return pipeline.NewSyncOp(func(ctx context.Context, work pipeline.IWorkpiece) (err error) {
-		return btstrp.Bootstrap(federation2, asp, time, apppar, clusterBuiltinApp, otherApps, sidecarApps, itokens2, storageProvider, postWireInterfacePtrs, blobHandler, requestSender)
+		return btstrp.Bootstrap(federation2, asp, time, appPartsCtl, clusterBuiltinApp, otherApps, sidecarApps, itokens2, storageProvider, postWireInterfacePtrs, blobHandler, requestSender, appImagesDeployer)
 	}), nil
 }
 
+// single VVM -> all partitions are deployed on the current VVM
+// cluster of VVMs -> partitions are distributed among alive VVMs, see [apppartsctl.NewDistributed]
+func provideAppPartitionsController(cfg *VVMConfig, parts appparts.IAppPartitions, sysVvmStorage storage.ISysVvmStorage,
+	vvmLeaders ielections.ITTLStorage[storage.TTLStorageImplKey, string], tm timeu.ITime, n10nPeers *N10nPeersSource) (apppartsctl.IAppPartitionsController, func(), error) {
+	if cfg.NumVVM <= 1 {
+		return apppartsctl.New(parts)
+	}
+	if cfg.VVMPort <= 0 {
+		return nil, nil, errors.New("VVMPort must be specified if NumVVM > 1: other VVMs forward requests to it")
+	}
+	aliveVVMs := func() (uint, error) {
+		alive := uint(0)
+		for vvmIdx := storage.TTLStorageImplKey(1); vvmIdx <= cfg.NumVVM; vvmIdx++ {
+			ok, _, err := vvmLeaders.Get(vvmIdx)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				alive++
+			}
+		}
+		return alive, nil
+	}
+	self := vvmAddress(cfg)
+	ctl, cleanup, err := apppartsctl.NewDistributed(parts, storage.NewPartitionOwnersTTLStorage(sysVvmStorage), aliveVVMs, self, tm)
+	if err != nil {
+		// notest
+		return nil, nil, err
+	}
+
+	// n10n updates of owned partitions are sent to other alive VVMs, VVM leadership value is the VVM address
+	n10nPeers.getter = func(app appdef.AppQName, wsid istructs.WSID) (peers []apppartsctl.VVMAddress) {
+		partitionID, err := parts.AppWorkspacePartitionID(app, wsid)
+		if err != nil || !ctl.OwnsPartition(app, partitionID) {
+			return nil
+		}
+		for vvmIdx := storage.TTLStorageImplKey(1); vvmIdx <= cfg.NumVVM; vvmIdx++ {
+			ok, addr, err := vvmLeaders.Get(vvmIdx)
+			if err != nil {
+				// notest
+				logger.Error(fmt.Sprintf("failed to get VVM %d address: %s", vvmIdx, err))
+				continue
+			}
+			if ok && addr != string(self) {
+				peers = append(peers, apppartsctl.VVMAddress(addr))
+			}
+		}
+		return peers
+	}
+	return ctl, cleanup, nil
+}
+
+// single VVM -> in-memory n10n broker
+// cluster of VVMs -> updates of owned partitions are sent to other VVMs as well, see [n10nBroadcaster]
+func provideN10nBroker(cfg *VVMConfig, quotas in10n.Quotas, tm timeu.ITime, n10nPeers *N10nPeersSource) (in10n.IN10nBroker, func()) {
+	broker, cleanup := in10nmem.NewN10nBroker(quotas, tm)
+	if cfg.NumVVM <= 1 {
+		return broker, cleanup
+	}
+	broadcaster, broadcasterCleanup := newN10nBroadcaster(broker, n10nPeers)
+	return broadcaster, func() {
+		broadcasterCleanup()
+		cleanup()
+	}
+}
+
+func provideN10nPeersSource() *N10nPeersSource {
+	return &N10nPeersSource{}
+}
+
+// single VVM -> nil, requests are never forwarded
+// error messages of the router are translated by the application translations
+func provideAppTranslations(appParts appparts.IAppPartitions) router.AppTranslations {
+	return func(app appdef.AppQName) appdef.IWithTranslations {
+		appDef, err := appParts.AppDef(app)
+		if err != nil {
+			// unknown app
+			return nil
+		}
+		return appDef
+	}
+}
+
+func providePartitionOwnerLocator(cfg *VVMConfig, parts appparts.IAppPartitions, ctl apppartsctl.IAppPartitionsController) router.PartitionOwnerLocator {
+	if cfg.NumVVM <= 1 {
+		return nil
+	}
+	return func(app appdef.AppQName, wsid istructs.WSID) (*url.URL, bool) {
+		partitionID, err := parts.AppWorkspacePartitionID(app, wsid)
+		if err != nil {
+			return nil, false // will be replied by the request handler
+		}
+		owner, ok := ctl.PartitionOwner(app, partitionID)
+		if !ok {
+			return nil, false
+		}
+		ownerURL, err := url.Parse(owner)
+		if err != nil {
+			// notest
+			logger.Error(fmt.Sprintf("wrong owner %q of partition %s/%d: %s", owner, app, partitionID, err))
+			return nil, false
+		}
+		return ownerURL, true
+	}
+}
+
 func provideBuiltInAppPackages(builtInAppsArtefacts BuiltInAppsArtefacts) []BuiltInAppPackages {
 	return builtInAppsArtefacts.builtInAppPackages
 }
@@ -483,9 +651,18 @@ func provideAppPartitions(
 		sch,
 		eef,
 		bf,
+		checkAppDefCompatibility,
 	)
 }
 
+// rejects redeploy of application with definition which is not backward compatible with the deployed one
+func checkAppDefCompatibility(old, new appdef.IAppDef) error {
+	if cerrs := appdefcompat.CheckBackwardCompatibility(old, new); cerrs != nil && len(cerrs.Errors) > 0 {
+		return cerrs
+	}
+	return nil
+}
+
 func provideIsDeviceAllowedFunc(appEPs map[appdef.AppQName]extensionpoints.IExtensionPoint) iauthnzimpl.IsDeviceAllowedFuncs {
 	res := iauthnzimpl.IsDeviceAllowedFuncs{}
 	for appQName, appEP := range appEPs {
@@ -516,6 +693,10 @@ func provideAppPartsCtlPipelineService(ctl apppartsctl.IAppPartitionsController)
 	return &AppPartsCtlPipelineService{IAppPartitionsController: ctl}
 }
 
+func provideAppImagesDeployerPipelineService(deployer cluster.IAppImagesDeployer) IAppImagesDeployerPipelineService {
+	return &AppImagesDeployerPipelineService{IAppImagesDeployer: deployer}
+}
+
 func provideIAppStorageUncachingProviderFactory(factory istorage.IAppStorageFactory, vvmCfg *VVMConfig) IAppStorageUncachingProviderFactory {
 	return func() istorage.IAppStorageProvider {
 		return provider.Provide(factory, vvmCfg.KeyspaceIsolationSuffix)
@@ -563,8 +744,11 @@ func provideSubjectGetterFunc() iauthnzimpl.SubjectGetterFunc {
 }
 
 func provideBucketsFactory(time timeu.ITime) irates.BucketsFactoryType {
-	return func() irates.IBuckets {
-		return iratesce.Provide(time)
+	return func(buckets appdef.RateBuckets, storage istructs.IAppTTLStorage) irates.IBuckets {
+		if buckets == appdef.RateBuckets_Local || storage == nil {
+			return iratesce.Provide(time)
+		}
+		return iratesstg.Provide(buckets, storage, time)
 	}
 }
 
@@ -630,7 +814,7 @@ func provideMetricsServicePortGetter(ms metrics.MetricsService) func() metrics.M
 	}
 }
 
-func provideRouterParams(cfg *VVMConfig, port VVMPortType) router.RouterParams {
+func provideRouterParams(cfg *VVMConfig, port VVMPortType, staticFolders httpstatic.IAppFolders) router.RouterParams {
 	res := router.RouterParams{
 		HTTPServerParams: router.HTTPServerParams{
 			Port:             int(port),
@@ -645,11 +829,17 @@ func provideRouterParams(cfg *VVMConfig, port VVMPortType) router.RouterParams {
 		RoutesRewrite:        cfg.RoutesRewrite,
 		RouteDomains:         cfg.RouteDomains,
 		MaxQueriesPerWS:      cfg.RouterMaxQueriesPerWS,
+		CompressionMinSize:   cfg.RouterCompressionMinSize,
 		ITime:                cfg.Time,
+		StaticFolders:        staticFolders,
 	}
 	return res
 }
 
+func provideIAppStaticFolders(staticFolders httpstatic.IAppFolders) ihttp.IAppStaticFolders {
+	return staticFolders
+}
+
 func provideVVMApps(builtInApps []appparts.BuiltInApp) (vvmApps VVMApps) {
 	for _, builtInApp := range builtInApps {
 		vvmApps = append(vvmApps, builtInApp.Name)
@@ -662,46 +852,6 @@ func provideBuiltInAppsArtefacts(vvmConfig *VVMConfig, apis builtinapps.APIs, cf
 	return vvmConfig.VVMAppsBuilder.BuildAppsArtefacts(apis, cfgs, appEPs, schemasCache)
 }
 
-// extModuleURLs is filled here
-func parseSidecarAppSubDir(fullPath string, basePath string, out_extModuleURLs map[string]*url.URL) (asts []*parser.PackageSchemaAST, err error) {
-	dirEntries, err := os.ReadDir(fullPath)
-	if err != nil {
-
-		return nil, err
-	}
-	modulePath := strings.ReplaceAll(fullPath, basePath, "")
-	modulePath = strings.TrimPrefix(modulePath, string(os.PathSeparator))
-	modulePath = strings.ReplaceAll(modulePath, string(os.PathSeparator), "/")
-	for _, dirEntry := range dirEntries {
-		if dirEntry.IsDir() {
-			subASTs, err := parseSidecarAppSubDir(filepath.Join(fullPath, dirEntry.Name()), basePath, out_extModuleURLs)
-			if err != nil {
-				return nil, err
-			}
-			asts = append(asts, subASTs...)
-			continue
-		}
-		if filepath.Ext(dirEntry.Name()) == ".wasm" {
-			moduleURL, err := url.Parse("file:///" + filepath.Join(fullPath, dirEntry.Name()))
-			if err != nil {
-
-				return nil, err
-			}
-
-			out_extModuleURLs[modulePath] = moduleURL
-			continue
-		}
-	}
-
-	dirAST, err := parser.ParsePackageDir(modulePath, os.DirFS(fullPath).(filesu.IReadFS), ".")
-	if err == nil {
-		asts = append(asts, dirAST)
-	} else if !errors.Is(err, parser.ErrDirContainsNoSchemaFiles) {
-		return nil, err
-	}
-	return asts, nil
-}
-
 func provideSidecarApps(vvmConfig *VVMConfig) (res []appparts.SidecarApp, err error) {
 	if len(vvmConfig.DataPath) == 0 {
 		return nil, nil
@@ -728,8 +878,8 @@ func provideSidecarApps(vvmConfig *VVMConfig) (res []appparts.SidecarApp, err er
 			return nil, err
 		}
 		var appDD *appparts.AppDeploymentDescriptor
-		appASTs := []*parser.PackageSchemaAST{}
-		extModuleURLs := map[string]*url.URL{}
+		var appDef appdef.IAppDef
+		var extModuleURLs map[string]*url.URL
 		for _, appDirEntry := range appDirEntries {
 
 			if !appDirEntry.IsDir() && appDirEntry.Name() == "descriptor.json" {
@@ -745,7 +895,7 @@ func provideSidecarApps(vvmConfig *VVMConfig) (res []appparts.SidecarApp, err er
 			if appDirEntry.IsDir() && appDirEntry.Name() == "image" {
 
 				pkgPath := filepath.Join(appPath, "image", "pkg")
-				appASTs, err = parseSidecarAppSubDir(pkgPath, pkgPath, extModuleURLs)
+				appDef, extModuleURLs, err = cluster.ParseAppImageDir(pkgPath)
 				if err != nil {
 					return nil, err
 				}
@@ -755,18 +905,8 @@ func provideSidecarApps(vvmConfig *VVMConfig) (res []appparts.SidecarApp, err er
 			return nil, fmt.Errorf("no descriptor for sidecar app %s", appQName)
 		}
 
-		appSchemaAST, err := parser.BuildAppSchema(appASTs)
-		if err != nil {
-			return nil, err
-		}
-		appDefBuilder := builder.New()
-		if err := parser.BuildAppDefs(appSchemaAST, appDefBuilder); err != nil {
-			return nil, err
-		}
-
-		appDef, err := appDefBuilder.Build()
-		if err != nil {
-			return nil, err
+		if appDef == nil {
+			return nil, fmt.Errorf("no image for sidecar app %s", appQName)
 		}
 
 		res = append(res, appparts.SidecarApp{
@@ -819,11 +959,20 @@ func provideChannelGroups(cfg *VVMConfig) (res []iprocbusmem.ChannelGroup) {
 }
 
 func provideCachingAppStorageProvider(storageCacheSize StorageCacheSizeType, metrics2 imetrics.IMetrics,
-	vvmName processors.VVMName, uncachingProvider IAppStorageUncachingProviderFactory, iTime timeu.ITime) istorage.IAppStorageProvider {
-	aspNonCaching := uncachingProvider()
+	vvmName processors.VVMName, aspNonCaching IAppStorageNonCachingProvider, iTime timeu.ITime) istorage.IAppStorageProvider {
 	return istoragecache.Provide(int(storageCacheSize), aspNonCaching, metrics2, string(vvmName), iTime)
 }
 
+func provideNonCachingAppStorageProvider(uncachingProvider IAppStorageUncachingProviderFactory, vvmConfig *VVMConfig,
+	secretReader isecrets.ISecretReader, iTime timeu.ITime) IAppStorageNonCachingProvider {
+	aspNonCaching := uncachingProvider()
+	if vvmConfig.StorageEncryption {
+		// below the cache: values are cached decrypted
+		aspNonCaching = istorageenc.Provide(aspNonCaching, secretReader, iTime)
+	}
+	return aspNonCaching
+}
+
 func provideBlobHandlerPtr() blobprocessor.IRequestHandlerPtr {
 	return new(blobprocessor.IRequestHandler)
 }
@@ -832,13 +981,18 @@ func provideIRequestSenderPtr() bus.IRequestSenderPtr {
 	return new(bus.IRequestSender)
 }
 
+func provideIAppPartitionsPtr() appparts.IAppPartitionsPtr {
+	return new(appparts.IAppPartitions)
+}
+
 func providePostWireInterfacePtrs(blobberAppStoragePtr iblobstoragestg.BlobAppStoragePtr, routerAppStoragePtr dbcertcache.RouterAppStoragePtr,
-	blobHandlerPtr blobprocessor.IRequestHandlerPtr, requestSenderPtr bus.IRequestSenderPtr) btstrp.PostWireInterfacePtrs {
+	blobHandlerPtr blobprocessor.IRequestHandlerPtr, requestSenderPtr bus.IRequestSenderPtr, appPartsPtr appparts.IAppPartitionsPtr) btstrp.PostWireInterfacePtrs {
 	return btstrp.PostWireInterfacePtrs{
 		BlobberAppStorage: blobberAppStoragePtr,
 		RouterAppStorage:  routerAppStoragePtr,
 		BlobHandler:       blobHandlerPtr,
 		RequestSender:     requestSenderPtr,
+		AppPartitions:     appPartsPtr,
 	}
 }
 
@@ -859,9 +1013,10 @@ func provideRouterServices(rp router.RouterParams, broker in10n.IN10nBroker, blo
 	wLimiterFactory blobprocessor.WLimiterFactory, blobStorage iblobstorage.IBLOBStorage,
 	autocertCache autocert.Cache, requestSender bus.IRequestSender, vvmPortSource *VVMPortSource,
 	numsAppsWorkspaces map[appdef.AppQName]istructs.NumAppWorkspaces, iTokens itokens.ITokens, federation2 federation.IFederation,
-	appTokensFactory payloads.IAppTokensFactory) RouterServices {
+	appTokensFactory payloads.IAppTokensFactory, ownerLocator router.PartitionOwnerLocator,
+	appTranslations router.AppTranslations) RouterServices {
 	httpSrv, acmeSrv, adminSrv := router.Provide(rp, broker, blobRequestHandler, autocertCache, requestSender, numsAppsWorkspaces,
-		iTokens, federation2, appTokensFactory)
+		iTokens, federation2, appTokensFactory, ownerLocator, appTranslations)
 	vvmPortSource.getter = func() VVMPortType {
 		return VVMPortType(httpSrv.GetPort())
 	}
@@ -929,10 +1084,10 @@ func provideQueryProcessors_V1(qpCount istructs.NumQueryProcessors, qc QueryChan
 func provideQueryProcessors_V2(qpCount istructs.NumQueryProcessors, qc QueryChannel_V2, appParts appparts.IAppPartitions, qpFactory query2.ServiceFactory, imetrics2 imetrics.IMetrics,
 	vvm processors.VVMName, mpq MaxPrepareQueriesType, authn iauthnz.IAuthenticator,
 	tokens itokens.ITokens, federation2 federation.IFederation, statelessResources istructsmem.IStatelessResources, secretReader isecrets.ISecretReader,
-	stateOpts state.StateOpts, httpClient httpu.IHTTPClient) OperatorQueryProcessors_V2 {
+	stateOpts state.StateOpts, httpClient httpu.IHTTPClient, oidc ioidc.IRelyingParty) OperatorQueryProcessors_V2 {
 	forks := make([]pipeline.ForkOperatorOptionFunc, qpCount)
 	for i := 0; i < int(qpCount); i++ {
-		forks[i] = pipeline.ForkBranch(pipeline.ServiceOperator(qpFactory(iprocbus.ServiceChannel(qc), appParts, int(mpq), imetrics2, string(vvm), authn, tokens, federation2, statelessResources, secretReader, stateOpts, httpClient)))
+		forks[i] = pipeline.ForkBranch(pipeline.ServiceOperator(qpFactory(iprocbus.ServiceChannel(qc), appParts, int(mpq), imetrics2, string(vvm), authn, tokens, federation2, statelessResources, secretReader, stateOpts, httpClient, oidc)))
 	}
 	return pipeline.ForkOperator(pipeline.ForkSame, forks[0], forks[1:]...)
 }
@@ -952,10 +1107,11 @@ func provideServicePipeline(
 	opQueryProcessors_v2 OperatorQueryProcessors_V2,
 	opBLOBProcessors OperatorBLOBProcessors,
 	appPartsCtl IAppPartsCtlPipelineService,
+	appImagesDeployer IAppImagesDeployerPipelineService,
 	bootstrapSyncOp BootstrapOperator,
 	adminEndpoint AdminEndpointServiceOperator,
 	publicEndpoint PublicEndpointServiceOperator,
 	appStorageProvider istorage.IAppStorageProvider,
 ) ServicePipeline {
-	return pipeline.NewSyncPipeline(vvmCtx, "ServicePipeline", pipeline.WireSyncOperator("internal services", pipeline.ForkOperator(pipeline.ForkSame, pipeline.ForkBranch(opQueryProcessors_v1), pipeline.ForkBranch(opQueryProcessors_v2), pipeline.ForkBranch(opCommandProcessors), pipeline.ForkBranch(opBLOBProcessors), pipeline.ForkBranch(pipeline.ServiceOperator(appPartsCtl)), pipeline.ForkBranch(pipeline.ServiceOperator(appStorageProvider)))), pipeline.WireSyncOperator("admin endpoint", adminEndpoint), pipeline.WireSyncOperator("bootstrap", bootstrapSyncOp), pipeline.WireSyncOperator("public endpoint", publicEndpoint))
+	return pipeline.NewSyncPipeline(vvmCtx, "ServicePipeline", pipeline.WireSyncOperator("internal services", pipeline.ForkOperator(pipeline.ForkSame, pipeline.ForkBranch(opQueryProcessors_v1), pipeline.ForkBranch(opQueryProcessors_v2), pipeline.ForkBranch(opCommandProcessors), pipeline.ForkBranch(opBLOBProcessors), pipeline.ForkBranch(pipeline.ServiceOperator(appPartsCtl)), pipeline.ForkBranch(pipeline.ServiceOperator(appImagesDeployer)), pipeline.ForkBranch(pipeline.ServiceOperator(appStorageProvider)))), pipeline.WireSyncOperator("admin endpoint", adminEndpoint), pipeline.WireSyncOperator("bootstrap", bootstrapSyncOp), pipeline.WireSyncOperator("public endpoint", publicEndpoint))
 }